@@ -44,7 +44,8 @@ func (act *AbstractAction) GasPrice() *big.Int {
 	return p.Set(act.gasPrice)
 }
 
-// Hash returns the hash value of referred SealedActionEnvelope hash.
+// Hash returns the hash value of referred SealedActionEnvelope hash, e.g., of a Transfer or a Vote, which is zero until
+// the action is sealed
 func (act *AbstractAction) Hash() hash.Hash256 { return act.hash }
 
 // BasicActionSize returns the basic size of action
@@ -8,13 +8,17 @@ package action
 
 import (
 	"math/big"
+	"runtime"
+	"sync"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
+	"go.uber.org/zap"
 
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/protogen/iotextypes"
 )
@@ -141,6 +145,8 @@ func (elp *Envelope) Proto() *iotextypes.ActionCore {
 		actCore.Action = &iotextypes.ActionCore_DepositToRewardingFund{DepositToRewardingFund: act.Proto()}
 	case *PutPollResult:
 		actCore.Action = &iotextypes.ActionCore_PutPollResult{PutPollResult: act.Proto()}
+	case *RotateKey:
+		actCore.Action = &iotextypes.ActionCore_RotateKey{RotateKey: act.Proto()}
 	default:
 		log.S().Panicf("Cannot convert type of action %T.\r\n", act)
 	}
@@ -235,6 +241,12 @@ func (elp *Envelope) LoadProto(pbAct *iotextypes.ActionCore) error {
 			return err
 		}
 		elp.payload = act
+	case pbAct.GetRotateKey() != nil:
+		act := &RotateKey{}
+		if err := act.LoadProto(pbAct.GetRotateKey()); err != nil {
+			return err
+		}
+		elp.payload = act
 	default:
 		return errors.Errorf("no applicable action to handle in action proto %+v", pbAct)
 	}
@@ -246,12 +258,15 @@ func (elp *Envelope) ByteStream() []byte {
 	return byteutil.Must(proto.Marshal(elp.Proto()))
 }
 
-// Hash returns the hash value of SealedEnvelope.
+// Hash returns the hash value of Envelope, which is what the sender signs.
 func (elp *Envelope) Hash() hash.Hash256 {
 	return hash.Hash256b(elp.ByteStream())
 }
 
-// Hash returns the hash value of SealedEnvelope.
+// Hash returns the hash value of SealedEnvelope, which identifies the action, e.g., in the actpool and the explorer.
+// It's the Keccak-256 hash of the serialized proto of the action, i.e., the core of the version, the nonce, the gas
+// limit, the gas price and the fields of the payload, along with the sender public key and the signature. Signing is
+// deterministic, so the same action signed by the same key always has the same hash.
 func (sealed *SealedEnvelope) Hash() hash.Hash256 {
 	return hash.Hash256b(byteutil.Must(proto.Marshal(sealed.Proto())))
 }
@@ -259,6 +274,17 @@ func (sealed *SealedEnvelope) Hash() hash.Hash256 {
 // SrcPubkey returns the source public key
 func (sealed *SealedEnvelope) SrcPubkey() keypair.PublicKey { return sealed.srcPubkey }
 
+// LogFields returns the fields every log line about the action carries, i.e., its short hash and its sender
+func (sealed *SealedEnvelope) LogFields() []zap.Field {
+	sender := ""
+	if sealed.srcPubkey != nil {
+		if addr, err := addrutil.PubKeyToAddress(sealed.srcPubkey); err == nil {
+			sender = addr.String()
+		}
+	}
+	return log.ActionFields(sealed.Hash(), sender)
+}
+
 // Signature returns signature bytes
 func (sealed *SealedEnvelope) Signature() []byte {
 	sig := make([]byte, len(sealed.signature))
@@ -300,14 +326,14 @@ func (sealed *SealedEnvelope) LoadProto(pbAct *iotextypes.Action) error {
 	return nil
 }
 
-// Sign signs the action using sender's private key
-func Sign(act Envelope, sk keypair.PrivateKey) (SealedEnvelope, error) {
+// Sign signs the action using the signer of sender, which is by default the private key of sender
+func Sign(act Envelope, signer keypair.Signer) (SealedEnvelope, error) {
 	sealed := SealedEnvelope{Envelope: act}
 
-	sealed.srcPubkey = sk.PublicKey()
+	sealed.srcPubkey = signer.PublicKey()
 
 	hash := act.Hash()
-	sig, err := sk.Sign(hash[:])
+	sig, err := signer.Sign(hash[:])
 	if err != nil {
 		return sealed, errors.Wrapf(ErrAction, "failed to sign action hash = %x", hash)
 	}
@@ -343,22 +369,49 @@ func AssembleSealedEnvelope(act Envelope, pk keypair.PublicKey, sig []byte) Seal
 func Verify(sealed SealedEnvelope) error {
 	hash := sealed.Envelope.Hash()
 	if len(sealed.Signature()) != SignatureLength {
-		return errors.New("incorrect length of signature")
+		return errors.Wrap(ErrSignature, "incorrect length of signature")
 	}
 	if sealed.SrcPubkey() == nil {
-		return errors.New("empty public key")
+		return errors.Wrap(ErrSignature, "empty public key")
 	}
 	if sealed.SrcPubkey().Verify(hash[:], sealed.Signature()) {
 		return nil
 	}
 	return errors.Wrapf(
-		ErrAction,
+		ErrSignature,
 		"failed to verify action hash = %x and signature = %x",
 		hash,
 		sealed.Signature(),
 	)
 }
 
+// VerifyActions verifies the actions like Verify on a pool of GOMAXPROCS workers, and returns the error of each action at
+// the index of the action, nil if its signature is valid, so that a bad signature fails only its own action
+func VerifyActions(acts []SealedEnvelope) []error {
+	errs := make([]error, len(acts))
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(acts) {
+		workers = len(acts)
+	}
+	indexes := make(chan int, len(acts))
+	for i := range acts {
+		indexes <- i
+	}
+	close(indexes)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				errs[i] = Verify(acts[i])
+			}
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
 // ClassifyActions classfies actions
 func ClassifyActions(actions []SealedEnvelope) ([]*Transfer, []*Vote, []*Execution) {
 	tsfs := make([]*Transfer, 0)
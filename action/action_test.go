@@ -12,8 +12,10 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
+	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/test/testaddress"
 )
 
@@ -40,3 +42,81 @@ func TestActionProto(t *testing.T) {
 
 	require.Equal(selp.Hash(), nselp.Hash())
 }
+
+func TestActionHashVectors(t *testing.T) {
+	require := require.New(t)
+	priKey := testaddress.Keyinfo["producer"].PriKey
+	recipient := testaddress.Addrinfo["alfa"].String()
+
+	seal := func(act actionPayload, nonce uint64) SealedEnvelope {
+		bd := &EnvelopeBuilder{}
+		elp := bd.SetNonce(nonce).
+			SetGasLimit(uint64(100000)).
+			SetGasPrice(big.NewInt(10)).
+			SetAction(act).Build()
+		selp, err := Sign(elp, priKey)
+		require.NoError(err)
+		return selp
+	}
+	tsf, err := NewTransfer(1, big.NewInt(100), recipient, []byte("memo"), uint64(100000), big.NewInt(10))
+	require.NoError(err)
+	vote, err := NewVote(2, recipient, uint64(100000), big.NewInt(10))
+	require.NoError(err)
+
+	type hashed interface {
+		Hash() hash.Hash256
+	}
+	// the hashes must not change, or the actions referred to by hash, e.g., in the explorer, would be lost
+	for _, c := range []struct {
+		selp SealedEnvelope
+		hash string
+	}{
+		{seal(tsf, 1), "6fcb2bb5e19a301ecada5896f5377a2bbfaf8cc977194daaa3e028e20ab9c17d"},
+		{seal(vote, 2), "140747470566ebd857b9f2e54fcb7e3ae6d488be74dc10c8115e292ece554aa8"},
+	} {
+		h := c.selp.Hash()
+		require.Equal(c.hash, hex.EncodeToString(h[:]))
+		// the action sealed, and the one loaded from the proto, carry the hash of the envelope
+		require.Equal(h, c.selp.Action().(hashed).Hash())
+		var loaded SealedEnvelope
+		require.NoError(loaded.LoadProto(c.selp.Proto()))
+		require.Equal(h, loaded.Hash())
+		require.Equal(h, loaded.Action().(hashed).Hash())
+	}
+}
+
+func TestVerifyActions(t *testing.T) {
+	require := require.New(t)
+	priKey := testaddress.Keyinfo["producer"].PriKey
+	recipient := testaddress.Addrinfo["alfa"].String()
+
+	require.Empty(VerifyActions(nil))
+
+	acts := make([]SealedEnvelope, 0, 100)
+	for i := uint64(1); i <= 100; i++ {
+		tsf, err := NewTransfer(i, big.NewInt(int64(i)), recipient, nil, uint64(100000), big.NewInt(10))
+		require.NoError(err)
+		bd := &EnvelopeBuilder{}
+		elp := bd.SetNonce(i).
+			SetGasLimit(uint64(100000)).
+			SetGasPrice(big.NewInt(10)).
+			SetAction(tsf).Build()
+		selp, err := Sign(elp, priKey)
+		require.NoError(err)
+		acts = append(acts, selp)
+	}
+	// the signature of another action, and no signature at all
+	acts[7] = AssembleSealedEnvelope(acts[7].Envelope, acts[7].SrcPubkey(), acts[8].Signature())
+	acts[42] = AssembleSealedEnvelope(acts[42].Envelope, acts[42].SrcPubkey(), nil)
+
+	errs := VerifyActions(acts)
+	require.Len(errs, len(acts))
+	for i, err := range errs {
+		switch i {
+		case 7, 42:
+			require.Equal(ErrSignature, errors.Cause(err))
+		default:
+			require.NoError(err)
+		}
+	}
+}
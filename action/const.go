@@ -31,4 +31,6 @@ var (
 	ErrVotee = errors.New("votee is not a candidate")
 	// ErrHash indicates the error of action's hash
 	ErrHash = errors.New("invalid hash")
+	// ErrSignature indicates the error of action's signature
+	ErrSignature = errors.New("invalid signature")
 )
@@ -0,0 +1,59 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// PendingNonceGetter is the interface to get the next nonce to use of an address, e.g., an actpool
+type PendingNonceGetter interface {
+	GetPendingNonce(addr string) (uint64, error)
+}
+
+// NonceManager hands out the nonces of the senders constructing actions in bulk. The next nonce of a sender is queried
+// once, and then incremented locally on each call.
+type NonceManager struct {
+	mu     sync.Mutex
+	getter PendingNonceGetter
+	nonces map[string]uint64
+}
+
+// NewNonceManager returns a nonce manager querying the pending nonces from the getter
+func NewNonceManager(getter PendingNonceGetter) *NonceManager {
+	return &NonceManager{
+		getter: getter,
+		nonces: make(map[string]uint64),
+	}
+}
+
+// Next returns the nonce to use for the next action of the sender
+func (m *NonceManager) Next(addr string) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	nonce, ok := m.nonces[addr]
+	if !ok {
+		var err error
+		if nonce, err = m.getter.GetPendingNonce(addr); err != nil {
+			return 0, errors.Wrapf(err, "failed to get the pending nonce of %s", addr)
+		}
+	}
+	m.nonces[addr] = nonce + 1
+	return nonce, nil
+}
+
+// Reset forgets the nonce tracked for the sender, so that it is queried again on the next call. It should be called
+// once an action handed a nonce isn't accepted.
+func (m *NonceManager) Reset(addr string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.nonces, addr)
+}
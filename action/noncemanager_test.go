@@ -0,0 +1,51 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type pendingNonces map[string]uint64
+
+func (p pendingNonces) GetPendingNonce(addr string) (uint64, error) {
+	nonce, ok := p[addr]
+	if !ok {
+		return 0, errors.New("unknown address")
+	}
+	return nonce, nil
+}
+
+func TestNonceManager(t *testing.T) {
+	require := require.New(t)
+	pending := pendingNonces{"alfa": 1, "bravo": 5}
+	m := NewNonceManager(pending)
+
+	for i := uint64(1); i <= 1000; i++ {
+		nonce, err := m.Next("alfa")
+		require.NoError(err)
+		require.Equal(i, nonce)
+	}
+	nonce, err := m.Next("bravo")
+	require.NoError(err)
+	require.Equal(uint64(5), nonce)
+	_, err = m.Next("charlie")
+	require.Error(err)
+
+	// the nonce is queried again after reset
+	pending["alfa"] = 10
+	m.Reset("alfa")
+	nonce, err = m.Next("alfa")
+	require.NoError(err)
+	require.Equal(uint64(10), nonce)
+	nonce, err = m.Next("bravo")
+	require.NoError(err)
+	require.Equal(uint64(6), nonce)
+}
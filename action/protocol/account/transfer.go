@@ -19,6 +19,7 @@ import (
 	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
 	"github.com/iotexproject/iotex-core/action/protocol/vote/candidatesutil"
 	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
 	"github.com/iotexproject/iotex-core/state"
 )
 
@@ -178,7 +179,7 @@ func (p *Protocol) validateTransfer(_ context.Context, act action.Action) error
 		return errors.Wrap(action.ErrGasPrice, "negative value")
 	}
 	// check if recipient's address is valid
-	if _, err := address.FromString(tsf.Recipient()); err != nil {
+	if err := addrutil.Validate(tsf.Recipient()); err != nil {
 		return errors.Wrapf(err, "error when validating recipient's address %s", tsf.Recipient())
 	}
 	return nil
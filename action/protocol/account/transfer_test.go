@@ -21,6 +21,8 @@ import (
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
 	"github.com/iotexproject/iotex-core/state"
 	"github.com/iotexproject/iotex-core/state/factory"
 	"github.com/iotexproject/iotex-core/test/testaddress"
@@ -125,32 +127,28 @@ func TestProtocol_ValidateTransfer(t *testing.T) {
 	// Case I: Oversized data
 	tmpPayload := [32769]byte{}
 	payload := tmpPayload[:]
-	tsf, err := action.NewTransfer(uint64(1), big.NewInt(1), "2", payload, uint64(0),
+	tsf, err := action.NewTransfer(uint64(1), big.NewInt(1), testaddress.Addrinfo["bravo"].String(), payload, uint64(0),
 		big.NewInt(0))
 	require.NoError(err)
 	err = protocol.Validate(context.Background(), tsf)
 	require.Equal(action.ErrActPool, errors.Cause(err))
 	// Case II: Negative amount
-	tsf, err = action.NewTransfer(uint64(1), big.NewInt(-100), "2", nil,
+	tsf, err = action.NewTransfer(uint64(1), big.NewInt(-100), testaddress.Addrinfo["bravo"].String(), nil,
 		uint64(100000), big.NewInt(0))
 	require.NoError(err)
 	err = protocol.Validate(context.Background(), tsf)
 	require.Equal(action.ErrBalance, errors.Cause(err))
 	// Case III: Invalid recipient address
-	tsf, err = action.NewTransfer(
-		1,
-		big.NewInt(1),
-		testaddress.Addrinfo["alfa"].String()+"aaa",
-		nil,
-		uint64(100000),
-		big.NewInt(0),
-	)
-	require.NoError(err)
+	invalidRecipient := testaddress.Addrinfo["alfa"].String() + "aaa"
+	_, err = action.NewTransfer(1, big.NewInt(1), invalidRecipient, nil, uint64(100000), big.NewInt(0))
+	require.Equal(addrutil.ErrLength, errors.Cause(err))
+	tsf = &action.Transfer{}
+	require.NoError(tsf.LoadProto(&iotextypes.Transfer{Amount: "1", Recipient: invalidRecipient}))
 	err = protocol.Validate(context.Background(), tsf)
 	require.Error(err)
 	require.True(strings.Contains(err.Error(), "error when validating recipient's address"))
 	// Case IV: Negative gas fee
-	tsf, err = action.NewTransfer(uint64(1), big.NewInt(100), "2", nil,
+	tsf, err = action.NewTransfer(uint64(1), big.NewInt(100), testaddress.Addrinfo["bravo"].String(), nil,
 		uint64(100000), big.NewInt(-1))
 	require.NoError(err)
 	err = protocol.Validate(context.Background(), tsf)
@@ -54,6 +54,8 @@ type ValidateActionsCtx struct {
 	ProducerAddr string
 	// Caller is the address of whom issues the action
 	Caller address.Address
+	// SignatureVerified tells the signature of the action has been verified, e.g., along with a batch of actions
+	SignatureVerified bool
 }
 
 // WithRunActionsCtx add RunActionsCtx into context.
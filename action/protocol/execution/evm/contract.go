@@ -7,6 +7,7 @@
 package evm
 
 import (
+	"bytes"
 	"context"
 
 	"github.com/pkg/errors"
@@ -169,3 +170,25 @@ func newContract(addr hash.Hash160, state *state.Account, dao db.KVStore, batch
 		trie:    tr,
 	}, nil
 }
+
+// VerifyContract checks that the code and the storage trie of the contract in the KV store are the ones its account
+// commits to by the code hash and the storage root, e.g., when the KV store is taken from a checkpoint snapshot
+func VerifyContract(addr hash.Hash160, account *state.Account, dao db.KVStore) error {
+	if account.IsContract() {
+		code, err := dao.Get(CodeKVNameSpace, account.CodeHash)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get the code of contract %x", addr)
+		}
+		if h := hash.Hash256b(code); !bytes.Equal(h[:], account.CodeHash) {
+			return errors.Errorf("code of contract %x hashes to %x rather than %x", addr, h, account.CodeHash)
+		}
+	}
+	if account.Root == hash.ZeroHash256 {
+		return nil
+	}
+	c, err := newContract(addr, account, dao, db.NewCachedBatch())
+	if err != nil {
+		return err
+	}
+	return errors.Wrapf(trie.VerifyNodes(c.(*contract).trie), "failed to verify the storage of contract %x", addr)
+}
@@ -251,3 +251,33 @@ func TestSnapshot(t *testing.T) {
 	require.Equal(big.NewInt(5), c2.SelfState().Balance)
 	require.NotEqual(c1.RootHash(), c2.RootHash())
 }
+
+func TestVerifyContract(t *testing.T) {
+	require := require.New(t)
+
+	dao := db.NewMemKVStore()
+	cb := db.NewCachedBatch()
+	addr := hash.BytesToHash160(testaddress.Addrinfo["alfa"].Bytes())
+	account := state.EmptyAccount()
+	c, err := newContract(addr, &account, dao, cb)
+	require.NoError(err)
+	code := []byte("test contract verification")
+	c.SetCode(hash.Hash256b(code), code)
+	k1 := hash.Hash256b([]byte("cat"))
+	v1 := hash.Hash256b([]byte("cat"))
+	k2 := hash.Hash256b([]byte("dog"))
+	v2 := hash.Hash256b([]byte("dog"))
+	require.NoError(c.SetState(k1, v1[:]))
+	require.NoError(c.SetState(k2, v2[:]))
+	require.NoError(c.Commit())
+	require.NoError(dao.Commit(cb))
+	require.NoError(VerifyContract(addr, c.SelfState(), dao))
+
+	// the storage of another contract doesn't match the root
+	other := hash.BytesToHash160(testaddress.Addrinfo["bravo"].Bytes())
+	require.Error(VerifyContract(other, c.SelfState(), dao))
+
+	// nor does the code replaced
+	require.NoError(dao.Put(CodeKVNameSpace, c.SelfState().CodeHash, []byte("malicious code")))
+	require.Error(VerifyContract(addr, c.SelfState(), dao))
+}
@@ -42,9 +42,11 @@ func (v *GenericValidator) Validate(ctx context.Context, act action.SealedEnvelo
 	if intrinsicGas > act.GasLimit() || err != nil {
 		return errors.Wrap(action.ErrInsufficientBalanceForGas, "insufficient gas")
 	}
-	// Verify action using action sender's public key
-	if err := action.Verify(act); err != nil {
-		return errors.Wrap(err, "failed to verify action signature")
+	// Verify action using action sender's public key, unless it's been verified
+	if !vaCtx.SignatureVerified {
+		if err := action.Verify(act); err != nil {
+			return errors.Wrap(err, "failed to verify action signature")
+		}
 	}
 	// Reject action if nonce is too low
 	confirmedNonce, err := v.cm.Nonce(vaCtx.Caller.String())
@@ -0,0 +1,105 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package keyrotation
+
+import (
+	"github.com/golang/protobuf/proto"
+
+	"github.com/iotexproject/iotex-core/action/protocol/keyrotation/keyrotationpb"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
+)
+
+// delegateKey stores the block-signing key of a delegate identity. A nil key stands for the key of the identity itself
+type delegateKey struct {
+	pubKey          keypair.PublicKey
+	effectiveHeight uint64
+	prevPubKey      keypair.PublicKey
+	recoveryPubKey  keypair.PublicKey
+}
+
+// activeKey returns the signing key which is active at the given height
+func (dk *delegateKey) activeKey(height uint64) keypair.PublicKey {
+	if dk.pubKey != nil && height >= dk.effectiveHeight {
+		return dk.pubKey
+	}
+	return dk.prevPubKey
+}
+
+// Serialize serializes delegate key state into bytes
+func (dk *delegateKey) Serialize() ([]byte, error) {
+	gen := keyrotationpb.DelegateKey{
+		EffectiveHeight: dk.effectiveHeight,
+	}
+	if dk.pubKey != nil {
+		gen.PubKey = dk.pubKey.Bytes()
+	}
+	if dk.prevPubKey != nil {
+		gen.PrevPubKey = dk.prevPubKey.Bytes()
+	}
+	if dk.recoveryPubKey != nil {
+		gen.RecoveryPubKey = dk.recoveryPubKey.Bytes()
+	}
+	return proto.Marshal(&gen)
+}
+
+// Deserialize deserializes bytes into delegate key state
+func (dk *delegateKey) Deserialize(data []byte) error {
+	gen := keyrotationpb.DelegateKey{}
+	if err := proto.Unmarshal(data, &gen); err != nil {
+		return err
+	}
+	var err error
+	*dk = delegateKey{effectiveHeight: gen.EffectiveHeight}
+	if dk.pubKey, err = bytesToPublicKey(gen.PubKey); err != nil {
+		return err
+	}
+	if dk.prevPubKey, err = bytesToPublicKey(gen.PrevPubKey); err != nil {
+		return err
+	}
+	dk.recoveryPubKey, err = bytesToPublicKey(gen.RecoveryPubKey)
+	return err
+}
+
+// signerRecord stores the delegate identity that a signing key has been registered for
+type signerRecord struct {
+	delegate string
+}
+
+// Serialize serializes signer record state into bytes
+func (s *signerRecord) Serialize() ([]byte, error) {
+	return proto.Marshal(&keyrotationpb.Signer{Delegate: s.delegate})
+}
+
+// Deserialize deserializes bytes into signer record state
+func (s *signerRecord) Deserialize(data []byte) error {
+	gen := keyrotationpb.Signer{}
+	if err := proto.Unmarshal(data, &gen); err != nil {
+		return err
+	}
+	s.delegate = gen.Delegate
+	return nil
+}
+
+func bytesToPublicKey(b []byte) (keypair.PublicKey, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+	return keypair.BytesToPublicKey(b)
+}
+
+// addressOf returns the address of the key, or the fallback if the key is nil
+func addressOf(pk keypair.PublicKey, fallback string) (string, error) {
+	if pk == nil {
+		return fallback, nil
+	}
+	addr, err := addrutil.PubKeyToAddress(pk)
+	if err != nil {
+		return "", err
+	}
+	return addr.String(), nil
+}
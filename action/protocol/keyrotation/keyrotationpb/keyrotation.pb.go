@@ -0,0 +1,148 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: keyrotation.proto
+
+package keyrotationpb
+
+import (
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type DelegateKey struct {
+	PubKey               []byte   `protobuf:"bytes,1,opt,name=pubKey,proto3" json:"pubKey,omitempty"`
+	EffectiveHeight      uint64   `protobuf:"varint,2,opt,name=effectiveHeight,proto3" json:"effectiveHeight,omitempty"`
+	PrevPubKey           []byte   `protobuf:"bytes,3,opt,name=prevPubKey,proto3" json:"prevPubKey,omitempty"`
+	RecoveryPubKey       []byte   `protobuf:"bytes,4,opt,name=recoveryPubKey,proto3" json:"recoveryPubKey,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DelegateKey) Reset()         { *m = DelegateKey{} }
+func (m *DelegateKey) String() string { return proto.CompactTextString(m) }
+func (*DelegateKey) ProtoMessage()    {}
+func (*DelegateKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc424359da3a2f21, []int{0}
+}
+
+func (m *DelegateKey) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DelegateKey.Unmarshal(m, b)
+}
+func (m *DelegateKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DelegateKey.Marshal(b, m, deterministic)
+}
+func (m *DelegateKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DelegateKey.Merge(m, src)
+}
+func (m *DelegateKey) XXX_Size() int {
+	return xxx_messageInfo_DelegateKey.Size(m)
+}
+func (m *DelegateKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_DelegateKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DelegateKey proto.InternalMessageInfo
+
+func (m *DelegateKey) GetPubKey() []byte {
+	if m != nil {
+		return m.PubKey
+	}
+	return nil
+}
+
+func (m *DelegateKey) GetEffectiveHeight() uint64 {
+	if m != nil {
+		return m.EffectiveHeight
+	}
+	return 0
+}
+
+func (m *DelegateKey) GetPrevPubKey() []byte {
+	if m != nil {
+		return m.PrevPubKey
+	}
+	return nil
+}
+
+func (m *DelegateKey) GetRecoveryPubKey() []byte {
+	if m != nil {
+		return m.RecoveryPubKey
+	}
+	return nil
+}
+
+type Signer struct {
+	Delegate             string   `protobuf:"bytes,1,opt,name=delegate,proto3" json:"delegate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Signer) Reset()         { *m = Signer{} }
+func (m *Signer) String() string { return proto.CompactTextString(m) }
+func (*Signer) ProtoMessage()    {}
+func (*Signer) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc424359da3a2f21, []int{1}
+}
+
+func (m *Signer) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Signer.Unmarshal(m, b)
+}
+func (m *Signer) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Signer.Marshal(b, m, deterministic)
+}
+func (m *Signer) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Signer.Merge(m, src)
+}
+func (m *Signer) XXX_Size() int {
+	return xxx_messageInfo_Signer.Size(m)
+}
+func (m *Signer) XXX_DiscardUnknown() {
+	xxx_messageInfo_Signer.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Signer proto.InternalMessageInfo
+
+func (m *Signer) GetDelegate() string {
+	if m != nil {
+		return m.Delegate
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*DelegateKey)(nil), "keyrotationpb.DelegateKey")
+	proto.RegisterType((*Signer)(nil), "keyrotationpb.Signer")
+}
+
+func init() { proto.RegisterFile("keyrotation.proto", fileDescriptor_cc424359da3a2f21) }
+
+var fileDescriptor_cc424359da3a2f21 = []byte{
+	// 222 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x90, 0x4f, 0x4b, 0xc3, 0x40,
+	0x10, 0xc5, 0x89, 0x96, 0xa0, 0xe3, 0x3f, 0xdc, 0x83, 0x14, 0x0f, 0x52, 0x8a, 0x48, 0x2e, 0x76,
+	0x0f, 0x7e, 0x03, 0xf1, 0x20, 0x54, 0x44, 0xe2, 0xcd, 0x5b, 0xb2, 0xbe, 0xa6, 0xab, 0xb5, 0xb3,
+	0x0c, 0xd3, 0xe0, 0x7e, 0x12, 0xbf, 0xae, 0xb8, 0x0d, 0x92, 0xe6, 0xf8, 0x7e, 0x3c, 0x86, 0x37,
+	0x3f, 0x3a, 0xff, 0x44, 0x14, 0xd6, 0x4a, 0x3d, 0xaf, 0x67, 0x41, 0x58, 0xd9, 0x9c, 0xf4, 0x50,
+	0xa8, 0xa7, 0x3f, 0x19, 0x1d, 0x3d, 0x60, 0x85, 0xa6, 0x52, 0xcc, 0x11, 0xcd, 0x05, 0xe5, 0x61,
+	0x53, 0xcf, 0x11, 0xc7, 0xd9, 0x24, 0x2b, 0x8e, 0xcb, 0x2e, 0x99, 0x82, 0xce, 0xb0, 0x58, 0xc0,
+	0xa9, 0x6f, 0xf1, 0x08, 0xdf, 0x2c, 0x75, 0xbc, 0x37, 0xc9, 0x8a, 0x51, 0x39, 0xc4, 0xe6, 0x8a,
+	0x28, 0x08, 0xda, 0x97, 0xed, 0x95, 0xfd, 0x74, 0xa5, 0x47, 0xcc, 0x0d, 0x9d, 0x0a, 0x1c, 0xb7,
+	0x90, 0xd8, 0x75, 0x46, 0xa9, 0x33, 0xa0, 0xd3, 0x6b, 0xca, 0x5f, 0x7d, 0xb3, 0x86, 0x98, 0x4b,
+	0x3a, 0x78, 0xef, 0x26, 0xa6, 0x55, 0x87, 0xe5, 0x7f, 0xbe, 0x7f, 0x7e, 0x7b, 0x6a, 0xbc, 0x2e,
+	0x37, 0xf5, 0xcc, 0xf1, 0x97, 0xf5, 0xac, 0xf8, 0x0e, 0xc2, 0x1f, 0x70, 0xba, 0x0d, 0xb7, 0x8e,
+	0x05, 0xb6, 0x72, 0x7f, 0xef, 0xda, 0x64, 0xc0, 0xf1, 0xca, 0xf6, 0x1c, 0xd8, 0x1d, 0x1f, 0x75,
+	0x9e, 0x3a, 0x77, 0xbf, 0x03, 0x00, 0xae, 0xc0, 0x5d, 0x34, 0x3a, 0x01, 0x00, 0x00,
+}
@@ -0,0 +1,283 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package keyrotation
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
+	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/action/protocol/vote/candidatesutil"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+const (
+	// ProtocolID is the protocol ID
+	// TODO: it works only for one instance per protocol definition now
+	ProtocolID = "keyrotation"
+)
+
+var (
+	delegateKeyPrefix = []byte("dlg")
+	signerKeyPrefix   = []byte("sgn")
+)
+
+var (
+	// ErrUnauthorized indicates that the caller is neither the active signing key nor the recovery key of the delegate
+	ErrUnauthorized = errors.New("unauthorized key rotation")
+	// ErrKeyInUse indicates that the new key already signs for another delegate, or is a delegate identity itself
+	ErrKeyInUse = errors.New("key is in use by another delegate")
+	// ErrRetiredKey indicates that the key has been rotated out by the delegate
+	ErrRetiredKey = errors.New("key is retired")
+	// ErrPendingKey indicates that the key is not effective yet
+	ErrPendingKey = errors.New("key is not effective yet")
+)
+
+// StateReader defines the interface to read the key rotation states
+type StateReader interface {
+	State(hash.Hash160, interface{}) error
+}
+
+// Protocol defines the protocol of delegate key rotation. It allows a delegate to re-point its identity to a new
+// block-signing key, which takes effect from the start of the next epoch.
+type Protocol struct {
+	cm   protocol.ChainManager
+	addr address.Address
+	rp   *rolldpos.Protocol
+}
+
+// NewProtocol instantiates a key rotation protocol instance.
+func NewProtocol(cm protocol.ChainManager, rp *rolldpos.Protocol) *Protocol {
+	h := hash.Hash160b([]byte(ProtocolID))
+	addr, err := address.FromBytes(h[:])
+	if err != nil {
+		log.L().Panic("Error when constructing the address of key rotation protocol", zap.Error(err))
+	}
+	return &Protocol{
+		cm:   cm,
+		addr: addr,
+		rp:   rp,
+	}
+}
+
+// Handle handles a rotate key action
+func (p *Protocol) Handle(ctx context.Context, act action.Action, sm protocol.StateManager) (*action.Receipt, error) {
+	rk, ok := act.(*action.RotateKey)
+	if !ok {
+		return nil, nil
+	}
+	raCtx := protocol.MustGetRunActionsCtx(ctx)
+	acc, err := accountutil.LoadOrCreateAccount(sm, raCtx.Caller.String(), big.NewInt(0))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load or create the account of %s", raCtx.Caller.String())
+	}
+	if raCtx.GasLimit < raCtx.IntrinsicGas {
+		return nil, action.ErrHitGasLimit
+	}
+	gasFee := big.NewInt(0).Mul(raCtx.GasPrice, big.NewInt(0).SetUint64(raCtx.IntrinsicGas))
+	if gasFee.Cmp(acc.Balance) == 1 {
+		return nil, errors.Wrapf(
+			state.ErrNotEnoughBalance,
+			"failed to verify the Balance for gas of %s, %d, %d",
+			raCtx.Caller.String(),
+			raCtx.IntrinsicGas,
+			acc.Balance,
+		)
+	}
+	if err := acc.SubBalance(gasFee); err != nil {
+		return nil, errors.Wrapf(err, "failed to charge the gas for %s", raCtx.Caller.String())
+	}
+	if err := rewarding.DepositGas(ctx, sm, gasFee, raCtx.Registry); err != nil {
+		return nil, err
+	}
+	accountutil.SetNonce(rk, acc)
+	if err := accountutil.StoreAccount(sm, raCtx.Caller.String(), acc); err != nil {
+		return nil, errors.Wrap(err, "failed to update pending account changes to trie")
+	}
+
+	status := action.SuccessReceiptStatus
+	si := sm.Snapshot()
+	if err := p.rotate(raCtx, sm, rk); err != nil {
+		log.L().Debug("Error when rotating delegate key", zap.Error(err))
+		if err := sm.Revert(si); err != nil {
+			return nil, err
+		}
+		status = action.FailureReceiptStatus
+	}
+	return &action.Receipt{
+		Status:          status,
+		BlockHeight:     raCtx.BlockHeight,
+		ActionHash:      raCtx.ActionHash,
+		GasConsumed:     raCtx.IntrinsicGas,
+		ContractAddress: p.addr.String(),
+	}, nil
+}
+
+// Validate validates a rotate key action
+func (p *Protocol) Validate(ctx context.Context, act action.Action) error {
+	rk, ok := act.(*action.RotateKey)
+	if !ok {
+		return nil
+	}
+	if _, err := address.FromString(rk.Delegate()); err != nil {
+		return errors.Wrapf(err, "error when validating delegate's address %s", rk.Delegate())
+	}
+	return rk.VerifyNewKey()
+}
+
+// ReadState read the state on blockchain via protocol
+func (p *Protocol) ReadState(
+	ctx context.Context,
+	sm protocol.StateManager,
+	method []byte,
+	args ...[]byte,
+) ([]byte, error) {
+	switch string(method) {
+	case "DelegateOf":
+		if len(args) != 1 {
+			return nil, errors.Errorf("invalid number of arguments %d", len(args))
+		}
+		delegate, err := DelegateOf(sm, string(args[0]), sm.Height())
+		if err != nil {
+			return nil, err
+		}
+		return []byte(delegate), nil
+	default:
+		return nil, errors.New("corresponding method isn't found")
+	}
+}
+
+// DelegateOf returns the delegate identity on behalf of which the signer address signs blocks and endorsements at the
+// given height. A signer that has never been involved in a rotation signs for itself.
+func DelegateOf(sr StateReader, signer string, height uint64) (string, error) {
+	delegate := signer
+	var s signerRecord
+	switch err := sr.State(stateKey(signerKeyPrefix, signer), &s); errors.Cause(err) {
+	case nil:
+		delegate = s.delegate
+	case state.ErrStateNotExist:
+	default:
+		return "", err
+	}
+	var dk delegateKey
+	switch err := sr.State(stateKey(delegateKeyPrefix, delegate), &dk); errors.Cause(err) {
+	case nil:
+	case state.ErrStateNotExist:
+		return delegate, nil
+	default:
+		return "", err
+	}
+	active, err := addressOf(dk.activeKey(height), delegate)
+	if err != nil {
+		return "", err
+	}
+	if active == signer {
+		return delegate, nil
+	}
+	pending, err := addressOf(dk.pubKey, delegate)
+	if err != nil {
+		return "", err
+	}
+	if pending == signer {
+		return "", errors.Wrapf(ErrPendingKey, "key %s of delegate %s is effective from %d", signer, delegate, dk.effectiveHeight)
+	}
+	return "", errors.Wrapf(ErrRetiredKey, "key %s of delegate %s", signer, delegate)
+}
+
+func (p *Protocol) rotate(raCtx protocol.RunActionsCtx, sm protocol.StateManager, rk *action.RotateKey) error {
+	if err := rk.VerifyNewKey(); err != nil {
+		return err
+	}
+	var dk delegateKey
+	if err := sm.State(stateKey(delegateKeyPrefix, rk.Delegate()), &dk); err != nil &&
+		errors.Cause(err) != state.ErrStateNotExist {
+		return errors.Wrapf(err, "error when loading the key of delegate %s", rk.Delegate())
+	}
+	active := dk.activeKey(raCtx.BlockHeight)
+	caller := raCtx.Caller.String()
+	activeAddr, err := addressOf(active, rk.Delegate())
+	if err != nil {
+		return err
+	}
+	recoveryAddr, err := addressOf(dk.recoveryPubKey, "")
+	if err != nil {
+		return err
+	}
+	if caller != activeAddr && caller != recoveryAddr {
+		return errors.Wrapf(ErrUnauthorized, "%s cannot rotate the key of delegate %s", caller, rk.Delegate())
+	}
+	newAddr, err := addressOf(rk.NewPubKey(), "")
+	if err != nil {
+		return err
+	}
+	if newAddr != rk.Delegate() {
+		if err := p.checkNotDelegate(sm, raCtx.BlockHeight, newAddr); err != nil {
+			return err
+		}
+		var s signerRecord
+		switch err := sm.State(stateKey(signerKeyPrefix, newAddr), &s); errors.Cause(err) {
+		case nil:
+			if s.delegate != rk.Delegate() {
+				return errors.Wrapf(ErrKeyInUse, "key %s signs for %s", newAddr, s.delegate)
+			}
+		case state.ErrStateNotExist:
+			if err := sm.PutState(stateKey(signerKeyPrefix, newAddr), &signerRecord{delegate: rk.Delegate()}); err != nil {
+				return err
+			}
+		default:
+			return err
+		}
+	}
+	dk.prevPubKey = active
+	dk.pubKey = rk.NewPubKey()
+	dk.effectiveHeight = p.rp.GetEpochHeight(p.rp.GetEpochNum(raCtx.BlockHeight) + 1)
+	if rk.RecoveryPubKey() != nil {
+		dk.recoveryPubKey = rk.RecoveryPubKey()
+	}
+	return sm.PutState(stateKey(delegateKeyPrefix, rk.Delegate()), &dk)
+}
+
+// checkNotDelegate returns ErrKeyInUse if the address is a delegate identity, i.e., it has rotated its key, or it is a
+// candidate, so that the key of a delegate cannot be taken to sign for another one
+func (p *Protocol) checkNotDelegate(sm protocol.StateManager, height uint64, addr string) error {
+	var dk delegateKey
+	switch err := sm.State(stateKey(delegateKeyPrefix, addr), &dk); errors.Cause(err) {
+	case nil:
+		return errors.Wrapf(ErrKeyInUse, "%s is a delegate identity", addr)
+	case state.ErrStateNotExist:
+	default:
+		return err
+	}
+	candidates, err := candidatesutil.GetMostRecentCandidateMap(sm, height)
+	if err != nil && errors.Cause(err) != state.ErrStateNotExist {
+		return errors.Wrap(err, "error when loading the candidates")
+	}
+	a, err := address.FromString(addr)
+	if err != nil {
+		return err
+	}
+	if _, ok := candidates[hash.BytesToHash160(a.Bytes())]; ok {
+		return errors.Wrapf(ErrKeyInUse, "%s is a candidate", addr)
+	}
+	return nil
+}
+
+func stateKey(prefix []byte, addr string) hash.Hash160 {
+	h := hash.Hash160b([]byte(ProtocolID))
+	key := append(h[:], prefix...)
+	return hash.Hash160b(append(key, addr...))
+}
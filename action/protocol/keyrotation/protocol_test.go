@@ -0,0 +1,189 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package keyrotation
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/action/protocol/vote/candidatesutil"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/state/factory"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/test/mock/mock_chainmanager"
+)
+
+func TestProtocol_RotateKey(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sf, err := factory.NewStateDB(config.Default, factory.InMemStateDBOption())
+	require.NoError(err)
+	require.NoError(sf.Start(context.Background()))
+	defer func() {
+		require.NoError(sf.Stop(context.Background()))
+	}()
+	// 4 blocks per epoch
+	p := NewProtocol(mock_chainmanager.NewMockChainManager(ctrl), rolldpos.NewProtocol(2, 2, 2))
+
+	identity := identityset.Address(0).String()
+	handle := func(height uint64, caller int, rk *action.RotateKey) uint64 {
+		ctx := protocol.WithRunActionsCtx(context.Background(), protocol.RunActionsCtx{
+			BlockHeight:  height,
+			Caller:       identityset.Address(caller),
+			GasLimit:     action.RotateKeyIntrinsicGas,
+			GasPrice:     big.NewInt(0),
+			IntrinsicGas: action.RotateKeyIntrinsicGas,
+		})
+		ws, err := sf.NewWorkingSet()
+		require.NoError(err)
+		receipt, err := p.Handle(ctx, rk, ws)
+		require.NoError(err)
+		require.NoError(sf.Commit(ws))
+		return receipt.Status
+	}
+	rotate := func(height uint64, caller int, delegate string, newKey keypair.PrivateKey, recoveryKey keypair.PublicKey) uint64 {
+		rk, err := action.NewRotateKey(1, delegate, newKey, recoveryKey, action.RotateKeyIntrinsicGas, big.NewInt(0))
+		require.NoError(err)
+		require.NoError(p.Validate(context.Background(), rk))
+		return handle(height, caller, rk)
+	}
+	delegateOf := func(signer int, height uint64) (string, error) {
+		return DelegateOf(sf, identityset.Address(signer).String(), height)
+	}
+
+	// a key never involved in a rotation signs for itself
+	delegate, err := delegateOf(0, 2)
+	require.NoError(err)
+	require.Equal(identity, delegate)
+
+	// only the active key may rotate when no recovery key is registered
+	require.Equal(action.FailureReceiptStatus, rotate(2, 3, identity, identityset.PrivateKey(1), nil))
+	require.Equal(
+		action.SuccessReceiptStatus,
+		rotate(2, 0, identity, identityset.PrivateKey(1), identityset.PrivateKey(2).PublicKey()),
+	)
+
+	// the new key becomes effective from the next epoch
+	delegate, err = delegateOf(0, 4)
+	require.NoError(err)
+	require.Equal(identity, delegate)
+	_, err = delegateOf(1, 4)
+	require.Equal(ErrPendingKey, errors.Cause(err))
+	delegate, err = delegateOf(1, 5)
+	require.NoError(err)
+	require.Equal(identity, delegate)
+	_, err = delegateOf(0, 5)
+	require.Equal(ErrRetiredKey, errors.Cause(err))
+
+	// the retired key cannot rotate anymore, while the recovery key can
+	require.Equal(action.FailureReceiptStatus, rotate(6, 0, identity, identityset.PrivateKey(0), nil))
+	require.Equal(action.SuccessReceiptStatus, rotate(6, 2, identity, identityset.PrivateKey(4), nil))
+	delegate, err = delegateOf(1, 8)
+	require.NoError(err)
+	require.Equal(identity, delegate)
+	delegate, err = delegateOf(4, 9)
+	require.NoError(err)
+	require.Equal(identity, delegate)
+	_, err = delegateOf(1, 9)
+	require.Equal(ErrRetiredKey, errors.Cause(err))
+	_, err = delegateOf(0, 9)
+	require.Equal(ErrRetiredKey, errors.Cause(err))
+
+	// a key signing for a delegate cannot be claimed by another one
+	other := identityset.Address(5).String()
+	require.Equal(action.FailureReceiptStatus, rotate(10, 5, other, identityset.PrivateKey(4), nil))
+	delegate, err = delegateOf(5, 13)
+	require.NoError(err)
+	require.Equal(other, delegate)
+
+	// the state can be read via the protocol as well, where the key rotated out the last time is still active at the
+	// height of the working set
+	ws, err := sf.NewWorkingSet()
+	require.NoError(err)
+	data, err := p.ReadState(context.Background(), ws, []byte("DelegateOf"), []byte(identityset.Address(1).String()))
+	require.NoError(err)
+	require.Equal(identity, string(data))
+}
+
+func TestProtocol_RotateKeyHijack(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sf, err := factory.NewStateDB(config.Default, factory.InMemStateDBOption())
+	require.NoError(err)
+	require.NoError(sf.Start(context.Background()))
+	defer func() {
+		require.NoError(sf.Stop(context.Background()))
+	}()
+	p := NewProtocol(mock_chainmanager.NewMockChainManager(ctrl), rolldpos.NewProtocol(2, 2, 2))
+	handle := func(height uint64, caller int, rk *action.RotateKey) uint64 {
+		ctx := protocol.WithRunActionsCtx(context.Background(), protocol.RunActionsCtx{
+			BlockHeight:  height,
+			Caller:       identityset.Address(caller),
+			GasLimit:     action.RotateKeyIntrinsicGas,
+			GasPrice:     big.NewInt(0),
+			IntrinsicGas: action.RotateKeyIntrinsicGas,
+		})
+		ws, err := sf.NewWorkingSet()
+		require.NoError(err)
+		receipt, err := p.Handle(ctx, rk, ws)
+		require.NoError(err)
+		require.NoError(sf.Commit(ws))
+		return receipt.Status
+	}
+	victim, mallory := identityset.Address(0).String(), identityset.Address(1).String()
+	victimSignsForItself := func(height uint64) {
+		delegate, err := DelegateOf(sf, victim, height)
+		require.NoError(err)
+		require.Equal(victim, delegate)
+	}
+
+	// mallory claims the public key of the victim without holding it, co-signing with her own key instead
+	rk, err := action.NewRotateKey(1, mallory, identityset.PrivateKey(1), nil, action.RotateKeyIntrinsicGas, big.NewInt(0))
+	require.NoError(err)
+	pb := rk.Proto()
+	pb.NewPubKey = identityset.PrivateKey(0).PublicKey().Bytes()
+	forged := &action.RotateKey{}
+	require.NoError(forged.LoadProto(pb))
+	require.Equal(action.ErrSignature, errors.Cause(p.Validate(context.Background(), forged)))
+	require.Equal(action.FailureReceiptStatus, handle(2, 1, forged))
+	victimSignsForItself(2)
+	victimSignsForItself(5)
+
+	// nor may the key of a delegate identity be taken even by its holder, once the identity rotated its key
+	rk, err = action.NewRotateKey(1, victim, identityset.PrivateKey(2), nil, action.RotateKeyIntrinsicGas, big.NewInt(0))
+	require.NoError(err)
+	require.Equal(action.SuccessReceiptStatus, handle(2, 0, rk))
+	rk, err = action.NewRotateKey(1, mallory, identityset.PrivateKey(0), nil, action.RotateKeyIntrinsicGas, big.NewInt(0))
+	require.NoError(err)
+	require.NoError(p.Validate(context.Background(), rk))
+	require.Equal(action.FailureReceiptStatus, handle(3, 1, rk))
+
+	// or once it is a candidate
+	ws, err := sf.NewWorkingSet()
+	require.NoError(err)
+	require.NoError(candidatesutil.LoadAndAddCandidates(ws, 1, identityset.Address(3).String()))
+	require.NoError(sf.Commit(ws))
+	rk, err = action.NewRotateKey(1, mallory, identityset.PrivateKey(3), nil, action.RotateKeyIntrinsicGas, big.NewInt(0))
+	require.NoError(err)
+	require.Equal(action.FailureReceiptStatus, handle(4, 1, rk))
+	delegate, err := DelegateOf(sf, identityset.Address(3).String(), 9)
+	require.NoError(err)
+	require.Equal(identityset.Address(3).String(), delegate)
+}
@@ -38,29 +38,55 @@ var (
 	exemptKey                   = []byte("xpt")
 )
 
+// BurnAddress is the address the block reward is granted to if the protocol burns it. Nobody has the private key of the
+// all-zero address, so the balance granted to it could never be claimed.
+var BurnAddress address.Address
+
+func init() {
+	var err error
+	if BurnAddress, err = address.FromBytes(make([]byte, 20)); err != nil {
+		log.L().Panic("Error when constructing the burn address", zap.Error(err))
+	}
+}
+
 // Protocol defines the protocol of the rewarding fund and the rewarding process. It allows the admin to config the
 // reward amount, users to donate tokens to the fund, block producers to grant them block and epoch reward and,
 // beneficiaries to claim the balance into their personal account.
 type Protocol struct {
-	cm        protocol.ChainManager
-	keyPrefix []byte
-	addr      address.Address
-	rp        *rolldpos.Protocol
+	cm              protocol.ChainManager
+	keyPrefix       []byte
+	addr            address.Address
+	rp              *rolldpos.Protocol
+	burnBlockReward bool
+}
+
+// Option sets an option of the rewarding protocol
+type Option func(*Protocol)
+
+// BurnBlockRewardOption grants the block reward to BurnAddress instead of the reward address of the block producer
+func BurnBlockRewardOption() Option {
+	return func(p *Protocol) {
+		p.burnBlockReward = true
+	}
 }
 
 // NewProtocol instantiates a rewarding protocol instance.
-func NewProtocol(cm protocol.ChainManager, rp *rolldpos.Protocol) *Protocol {
+func NewProtocol(cm protocol.ChainManager, rp *rolldpos.Protocol, opts ...Option) *Protocol {
 	h := hash.Hash160b([]byte(ProtocolID))
 	addr, err := address.FromBytes(h[:])
 	if err != nil {
 		log.L().Panic("Error when constructing the address of rewarding protocol", zap.Error(err))
 	}
-	return &Protocol{
+	p := &Protocol{
 		cm:        cm,
 		keyPrefix: h[:],
 		addr:      addr,
 		rp:        rp,
 	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
 }
 
 // Handle handles the actions on the rewarding protocol
@@ -72,26 +72,15 @@ func (p *Protocol) GrantBlockReward(
 		return nil, err
 	}
 
-	// Get the reward address for the block producer
-	epochNum := p.rp.GetEpochNum(raCtx.BlockHeight)
-	candidates, err := p.cm.CandidatesByHeight(p.rp.GetEpochHeight(epochNum))
+	rewardAddr, err := p.blockRewardAddress(raCtx)
 	if err != nil {
 		return nil, err
 	}
-	producerAddrStr := raCtx.Producer.String()
-	rewardAddrStr := ""
-	for _, candidate := range candidates {
-		if candidate.Address == producerAddrStr {
-			rewardAddrStr = candidate.RewardAddress
-			break
-		}
-	}
 	// If reward address doesn't exist, do nothing
-	if rewardAddrStr == "" {
-		log.S().Warnf("Producer %s doesn't have a reward address", producerAddrStr)
+	if rewardAddr == nil {
 		return nil, nil
 	}
-	rewardAddr, err := address.FromString(rewardAddrStr)
+	rewardAddrStr := rewardAddr.String()
 
 	a := admin{}
 	if err := p.state(sm, adminKey, &a); err != nil {
@@ -100,9 +89,6 @@ func (p *Protocol) GrantBlockReward(
 	if err := p.updateAvailableBalance(sm, a.blockReward); err != nil {
 		return nil, err
 	}
-	if err != nil {
-		return nil, err
-	}
 	if err := p.grantToAccount(sm, rewardAddr, a.blockReward); err != nil {
 		return nil, err
 	}
@@ -127,6 +113,33 @@ func (p *Protocol) GrantBlockReward(
 	}, nil
 }
 
+// blockRewardAddress returns the address the block reward goes to, which is BurnAddress if the protocol burns the
+// block reward, or the reward address of the block producer otherwise. It returns nil if the producer doesn't have one.
+func (p *Protocol) blockRewardAddress(raCtx protocol.RunActionsCtx) (address.Address, error) {
+	if p.burnBlockReward {
+		return BurnAddress, nil
+	}
+	// Get the reward address for the block producer
+	epochNum := p.rp.GetEpochNum(raCtx.BlockHeight)
+	candidates, err := p.cm.CandidatesByHeight(p.rp.GetEpochHeight(epochNum))
+	if err != nil {
+		return nil, err
+	}
+	producerAddrStr := raCtx.Producer.String()
+	rewardAddrStr := ""
+	for _, candidate := range candidates {
+		if candidate.Address == producerAddrStr {
+			rewardAddrStr = candidate.RewardAddress
+			break
+		}
+	}
+	if rewardAddrStr == "" {
+		log.S().Warnf("Producer %s doesn't have a reward address", producerAddrStr)
+		return nil, nil
+	}
+	return address.FromString(rewardAddrStr)
+}
+
 // GrantEpochReward grants the epoch reward (token) to all beneficiaries of a epoch
 func (p *Protocol) GrantEpochReward(
 	ctx context.Context,
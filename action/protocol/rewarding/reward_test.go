@@ -82,6 +82,40 @@ func TestProtocol_GrantBlockReward(t *testing.T) {
 	}, false)
 }
 
+func TestProtocol_BurnBlockReward(t *testing.T) {
+	testProtocol(t, func(t *testing.T, ctx context.Context, stateDB factory.Factory, p *Protocol) {
+		BurnBlockRewardOption()(p)
+
+		ws, err := stateDB.NewWorkingSet()
+		require.NoError(t, err)
+		require.NoError(t, p.Deposit(ctx, ws, big.NewInt(200)))
+		require.NoError(t, stateDB.Commit(ws))
+
+		ws, err = stateDB.NewWorkingSet()
+		require.NoError(t, err)
+		rewardLog, err := p.GrantBlockReward(ctx, ws)
+		require.NoError(t, err)
+		var rl rewardingpb.RewardLog
+		require.NoError(t, proto.Unmarshal(rewardLog.Data, &rl))
+		require.Equal(t, BurnAddress.String(), rl.Addr)
+		require.Equal(t, "10", rl.Amount)
+		require.NoError(t, stateDB.Commit(ws))
+
+		ws, err = stateDB.NewWorkingSet()
+		require.NoError(t, err)
+		availableBalance, err := p.AvailableBalance(ctx, ws)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(190), availableBalance)
+		// The burn address gets the reward instead of the beneficiary
+		unclaimedBalance, err := p.UnclaimedBalance(ctx, ws, BurnAddress)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(10), unclaimedBalance)
+		unclaimedBalance, err = p.UnclaimedBalance(ctx, ws, identityset.Address(0))
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(0), unclaimedBalance)
+	}, false)
+}
+
 func TestProtocol_GrantEpochReward(t *testing.T) {
 	testProtocol(t, func(t *testing.T, ctx context.Context, stateDB factory.Factory, p *Protocol) {
 		raCtx, ok := protocol.GetRunActionsCtx(ctx)
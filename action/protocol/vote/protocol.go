@@ -21,6 +21,7 @@ import (
 	"github.com/iotexproject/iotex-core/action/protocol/vote/candidatesutil"
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
 	"github.com/iotexproject/iotex-core/state"
 )
 
@@ -108,8 +109,9 @@ func (p *Protocol) Handle(ctx context.Context, act action.Action, sm protocol.St
 	accountutil.SetNonce(vote, voteFrom)
 	prevVotee := voteFrom.Votee
 	voteFrom.Votee = vote.Votee()
-	if vote.Votee() == "" {
-		// unvote operation
+	if vote.IsUnvote() {
+		// unvote operation, which votes to no one even if the votee is the zero address
+		voteFrom.Votee = action.EmptyAddress
 		voteFrom.IsCandidate = false
 		// Remove the candidate from candidateMap if the person is not a candidate anymore
 		if err := candidatesutil.LoadAndDeleteCandidates(sm, raCtx.BlockHeight, raCtx.Caller.String()); err != nil {
@@ -118,7 +120,7 @@ func (p *Protocol) Handle(ctx context.Context, act action.Action, sm protocol.St
 	} else if raCtx.Caller.String() == vote.Votee() {
 		// Vote to self: self-nomination
 		voteFrom.IsCandidate = true
-		addr, err := address.FromBytes(vote.VoterPublicKey().Hash())
+		addr, err := addrutil.PubKeyToAddress(vote.VoterPublicKey())
 		if err != nil {
 			return nil, err
 		}
@@ -151,7 +153,7 @@ func (p *Protocol) Handle(ctx context.Context, act action.Action, sm protocol.St
 		}
 	}
 
-	if vote.Votee() != "" {
+	if !vote.IsUnvote() {
 		voteTo, err := accountutil.LoadOrCreateAccount(sm, vote.Votee(), big.NewInt(0))
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to load or create the account of votee %s", vote.Votee())
@@ -201,7 +203,7 @@ func (p *Protocol) Validate(ctx context.Context, act action.Action) error {
 			return errors.Wrapf(err, "error when validating votee's address %s", vote.Votee())
 		}
 	}
-	if vote.Votee() != "" {
+	if !vote.IsUnvote() {
 		// Reject vote if votee is not a candidate
 		voteeState, err := p.cm.StateByAddr(vote.Votee())
 		if err != nil {
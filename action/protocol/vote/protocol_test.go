@@ -0,0 +1,114 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package vote
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/vote/candidatesutil"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/state/factory"
+	"github.com/iotexproject/iotex-core/test/testaddress"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestProtocol_HandleUnvote(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	sf, err := factory.NewFactory(config.Default, factory.InMemTrieOption())
+	require.NoError(err)
+	require.NoError(sf.Start(ctx))
+	defer func() {
+		require.NoError(sf.Stop(ctx))
+	}()
+	ws, err := sf.NewWorkingSet()
+	require.NoError(err)
+	alfa, bravo := testaddress.Addrinfo["alfa"], testaddress.Addrinfo["bravo"]
+	for _, c := range []struct {
+		addr    address.Address
+		balance int64
+	}{{alfa, 100}, {bravo, 50}} {
+		require.NoError(ws.PutState(
+			hash.BytesToHash160(c.addr.Bytes()),
+			&state.Account{Balance: big.NewInt(c.balance), VotingWeight: big.NewInt(0)},
+		))
+	}
+	zero, err := address.FromBytes(hash.ZeroHash160[:])
+	require.NoError(err)
+
+	p := NewProtocol(nil)
+	handle := func(voter string, votee string, nonce uint64) {
+		selp, err := testutil.SignedVote(votee, testaddress.Keyinfo[voter].PriKey, nonce, uint64(100000), big.NewInt(0))
+		require.NoError(err)
+		ctx := protocol.WithRunActionsCtx(ctx, protocol.RunActionsCtx{
+			BlockHeight:  1,
+			Caller:       testaddress.Addrinfo[voter],
+			GasLimit:     testutil.TestGasLimit,
+			IntrinsicGas: action.VoteIntrinsicGas,
+			GasPrice:     big.NewInt(0),
+		})
+		receipt, err := p.Handle(ctx, selp.Action(), ws)
+		require.NoError(err)
+		require.Equal(action.SuccessReceiptStatus, receipt.Status)
+	}
+	account := func(addr address.Address) *state.Account {
+		var acct state.Account
+		require.NoError(ws.State(hash.BytesToHash160(addr.Bytes()), &acct))
+		return &acct
+	}
+
+	// alfa self-nominates, and bravo votes for alfa
+	handle("alfa", alfa.String(), 1)
+	handle("bravo", alfa.String(), 1)
+	require.Equal(big.NewInt(150), account(alfa).VotingWeight)
+	require.Equal(alfa.String(), account(bravo).Votee)
+
+	// bravo unvotes by voting to the zero address, which takes no vote
+	handle("bravo", zero.String(), 2)
+	require.Equal(big.NewInt(100), account(alfa).VotingWeight)
+	require.Equal("", account(bravo).Votee)
+	err = ws.State(hash.BytesToHash160(zero.Bytes()), &state.Account{})
+	require.Equal(state.ErrStateNotExist, errors.Cause(err))
+
+	// alfa steps down by voting to the empty address
+	handle("alfa", "", 2)
+	require.Equal("", account(alfa).Votee)
+	require.False(account(alfa).IsCandidate)
+	candidates, err := candidatesutil.GetMostRecentCandidateMap(ws, 1)
+	require.NoError(err)
+	require.Empty(candidates)
+}
+
+func TestProtocol_ValidateUnvote(t *testing.T) {
+	require := require.New(t)
+
+	p := NewProtocol(nil)
+	ctx := protocol.WithValidateActionsCtx(context.Background(), protocol.ValidateActionsCtx{
+		Caller: testaddress.Addrinfo["alfa"],
+	})
+	zero, err := address.FromBytes(hash.ZeroHash160[:])
+	require.NoError(err)
+	for _, votee := range []string{"", zero.String()} {
+		vote, err := action.NewVote(1, votee, uint64(100000), big.NewInt(0))
+		require.NoError(err)
+		require.NoError(p.Validate(ctx, vote))
+	}
+	vote, err := action.NewVote(1, "io1malformed", uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	require.Error(p.Validate(ctx, vote))
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/pkg/version"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+const (
+	// RotateKeyIntrinsicGas represents the intrinsic gas for rotating a delegate key
+	RotateKeyIntrinsicGas = uint64(10000)
+)
+
+// RotateKey is the action to re-point a delegate identity to a new block-signing key. It is signed by the current
+// signing key of the delegate or by its recovery key, and optionally registers a new recovery key. The new key co-signs
+// the rotation to prove the possession of it, so that no one can claim the key of someone else
+type RotateKey struct {
+	AbstractAction
+
+	delegate        string
+	newPubKey       keypair.PublicKey
+	recoveryPubKey  keypair.PublicKey
+	newKeySignature []byte
+}
+
+// NewRotateKey returns a RotateKey instance, co-signed by the new key
+func NewRotateKey(
+	nonce uint64,
+	delegate string,
+	newKey keypair.Signer,
+	recoveryPubKey keypair.PublicKey,
+	gasLimit uint64,
+	gasPrice *big.Int,
+) (*RotateKey, error) {
+	if newKey == nil || newKey.PublicKey() == nil {
+		return nil, errors.New("new key is missing")
+	}
+	r := &RotateKey{
+		AbstractAction: AbstractAction{
+			version:  version.ProtocolVersion,
+			nonce:    nonce,
+			gasLimit: gasLimit,
+			gasPrice: gasPrice,
+		},
+		delegate:       delegate,
+		newPubKey:      newKey.PublicKey(),
+		recoveryPubKey: recoveryPubKey,
+	}
+	h := r.newKeyHash()
+	sig, err := newKey.Sign(h[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to co-sign the rotation with the new key")
+	}
+	r.newKeySignature = sig
+	return r, nil
+}
+
+// Delegate returns the address of the delegate identity
+func (r *RotateKey) Delegate() string { return r.delegate }
+
+// NewPubKey returns the new block-signing public key
+func (r *RotateKey) NewPubKey() keypair.PublicKey { return r.newPubKey }
+
+// RecoveryPubKey returns the recovery public key to register, or nil if the registered one is kept
+func (r *RotateKey) RecoveryPubKey() keypair.PublicKey { return r.recoveryPubKey }
+
+// NewKeySignature returns the signature of the rotation by the new key
+func (r *RotateKey) NewKeySignature() []byte { return r.newKeySignature }
+
+// VerifyNewKey verifies that the rotation is co-signed by the new key
+func (r *RotateKey) VerifyNewKey() error {
+	if len(r.newKeySignature) != SignatureLength {
+		return errors.Wrap(ErrSignature, "incorrect length of the new key signature")
+	}
+	h := r.newKeyHash()
+	if !r.newPubKey.Verify(h[:], r.newKeySignature) {
+		return errors.Wrap(ErrSignature, "the rotation isn't signed by the new key")
+	}
+	return nil
+}
+
+// newKeyHash returns the hash of the rotation that the new key signs, i.e., of everything but the signature itself
+func (r *RotateKey) newKeyHash() hash.Hash256 {
+	pb := r.Proto()
+	pb.NewKeySignature = nil
+	return hash.Hash256b(byteutil.Must(proto.Marshal(pb)))
+}
+
+// ByteStream returns a raw byte stream of this rotate key action
+func (r *RotateKey) ByteStream() []byte {
+	return byteutil.Must(proto.Marshal(r.Proto()))
+}
+
+// Proto converts RotateKey to protobuf's Action
+func (r *RotateKey) Proto() *iotextypes.RotateKey {
+	pb := &iotextypes.RotateKey{
+		Delegate:  r.delegate,
+		NewPubKey: r.newPubKey.Bytes(),
+	}
+	if r.recoveryPubKey != nil {
+		pb.RecoveryPubKey = r.recoveryPubKey.Bytes()
+	}
+	if len(r.newKeySignature) > 0 {
+		pb.NewKeySignature = make([]byte, len(r.newKeySignature))
+		copy(pb.NewKeySignature, r.newKeySignature)
+	}
+	return pb
+}
+
+// LoadProto converts a protobuf's Action to RotateKey
+func (r *RotateKey) LoadProto(pbAct *iotextypes.RotateKey) error {
+	if pbAct == nil {
+		return errors.New("empty action proto to load")
+	}
+	if r == nil {
+		return errors.New("nil action to load proto")
+	}
+	*r = RotateKey{}
+	r.delegate = pbAct.GetDelegate()
+	pk, err := keypair.BytesToPublicKey(pbAct.GetNewPubKey())
+	if err != nil {
+		return errors.Wrap(err, "failed to load new public key")
+	}
+	r.newPubKey = pk
+	if len(pbAct.GetRecoveryPubKey()) > 0 {
+		if r.recoveryPubKey, err = keypair.BytesToPublicKey(pbAct.GetRecoveryPubKey()); err != nil {
+			return errors.Wrap(err, "failed to load recovery public key")
+		}
+	}
+	if len(pbAct.GetNewKeySignature()) > 0 {
+		r.newKeySignature = make([]byte, len(pbAct.GetNewKeySignature()))
+		copy(r.newKeySignature, pbAct.GetNewKeySignature())
+	}
+	return nil
+}
+
+// IntrinsicGas returns the intrinsic gas of a rotate key action
+func (r *RotateKey) IntrinsicGas() (uint64, error) {
+	return RotateKeyIntrinsicGas, nil
+}
+
+// Cost returns the total cost of a rotate key action
+func (r *RotateKey) Cost() (*big.Int, error) {
+	intrinsicGas, err := r.IntrinsicGas()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get intrinsic gas for the rotate key action")
+	}
+	return big.NewInt(0).Mul(r.GasPrice(), big.NewInt(0).SetUint64(intrinsicGas)), nil
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/pkg/version"
 	"github.com/iotexproject/iotex-core/protogen/iotextypes"
@@ -46,6 +47,9 @@ func NewTransfer(
 	gasLimit uint64,
 	gasPrice *big.Int,
 ) (*Transfer, error) {
+	if err := addrutil.Validate(recipient); err != nil {
+		return nil, errors.Wrapf(err, "error when validating recipient's address %s", recipient)
+	}
 	return &Transfer{
 		AbstractAction: AbstractAction{
 			version:  version.ProtocolVersion,
@@ -10,6 +10,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/test/testaddress"
@@ -43,3 +44,70 @@ func TestTransferSignVerify(t *testing.T) {
 	// verify signature
 	require.NoError(Verify(selp))
 }
+
+func TestTransferGasSigned(t *testing.T) {
+	require := require.New(t)
+	recipientAddr := testaddress.Addrinfo["alfa"]
+	senderKey := testaddress.Keyinfo["producer"]
+
+	tsf, err := NewTransfer(1, big.NewInt(10), recipientAddr.String(), []byte{}, uint64(100000), big.NewInt(10))
+	require.NoError(err)
+	bd := &EnvelopeBuilder{}
+	elp := bd.SetNonce(1).
+		SetGasLimit(uint64(100000)).
+		SetGasPrice(big.NewInt(10)).
+		SetAction(tsf).Build()
+	selp, err := Sign(elp, senderKey.PriKey)
+	require.NoError(err)
+
+	// the gas limit and the gas price survive the proto round trip
+	var loaded SealedEnvelope
+	require.NoError(loaded.LoadProto(selp.Proto()))
+	require.Equal(uint64(100000), loaded.GasLimit())
+	require.Equal(big.NewInt(10), loaded.GasPrice())
+	require.NoError(Verify(loaded))
+
+	// both are signed, so tampering with either invalidates the signature
+	pb := selp.Proto()
+	pb.Core.GasPrice = "1"
+	require.NoError(loaded.LoadProto(pb))
+	require.Equal(ErrSignature, errors.Cause(Verify(loaded)))
+	pb = selp.Proto()
+	pb.Core.GasLimit = 200000
+	require.NoError(loaded.LoadProto(pb))
+	require.Equal(ErrSignature, errors.Cause(Verify(loaded)))
+}
+
+func TestTransferPayloadSigned(t *testing.T) {
+	require := require.New(t)
+	recipientAddr := testaddress.Addrinfo["alfa"]
+	senderKey := testaddress.Keyinfo["producer"]
+
+	sign := func(payload []byte) SealedEnvelope {
+		tsf, err := NewTransfer(1, big.NewInt(10), recipientAddr.String(), payload, uint64(100000), big.NewInt(10))
+		require.NoError(err)
+		bd := &EnvelopeBuilder{}
+		elp := bd.SetNonce(1).
+			SetGasLimit(uint64(100000)).
+			SetGasPrice(big.NewInt(10)).
+			SetAction(tsf).Build()
+		selp, err := Sign(elp, senderKey.PriKey)
+		require.NoError(err)
+		return selp
+	}
+	plain := sign([]byte{})
+	selp := sign([]byte("memo"))
+
+	// the payload survives the proto round trip, and the transfer carrying it has a distinct hash
+	var loaded SealedEnvelope
+	require.NoError(loaded.LoadProto(selp.Proto()))
+	require.Equal([]byte("memo"), loaded.Action().(*Transfer).Payload())
+	require.NoError(Verify(loaded))
+	require.NotEqual(plain.Hash(), selp.Hash())
+
+	// the payload is signed, so tampering with it invalidates the signature
+	pb := selp.Proto()
+	pb.Core.GetTransfer().Payload = []byte("mema")
+	require.NoError(loaded.LoadProto(pb))
+	require.Equal(ErrSignature, errors.Cause(Verify(loaded)))
+}
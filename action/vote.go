@@ -7,12 +7,15 @@
 package action
 
 import (
+	"bytes"
 	"math/big"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/iotexproject/iotex-address/address"
 	"github.com/pkg/errors"
 
+	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/pkg/version"
@@ -34,7 +37,8 @@ type Vote struct {
 	votee     string
 }
 
-// NewVote returns a Vote instance
+// NewVote returns a Vote instance. A vote of the empty votee address is an unvote, which removes the voter's existing
+// vote
 func NewVote(nonce uint64, voteeAddress string, gasLimit uint64, gasPrice *big.Int) (*Vote, error) {
 	return &Vote{
 		AbstractAction: AbstractAction{
@@ -55,6 +59,15 @@ func (v *Vote) VoterPublicKey() keypair.PublicKey {
 // Votee returns the votee's address
 func (v *Vote) Votee() string { return v.votee }
 
+// IsUnvote tells whether the vote removes the voter's existing vote, i.e., the votee is the empty or the zero address
+func (v *Vote) IsUnvote() bool {
+	if v.votee == EmptyAddress {
+		return true
+	}
+	addr, err := address.FromString(v.votee)
+	return err == nil && bytes.Equal(addr.Bytes(), hash.ZeroHash160[:])
+}
+
 // Destination returns the votee's address
 func (v *Vote) Destination() string { return v.Votee() }
 
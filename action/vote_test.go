@@ -0,0 +1,57 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+func TestVoteUnvote(t *testing.T) {
+	require := require.New(t)
+
+	zero, err := address.FromBytes(hash.ZeroHash160[:])
+	require.NoError(err)
+	for _, c := range []struct {
+		votee  string
+		unvote bool
+	}{
+		{EmptyAddress, true},
+		{zero.String(), true},
+		{testaddress.Addrinfo["alfa"].String(), false},
+		{"io1malformed", false},
+	} {
+		vote, err := NewVote(1, c.votee, uint64(100000), big.NewInt(10))
+		require.NoError(err)
+		require.Equal(c.unvote, vote.IsUnvote())
+	}
+
+	// the empty votee survives the proto round trip of the signed vote
+	vote, err := NewVote(1, EmptyAddress, uint64(100000), big.NewInt(10))
+	require.NoError(err)
+	bd := &EnvelopeBuilder{}
+	elp := bd.SetNonce(1).
+		SetGasLimit(uint64(100000)).
+		SetGasPrice(big.NewInt(10)).
+		SetAction(vote).Build()
+	selp, err := Sign(elp, testaddress.Keyinfo["alfa"].PriKey)
+	require.NoError(err)
+	var loaded SealedEnvelope
+	require.NoError(loaded.LoadProto(selp.Proto()))
+	require.NoError(Verify(loaded))
+	loadedVote, ok := loaded.Action().(*Vote)
+	require.True(ok)
+	require.Equal(EmptyAddress, loadedVote.Votee())
+	require.True(loadedVote.IsUnvote())
+	require.Equal(selp.Hash(), loaded.Hash())
+}
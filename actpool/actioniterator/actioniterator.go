@@ -7,33 +7,44 @@
 package actioniterator
 
 import (
+	"bytes"
 	"container/heap"
 
-	"github.com/iotexproject/iotex-address/address"
-
 	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
 )
 
-// ActionByPrice implements both the sort and the heap interface, making it useful
-// for all at once sorting as well as individually adding and removing elements.
-// It's essentially a big root heap of actions
-type actionByPrice []action.SealedEnvelope
+// ByGasPrice orders the actions in descending order of gas price, and the ones of the same gas price by the addresses
+// of their senders, so that the order doesn't depend on the order the senders are iterated in
+func ByGasPrice(a, b action.SealedEnvelope) bool {
+	if c := a.GasPrice().Cmp(b.GasPrice()); c != 0 {
+		return c > 0
+	}
+	return bytes.Compare(a.SrcPubkey().Hash(), b.SrcPubkey().Hash()) < 0
+}
+
+// actionHeap implements both the sort and the heap interface of the head actions of the senders, whose top is the
+// one ahead of the others by less, e.g., the one of the highest gas price by ByGasPrice
+type actionHeap struct {
+	acts []action.SealedEnvelope
+	less func(a, b action.SealedEnvelope) bool
+}
 
-func (s actionByPrice) Len() int           { return len(s) }
-func (s actionByPrice) Less(i, j int) bool { return s[i].GasPrice().Cmp(s[j].GasPrice()) > 0 }
-func (s actionByPrice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (h *actionHeap) Len() int           { return len(h.acts) }
+func (h *actionHeap) Less(i, j int) bool { return h.less(h.acts[i], h.acts[j]) }
+func (h *actionHeap) Swap(i, j int)      { h.acts[i], h.acts[j] = h.acts[j], h.acts[i] }
 
 // Push define the push function of heap
-func (s *actionByPrice) Push(x interface{}) {
-	*s = append(*s, x.(action.SealedEnvelope))
+func (h *actionHeap) Push(x interface{}) {
+	h.acts = append(h.acts, x.(action.SealedEnvelope))
 }
 
 // Pop define the pop function of heap
-func (s *actionByPrice) Pop() interface{} {
-	old := *s
+func (h *actionHeap) Pop() interface{} {
+	old := h.acts
 	n := len(old)
 	x := old[n-1]
-	*s = old[0 : n-1]
+	h.acts = old[0 : n-1]
 	return x
 }
 
@@ -44,59 +55,99 @@ type ActionIterator interface {
 }
 
 type actionIterator struct {
-	accountActs map[string][]action.SealedEnvelope
-	heads       actionByPrice
+	accountActs   map[string][]action.SealedEnvelope
+	heads         *actionHeap
+	priorityHeads *actionHeap
 }
 
 // NewActionIterator return a new action iterator
 func NewActionIterator(accountActs map[string][]action.SealedEnvelope) ActionIterator {
-	heads := make(actionByPrice, 0, len(accountActs))
+	return NewPriorityActionIterator(accountActs, nil)
+}
+
+// NewPriorityActionIterator returns a new action iterator, which yields the actions of the priority senders ahead of
+// the others regardless of the gas price. The actions of each sender are still yielded in nonce order.
+func NewPriorityActionIterator(
+	accountActs map[string][]action.SealedEnvelope,
+	prioritySenders []string,
+) ActionIterator {
+	return NewOrderedActionIterator(accountActs, prioritySenders, ByGasPrice)
+}
+
+// NewOrderedActionIterator returns a new action iterator like NewPriorityActionIterator, which yields the next action
+// of the sender whose next action is ahead of the others' by less, rather than by the gas price
+func NewOrderedActionIterator(
+	accountActs map[string][]action.SealedEnvelope,
+	prioritySenders []string,
+	less func(a, b action.SealedEnvelope) bool,
+) ActionIterator {
+	priority := make(map[string]bool, len(prioritySenders))
+	for _, sender := range prioritySenders {
+		priority[sender] = true
+	}
+	heads := &actionHeap{acts: make([]action.SealedEnvelope, 0, len(accountActs)), less: less}
+	priorityHeads := &actionHeap{acts: make([]action.SealedEnvelope, 0, len(prioritySenders)), less: less}
 	for sender, accActs := range accountActs {
 		if len(accActs) == 0 {
 			continue
 		}
 
-		heads = append(heads, accActs[0])
+		if priority[sender] {
+			priorityHeads.acts = append(priorityHeads.acts, accActs[0])
+		} else {
+			heads.acts = append(heads.acts, accActs[0])
+		}
 		if len(accActs) > 1 {
 			accountActs[sender] = accActs[1:]
 		} else {
 			accountActs[sender] = []action.SealedEnvelope{}
 		}
 	}
-	heap.Init(&heads)
+	heap.Init(heads)
+	heap.Init(priorityHeads)
 	return &actionIterator{
-		accountActs: accountActs,
-		heads:       heads,
+		accountActs:   accountActs,
+		heads:         heads,
+		priorityHeads: priorityHeads,
+	}
+}
+
+// topHeads returns the heads of the priority senders until they run out, and then the heads of the others
+func (ai *actionIterator) topHeads() *actionHeap {
+	if ai.priorityHeads.Len() != 0 {
+		return ai.priorityHeads
 	}
+	return ai.heads
 }
 
 // LoadNext load next action of account of top action
-func (ai *actionIterator) loadNextActionForTopAccount() {
-	sender := ai.heads[0].SrcPubkey()
-	callerAddr, _ := address.FromBytes(sender.Hash())
+func (ai *actionIterator) loadNextActionForTopAccount(heads *actionHeap) {
+	sender := heads.acts[0].SrcPubkey()
+	callerAddr, _ := addrutil.PubKeyToAddress(sender)
 	callerAddrStr := callerAddr.String()
 	if actions, ok := ai.accountActs[callerAddrStr]; ok && len(actions) > 0 {
-		ai.heads[0], ai.accountActs[callerAddrStr] = actions[0], actions[1:]
-		heap.Fix(&ai.heads, 0)
+		heads.acts[0], ai.accountActs[callerAddrStr] = actions[0], actions[1:]
+		heap.Fix(heads, 0)
 	} else {
-		heap.Pop(&ai.heads)
+		heap.Pop(heads)
 	}
 }
 
 // Next load next action of account of top action
 func (ai *actionIterator) Next() (action.SealedEnvelope, bool) {
-	if len(ai.heads) == 0 {
+	heads := ai.topHeads()
+	if heads.Len() == 0 {
 		return action.SealedEnvelope{}, false
 	}
 
-	headAction := ai.heads[0]
-	ai.loadNextActionForTopAccount()
+	headAction := heads.acts[0]
+	ai.loadNextActionForTopAccount(heads)
 	return headAction, true
 }
 
 // PopAccount will remove all actions related to this account
 func (ai *actionIterator) PopAccount() {
-	if len(ai.heads) != 0 {
-		heap.Pop(&ai.heads)
+	if heads := ai.topHeads(); heads.Len() != 0 {
+		heap.Pop(heads)
 	}
 }
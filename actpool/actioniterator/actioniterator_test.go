@@ -35,7 +35,7 @@ func TestActionIterator(t *testing.T) {
 	selp1, err := action.Sign(elp, priKeyA)
 	require.Nil(err)
 
-	tsf2, err := action.NewTransfer(uint64(2), big.NewInt(100), "2", nil, uint64(0), big.NewInt(30))
+	tsf2, err := action.NewTransfer(uint64(2), big.NewInt(100), c.String(), nil, uint64(0), big.NewInt(30))
 	require.Nil(err)
 	bd = &action.EnvelopeBuilder{}
 	elp = bd.SetNonce(2).
@@ -55,7 +55,7 @@ func TestActionIterator(t *testing.T) {
 	selp3, err := action.Sign(elp, priKeyB)
 	require.Nil(err)
 
-	tsf4, err := action.NewTransfer(uint64(2), big.NewInt(100), "3", nil, uint64(0), big.NewInt(10))
+	tsf4, err := action.NewTransfer(uint64(2), big.NewInt(100), c.String(), nil, uint64(0), big.NewInt(10))
 	require.NoError(err)
 	bd = &action.EnvelopeBuilder{}
 	elp = bd.SetNonce(2).
@@ -75,7 +75,7 @@ func TestActionIterator(t *testing.T) {
 
 	accMap[b.String()] = []action.SealedEnvelope{selp3, selp4, selp5}
 
-	tsf6, err := action.NewTransfer(uint64(1), big.NewInt(100), "1", nil, uint64(0), big.NewInt(5))
+	tsf6, err := action.NewTransfer(uint64(1), big.NewInt(100), a.String(), nil, uint64(0), big.NewInt(5))
 	require.NoError(err)
 	bd = &action.EnvelopeBuilder{}
 	elp = bd.SetNonce(1).
@@ -96,4 +96,21 @@ func TestActionIterator(t *testing.T) {
 		appliedActionList = append(appliedActionList, bestAction)
 	}
 	require.Equal(appliedActionList, []action.SealedEnvelope{selp3, selp1, selp2, selp4, selp5, selp6})
+
+	// the actions of the priority senders go first regardless of the gas price, still in nonce order
+	accMap = map[string][]action.SealedEnvelope{
+		a.String(): {selp1, selp2},
+		b.String(): {selp3, selp4, selp5},
+		c.String(): {selp6},
+	}
+	ai = NewPriorityActionIterator(accMap, []string{b.String(), c.String()})
+	appliedActionList = make([]action.SealedEnvelope, 0)
+	for {
+		bestAction, ok := ai.Next()
+		if !ok {
+			break
+		}
+		appliedActionList = append(appliedActionList, bestAction)
+	}
+	require.Equal(appliedActionList, []action.SealedEnvelope{selp3, selp4, selp5, selp6, selp1, selp2})
 }
@@ -0,0 +1,64 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/pkg/metrics"
+)
+
+var dropMtc = metrics.NewCounter(
+	"iotex_actpool_subscription_drops",
+	"Number of the actions accepted into the action pool but dropped as the subscribing channel is full.",
+)
+
+// ActionEventType is the type of a change to the pool
+type ActionEventType int
+
+const (
+	// ActionAdded indicates an action has been accepted into the pool
+	ActionAdded ActionEventType = iota
+	// ActionRemoved indicates an action has left the pool, either committed to a block or invalidated
+	ActionRemoved
+)
+
+// ActionEvent is a change of an action in the pool
+type ActionEvent struct {
+	Type   ActionEventType
+	Action action.SealedEnvelope
+}
+
+// ActionSubscriber is an interface which will get notified when an action is added to or removed from the pool. It is
+// called synchronously while the pool is locked, so it must neither block nor call back into the pool
+type ActionSubscriber interface {
+	HandleActionEvent(ActionEvent)
+}
+
+// ActionChan is an ActionSubscriber relaying each action accepted into the pool to a channel, so that the subscriber
+// reacts to it immediately rather than polling the pool. An action is dropped and counted in
+// iotex_actpool_subscription_drops rather than blocking the pool when the channel is full, so the capacity of the
+// channel bounds how far the subscriber could fall behind
+type ActionChan struct {
+	ch chan<- action.SealedEnvelope
+}
+
+// NewActionChan returns an ActionChan relaying the actions accepted into the pool to ch
+func NewActionChan(ch chan<- action.SealedEnvelope) *ActionChan {
+	return &ActionChan{ch: ch}
+}
+
+// HandleActionEvent relays the action added to the pool, or drops it if the channel is full
+func (c *ActionChan) HandleActionEvent(e ActionEvent) {
+	if e.Type != ActionAdded {
+		return
+	}
+	select {
+	case c.ch <- e.Action:
+	default:
+		dropMtc.WithLabelValues().Inc()
+	}
+}
@@ -8,8 +8,13 @@ package actpool
 
 import (
 	"context"
+	"math/big"
+	"sort"
 	"sync"
+	"time"
 
+	"github.com/facebookgo/clock"
+	"github.com/golang/protobuf/proto"
 	"github.com/iotexproject/iotex-core/pkg/prometheustimer"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -17,26 +22,102 @@ import (
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/actpool/actioniterator"
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/metrics"
+	"github.com/iotexproject/iotex-core/pkg/routine"
+	"github.com/iotexproject/iotex-core/pkg/unit"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+const (
+	// actionOverhead is the rough number of bytes an action takes in pool besides its serialized size, i.e., the
+	// decoded envelope and its entries in the indexes of the pool, the account queue and the gas price list
+	actionOverhead = 512
+	// accountOverhead is the rough number of bytes an account queue takes besides its actions
+	accountOverhead = 256
+)
+
+var (
+	// ErrDuplicate indicates the action is already in the pool
+	ErrDuplicate = errors.New("action already in pool")
+	// ErrPoolFull indicates the pool is holding as many actions, or as much gas, as its capacity
+	ErrPoolFull = errors.New("actpool is full")
+	// ErrTooManySenders indicates the pool is holding the actions of as many senders as it could
+	ErrTooManySenders = errors.New("too many senders in actpool")
+	// ErrNodeSyncing indicates the pool rejects the new actions until the node catches up with its peers
+	ErrNodeSyncing = errors.New("node is syncing")
+	// ErrNonceRaced indicates the pending nonce of the sender isn't the one expected, e.g., another action of the
+	// sender is added in between
+	ErrNonceRaced = errors.New("pending nonce raced")
+)
+
+var (
+	actionMtc = metrics.NewCounter(
+		"iotex_actpool_actions",
+		"Number of the actions offered to the action pool, by whether they're accepted or rejected.",
+		"result",
+	)
+	sizeMtc = metrics.NewGauge("iotex_actpool_size", "Number of the actions in the action pool.")
 )
 
 // ActPool is the interface of actpool
 type ActPool interface {
+	// Start takes the actions in the journal again if Journal is set, and starts sweeping the actions beyond
+	// ActionExpiry every SweepInterval in the background
+	Start(context.Context) error
+	// Stop stops the sweep, and rewrites the journal with the actions in pool once the queued writes are done
+	Stop(context.Context) error
 	// Reset resets actpool state
 	Reset()
-	// PendingActionMap returns an action map with all accepted actions
+	// PendingActionMap returns an action map with all accepted actions, up to the limits per block of the config
 	PendingActionMap() map[string][]action.SealedEnvelope
-	// Add adds an action into the pool after passing validation
+	// PendingActionMapWithLimit returns an action map with the accepted actions taken in the order they're picked into
+	// a block, which stops once maxTransfers transfers or maxVotes votes are taken. The actions of an account are
+	// always taken in the order of nonce without a gap. 0 means no limit
+	PendingActionMapWithLimit(maxTransfers, maxVotes int) map[string][]action.SealedEnvelope
+	// Add adds an action into the pool after passing validation. An action of the same sender and nonce as the one in
+	// pool replaces it if it offers a higher gas price. The error of a rejected action wraps the reason, which
+	// errors.Cause tells, e.g., ErrDuplicate, ErrPoolFull, action.ErrNonce, action.ErrBalance and action.ErrSignature
 	Add(act action.SealedEnvelope) error
-	// GetPendingNonce returns pending nonce in pool given an account address
+	// AddIfNonce adds an action like Add, only if the pending nonce of the sender is expectedPendingNonce, and
+	// returns ErrNonceRaced otherwise
+	AddIfNonce(act action.SealedEnvelope, expectedPendingNonce uint64) error
+	// AddActions adds a batch of actions like Add, with the signatures verified concurrently and the pool locked once,
+	// and returns the error of each action at the index of the action, so that a bad action doesn't fail the others
+	AddActions(acts []action.SealedEnvelope) []error
+	// GetPendingNonce returns the next nonce expected of the account address, i.e., following the actions of the
+	// account pending in pool, or the confirmed nonce + 1 if none is pending. It returns an error if the address is
+	// invalid
 	GetPendingNonce(addr string) (uint64, error)
 	// GetUnconfirmedActs returns unconfirmed actions in pool given an account address
 	GetUnconfirmedActs(addr string) []action.SealedEnvelope
+	// GetUnconfirmedTransfers returns the copies of the transfers in pool given a sender address in the order of
+	// nonce, including the ones waiting behind a nonce gap
+	GetUnconfirmedTransfers(addr string) []*action.Transfer
+	// GetUnconfirmedVotes returns the copies of the votes in pool given a voter address in the order of nonce,
+	// including the ones waiting behind a nonce gap
+	GetUnconfirmedVotes(addr string) []*action.Vote
+	// RemoveActs removes the actions from the pool, along with the actions of the same sender of the higher nonces,
+	// which would otherwise be left behind a nonce gap. The actions not in pool are ignored
+	RemoveActs(acts []action.SealedEnvelope)
+	// DropAccount removes all the actions of the account address from the pool
+	DropAccount(addr string)
 	// GetActionByHash returns the pending action in pool given action's hash
 	GetActionByHash(hash hash.Hash256) (action.SealedEnvelope, error)
+	// GetPendingByArrival returns the pending actions in pool in the order the pool received them
+	GetPendingByArrival() []ActionWithTime
+	// RecentEvictions returns the actions evicted from the pool before being committed within the last hour, up to
+	// the latest 1000, in the order of eviction
+	RecentEvictions() []EvictionRecord
+	// GetPickRank returns the 1-based position of the action in the current pick order and the number of pickable
+	// actions
+	GetPickRank(hash hash.Hash256) (int, int, error)
 	// GetSize returns the act pool size
 	GetSize() uint64
 	// GetCapacity returns the act pool capacity
@@ -45,10 +126,90 @@ type ActPool interface {
 	GetGasSize() uint64
 	// GetGasCapacity returns the act pool gas capacity
 	GetGasCapacity() uint64
+	// GasPricePercentiles returns the gas prices at GasPricePercentiles among the actions in pool
+	GasPricePercentiles() map[int]*big.Int
+	// SizeStats returns the total, the average and the max serialized size in bytes of the actions in pool
+	SizeStats() (totalBytes int, avgBytes int, maxBytes int)
+	// MemoryUsage returns the rough number of bytes the actions in pool take, including the indexes of them
+	MemoryUsage() int64
+	// Stats returns the numbers of the transfers, the votes and the accounts in pool, and the serialized size in bytes
+	// of the actions in pool, which are cheap to read periodically for monitoring
+	Stats() ActPoolStats
+	// SetLimits applies the capacities, the thresholds, the rate limit and the limits per block of cfg to the running
+	// pool, while the other values only take effect on restart
+	SetLimits(cfg config.ActPool)
 	// AddActionValidators add validators
 	AddActionValidators(...protocol.ActionValidator)
 
 	AddActionEnvelopeValidators(...protocol.ActionEnvelopeValidator)
+	// SetAdmissionHook sets the hook deciding whether to take an action which passes all the validations, and nil
+	// clears it
+	SetAdmissionHook(AdmissionHook)
+	// SetSyncing sets whether the node is syncing with its peers, during which the new actions are rejected if
+	// RejectDuringSync is set
+	SetSyncing(syncing bool)
+	// AddSubscriber makes the subscriber get notified of every action added to or removed from the pool
+	AddSubscriber(ActionSubscriber) error
+	// RemoveSubscriber stops notifying the subscriber
+	RemoveSubscriber(ActionSubscriber) error
+}
+
+// AdmissionHook applies custom rules to an action about to be added to the pool, e.g., rejecting the transfers over
+// an amount during a maintenance window. A non-nil error rejects the action, and is returned by Add as the reason.
+// The hook is called with the pool locked, so it must be fast, safe for concurrent use, and must not call the pool.
+type AdmissionHook func(act action.SealedEnvelope) error
+
+// EvictionReason is the reason an action is evicted from the pool
+type EvictionReason string
+
+const (
+	// EvictedExpired means the action, or one before it of the same sender, stayed in the pool longer than
+	// ActionExpiry
+	EvictedExpired EvictionReason = "expired"
+	// EvictedUnpayable means the pending balance of the sender no longer covers the action or one before it
+	EvictedUnpayable EvictionReason = "unpayable"
+	// EvictedRemoved means the action, or one before it of the same sender, is removed from the pool manually
+	EvictedRemoved EvictionReason = "removed"
+	// EvictedReplaced means the action is replaced by another action of the same sender and nonce offering a higher
+	// gas price
+	EvictedReplaced EvictionReason = "replaced"
+	// EvictedOutranked means the action is the least valuable one in the full pool, and is evicted to make room for a
+	// more valuable action
+	EvictedOutranked EvictionReason = "outranked"
+)
+
+const (
+	// evictionLogSize is the maximum number of the eviction records kept
+	evictionLogSize = 1000
+	// evictionLogWindow is how long an eviction record is kept
+	evictionLogWindow = time.Hour
+)
+
+// EvictionRecord is an action accepted into the pool but evicted before being committed
+type EvictionRecord struct {
+	Hash   hash.Hash256
+	Sender string
+	Nonce  uint64
+	Reason EvictionReason
+	Time   time.Time
+}
+
+// ActPoolStats is a snapshot of the actions in pool for monitoring
+type ActPoolStats struct {
+	// Transfers is the number of the transfers in pool
+	Transfers int
+	// Votes is the number of the votes in pool
+	Votes int
+	// Accounts is the number of the distinct senders having actions in pool
+	Accounts int
+	// Bytes is the total serialized size of the actions in pool
+	Bytes int
+}
+
+// ActionWithTime is an action in pool tagged with the time the pool received it
+type ActionWithTime struct {
+	Action  action.SealedEnvelope
+	Arrival time.Time
 }
 
 // Option sets action pool construction parameter
@@ -69,12 +230,41 @@ type actPool struct {
 	bc                        blockchain.Blockchain
 	accountActs               map[string]ActQueue
 	allActions                map[hash.Hash256]action.SealedEnvelope
+	arrivals                  map[hash.Hash256]arrival
+	numArrivals               uint64
 	gasInPool                 uint64
+	bytesInPool               int
+	numTransfers              int
+	numVotes                  int
+	gasPrices                 gasPriceList
 	actionEnvelopeValidators  []protocol.ActionEnvelopeValidator
 	validators                []protocol.ActionValidator
+	admissionHook             AdmissionHook
+	syncing                   bool
 	timerFactory              *prometheustimer.TimerFactory
 	enableExperimentalActions bool
 	senderBlackList           map[string]bool
+	senderRates               map[string]*senderRate
+	subscribers               []ActionSubscriber
+	evictions                 []EvictionRecord
+	clock                     clock.Clock
+	sweepTask                 *routine.RecurringTask
+	journal                   *journal
+}
+
+// arrival records when an action arrived at the pool, and its sequence number among the arrivals to break the ties of
+// the arrival times, along with its serialized size
+type arrival struct {
+	act  action.SealedEnvelope
+	time time.Time
+	seq  uint64
+	size int
+}
+
+// senderRate counts the actions a sender has added to the pool since the start of the current window
+type senderRate struct {
+	start time.Time
+	count uint64
 }
 
 // NewActPool constructs a new actpool
@@ -82,6 +272,9 @@ func NewActPool(bc blockchain.Blockchain, cfg config.ActPool, opts ...Option) (A
 	if bc == nil {
 		return nil, errors.New("Try to attach a nil blockchain")
 	}
+	if err := metrics.Register(actionMtc, sizeMtc, dropMtc); err != nil {
+		return nil, err
+	}
 
 	senderBlackList := make(map[string]bool)
 	for _, bannedSender := range cfg.BlackList {
@@ -92,8 +285,14 @@ func NewActPool(bc blockchain.Blockchain, cfg config.ActPool, opts ...Option) (A
 		cfg:             cfg,
 		bc:              bc,
 		senderBlackList: senderBlackList,
+		senderRates:     make(map[string]*senderRate),
 		accountActs:     make(map[string]ActQueue),
 		allActions:      make(map[hash.Hash256]action.SealedEnvelope),
+		arrivals:        make(map[hash.Hash256]arrival),
+		clock:           clock.New(),
+	}
+	if cfg.Journal != "" {
+		ap.journal = newJournal(cfg.Journal)
 	}
 	for _, opt := range opts {
 		if err := opt(ap); err != nil {
@@ -113,6 +312,78 @@ func NewActPool(bc blockchain.Blockchain, cfg config.ActPool, opts ...Option) (A
 	return ap, nil
 }
 
+// Start replays the journal, and starts the sweep, if both ActionExpiry and SweepInterval are set
+func (ap *actPool) Start(ctx context.Context) error {
+	if ap.journal != nil {
+		if err := ap.replayJournal(); err != nil {
+			return err
+		}
+	}
+	if ap.cfg.ActionExpiry == 0 || ap.cfg.SweepInterval == 0 {
+		return nil
+	}
+	ap.sweepTask = routine.NewRecurringTask(ap.sweep, ap.cfg.SweepInterval, routine.WithClock(ap.clock))
+	return ap.sweepTask.Start(ctx)
+}
+
+// Stop stops the sweep, and rewrites the journal with the actions in pool, leaving out the ones no longer in pool
+func (ap *actPool) Stop(ctx context.Context) error {
+	if ap.sweepTask != nil {
+		if err := ap.sweepTask.Stop(ctx); err != nil {
+			return err
+		}
+	}
+	if ap.journal == nil {
+		return nil
+	}
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+	ap.journal.stop()
+	if err := ap.journal.rotate(ap.actsByArrival()); err != nil {
+		return err
+	}
+	return ap.journal.close()
+}
+
+// replayJournal adds the actions in the journal to the pool, which validates them against the current state, so
+// that the ones committed while the node is down are left out, and rewrites the journal with the ones taken
+func (ap *actPool) replayJournal() error {
+	acts, err := ap.journal.load()
+	if err != nil {
+		return err
+	}
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+	taken := 0
+	for _, act := range acts {
+		if err := ap.add(act, false); err != nil {
+			log.L().Debug("Skipped the action in actpool journal.", append(act.LogFields(), zap.Error(err))...)
+			continue
+		}
+		taken++
+	}
+	sizeMtc.WithLabelValues().Set(float64(len(ap.allActions)))
+	log.L().Info("Replayed actpool journal.",
+		zap.String("journal", ap.cfg.Journal),
+		zap.Int("actions", len(acts)),
+		zap.Int("taken", taken))
+	if err := ap.journal.rotate(ap.actsByArrival()); err != nil {
+		return err
+	}
+	ap.journal.records = len(ap.allActions)
+	ap.journal.start()
+	return nil
+}
+
+// rotateJournal rewrites the journal with the actions in pool, if it has grown well beyond them as the actions
+// left the pool, e.g., committed, replaced, or evicted
+func (ap *actPool) rotateJournal() {
+	if ap.journal == nil {
+		return
+	}
+	ap.journal.queueRotate(len(ap.allActions), ap.actsByArrival)
+}
+
 // AddActionValidators add validators
 func (ap *actPool) AddActionValidators(validators ...protocol.ActionValidator) {
 	ap.validators = append(ap.validators, validators...)
@@ -122,6 +393,20 @@ func (ap *actPool) AddActionEnvelopeValidators(fs ...protocol.ActionEnvelopeVali
 	ap.actionEnvelopeValidators = append(ap.actionEnvelopeValidators, fs...)
 }
 
+// SetAdmissionHook sets the hook deciding whether to take an action which passes all the validations
+func (ap *actPool) SetAdmissionHook(hook AdmissionHook) {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+	ap.admissionHook = hook
+}
+
+// SetSyncing sets whether the node is syncing with its peers
+func (ap *actPool) SetSyncing(syncing bool) {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+	ap.syncing = syncing
+}
+
 // Reset resets actpool state
 // Step I: remove all the actions in actpool that have already been committed to block
 // Step II: update pending balance of each account if it still exists in pool
@@ -137,8 +422,17 @@ func (ap *actPool) Reset() {
 	ap.reset()
 }
 
-// PendingActionIterator returns an action interator with all accepted actions
+// PendingActionMap returns an action map with all accepted actions, up to the limits per block of the config
 func (ap *actPool) PendingActionMap() map[string][]action.SealedEnvelope {
+	ap.mutex.RLock()
+	maxTransfers, maxVotes := int(ap.cfg.MaxTransfersPerBlock), int(ap.cfg.MaxVotesPerBlock)
+	ap.mutex.RUnlock()
+	return ap.PendingActionMapWithLimit(maxTransfers, maxVotes)
+}
+
+// PendingActionMapWithLimit returns an action map with the accepted actions, up to maxTransfers transfers and maxVotes
+// votes
+func (ap *actPool) PendingActionMapWithLimit(maxTransfers, maxVotes int) map[string][]action.SealedEnvelope {
 	ap.mutex.Lock()
 	defer ap.mutex.Unlock()
 
@@ -149,15 +443,94 @@ func (ap *actPool) PendingActionMap() map[string][]action.SealedEnvelope {
 	for from, queue := range ap.accountActs {
 		actionMap[from] = append(actionMap[from], queue.PendingActs()...)
 	}
-	return actionMap
+	if maxTransfers <= 0 && maxVotes <= 0 {
+		return actionMap
+	}
+	// the actions are taken in the order the block producer picks them, which never skips a nonce of an account
+	limited := make(map[string][]action.SealedEnvelope)
+	iter := ap.newActionIterator(actionMap)
+	var transfers, votes int
+	for {
+		act, ok := iter.Next()
+		if !ok {
+			break
+		}
+		caller, err := addrutil.PubKeyToAddress(act.SrcPubkey())
+		if err != nil {
+			break
+		}
+		limited[caller.String()] = append(limited[caller.String()], act)
+		switch act.Action().(type) {
+		case *action.Transfer:
+			transfers++
+		case *action.Vote:
+			votes++
+		}
+		if (maxTransfers > 0 && transfers >= maxTransfers) || (maxVotes > 0 && votes >= maxVotes) {
+			break
+		}
+	}
+	return limited
+}
+
+func (ap *actPool) Add(act action.SealedEnvelope) (err error) {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+	defer func() { ap.observeAdd(act, err) }()
+	return ap.add(act, false)
+}
+
+// AddActions verifies the signatures of the actions concurrently, and adds the ones of valid signatures like Add, with
+// the pool locked once for the whole batch. It returns the error of each action at the index of the action
+func (ap *actPool) AddActions(acts []action.SealedEnvelope) []error {
+	errs := action.VerifyActions(acts)
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+	for i, act := range acts {
+		if errs[i] != nil {
+			errs[i] = invalidActionError(errors.Wrap(errs[i], "failed to verify action signature"), act.Hash())
+		} else {
+			errs[i] = ap.add(act, true)
+		}
+		ap.observeAdd(act, errs[i])
+	}
+	return errs
 }
 
-func (ap *actPool) Add(act action.SealedEnvelope) error {
+// AddIfNonce checks the pending nonce of the sender and adds the action with the pool locked, so that no other
+// action of the sender is added in between
+func (ap *actPool) AddIfNonce(act action.SealedEnvelope, expectedPendingNonce uint64) (err error) {
 	ap.mutex.Lock()
 	defer ap.mutex.Unlock()
+	defer func() { ap.observeAdd(act, err) }()
+	caller, err := addrutil.PubKeyToAddress(act.SrcPubkey())
+	if err != nil {
+		return err
+	}
+	pendingNonce, err := ap.pendingNonce(caller.String())
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the pending nonce of %s", caller.String())
+	}
+	if pendingNonce != expectedPendingNonce {
+		return errors.Wrapf(
+			ErrNonceRaced,
+			"pending nonce of %s is %d rather than %d",
+			caller.String(),
+			pendingNonce,
+			expectedPendingNonce,
+		)
+	}
+	return ap.add(act, false)
+}
+
+// add adds the action with the pool locked, skipping the signature verification if verified
+func (ap *actPool) add(act action.SealedEnvelope, verified bool) error {
 	if !ap.enableExperimentalActions && action.IsExperimentalAction(act.Action()) {
 		return errors.New("Experimental action is not enabled")
 	}
+	if ap.cfg.RejectDuringSync && ap.syncing {
+		return ErrNodeSyncing
+	}
 	// Reject action if action source address is blacklisted
 	pubKeyHash := act.SrcPubkey().Hash()
 	srcAddr, err := address.FromBytes(pubKeyHash)
@@ -167,21 +540,44 @@ func (ap *actPool) Add(act action.SealedEnvelope) error {
 	if _, ok := ap.senderBlackList[srcAddr.String()]; ok {
 		return errors.Wrap(action.ErrAddress, "action source address is blacklisted")
 	}
-	// Reject action if pool space is full
-	if uint64(len(ap.allActions)) >= ap.cfg.MaxNumActsPerPool {
-		return errors.Wrap(action.ErrActPool, "insufficient space for action")
+	// An action replacing the pending one of the same nonce, e.g., a fee bump, doesn't take more space in pool, and only
+	// takes the gas it adds to the one replaced
+	var replaced action.SealedEnvelope
+	replacing := false
+	if queue, ok := ap.accountActs[srcAddr.String()]; ok {
+		replaced, replacing = queue.Get(act.Nonce())
+	}
+	// Reject action if pool space is full, unless it outranks the least valuable action in pool, which is evicted to
+	// make room for it once it's taken
+	var victim string
+	if !replacing && uint64(len(ap.allActions)) >= ap.cfg.MaxNumActsPerPool {
+		if victim = ap.evictionVictim(srcAddr.String(), act); victim == "" {
+			return errors.Wrap(ErrPoolFull, "insufficient space for action")
+		}
 	}
 	intrinsicGas, err := act.IntrinsicGas()
 	if err != nil {
 		return errors.Wrap(err, "failed to get action's intrinsic gas")
 	}
-	if ap.gasInPool+intrinsicGas > ap.cfg.MaxGasLimitPerPool {
-		return errors.Wrap(action.ErrActPool, "insufficient gas space for action")
+	addedGas := intrinsicGas
+	if replacing {
+		replacedGas, err := replaced.IntrinsicGas()
+		if err != nil {
+			return errors.Wrap(err, "failed to get the intrinsic gas of the action replaced")
+		}
+		if replacedGas >= intrinsicGas {
+			addedGas = 0
+		} else {
+			addedGas -= replacedGas
+		}
+	}
+	if ap.gasInPool+addedGas > ap.cfg.MaxGasLimitPerPool {
+		return errors.Wrap(ErrPoolFull, "insufficient gas space for action")
 	}
 	hash := act.Hash()
 	// Reject action if it already exists in pool
 	if _, exist := ap.allActions[hash]; exist {
-		return errors.Errorf("reject existed action: %x", hash)
+		return errors.Wrapf(ErrDuplicate, "reject existed action: %x", hash)
 	}
 	// Reject action if the gas price is lower than the threshold
 	if act.GasPrice().Cmp(ap.cfg.MinGasPrice()) < 0 {
@@ -191,21 +587,50 @@ func (ap *actPool) Add(act action.SealedEnvelope) error {
 			act.GasPrice(),
 		)
 	}
+	// Reject transfer if the amount is lower than the threshold
+	if tsf, ok := act.Action().(*action.Transfer); ok && tsf.Amount().Cmp(ap.cfg.MinTransferAmount()) < 0 {
+		return errors.Errorf(
+			"reject the transfer %x whose amount %s is lower than minimal transfer amount threshold",
+			hash,
+			tsf.Amount(),
+		)
+	}
+	// Reject transfer if the payload is larger than the threshold
+	if tsf, ok := act.Action().(*action.Transfer); ok && ap.cfg.MaxTransferPayloadSize > 0 &&
+		unit.ByteSize(len(tsf.Payload())) > ap.cfg.MaxTransferPayloadSize {
+		return errors.Wrapf(
+			action.ErrActPool,
+			"reject the transfer %x whose payload of %d bytes is larger than %d bytes",
+			hash,
+			len(tsf.Payload()),
+			ap.cfg.MaxTransferPayloadSize,
+		)
+	}
 
-	caller, err := address.FromBytes(act.SrcPubkey().Hash())
+	caller, err := addrutil.PubKeyToAddress(act.SrcPubkey())
 	if err != nil {
 		return err
 	}
+	// Reject action of a new sender if the pool is holding the actions of too many senders
+	if _, ok := ap.accountActs[caller.String()]; !ok && ap.cfg.MaxSenders > 0 &&
+		uint64(len(ap.accountActs)) >= ap.cfg.MaxSenders {
+		return errors.Wrapf(ErrTooManySenders, "cannot take the action %x of new sender %s", hash, caller.String())
+	}
+	// Reject action if the sender has added too many actions recently
+	if ap.exceedsRateLimit(caller.String()) {
+		return errors.Wrapf(action.ErrActPool, "sender %s exceeds the rate limit", caller.String())
+	}
 	// envelope validation
 	for _, validator := range ap.actionEnvelopeValidators {
 		ctx := protocol.WithValidateActionsCtx(
 			context.Background(),
 			protocol.ValidateActionsCtx{
-				Caller: caller,
+				Caller:            caller,
+				SignatureVerified: verified,
 			},
 		)
 		if err := validator.Validate(ctx, act); err != nil {
-			return errors.Wrapf(err, "reject invalid action: %x", hash)
+			return invalidActionError(err, hash)
 		}
 	}
 	// Reject action if it's invalid
@@ -217,17 +642,35 @@ func (ap *actPool) Add(act action.SealedEnvelope) error {
 			},
 		)
 		if err := validator.Validate(ctx, act.Action()); err != nil {
-			return errors.Wrapf(err, "reject invalid action: %x", hash)
+			return invalidActionError(err, hash)
+		}
+	}
+	// Reject action if the custom rules of the admission hook refuse it
+	if ap.admissionHook != nil {
+		if err := ap.admissionHook(act); err != nil {
+			return errors.Wrapf(err, "action %x is rejected by the admission hook", hash)
 		}
 	}
-	return ap.enqueueAction(caller.String(), act, hash, act.Nonce())
+	if err := ap.enqueueAction(caller.String(), act, hash, act.Nonce()); err != nil {
+		return err
+	}
+	if victim != "" && uint64(len(ap.allActions)) > ap.cfg.MaxNumActsPerPool {
+		ap.evictTail(victim)
+	}
+	return nil
 }
 
-// GetPendingNonce returns pending nonce in pool or confirmed nonce given an account address
+// GetPendingNonce returns pending nonce in pool or confirmed nonce + 1 given an account address
 func (ap *actPool) GetPendingNonce(addr string) (uint64, error) {
+	if err := addrutil.Validate(addr); err != nil {
+		return 0, errors.Wrapf(err, "failed to get the pending nonce of %s", addr)
+	}
 	ap.mutex.RLock()
 	defer ap.mutex.RUnlock()
+	return ap.pendingNonce(addr)
+}
 
+func (ap *actPool) pendingNonce(addr string) (uint64, error) {
 	if queue, ok := ap.accountActs[addr]; ok {
 		return queue.PendingNonce(), nil
 	}
@@ -247,6 +690,47 @@ func (ap *actPool) GetUnconfirmedActs(addr string) []action.SealedEnvelope {
 	return make([]action.SealedEnvelope, 0)
 }
 
+// GetUnconfirmedTransfers returns the copies of the transfers in pool given a sender address in the order of nonce,
+// including the ones waiting behind a nonce gap
+func (ap *actPool) GetUnconfirmedTransfers(addr string) []*action.Transfer {
+	tsfs := make([]*action.Transfer, 0)
+	for _, act := range ap.GetUnconfirmedActs(addr) {
+		if _, ok := act.Action().(*action.Transfer); !ok {
+			continue
+		}
+		if copied, ok := copyAction(act); ok {
+			tsfs = append(tsfs, copied.Action().(*action.Transfer))
+		}
+	}
+	return tsfs
+}
+
+// GetUnconfirmedVotes returns the copies of the votes in pool given a voter address in the order of nonce, including
+// the ones waiting behind a nonce gap
+func (ap *actPool) GetUnconfirmedVotes(addr string) []*action.Vote {
+	votes := make([]*action.Vote, 0)
+	for _, act := range ap.GetUnconfirmedActs(addr) {
+		if _, ok := act.Action().(*action.Vote); !ok {
+			continue
+		}
+		if copied, ok := copyAction(act); ok {
+			votes = append(votes, copied.Action().(*action.Vote))
+		}
+	}
+	return votes
+}
+
+// copyAction returns a deep copy of the action rebuilt from its proto, which shares neither the amounts, the payload
+// nor the public key with the one in pool
+func copyAction(act action.SealedEnvelope) (action.SealedEnvelope, bool) {
+	var copied action.SealedEnvelope
+	if err := copied.LoadProto(proto.Clone(act.Proto()).(*iotextypes.Action)); err != nil {
+		log.L().Error("Failed to copy the action in pool.", append(act.LogFields(), zap.Error(err))...)
+		return copied, false
+	}
+	return copied, true
+}
+
 // GetActionByHash returns the pending action in pool given action's hash
 func (ap *actPool) GetActionByHash(hash hash.Hash256) (action.SealedEnvelope, error) {
 	ap.mutex.RLock()
@@ -259,6 +743,146 @@ func (ap *actPool) GetActionByHash(hash hash.Hash256) (action.SealedEnvelope, er
 	return act, nil
 }
 
+// actsByArrival returns all the actions in pool in the order the pool received them
+func (ap *actPool) actsByArrival() []action.SealedEnvelope {
+	arrived := make([]arrival, 0, len(ap.allActions))
+	for hash, act := range ap.allActions {
+		a := ap.arrivals[hash]
+		a.act = act
+		arrived = append(arrived, a)
+	}
+	sort.Slice(arrived, func(i, j int) bool { return arrived[i].seq < arrived[j].seq })
+	acts := make([]action.SealedEnvelope, 0, len(arrived))
+	for _, a := range arrived {
+		acts = append(acts, a.act)
+	}
+	return acts
+}
+
+// newActionIterator returns the iterator picking the actions of actionMap in the order of the selection policy
+func (ap *actPool) newActionIterator(actionMap map[string][]action.SealedEnvelope) actioniterator.ActionIterator {
+	if ap.cfg.Selection != config.FIFOSelection {
+		return actioniterator.NewPriorityActionIterator(actionMap, ap.cfg.PrioritySenders)
+	}
+	// the actions are told apart by the signatures, which are much cheaper than the hashes to compare over and over
+	seqs := make(map[string]uint64)
+	for _, acts := range actionMap {
+		for _, act := range acts {
+			seqs[string(act.Signature())] = ap.arrivals[act.Hash()].seq
+		}
+	}
+	return actioniterator.NewOrderedActionIterator(actionMap, ap.cfg.PrioritySenders,
+		func(a, b action.SealedEnvelope) bool {
+			return seqs[string(a.Signature())] < seqs[string(b.Signature())]
+		})
+}
+
+// GetPendingByArrival returns the pending actions in pool, i.e., the ones PendingActionMap returns, in the order the
+// pool received them, each tagged with its arrival time
+func (ap *actPool) GetPendingByArrival() []ActionWithTime {
+	ap.mutex.RLock()
+	defer ap.mutex.RUnlock()
+
+	var arrived []arrival
+	for _, queue := range ap.accountActs {
+		for _, act := range queue.PendingActs() {
+			a := ap.arrivals[act.Hash()]
+			a.act = act
+			arrived = append(arrived, a)
+		}
+	}
+	sort.Slice(arrived, func(i, j int) bool { return arrived[i].seq < arrived[j].seq })
+	pending := make([]ActionWithTime, 0, len(arrived))
+	for _, a := range arrived {
+		pending = append(pending, ActionWithTime{Action: a.act, Arrival: a.time})
+	}
+	return pending
+}
+
+// RemoveActs removes the actions and the ones of the higher nonces of the same senders from the pool
+func (ap *actPool) RemoveActs(acts []action.SealedEnvelope) {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+
+	for _, act := range acts {
+		if _, ok := ap.allActions[act.Hash()]; !ok {
+			continue
+		}
+		caller, err := addrutil.PubKeyToAddress(act.SrcPubkey())
+		if err != nil {
+			continue
+		}
+		sender := caller.String()
+		queue, ok := ap.accountActs[sender]
+		if !ok {
+			continue
+		}
+		removed := queue.RemoveFrom(act.Nonce())
+		ap.removeInvalidActs(removed)
+		ap.recordEvictions(sender, removed, EvictedRemoved)
+		if queue.Empty() {
+			delete(ap.accountActs, sender)
+		}
+	}
+	sizeMtc.WithLabelValues().Set(float64(len(ap.allActions)))
+}
+
+// DropAccount removes the whole queue of the account from the pool
+func (ap *actPool) DropAccount(addr string) {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+
+	queue, ok := ap.accountActs[addr]
+	if !ok {
+		return
+	}
+	removed := queue.AllActs()
+	ap.removeInvalidActs(removed)
+	ap.recordEvictions(addr, removed, EvictedRemoved)
+	delete(ap.accountActs, addr)
+	sizeMtc.WithLabelValues().Set(float64(len(ap.allActions)))
+}
+
+// RecentEvictions returns the actions evicted within the last hour, up to the latest 1000, in the order of eviction
+func (ap *actPool) RecentEvictions() []EvictionRecord {
+	ap.mutex.RLock()
+	defer ap.mutex.RUnlock()
+
+	since := ap.clock.Now().Add(-evictionLogWindow)
+	i := sort.Search(len(ap.evictions), func(i int) bool { return ap.evictions[i].Time.After(since) })
+	return append([]EvictionRecord{}, ap.evictions[i:]...)
+}
+
+// GetPickRank returns the 1-based position of the action in the current pick order and the number of pickable
+// actions. The pick order is the same one block producers follow: the pending actions of each account in nonce order,
+// interleaved across accounts by the selection policy, with the ones of the priority senders ahead
+func (ap *actPool) GetPickRank(hash hash.Hash256) (int, int, error) {
+	ap.mutex.RLock()
+	defer ap.mutex.RUnlock()
+
+	if _, ok := ap.allActions[hash]; !ok {
+		return 0, 0, errors.Wrapf(action.ErrHash, "action hash %x does not exist in pool", hash)
+	}
+	actionMap := make(map[string][]action.SealedEnvelope)
+	total := 0
+	for from, queue := range ap.accountActs {
+		pending := queue.PendingActs()
+		actionMap[from] = pending
+		total += len(pending)
+	}
+	iter := ap.newActionIterator(actionMap)
+	for rank := 1; ; rank++ {
+		act, ok := iter.Next()
+		if !ok {
+			break
+		}
+		if act.Hash() == hash {
+			return rank, total, nil
+		}
+	}
+	return 0, total, errors.Wrapf(action.ErrNonce, "action %x is not pickable until its nonce gap is filled", hash)
+}
+
 // GetSize returns the act pool size
 func (ap *actPool) GetSize() uint64 {
 	ap.mutex.RLock()
@@ -269,6 +893,9 @@ func (ap *actPool) GetSize() uint64 {
 
 // GetCapacity returns the act pool capacity
 func (ap *actPool) GetCapacity() uint64 {
+	ap.mutex.RLock()
+	defer ap.mutex.RUnlock()
+
 	return ap.cfg.MaxNumActsPerPool
 }
 
@@ -282,9 +909,115 @@ func (ap *actPool) GetGasSize() uint64 {
 
 // GetGasCapacity returns the act pool gas capacity
 func (ap *actPool) GetGasCapacity() uint64 {
+	ap.mutex.RLock()
+	defer ap.mutex.RUnlock()
+
 	return ap.cfg.MaxGasLimitPerPool
 }
 
+// SetLimits applies the capacities, the thresholds, the rate limit and the limits per block of cfg to the running pool.
+// The actions already in pool are kept even if they are beyond the new limits.
+func (ap *actPool) SetLimits(cfg config.ActPool) {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+
+	ap.cfg.MaxNumActsPerPool = cfg.MaxNumActsPerPool
+	ap.cfg.MaxGasLimitPerPool = cfg.MaxGasLimitPerPool
+	ap.cfg.MaxNumActsPerAcct = cfg.MaxNumActsPerAcct
+	ap.cfg.MinGasPriceStr = cfg.MinGasPriceStr
+	ap.cfg.MinTransferAmountStr = cfg.MinTransferAmountStr
+	ap.cfg.MaxTransferPayloadSize = cfg.MaxTransferPayloadSize
+	ap.cfg.MaxNonceGap = cfg.MaxNonceGap
+	ap.cfg.SenderRateLimit = cfg.SenderRateLimit
+	ap.cfg.SenderRateWindow = cfg.SenderRateWindow
+	ap.cfg.MaxSenders = cfg.MaxSenders
+	ap.cfg.MaxTransfersPerBlock = cfg.MaxTransfersPerBlock
+	ap.cfg.MaxVotesPerBlock = cfg.MaxVotesPerBlock
+}
+
+// GasPricePercentiles returns the gas prices at GasPricePercentiles among the actions in pool, which wallets could
+// suggest as the gas price of a new action. An empty map is returned if the pool is empty.
+func (ap *actPool) GasPricePercentiles() map[int]*big.Int {
+	ap.mutex.RLock()
+	defer ap.mutex.RUnlock()
+
+	percentiles := make(map[int]*big.Int)
+	if len(ap.gasPrices) == 0 {
+		return percentiles
+	}
+	for _, p := range GasPricePercentiles {
+		percentiles[p] = ap.gasPrices.percentile(p)
+	}
+	return percentiles
+}
+
+// SizeStats returns the total, the average and the max serialized size in bytes of the actions in pool, which are
+// measured once the actions are added. All are 0 if the pool is empty.
+func (ap *actPool) SizeStats() (int, int, int) {
+	ap.mutex.RLock()
+	defer ap.mutex.RUnlock()
+
+	if len(ap.arrivals) == 0 {
+		return 0, 0, 0
+	}
+	maxBytes := 0
+	for _, a := range ap.arrivals {
+		if a.size > maxBytes {
+			maxBytes = a.size
+		}
+	}
+	return ap.bytesInPool, ap.bytesInPool / len(ap.arrivals), maxBytes
+}
+
+// MemoryUsage estimates the memory the actions in pool take by their serialized sizes, plus a fixed overhead per
+// action and per account
+func (ap *actPool) MemoryUsage() int64 {
+	ap.mutex.RLock()
+	defer ap.mutex.RUnlock()
+
+	return int64(ap.bytesInPool) + int64(len(ap.arrivals))*actionOverhead + int64(len(ap.accountActs))*accountOverhead
+}
+
+// Stats returns the numbers of the transfers, the votes and the accounts, and the serialized size of the actions in
+// pool, all of which are kept up to date as the actions are added and removed
+func (ap *actPool) Stats() ActPoolStats {
+	ap.mutex.RLock()
+	defer ap.mutex.RUnlock()
+
+	return ActPoolStats{
+		Transfers: ap.numTransfers,
+		Votes:     ap.numVotes,
+		Accounts:  len(ap.accountActs),
+		Bytes:     ap.bytesInPool,
+	}
+}
+
+// AddSubscriber makes the subscriber get notified of every action added to or removed from the pool
+func (ap *actPool) AddSubscriber(s ActionSubscriber) error {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+
+	if s == nil {
+		return errors.New("subscriber could not be nil")
+	}
+	ap.subscribers = append(ap.subscribers, s)
+	return nil
+}
+
+// RemoveSubscriber stops notifying the subscriber
+func (ap *actPool) RemoveSubscriber(s ActionSubscriber) error {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+
+	for i, sub := range ap.subscribers {
+		if sub == s {
+			ap.subscribers = append(ap.subscribers[:i], ap.subscribers[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("cannot find subscription")
+}
+
 //======================================
 // private functions
 //======================================
@@ -296,7 +1029,7 @@ func (ap *actPool) enqueueAction(sender string, act action.SealedEnvelope, hash
 
 	queue := ap.accountActs[sender]
 	if queue == nil {
-		queue = NewActQueue(ap, sender, WithTimeOut(ap.cfg.ActionExpiry))
+		queue = NewActQueue(ap, sender, WithTimeOut(ap.cfg.ActionExpiry), WithClock(ap.clock))
 		ap.accountActs[sender] = queue
 
 		// Initialize pending nonce for new account
@@ -311,15 +1044,15 @@ func (ap *actPool) enqueueAction(sender string, act action.SealedEnvelope, hash
 	}
 	if queue.Overlaps(act) {
 		// Nonce already exists
-		return errors.Wrapf(action.ErrNonce, "duplicate nonce for action %x", hash)
+		return ap.replaceAction(sender, queue, act, hash, confirmedNonce)
 	}
 
-	if actNonce-confirmedNonce-1 >= ap.cfg.MaxNumActsPerAcct {
+	if actNonce-confirmedNonce-1 >= ap.cfg.NonceGap() {
 		// Nonce exceeds current range
-		log.L().Debug("Rejecting action because nonce is too large.",
-			log.Hex("hash", hash[:]),
-			zap.Uint64("startNonce", confirmedNonce+1),
-			zap.Uint64("actNonce", actNonce))
+		log.Logger("actpool").Debug("Rejecting action because nonce is too large.",
+			append(act.LogFields(),
+				zap.Uint64("startNonce", confirmedNonce+1),
+				zap.Uint64("actNonce", actNonce))...)
 		return errors.Wrapf(action.ErrNonce, "nonce too large")
 	}
 
@@ -341,11 +1074,9 @@ func (ap *actPool) enqueueAction(sender string, act action.SealedEnvelope, hash
 	if err := queue.Put(act); err != nil {
 		return errors.Wrapf(err, "cannot put action %x into ActQueue", hash)
 	}
-	ap.allActions[hash] = act
-
-	intrinsicGas, _ := act.IntrinsicGas()
-	ap.gasInPool += intrinsicGas
-	// If the pending nonce equals this nonce, update queue
+	ap.trackAction(sender, act, hash)
+	// If the action fills the gap at the pending nonce, the actions of the nonces following it become pending at once,
+	// rather than on the next reset
 	nonce := queue.PendingNonce()
 	if actNonce == nonce {
 		ap.updateAccount(sender)
@@ -353,16 +1084,102 @@ func (ap *actPool) enqueueAction(sender string, act action.SealedEnvelope, hash
 	return nil
 }
 
+// replaceAction replaces the action of the same nonce in the queue with act, if act offers a higher gas price and the
+// sender could pay for it instead. The pending actions of the sender are evaluated again since, e.g., act may cost
+// more than the one replaced, which leaves the actions after it unpayable
+func (ap *actPool) replaceAction(
+	sender string,
+	queue ActQueue,
+	act action.SealedEnvelope,
+	hash hash.Hash256,
+	confirmedNonce uint64,
+) error {
+	old, _ := queue.Get(act.Nonce())
+	if act.GasPrice().Cmp(old.GasPrice()) <= 0 {
+		return errors.Wrapf(
+			action.ErrNonce,
+			"duplicate nonce for action %x, whose gas price %s isn't higher than %s of the pending one",
+			hash,
+			act.GasPrice(),
+			old.GasPrice(),
+		)
+	}
+	cost, err := act.Cost()
+	if err != nil {
+		return errors.Wrapf(err, "failed to get cost of action %x", hash)
+	}
+	balance, err := ap.bc.Balance(sender)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get sender's balance for action %x", hash)
+	}
+	// a pending action replaced leaves the balance after the pending actions before it, while the others are only
+	// payable after the pending ones
+	available := new(big.Int).Set(queue.PendingBalance())
+	if act.Nonce() < queue.PendingNonce() {
+		available.Set(balance)
+		for nonce := confirmedNonce + 1; nonce < act.Nonce(); nonce++ {
+			prev, ok := queue.Get(nonce)
+			if !ok {
+				continue
+			}
+			prevCost, err := prev.Cost()
+			if err != nil {
+				return errors.Wrapf(err, "failed to get cost of action %x", prev.Hash())
+			}
+			available.Sub(available, prevCost)
+		}
+	}
+	if available.Cmp(cost) < 0 {
+		return errors.Wrapf(
+			action.ErrBalance,
+			"insufficient balance for action %x replacing %x, cost = %s, available balance = %s",
+			hash,
+			old.Hash(),
+			cost.String(),
+			available.String(),
+		)
+	}
+
+	replaced := []action.SealedEnvelope{queue.Replace(act)}
+	ap.removeInvalidActs(replaced)
+	ap.recordEvictions(sender, replaced, EvictedReplaced)
+	ap.trackAction(sender, act, hash)
+	queue.SetPendingBalance(balance)
+	queue.SetPendingNonce(confirmedNonce + 1)
+	ap.updateAccount(sender)
+	return nil
+}
+
+// trackAction counts the action put into the queue of the sender in the pool
+func (ap *actPool) trackAction(sender string, act action.SealedEnvelope, hash hash.Hash256) {
+	ap.allActions[hash] = act
+	ap.countActionType(act, 1)
+	ap.numArrivals++
+	size := proto.Size(act.Proto())
+	ap.arrivals[hash] = arrival{time: ap.clock.Now(), seq: ap.numArrivals, size: size}
+	ap.bytesInPool += size
+	ap.countSenderRate(sender)
+	ap.emitToSubscribers(ActionEvent{Type: ActionAdded, Action: act})
+
+	intrinsicGas, _ := act.IntrinsicGas()
+	ap.gasInPool += intrinsicGas
+	ap.gasPrices.add(act.GasPrice())
+	if ap.journal != nil {
+		ap.journal.queueAppend(act)
+	}
+}
+
 // removeConfirmedActs removes processed (committed to block) actions from pool
 func (ap *actPool) removeConfirmedActs() {
 	for from, queue := range ap.accountActs {
 		confirmedNonce, err := ap.bc.Nonce(from)
 		if err != nil {
-			log.L().Error("Error when removing confirmed actions", zap.Error(err))
-			return
+			log.Logger("actpool").Error("Error when removing confirmed actions",
+				log.SenderField(from), zap.Error(err))
+			continue
 		}
 		pendingNonce := confirmedNonce + 1
-		// Remove all actions that are committed to new block
+		// Remove all actions whose nonces are used, by themselves or by the conflicting actions committed instead
 		acts := queue.FilterNonce(pendingNonce)
 		ap.removeInvalidActs(acts)
 
@@ -376,19 +1193,139 @@ func (ap *actPool) removeConfirmedActs() {
 func (ap *actPool) removeInvalidActs(acts []action.SealedEnvelope) {
 	for _, act := range acts {
 		hash := act.Hash()
-		log.L().Debug("Removed invalidated action.", log.Hex("hash", hash[:]))
+		log.Logger("actpool").Debug("Removed invalidated action.", act.LogFields()...)
 		delete(ap.allActions, hash)
+		ap.countActionType(act, -1)
+		ap.bytesInPool -= ap.arrivals[hash].size
+		delete(ap.arrivals, hash)
 		intrinsicGas, _ := act.IntrinsicGas()
 		ap.gasInPool -= intrinsicGas
+		ap.gasPrices.remove(act.GasPrice())
+		ap.emitToSubscribers(ActionEvent{Type: ActionRemoved, Action: act})
+	}
+}
+
+// evictionVictim returns the sender of the least valuable action in pool if act outranks it, or an empty string
+// otherwise. Only the last action of each sender is considered, so as not to leave a nonce gap behind, and the least
+// valuable one is of the lowest gas price, of the sender having the most actions among the ones of the same gas
+// price. act outranks it by a higher gas price, or by the same gas price if the victim would still have more actions
+// than the sender of act. The actions of the sender of act and of the priority senders are never evicted. It is only
+// for an action taking a new place in pool, not for one replacing the pending action of the same nonce
+func (ap *actPool) evictionVictim(sender string, act action.SealedEnvelope) string {
+	var (
+		victim      string
+		victimPrice *big.Int
+		victimLen   int
+	)
+	for from, queue := range ap.accountActs {
+		if from == sender || ap.isPrioritySender(from) {
+			continue
+		}
+		tail, ok := queue.Tail()
+		if !ok {
+			continue
+		}
+		price, n := tail.GasPrice(), queue.Len()
+		if victim == "" {
+			victim, victimPrice, victimLen = from, price, n
+			continue
+		}
+		// the ties are broken by the sender address, so that the victim doesn't depend on the map iteration order
+		if c := price.Cmp(victimPrice); c < 0 || c == 0 && (n > victimLen || n == victimLen && from > victim) {
+			victim, victimPrice, victimLen = from, price, n
+		}
+	}
+	if victim == "" {
+		return ""
+	}
+	senderLen := 0
+	if queue, ok := ap.accountActs[sender]; ok {
+		senderLen = queue.Len()
+	}
+	if c := act.GasPrice().Cmp(victimPrice); c > 0 || c == 0 && victimLen > senderLen+1 {
+		return victim
+	}
+	return ""
+}
+
+// evictTail evicts the last action of the sender to make room for a more valuable action
+func (ap *actPool) evictTail(sender string) {
+	queue, ok := ap.accountActs[sender]
+	if !ok {
+		return
+	}
+	tail, ok := queue.Tail()
+	if !ok {
+		return
+	}
+	acts := queue.RemoveFrom(tail.Nonce())
+	ap.removeInvalidActs(acts)
+	ap.recordEvictions(sender, acts, EvictedOutranked)
+	if queue.Empty() {
+		delete(ap.accountActs, sender)
+	}
+}
+
+func (ap *actPool) isPrioritySender(sender string) bool {
+	for _, s := range ap.cfg.PrioritySenders {
+		if s == sender {
+			return true
+		}
+	}
+	return false
+}
+
+// countActionType adds delta to the number of the actions of the type of act in pool
+func (ap *actPool) countActionType(act action.SealedEnvelope, delta int) {
+	switch act.Action().(type) {
+	case *action.Transfer:
+		ap.numTransfers += delta
+	case *action.Vote:
+		ap.numVotes += delta
+	}
+}
+
+// recordEvictions logs the actions evicted, dropping the records beyond evictionLogSize
+func (ap *actPool) recordEvictions(sender string, acts []action.SealedEnvelope, reason EvictionReason) {
+	now := ap.clock.Now()
+	for _, act := range acts {
+		hash := act.Hash()
+		log.Logger("actpool").Debug("Evicted action.",
+			log.ShortHash("action", hash),
+			log.SenderField(sender),
+			zap.Uint64("nonce", act.Nonce()),
+			zap.String("reason", string(reason)))
+		ap.evictions = append(ap.evictions, EvictionRecord{
+			Hash:   hash,
+			Sender: sender,
+			Nonce:  act.Nonce(),
+			Reason: reason,
+			Time:   now,
+		})
+	}
+	if n := len(ap.evictions); n > evictionLogSize {
+		ap.evictions = append(ap.evictions[:0], ap.evictions[n-evictionLogSize:]...)
+	}
+}
+
+func (ap *actPool) emitToSubscribers(e ActionEvent) {
+	for _, s := range ap.subscribers {
+		s.HandleActionEvent(e)
 	}
 }
 
 // updateAccount updates queue's status and remove invalidated actions from pool if necessary
 func (ap *actPool) updateAccount(sender string) {
 	queue := ap.accountActs[sender]
+	// the actions timed out are removed first, so that the rest removed are unpayable
+	if acts := queue.CleanTimeout(); len(acts) > 0 {
+		ap.removeInvalidActs(acts)
+		ap.recordEvictions(sender, acts, EvictedExpired)
+	}
 	acts := queue.UpdateQueue(queue.PendingNonce())
 	if len(acts) > 0 {
 		ap.removeInvalidActs(acts)
+		ap.recordEvictions(sender, acts, EvictedUnpayable)
 	}
 	// Delete the queue entry if it becomes empty
 	if queue.Empty() {
@@ -396,29 +1333,138 @@ func (ap *actPool) updateAccount(sender string) {
 	}
 }
 
+// sweep evicts the actions beyond ActionExpiry, and the ones of the higher nonces of the same senders. The pool is
+// locked for one sender at a time, so that the sweep doesn't hold up adding the actions
+func (ap *actPool) sweep() {
+	ap.mutex.RLock()
+	senders := make([]string, 0, len(ap.accountActs))
+	for sender := range ap.accountActs {
+		senders = append(senders, sender)
+	}
+	ap.mutex.RUnlock()
+
+	for _, sender := range senders {
+		ap.sweepAccount(sender)
+	}
+
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+	ap.rotateJournal()
+}
+
+func (ap *actPool) sweepAccount(sender string) {
+	ap.mutex.Lock()
+	defer ap.mutex.Unlock()
+
+	queue, ok := ap.accountActs[sender]
+	if !ok {
+		return
+	}
+	acts := queue.CleanTimeout()
+	if len(acts) == 0 {
+		return
+	}
+	ap.removeInvalidActs(acts)
+	ap.recordEvictions(sender, acts, EvictedExpired)
+	if queue.Empty() {
+		delete(ap.accountActs, sender)
+	}
+	sizeMtc.WithLabelValues().Set(float64(len(ap.allActions)))
+}
+
 func (ap *actPool) reset() {
 	timer := ap.timerFactory.NewTimer("reset")
 	defer timer.End()
 
 	// Remove confirmed actions in actpool
 	ap.removeConfirmedActs()
+	// Validate the actions left against the new state of each account, so that the ones no longer payable aren't
+	// picked again. An account whose state can't be read is skipped rather than holding up the others
 	for from, queue := range ap.accountActs {
 		// Reset pending balance for each account
 		balance, err := ap.bc.Balance(from)
 		if err != nil {
-			log.L().Error("Error when resetting actpool state.", zap.Error(err))
-			return
+			log.Logger("actpool").Error("Error when resetting actpool state.", log.SenderField(from), zap.Error(err))
+			continue
 		}
-		queue.SetPendingBalance(balance)
 
 		// Reset pending nonce and remove invalid actions for each account
 		confirmedNonce, err := ap.bc.Nonce(from)
 		if err != nil {
-			log.L().Error("Error when resetting actpool state.", zap.Error(err))
-			return
+			log.Logger("actpool").Error("Error when resetting actpool state.", log.SenderField(from), zap.Error(err))
+			continue
 		}
+		queue.SetPendingBalance(balance)
 		pendingNonce := confirmedNonce + 1
 		queue.SetPendingNonce(pendingNonce)
 		ap.updateAccount(from)
 	}
+	// Forget the senders whose rate windows have passed
+	now := ap.clock.Now()
+	for sender, rate := range ap.senderRates {
+		if now.Sub(rate.start) >= ap.cfg.SenderRateWindow {
+			delete(ap.senderRates, sender)
+		}
+	}
+	ap.rotateJournal()
+	sizeMtc.WithLabelValues().Set(float64(len(ap.allActions)))
+}
+
+// observeAdd counts the action offered by whether it's accepted, updates the pool size, and traces the action
+func (ap *actPool) observeAdd(act action.SealedEnvelope, err error) {
+	if log.ActionTracing() {
+		traceAdd(act, err)
+	}
+	if err != nil {
+		actionMtc.WithLabelValues("rejected").Inc()
+		return
+	}
+	actionMtc.WithLabelValues("accepted").Inc()
+	sizeMtc.WithLabelValues().Set(float64(len(ap.allActions)))
+}
+
+// traceAdd traces the action offered as admitted or rejected with the error
+func traceAdd(act action.SealedEnvelope, err error) {
+	var fields []zap.Field
+	if sender, e := addrutil.PubKeyToAddress(act.SrcPubkey()); e == nil {
+		fields = append(fields, log.SenderField(sender.String()))
+	}
+	if err != nil {
+		log.TraceAction(log.StageRejected, act.Hash(), append(fields, zap.Error(err))...)
+		return
+	}
+	log.TraceAction(log.StageAdmitted, act.Hash(), fields...)
+}
+
+// exceedsRateLimit tells whether the sender has added SenderRateLimit actions within the current window
+func (ap *actPool) exceedsRateLimit(sender string) bool {
+	if ap.cfg.SenderRateLimit == 0 {
+		return false
+	}
+	rate, ok := ap.senderRates[sender]
+	return ok && ap.clock.Now().Sub(rate.start) < ap.cfg.SenderRateWindow && rate.count >= ap.cfg.SenderRateLimit
+}
+
+// countSenderRate counts an action the sender has added, starting a new window if the current one has passed
+func (ap *actPool) countSenderRate(sender string) {
+	if ap.cfg.SenderRateLimit == 0 {
+		return
+	}
+	now := ap.clock.Now()
+	rate, ok := ap.senderRates[sender]
+	if !ok || now.Sub(rate.start) >= ap.cfg.SenderRateWindow {
+		ap.senderRates[sender] = &senderRate{start: now, count: 1}
+		return
+	}
+	rate.count++
+}
+
+// invalidActionError maps a validation error caused by a malformed address or public key to action.ErrAddress, so that
+// callers can tell it apart from other invalid actions
+func invalidActionError(err error, hash hash.Hash256) error {
+	cause := errors.Cause(err)
+	if addrutil.IsAddressError(cause) || cause == keypair.ErrInvalidPubKeyLength || cause == keypair.ErrPublicKey {
+		return errors.Wrapf(action.ErrAddress, "reject invalid action %x: %v", hash, err)
+	}
+	return errors.Wrapf(err, "reject invalid action: %x", hash)
 }
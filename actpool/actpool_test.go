@@ -8,12 +8,17 @@ package actpool
 
 import (
 	"context"
+	"io/ioutil"
 	"math/big"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/facebookgo/clock"
 	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
@@ -21,9 +26,14 @@ import (
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/action/protocol/account"
 	"github.com/iotexproject/iotex-core/action/protocol/execution"
+	"github.com/iotexproject/iotex-core/actpool/actioniterator"
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
 	"github.com/iotexproject/iotex-core/test/identityset"
 	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
 	"github.com/iotexproject/iotex-core/test/testaddress"
@@ -94,6 +104,7 @@ func TestActPool_validateGenericAction(t *testing.T) {
 	selp := action.FakeSeal(elp, pubKey1)
 	err = validator.Validate(ctx, selp)
 	require.True(strings.Contains(err.Error(), "incorrect length of signature"))
+	require.Equal(action.ErrSignature, errors.Cause(err))
 	// Case IV: Nonce is too low
 	prevTsf, err := testutil.SignedTransfer(addr1, priKey1, uint64(1), big.NewInt(50), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
@@ -122,6 +133,20 @@ func TestActPool_validateGenericAction(t *testing.T) {
 	require.Equal(action.ErrNonce, errors.Cause(err))
 }
 
+func TestInvalidActionError(t *testing.T) {
+	require := require.New(t)
+
+	for _, err := range []error{
+		addrutil.ErrChecksum,
+		addrutil.ErrLength,
+		keypair.ErrInvalidPubKeyLength,
+		keypair.ErrPublicKey,
+	} {
+		require.Equal(action.ErrAddress, errors.Cause(invalidActionError(errors.Wrap(err, "recipient"), hash.ZeroHash256)))
+	}
+	require.Equal(action.ErrNonce, errors.Cause(invalidActionError(action.ErrNonce, hash.ZeroHash256)))
+}
+
 func TestActPool_AddActs(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
@@ -208,9 +233,9 @@ func TestActPool_AddActs(t *testing.T) {
 	require.True(strings.Contains(err.Error(), "action source address is blacklisted"))
 	// Case II: Action already exists in pool
 	err = ap.Add(tsf1)
-	require.Error(err)
+	require.Equal(ErrDuplicate, errors.Cause(err))
 	err = ap.Add(tsf4)
-	require.Error(err)
+	require.Equal(ErrDuplicate, errors.Cause(err))
 	// Case III: Pool space/gas space is full
 	mockBC := mock_blockchain.NewMockBlockchain(ctrl)
 	Ap2, err := NewActPool(mockBC, apConfig, EnableExperimentalActions())
@@ -224,9 +249,9 @@ func TestActPool_AddActs(t *testing.T) {
 		ap2.allActions[nTsf.Hash()] = nTsf
 	}
 	err = ap2.Add(tsf1)
-	require.Equal(action.ErrActPool, errors.Cause(err))
+	require.Equal(ErrPoolFull, errors.Cause(err))
 	err = ap2.Add(tsf4)
-	require.Equal(action.ErrActPool, errors.Cause(err))
+	require.Equal(ErrPoolFull, errors.Cause(err))
 
 	Ap3, err := NewActPool(mockBC, apConfig)
 	require.NoError(err)
@@ -244,6 +269,7 @@ func TestActPool_AddActs(t *testing.T) {
 	require.NoError(err)
 	err = ap3.Add(tsf10)
 	require.True(strings.Contains(err.Error(), "insufficient gas space for action"))
+	require.Equal(ErrPoolFull, errors.Cause(err))
 
 	// Case IV: Nonce already exists
 	replaceTsf, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(1), []byte{}, uint64(100000), big.NewInt(0))
@@ -401,6 +427,440 @@ func TestActPool_PickActs(t *testing.T) {
 	})
 }
 
+func TestActPool_PendingActionMapWithLimit(t *testing.T) {
+	require := require.New(t)
+	bc := blockchain.NewBlockchain(
+		config.Default,
+		blockchain.InMemStateFactoryOption(),
+		blockchain.InMemDaoOption(),
+	)
+	require.NoError(bc.Start(context.Background()))
+	_, err := bc.CreateState(addr1, big.NewInt(1000000))
+	require.NoError(err)
+	_, err = bc.CreateState(addr2, big.NewInt(1000000))
+	require.NoError(err)
+	apConfig := getActPoolCfg()
+	Ap, err := NewActPool(bc, apConfig, EnableExperimentalActions())
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+	ap.AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesis.Default.ActionGasLimit))
+	ap.AddActionValidators(account.NewProtocol(), execution.NewProtocol(bc))
+
+	// addr1 sends 3 transfers, and addr2 sends 2 votes and a transfer, of the gas prices picked in the order of
+	// addr1, addr2, addr1, addr2, addr1, addr2
+	var acts []action.SealedEnvelope
+	for nonce := uint64(1); nonce <= 3; nonce++ {
+		tsf, err := testutil.SignedTransfer(addr2, priKey1, nonce, big.NewInt(1), []byte{}, uint64(100000),
+			big.NewInt(int64(20-2*nonce)))
+		require.NoError(err)
+		acts = append(acts, tsf)
+	}
+	for nonce := uint64(1); nonce <= 2; nonce++ {
+		vote, err := testutil.SignedVote(addr2, priKey2, nonce, uint64(100000), big.NewInt(int64(19-2*nonce)))
+		require.NoError(err)
+		acts = append(acts, vote)
+	}
+	tsf, err := testutil.SignedTransfer(addr1, priKey2, 3, big.NewInt(1), []byte{}, uint64(100000), big.NewInt(13))
+	require.NoError(err)
+	acts = append(acts, tsf)
+	for _, act := range acts {
+		require.NoError(ap.Add(act))
+	}
+
+	require.Equal(6, lenPendingActionMap(ap.PendingActionMapWithLimit(0, 0)))
+	require.Equal(6, lenPendingActionMap(ap.PendingActionMap()))
+	// the 2nd transfer of addr1 hits the limit, before the 2nd vote of addr2
+	picked := ap.PendingActionMapWithLimit(2, 0)
+	require.Equal(acts[:2], picked[addr1])
+	require.Equal(acts[3:4], picked[addr2])
+	// the 1st vote of addr2 hits the limit
+	picked = ap.PendingActionMapWithLimit(0, 1)
+	require.Equal(acts[:1], picked[addr1])
+	require.Equal(acts[3:4], picked[addr2])
+
+	// the limits of the config apply to PendingActionMap
+	apConfig.MaxTransfersPerBlock = 1
+	ap.SetLimits(apConfig)
+	picked = ap.PendingActionMap()
+	require.Equal(1, lenPendingActionMap(picked))
+	require.Equal(acts[:1], picked[addr1])
+}
+
+func TestActPool_RemoveActs(t *testing.T) {
+	require := require.New(t)
+	bc := blockchain.NewBlockchain(
+		config.Default,
+		blockchain.InMemStateFactoryOption(),
+		blockchain.InMemDaoOption(),
+	)
+	require.NoError(bc.Start(context.Background()))
+	_, err := bc.CreateState(addr1, big.NewInt(100))
+	require.NoError(err)
+	_, err = bc.CreateState(addr2, big.NewInt(100))
+	require.NoError(err)
+	Ap, err := NewActPool(bc, getActPoolCfg())
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+	ap.AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesis.Default.ActionGasLimit))
+	ap.AddActionValidators(account.NewProtocol())
+
+	var acts1, acts2 []action.SealedEnvelope
+	for nonce := uint64(1); nonce <= 3; nonce++ {
+		tsf, err := testutil.SignedTransfer(addr2, priKey1, nonce, big.NewInt(10), []byte{}, uint64(100000),
+			big.NewInt(0))
+		require.NoError(err)
+		require.NoError(ap.Add(tsf))
+		acts1 = append(acts1, tsf)
+		tsf, err = testutil.SignedTransfer(addr1, priKey2, nonce, big.NewInt(10), []byte{}, uint64(100000),
+			big.NewInt(0))
+		require.NoError(err)
+		require.NoError(ap.Add(tsf))
+		acts2 = append(acts2, tsf)
+	}
+	emptyUsage := func() int64 {
+		empty, err := NewActPool(bc, getActPoolCfg())
+		require.NoError(err)
+		return empty.MemoryUsage()
+	}()
+
+	// removing the 2nd action of addr1 removes the 3rd one too
+	ap.RemoveActs([]action.SealedEnvelope{acts1[1]})
+	require.Equal(uint64(4), ap.GetSize())
+	require.Equal(acts1[:1], ap.GetUnconfirmedActs(addr1))
+	nonce, err := ap.GetPendingNonce(addr1)
+	require.NoError(err)
+	require.Equal(uint64(2), nonce)
+	_, err = ap.GetActionByHash(acts1[2].Hash())
+	require.Error(err)
+	evictions := ap.RecentEvictions()
+	require.Len(evictions, 2)
+	for i, e := range evictions {
+		require.Equal(acts1[i+1].Hash(), e.Hash)
+		require.Equal(EvictedRemoved, e.Reason)
+	}
+	// the actions removed already are ignored, and the nonce freed is taken again
+	ap.RemoveActs(acts1[1:])
+	require.Equal(uint64(4), ap.GetSize())
+	require.NoError(ap.Add(acts1[1]))
+	require.Equal(uint64(5), ap.GetSize())
+
+	ap.DropAccount(addr2)
+	ap.DropAccount(addr2)
+	require.Equal(uint64(2), ap.GetSize())
+	require.Empty(ap.GetUnconfirmedActs(addr2))
+	require.Len(ap.RecentEvictions(), 5)
+
+	ap.RemoveActs(acts1)
+	require.Zero(ap.GetSize())
+	require.Zero(ap.GetGasSize())
+	require.Empty(ap.accountActs)
+	require.Equal(emptyUsage, ap.MemoryUsage())
+}
+
+func TestActPool_ReplaceAction(t *testing.T) {
+	require := require.New(t)
+	bc := blockchain.NewBlockchain(
+		config.Default,
+		blockchain.InMemStateFactoryOption(),
+		blockchain.InMemDaoOption(),
+	)
+	require.NoError(bc.Start(context.Background()))
+	_, err := bc.CreateState(addr1, big.NewInt(1000000))
+	require.NoError(err)
+	Ap, err := NewActPool(bc, getActPoolCfg())
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+	ap.AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesis.Default.ActionGasLimit))
+	ap.AddActionValidators(account.NewProtocol())
+
+	transfer := func(nonce uint64, amount, gasPrice int64) action.SealedEnvelope {
+		tsf, err := testutil.SignedTransfer(addr2, priKey1, nonce, big.NewInt(amount), []byte{}, uint64(100000),
+			big.NewInt(gasPrice))
+		require.NoError(err)
+		return tsf
+	}
+	tsf1 := transfer(1, 10, 1)
+	tsf2 := transfer(2, 10, 1)
+	require.NoError(ap.Add(tsf1))
+	require.NoError(ap.Add(tsf2))
+	// the replacement of a gas price no higher is rejected
+	require.Equal(action.ErrNonce, errors.Cause(ap.Add(transfer(1, 20, 1))))
+	// the replacement costing more than the balance is rejected
+	require.Equal(action.ErrBalance, errors.Cause(ap.Add(transfer(1, 999990, 2))))
+
+	replacement := transfer(1, 20, 2)
+	require.NoError(ap.Add(replacement))
+	require.Equal(uint64(2), ap.GetSize())
+	require.Equal([]action.SealedEnvelope{replacement, tsf2}, ap.GetUnconfirmedActs(addr1))
+	require.Equal([]action.SealedEnvelope{replacement, tsf2}, ap.PendingActionMap()[addr1])
+	_, err = ap.GetActionByHash(tsf1.Hash())
+	require.Error(err)
+	evictions := ap.RecentEvictions()
+	require.Len(evictions, 1)
+	require.Equal(tsf1.Hash(), evictions[0].Hash)
+	require.Equal(EvictedReplaced, evictions[0].Reason)
+	nonce, err := ap.GetPendingNonce(addr1)
+	require.NoError(err)
+	require.Equal(uint64(3), nonce)
+
+	// the replacement costing more leaves the actions after it unpayable
+	gas, err := replacement.IntrinsicGas()
+	require.NoError(err)
+	require.NoError(ap.Add(transfer(1, 1000000-3*int64(gas), 3)))
+	require.Equal(uint64(1), ap.GetSize())
+	nonce, err = ap.GetPendingNonce(addr1)
+	require.NoError(err)
+	require.Equal(uint64(2), nonce)
+}
+
+func TestActPool_ExpiredNoncesFreed(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	bc.EXPECT().Nonce(gomock.Any()).Return(uint64(0), nil).AnyTimes()
+	bc.EXPECT().Balance(addr1).Return(big.NewInt(1000), nil).AnyTimes()
+	apConfig := getActPoolCfg()
+	apConfig.ActionExpiry = time.Minute
+	Ap, err := NewActPool(bc, apConfig)
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+	clk := clock.NewMock()
+	ap.clock = clk
+
+	var acts []action.SealedEnvelope
+	for nonce := uint64(1); nonce <= 4; nonce++ {
+		tsf, err := testutil.SignedTransfer(addr2, priKey1, nonce, big.NewInt(10), []byte{}, uint64(100000),
+			big.NewInt(0))
+		require.NoError(err)
+		acts = append(acts, tsf)
+	}
+	for _, act := range acts[:3] {
+		require.NoError(ap.Add(act))
+	}
+	clk.Add(30 * time.Second)
+	require.NoError(ap.Add(acts[3]))
+
+	// the actions of nonce 1 to 3 time out, and the one of nonce 4 is evicted along with them rather than left
+	// behind the nonce gap
+	clk.Add(40 * time.Second)
+	ap.Reset()
+	require.Zero(ap.GetSize())
+	require.Len(ap.RecentEvictions(), 4)
+	for _, e := range ap.RecentEvictions() {
+		require.Equal(EvictedExpired, e.Reason)
+	}
+	for _, act := range acts {
+		_, err := ap.GetActionByHash(act.Hash())
+		require.Error(err)
+	}
+	require.Zero(lenPendingActionMap(ap.PendingActionMap()))
+	nonce, err := ap.GetPendingNonce(addr1)
+	require.NoError(err)
+	require.Equal(uint64(1), nonce)
+
+	// the nonces freed are taken again
+	for _, act := range acts {
+		require.NoError(ap.Add(act))
+	}
+	require.Equal(uint64(4), ap.GetSize())
+	nonce, err = ap.GetPendingNonce(addr1)
+	require.NoError(err)
+	require.Equal(uint64(5), nonce)
+}
+
+func TestActPool_Sweep(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	bc.EXPECT().Nonce(gomock.Any()).Return(uint64(0), nil).AnyTimes()
+	bc.EXPECT().Balance(gomock.Any()).Return(big.NewInt(1000), nil).AnyTimes()
+	apConfig := getActPoolCfg()
+	apConfig.ActionExpiry = time.Minute
+	apConfig.SweepInterval = 10 * time.Second
+	Ap, err := NewActPool(bc, apConfig)
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+	clk := clock.NewMock()
+	ap.clock = clk
+	ctx := context.Background()
+	require.NoError(ap.Start(ctx))
+	defer func() { require.NoError(ap.Stop(ctx)) }()
+
+	tsf1, err := testutil.SignedTransfer(addr2, priKey1, 1, big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	require.NoError(ap.Add(tsf1))
+	clk.Add(30 * time.Second)
+	tsf2, err := testutil.SignedTransfer(addr2, priKey1, 2, big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	require.NoError(ap.Add(tsf2))
+	tsf3, err := testutil.SignedTransfer(addr1, priKey2, 1, big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	require.NoError(ap.Add(tsf3))
+
+	// the action of nonce 1 of addr1 expires without a block committed, and the one of nonce 2 is evicted along with
+	// it, while the action of addr2 stays
+	clk.Add(40 * time.Second)
+	require.NoError(testutil.WaitUntil(10*time.Millisecond, 2*time.Second, func() (bool, error) {
+		return ap.GetSize() == 1, nil
+	}))
+	_, err = ap.GetActionByHash(tsf3.Hash())
+	require.NoError(err)
+	evictions := ap.RecentEvictions()
+	require.Len(evictions, 2)
+	for _, e := range evictions {
+		require.Equal(addr1, e.Sender)
+		require.Equal(EvictedExpired, e.Reason)
+	}
+	nonce, err := ap.GetPendingNonce(addr1)
+	require.NoError(err)
+	require.Equal(uint64(1), nonce)
+	require.Len(ap.GetUnconfirmedActs(addr1), 0)
+
+	// the action of addr2 expires on the following sweep
+	clk.Add(30 * time.Second)
+	require.NoError(testutil.WaitUntil(10*time.Millisecond, 2*time.Second, func() (bool, error) {
+		return ap.GetSize() == 0, nil
+	}))
+	require.Len(ap.RecentEvictions(), 3)
+}
+
+func TestActPool_Journal(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir, err := ioutil.TempDir("", "actpool")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	confirmedNonce := uint64(0)
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	bc.EXPECT().Nonce(gomock.Any()).DoAndReturn(func(string) (uint64, error) {
+		return confirmedNonce, nil
+	}).AnyTimes()
+	bc.EXPECT().Balance(gomock.Any()).Return(big.NewInt(1000), nil).AnyTimes()
+	apConfig := getActPoolCfg()
+	apConfig.Journal = filepath.Join(dir, "actpool.journal")
+	ctx := context.Background()
+
+	var acts []action.SealedEnvelope
+	for nonce := uint64(1); nonce <= 4; nonce++ {
+		tsf, err := testutil.SignedTransfer(addr2, priKey1, nonce, big.NewInt(10), []byte{}, uint64(100000),
+			big.NewInt(0))
+		require.NoError(err)
+		acts = append(acts, tsf)
+	}
+	ap, err := NewActPool(bc, apConfig)
+	require.NoError(err)
+	require.NoError(ap.Start(ctx))
+	for _, act := range acts[:3] {
+		require.NoError(ap.Add(act))
+	}
+	require.NoError(ap.Stop(ctx))
+
+	// the action of nonce 1 is committed while the node is down, and is left out on restart
+	confirmedNonce = 1
+	ap, err = NewActPool(bc, apConfig)
+	require.NoError(err)
+	require.NoError(ap.Start(ctx))
+	require.Equal(uint64(2), ap.GetSize())
+	_, err = ap.GetActionByHash(acts[0].Hash())
+	require.Error(err)
+	for _, act := range acts[1:3] {
+		_, err := ap.GetActionByHash(act.Hash())
+		require.NoError(err)
+	}
+	nonce, err := ap.GetPendingNonce(addr1)
+	require.NoError(err)
+	require.Equal(uint64(4), nonce)
+
+	// the journal is rewritten with the actions in pool, followed by the ones added after the restart
+	require.NoError(ap.Add(acts[3]))
+	ap.(*actPool).journal.flush()
+	journaled, err := newJournal(apConfig.Journal).load()
+	require.NoError(err)
+	require.Len(journaled, 3)
+	for i, act := range journaled {
+		require.Equal(acts[i+1].Hash(), act.Hash())
+	}
+	require.NoError(ap.Stop(ctx))
+}
+
+func TestActPool_JournalRotation(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dir, err := ioutil.TempDir("", "actpool")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	confirmedNonce := uint64(0)
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	bc.EXPECT().Nonce(gomock.Any()).DoAndReturn(func(string) (uint64, error) {
+		return confirmedNonce, nil
+	}).AnyTimes()
+	bc.EXPECT().Balance(gomock.Any()).Return(big.NewInt(100000), nil).AnyTimes()
+	apConfig := getActPoolCfg()
+	apConfig.Journal = filepath.Join(dir, "actpool.journal")
+	apConfig.MaxNumActsPerPool = 200
+	apConfig.MaxNumActsPerAcct = 200
+	apConfig.MaxGasLimitPerPool = 200 * 100000
+	ctx := context.Background()
+
+	Ap, err := NewActPool(bc, apConfig)
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+	require.NoError(ap.Start(ctx))
+	var acts []action.SealedEnvelope
+	for nonce := uint64(1); nonce <= 160; nonce++ {
+		tsf, err := testutil.SignedTransfer(addr2, priKey1, nonce, big.NewInt(10), []byte{}, uint64(100000),
+			big.NewInt(0))
+		require.NoError(err)
+		require.NoError(ap.Add(tsf))
+		acts = append(acts, tsf)
+	}
+
+	// the journal isn't rewritten as long as it doesn't grow well beyond the pool
+	ap.Reset()
+	ap.journal.flush()
+	journaled, err := newJournal(apConfig.Journal).load()
+	require.NoError(err)
+	require.Len(journaled, 160)
+
+	// once most of the actions are committed, the journal is rewritten with the ones left in pool
+	confirmedNonce = 155
+	ap.Reset()
+	require.Equal(uint64(5), ap.GetSize())
+	ap.journal.flush()
+	journaled, err = newJournal(apConfig.Journal).load()
+	require.NoError(err)
+	require.Len(journaled, 5)
+	for i, act := range journaled {
+		require.Equal(acts[155+i].Hash(), act.Hash())
+	}
+
+	// the actions added afterwards are appended to the rewritten journal
+	tsf, err := testutil.SignedTransfer(addr2, priKey1, 161, big.NewInt(10), []byte{}, uint64(100000),
+		big.NewInt(0))
+	require.NoError(err)
+	require.NoError(ap.Add(tsf))
+	ap.journal.flush()
+	journaled, err = newJournal(apConfig.Journal).load()
+	require.NoError(err)
+	require.Len(journaled, 6)
+	require.Equal(tsf.Hash(), journaled[5].Hash())
+	require.NoError(ap.Stop(ctx))
+}
+
 func TestActPool_removeConfirmedActs(t *testing.T) {
 	require := require.New(t)
 	bc := blockchain.NewBlockchain(
@@ -460,67 +920,430 @@ func TestActPool_removeConfirmedActs(t *testing.T) {
 	require.Nil(ap.accountActs[addr1])
 }
 
-func TestActPool_Reset(t *testing.T) {
+func TestActPool_MaxSenders(t *testing.T) {
 	require := require.New(t)
 	bc := blockchain.NewBlockchain(
 		config.Default,
 		blockchain.InMemStateFactoryOption(),
 		blockchain.InMemDaoOption(),
+		blockchain.EnableExperimentalActions(),
 	)
 	bc.GetFactory().AddActionHandlers(account.NewProtocol(), execution.NewProtocol(bc))
 	require.NoError(bc.Start(context.Background()))
-	_, err := bc.CreateState(addr1, big.NewInt(100))
-	require.NoError(err)
-	_, err = bc.CreateState(addr2, big.NewInt(200))
-	require.NoError(err)
-	_, err = bc.CreateState(addr3, big.NewInt(300))
-	require.NoError(err)
-
+	for _, addr := range []string{addr1, addr2, addr3} {
+		_, err := bc.CreateState(addr, big.NewInt(100))
+		require.NoError(err)
+	}
+	// Create actpool
 	apConfig := getActPoolCfg()
-	Ap1, err := NewActPool(bc, apConfig, EnableExperimentalActions())
-	require.NoError(err)
-	ap1, ok := Ap1.(*actPool)
-	require.True(ok)
-	ap1.AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesis.Default.ActionGasLimit))
-	ap1.AddActionValidators(account.NewProtocol(), execution.NewProtocol(bc),
-		execution.NewProtocol(bc))
-	Ap2, err := NewActPool(bc, apConfig, EnableExperimentalActions())
+	apConfig.MaxSenders = 2
+	Ap, err := NewActPool(bc, apConfig, EnableExperimentalActions())
 	require.NoError(err)
-	ap2, ok := Ap2.(*actPool)
+	ap, ok := Ap.(*actPool)
 	require.True(ok)
-	ap2.AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesis.Default.ActionGasLimit))
-	ap2.AddActionValidators(account.NewProtocol(), execution.NewProtocol(bc),
-		execution.NewProtocol(bc))
 
-	// Tsfs to be added to ap1
-	tsf1, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(50), []byte{}, uint64(20000), big.NewInt(0))
-	require.NoError(err)
-	tsf2, err := testutil.SignedTransfer(addr3, priKey1, uint64(2), big.NewInt(30), []byte{}, uint64(20000), big.NewInt(0))
+	tsf1, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
-	tsf3, err := testutil.SignedTransfer(addr2, priKey1, uint64(3), big.NewInt(60), []byte{}, uint64(20000), big.NewInt(0))
+	tsf2, err := testutil.SignedTransfer(addr1, priKey2, uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
-	tsf4, err := testutil.SignedTransfer(addr1, priKey2, uint64(1), big.NewInt(100), []byte{}, uint64(20000), big.NewInt(0))
+	tsf3, err := testutil.SignedTransfer(addr1, priKey3, uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
-	tsf5, err := testutil.SignedTransfer(addr3, priKey2, uint64(2), big.NewInt(50), []byte{}, uint64(20000), big.NewInt(0))
+	tsf4, err := testutil.SignedTransfer(addr2, priKey1, uint64(2), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
-	tsf6, err := testutil.SignedTransfer(addr1, priKey2, uint64(3), big.NewInt(60), []byte{}, uint64(20000), big.NewInt(0))
+	require.NoError(ap.Add(tsf1))
+	require.NoError(ap.Add(tsf2))
+	// The pool has the actions of 2 senders already
+	require.Equal(ErrTooManySenders, errors.Cause(ap.Add(tsf3)))
+	// The existing senders could still add actions
+	require.NoError(ap.Add(tsf4))
+
+	// The new sender is taken once the actions of an existing sender clear
+	ws, err := bc.GetFactory().NewWorkingSet()
 	require.NoError(err)
-	tsf7, err := testutil.SignedTransfer(addr1, priKey3, uint64(1), big.NewInt(100), []byte{}, uint64(20000), big.NewInt(0))
+	ctx := protocol.WithRunActionsCtx(context.Background(),
+		protocol.RunActionsCtx{
+			Producer: testaddress.Addrinfo["producer"],
+			GasLimit: uint64(1000000),
+		})
+	_, err = ws.RunActions(ctx, 0, []action.SealedEnvelope{tsf2})
 	require.NoError(err)
-	tsf8, err := testutil.SignedTransfer(addr2, priKey3, uint64(2), big.NewInt(100), []byte{}, uint64(20000), big.NewInt(0))
+	require.NoError(bc.GetFactory().Commit(ws))
+	ap.Reset()
+	require.NoError(ap.Add(tsf3))
+}
+
+func TestActPool_AdmissionHook(t *testing.T) {
+	require := require.New(t)
+	bc := blockchain.NewBlockchain(
+		config.Default,
+		blockchain.InMemStateFactoryOption(),
+		blockchain.InMemDaoOption(),
+		blockchain.EnableExperimentalActions(),
+	)
+	bc.GetFactory().AddActionHandlers(account.NewProtocol(), execution.NewProtocol(bc))
+	require.NoError(bc.Start(context.Background()))
+	_, err := bc.CreateState(addr1, big.NewInt(100))
 	require.NoError(err)
-	tsf9, err := testutil.SignedTransfer(addr1, priKey3, uint64(4), big.NewInt(100), []byte{}, uint64(20000), big.NewInt(0))
+	Ap, err := NewActPool(bc, getActPoolCfg(), EnableExperimentalActions())
 	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
 
-	err = ap1.Add(tsf1)
-	require.NoError(err)
-	err = ap1.Add(tsf2)
+	// reject the transfers over 20 during the maintenance window
+	errMaintenance := errors.New("large transfers are suspended during maintenance")
+	ap.SetAdmissionHook(func(act action.SealedEnvelope) error {
+		if tsf, ok := act.Action().(*action.Transfer); ok && tsf.Amount().Cmp(big.NewInt(20)) > 0 {
+			return errMaintenance
+		}
+		return nil
+	})
+	tsf1, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
-	err = ap1.Add(tsf3)
-	require.Equal(action.ErrBalance, errors.Cause(err))
-	err = ap1.Add(tsf4)
+	tsf2, err := testutil.SignedTransfer(addr2, priKey1, uint64(2), big.NewInt(30), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
-	err = ap1.Add(tsf5)
+	require.NoError(ap.Add(tsf1))
+	err = ap.Add(tsf2)
+	require.Equal(errMaintenance, errors.Cause(err))
+	require.Equal(uint64(1), ap.GetSize())
+
+	// clearing the hook takes the action
+	ap.SetAdmissionHook(nil)
+	require.NoError(ap.Add(tsf2))
+	require.Equal(uint64(2), ap.GetSize())
+}
+
+func TestActPool_RejectDuringSync(t *testing.T) {
+	require := require.New(t)
+	bc := blockchain.NewBlockchain(
+		config.Default,
+		blockchain.InMemStateFactoryOption(),
+		blockchain.InMemDaoOption(),
+	)
+	bc.GetFactory().AddActionHandlers(account.NewProtocol(), execution.NewProtocol(bc))
+	require.NoError(bc.Start(context.Background()))
+	_, err := bc.CreateState(addr1, big.NewInt(100))
+	require.NoError(err)
+	apConfig := getActPoolCfg()
+	apConfig.RejectDuringSync = true
+	ap, err := NewActPool(bc, apConfig)
+	require.NoError(err)
+
+	tsf1, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr2, priKey1, uint64(2), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	ap.SetSyncing(true)
+	require.Equal(ErrNodeSyncing, errors.Cause(ap.Add(tsf1)))
+	require.Equal(uint64(0), ap.GetSize())
+	ap.SetSyncing(false)
+	require.NoError(ap.Add(tsf1))
+
+	// the syncing node takes the actions unless RejectDuringSync is set
+	apConfig.RejectDuringSync = false
+	ap, err = NewActPool(bc, apConfig)
+	require.NoError(err)
+	ap.SetSyncing(true)
+	require.NoError(ap.Add(tsf1))
+	require.NoError(ap.Add(tsf2))
+}
+
+func TestActPool_AddIfNonce(t *testing.T) {
+	require := require.New(t)
+	bc := blockchain.NewBlockchain(
+		config.Default,
+		blockchain.InMemStateFactoryOption(),
+		blockchain.InMemDaoOption(),
+	)
+	bc.GetFactory().AddActionHandlers(account.NewProtocol(), execution.NewProtocol(bc))
+	require.NoError(bc.Start(context.Background()))
+	_, err := bc.CreateState(addr1, big.NewInt(100))
+	require.NoError(err)
+	ap, err := NewActPool(bc, getActPoolCfg())
+	require.NoError(err)
+
+	// two clients read the same pending nonce, and the one adding its action later loses the race
+	nonce, err := ap.GetPendingNonce(addr1)
+	require.NoError(err)
+	require.Equal(uint64(1), nonce)
+	tsf1, err := testutil.SignedTransfer(addr2, priKey1, nonce, big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr2, priKey1, nonce, big.NewInt(20), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	require.NoError(ap.AddIfNonce(tsf1, nonce))
+	require.Equal(ErrNonceRaced, errors.Cause(ap.AddIfNonce(tsf2, nonce)))
+	require.Equal(uint64(1), ap.GetSize())
+	_, err = ap.GetActionByHash(tsf1.Hash())
+	require.NoError(err)
+
+	// the loser retries with the new pending nonce
+	nonce, err = ap.GetPendingNonce(addr1)
+	require.NoError(err)
+	require.Equal(uint64(2), nonce)
+	tsf3, err := testutil.SignedTransfer(addr2, priKey1, nonce, big.NewInt(20), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	require.NoError(ap.AddIfNonce(tsf3, nonce))
+	require.Equal(uint64(2), ap.GetSize())
+}
+
+func TestActPool_AddActions(t *testing.T) {
+	require := require.New(t)
+	bc := blockchain.NewBlockchain(
+		config.Default,
+		blockchain.InMemStateFactoryOption(),
+		blockchain.InMemDaoOption(),
+	)
+	bc.GetFactory().AddActionHandlers(account.NewProtocol(), execution.NewProtocol(bc))
+	require.NoError(bc.Start(context.Background()))
+	_, err := bc.CreateState(addr1, big.NewInt(100))
+	require.NoError(err)
+	Ap, err := NewActPool(bc, getActPoolCfg())
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+	ap.AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesis.Default.ActionGasLimit))
+	ap.AddActionValidators(account.NewProtocol(), execution.NewProtocol(bc))
+
+	var acts []action.SealedEnvelope
+	for nonce := uint64(1); nonce <= 5; nonce++ {
+		tsf, err := testutil.SignedTransfer(addr2, priKey1, nonce, big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+		require.NoError(err)
+		acts = append(acts, tsf)
+	}
+	valid := acts[2]
+	// the action of nonce 3 carries the signature of the one of nonce 4, which fails only itself
+	acts[2] = action.AssembleSealedEnvelope(acts[2].Envelope, acts[2].SrcPubkey(), acts[3].Signature())
+	errs := ap.AddActions(acts)
+	require.Equal(len(acts), len(errs))
+	for i, err := range errs {
+		if i == 2 {
+			require.Equal(action.ErrSignature, errors.Cause(err))
+			continue
+		}
+		require.NoError(err)
+	}
+	require.Equal(uint64(4), ap.GetSize())
+	nonce, err := ap.GetPendingNonce(addr1)
+	require.NoError(err)
+	require.Equal(uint64(3), nonce)
+
+	// the action of the valid signature fills the gap
+	require.Equal([]error{nil}, ap.AddActions([]action.SealedEnvelope{valid}))
+	nonce, err = ap.GetPendingNonce(addr1)
+	require.NoError(err)
+	require.Equal(uint64(6), nonce)
+}
+
+func BenchmarkActPool_AddActions(b *testing.B) {
+	const numActs = 1000
+	require := require.New(b)
+	bc := blockchain.NewBlockchain(
+		config.Default,
+		blockchain.InMemStateFactoryOption(),
+		blockchain.InMemDaoOption(),
+	)
+	require.NoError(bc.Start(context.Background()))
+	_, err := bc.CreateState(addr1, big.NewInt(numActs))
+	require.NoError(err)
+	acts := make([]action.SealedEnvelope, 0, numActs)
+	for nonce := uint64(1); nonce <= numActs; nonce++ {
+		tsf, err := testutil.SignedTransfer(addr2, priKey1, nonce, big.NewInt(1), []byte{}, uint64(100000), big.NewInt(0))
+		require.NoError(err)
+		acts = append(acts, tsf)
+	}
+	newActPool := func() *actPool {
+		cfg := getActPoolCfg()
+		cfg.MaxNumActsPerAcct = numActs
+		Ap, err := NewActPool(bc, cfg)
+		require.NoError(err)
+		ap := Ap.(*actPool)
+		ap.AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesis.Default.ActionGasLimit))
+		ap.AddActionValidators(account.NewProtocol())
+		return ap
+	}
+
+	b.Run("batch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			ap := newActPool()
+			b.StartTimer()
+			for _, err := range ap.AddActions(acts) {
+				require.NoError(err)
+			}
+		}
+	})
+	b.Run("one-by-one", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			ap := newActPool()
+			b.StartTimer()
+			for _, act := range acts {
+				require.NoError(ap.Add(act))
+			}
+		}
+	})
+}
+
+func TestActPool_RecentEvictions(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// addr1 has 100 at first, and 50 after the actions are added
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	bc.EXPECT().Nonce(gomock.Any()).Return(uint64(0), nil).AnyTimes()
+	gomock.InOrder(
+		bc.EXPECT().Balance(addr1).Return(big.NewInt(100), nil).Times(1),
+		bc.EXPECT().Balance(addr1).Return(big.NewInt(50), nil).AnyTimes(),
+	)
+	bc.EXPECT().Balance(addr2).Return(big.NewInt(100), nil).AnyTimes()
+	apConfig := getActPoolCfg()
+	apConfig.ActionExpiry = time.Minute
+	Ap, err := NewActPool(bc, apConfig)
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+	clk := clock.NewMock()
+	ap.clock = clk
+
+	tsf1, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(40), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr2, priKey1, uint64(2), big.NewInt(40), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf3, err := testutil.SignedTransfer(addr1, priKey2, uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	require.NoError(ap.Add(tsf1))
+	require.NoError(ap.Add(tsf2))
+	require.NoError(ap.Add(tsf3))
+	require.Empty(ap.RecentEvictions())
+
+	// tsf2 is no longer payable
+	clk.Add(30 * time.Second)
+	ap.Reset()
+	evictions := ap.RecentEvictions()
+	require.Equal(1, len(evictions))
+	require.Equal(tsf2.Hash(), evictions[0].Hash)
+	require.Equal(addr1, evictions[0].Sender)
+	require.Equal(uint64(2), evictions[0].Nonce)
+	require.Equal(EvictedUnpayable, evictions[0].Reason)
+	require.Equal(clk.Now(), evictions[0].Time)
+
+	// tsf1 and tsf3 time out
+	clk.Add(time.Minute)
+	ap.Reset()
+	evictions = ap.RecentEvictions()
+	require.Equal(3, len(evictions))
+	require.Equal(EvictedExpired, evictions[1].Reason)
+	require.Equal(EvictedExpired, evictions[2].Reason)
+	require.ElementsMatch(
+		[]hash.Hash256{tsf1.Hash(), tsf3.Hash()},
+		[]hash.Hash256{evictions[1].Hash, evictions[2].Hash},
+	)
+	require.Equal(uint64(0), ap.GetSize())
+
+	// the records are kept for an hour
+	clk.Add(59 * time.Minute)
+	require.Equal(2, len(ap.RecentEvictions()))
+	clk.Add(time.Minute)
+	require.Empty(ap.RecentEvictions())
+}
+
+func TestActPool_ResetSkipsUnreadableAccount(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// addr1 has 100 at first, and 10 after the block, while the state of addr2 can't be read after the block
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	bc.EXPECT().Nonce(gomock.Any()).Return(uint64(0), nil).AnyTimes()
+	gomock.InOrder(
+		bc.EXPECT().Balance(addr1).Return(big.NewInt(100), nil).Times(1),
+		bc.EXPECT().Balance(addr1).Return(big.NewInt(10), nil).AnyTimes(),
+	)
+	gomock.InOrder(
+		bc.EXPECT().Balance(addr2).Return(big.NewInt(100), nil).Times(1),
+		bc.EXPECT().Balance(addr2).Return(nil, errors.New("state unavailable")).AnyTimes(),
+	)
+	Ap, err := NewActPool(bc, getActPoolCfg())
+	require.NoError(err)
+
+	tsf1, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(50), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr1, priKey2, uint64(1), big.NewInt(50), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	require.NoError(Ap.Add(tsf1))
+	require.NoError(Ap.Add(tsf2))
+
+	// tsf1 is no longer payable regardless of the order the accounts are reset in, while tsf2 is kept as is
+	Ap.Reset()
+	evictions := Ap.RecentEvictions()
+	require.Equal(1, len(evictions))
+	require.Equal(tsf1.Hash(), evictions[0].Hash)
+	require.Equal(EvictedUnpayable, evictions[0].Reason)
+	_, err = Ap.GetActionByHash(tsf2.Hash())
+	require.NoError(err)
+}
+
+func TestActPool_Reset(t *testing.T) {
+	require := require.New(t)
+	bc := blockchain.NewBlockchain(
+		config.Default,
+		blockchain.InMemStateFactoryOption(),
+		blockchain.InMemDaoOption(),
+	)
+	bc.GetFactory().AddActionHandlers(account.NewProtocol(), execution.NewProtocol(bc))
+	require.NoError(bc.Start(context.Background()))
+	_, err := bc.CreateState(addr1, big.NewInt(100))
+	require.NoError(err)
+	_, err = bc.CreateState(addr2, big.NewInt(200))
+	require.NoError(err)
+	_, err = bc.CreateState(addr3, big.NewInt(300))
+	require.NoError(err)
+
+	apConfig := getActPoolCfg()
+	Ap1, err := NewActPool(bc, apConfig, EnableExperimentalActions())
+	require.NoError(err)
+	ap1, ok := Ap1.(*actPool)
+	require.True(ok)
+	ap1.AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesis.Default.ActionGasLimit))
+	ap1.AddActionValidators(account.NewProtocol(), execution.NewProtocol(bc),
+		execution.NewProtocol(bc))
+	Ap2, err := NewActPool(bc, apConfig, EnableExperimentalActions())
+	require.NoError(err)
+	ap2, ok := Ap2.(*actPool)
+	require.True(ok)
+	ap2.AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesis.Default.ActionGasLimit))
+	ap2.AddActionValidators(account.NewProtocol(), execution.NewProtocol(bc),
+		execution.NewProtocol(bc))
+
+	// Tsfs to be added to ap1
+	tsf1, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(50), []byte{}, uint64(20000), big.NewInt(0))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr3, priKey1, uint64(2), big.NewInt(30), []byte{}, uint64(20000), big.NewInt(0))
+	require.NoError(err)
+	tsf3, err := testutil.SignedTransfer(addr2, priKey1, uint64(3), big.NewInt(60), []byte{}, uint64(20000), big.NewInt(0))
+	require.NoError(err)
+	tsf4, err := testutil.SignedTransfer(addr1, priKey2, uint64(1), big.NewInt(100), []byte{}, uint64(20000), big.NewInt(0))
+	require.NoError(err)
+	tsf5, err := testutil.SignedTransfer(addr3, priKey2, uint64(2), big.NewInt(50), []byte{}, uint64(20000), big.NewInt(0))
+	require.NoError(err)
+	tsf6, err := testutil.SignedTransfer(addr1, priKey2, uint64(3), big.NewInt(60), []byte{}, uint64(20000), big.NewInt(0))
+	require.NoError(err)
+	tsf7, err := testutil.SignedTransfer(addr1, priKey3, uint64(1), big.NewInt(100), []byte{}, uint64(20000), big.NewInt(0))
+	require.NoError(err)
+	tsf8, err := testutil.SignedTransfer(addr2, priKey3, uint64(2), big.NewInt(100), []byte{}, uint64(20000), big.NewInt(0))
+	require.NoError(err)
+	tsf9, err := testutil.SignedTransfer(addr1, priKey3, uint64(4), big.NewInt(100), []byte{}, uint64(20000), big.NewInt(0))
+	require.NoError(err)
+
+	err = ap1.Add(tsf1)
+	require.NoError(err)
+	err = ap1.Add(tsf2)
+	require.NoError(err)
+	err = ap1.Add(tsf3)
+	require.Equal(action.ErrBalance, errors.Cause(err))
+	err = ap1.Add(tsf4)
+	require.NoError(err)
+	err = ap1.Add(tsf5)
 	require.NoError(err)
 	err = ap1.Add(tsf6)
 	require.Equal(action.ErrBalance, errors.Cause(err))
@@ -765,8 +1588,11 @@ func TestActPool_Reset(t *testing.T) {
 	require.NoError(err)
 	tsf22, err := testutil.SignedTransfer(addr5, priKey4, uint64(2), big.NewInt(10), []byte{}, uint64(20000), big.NewInt(0))
 	require.NoError(err)
-	tsf23, err := action.NewTransfer(uint64(3), big.NewInt(1), "", []byte{}, uint64(100000), big.NewInt(0))
-	require.NoError(err)
+	_, err = action.NewTransfer(uint64(3), big.NewInt(1), "", []byte{}, uint64(100000), big.NewInt(0))
+	require.Equal(addrutil.ErrLength, errors.Cause(err))
+	// a transfer with invalid recipient can still arrive from the network
+	tsf23 := &action.Transfer{}
+	require.NoError(tsf23.LoadProto(&iotextypes.Transfer{Amount: "1"}))
 
 	bd := &action.EnvelopeBuilder{}
 	elp := bd.SetNonce(3).
@@ -934,6 +1760,40 @@ func TestActPool_GetPendingNonce(t *testing.T) {
 	nonce, err = ap.GetPendingNonce(addr1)
 	require.NoError(err)
 	require.Equal(uint64(2), nonce)
+
+	// the nonce of an account without any action is the confirmed nonce + 1
+	nonce, err = ap.GetPendingNonce(testaddress.Addrinfo["charlie"].String())
+	require.NoError(err)
+	require.Equal(uint64(1), nonce)
+
+	_, err = ap.GetPendingNonce("io1invalid")
+	require.Error(err)
+	require.True(addrutil.IsAddressError(err))
+}
+
+func TestActPool_GetPendingNonceWithGap(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	bc.EXPECT().Nonce(addr1).Return(uint64(5), nil).AnyTimes()
+	bc.EXPECT().Balance(addr1).Return(big.NewInt(1000), nil).AnyTimes()
+	Ap, err := NewActPool(bc, getActPoolCfg())
+	require.NoError(err)
+
+	nonce, err := Ap.GetPendingNonce(addr1)
+	require.NoError(err)
+	require.Equal(uint64(6), nonce)
+	// the pool holds 6, 7 and 9, so 8 is the lowest nonce unused
+	for _, n := range []uint64{9, 6, 7} {
+		tsf, err := testutil.SignedTransfer(addr2, priKey1, n, big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+		require.NoError(err)
+		require.NoError(Ap.Add(tsf))
+	}
+	nonce, err = Ap.GetPendingNonce(addr1)
+	require.NoError(err)
+	require.Equal(uint64(8), nonce)
 }
 
 func TestActPool_GetUnconfirmedActs(t *testing.T) {
@@ -978,6 +1838,58 @@ func TestActPool_GetUnconfirmedActs(t *testing.T) {
 	require.Equal([]action.SealedEnvelope{tsf1, tsf3, tsf4}, acts)
 }
 
+func TestActPool_GetUnconfirmedTransfersAndVotes(t *testing.T) {
+	require := require.New(t)
+	bc := blockchain.NewBlockchain(
+		config.Default,
+		blockchain.InMemStateFactoryOption(),
+		blockchain.InMemDaoOption(),
+	)
+	require.NoError(bc.Start(context.Background()))
+	_, err := bc.CreateState(addr1, big.NewInt(100))
+	require.NoError(err)
+	Ap, err := NewActPool(bc, getActPoolCfg(), EnableExperimentalActions())
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+	ap.AddActionEnvelopeValidators(protocol.NewGenericValidator(bc, genesis.Default.ActionGasLimit))
+	ap.AddActionValidators(account.NewProtocol(), execution.NewProtocol(bc))
+
+	tsf1, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	vote2, err := testutil.SignedVote(addr2, priKey1, uint64(2), uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf3, err := testutil.SignedTransfer(addr2, priKey1, uint64(3), big.NewInt(20), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	// the transfer of nonce 7 waits for the nonces 4 to 6
+	tsf7, err := testutil.SignedTransfer(addr2, priKey1, uint64(7), big.NewInt(30), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	for _, act := range []action.SealedEnvelope{tsf7, tsf3, vote2, tsf1} {
+		require.NoError(ap.Add(act))
+	}
+
+	tsfs := ap.GetUnconfirmedTransfers(addr1)
+	require.Equal(3, len(tsfs))
+	for i, tsf := range []action.SealedEnvelope{tsf1, tsf3, tsf7} {
+		require.Equal(tsf.Nonce(), tsfs[i].Nonce())
+		require.Equal(tsf.Hash(), tsfs[i].Hash())
+		require.False(tsf.Action() == tsfs[i], "the transfer in pool is returned rather than a copy")
+	}
+	// the copies share nothing with the transfers in pool
+	tsfs[0].Amount().SetInt64(1000)
+	tsfs = ap.GetUnconfirmedTransfers(addr1)
+	require.Equal(big.NewInt(10), tsfs[0].Amount())
+	votes := ap.GetUnconfirmedVotes(addr1)
+	require.Equal(1, len(votes))
+	require.Equal(vote2.Hash(), votes[0].Hash())
+	require.False(votes[0].SrcPubkey() == vote2.SrcPubkey(), "the public key in pool is shared by the copy")
+	require.Empty(ap.GetUnconfirmedTransfers(addr2))
+	require.Empty(ap.GetUnconfirmedVotes(addr2))
+
+	// the transfer of nonce 7 isn't picked into a block
+	require.Equal([]action.SealedEnvelope{tsf1, vote2, tsf3}, ap.PendingActionMap()[addr1])
+}
+
 func TestActPool_GetActionByHash(t *testing.T) {
 	require := require.New(t)
 	bc := blockchain.NewBlockchain(
@@ -1018,6 +1930,368 @@ func TestActPool_GetActionByHash(t *testing.T) {
 	require.Equal(tsf2, act)
 }
 
+func TestActPool_EvictLeastValuable(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	bc.EXPECT().Nonce(gomock.Any()).Return(uint64(0), nil).AnyTimes()
+	bc.EXPECT().Balance(gomock.Any()).DoAndReturn(func(string) (*big.Int, error) {
+		return big.NewInt(1000000), nil
+	}).AnyTimes()
+	apConfig := getActPoolCfg()
+	apConfig.MaxNumActsPerPool = 4
+	apConfig.PrioritySenders = []string{addr2}
+	Ap, err := NewActPool(bc, apConfig)
+	require.NoError(err)
+
+	transfer := func(priKey keypair.PrivateKey, nonce uint64, gasPrice int64) action.SealedEnvelope {
+		tsf, err := testutil.SignedTransfer(addr6, priKey, nonce, big.NewInt(1), []byte{}, uint64(100000),
+			big.NewInt(gasPrice))
+		require.NoError(err)
+		return tsf
+	}
+	acts1 := []action.SealedEnvelope{transfer(priKey1, 1, 1), transfer(priKey1, 2, 1), transfer(priKey1, 3, 1)}
+	for _, act := range acts1 {
+		require.NoError(Ap.Add(act))
+	}
+	require.NoError(Ap.Add(transfer(priKey2, 1, 1)))
+	require.Equal(uint64(4), Ap.GetSize())
+
+	// the last action of the largest queue makes room for the one of the same gas price of a new sender
+	require.NoError(Ap.Add(transfer(priKey3, 1, 1)))
+	// but not for another one, as the queues would be as large
+	require.Equal(ErrPoolFull, errors.Cause(Ap.Add(transfer(priKey3, 2, 1))))
+	// unless it offers a higher gas price
+	require.NoError(Ap.Add(transfer(priKey3, 2, 2)))
+	// the actions of the priority sender are kept, even if they are of the lowest gas price
+	require.NoError(Ap.Add(transfer(priKey4, 1, 5)))
+	require.Equal(uint64(4), Ap.GetSize())
+	require.Empty(Ap.GetUnconfirmedActs(addr1))
+	require.Len(Ap.GetUnconfirmedActs(addr2), 1)
+
+	evictions := Ap.RecentEvictions()
+	require.Len(evictions, 3)
+	for i, e := range evictions {
+		require.Equal(acts1[2-i].Hash(), e.Hash)
+		require.Equal(EvictedOutranked, e.Reason)
+	}
+}
+
+func TestActPool_ReplaceInFullPool(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	bc.EXPECT().Nonce(gomock.Any()).Return(uint64(0), nil).AnyTimes()
+	bc.EXPECT().Balance(gomock.Any()).DoAndReturn(func(string) (*big.Int, error) {
+		return big.NewInt(1000000), nil
+	}).AnyTimes()
+	transfer := func(priKey keypair.PrivateKey, nonce uint64, gasPrice int64) action.SealedEnvelope {
+		tsf, err := testutil.SignedTransfer(addr6, priKey, nonce, big.NewInt(1), []byte{}, uint64(100000),
+			big.NewInt(gasPrice))
+		require.NoError(err)
+		return tsf
+	}
+	acts := []action.SealedEnvelope{transfer(priKey1, 1, 1), transfer(priKey1, 2, 1)}
+	intrinsicGas, err := acts[0].IntrinsicGas()
+	require.NoError(err)
+
+	// the pool is full of the actions of the only sender, either by the number of actions or by the gas
+	for _, c := range []struct {
+		maxActs, maxGas uint64
+	}{{2, maxGasLimitPerPool}, {maxNumActsPerPool, 2 * intrinsicGas}} {
+		apConfig := getActPoolCfg()
+		apConfig.MaxNumActsPerPool = c.maxActs
+		apConfig.MaxGasLimitPerPool = c.maxGas
+		Ap, err := NewActPool(bc, apConfig)
+		require.NoError(err)
+		for _, act := range acts {
+			require.NoError(Ap.Add(act))
+		}
+		require.Equal(ErrPoolFull, errors.Cause(Ap.Add(transfer(priKey1, 3, 2))))
+
+		// the fee bump of a pending action takes no more space, and evicts nothing but the action replaced
+		bump := transfer(priKey1, 2, 2)
+		require.NoError(Ap.Add(bump))
+		require.Equal(uint64(2), Ap.GetSize())
+		require.Equal(2*intrinsicGas, Ap.GetGasSize())
+		require.Equal([]action.SealedEnvelope{acts[0], bump}, Ap.GetUnconfirmedActs(addr1))
+		evictions := Ap.RecentEvictions()
+		require.Len(evictions, 1)
+		require.Equal(acts[1].Hash(), evictions[0].Hash)
+		require.Equal(EvictedReplaced, evictions[0].Reason)
+	}
+
+	// the fee bump of a sender sharing the full pool with another, whose last action it would outrank, doesn't evict it
+	apConfig := getActPoolCfg()
+	apConfig.MaxNumActsPerPool = 3
+	Ap, err := NewActPool(bc, apConfig)
+	require.NoError(err)
+	for _, act := range append(acts, transfer(priKey2, 1, 1)) {
+		require.NoError(Ap.Add(act))
+	}
+	require.NoError(Ap.Add(transfer(priKey2, 1, 5)))
+	require.Equal(uint64(3), Ap.GetSize())
+	require.Equal(acts, Ap.GetUnconfirmedActs(addr1))
+	evictions := Ap.RecentEvictions()
+	require.Len(evictions, 1)
+	require.Equal(EvictedReplaced, evictions[0].Reason)
+}
+
+func TestActPool_DeterministicPickOrder(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	bc.EXPECT().Nonce(gomock.Any()).Return(uint64(0), nil).AnyTimes()
+	// every pool takes a balance of its own, as the pending balance is spent in place
+	bc.EXPECT().Balance(gomock.Any()).DoAndReturn(func(string) (*big.Int, error) {
+		return big.NewInt(1000000), nil
+	}).AnyTimes()
+
+	// 10 actions of each of 5 senders, where many of the heads of the senders tie on the gas price
+	priKeys := []keypair.PrivateKey{priKey1, priKey2, priKey3, priKey4, priKey5}
+	var acts []action.SealedEnvelope
+	for _, priKey := range priKeys {
+		for nonce := uint64(1); nonce <= 10; nonce++ {
+			tsf, err := testutil.SignedTransfer(addr6, priKey, nonce, big.NewInt(1), []byte{}, uint64(100000),
+				big.NewInt(int64(nonce%3)))
+			require.NoError(err)
+			acts = append(acts, tsf)
+		}
+	}
+	pickOrder := func(acts []action.SealedEnvelope) []hash.Hash256 {
+		ap, err := NewActPool(bc, getActPoolCfg())
+		require.NoError(err)
+		for _, act := range acts {
+			require.NoError(ap.Add(act))
+		}
+		var hashes []hash.Hash256
+		iter := actioniterator.NewPriorityActionIterator(ap.PendingActionMap(), nil)
+		for {
+			act, ok := iter.Next()
+			if !ok {
+				return hashes
+			}
+			hashes = append(hashes, act.Hash())
+		}
+	}
+
+	// the fresh pools taking the same actions in different orders pick them in the same order
+	picked := pickOrder(acts)
+	require.Len(picked, 50)
+	reversed := make([]action.SealedEnvelope, 0, len(acts))
+	for i := len(priKeys) - 1; i >= 0; i-- {
+		reversed = append(reversed, acts[i*10:(i+1)*10]...)
+	}
+	require.Equal(picked, pickOrder(reversed))
+	require.Equal(picked, pickOrder(acts))
+}
+
+func TestActPool_GetPickRank(t *testing.T) {
+	require := require.New(t)
+	bc := blockchain.NewBlockchain(
+		config.Default,
+		blockchain.InMemStateFactoryOption(),
+		blockchain.InMemDaoOption(),
+	)
+	require.NoError(bc.Start(context.Background()))
+	_, err := bc.CreateState(addr1, big.NewInt(10000000))
+	require.NoError(err)
+	_, err = bc.CreateState(addr2, big.NewInt(10000000))
+	require.NoError(err)
+	// Create actpool
+	apConfig := getActPoolCfg()
+	ap, err := NewActPool(bc, apConfig, EnableExperimentalActions())
+	require.NoError(err)
+
+	tsf1, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(1))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr2, priKey1, uint64(2), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(3))
+	require.NoError(err)
+	tsf3, err := testutil.SignedTransfer(addr1, priKey2, uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(2))
+	require.NoError(err)
+	tsf4, err := testutil.SignedTransfer(addr2, priKey1, uint64(4), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(5))
+	require.NoError(err)
+	for _, tsf := range []action.SealedEnvelope{tsf1, tsf2, tsf3, tsf4} {
+		require.NoError(ap.Add(tsf))
+	}
+
+	// tsf3 has a higher gas price than tsf1, and tsf2 can only be picked after tsf1
+	for i, tsf := range []action.SealedEnvelope{tsf3, tsf1, tsf2} {
+		rank, total, err := ap.GetPickRank(tsf.Hash())
+		require.NoError(err)
+		require.Equal(i+1, rank)
+		require.Equal(3, total)
+	}
+	// tsf4 is in pool but not pickable due to the nonce gap
+	_, total, err := ap.GetPickRank(tsf4.Hash())
+	require.Equal(action.ErrNonce, errors.Cause(err))
+	require.Equal(3, total)
+	// unknown action
+	_, _, err = ap.GetPickRank(hash.ZeroHash256)
+	require.Equal(action.ErrHash, errors.Cause(err))
+
+	// the actions of a priority sender are picked first regardless of the gas price
+	apConfig.PrioritySenders = []string{addr1}
+	ap, err = NewActPool(bc, apConfig, EnableExperimentalActions())
+	require.NoError(err)
+	for _, tsf := range []action.SealedEnvelope{tsf1, tsf2, tsf3} {
+		require.NoError(ap.Add(tsf))
+	}
+	for i, tsf := range []action.SealedEnvelope{tsf1, tsf2, tsf3} {
+		rank, _, err := ap.GetPickRank(tsf.Hash())
+		require.NoError(err)
+		require.Equal(i+1, rank)
+	}
+
+	// the actions are picked in the order they arrive regardless of the gas price, but still in nonce order
+	apConfig.PrioritySenders = nil
+	apConfig.Selection = config.FIFOSelection
+	ap, err = NewActPool(bc, apConfig, EnableExperimentalActions())
+	require.NoError(err)
+	for _, tsf := range []action.SealedEnvelope{tsf2, tsf3, tsf1} {
+		require.NoError(ap.Add(tsf))
+	}
+	for i, tsf := range []action.SealedEnvelope{tsf3, tsf1, tsf2} {
+		rank, _, err := ap.GetPickRank(tsf.Hash())
+		require.NoError(err)
+		require.Equal(i+1, rank)
+	}
+	pending := ap.PendingActionMapWithLimit(1, 0)
+	require.Equal([]action.SealedEnvelope{tsf3}, pending[addr2])
+	require.Empty(pending[addr1])
+}
+
+func TestActPool_GetPendingByArrival(t *testing.T) {
+	require := require.New(t)
+	bc := blockchain.NewBlockchain(
+		config.Default,
+		blockchain.InMemStateFactoryOption(),
+		blockchain.InMemDaoOption(),
+	)
+	require.NoError(bc.Start(context.Background()))
+	_, err := bc.CreateState(addr1, big.NewInt(10000000))
+	require.NoError(err)
+	_, err = bc.CreateState(addr2, big.NewInt(10000000))
+	require.NoError(err)
+	// Create actpool
+	Ap, err := NewActPool(bc, getActPoolCfg(), EnableExperimentalActions())
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+	clk := clock.NewMock()
+	ap.clock = clk
+
+	tsf1, err := testutil.SignedTransfer(addr2, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(1))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr2, priKey1, uint64(2), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(3))
+	require.NoError(err)
+	tsf3, err := testutil.SignedTransfer(addr1, priKey2, uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(2))
+	require.NoError(err)
+	tsf4, err := testutil.SignedTransfer(addr2, priKey1, uint64(4), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(5))
+	require.NoError(err)
+	// tsf3 and tsf2 arrive at the same time
+	arrivals := []time.Time{clk.Now(), clk.Now(), clk.Now().Add(time.Second)}
+	require.NoError(ap.Add(tsf3))
+	require.NoError(ap.Add(tsf2))
+	clk.Add(time.Second)
+	require.NoError(ap.Add(tsf1))
+	clk.Add(time.Second)
+	require.NoError(ap.Add(tsf4))
+
+	// tsf4 isn't pending due to the nonce gap
+	pending := ap.GetPendingByArrival()
+	require.Len(pending, 3)
+	for i, tsf := range []action.SealedEnvelope{tsf3, tsf2, tsf1} {
+		require.Equal(tsf.Hash(), pending[i].Action.Hash())
+		require.Equal(arrivals[i], pending[i].Arrival)
+	}
+}
+
+type eventCollector struct {
+	events []ActionEvent
+}
+
+func (c *eventCollector) HandleActionEvent(e ActionEvent) { c.events = append(c.events, e) }
+
+func TestActPool_Subscriber(t *testing.T) {
+	require := require.New(t)
+	bc := blockchain.NewBlockchain(
+		config.Default,
+		blockchain.InMemStateFactoryOption(),
+		blockchain.InMemDaoOption(),
+		blockchain.EnableExperimentalActions(),
+	)
+	bc.GetFactory().AddActionHandlers(account.NewProtocol(), execution.NewProtocol(bc))
+	require.NoError(bc.Start(context.Background()))
+	_, err := bc.CreateState(addr1, big.NewInt(100))
+	require.NoError(err)
+	// Create actpool
+	apConfig := getActPoolCfg()
+	ap, err := NewActPool(bc, apConfig, EnableExperimentalActions())
+	require.NoError(err)
+	require.Error(ap.AddSubscriber(nil))
+	c := &eventCollector{}
+	require.NoError(ap.AddSubscriber(c))
+
+	tsf1, err := testutil.SignedTransfer(addr1, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr1, priKey1, uint64(2), big.NewInt(20), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf3, err := testutil.SignedTransfer(addr1, priKey1, uint64(3), big.NewInt(30), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	require.NoError(ap.Add(tsf1))
+	require.NoError(ap.Add(tsf2))
+	// Rejected action emits no event
+	require.Error(ap.Add(tsf2))
+	require.Equal([]ActionEvent{{ActionAdded, tsf1}, {ActionAdded, tsf2}}, c.events)
+
+	// Confirm tsf1 and remove it from pool
+	sf := bc.GetFactory()
+	ws, err := sf.NewWorkingSet()
+	require.NoError(err)
+	ctx := protocol.WithRunActionsCtx(context.Background(),
+		protocol.RunActionsCtx{
+			Producer: testaddress.Addrinfo["producer"],
+			GasLimit: uint64(1000000),
+		})
+	_, err = ws.RunActions(ctx, 0, []action.SealedEnvelope{tsf1})
+	require.NoError(err)
+	require.NoError(sf.Commit(ws))
+	ap.Reset()
+	require.Equal(3, len(c.events))
+	require.Equal(ActionEvent{ActionRemoved, tsf1}, c.events[2])
+
+	require.NoError(ap.RemoveSubscriber(c))
+	require.Error(ap.RemoveSubscriber(c))
+	require.NoError(ap.Add(tsf3))
+	require.Equal(3, len(c.events))
+}
+
+func TestActionChan(t *testing.T) {
+	require := require.New(t)
+
+	tsf1, err := testutil.SignedTransfer(addr1, priKey1, uint64(1), big.NewInt(10), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	tsf2, err := testutil.SignedTransfer(addr1, priKey1, uint64(2), big.NewInt(20), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	ch := make(chan action.SealedEnvelope, 1)
+	c := NewActionChan(ch)
+	// the action removed isn't relayed, and the one beyond the capacity is dropped rather than blocking
+	c.HandleActionEvent(ActionEvent{ActionRemoved, tsf1})
+	c.HandleActionEvent(ActionEvent{ActionAdded, tsf1})
+	c.HandleActionEvent(ActionEvent{ActionAdded, tsf2})
+	require.Len(ch, 1)
+	require.Equal(tsf1, <-ch)
+	c.HandleActionEvent(ActionEvent{ActionAdded, tsf2})
+	require.Equal(tsf2, <-ch)
+}
+
 func TestActPool_GetCapacity(t *testing.T) {
 	require := require.New(t)
 	bc := blockchain.NewBlockchain(config.Default, blockchain.InMemStateFactoryOption(), blockchain.InMemDaoOption())
@@ -1087,6 +2361,145 @@ func TestActPool_GetSize(t *testing.T) {
 	require.Equal(uint64(0), ap.GetGasSize())
 }
 
+func TestActPool_GasPricePercentiles(t *testing.T) {
+	require := require.New(t)
+	bc := blockchain.NewBlockchain(
+		config.Default,
+		blockchain.InMemStateFactoryOption(),
+		blockchain.InMemDaoOption(),
+	)
+	bc.GetFactory().AddActionHandlers(account.NewProtocol())
+	require.NoError(bc.Start(context.Background()))
+	_, err := bc.CreateState(addr1, big.NewInt(1000000))
+	require.NoError(err)
+	_, err = bc.CreateState(addr2, big.NewInt(1000000))
+	require.NoError(err)
+	// Create actpool
+	apConfig := getActPoolCfg()
+	ap, err := NewActPool(bc, apConfig)
+	require.NoError(err)
+	require.Empty(ap.GasPricePercentiles())
+
+	var acts []action.SealedEnvelope
+	for i, price := range []int64{4, 1, 3, 2} {
+		tsf, err := testutil.SignedTransfer(addr1, priKey1, uint64(i+1), big.NewInt(10), []byte{}, uint64(20000), big.NewInt(price))
+		require.NoError(err)
+		require.NoError(ap.Add(tsf))
+		acts = append(acts, tsf)
+	}
+	tsf, err := testutil.SignedTransfer(addr2, priKey2, uint64(1), big.NewInt(10), []byte{}, uint64(20000), big.NewInt(5))
+	require.NoError(err)
+	require.NoError(ap.Add(tsf))
+	// gas prices in pool are 1, 2, 3, 4, 5
+	require.Equal(map[int]*big.Int{25: big.NewInt(2), 50: big.NewInt(3), 75: big.NewInt(4)}, ap.GasPricePercentiles())
+
+	// Confirm the first two actions of addr1, whose gas prices are 4 and 1
+	sf := bc.GetFactory()
+	ws, err := sf.NewWorkingSet()
+	require.NoError(err)
+	ctx := protocol.WithRunActionsCtx(context.Background(),
+		protocol.RunActionsCtx{
+			Producer: testaddress.Addrinfo["producer"],
+			GasLimit: uint64(1000000),
+		})
+	_, err = ws.RunActions(ctx, 0, acts[:2])
+	require.NoError(err)
+	require.NoError(sf.Commit(ws))
+	ap.Reset()
+	// gas prices in pool are 2, 3, 5
+	require.Equal(map[int]*big.Int{25: big.NewInt(2), 50: big.NewInt(3), 75: big.NewInt(5)}, ap.GasPricePercentiles())
+}
+
+func TestActPool_SizeStats(t *testing.T) {
+	require := require.New(t)
+	bc := blockchain.NewBlockchain(
+		config.Default,
+		blockchain.InMemStateFactoryOption(),
+		blockchain.InMemDaoOption(),
+	)
+	bc.GetFactory().AddActionHandlers(account.NewProtocol())
+	require.NoError(bc.Start(context.Background()))
+	_, err := bc.CreateState(addr1, big.NewInt(1000000))
+	require.NoError(err)
+	// Create actpool
+	apConfig := getActPoolCfg()
+	ap, err := NewActPool(bc, apConfig)
+	require.NoError(err)
+	total, avg, max := ap.SizeStats()
+	require.Equal(0, total)
+	require.Equal(0, avg)
+	require.Equal(0, max)
+	require.Equal(int64(0), ap.MemoryUsage())
+
+	var acts []action.SealedEnvelope
+	var sizes []int
+	for i, payloadSize := range []int{0, 300, 100} {
+		tsf, err := testutil.SignedTransfer(addr1, priKey1, uint64(i+1), big.NewInt(10), make([]byte, payloadSize),
+			uint64(100000), big.NewInt(0))
+		require.NoError(err)
+		require.NoError(ap.Add(tsf))
+		acts = append(acts, tsf)
+		sizes = append(sizes, proto.Size(tsf.Proto()))
+	}
+	total, avg, max = ap.SizeStats()
+	require.Equal(sizes[0]+sizes[1]+sizes[2], total)
+	require.Equal(total/3, avg)
+	require.Equal(sizes[1], max)
+	require.Equal(int64(total+3*actionOverhead+accountOverhead), ap.MemoryUsage())
+
+	// Confirm the first two actions
+	sf := bc.GetFactory()
+	ws, err := sf.NewWorkingSet()
+	require.NoError(err)
+	ctx := protocol.WithRunActionsCtx(context.Background(),
+		protocol.RunActionsCtx{
+			Producer: testaddress.Addrinfo["producer"],
+			GasLimit: uint64(1000000),
+		})
+	_, err = ws.RunActions(ctx, 0, acts[:2])
+	require.NoError(err)
+	require.NoError(sf.Commit(ws))
+	ap.Reset()
+	total, avg, max = ap.SizeStats()
+	require.Equal(sizes[2], total)
+	require.Equal(sizes[2], avg)
+	require.Equal(sizes[2], max)
+	require.Equal(int64(sizes[2]+actionOverhead+accountOverhead), ap.MemoryUsage())
+}
+
+func TestActPool_Stats(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bc := mock_blockchain.NewMockBlockchain(ctrl)
+	bc.EXPECT().Nonce(gomock.Any()).Return(uint64(0), nil).AnyTimes()
+	bc.EXPECT().Balance(gomock.Any()).Return(big.NewInt(1000), nil).AnyTimes()
+	ap, err := NewActPool(bc, getActPoolCfg(), EnableExperimentalActions())
+	require.NoError(err)
+	require.Equal(ActPoolStats{}, ap.Stats())
+
+	var acts []action.SealedEnvelope
+	for nonce := uint64(1); nonce <= 2; nonce++ {
+		tsf, err := testutil.SignedTransfer(addr2, priKey1, nonce, big.NewInt(10), make([]byte, 100*nonce),
+			uint64(100000), big.NewInt(0))
+		require.NoError(err)
+		acts = append(acts, tsf)
+	}
+	vote, err := testutil.SignedVote(addr1, priKey2, 1, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	acts = append(acts, vote)
+	size := 0
+	for _, act := range acts {
+		require.NoError(ap.Add(act))
+		size += proto.Size(act.Proto())
+	}
+	require.Equal(ActPoolStats{Transfers: 2, Votes: 1, Accounts: 2, Bytes: size}, ap.Stats())
+
+	ap.DropAccount(addr1)
+	require.Equal(ActPoolStats{Transfers: 0, Votes: 1, Accounts: 1, Bytes: proto.Size(vote.Proto())}, ap.Stats())
+}
+
 func TestActPool_AddActionNotEnoughGasPride(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1116,6 +2529,61 @@ func TestActPool_AddActionNotEnoughGasPride(t *testing.T) {
 	require.Error(t, ap.Add(tsf))
 }
 
+func TestActPool_ConfigLimits(t *testing.T) {
+	require := require.New(t)
+	bc := blockchain.NewBlockchain(
+		config.Default,
+		blockchain.InMemStateFactoryOption(),
+		blockchain.InMemDaoOption(),
+		blockchain.EnableExperimentalActions(),
+	)
+	require.NoError(bc.Start(context.Background()))
+	defer func() {
+		require.NoError(bc.Stop(context.Background()))
+	}()
+	_, err := bc.CreateState(addr1, big.NewInt(100))
+	require.NoError(err)
+
+	apConfig := getActPoolCfg()
+	apConfig.MinTransferAmountStr = "5"
+	apConfig.MaxTransferPayloadSize = 2
+	apConfig.MaxNonceGap = 3
+	apConfig.SenderRateLimit = 2
+	apConfig.SenderRateWindow = time.Minute
+	Ap, err := NewActPool(bc, apConfig, EnableExperimentalActions())
+	require.NoError(err)
+	ap, ok := Ap.(*actPool)
+	require.True(ok)
+	clk := clock.NewMock()
+	ap.clock = clk
+
+	transfer := func(nonce uint64, amount int64) action.SealedEnvelope {
+		tsf, err := testutil.SignedTransfer(addr2, priKey1, nonce, big.NewInt(amount), []byte{}, uint64(100000), big.NewInt(0))
+		require.NoError(err)
+		return tsf
+	}
+	// The amount is lower than the minimal transfer amount
+	require.Error(ap.Add(transfer(1, 4)))
+	// The payload is larger than the maximal transfer payload size
+	tsf, err := testutil.SignedTransfer(addr2, priKey1, 1, big.NewInt(5), []byte{1, 2, 3}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	require.Equal(action.ErrActPool, errors.Cause(ap.Add(tsf)))
+	// The nonce is beyond the nonce gap
+	require.Equal(action.ErrNonce, errors.Cause(ap.Add(transfer(4, 5))))
+	require.NoError(ap.Add(transfer(1, 5)))
+	require.NoError(ap.Add(transfer(2, 5)))
+	// The sender has added 2 actions within the window
+	require.Equal(action.ErrActPool, errors.Cause(ap.Add(transfer(3, 5))))
+	clk.Add(time.Minute)
+	require.NoError(ap.Add(transfer(3, 5)))
+
+	ap.Reset()
+	require.Len(ap.senderRates, 1)
+	clk.Add(time.Minute)
+	ap.Reset()
+	require.Len(ap.senderRates, 0)
+}
+
 // Helper function to return the correct pending nonce just in case of empty queue
 func (ap *actPool) getPendingNonce(addr string) (uint64, error) {
 	if queue, ok := ap.accountActs[addr]; ok {
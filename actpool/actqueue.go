@@ -52,8 +52,13 @@ func (h *noncePriorityQueue) Pop() interface{} {
 type ActQueue interface {
 	Overlaps(action.SealedEnvelope) bool
 	Put(action.SealedEnvelope) error
+	Get(uint64) (action.SealedEnvelope, bool)
+	Tail() (action.SealedEnvelope, bool)
+	Replace(action.SealedEnvelope) action.SealedEnvelope
 	FilterNonce(uint64) []action.SealedEnvelope
+	RemoveFrom(uint64) []action.SealedEnvelope
 	UpdateQueue(uint64) []action.SealedEnvelope
+	CleanTimeout() []action.SealedEnvelope
 	SetPendingNonce(uint64)
 	PendingNonce() uint64
 	SetPendingBalance(*big.Int)
@@ -120,6 +125,41 @@ func (q *actQueue) Put(act action.SealedEnvelope) error {
 	return nil
 }
 
+// Get returns the action of the nonce in the queue
+func (q *actQueue) Get(nonce uint64) (action.SealedEnvelope, bool) {
+	act, ok := q.items[nonce]
+	return act, ok
+}
+
+// Tail returns the action of the highest nonce in the queue
+func (q *actQueue) Tail() (action.SealedEnvelope, bool) {
+	if q.index.Len() == 0 {
+		return action.SealedEnvelope{}, false
+	}
+	last := q.index[0].nonce
+	for _, n := range q.index[1:] {
+		if n.nonce > last {
+			last = n.nonce
+		}
+	}
+	return q.items[last], true
+}
+
+// Replace replaces the action of the same nonce in the queue with act, which stays in the queue for the full ttl from
+// now, and returns the action replaced
+func (q *actQueue) Replace(act action.SealedEnvelope) action.SealedEnvelope {
+	nonce := act.Nonce()
+	replaced := q.items[nonce]
+	q.items[nonce] = act
+	for i := range q.index {
+		if q.index[i].nonce == nonce {
+			q.index[i].deadline = q.clock.Now().Add(q.ttl)
+			break
+		}
+	}
+	return replaced
+}
+
 // FilterNonce removes all actions from the map with a nonce lower than the given threshold
 func (q *actQueue) FilterNonce(threshold uint64) []action.SealedEnvelope {
 	var removed []action.SealedEnvelope
@@ -132,19 +172,62 @@ func (q *actQueue) FilterNonce(threshold uint64) []action.SealedEnvelope {
 	return removed
 }
 
+// RemoveFrom removes the action of the nonce and all the actions of the higher nonces, which would otherwise be left
+// behind a nonce gap, and gives the pending balance the removed pending actions took back
+func (q *actQueue) RemoveFrom(nonce uint64) []action.SealedEnvelope {
+	sort.Sort(q.index)
+	i := sort.Search(q.index.Len(), func(i int) bool { return q.index[i].nonce >= nonce })
+	removed := q.removeActs(i)
+	for _, act := range removed {
+		if act.Nonce() < q.pendingNonce {
+			cost, _ := act.Cost()
+			q.pendingBalance.Add(q.pendingBalance, cost)
+		}
+	}
+	if nonce < q.pendingNonce {
+		q.pendingNonce = nonce
+	}
+	return removed
+}
+
 func (q *actQueue) cleanTimeout() []action.SealedEnvelope {
 	removedFromQueue := make([]action.SealedEnvelope, 0)
-	for i := 0; i < len(q.index); i++ {
-		if q.clock.Now().After(q.index[i].deadline) {
-			// remove
-			removedFromQueue = append(removedFromQueue, q.items[q.index[i].nonce])
-			delete(q.items, q.index[i].nonce)
-			q.index = append(q.index[:i], q.index[i+1:]...)
+	now := q.clock.Now()
+	kept := q.index[:0]
+	for _, n := range q.index {
+		if now.After(n.deadline) {
+			removedFromQueue = append(removedFromQueue, q.items[n.nonce])
+			delete(q.items, n.nonce)
+			continue
 		}
+		kept = append(kept, n)
 	}
+	q.index = kept
+	heap.Init(&q.index)
 	return removedFromQueue
 }
 
+// CleanTimeout removes the actions which have been in the queue longer than the ttl, along with the actions of the
+// higher nonces, which would otherwise be left behind a nonce gap
+func (q *actQueue) CleanTimeout() []action.SealedEnvelope {
+	if q.ttl == 0 {
+		return nil
+	}
+	now := q.clock.Now()
+	expired := false
+	var lowest uint64
+	for _, n := range q.index {
+		if now.After(n.deadline) && (!expired || n.nonce < lowest) {
+			expired = true
+			lowest = n.nonce
+		}
+	}
+	if !expired {
+		return nil
+	}
+	return q.RemoveFrom(lowest)
+}
+
 // UpdateQueue updates the pending nonce and balance of the queue
 func (q *actQueue) UpdateQueue(nonce uint64) []action.SealedEnvelope {
 	removedFromQueue := make([]action.SealedEnvelope, 0)
@@ -234,7 +317,7 @@ func (q *actQueue) PendingActs() []action.SealedEnvelope {
 	acts := make([]action.SealedEnvelope, 0, len(q.items))
 	confirmedNonce, err := q.ap.bc.Nonce(q.address)
 	if err != nil {
-		log.L().Error("Error when getting the nonce", zap.String("address", q.address), zap.Error(err))
+		log.Logger("actpool").Error("Error when getting the nonce", zap.String("address", q.address), zap.Error(err))
 		return nil
 	}
 	nonce := confirmedNonce + 1
@@ -98,6 +98,47 @@ func TestActQueueFilterNonce(t *testing.T) {
 	require.Equal(tsf3, q.items[q.index[0].nonce])
 }
 
+func TestActQueueTail(t *testing.T) {
+	require := require.New(t)
+	q := NewActQueue(nil, "").(*actQueue)
+	_, ok := q.Tail()
+	require.False(ok)
+	for _, nonce := range []uint64{2, 5, 1, 3} {
+		tsf, err := testutil.SignedTransfer(addr2, priKey1, nonce, big.NewInt(10), nil, uint64(0), big.NewInt(0))
+		require.NoError(err)
+		require.NoError(q.Put(tsf))
+	}
+	tail, ok := q.Tail()
+	require.True(ok)
+	require.Equal(uint64(5), tail.Nonce())
+}
+
+func TestActQueueRemoveFrom(t *testing.T) {
+	require := require.New(t)
+	q := NewActQueue(nil, "").(*actQueue)
+	var acts []action.SealedEnvelope
+	for nonce := uint64(1); nonce <= 4; nonce++ {
+		tsf, err := testutil.SignedTransfer(addr2, priKey1, nonce, big.NewInt(10), nil, uint64(0), big.NewInt(0))
+		require.NoError(err)
+		require.NoError(q.Put(tsf))
+		acts = append(acts, tsf)
+	}
+	q.pendingBalance = big.NewInt(100)
+	q.UpdateQueue(uint64(1))
+	require.Equal(uint64(5), q.pendingNonce)
+	require.Equal(big.NewInt(60), q.pendingBalance)
+
+	// the actions of nonce 3 and 4 are removed, and their pending balance is given back
+	require.Equal(acts[2:], q.RemoveFrom(3))
+	require.Equal(acts[:2], q.AllActs())
+	require.Equal(uint64(3), q.pendingNonce)
+	require.Equal(big.NewInt(80), q.pendingBalance)
+	require.Empty(q.RemoveFrom(5))
+	require.Equal(acts[:2], q.RemoveFrom(0))
+	require.True(q.Empty())
+	require.Equal(uint64(0), q.pendingNonce)
+}
+
 func TestActQueueUpdateNonce(t *testing.T) {
 	require := require.New(t)
 	q := NewActQueue(nil, "").(*actQueue)
@@ -233,4 +274,33 @@ func TestActQueueTimeOutAction(t *testing.T) {
 	c.Add(2 * time.Minute)
 	q.(*actQueue).cleanTimeout()
 	assert.Equal(t, 1, q.Len())
+
+	// the actions timing out together are all removed
+	for nonce := uint64(4); nonce <= 6; nonce++ {
+		tsf, err := testutil.SignedTransfer(addr2, priKey1, nonce, big.NewInt(100), nil, uint64(0), big.NewInt(0))
+		require.NoError(t, err)
+		require.NoError(t, q.Put(tsf))
+	}
+	c.Add(4 * time.Minute)
+	assert.Equal(t, 4, len(q.(*actQueue).cleanTimeout()))
+	assert.True(t, q.Empty())
+	assert.Equal(t, 0, len(q.(*actQueue).index))
+
+	// the actions of the higher nonces are removed along with the one timing out
+	q.SetPendingNonce(4)
+	for nonce := uint64(4); nonce <= 6; nonce++ {
+		tsf, err := testutil.SignedTransfer(addr2, priKey1, nonce, big.NewInt(100), nil, uint64(0), big.NewInt(0))
+		require.NoError(t, err)
+		require.NoError(t, q.Put(tsf))
+		c.Add(time.Minute)
+	}
+	assert.Nil(t, q.CleanTimeout())
+	c.Add(time.Minute)
+	removed := q.CleanTimeout()
+	require.Equal(t, 3, len(removed))
+	for i, act := range removed {
+		assert.Equal(t, uint64(4+i), act.Nonce())
+	}
+	assert.True(t, q.Empty())
+	assert.Equal(t, uint64(4), q.PendingNonce())
 }
@@ -0,0 +1,48 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"math/big"
+	"sort"
+)
+
+// GasPricePercentiles are the percentiles returned by ActPool.GasPricePercentiles
+var GasPricePercentiles = []int{25, 50, 75}
+
+// gasPriceList keeps the gas prices of the actions in pool in ascending order, so that the percentiles could be read
+// without sorting the whole pool on every query
+type gasPriceList []*big.Int
+
+// add inserts a gas price into the list
+func (l *gasPriceList) add(price *big.Int) {
+	i := l.search(price)
+	*l = append(*l, nil)
+	copy((*l)[i+1:], (*l)[i:])
+	(*l)[i] = price
+}
+
+// remove removes a gas price from the list
+func (l *gasPriceList) remove(price *big.Int) {
+	i := l.search(price)
+	if i < len(*l) && (*l)[i].Cmp(price) == 0 {
+		*l = append((*l)[:i], (*l)[i+1:]...)
+	}
+}
+
+// percentile returns the gas price of the p-th percentile by the nearest-rank method
+func (l gasPriceList) percentile(p int) *big.Int {
+	rank := (p*len(l) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return new(big.Int).Set(l[rank-1])
+}
+
+func (l gasPriceList) search(price *big.Int) int {
+	return sort.Search(len(l), func(i int) bool { return l[i].Cmp(price) >= 0 })
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+const (
+	// recordHeaderSize is the number of bytes of the size prefixing each action in the journal
+	recordHeaderSize = 4
+	// journalQueueSize is the number of writes queued up for the journal writer, beyond which an append is dropped
+	// until the next rotation
+	journalQueueSize = 1024
+	// journalSlack is the number of records the journal may hold beyond twice the pool size before it's rotated
+	journalSlack = 128
+)
+
+// journal is the file the actions accepted into the pool are appended to, so that the pool takes them again on
+// restart. Each action is kept as its serialized proto prefixed with the size. Once started, the writes are queued
+// up and done by a writer of its own, so that the pool doesn't wait on the file under its lock
+type journal struct {
+	path   string
+	writer *os.File
+	writes chan journalWrite
+	done   chan struct{}
+	// records is the number of records in the journal once the queued writes are done, and stale is set if an
+	// append has been dropped, both of which are only accessed by the pool under its lock
+	records int
+	stale   bool
+}
+
+// journalWrite is either an action to append, the actions to rotate the journal with, or a flush to be notified of
+type journalWrite struct {
+	act     action.SealedEnvelope
+	acts    []action.SealedEnvelope
+	rotate  bool
+	flushed chan struct{}
+}
+
+func newJournal(path string) *journal {
+	return &journal{path: path}
+}
+
+// load reads the actions in the order they're appended, up to the first one truncated or corrupted, e.g., by a crash
+// in the middle of appending it
+func (j *journal) load() ([]action.SealedEnvelope, error) {
+	data, err := ioutil.ReadFile(j.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read actpool journal %s", j.path)
+	}
+	var acts []action.SealedEnvelope
+	for len(data) > 0 {
+		act, size, err := decodeRecord(data)
+		if err != nil {
+			log.L().Warn("Skipped the rest of the actpool journal.", zap.String("journal", j.path), zap.Error(err))
+			break
+		}
+		acts = append(acts, act)
+		data = data[size:]
+	}
+	return acts, nil
+}
+
+// rotate rewrites the journal with the actions, which replaces the old one at once, and keeps it open for appending
+func (j *journal) rotate(acts []action.SealedEnvelope) error {
+	if err := j.close(); err != nil {
+		return err
+	}
+	tmp := j.path + ".new"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create actpool journal %s", tmp)
+	}
+	for _, act := range acts {
+		if err := writeRecord(f, act); err != nil {
+			f.Close()
+			return errors.Wrapf(err, "failed to write actpool journal %s", tmp)
+		}
+	}
+	if err := f.Close(); err != nil {
+		return errors.Wrapf(err, "failed to close actpool journal %s", tmp)
+	}
+	if err := os.Rename(tmp, j.path); err != nil {
+		return errors.Wrapf(err, "failed to replace actpool journal %s", j.path)
+	}
+	if j.writer, err = os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0644); err != nil {
+		return errors.Wrapf(err, "failed to open actpool journal %s", j.path)
+	}
+	return nil
+}
+
+// start starts the writer doing the queued writes
+func (j *journal) start() {
+	j.writes = make(chan journalWrite, journalQueueSize)
+	j.done = make(chan struct{})
+	go j.run()
+}
+
+func (j *journal) run() {
+	defer close(j.done)
+	for w := range j.writes {
+		var err error
+		switch {
+		case w.flushed != nil:
+			close(w.flushed)
+		case w.rotate:
+			err = j.rotate(w.acts)
+		default:
+			err = j.append(w.act)
+		}
+		if err != nil {
+			log.L().Warn("Failed to write actpool journal.", zap.String("journal", j.path), zap.Error(err))
+		}
+	}
+}
+
+// stop stops the writer once it's done with the queued writes
+func (j *journal) stop() {
+	if j.writes == nil {
+		return
+	}
+	close(j.writes)
+	<-j.done
+	j.writes = nil
+}
+
+// queueAppend queues up the action to append without waiting. If the queue is full, the action is dropped, and
+// the journal is marked stale to be rotated
+func (j *journal) queueAppend(act action.SealedEnvelope) {
+	if j.writes == nil {
+		return
+	}
+	select {
+	case j.writes <- journalWrite{act: act}:
+		j.records++
+	default:
+		j.stale = true
+		log.L().Warn("Dropped the action to journal.", act.LogFields()...)
+	}
+}
+
+// queueRotate queues up the actions to rotate the journal with without waiting, if it's stale or holds more than
+// twice as many records as the actions in pool. If the queue is full, it's left to the next time
+func (j *journal) queueRotate(size int, acts func() []action.SealedEnvelope) {
+	if j.writes == nil || !j.stale && j.records <= 2*size+journalSlack {
+		return
+	}
+	select {
+	case j.writes <- journalWrite{acts: acts(), rotate: true}:
+		j.records = size
+		j.stale = false
+	default:
+	}
+}
+
+// flush waits for the writes queued up so far to be done
+func (j *journal) flush() {
+	if j.writes == nil {
+		return
+	}
+	flushed := make(chan struct{})
+	j.writes <- journalWrite{flushed: flushed}
+	<-flushed
+}
+
+// append appends the action to the journal, if it's open, i.e., rotated and not closed yet
+func (j *journal) append(act action.SealedEnvelope) error {
+	if j.writer == nil {
+		return nil
+	}
+	return errors.Wrapf(writeRecord(j.writer, act), "failed to append to actpool journal %s", j.path)
+}
+
+func (j *journal) close() error {
+	if j.writer == nil {
+		return nil
+	}
+	err := j.writer.Close()
+	j.writer = nil
+	return errors.Wrapf(err, "failed to close actpool journal %s", j.path)
+}
+
+func writeRecord(w io.Writer, act action.SealedEnvelope) error {
+	data, err := proto.Marshal(act.Proto())
+	if err != nil {
+		return err
+	}
+	record := make([]byte, recordHeaderSize+len(data))
+	binary.BigEndian.PutUint32(record, uint32(len(data)))
+	copy(record[recordHeaderSize:], data)
+	_, err = w.Write(record)
+	return err
+}
+
+// decodeRecord decodes the action at the beginning of data, and returns it with the size of its record
+func decodeRecord(data []byte) (action.SealedEnvelope, int, error) {
+	var act action.SealedEnvelope
+	if len(data) < recordHeaderSize {
+		return act, 0, errors.New("truncated record header")
+	}
+	size := recordHeaderSize + int(binary.BigEndian.Uint32(data))
+	if len(data) < size {
+		return act, 0, errors.New("truncated record")
+	}
+	var pb iotextypes.Action
+	if err := proto.Unmarshal(data[recordHeaderSize:size], &pb); err != nil {
+		return act, 0, errors.Wrap(err, "failed to unmarshal action")
+	}
+	if err := act.LoadProto(&pb); err != nil {
+		return act, 0, errors.Wrap(err, "failed to load action")
+	}
+	return act, size, nil
+}
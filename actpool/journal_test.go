@@ -0,0 +1,61 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package actpool
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestJournal(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "journal")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	j := newJournal(filepath.Join(dir, "actpool.journal"))
+	acts, err := j.load()
+	require.NoError(err)
+	require.Empty(acts)
+
+	var tsfs []action.SealedEnvelope
+	for nonce := uint64(1); nonce <= 3; nonce++ {
+		tsf, err := testutil.SignedTransfer(addr2, priKey1, nonce, big.NewInt(10), []byte{}, uint64(100000),
+			big.NewInt(0))
+		require.NoError(err)
+		tsfs = append(tsfs, tsf)
+	}
+	// the actions appended before the journal is rotated aren't kept
+	require.NoError(j.append(tsfs[0]))
+	require.NoError(j.rotate(tsfs[:2]))
+	require.NoError(j.append(tsfs[2]))
+	require.NoError(j.close())
+	acts, err = j.load()
+	require.NoError(err)
+	require.Len(acts, 3)
+	for i, act := range acts {
+		require.Equal(tsfs[i].Hash(), act.Hash())
+	}
+
+	// the record truncated by a crash is skipped along with the rest
+	f, err := os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0644)
+	require.NoError(err)
+	_, err = f.Write([]byte{0, 0, 1, 0, 1, 2})
+	require.NoError(err)
+	require.NoError(f.Close())
+	acts, err = j.load()
+	require.NoError(err)
+	require.Len(acts, 3)
+}
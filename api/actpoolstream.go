@@ -0,0 +1,50 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"encoding/hex"
+	"sync/atomic"
+
+	"github.com/iotexproject/iotex-core/actpool"
+	"github.com/iotexproject/iotex-core/protogen/iotexapi"
+)
+
+// actPoolStream buffers the actpool events of a streaming client. The actpool notifies it with the pool locked, so an
+// event is dropped rather than blocking the pool when the buffer is full
+type actPoolStream struct {
+	events  chan actpool.ActionEvent
+	dropped uint64
+}
+
+func newActPoolStream(size int) *actPoolStream {
+	return &actPoolStream{events: make(chan actpool.ActionEvent, size)}
+}
+
+// HandleActionEvent buffers the event, or counts it as dropped if the buffer is full
+func (s *actPoolStream) HandleActionEvent(e actpool.ActionEvent) {
+	select {
+	case s.events <- e:
+	default:
+		atomic.AddUint64(&s.dropped, 1)
+	}
+}
+
+// toEvent converts an event to be sent along with the number of events dropped since the last one sent
+func (s *actPoolStream) toEvent(e actpool.ActionEvent) *iotexapi.ActPoolEvent {
+	h := e.Action.Hash()
+	pb := &iotexapi.ActPoolEvent{
+		Type:       iotexapi.ActPoolEventType_ADDED,
+		Action:     e.Action.Proto(),
+		ActionHash: hex.EncodeToString(h[:]),
+		Dropped:    atomic.SwapUint64(&s.dropped, 0),
+	}
+	if e.Type == actpool.ActionRemoved {
+		pb.Type = iotexapi.ActPoolEventType_REMOVED
+	}
+	return pb
+}
@@ -23,7 +23,6 @@ import (
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 
-	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/action/protocol/poll"
@@ -37,6 +36,7 @@ import (
 	"github.com/iotexproject/iotex-core/indexservice"
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/pkg/version"
 	"github.com/iotexproject/iotex-core/protogen/iotexapi"
@@ -83,6 +83,8 @@ type Server struct {
 	idx              *indexservice.Server
 	registry         *protocol.Registry
 	grpcserver       *grpc.Server
+	limiter          *connLimiter
+	guard            *panicGuard
 }
 
 // NewServer creates a new server
@@ -103,13 +105,16 @@ func NewServer(
 	}
 
 	if cfg == (config.API{}) {
-		log.L().Warn("API server is not configured.")
+		log.Logger("api").Warn("API server is not configured.")
 		cfg = config.Default.API
 	}
 
 	if cfg.RangeQueryLimit < uint64(cfg.TpsWindow) {
 		return nil, errors.New("range query upper limit cannot be less than tps window")
 	}
+	if cfg.MaxResponseItems > 0 && cfg.MaxResponseItems < cfg.TpsWindow {
+		return nil, errors.New("max response items cannot be less than tps window")
+	}
 
 	svr := &Server{
 		bc:               chain,
@@ -122,10 +127,21 @@ func NewServer(
 		gs:               gasstation.NewGasStation(chain, cfg),
 	}
 
-	svr.grpcserver = grpc.NewServer(
-		grpc.StreamInterceptor(grpc_prometheus.StreamServerInterceptor),
-		grpc.UnaryInterceptor(grpc_prometheus.UnaryServerInterceptor),
-	)
+	svr.limiter = newConnLimiter(cfg.MaxConnections)
+	svr.guard = newPanicGuard(cfg.PanicPenalty)
+	grpcOpts := []grpc.ServerOption{
+		grpc.StatsHandler(svr.limiter),
+		grpc.StreamInterceptor(svr.limiter.StreamInterceptor(
+			svr.guard.StreamInterceptor(grpc_prometheus.StreamServerInterceptor),
+		)),
+		grpc.UnaryInterceptor(svr.limiter.UnaryInterceptor(
+			svr.guard.UnaryInterceptor(grpc_prometheus.UnaryServerInterceptor),
+		)),
+	}
+	if cfg.MaxConcurrentStreams > 0 {
+		grpcOpts = append(grpcOpts, grpc.MaxConcurrentStreams(uint32(cfg.MaxConcurrentStreams)))
+	}
+	svr.grpcserver = grpc.NewServer(grpcOpts...)
 	iotexapi.RegisterAPIServiceServer(svr.grpcserver, svr)
 	grpc_prometheus.Register(svr.grpcserver)
 	reflection.Register(svr.grpcserver)
@@ -133,8 +149,16 @@ func NewServer(
 	return svr, nil
 }
 
+// SetMaxConnections changes the max number of simultaneous connections of the running server. 0 means no limit.
+func (api *Server) SetMaxConnections(max int) {
+	api.limiter.setMax(max)
+}
+
 // GetAccount returns the metadata of an account
 func (api *Server) GetAccount(ctx context.Context, in *iotexapi.GetAccountRequest) (*iotexapi.GetAccountResponse, error) {
+	if err := addrutil.Validate(in.Address); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	state, err := api.bc.StateByAddr(in.Address)
 	if err != nil {
 		return nil, status.Error(codes.NotFound, err.Error())
@@ -284,18 +308,20 @@ func (api *Server) GetServerMeta(ctx context.Context,
 
 // SendAction is the API to send an action to blockchain.
 func (api *Server) SendAction(ctx context.Context, in *iotexapi.SendActionRequest) (res *iotexapi.SendActionResponse, err error) {
-	log.L().Debug("receive send action request")
-
-	// broadcast to the network
-	if err = api.broadcastHandler(context.Background(), api.bc.ChainID(), in.Action); err != nil {
-		log.L().Warn("Failed to broadcast SendAction request.", zap.Error(err))
-	}
-	// send to actpool via dispatcher
-	api.dp.HandleBroadcast(context.Background(), api.bc.ChainID(), in.Action)
+	log.Logger("api").Debug("receive send action request")
 
 	var selp action.SealedEnvelope
 	if err = selp.LoadProto(in.Action); err != nil {
-		return
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	// add to the local actpool first, so that the submitter gets the reason the action is rejected for
+	if err = api.ap.Add(selp); err != nil {
+		log.Logger("api").Debug("Failed to add the action to actpool.", append(selp.LogFields(), zap.Error(err))...)
+		return nil, status.Error(actPoolErrorCode(err), err.Error())
+	}
+	// broadcast to the network
+	if err = api.broadcastHandler(context.Background(), api.bc.ChainID(), in.Action); err != nil {
+		log.Logger("api").Warn("Failed to broadcast SendAction request.", zap.Error(err))
 	}
 	hash := selp.Hash()
 
@@ -326,7 +352,7 @@ func (api *Server) GetReceiptByAction(ctx context.Context, in *iotexapi.GetRecei
 
 // ReadContract reads the state in a contract address specified by the slot
 func (api *Server) ReadContract(ctx context.Context, in *iotexapi.ReadContractRequest) (*iotexapi.ReadContractResponse, error) {
-	log.L().Debug("receive read smart contract request")
+	log.Logger("api").Debug("receive read smart contract request")
 
 	selp := &action.SealedEnvelope{}
 	if err := selp.LoadProto(in.Action); err != nil {
@@ -337,7 +363,7 @@ func (api *Server) ReadContract(ctx context.Context, in *iotexapi.ReadContractRe
 		return nil, status.Error(codes.InvalidArgument, "not an execution")
 	}
 
-	callerAddr, err := address.FromBytes(selp.SrcPubkey().Hash())
+	callerAddr, err := addrutil.PubKeyToAddress(selp.SrcPubkey())
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
@@ -448,19 +474,50 @@ func (api *Server) GetEpochMeta(
 	}, nil
 }
 
+// StreamActPool streams the events of actions added to or removed from actpool until the client disconnects. Events
+// are dropped instead of blocking actpool if the client falls behind, and the next event sent carries the number of
+// events dropped in between
+func (api *Server) StreamActPool(
+	in *iotexapi.StreamActPoolRequest,
+	stream iotexapi.APIService_StreamActPoolServer,
+) error {
+	if api.ap == nil {
+		return status.Error(codes.Unavailable, "actpool is not available")
+	}
+	sub := newActPoolStream(api.cfg.StreamBufferSize)
+	if err := api.ap.AddSubscriber(sub); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	defer func() {
+		if err := api.ap.RemoveSubscriber(sub); err != nil {
+			log.Logger("api").Error("Failed to unsubscribe actpool events.", zap.Error(err))
+		}
+	}()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case e := <-sub.events:
+			if err := stream.Send(sub.toEvent(e)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
 // Start starts the API server
 func (api *Server) Start() error {
 	portStr := ":" + strconv.Itoa(api.cfg.Port)
 	lis, err := net.Listen("tcp", portStr)
 	if err != nil {
-		log.L().Error("API server failed to listen.", zap.Error(err))
+		log.Logger("api").Error("API server failed to listen.", zap.Error(err))
 		return errors.Wrap(err, "API server failed to listen")
 	}
-	log.L().Info("API server is listening.", zap.String("addr", lis.Addr().String()))
+	log.Logger("api").Info("API server is listening.", zap.String("addr", lis.Addr().String()))
 
 	go func() {
 		if err := api.grpcserver.Serve(lis); err != nil {
-			log.L().Fatal("Node failed to serve.", zap.Error(err))
+			log.Logger("api").Fatal("Node failed to serve.", zap.Error(err))
 		}
 	}()
 	return nil
@@ -469,7 +526,7 @@ func (api *Server) Start() error {
 // Stop stops the API server
 func (api *Server) Stop() error {
 	api.grpcserver.Stop()
-	log.L().Info("API server stops.")
+	log.Logger("api").Info("API server stops.")
 	return nil
 }
 
@@ -504,6 +561,7 @@ func (api *Server) getActions(start uint64, count uint64) (*iotexapi.GetActionsR
 		return nil, status.Error(codes.InvalidArgument, "range exceeds the limit")
 	}
 
+	limit, capped := api.responseLimit(count)
 	var res []*iotexapi.ActionInfo
 	var actionCount uint64
 	tipHeight := api.bc.TipHeight()
@@ -520,8 +578,8 @@ func (api *Server) getActions(start uint64, count uint64) (*iotexapi.GetActionsR
 				continue
 			}
 
-			if uint64(len(res)) >= count {
-				return &iotexapi.GetActionsResponse{ActionInfo: res}, nil
+			if uint64(len(res)) >= limit {
+				return actionsResponse(res, start, capped), nil
 			}
 			act, err := api.convertToAction(selps[i], true)
 			if err != nil {
@@ -531,7 +589,26 @@ func (api *Server) getActions(start uint64, count uint64) (*iotexapi.GetActionsR
 		}
 	}
 
-	return &iotexapi.GetActionsResponse{ActionInfo: res}, nil
+	return actionsResponse(res, start, false), nil
+}
+
+// responseLimit returns the number of the items to respond for the count asked, which is capped at
+// API.MaxResponseItems, and whether it's capped
+func (api *Server) responseLimit(count uint64) (uint64, bool) {
+	if limit := uint64(api.cfg.MaxResponseItems); limit > 0 && count > limit {
+		return limit, true
+	}
+	return count, false
+}
+
+// actionsResponse returns the response of the actions queried from start, which has the start of the rest if it's
+// truncated
+func actionsResponse(res []*iotexapi.ActionInfo, start uint64, truncated bool) *iotexapi.GetActionsResponse {
+	resp := &iotexapi.GetActionsResponse{ActionInfo: res, Truncated: truncated}
+	if truncated {
+		resp.NextStart = start + uint64(len(res))
+	}
+	return resp
 }
 
 // getSingleAction returns action by action hash
@@ -549,10 +626,15 @@ func (api *Server) getSingleAction(actionHash string, checkPending bool) (*iotex
 
 // getActionsByAddress returns all actions associated with an address
 func (api *Server) getActionsByAddress(address string, start uint64, count uint64) (*iotexapi.GetActionsResponse, error) {
+	if err := addrutil.Validate(address); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	if count > api.cfg.RangeQueryLimit {
 		return nil, status.Error(codes.InvalidArgument, "range exceeds the limit")
 	}
 
+	limit, capped := api.responseLimit(count)
+	truncated := false
 	var res []*iotexapi.ActionInfo
 	actions, err := api.getTotalActionsByAddress(address)
 	if err != nil {
@@ -566,7 +648,8 @@ func (api *Server) getActionsByAddress(address string, start uint64, count uint6
 			continue
 		}
 
-		if uint64(len(res)) >= count {
+		if uint64(len(res)) >= limit {
+			truncated = capped
 			break
 		}
 
@@ -578,15 +661,20 @@ func (api *Server) getActionsByAddress(address string, start uint64, count uint6
 		res = append(res, act)
 	}
 
-	return &iotexapi.GetActionsResponse{ActionInfo: res}, nil
+	return actionsResponse(res, start, truncated), nil
 }
 
 // getUnconfirmedActionsByAddress returns all unconfirmed actions in actpool associated with an address
 func (api *Server) getUnconfirmedActionsByAddress(address string, start uint64, count uint64) (*iotexapi.GetActionsResponse, error) {
+	if err := addrutil.Validate(address); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	if count > api.cfg.RangeQueryLimit {
 		return nil, status.Error(codes.InvalidArgument, "range exceeds the limit")
 	}
 
+	limit, capped := api.responseLimit(count)
+	truncated := false
 	var res []*iotexapi.ActionInfo
 	var actionCount uint64
 	selps := api.ap.GetUnconfirmedActs(address)
@@ -597,7 +685,8 @@ func (api *Server) getUnconfirmedActionsByAddress(address string, start uint64,
 			continue
 		}
 
-		if uint64(len(res)) >= count {
+		if uint64(len(res)) >= limit {
+			truncated = capped
 			break
 		}
 		act, err := api.convertToAction(selps[i], false)
@@ -607,7 +696,7 @@ func (api *Server) getUnconfirmedActionsByAddress(address string, start uint64,
 		res = append(res, act)
 	}
 
-	return &iotexapi.GetActionsResponse{ActionInfo: res}, nil
+	return actionsResponse(res, start, truncated), nil
 }
 
 // getActionsByBlock returns all actions in a block
@@ -616,6 +705,8 @@ func (api *Server) getActionsByBlock(blkHash string, start uint64, count uint64)
 		return nil, status.Error(codes.InvalidArgument, "range exceeds the limit")
 	}
 
+	limit, capped := api.responseLimit(count)
+	truncated := false
 	var res []*iotexapi.ActionInfo
 	hash, err := toHash256(blkHash)
 	if err != nil {
@@ -636,7 +727,8 @@ func (api *Server) getActionsByBlock(blkHash string, start uint64, count uint64)
 			continue
 		}
 
-		if uint64(len(res)) >= count {
+		if uint64(len(res)) >= limit {
+			truncated = capped
 			break
 		}
 
@@ -646,7 +738,7 @@ func (api *Server) getActionsByBlock(blkHash string, start uint64, count uint64)
 		}
 		res = append(res, act)
 	}
-	return &iotexapi.GetActionsResponse{ActionInfo: res}, nil
+	return actionsResponse(res, start, truncated), nil
 }
 
 // getBlockMetas gets block within the height range
@@ -659,9 +751,12 @@ func (api *Server) getBlockMetas(start uint64, number uint64) (*iotexapi.GetBloc
 	if start > tipHeight {
 		return nil, status.Error(codes.InvalidArgument, "start height should not exceed tip height")
 	}
+	limit, capped := api.responseLimit(number)
+	truncated := false
 	var res []*iotextypes.BlockMeta
 	for height := int(start); height <= int(tipHeight); height++ {
-		if uint64(len(res)) >= number {
+		if uint64(len(res)) >= limit {
+			truncated = capped
 			break
 		}
 		blk, err := api.bc.GetBlockByHeight(uint64(height))
@@ -691,7 +786,11 @@ func (api *Server) getBlockMetas(start uint64, number uint64) (*iotexapi.GetBloc
 		res = append(res, blockMeta)
 	}
 
-	return &iotexapi.GetBlockMetasResponse{BlkMetas: res}, nil
+	resp := &iotexapi.GetBlockMetasResponse{BlkMetas: res, Truncated: truncated}
+	if truncated {
+		resp.NextStart = start + uint64(len(res))
+	}
+	return resp, nil
 }
 
 // getBlockMeta returns block by block hash
@@ -807,6 +906,20 @@ func (api *Server) getTotalActionsByAddress(address string) ([]hash.Hash256, err
 	return actions, nil
 }
 
+// actPoolErrorCode returns the status code of the reason the actpool rejects an action for
+func actPoolErrorCode(err error) codes.Code {
+	switch errors.Cause(err) {
+	case actpool.ErrDuplicate:
+		return codes.AlreadyExists
+	case actpool.ErrPoolFull, actpool.ErrTooManySenders:
+		return codes.ResourceExhausted
+	case actpool.ErrNodeSyncing:
+		return codes.Unavailable
+	default:
+		return codes.InvalidArgument
+	}
+}
+
 func toHash256(hashString string) (hash.Hash256, error) {
 	bytes, err := hex.DecodeString(hashString)
 	if err != nil {
@@ -21,6 +21,9 @@ import (
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
@@ -36,6 +39,7 @@ import (
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/gasstation"
 	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/recovery"
 	"github.com/iotexproject/iotex-core/pkg/unit"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/protogen/iotexapi"
@@ -43,8 +47,8 @@ import (
 	"github.com/iotexproject/iotex-core/state"
 	"github.com/iotexproject/iotex-core/state/factory"
 	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/test/mock/mock_actpool"
 	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
-	"github.com/iotexproject/iotex-core/test/mock/mock_dispatcher"
 	"github.com/iotexproject/iotex-core/test/mock/mock_factory"
 	ta "github.com/iotexproject/iotex-core/test/testaddress"
 	"github.com/iotexproject/iotex-core/testutil"
@@ -657,6 +661,28 @@ func TestServer_GetUnconfirmedActionsByAddress(t *testing.T) {
 		require.NoError(err)
 		require.Equal(test.numActions, len(res.ActionInfo))
 	}
+
+	// the transfer waiting behind a nonce gap is listed too, in the order of nonce
+	tsf, err := testutil.SignedTransfer(ta.Addrinfo["alfa"].String(), ta.Keyinfo["producer"].PriKey, 7, big.NewInt(20),
+		[]byte{}, testutil.TestGasLimit, big.NewInt(testutil.TestGasPriceInt64))
+	require.NoError(err)
+	require.NoError(svr.ap.Add(tsf))
+	res, err := svr.GetActions(context.Background(), &iotexapi.GetActionsRequest{
+		Lookup: &iotexapi.GetActionsRequest_UnconfirmedByAddr{
+			UnconfirmedByAddr: &iotexapi.GetUnconfirmedActionsByAddressRequest{
+				Address: ta.Addrinfo["producer"].String(),
+				Count:   10,
+			},
+		},
+	})
+	require.NoError(err)
+	var nonces []uint64
+	for _, info := range res.ActionInfo {
+		nonces = append(nonces, info.Action.GetCore().GetNonce())
+	}
+	require.Equal([]uint64{2, 3, 4, 5, 7}, nonces)
+	require.Equal(4, len(svr.ap.GetUnconfirmedTransfers(ta.Addrinfo["producer"].String())))
+	require.Equal(4, len(svr.ap.PendingActionMap()[ta.Addrinfo["producer"].String()]))
 }
 
 func TestServer_GetActionsByBlock(t *testing.T) {
@@ -714,6 +740,70 @@ func TestServer_GetBlockMetas(t *testing.T) {
 	}
 }
 
+func TestServer_MaxResponseItems(t *testing.T) {
+	require := require.New(t)
+	cfg := newConfig()
+
+	svr, err := createServer(cfg, false)
+	require.NoError(err)
+	getActions := func(start uint64) *iotexapi.GetActionsResponse {
+		res, err := svr.GetActions(context.Background(), &iotexapi.GetActionsRequest{
+			Lookup: &iotexapi.GetActionsRequest_ByIndex{
+				ByIndex: &iotexapi.GetActionsByIndexRequest{Start: start, Count: 100},
+			},
+		})
+		require.NoError(err)
+		return res
+	}
+	getBlockMetas := func(start uint64) *iotexapi.GetBlockMetasResponse {
+		res, err := svr.GetBlockMetas(context.Background(), &iotexapi.GetBlockMetasRequest{
+			Lookup: &iotexapi.GetBlockMetasRequest_ByIndex{
+				ByIndex: &iotexapi.GetBlockMetasByIndexRequest{Start: start, Count: 100},
+			},
+		})
+		require.NoError(err)
+		return res
+	}
+	allActions := getActions(0)
+	require.False(allActions.Truncated)
+	allBlkMetas := getBlockMetas(1)
+	require.False(allBlkMetas.Truncated)
+
+	// the truncated responses are continued from the next start, and add up to the whole
+	svr.cfg.MaxResponseItems = 2
+	var actions []*iotexapi.ActionInfo
+	for res, pages := getActions(0), 1; ; res, pages = getActions(res.NextStart), pages+1 {
+		require.True(len(res.ActionInfo) <= 2)
+		actions = append(actions, res.ActionInfo...)
+		if !res.Truncated {
+			require.Equal((len(allActions.ActionInfo)+1)/2, pages)
+			break
+		}
+		require.Equal(uint64(len(actions)), res.NextStart)
+	}
+	require.Equal(allActions.ActionInfo, actions)
+	var blkMetas []*iotextypes.BlockMeta
+	for res := getBlockMetas(1); ; res = getBlockMetas(res.NextStart) {
+		require.True(len(res.BlkMetas) <= 2)
+		blkMetas = append(blkMetas, res.BlkMetas...)
+		if !res.Truncated {
+			break
+		}
+		require.Equal(uint64(len(blkMetas)+1), res.NextStart)
+	}
+	require.Equal(allBlkMetas.BlkMetas, blkMetas)
+
+	// the counts within the limit aren't truncated
+	res, err := svr.GetActions(context.Background(), &iotexapi.GetActionsRequest{
+		Lookup: &iotexapi.GetActionsRequest_ByAddr{
+			ByAddr: &iotexapi.GetActionsByAddressRequest{Address: ta.Addrinfo["producer"].String(), Start: 0, Count: 2},
+		},
+	})
+	require.NoError(err)
+	require.False(res.Truncated)
+	require.Zero(res.NextStart)
+}
+
 func TestServer_GetBlockMeta(t *testing.T) {
 	require := require.New(t)
 	cfg := newConfig()
@@ -797,15 +887,15 @@ func TestServer_SendAction(t *testing.T) {
 	defer ctrl.Finish()
 
 	chain := mock_blockchain.NewMockBlockchain(ctrl)
-	mDp := mock_dispatcher.NewMockDispatcher(ctrl)
+	ap := mock_actpool.NewMockActPool(ctrl)
 	broadcastHandlerCount := 0
-	svr := Server{bc: chain, dp: mDp, broadcastHandler: func(_ context.Context, _ uint32, _ proto.Message) error {
+	svr := Server{bc: chain, ap: ap, broadcastHandler: func(_ context.Context, _ uint32, _ proto.Message) error {
 		broadcastHandlerCount++
 		return nil
 	}}
 
-	chain.EXPECT().ChainID().Return(uint32(1)).Times(4)
-	mDp.EXPECT().HandleBroadcast(gomock.Any(), gomock.Any(), gomock.Any()).Times(2)
+	chain.EXPECT().ChainID().Return(uint32(1)).Times(2)
+	ap.EXPECT().Add(gomock.Any()).Return(nil).Times(2)
 
 	for i, test := range sendActionTests {
 		request := &iotexapi.SendActionRequest{Action: test.actionPb}
@@ -814,6 +904,40 @@ func TestServer_SendAction(t *testing.T) {
 		require.Equal(i+1, broadcastHandlerCount)
 		require.Equal(test.actionHash, res.ActionHash)
 	}
+
+	// the action rejected by the actpool isn't broadcast, and the submitter gets the reason
+	for _, test := range []struct {
+		err  error
+		code codes.Code
+	}{
+		{errors.Wrap(actpool.ErrDuplicate, "reject existed action"), codes.AlreadyExists},
+		{errors.Wrap(actpool.ErrPoolFull, "insufficient space for action"), codes.ResourceExhausted},
+		{errors.Wrap(action.ErrNonce, "nonce too large"), codes.InvalidArgument},
+	} {
+		ap.EXPECT().Add(gomock.Any()).Return(test.err).Times(1)
+		_, err := svr.SendAction(context.Background(), &iotexapi.SendActionRequest{Action: testTransferPb})
+		require.Equal(test.code, status.Code(err))
+		require.Contains(err.Error(), test.err.Error())
+	}
+	require.Equal(len(sendActionTests), broadcastHandlerCount)
+}
+
+func TestServer_SendAction_InvalidPubKey(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	ap := mock_actpool.NewMockActPool(ctrl)
+	svr := Server{bc: chain, ap: ap, broadcastHandler: func(_ context.Context, _ uint32, _ proto.Message) error {
+		return nil
+	}}
+
+	actPb := proto.Clone(testTransferPb).(*iotextypes.Action)
+	actPb.SenderPubKey = actPb.SenderPubKey[1:]
+	_, err := svr.SendAction(context.Background(), &iotexapi.SendActionRequest{Action: actPb})
+	require.Equal(codes.InvalidArgument, status.Code(err))
 }
 
 func TestServer_GetReceiptByAction(t *testing.T) {
@@ -1155,6 +1279,153 @@ func TestServer_GetEpochMeta(t *testing.T) {
 	}
 }
 
+type actPoolStreamServer struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events chan *iotexapi.ActPoolEvent
+}
+
+func (s *actPoolStreamServer) Context() context.Context { return s.ctx }
+
+func (s *actPoolStreamServer) Send(e *iotexapi.ActPoolEvent) error {
+	s.events <- e
+	return nil
+}
+
+func TestServer_StreamActPool(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tsf, err := testutil.SignedTransfer(ta.Addrinfo["alfa"].String(), ta.Keyinfo["producer"].PriKey, 1, big.NewInt(20), []byte{}, testutil.TestGasLimit, big.NewInt(testutil.TestGasPriceInt64))
+	require.NoError(err)
+	tsfHash := tsf.Hash()
+
+	svr := Server{cfg: config.API{StreamBufferSize: 1}}
+	err = svr.StreamActPool(&iotexapi.StreamActPoolRequest{}, &actPoolStreamServer{ctx: context.Background()})
+	require.Equal(codes.Unavailable, status.Code(err))
+
+	ap := mock_actpool.NewMockActPool(ctrl)
+	subs := make(chan actpool.ActionSubscriber, 1)
+	ap.EXPECT().AddSubscriber(gomock.Any()).Do(func(s actpool.ActionSubscriber) {
+		subs <- s
+	}).Return(nil).Times(1)
+	ap.EXPECT().RemoveSubscriber(gomock.Any()).Return(nil).Times(1)
+	svr.ap = ap
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &actPoolStreamServer{ctx: ctx, events: make(chan *iotexapi.ActPoolEvent)}
+	errChan := make(chan error)
+	go func() {
+		errChan <- svr.StreamActPool(&iotexapi.StreamActPoolRequest{}, stream)
+	}()
+	sub := <-subs
+	sub.HandleActionEvent(actpool.ActionEvent{Type: actpool.ActionAdded, Action: tsf})
+	e := <-stream.events
+	require.Equal(iotexapi.ActPoolEventType_ADDED, e.Type)
+	require.Equal(hex.EncodeToString(tsfHash[:]), e.ActionHash)
+	require.True(proto.Equal(tsf.Proto(), e.Action))
+	require.Equal(uint64(0), e.Dropped)
+	sub.HandleActionEvent(actpool.ActionEvent{Type: actpool.ActionRemoved, Action: tsf})
+	e = <-stream.events
+	require.Equal(iotexapi.ActPoolEventType_REMOVED, e.Type)
+
+	cancel()
+	require.NoError(<-errChan)
+}
+
+func TestActPoolStream_Drop(t *testing.T) {
+	require := require.New(t)
+
+	tsf, err := testutil.SignedTransfer(ta.Addrinfo["alfa"].String(), ta.Keyinfo["producer"].PriKey, 1, big.NewInt(20), []byte{}, testutil.TestGasLimit, big.NewInt(testutil.TestGasPriceInt64))
+	require.NoError(err)
+
+	s := newActPoolStream(1)
+	s.HandleActionEvent(actpool.ActionEvent{Type: actpool.ActionAdded, Action: tsf})
+	// The buffer is full, so the following events are dropped
+	s.HandleActionEvent(actpool.ActionEvent{Type: actpool.ActionRemoved, Action: tsf})
+	s.HandleActionEvent(actpool.ActionEvent{Type: actpool.ActionAdded, Action: tsf})
+	e := s.toEvent(<-s.events)
+	require.Equal(iotexapi.ActPoolEventType_ADDED, e.Type)
+	require.Equal(uint64(2), e.Dropped)
+
+	s.HandleActionEvent(actpool.ActionEvent{Type: actpool.ActionRemoved, Action: tsf})
+	e = s.toEvent(<-s.events)
+	require.Equal(iotexapi.ActPoolEventType_REMOVED, e.Type)
+	require.Equal(uint64(0), e.Dropped)
+}
+
+func TestServer_MaxConnections(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default.API
+	cfg.Port = testutil.RandomPort()
+	cfg.MaxConnections = 1
+	cfg.MaxConcurrentStreams = 10
+	svr, err := NewServer(cfg, nil, nil, nil, nil, nil)
+	require.NoError(err)
+	require.NoError(svr.Start())
+	defer func() {
+		require.NoError(svr.Stop())
+	}()
+
+	dial := func() (*grpc.ClientConn, iotexapi.APIServiceClient) {
+		conn, err := grpc.Dial("127.0.0.1:"+strconv.Itoa(cfg.Port), grpc.WithInsecure())
+		require.NoError(err)
+		return conn, iotexapi.NewAPIServiceClient(conn)
+	}
+	// an invalid address makes the RPC return without touching the chain once it gets through the limiter
+	call := func(cli iotexapi.APIServiceClient) codes.Code {
+		_, err := cli.GetAccount(context.Background(), &iotexapi.GetAccountRequest{Address: "io1"})
+		return status.Code(err)
+	}
+
+	conn1, cli1 := dial()
+	require.Equal(codes.InvalidArgument, call(cli1))
+	conn2, cli2 := dial()
+	defer conn2.Close()
+	require.Equal(codes.ResourceExhausted, call(cli2))
+
+	// the slot is released once the admitted connection is closed, which admits the connection rejected before
+	require.NoError(conn1.Close())
+	require.NoError(testutil.WaitUntil(10*time.Millisecond, 2*time.Second, func() (bool, error) {
+		return call(cli2) == codes.InvalidArgument, nil
+	}))
+	conn3, cli3 := dial()
+	defer conn3.Close()
+	require.Equal(codes.ResourceExhausted, call(cli3))
+}
+
+func TestServer_RecoverPanickingHandler(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default.API
+	cfg.Port = testutil.RandomPort()
+	svr, err := NewServer(cfg, nil, nil, nil, nil, nil)
+	require.NoError(err)
+	require.NoError(svr.Start())
+	defer func() {
+		require.NoError(svr.Stop())
+	}()
+	conn, err := grpc.Dial("127.0.0.1:"+strconv.Itoa(cfg.Port), grpc.WithInsecure())
+	require.NoError(err)
+	defer conn.Close()
+	cli := iotexapi.NewAPIServiceClient(conn)
+
+	// the server without a chain panics on reading the chain, which fails the RPC only
+	panics := recovery.Panics("api.GetChainMeta")
+	_, err = cli.GetChainMeta(context.Background(), &iotexapi.GetChainMetaRequest{})
+	require.Equal(codes.Internal, status.Code(err))
+	require.Equal(panics+1, recovery.Panics("api.GetChainMeta"))
+
+	// the server keeps running, and rejects the client host for a while
+	_, err = cli.GetAccount(context.Background(), &iotexapi.GetAccountRequest{Address: "io1"})
+	require.Equal(codes.PermissionDenied, status.Code(err))
+	require.True(svr.guard.penalties.Penalized("127.0.0.1"))
+	require.False(svr.guard.penalties.Penalized("127.0.0.2"))
+}
+
 func addProducerToFactory(sf factory.Factory) error {
 	ws, err := sf.NewWorkingSet()
 	if err != nil {
@@ -1339,7 +1610,7 @@ func addActsToActPool(ap actpool.ActPool) error {
 }
 
 func setupChain(cfg config.Config) (blockchain.Blockchain, *protocol.Registry, error) {
-	cfg.Chain.ProducerPrivKey = hex.EncodeToString(identityset.PrivateKey(0).Bytes())
+	cfg.Chain.ProducerPrivKey = config.Secret(hex.EncodeToString(identityset.PrivateKey(0).Bytes()))
 	sf, err := factory.NewFactory(cfg, factory.InMemTrieOption())
 	if err != nil {
 		return nil, nil, err
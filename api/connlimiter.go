@@ -0,0 +1,130 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+)
+
+type connSlotKey struct{}
+
+// connSlot tells whether a connection is admitted, which is guarded by the mutex of the limiter
+type connSlot struct {
+	admitted bool
+}
+
+// connLimiter admits at most max connections at a time. It tracks the connections as a gRPC stats handler, and the
+// RPCs on a connection beyond the limit are rejected with codes.ResourceExhausted by its interceptors, so that the
+// client gets a clear status rather than a hanging connection. A connection beyond the limit is admitted by its next
+// RPC once a slot is released.
+type connLimiter struct {
+	max    int
+	mu     sync.Mutex
+	active int
+}
+
+func newConnLimiter(max int) *connLimiter {
+	return &connLimiter{max: max}
+}
+
+// setMax changes the limit. The connections already admitted are kept even if they are beyond the new limit.
+func (l *connLimiter) setMax(max int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.max = max
+}
+
+// TagConn admits the connection if the limit isn't reached yet
+func (l *connLimiter) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	slot := &connSlot{}
+	l.admit(slot)
+	return context.WithValue(ctx, connSlotKey{}, slot)
+}
+
+// HandleConn releases the slot of an admitted connection when it ends
+func (l *connLimiter) HandleConn(ctx context.Context, s stats.ConnStats) {
+	if _, ok := s.(*stats.ConnEnd); !ok {
+		return
+	}
+	slot, ok := ctx.Value(connSlotKey{}).(*connSlot)
+	if !ok {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if slot.admitted {
+		slot.admitted = false
+		l.active--
+	}
+}
+
+// TagRPC returns the context as is
+func (l *connLimiter) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context { return ctx }
+
+// HandleRPC does nothing
+func (l *connLimiter) HandleRPC(context.Context, stats.RPCStats) {}
+
+// UnaryInterceptor rejects the unary RPCs on a connection beyond the limit, and passes the others to next
+func (l *connLimiter) UnaryInterceptor(next grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if err := l.check(ctx); err != nil {
+			return nil, err
+		}
+		return next(ctx, req, info, handler)
+	}
+}
+
+// StreamInterceptor rejects the streaming RPCs on a connection beyond the limit, and passes the others to next
+func (l *connLimiter) StreamInterceptor(next grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := l.check(ss.Context()); err != nil {
+			return err
+		}
+		return next(srv, ss, info, handler)
+	}
+}
+
+// check admits the connection of the RPC if it's not admitted yet and a slot is free, and rejects the RPC otherwise
+func (l *connLimiter) check(ctx context.Context) error {
+	slot, ok := ctx.Value(connSlotKey{}).(*connSlot)
+	if !ok {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.admit(slot) {
+		return status.Errorf(codes.ResourceExhausted, "too many connections, the limit is %d", l.max)
+	}
+	return nil
+}
+
+// admit takes a slot for the connection if it doesn't have one and the limit isn't reached yet, and returns whether
+// the connection is admitted. The caller must hold the mutex.
+func (l *connLimiter) admit(slot *connSlot) bool {
+	if slot.admitted {
+		return true
+	}
+	if l.max <= 0 || l.active < l.max {
+		slot.admitted = true
+		l.active++
+	}
+	return slot.admitted
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package api
+
+import (
+	"context"
+	"net"
+	"path"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/pkg/recovery"
+)
+
+// panicGuard recovers the RPC handlers from panics, so that a malformed request doesn't take down the node. The RPC
+// panicking fails with codes.Internal, and the RPCs from its client host are rejected with codes.PermissionDenied for
+// the penalty duration.
+type panicGuard struct {
+	penalties *recovery.PenaltyBox
+}
+
+func newPanicGuard(penalty time.Duration) *panicGuard {
+	return &panicGuard{penalties: recovery.NewPenaltyBox(penalty)}
+}
+
+// UnaryInterceptor guards the unary RPCs, and passes them to next
+func (g *panicGuard) UnaryInterceptor(next grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (resp interface{}, err error) {
+		host := clientHost(ctx)
+		if err := g.check(host); err != nil {
+			return nil, err
+		}
+		payload, _ := req.(proto.Message)
+		if g.handle(info.FullMethod, host, payload, func() {
+			resp, err = next(ctx, req, info, handler)
+		}) {
+			return nil, status.Error(codes.Internal, "internal error")
+		}
+		return resp, err
+	}
+}
+
+// StreamInterceptor guards the streaming RPCs, and passes them to next
+func (g *panicGuard) StreamInterceptor(next grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) (err error) {
+		host := clientHost(ss.Context())
+		if err := g.check(host); err != nil {
+			return err
+		}
+		if g.handle(info.FullMethod, host, nil, func() {
+			err = next(srv, ss, info, handler)
+		}) {
+			return status.Error(codes.Internal, "internal error")
+		}
+		return err
+	}
+}
+
+// handle calls the RPC handler, and returns true if it panics, in which case the client host is penalized
+func (g *panicGuard) handle(method, host string, payload proto.Message, call func()) bool {
+	if !recovery.Handle("api."+path.Base(method), payload, call, zap.String("method", method), zap.String("peer", host)) {
+		return false
+	}
+	g.penalties.Penalize(host)
+	return true
+}
+
+func (g *panicGuard) check(host string) error {
+	if g.penalties.Penalized(host) {
+		return status.Errorf(codes.PermissionDenied, "%s is rejected for a request failing the server", host)
+	}
+	return nil
+}
+
+// clientHost returns the host of the client address, which is empty if unknown
+func clientHost(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
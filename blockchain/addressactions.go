@@ -0,0 +1,114 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"sort"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
+// GetTransfersByAddress returns the committed transfers the address sent or received, in the order of the blocks
+func (bc *blockchain) GetTransfersByAddress(addrStr string) ([]*action.Transfer, error) {
+	selps, err := bc.addressActions(addrStr, func(act action.Action) bool {
+		_, ok := act.(*action.Transfer)
+		return ok
+	})
+	if err != nil {
+		return nil, err
+	}
+	transfers := make([]*action.Transfer, 0, len(selps))
+	for _, selp := range selps {
+		transfers = append(transfers, selp.Action().(*action.Transfer))
+	}
+	return transfers, nil
+}
+
+// GetVotesByAddress returns the committed votes the address cast or received, in the order of the blocks
+func (bc *blockchain) GetVotesByAddress(addrStr string) ([]*action.Vote, error) {
+	selps, err := bc.addressActions(addrStr, func(act action.Action) bool {
+		_, ok := act.(*action.Vote)
+		return ok
+	})
+	if err != nil {
+		return nil, err
+	}
+	votes := make([]*action.Vote, 0, len(selps))
+	for _, selp := range selps {
+		votes = append(votes, selp.Action().(*action.Vote))
+	}
+	return votes, nil
+}
+
+// addressActions returns the matching actions the address sent or received, walking the actions of the address in the
+// action index, so it requires the gateway plugin, and misses the blocks not indexed yet if the index is written
+// asynchronously. An action the address sent to itself is returned once.
+func (bc *blockchain) addressActions(
+	addrStr string,
+	match func(action.Action) bool,
+) ([]action.SealedEnvelope, error) {
+	if _, ok := bc.config.Plugins[config.GatewayPlugin]; !ok {
+		return nil, errors.New("action index isn't enabled")
+	}
+	addr, err := address.FromString(addrStr)
+	if err != nil {
+		return nil, err
+	}
+	addrBytes := hash.BytesToHash160(addr.Bytes())
+	sent, err := getActionsBySenderAddress(bc.dao.kvstore, addrBytes)
+	if err != nil {
+		return nil, err
+	}
+	received, err := getActionsByRecipientAddress(bc.dao.kvstore, addrBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	type indexedAction struct {
+		height uint64
+		selp   action.SealedEnvelope
+	}
+	var acts []indexedAction
+	seen := make(map[hash.Hash256]bool)
+	for _, h := range append(sent, received...) {
+		if seen[h] {
+			continue
+		}
+		seen[h] = true
+		blkHash, err := getBlockHashByActionHash(bc.dao.kvstore, h)
+		if err != nil {
+			return nil, err
+		}
+		height, err := bc.dao.getBlockHeight(blkHash)
+		if err != nil {
+			return nil, err
+		}
+		blk, err := bc.dao.getBlock(blkHash)
+		if err != nil {
+			return nil, err
+		}
+		for _, selp := range blk.Actions {
+			if selp.Hash() == h {
+				if match(selp.Action()) {
+					acts = append(acts, indexedAction{height, selp})
+				}
+				break
+			}
+		}
+	}
+	sort.SliceStable(acts, func(i, j int) bool { return acts[i].height < acts[j].height })
+	selps := make([]action.SealedEnvelope, 0, len(acts))
+	for _, act := range acts {
+		selps = append(selps, act.selp)
+	}
+	return selps, nil
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"math/big"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
+// GetBalanceDelta returns the net balance change of the address by the actions in blocks (fromHeight, toHeight],
+// walking the actions the address sent or received in the action index, so it requires the gateway plugin, and misses
+// the blocks not indexed yet if the index is written asynchronously. Only the balance moved by the actions themselves
+// is counted, i.e., the gas fees, the transfers and executions succeeded, the deposits to and claims from the rewarding
+// fund, and the deposits to and from sub-chains. The value transferred from within a contract isn't in the index, and
+// thus isn't counted.
+func (bc *blockchain) GetBalanceDelta(addrStr string, fromHeight, toHeight uint64) (*big.Int, error) {
+	if _, ok := bc.config.Plugins[config.GatewayPlugin]; !ok {
+		return nil, errors.New("action index isn't enabled")
+	}
+	if fromHeight > toHeight || toHeight > bc.TipHeight() {
+		return nil, errors.Wrapf(db.ErrNotExist, "invalid height range (%d, %d]", fromHeight, toHeight)
+	}
+	addr, err := address.FromString(addrStr)
+	if err != nil {
+		return nil, err
+	}
+	addrBytes := hash.BytesToHash160(addr.Bytes())
+	sent, err := getActionsBySenderAddress(bc.dao.kvstore, addrBytes)
+	if err != nil {
+		return nil, err
+	}
+	received, err := getActionsByRecipientAddress(bc.dao.kvstore, addrBytes)
+	if err != nil {
+		return nil, err
+	}
+	delta := big.NewInt(0)
+	for _, actions := range []struct {
+		hashes []hash.Hash256
+		sent   bool
+	}{{sent, true}, {received, false}} {
+		for _, h := range actions.hashes {
+			d, err := bc.actionBalanceDelta(h, fromHeight, toHeight, actions.sent)
+			if err != nil {
+				return nil, err
+			}
+			delta.Add(delta, d)
+		}
+	}
+	return delta, nil
+}
+
+// actionBalanceDelta returns the balance change of the sender or the recipient by the action, which is 0 if the
+// action isn't in blocks (fromHeight, toHeight]
+func (bc *blockchain) actionBalanceDelta(h hash.Hash256, fromHeight, toHeight uint64, sent bool) (*big.Int, error) {
+	blkHash, err := getBlockHashByActionHash(bc.dao.kvstore, h)
+	if err != nil {
+		return nil, err
+	}
+	height, err := bc.dao.getBlockHeight(blkHash)
+	if err != nil {
+		return nil, err
+	}
+	if height <= fromHeight || height > toHeight {
+		return big.NewInt(0), nil
+	}
+	selp, err := bc.GetActionByActionHash(h)
+	if err != nil {
+		return nil, err
+	}
+	if sd, ok := selp.Action().(*action.SettleDeposit); ok {
+		// settling a deposit has no receipt
+		if sent {
+			return big.NewInt(0), nil
+		}
+		return new(big.Int).Set(sd.Amount()), nil
+	}
+	receipt, err := bc.dao.getReceiptByActionHash(h)
+	if err != nil {
+		return nil, err
+	}
+	succeeded := receipt.Status == action.SuccessReceiptStatus
+	if !sent {
+		switch act := selp.Action().(type) {
+		case *action.Transfer:
+			if succeeded {
+				return new(big.Int).Set(act.Amount()), nil
+			}
+		case *action.Execution:
+			if succeeded {
+				return new(big.Int).Set(act.Amount()), nil
+			}
+		}
+		return big.NewInt(0), nil
+	}
+	gasFee := new(big.Int).Mul(selp.GasPrice(), new(big.Int).SetUint64(receipt.GasConsumed))
+	switch act := selp.Action().(type) {
+	case *action.Transfer:
+		if succeeded {
+			gasFee.Add(gasFee, act.Amount())
+		}
+		return gasFee.Neg(gasFee), nil
+	case *action.Execution:
+		if succeeded {
+			gasFee.Add(gasFee, act.Amount())
+		}
+		return gasFee.Neg(gasFee), nil
+	case *action.Vote:
+		return gasFee.Neg(gasFee), nil
+	case *action.CreateDeposit:
+		// the status of a deposit to a sub-chain is always 0, and no gas is charged for it
+		return new(big.Int).Neg(act.Amount()), nil
+	case *action.DepositToRewardingFund:
+		// no gas is charged for the rewarding actions
+		if succeeded {
+			return new(big.Int).Neg(act.Amount()), nil
+		}
+	case *action.ClaimFromRewardingFund:
+		if succeeded {
+			return new(big.Int).Set(act.Amount()), nil
+		}
+	}
+	return big.NewInt(0), nil
+}
@@ -69,13 +69,13 @@ func (b *Builder) SetReceiptRoot(h hash.Hash256) *Builder {
 }
 
 // SignAndBuild signs and then builds a block.
-func (b *Builder) SignAndBuild(signerPrvKey keypair.PrivateKey) (Block, error) {
-	if !bytes.Equal(b.blk.Header.pubkey.Bytes(), signerPrvKey.PublicKey().Bytes()) {
+func (b *Builder) SignAndBuild(signer keypair.Signer) (Block, error) {
+	if !bytes.Equal(b.blk.Header.pubkey.Bytes(), signer.PublicKey().Bytes()) {
 		return Block{}, errors.New("public key from the signer doesn't match that from runnable actions")
 	}
 
 	h := b.blk.Header.HashHeaderCore()
-	sig, err := signerPrvKey.Sign(h[:])
+	sig, err := signer.Sign(h[:])
 	if err != nil {
 		return Block{}, errors.New("failed to sign block")
 	}
@@ -13,11 +13,11 @@ import (
 	"github.com/golang/protobuf/ptypes"
 	"go.uber.org/zap"
 
-	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/protogen/iotextypes"
 )
@@ -166,14 +166,20 @@ func (h *Header) VerifySignature() bool {
 
 // ProducerAddress returns the address of producer
 func (h *Header) ProducerAddress() string {
-	addr, _ := address.FromBytes(h.pubkey.Hash())
+	addr, _ := addrutil.PubKeyToAddress(h.pubkey)
 	return addr.String()
 }
 
+// LogFields returns the fields every log line about the block carries, i.e., its height and its short hash
+func (h *Header) LogFields() []zap.Field {
+	return log.BlockFields(h.height, h.HashBlock())
+}
+
 // HeaderLogger returns a new logger with block header fields' value.
 func (h *Header) HeaderLogger(l *zap.Logger) *zap.Logger {
 	return l.With(zap.Uint32("version", h.version),
 		zap.Uint64("height", h.height),
+		log.ShortHash("block", h.HashBlock()),
 		zap.String("timestamp", h.timestamp.String()),
 		log.Hex("prevBlockHash", h.prevBlockHash[:]),
 		log.Hex("txRoot", h.txRoot[:]),
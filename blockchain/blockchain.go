@@ -8,6 +8,7 @@ package blockchain
 
 import (
 	"context"
+	"io"
 	"math/big"
 	"os"
 	"strconv"
@@ -17,7 +18,6 @@ import (
 
 	"github.com/facebookgo/clock"
 	"github.com/pkg/errors"
-	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -38,39 +38,41 @@ import (
 	"github.com/iotexproject/iotex-core/crypto"
 	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/metrics"
 	"github.com/iotexproject/iotex-core/pkg/prometheustimer"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/pkg/util/fileutil"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
 	"github.com/iotexproject/iotex-core/state"
 	"github.com/iotexproject/iotex-core/state/factory"
 )
 
 var (
-	blockMtc = prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Name: "iotex_block_metrics",
-
-			Help: "Block metrics.",
-		},
-		[]string{"type"},
-	)
+	blockMtc             = metrics.NewGauge("iotex_block_metrics", "Block metrics.", "type")
 	errDelegatesNotExist = errors.New("delegates cannot be found")
+
+	// ErrBlockNotAvailable indicates that the block of the given height is below the blocks held by a node synced from
+	// a checkpoint
+	ErrBlockNotAvailable = errors.New("block is not available on this node")
 )
 
-func init() {
-	prometheus.MustRegister(blockMtc)
-}
+// MaxBlocksInRange is the max number of blocks returned by GetBlocksByHeightRange at a time
+const MaxBlocksInRange = 1000
 
 // Blockchain represents the blockchain data structure and hosts the APIs to access it
 type Blockchain interface {
 	lifecycle.StartStopper
 
-	// Balance returns balance of an account
+	// Balance returns balance of an account, which is 0 for an address never seen on the chain
 	Balance(addr string) (*big.Int, error)
-	// Nonce returns the nonce if the account exists
+	// Nonce returns the confirmed nonce of an account, which is 0 for an address never seen on the chain
 	Nonce(addr string) (uint64, error)
+	// GetSentActionCount returns the number of the committed actions sent by the address
+	GetSentActionCount(addr string) (uint64, error)
 	// CreateState adds a new account with initial balance to the factory
 	CreateState(addr string, init *big.Int) (*state.Account, error)
 	// CandidatesByHeight returns the candidate list by a given height
@@ -84,12 +86,24 @@ type Blockchain interface {
 	GetHashByHeight(height uint64) (hash.Hash256, error)
 	// GetBlockByHeight returns Block by height
 	GetBlockByHeight(height uint64) (*block.Block, error)
+	// GetBlocksByHeightRange returns the blocks of the heights in [start, end] in ascending order, at most
+	// MaxBlocksInRange of them
+	GetBlocksByHeightRange(start, end uint64) ([]*block.Block, error)
 	// GetBlockByHash returns Block by hash
 	GetBlockByHash(h hash.Hash256) (*block.Block, error)
+	// GetBlockVotes returns the votes in the block of the given height
+	GetBlockVotes(height uint64) ([]*action.Vote, error)
+	// GetBlockTransfers returns the transfers in the block of the given height
+	GetBlockTransfers(height uint64) ([]*action.Transfer, error)
 	// BlockHeaderByHeight return block header by height
 	BlockHeaderByHeight(height uint64) (*block.Header, error)
 	// BlockHeaderByHash return block header by hash
 	BlockHeaderByHash(h hash.Hash256) (*block.Header, error)
+	// GetBlockTime returns the timestamp of the block of the given height in Unix seconds, which is the genesis
+	// timestamp at height 0
+	GetBlockTime(height uint64) (int64, error)
+	// GetBlockIntervalStats returns the min, max and average intervals between the last lastN+1 blocks
+	GetBlockIntervalStats(lastN int) (min, max, avg time.Duration, err error)
 	// BlockFooterByHeight return block footer by height
 	BlockFooterByHeight(height uint64) (*block.Footer, error)
 	// BlockFooterByHash return block footer by hash
@@ -102,8 +116,14 @@ type Blockchain interface {
 	GetActionsFromAddress(address string) ([]hash.Hash256, error)
 	// GetActionsToAddress returns actions to address
 	GetActionsToAddress(address string) ([]hash.Hash256, error)
+	// GetTransfersByAddress returns the committed transfers the address sent or received, read from the action index
+	GetTransfersByAddress(address string) ([]*action.Transfer, error)
+	// GetVotesByAddress returns the committed votes the address cast or received, read from the action index
+	GetVotesByAddress(address string) ([]*action.Vote, error)
 	// GetActionCountByAddress returns action count by address
 	GetActionCountByAddress(address string) (uint64, error)
+	// GetBalanceDelta returns the net balance change of the address by the actions in blocks (fromHeight, toHeight]
+	GetBalanceDelta(address string, fromHeight, toHeight uint64) (*big.Int, error)
 	// GetActionByActionHash returns action by action hash
 	GetActionByActionHash(h hash.Hash256) (action.SealedEnvelope, error)
 	// GetBlockHashByActionHash returns Block hash by action hash
@@ -118,12 +138,22 @@ type Blockchain interface {
 	TipHash() hash.Hash256
 	// TipHeight returns tip block's height
 	TipHeight() uint64
-	// StateByAddr returns account of a given address
+	// StateByAddr returns account of a given address at the tip, which is the empty account of 0 balance and nonce for
+	// an address never seen on the chain
 	StateByAddr(address string) (*state.Account, error)
+	// ExportStateCSV writes the address, balance, nonce and candidate status of every account at the tip as CSV
+	ExportStateCSV(w io.Writer) error
 	// RecoverChainAndState recovers the chain to target height and refresh state db if necessary
 	RecoverChainAndState(targetHeight uint64) error
+	// VerifyChain re-validates the stored blocks in [from, to] by replaying the chain from genesis into a throwaway
+	// state, neither touching the chain nor the states, and returns the first inconsistency found
+	VerifyChain(from, to uint64) error
 	// GenesisTimestamp returns the timestamp of genesis
 	GenesisTimestamp() int64
+	// GetForkChoice returns the best tip among the canonical tip and the competing fork tips, which is the highest one,
+	// then the earliest one. A fork branching off deeper than Chain.MaxReorgDepth blocks below the tip or below the
+	// latest checkpoint is ignored.
+	GetForkChoice() (*block.Block, error)
 
 	// For block operations
 	// MintNewBlock creates a new block with given actions
@@ -167,11 +197,15 @@ type blockchain struct {
 	clk           clock.Clock
 	blocklistener []BlockCreationSubscriber
 	timerFactory  *prometheustimer.TimerFactory
+	forks         *forkTips
+	checkpoints   checkpoints
 
 	// used by account-based model
 	sf factory.Factory
 
 	registry *protocol.Registry
+	// signer signs the blocks and system actions produced by this node
+	signer keypair.Signer
 
 	enableExperimentalActions bool
 }
@@ -272,6 +306,14 @@ func RegistryOption(registry *protocol.Registry) Option {
 	}
 }
 
+// SignerOption sets the signer of produced blocks instead of the producer private key in config
+func SignerOption(signer keypair.Signer) Option {
+	return func(bc *blockchain, conf config.Config) error {
+		bc.signer = signer
+		return nil
+	}
+}
+
 // EnableExperimentalActions enables the blockchain to process experimental actions
 func EnableExperimentalActions() Option {
 	return func(bc *blockchain, conf config.Config) error {
@@ -282,16 +324,22 @@ func EnableExperimentalActions() Option {
 
 // NewBlockchain creates a new blockchain and DB instance
 func NewBlockchain(cfg config.Config, opts ...Option) Blockchain {
+	metrics.MustRegister(blockMtc)
 	// create the Blockchain
 	chain := &blockchain{
-		config: cfg,
-		clk:    clock.New(),
+		config:      cfg,
+		clk:         clock.New(),
+		forks:       newForkTips(),
+		checkpoints: newCheckpoints(cfg.Chain.Checkpoints),
 	}
 	for _, opt := range opts {
 		if err := opt(chain, cfg); err != nil {
 			log.S().Panicf("Failed to execute blockchain creation option %p: %v", opt, err)
 		}
 	}
+	if chain.signer == nil {
+		chain.signer = cfg.ProducerPrivateKey()
+	}
 	timerFactory, err := prometheustimer.New(
 		"iotex_blockchain_perf",
 		"Performance of blockchain module",
@@ -299,17 +347,18 @@ func NewBlockchain(cfg config.Config, opts ...Option) Blockchain {
 		[]string{"default", strconv.FormatUint(uint64(cfg.Chain.ID), 10)},
 	)
 	if err != nil {
-		log.L().Panic("Failed to generate prometheus timer factory.", zap.Error(err))
+		log.Logger("blockchain").Panic("Failed to generate prometheus timer factory.", zap.Error(err))
 	}
 	chain.timerFactory = timerFactory
 	// Set block validator
 	if err != nil {
-		log.L().Panic("Failed to get block producer address.", zap.Error(err))
+		log.Logger("blockchain").Panic("Failed to get block producer address.", zap.Error(err))
 	}
 	chain.validator = &validator{
 		sf:                        chain.sf,
 		validatorAddr:             cfg.ProducerAddress().String(),
 		enableExperimentalActions: chain.enableExperimentalActions,
+		strictNonceOrder:          cfg.Chain.StrictNonceOrder,
 	}
 
 	if chain.dao != nil {
@@ -340,6 +389,9 @@ func (bc *blockchain) Start(ctx context.Context) (err error) {
 	if bc.tipHeight, err = bc.dao.getBlockchainHeight(); err != nil {
 		return err
 	}
+	if err = bc.rebuildIndexes(); err != nil {
+		return err
+	}
 	if bc.tipHeight == 0 {
 		return bc.startEmptyBlockchain()
 	}
@@ -347,7 +399,17 @@ func (bc *blockchain) Start(ctx context.Context) (err error) {
 	if bc.tipHash, err = bc.dao.getBlockHash(bc.tipHeight); err != nil {
 		return err
 	}
-	return bc.startExistingBlockchain()
+	if err = bc.verifyCheckpoints(); err != nil {
+		return err
+	}
+	if err = bc.startExistingBlockchain(); err != nil {
+		return err
+	}
+	if !bc.dao.writeIndex {
+		// the index written asynchronously is reconciled by the index builder in the background
+		return nil
+	}
+	return bc.dao.reconcileIndexes(bc.tipHeight)
 }
 
 // Stop stops the blockchain.
@@ -368,6 +430,13 @@ func (bc *blockchain) Nonce(addr string) (uint64, error) {
 	return bc.sf.Nonce(addr)
 }
 
+// GetSentActionCount returns the number of the committed actions sent by the address. Each action sent bumps the
+// confirmed nonce of the sender by one from the starting nonce 0, so it is read from the account state rather than
+// counted over the blocks.
+func (bc *blockchain) GetSentActionCount(addr string) (uint64, error) {
+	return bc.sf.Nonce(addr)
+}
+
 // CandidatesByHeight returns the candidate list by a given height
 func (bc *blockchain) CandidatesByHeight(height uint64) ([]*state.Candidate, error) {
 	return bc.candidatesByHeight(height)
@@ -454,15 +523,69 @@ func (bc *blockchain) GetBlockByHeight(height uint64) (*block.Block, error) {
 	if blk == nil || err != nil {
 		return blk, err
 	}
-	blk.HeaderLogger(log.L()).Debug("Get block.")
+	blk.HeaderLogger(log.Logger("blockchain")).Debug("Get block.")
 	return blk, err
 }
 
+// GetBlocksByHeightRange returns the blocks of the heights in [start, end] in ascending order
+func (bc *blockchain) GetBlocksByHeightRange(start, end uint64) ([]*block.Block, error) {
+	tipHeight := bc.TipHeight()
+	if start == 0 || start > end || end > tipHeight {
+		return nil, errors.Wrapf(db.ErrNotExist, "cannot get blocks %d to %d of the chain at height %d", start, end, tipHeight)
+	}
+	if end-start >= MaxBlocksInRange {
+		return nil, errors.Errorf("cannot get more than %d blocks at a time", MaxBlocksInRange)
+	}
+	blks := make([]*block.Block, 0, end-start+1)
+	for height := start; height <= end; height++ {
+		blk, err := bc.getBlockByHeight(height)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get block %d", height)
+		}
+		blks = append(blks, blk)
+	}
+	return blks, nil
+}
+
 // GetBlockByHash returns block from the blockchain hash by hash
 func (bc *blockchain) GetBlockByHash(h hash.Hash256) (*block.Block, error) {
 	return bc.dao.getBlock(h)
 }
 
+// GetBlockVotes returns the votes in the block of the given height, without deserializing the other actions
+func (bc *blockchain) GetBlockVotes(height uint64) ([]*action.Vote, error) {
+	selps, err := bc.blockActions(height, func(core *iotextypes.ActionCore) bool {
+		return core.GetVote() != nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	votes := []*action.Vote{}
+	for _, selp := range selps {
+		if vote, ok := selp.Action().(*action.Vote); ok {
+			votes = append(votes, vote)
+		}
+	}
+	return votes, nil
+}
+
+// GetBlockTransfers returns the transfers in the block of the given height, without deserializing the other actions
+func (bc *blockchain) GetBlockTransfers(height uint64) ([]*action.Transfer, error) {
+	selps, err := bc.blockActions(height, func(core *iotextypes.ActionCore) bool {
+		return core.GetTransfer() != nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	transfers := []*action.Transfer{}
+	for _, selp := range selps {
+		if tsf, ok := selp.Action().(*action.Transfer); ok {
+			transfers = append(transfers, tsf)
+		}
+	}
+	return transfers, nil
+}
+
 func (bc *blockchain) BlockHeaderByHeight(height uint64) (*block.Header, error) {
 	return bc.blockHeaderByHeight(height)
 }
@@ -471,6 +594,69 @@ func (bc *blockchain) BlockHeaderByHash(h hash.Hash256) (*block.Header, error) {
 	return bc.dao.Header(h)
 }
 
+// GetBlockTime returns the timestamp of the block of the given height in Unix seconds. Only the block header is read.
+// Height 0 has no block stored, and its time is the genesis timestamp.
+func (bc *blockchain) GetBlockTime(height uint64) (int64, error) {
+	if height == 0 {
+		return bc.config.Genesis.Timestamp, nil
+	}
+	ts, err := bc.blockTimestamp(height)
+	if err != nil {
+		return 0, err
+	}
+	return ts.Unix(), nil
+}
+
+// GetBlockIntervalStats returns the min, max and average intervals between the last lastN+1 blocks, or between all
+// the blocks if the chain is shorter. It reads the block headers only, like GetBlockTime, but keeps the timestamps in
+// full precision, since the block interval could be less than a second.
+func (bc *blockchain) GetBlockIntervalStats(lastN int) (min, max, avg time.Duration, err error) {
+	if lastN <= 0 {
+		return 0, 0, 0, errors.Errorf("invalid number of block intervals %d", lastN)
+	}
+	tip := bc.TipHeight()
+	if tip < 2 {
+		return 0, 0, 0, errors.Wrapf(db.ErrNotExist, "no block interval at height %d", tip)
+	}
+	start := uint64(1)
+	if tip > uint64(lastN) {
+		start = tip - uint64(lastN)
+	}
+	prev, err := bc.blockTimestamp(start)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	var total time.Duration
+	for height := start + 1; height <= tip; height++ {
+		ts, err := bc.blockTimestamp(height)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		interval := ts.Sub(prev)
+		if height == start+1 || interval < min {
+			min = interval
+		}
+		if height == start+1 || interval > max {
+			max = interval
+		}
+		total += interval
+		prev = ts
+	}
+	return min, max, total / time.Duration(tip-start), nil
+}
+
+// blockTimestamp returns the timestamp of the block of the given height, reading the block header only
+func (bc *blockchain) blockTimestamp(height uint64) (time.Time, error) {
+	if height == 0 || height > bc.TipHeight() {
+		return time.Time{}, errors.Wrapf(db.ErrNotExist, "height %d", height)
+	}
+	header, err := bc.blockHeaderByHeight(height)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return header.Timestamp(), nil
+}
+
 func (bc *blockchain) BlockFooterByHeight(height uint64) (*block.Footer, error) {
 	return bc.blockFooterByHeight(height)
 }
@@ -575,7 +761,27 @@ func (bc *blockchain) ValidateBlock(blk *block.Block) error {
 	defer bc.mu.RUnlock()
 	timer := bc.timerFactory.NewTimer("ValidateBlock")
 	defer timer.End()
-	return bc.validateBlock(blk)
+	err := bc.validateBlock(blk)
+	if errors.Cause(err) == ErrInvalidTipHeight || errors.Cause(err) == ErrInvalidBlock {
+		bc.trackFork(blk)
+	}
+	return err
+}
+
+// GetForkChoice returns the best tip among the canonical tip and the competing fork tips
+func (bc *blockchain) GetForkChoice() (*block.Block, error) {
+	bc.mu.RLock()
+	tipHash := bc.tipHash
+	bc.mu.RUnlock()
+	tip, err := bc.dao.getBlock(tipHash)
+	if err != nil {
+		tip = nil
+	}
+	minBase := bc.minForkBase(bc.TipHeight())
+	if best := bc.forks.best(tip, minBase); best != nil {
+		return best, nil
+	}
+	return nil, errors.Wrap(err, "failed to get the tip block")
 }
 
 func (bc *blockchain) MintNewBlock(
@@ -594,12 +800,16 @@ func (bc *blockchain) MintNewBlock(
 		return nil, errors.Wrap(err, "Failed to obtain working set from state factory")
 	}
 
+	producer, err := addrutil.PubKeyToAddress(bc.signer.PublicKey())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get producer address from signer")
+	}
 	gasLimitForContext := bc.config.Genesis.BlockGasLimit
 	ctx := protocol.WithRunActionsCtx(context.Background(),
 		protocol.RunActionsCtx{
 			BlockHeight:    newblockHeight,
 			BlockTimeStamp: timestamp,
-			Producer:       bc.config.ProducerAddress(),
+			Producer:       producer,
 			GasLimit:       gasLimitForContext,
 			ActionGasLimit: bc.config.Genesis.ActionGasLimit,
 			Registry:       bc.registry,
@@ -611,12 +821,11 @@ func (bc *blockchain) MintNewBlock(
 
 	blockMtc.WithLabelValues("numActions").Set(float64(len(actions)))
 
-	sk := bc.config.ProducerPrivateKey()
 	ra := block.NewRunnableActionsBuilder().
 		SetHeight(newblockHeight).
 		SetTimeStamp(timestamp).
 		AddActions(actions...).
-		Build(sk.PublicKey())
+		Build(bc.signer.PublicKey())
 
 	prevBlkHash := bc.tipHash
 	// The first block's previous block hash is pointing to the digest of genesis config. This is to guarantee all nodes
@@ -629,7 +838,7 @@ func (bc *blockchain) MintNewBlock(
 		SetDeltaStateDigest(ws.Digest()).
 		SetReceipts(rc).
 		SetReceiptRoot(calculateReceiptRoot(rc)).
-		SignAndBuild(sk)
+		SignAndBuild(bc.signer)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to create block")
 	}
@@ -638,7 +847,7 @@ func (bc *blockchain) MintNewBlock(
 	return &blk, nil
 }
 
-//  CommitBlock validates and appends a block to the chain
+// CommitBlock validates and appends a block to the chain
 func (bc *blockchain) CommitBlock(blk *block.Block) error {
 	bc.mu.Lock()
 	defer bc.mu.Unlock()
@@ -648,13 +857,13 @@ func (bc *blockchain) CommitBlock(blk *block.Block) error {
 	return bc.commitBlock(blk)
 }
 
-// StateByAddr returns the account of an address
+// StateByAddr returns the account of an address at the tip, or the empty account if the address was never seen
 func (bc *blockchain) StateByAddr(address string) (*state.Account, error) {
 	if bc.sf != nil {
 		s, err := bc.sf.AccountState(address)
 		if err != nil {
-			log.L().Warn("Failed to get account.", zap.String("address", address), zap.Error(err))
-			return nil, errors.New("account does not exist")
+			log.Logger("blockchain").Warn("Failed to get account.", zap.String("address", address), zap.Error(err))
+			return nil, errors.Wrapf(err, "failed to get the account of %s", address)
 		}
 		return s, nil
 	}
@@ -678,7 +887,7 @@ func (bc *blockchain) Validator() Validator {
 func (bc *blockchain) AddSubscriber(s BlockCreationSubscriber) error {
 	bc.mu.Lock()
 	defer bc.mu.Unlock()
-	log.L().Info("Add a subscriber.")
+	log.Logger("blockchain").Info("Add a subscriber.")
 	if s == nil {
 		return errors.New("subscriber could not be nil")
 	}
@@ -693,7 +902,7 @@ func (bc *blockchain) RemoveSubscriber(s BlockCreationSubscriber) error {
 	for i, sub := range bc.blocklistener {
 		if sub == s {
 			bc.blocklistener = append(bc.blocklistener[:i], bc.blocklistener[i+1:]...)
-			log.L().Info("Successfully unsubscribe block creation.")
+			log.Logger("blockchain").Info("Successfully unsubscribe block creation.")
 			return nil
 		}
 	}
@@ -799,6 +1008,78 @@ func (bc *blockchain) RecoverChainAndState(targetHeight uint64) error {
 	return nil
 }
 
+// VerifyChain re-validates the stored blocks in [from, to], which are the signatures, the links to the previous blocks,
+// the action roots, the actions and the state transitions. The blocks below from are replayed without validation to
+// build the states the range starts from.
+func (bc *blockchain) VerifyChain(from, to uint64) error {
+	tipHeight := bc.TipHeight()
+	if from == 0 || from > to || to > tipHeight {
+		return errors.Wrapf(db.ErrNotExist, "cannot verify blocks %d to %d of the chain at height %d", from, to, tipHeight)
+	}
+	sf, err := bc.newReplayFactory()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	if err := sf.Start(ctx); err != nil {
+		return errors.Wrap(err, "failed to start the replay state factory")
+	}
+	defer func() {
+		if err := sf.Stop(ctx); err != nil {
+			log.Logger("blockchain").Error("Error when stopping the replay state factory.", zap.Error(err))
+		}
+	}()
+	ws, err := sf.NewWorkingSet()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain working set from state factory")
+	}
+	if !bc.config.Chain.EmptyGenesis {
+		if err := bc.createGenesisStates(ws); err != nil {
+			return err
+		}
+		_ = ws.UpdateBlockLevelInfo(0)
+	}
+	if err := sf.Commit(ws); err != nil {
+		return errors.Wrap(err, "failed to commit Genesis states")
+	}
+
+	val := bc.replayValidator(sf)
+	prevBlkHash := bc.config.Genesis.Hash()
+	for height := uint64(1); height <= to; height++ {
+		blk, err := bc.getBlockByHeight(height)
+		if err != nil {
+			return err
+		}
+		verify := height >= from
+		if verify {
+			if err := val.Validate(blk, height-1, prevBlkHash); err != nil {
+				return errors.Wrapf(err, "error when verifying block %d", height)
+			}
+		}
+		ws, err := sf.NewWorkingSet()
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain working set from state factory")
+		}
+		receipts, err := bc.runActions(blk.RunnableActions(), ws)
+		if err != nil {
+			return errors.Wrapf(err, "error when replaying block %d", height)
+		}
+		if verify {
+			if err := blk.VerifyDeltaStateDigest(ws.Digest()); err != nil {
+				return errors.Wrapf(err, "error when verifying block %d", height)
+			}
+			if err := blk.VerifyReceiptRoot(calculateReceiptRoot(receipts)); err != nil {
+				return errors.Wrapf(err, "error when verifying block %d", height)
+			}
+		}
+		if err := sf.Commit(ws); err != nil {
+			return errors.Wrapf(err, "failed to commit the states of block %d", height)
+		}
+		prevBlkHash = blk.HashBlock()
+	}
+	return nil
+}
+
 func (bc *blockchain) GenesisTimestamp() int64 {
 	return bc.config.Genesis.Timestamp
 }
@@ -817,11 +1098,11 @@ func (bc *blockchain) protocol(id string) (protocol.Protocol, bool) {
 func (bc *blockchain) mustGetRollDPoSProtocol() *rolldpos.Protocol {
 	p, ok := bc.protocol(rolldpos.ProtocolID)
 	if !ok {
-		log.L().Panic("protocol rolldpos has not been registered")
+		log.Logger("blockchain").Panic("protocol rolldpos has not been registered")
 	}
 	rp, ok := p.(*rolldpos.Protocol)
 	if !ok {
-		log.L().Panic("failed to cast to rolldpos protocol")
+		log.Logger("blockchain").Panic("failed to cast to rolldpos protocol")
 	}
 
 	return rp
@@ -852,6 +1133,17 @@ func (bc *blockchain) getBlockByHeight(height uint64) (*block.Block, error) {
 	return bc.dao.getBlock(hash)
 }
 
+func (bc *blockchain) blockActions(
+	height uint64,
+	match func(*iotextypes.ActionCore) bool,
+) ([]action.SealedEnvelope, error) {
+	hash, err := bc.dao.getBlockHash(height)
+	if err != nil {
+		return nil, err
+	}
+	return bc.dao.bodyActions(hash, match)
+}
+
 func (bc *blockchain) blockHeaderByHeight(height uint64) (*block.Header, error) {
 	hash, err := bc.dao.getBlockHash(height)
 	if err != nil {
@@ -868,6 +1160,23 @@ func (bc *blockchain) blockFooterByHeight(height uint64) (*block.Footer, error)
 	return bc.dao.Footer(hash)
 }
 
+// rebuildIndexes rebuilds the secondary indexes of the blocks if configured, or resumes the rebuild interrupted
+// before
+func (bc *blockchain) rebuildIndexes() error {
+	progress, err := bc.dao.rebuildProgress()
+	if err != nil {
+		return err
+	}
+	if !bc.config.Chain.RebuildIndexes && progress == nil {
+		return nil
+	}
+	if _, gateway := bc.config.Plugins[config.GatewayPlugin]; !gateway {
+		log.L().Warn("Skip rebuilding the indexes, which are only written with the gateway plugin.")
+		return nil
+	}
+	return bc.dao.rebuildIndexes(bc.tipHeight)
+}
+
 func (bc *blockchain) startEmptyBlockchain() error {
 	var ws factory.WorkingSet
 	var err error
@@ -893,35 +1202,14 @@ func (bc *blockchain) startExistingBlockchain() error {
 		return errors.New("statefactory cannot be nil")
 	}
 
-	stateHeight, err := bc.sf.Height()
-	if err != nil {
+	if err := bc.reconcileState(); err != nil {
 		return err
 	}
-	if stateHeight > bc.tipHeight {
-		return errors.New("factory is higher than blockchain")
-	}
-
-	for i := stateHeight + 1; i <= bc.tipHeight; i++ {
-		blk, err := bc.getBlockByHeight(i)
-		if err != nil {
-			return err
-		}
-		ws, err := bc.sf.NewWorkingSet()
-		if err != nil {
-			return errors.Wrap(err, "failed to obtain working set from state factory")
-		}
-		if _, err := bc.runActions(blk.RunnableActions(), ws); err != nil {
-			return err
-		}
-		if err := bc.sf.Commit(ws); err != nil {
-			return err
-		}
-	}
-	stateHeight, err = bc.sf.Height()
+	stateHeight, err := bc.sf.Height()
 	if err != nil {
 		return errors.Wrap(err, "failed to get factory's height")
 	}
-	log.L().Info("Restarting blockchain.",
+	log.Logger("blockchain").Info("Restarting blockchain.",
 		zap.Uint64("chainHeight",
 			bc.tipHeight),
 		zap.Uint64("factoryHeight", stateHeight))
@@ -929,6 +1217,18 @@ func (bc *blockchain) startExistingBlockchain() error {
 }
 
 func (bc *blockchain) validateBlock(blk *block.Block) error {
+	if latest := bc.clk.Now().Add(bc.config.Chain.FutureBlockTolerance); blk.Timestamp().After(latest) {
+		return errors.Wrapf(
+			ErrFutureBlock,
+			"block %d is timestamped at %s, later than %s",
+			blk.Height(),
+			blk.Timestamp(),
+			latest,
+		)
+	}
+	if err := bc.checkpoints.verify(blk.Height(), blk.HashBlock()); err != nil {
+		return err
+	}
 	validateTimer := bc.timerFactory.NewTimer("validate")
 	prevBlkHash := bc.tipHash
 	if blk.Height() == 1 {
@@ -948,7 +1248,7 @@ func (bc *blockchain) validateBlock(blk *block.Block) error {
 	receipts, err := bc.runActions(blk.RunnableActions(), ws)
 	runTimer.End()
 	if err != nil {
-		log.L().Panic("Failed to update state.", zap.Uint64("tipHeight", bc.tipHeight), zap.Error(err))
+		log.Logger("blockchain").Panic("Failed to update state.", zap.Uint64("tipHeight", bc.tipHeight), zap.Error(err))
 	}
 
 	if err = blk.VerifyDeltaStateDigest(ws.Digest()); err != nil {
@@ -968,10 +1268,13 @@ func (bc *blockchain) validateBlock(blk *block.Block) error {
 
 // commitBlock commits a block to the chain
 func (bc *blockchain) commitBlock(blk *block.Block) error {
+	if err := bc.checkpoints.verify(blk.Height(), blk.HashBlock()); err != nil {
+		return err
+	}
 	// Check if it is already exists, and return earlier
 	blkHash, err := bc.dao.getBlockHash(blk.Height())
 	if blkHash != hash.ZeroHash256 {
-		log.L().Debug("Block already exists.", zap.Uint64("height", blk.Height()))
+		log.Logger("blockchain").Debug("Block already exists.", blk.LogFields()...)
 		return nil
 	}
 	// If it's a ready db io error, return earlier with the error
@@ -989,6 +1292,8 @@ func (bc *blockchain) commitBlock(blk *block.Block) error {
 	// update tip hash and height
 	atomic.StoreUint64(&bc.tipHeight, blk.Height())
 	bc.tipHash = blk.HashBlock()
+	blockMtc.WithLabelValues("tipHeight").Set(float64(blk.Height()))
+	bc.forks.remove(bc.tipHash)
 
 	if bc.sf != nil {
 		sfTimer := bc.timerFactory.NewTimer("sf.Commit")
@@ -997,7 +1302,7 @@ func (bc *blockchain) commitBlock(blk *block.Block) error {
 		// detach working set so it can be freed by GC
 		blk.WorkingSet = nil
 		if err != nil {
-			log.L().Panic("Error when committing states.", zap.Error(err))
+			log.Logger("blockchain").Panic("Error when committing states.", zap.Error(err))
 		}
 
 		// write smart contract receipt into DB
@@ -1008,7 +1313,12 @@ func (bc *blockchain) commitBlock(blk *block.Block) error {
 			return errors.Wrapf(err, "failed to put smart contract receipts into DB on height %d", blk.Height())
 		}
 	}
-	blk.HeaderLogger(log.L()).Info("Committed a block.", log.Hex("tipHash", bc.tipHash[:]))
+	blk.HeaderLogger(log.Logger("blockchain")).Info("Committed a block.", log.Hex("tipHash", bc.tipHash[:]))
+	if log.ActionTracing() {
+		for _, selp := range blk.Actions {
+			log.TraceAction(log.StageCommitted, selp.Hash(), blk.LogFields()...)
+		}
+	}
 
 	// emit block to all block subscribers
 	bc.emitToSubscribers(blk)
@@ -1024,7 +1334,7 @@ func (bc *blockchain) runActions(
 	}
 	gasLimit := bc.config.Genesis.BlockGasLimit
 	// update state factory
-	producer, err := address.FromBytes(acts.BlockProducerPubKey().Hash())
+	producer, err := addrutil.PubKeyToAddress(acts.BlockProducerPubKey())
 	if err != nil {
 		return nil, err
 	}
@@ -1052,7 +1362,7 @@ func (bc *blockchain) pickAndRunActions(ctx context.Context, actionMap map[strin
 
 	raCtx := protocol.MustGetRunActionsCtx(ctx)
 	// initial action iterator
-	actionIterator := actioniterator.NewActionIterator(actionMap)
+	actionIterator := actioniterator.NewPriorityActionIterator(actionMap, bc.config.ActPool.PrioritySenders)
 	for {
 		nextAction, ok := actionIterator.Next()
 		if !ok {
@@ -1153,11 +1463,11 @@ func (bc *blockchain) createPutPollResultAction(height uint64) (skip bool, se ac
 	}
 	pl, ok := bc.protocol(poll.ProtocolID)
 	if !ok {
-		log.L().Panic("protocol poll has not been registered")
+		log.Logger("blockchain").Panic("protocol poll has not been registered")
 	}
 	pp, ok := pl.(poll.Protocol)
 	if !ok {
-		log.L().Panic("Failed to cast to poll.Protocol")
+		log.Logger("blockchain").Panic("Failed to cast to poll.Protocol")
 	}
 	rp := bc.mustGetRollDPoSProtocol()
 	epochNum := rp.GetEpochNum(height)
@@ -1166,7 +1476,7 @@ func (bc *blockchain) createPutPollResultAction(height uint64) (skip bool, se ac
 	if height < epochHeight+(nextEpochHeight-epochHeight)/2 {
 		return
 	}
-	log.L().Debug(
+	log.Logger("blockchain").Debug(
 		"createPutPollResultAction",
 		zap.Uint64("height", height),
 		zap.Uint64("epochNum", epochNum),
@@ -1204,11 +1514,10 @@ func (bc *blockchain) createPutPollResultAction(height uint64) (skip bool, se ac
 	default:
 		return
 	}
-	sk := bc.config.ProducerPrivateKey()
 	nonce := uint64(0)
 	pollAction := action.NewPutPollResult(nonce, nextEpochHeight, l)
 	builder := action.EnvelopeBuilder{}
-	se, err = action.Sign(builder.SetNonce(nonce).SetAction(pollAction).Build(), sk)
+	se, err = action.Sign(builder.SetNonce(nonce).SetAction(pollAction).Build(), bc.signer)
 	return skip, se, err
 }
 
@@ -1219,12 +1528,86 @@ func (bc *blockchain) emitToSubscribers(blk *block.Block) {
 	for _, s := range bc.blocklistener {
 		go func(bcs BlockCreationSubscriber, b *block.Block) {
 			if err := bcs.HandleBlock(b); err != nil {
-				log.L().Error("Failed to handle new block.", zap.Error(err))
+				log.Logger("blockchain").Error("Failed to handle new block.", zap.Error(err))
 			}
 		}(s, blk)
 	}
 }
 
+// trackFork records blk as a competing fork tip if it is correctly signed and links to either a canonical block below
+// the tip or a tracked fork tip. A fork branching off deeper than Chain.MaxReorgDepth blocks below the tip or below the
+// latest checkpoint is treated as invalid, and isn't tracked.
+func (bc *blockchain) trackFork(blk *block.Block) {
+	if blk == nil || blk.Height() == 0 || verifySigAndRoot(blk) != nil {
+		return
+	}
+	if bc.checkpoints.verify(blk.Height(), blk.HashBlock()) != nil {
+		return
+	}
+	minBase := bc.minForkBase(bc.tipHeight)
+	bc.forks.prune(minBase)
+	if bc.forks.extend(blk) {
+		blk.HeaderLogger(log.Logger("blockchain")).Info("Extended a competing fork.")
+		return
+	}
+	if blk.Height() > bc.tipHeight {
+		return
+	}
+	if blk.Height()-1 < minBase {
+		blk.HeaderLogger(log.Logger("blockchain")).Warn(
+			"Ignored a competing fork deeper than the max reorg depth or the latest checkpoint.",
+			zap.Uint64("maxReorgDepth", bc.config.Chain.MaxReorgDepth),
+			zap.Uint64("minBase", minBase),
+		)
+		return
+	}
+	parentHash := bc.config.Genesis.Hash()
+	if blk.Height() > 1 {
+		h, err := bc.dao.getBlockHash(blk.Height() - 1)
+		if err != nil {
+			return
+		}
+		parentHash = h
+	}
+	if parentHash != blk.PrevHash() {
+		return
+	}
+	if h, err := bc.dao.getBlockHash(blk.Height()); err == nil && h == blk.HashBlock() {
+		return
+	}
+	if bc.forks.add(blk) {
+		blk.HeaderLogger(log.Logger("blockchain")).Info("Found a competing fork.")
+	}
+}
+
+// minForkBase returns the lowest height a fork may branch off, which is bounded by both the max reorg depth and the
+// latest checkpoint, as a checkpoint cannot be reorged away
+func (bc *blockchain) minForkBase(tipHeight uint64) uint64 {
+	minBase := minForkBase(tipHeight, bc.config.Chain.MaxReorgDepth)
+	if latest := bc.checkpoints.latest(tipHeight); latest > minBase {
+		minBase = latest
+	}
+	return minBase
+}
+
+// verifyCheckpoints checks the committed blocks at the checkpoint heights up to the tip, leaving out the ones below the
+// blocks held by a node synced from a checkpoint
+func (bc *blockchain) verifyCheckpoints() error {
+	for height := range bc.checkpoints {
+		if height > bc.tipHeight || height < bc.dao.lowestHeight {
+			continue
+		}
+		h, err := bc.dao.getBlockHash(height)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get the block at checkpoint %d", height)
+		}
+		if err := bc.checkpoints.verify(height, h); err != nil {
+			return errors.Wrap(err, "the committed chain conflicts with the checkpoints")
+		}
+	}
+	return nil
+}
+
 // RecoverToHeight recovers the blockchain to target height
 func (bc *blockchain) recoverToHeight(targetHeight uint64) error {
 	for bc.tipHeight > targetHeight {
@@ -1262,6 +1645,65 @@ func (bc *blockchain) refreshStateDB() error {
 	return nil
 }
 
+// newReplayFactory creates an in-memory state factory of the same kind as the chain's, since the two kinds compute the
+// delta state digests differently. The state DB keeps no state trie, and thus always has a zero root hash.
+func (bc *blockchain) newReplayFactory() (sf factory.Factory, err error) {
+	if bc.sf == nil {
+		return nil, errors.New("statefactory cannot be nil")
+	}
+	if bc.sf.RootHash() == hash.ZeroHash256 {
+		sf, err = factory.NewStateDB(bc.config, factory.InMemStateDBOption())
+	} else {
+		sf, err = factory.NewFactory(bc.config, factory.InMemTrieOption())
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the replay state factory")
+	}
+	if bc.registry != nil {
+		for _, p := range bc.registry.All() {
+			sf.AddActionHandlers(p)
+		}
+	}
+	return sf, nil
+}
+
+// replayValidator returns the validator of the chain working on the replayed states rather than the chain's
+func (bc *blockchain) replayValidator(sf factory.Factory) Validator {
+	v, ok := bc.validator.(*validator)
+	if !ok {
+		return &validator{
+			sf:                        sf,
+			enableExperimentalActions: bc.enableExperimentalActions,
+			strictNonceOrder:          bc.config.Chain.StrictNonceOrder,
+		}
+	}
+	replay := *v
+	replay.sf = sf
+	// the generic validator checks the nonces against the chain, which is to be the replayed states instead
+	replay.actionEnvelopeValidators = make([]protocol.ActionEnvelopeValidator, len(v.actionEnvelopeValidators))
+	for i, ev := range v.actionEnvelopeValidators {
+		if _, ok := ev.(*protocol.GenericValidator); ok {
+			ev = protocol.NewGenericValidator(&replayChainManager{ChainManager: bc, sf: sf}, bc.config.Genesis.ActionGasLimit)
+		}
+		replay.actionEnvelopeValidators[i] = ev
+	}
+	return &replay
+}
+
+// replayChainManager serves the account states of the replayed chain
+type replayChainManager struct {
+	protocol.ChainManager
+	sf factory.Factory
+}
+
+func (cm *replayChainManager) Nonce(addr string) (uint64, error) {
+	return cm.sf.Nonce(addr)
+}
+
+func (cm *replayChainManager) StateByAddr(addr string) (*state.Account, error) {
+	return cm.sf.AccountState(addr)
+}
+
 func (bc *blockchain) createGrantRewardAction(rewardType int, height uint64) (action.SealedEnvelope, error) {
 	gb := action.GrantRewardBuilder{}
 	grant := gb.SetRewardType(rewardType).SetHeight(height).Build()
@@ -1271,8 +1713,7 @@ func (bc *blockchain) createGrantRewardAction(rewardType int, height uint64) (ac
 		SetGasLimit(grant.GasLimit()).
 		SetAction(&grant).
 		Build()
-	sk := bc.config.ProducerPrivateKey()
-	return action.Sign(envelope, sk)
+	return action.Sign(envelope, bc.signer)
 }
 
 func (bc *blockchain) createGenesisStates(ws factory.WorkingSet) error {
@@ -7,15 +7,24 @@
 package blockchain
 
 import (
+	"bytes"
 	"context"
+	"encoding/csv"
 	"encoding/hex"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"math/big"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 
+	"github.com/facebookgo/clock"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/action"
@@ -23,14 +32,19 @@ import (
 	"github.com/iotexproject/iotex-core/action/protocol/account"
 	accountutil "github.com/iotexproject/iotex-core/action/protocol/account/util"
 	"github.com/iotexproject/iotex-core/action/protocol/execution"
+	"github.com/iotexproject/iotex-core/action/protocol/keyrotation"
 	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
 	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
 	"github.com/iotexproject/iotex-core/action/protocol/vote"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/endorsement"
 	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/unit"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
 	"github.com/iotexproject/iotex-core/state/factory"
 	"github.com/iotexproject/iotex-core/test/identityset"
 	ta "github.com/iotexproject/iotex-core/test/testaddress"
@@ -290,6 +304,19 @@ func TestCreateBlockchain(t *testing.T) {
 	require.NoError(addTestingTsfBlocks(bc))
 	height = bc.TipHeight()
 	require.Equal(5, int(height))
+
+	// read the actions of a given type only
+	blk, err := bc.GetBlockByHeight(1)
+	require.NoError(err)
+	tsfs, err := bc.GetBlockTransfers(1)
+	require.NoError(err)
+	require.Equal(1, len(tsfs))
+	require.Equal(blk.Actions[0].Action(), tsfs[0])
+	votes, err := bc.GetBlockVotes(1)
+	require.NoError(err)
+	require.Equal(0, len(votes))
+	_, err = bc.GetBlockVotes(height + 1)
+	require.Error(err)
 }
 
 func TestBlockchain_MintNewBlock(t *testing.T) {
@@ -810,6 +837,420 @@ func TestBlockchain_Validator(t *testing.T) {
 	require.NotNil(t, bc.Validator())
 }
 
+func TestBlockchain_FutureBlock(t *testing.T) {
+	require := require.New(t)
+	cfg := config.Default
+	cfg.Chain.FutureBlockTolerance = 10 * time.Second
+
+	registry := protocol.Registry{}
+	acc := account.NewProtocol()
+	require.NoError(registry.Register(account.ProtocolID, acc))
+	rp := rolldpos.NewProtocol(cfg.Genesis.NumCandidateDelegates, cfg.Genesis.NumDelegates, cfg.Genesis.NumSubEpochs)
+	require.NoError(registry.Register(rolldpos.ProtocolID, rp))
+	clk := clock.NewMock()
+	clk.Add(time.Now().Sub(clk.Now()))
+	ctx := context.Background()
+	bc := NewBlockchain(cfg, InMemDaoOption(), InMemStateFactoryOption(), RegistryOption(&registry), ClockOption(clk))
+	v := vote.NewProtocol(bc)
+	require.NoError(registry.Register(vote.ProtocolID, v))
+	bc.GetFactory().AddActionHandlers(acc, v)
+	require.NoError(bc.Start(ctx))
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+	}()
+
+	// a block within the tolerance is accepted
+	blk, err := bc.MintNewBlock(nil, clk.Now().Add(9*time.Second))
+	require.NoError(err)
+	require.NoError(bc.ValidateBlock(blk))
+
+	// a block beyond the tolerance is rejected, until the clock catches up
+	blk, err = bc.MintNewBlock(nil, clk.Now().Add(11*time.Second))
+	require.NoError(err)
+	require.Equal(ErrFutureBlock, errors.Cause(bc.ValidateBlock(blk)))
+	clk.Add(time.Second)
+	require.NoError(bc.ValidateBlock(blk))
+}
+
+func TestBlockchain_GetForkChoice(t *testing.T) {
+	require := require.New(t)
+	cfg := config.Default
+
+	registry := protocol.Registry{}
+	acc := account.NewProtocol()
+	require.NoError(registry.Register(account.ProtocolID, acc))
+	rp := rolldpos.NewProtocol(cfg.Genesis.NumCandidateDelegates, cfg.Genesis.NumDelegates, cfg.Genesis.NumSubEpochs)
+	require.NoError(registry.Register(rolldpos.ProtocolID, rp))
+	ctx := context.Background()
+	bc := NewBlockchain(cfg, InMemDaoOption(), InMemStateFactoryOption(), RegistryOption(&registry))
+	v := vote.NewProtocol(bc)
+	require.NoError(registry.Register(vote.ProtocolID, v))
+	bc.GetFactory().AddActionHandlers(acc, v)
+	require.NoError(bc.Start(ctx))
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+	}()
+
+	now := time.Now()
+	for i := 1; i <= 2; i++ {
+		blk, err := bc.MintNewBlock(nil, now.Add(time.Duration(i)*time.Second))
+		require.NoError(err)
+		require.NoError(bc.ValidateBlock(blk))
+		require.NoError(bc.CommitBlock(blk))
+	}
+	tip, err := bc.GetForkChoice()
+	require.NoError(err)
+	require.Equal(bc.TipHash(), tip.HashBlock())
+
+	forkBlock := func(height uint64, prevHash hash.Hash256, ts time.Time) *block.Block {
+		blk, err := block.NewTestingBuilder().
+			SetHeight(height).
+			SetPrevBlockHash(prevHash).
+			SetTimeStamp(ts).
+			SignAndBuild(identityset.PrivateKey(1).PublicKey(), identityset.PrivateKey(1))
+		require.NoError(err)
+		return &blk
+	}
+	h1, err := bc.GetHashByHeight(1)
+	require.NoError(err)
+
+	// a later competing block of the same height is not preferred
+	later := forkBlock(2, h1, now.Add(3*time.Second))
+	require.Error(bc.ValidateBlock(later))
+	tip, err = bc.GetForkChoice()
+	require.NoError(err)
+	require.Equal(bc.TipHash(), tip.HashBlock())
+
+	// an earlier competing block of the same height is preferred
+	earlier := forkBlock(2, h1, now)
+	require.Error(bc.ValidateBlock(earlier))
+	tip, err = bc.GetForkChoice()
+	require.NoError(err)
+	require.Equal(earlier.HashBlock(), tip.HashBlock())
+
+	// a higher block extending the fork is preferred regardless of the timestamp
+	higher := forkBlock(3, later.HashBlock(), now.Add(4*time.Second))
+	require.Error(bc.ValidateBlock(higher))
+	tip, err = bc.GetForkChoice()
+	require.NoError(err)
+	require.Equal(higher.HashBlock(), tip.HashBlock())
+
+	// a block linking to nowhere is ignored
+	orphan := forkBlock(4, hash.ZeroHash256, now)
+	require.Error(bc.ValidateBlock(orphan))
+	tip, err = bc.GetForkChoice()
+	require.NoError(err)
+	require.Equal(higher.HashBlock(), tip.HashBlock())
+}
+
+func TestBlockchain_ExportStateCSV(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	cfg := config.Default
+	registry := protocol.Registry{}
+	acc := account.NewProtocol()
+	require.NoError(registry.Register(account.ProtocolID, acc))
+	rp := rolldpos.NewProtocol(cfg.Genesis.NumCandidateDelegates, cfg.Genesis.NumDelegates, cfg.Genesis.NumSubEpochs)
+	require.NoError(registry.Register(rolldpos.ProtocolID, rp))
+	bc := NewBlockchain(cfg, InMemStateFactoryOption(), InMemDaoOption(), RegistryOption(&registry), EnableExperimentalActions())
+	v := vote.NewProtocol(bc)
+	require.NoError(registry.Register(vote.ProtocolID, v))
+	bc.GetFactory().AddActionHandlers(acc, v)
+	require.NoError(bc.Start(ctx))
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+	}()
+	require.NoError(addTestingTsfBlocks(bc))
+
+	var buf bytes.Buffer
+	require.NoError(bc.ExportStateCSV(&buf))
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(err)
+	require.Equal([]string{"address", "balance", "nonce", "isCandidate"}, rows[0])
+	rows = rows[1:]
+
+	// every account shows up once, with its state at the tip
+	initAddrs, initBalances := cfg.Genesis.InitBalances()
+	require.True(len(rows) > len(initAddrs))
+	total := big.NewInt(0)
+	exported := make(map[string]bool)
+	for _, row := range rows {
+		require.False(exported[row[0]])
+		exported[row[0]] = true
+		balance, err := bc.Balance(row[0])
+		require.NoError(err)
+		require.Equal(balance.String(), row[1])
+		nonce, err := bc.Nonce(row[0])
+		require.NoError(err)
+		require.Equal(strconv.FormatUint(nonce, 10), row[2])
+		total.Add(total, balance)
+	}
+	for _, addr := range initAddrs {
+		require.True(exported[addr.String()])
+	}
+	for _, name := range []string{"producer", "alfa", "bravo", "charlie", "delta", "echo", "foxtrot"} {
+		require.True(exported[ta.Addrinfo[name].String()])
+	}
+
+	// the balances sum up to the initial supply minus the burnt gas fees
+	supply := big.NewInt(0)
+	for _, balance := range initBalances {
+		supply.Add(supply, balance)
+	}
+	for height := uint64(1); height <= bc.TipHeight(); height++ {
+		blk, err := bc.GetBlockByHeight(height)
+		require.NoError(err)
+		receipts, err := bc.(*blockchain).dao.getReceipts(height)
+		require.NoError(err)
+		gasPrices := make(map[hash.Hash256]*big.Int)
+		for _, selp := range blk.Actions {
+			gasPrices[selp.Hash()] = selp.GasPrice()
+		}
+		for _, r := range receipts {
+			fee := new(big.Int).Mul(gasPrices[r.ActionHash], new(big.Int).SetUint64(r.GasConsumed))
+			supply.Sub(supply, fee)
+		}
+	}
+	require.Equal(supply, total)
+
+	// an account funded by a contract shows up in no action, but is exported as well
+	funded := testutil.NewKeyPair("funded by contract").Address.String()
+	require.False(exported[funded])
+	sf := bc.GetFactory()
+	ws, err := sf.NewWorkingSet()
+	require.NoError(err)
+	_, err = accountutil.LoadOrCreateAccount(ws, funded, big.NewInt(7))
+	require.NoError(err)
+	require.NoError(sf.Commit(ws))
+	buf.Reset()
+	require.NoError(bc.ExportStateCSV(&buf))
+	rows, err = csv.NewReader(&buf).ReadAll()
+	require.NoError(err)
+	require.Contains(rows, []string{funded, "7", "0", "false"})
+}
+
+func TestBlockchain_MaxReorgDepth(t *testing.T) {
+	require := require.New(t)
+	cfg := config.Default
+	cfg.Chain.MaxReorgDepth = 2
+
+	registry := protocol.Registry{}
+	acc := account.NewProtocol()
+	require.NoError(registry.Register(account.ProtocolID, acc))
+	rp := rolldpos.NewProtocol(cfg.Genesis.NumCandidateDelegates, cfg.Genesis.NumDelegates, cfg.Genesis.NumSubEpochs)
+	require.NoError(registry.Register(rolldpos.ProtocolID, rp))
+	ctx := context.Background()
+	bc := NewBlockchain(cfg, InMemDaoOption(), InMemStateFactoryOption(), RegistryOption(&registry))
+	v := vote.NewProtocol(bc)
+	require.NoError(registry.Register(vote.ProtocolID, v))
+	bc.GetFactory().AddActionHandlers(acc, v)
+	require.NoError(bc.Start(ctx))
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+	}()
+
+	now := time.Now()
+	mint := func(i int) {
+		blk, err := bc.MintNewBlock(nil, now.Add(time.Duration(i)*time.Second))
+		require.NoError(err)
+		require.NoError(bc.ValidateBlock(blk))
+		require.NoError(bc.CommitBlock(blk))
+	}
+	for i := 1; i <= 4; i++ {
+		mint(i)
+	}
+	forkBlock := func(height uint64, prevHash hash.Hash256) *block.Block {
+		blk, err := block.NewTestingBuilder().
+			SetHeight(height).
+			SetPrevBlockHash(prevHash).
+			SetTimeStamp(now).
+			SignAndBuild(identityset.PrivateKey(1).PublicKey(), identityset.PrivateKey(1))
+		require.NoError(err)
+		return &blk
+	}
+
+	// a fork reorging 3 blocks is ignored even if it is preferred
+	prevHash, err := bc.GetHashByHeight(1)
+	require.NoError(err)
+	for height := uint64(2); height <= 5; height++ {
+		blk := forkBlock(height, prevHash)
+		require.Error(bc.ValidateBlock(blk))
+		prevHash = blk.HashBlock()
+	}
+	tip, err := bc.GetForkChoice()
+	require.NoError(err)
+	require.Equal(bc.TipHash(), tip.HashBlock())
+
+	// a fork reorging 2 blocks is chosen
+	prevHash, err = bc.GetHashByHeight(2)
+	require.NoError(err)
+	shallow := forkBlock(3, prevHash)
+	require.Error(bc.ValidateBlock(shallow))
+	shallow = forkBlock(4, shallow.HashBlock())
+	require.Error(bc.ValidateBlock(shallow))
+	tip, err = bc.GetForkChoice()
+	require.NoError(err)
+	require.Equal(shallow.HashBlock(), tip.HashBlock())
+
+	// the fork becomes too deep once the canonical chain grows
+	mint(5)
+	tip, err = bc.GetForkChoice()
+	require.NoError(err)
+	require.Equal(bc.TipHash(), tip.HashBlock())
+}
+
+func TestBlockchain_Checkpoints(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	newChain := func(cfg config.Config, opts ...Option) Blockchain {
+		registry := protocol.Registry{}
+		acc := account.NewProtocol()
+		require.NoError(registry.Register(account.ProtocolID, acc))
+		rp := rolldpos.NewProtocol(cfg.Genesis.NumCandidateDelegates, cfg.Genesis.NumDelegates, cfg.Genesis.NumSubEpochs)
+		require.NoError(registry.Register(rolldpos.ProtocolID, rp))
+		bc := NewBlockchain(cfg, append(opts, InMemStateFactoryOption(), RegistryOption(&registry))...)
+		v := vote.NewProtocol(bc)
+		require.NoError(registry.Register(vote.ProtocolID, v))
+		bc.GetFactory().AddActionHandlers(acc, v)
+		return bc
+	}
+
+	// mint the blocks to replay
+	cfg := config.Default
+	bc := newChain(cfg, InMemDaoOption())
+	require.NoError(bc.Start(ctx))
+	now := time.Now()
+	var blks []*block.Block
+	for i := 1; i <= 4; i++ {
+		blk, err := bc.MintNewBlock(nil, now.Add(time.Duration(i)*time.Second))
+		require.NoError(err)
+		require.NoError(bc.ValidateBlock(blk))
+		require.NoError(bc.CommitBlock(blk))
+		blks = append(blks, blk)
+	}
+	require.NoError(bc.Stop(ctx))
+
+	checkpoint := func(blk *block.Block) string {
+		h := blk.HashBlock()
+		return hex.EncodeToString(h[:])
+	}
+	cfg.Chain.Checkpoints = map[uint64]string{2: checkpoint(blks[1])}
+	dao := newBlockDAO(db.NewMemKVStore(), false, false, 0)
+	bc = newChain(cfg, PrecreatedDaoOption(dao))
+	require.NoError(bc.Start(ctx))
+	forkBlock := func(height uint64, prevHash hash.Hash256, ts time.Time) *block.Block {
+		blk, err := block.NewTestingBuilder().
+			SetHeight(height).
+			SetPrevBlockHash(prevHash).
+			SetTimeStamp(ts).
+			SignAndBuild(identityset.PrivateKey(1).PublicKey(), identityset.PrivateKey(1))
+		require.NoError(err)
+		return &blk
+	}
+	require.NoError(bc.ValidateBlock(blks[0]))
+	require.NoError(bc.CommitBlock(blks[0]))
+
+	// a block conflicting with the checkpoint is refused
+	conflict := forkBlock(2, blks[0].HashBlock(), now)
+	require.Equal(ErrCheckpointMismatch, errors.Cause(bc.ValidateBlock(conflict)))
+	require.Equal(ErrCheckpointMismatch, errors.Cause(bc.CommitBlock(conflict)))
+	for _, blk := range blks[1:] {
+		require.NoError(bc.ValidateBlock(blk))
+		require.NoError(bc.CommitBlock(blk))
+	}
+
+	// a fork reorging the checkpoint is ignored even if it is preferred, while the one above it is chosen
+	deep := forkBlock(2, blks[0].HashBlock(), now)
+	require.Error(bc.ValidateBlock(deep))
+	for height := uint64(3); height <= 5; height++ {
+		deep = forkBlock(height, deep.HashBlock(), now)
+		require.Error(bc.ValidateBlock(deep))
+	}
+	tip, err := bc.GetForkChoice()
+	require.NoError(err)
+	require.Equal(bc.TipHash(), tip.HashBlock())
+	shallow := forkBlock(3, blks[1].HashBlock(), now)
+	require.Error(bc.ValidateBlock(shallow))
+	shallow = forkBlock(4, shallow.HashBlock(), now)
+	require.Error(bc.ValidateBlock(shallow))
+	tip, err = bc.GetForkChoice()
+	require.NoError(err)
+	require.Equal(shallow.HashBlock(), tip.HashBlock())
+	require.NoError(bc.Stop(ctx))
+
+	// the node refuses to start on a chain conflicting with the checkpoints
+	cfg.Chain.Checkpoints = map[uint64]string{2: checkpoint(blks[2])}
+	bc = newChain(cfg, PrecreatedDaoOption(dao))
+	require.Equal(ErrCheckpointMismatch, errors.Cause(bc.Start(ctx)))
+}
+
+func TestBlockchain_RotateKey(t *testing.T) {
+	require := require.New(t)
+	cfg := config.Default
+	cfg.Genesis.NumDelegates = 2
+	cfg.Genesis.NumSubEpochs = 1
+
+	registry := protocol.Registry{}
+	acc := account.NewProtocol()
+	require.NoError(registry.Register(account.ProtocolID, acc))
+	rp := rolldpos.NewProtocol(cfg.Genesis.NumCandidateDelegates, cfg.Genesis.NumDelegates, cfg.Genesis.NumSubEpochs)
+	require.NoError(registry.Register(rolldpos.ProtocolID, rp))
+	ctx := context.Background()
+	bc := NewBlockchain(cfg, InMemDaoOption(), InMemStateFactoryOption(), RegistryOption(&registry))
+	v := vote.NewProtocol(bc)
+	require.NoError(registry.Register(vote.ProtocolID, v))
+	kr := keyrotation.NewProtocol(bc, rp)
+	require.NoError(registry.Register(keyrotation.ProtocolID, kr))
+	bc.GetFactory().AddActionHandlers(acc, v, kr)
+	require.NoError(bc.Start(ctx))
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+	}()
+
+	identity := cfg.ProducerAddress().String()
+	// the new key must not be the key of a candidate
+	newKey, err := keypair.GenerateKey()
+	require.NoError(err)
+	rk, err := action.NewRotateKey(1, identity, newKey, nil, action.RotateKeyIntrinsicGas, big.NewInt(0))
+	require.NoError(err)
+	bd := &action.EnvelopeBuilder{}
+	elp := bd.SetAction(rk).
+		SetNonce(1).
+		SetGasLimit(action.RotateKeyIntrinsicGas).
+		SetGasPrice(big.NewInt(0)).Build()
+	selp, err := action.Sign(elp, cfg.ProducerPrivateKey())
+	require.NoError(err)
+
+	// the new key becomes effective from the next epoch, i.e., height 3
+	now := time.Now()
+	blk, err := bc.MintNewBlock(map[string][]action.SealedEnvelope{identity: {selp}}, now)
+	require.NoError(err)
+	require.NoError(bc.ValidateBlock(blk))
+	require.Equal(action.SuccessReceiptStatus, blk.Receipts[0].Status)
+	require.NoError(bc.CommitBlock(blk))
+	blk, err = bc.MintNewBlock(nil, now.Add(time.Second))
+	require.NoError(err)
+	require.NoError(bc.ValidateBlock(blk))
+	require.NoError(bc.CommitBlock(blk))
+
+	// the old key is rejected after the effective height
+	blk, err = bc.MintNewBlock(nil, now.Add(2*time.Second))
+	require.NoError(err)
+	require.Equal(keyrotation.ErrRetiredKey, errors.Cause(bc.ValidateBlock(blk)))
+
+	// the new key is accepted on behalf of the identity
+	bc.(*blockchain).signer = newKey
+	blk, err = bc.MintNewBlock(nil, now.Add(2*time.Second))
+	require.NoError(err)
+	require.Equal(newKey.PublicKey(), blk.PublicKey())
+	require.NoError(bc.ValidateBlock(blk))
+	require.NoError(bc.CommitBlock(blk))
+	delegate, err := keyrotation.DelegateOf(bc.GetFactory(), blk.ProducerAddress(), blk.Height())
+	require.NoError(err)
+	require.Equal(identity, delegate)
+}
+
 func TestBlockchainInitialCandidate(t *testing.T) {
 	require := require.New(t)
 
@@ -874,6 +1315,61 @@ func TestBlockchain_StateByAddr(t *testing.T) {
 	require.Equal(false, s.IsCandidate)
 	require.Equal(big.NewInt(0), s.VotingWeight)
 	require.Equal("", s.Votee)
+
+	// an address never seen has the empty account rather than an error
+	unknown := identityset.Address(1).String()
+	s, err = bc.StateByAddr(unknown)
+	require.NoError(err)
+	require.Equal(uint64(0), s.Nonce)
+	require.Equal(big.NewInt(0), s.Balance)
+	balance, err := bc.Balance(unknown)
+	require.NoError(err)
+	require.Equal(big.NewInt(0), balance)
+	nonce, err := bc.Nonce(unknown)
+	require.NoError(err)
+	require.Equal(uint64(0), nonce)
+
+	_, err = bc.StateByAddr("invalid")
+	require.Error(err)
+}
+
+func TestBlockchain_GetSentActionCount(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	cfg := config.Default
+	registry := protocol.Registry{}
+	acc := account.NewProtocol()
+	require.NoError(registry.Register(account.ProtocolID, acc))
+	rp := rolldpos.NewProtocol(cfg.Genesis.NumCandidateDelegates, cfg.Genesis.NumDelegates, cfg.Genesis.NumSubEpochs)
+	require.NoError(registry.Register(rolldpos.ProtocolID, rp))
+	bc := NewBlockchain(cfg, InMemStateFactoryOption(), InMemDaoOption(), RegistryOption(&registry), EnableExperimentalActions())
+	v := vote.NewProtocol(bc)
+	require.NoError(registry.Register(vote.ProtocolID, v))
+	bc.GetFactory().AddActionHandlers(acc, v)
+	require.NoError(bc.Start(ctx))
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+	}()
+	require.NoError(addTestingTsfBlocks(bc))
+
+	for _, name := range []string{"producer", "alfa", "charlie"} {
+		addr := ta.Addrinfo[name].String()
+		count, err := bc.GetSentActionCount(addr)
+		require.NoError(err)
+		require.NotZero(count, name)
+		s, err := bc.StateByAddr(addr)
+		require.NoError(err)
+		require.Equal(s.Nonce, count, name)
+	}
+	// the addresses only receiving the transfers
+	for _, name := range []string{"bravo", "foxtrot"} {
+		count, err := bc.GetSentActionCount(ta.Addrinfo[name].String())
+		require.NoError(err)
+		require.Zero(count, name)
+	}
+	_, err := bc.GetSentActionCount("invalid")
+	require.Error(err)
 }
 
 func TestBlocks(t *testing.T) {
@@ -1023,3 +1519,615 @@ func addCreatorToFactory(sf factory.Factory) error {
 	}
 	return sf.Commit(ws)
 }
+
+func TestBlockchain_GetBlockTime(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	cfg := config.Default
+	registry := protocol.Registry{}
+	acc := account.NewProtocol()
+	require.NoError(registry.Register(account.ProtocolID, acc))
+	rp := rolldpos.NewProtocol(cfg.Genesis.NumCandidateDelegates, cfg.Genesis.NumDelegates, cfg.Genesis.NumSubEpochs)
+	require.NoError(registry.Register(rolldpos.ProtocolID, rp))
+	bc := NewBlockchain(cfg, InMemStateFactoryOption(), InMemDaoOption(), RegistryOption(&registry), EnableExperimentalActions())
+	v := vote.NewProtocol(bc)
+	require.NoError(registry.Register(vote.ProtocolID, v))
+	bc.GetFactory().AddActionHandlers(acc, v)
+	require.NoError(bc.Start(ctx))
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+	}()
+	require.NoError(addTestingTsfBlocks(bc))
+
+	tip := bc.TipHeight()
+	for height := uint64(1); height <= tip; height++ {
+		blk, err := bc.GetBlockByHeight(height)
+		require.NoError(err)
+		ts, err := bc.GetBlockTime(height)
+		require.NoError(err)
+		require.Equal(blk.Timestamp().Unix(), ts)
+	}
+	ts, err := bc.GetBlockTime(0)
+	require.NoError(err)
+	require.Equal(cfg.Genesis.Timestamp, ts)
+	_, err = bc.GetBlockTime(tip + 1)
+	require.Equal(db.ErrNotExist, errors.Cause(err))
+}
+
+func TestBlockchain_GetBlocksByHeightRange(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	cfg := config.Default
+	registry := protocol.Registry{}
+	acc := account.NewProtocol()
+	require.NoError(registry.Register(account.ProtocolID, acc))
+	rp := rolldpos.NewProtocol(cfg.Genesis.NumCandidateDelegates, cfg.Genesis.NumDelegates, cfg.Genesis.NumSubEpochs)
+	require.NoError(registry.Register(rolldpos.ProtocolID, rp))
+	bc := NewBlockchain(cfg, InMemStateFactoryOption(), InMemDaoOption(), RegistryOption(&registry), EnableExperimentalActions())
+	v := vote.NewProtocol(bc)
+	require.NoError(registry.Register(vote.ProtocolID, v))
+	bc.GetFactory().AddActionHandlers(acc, v)
+	require.NoError(bc.Start(ctx))
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+	}()
+	require.NoError(addTestingTsfBlocks(bc))
+
+	tip := bc.TipHeight()
+	for _, r := range [][2]uint64{{1, tip}, {2, 3}, {tip, tip}} {
+		blks, err := bc.GetBlocksByHeightRange(r[0], r[1])
+		require.NoError(err)
+		require.Len(blks, int(r[1]-r[0]+1))
+		for i, blk := range blks {
+			expected, err := bc.GetBlockByHeight(r[0] + uint64(i))
+			require.NoError(err)
+			require.Equal(expected.HashBlock(), blk.HashBlock())
+		}
+	}
+	for _, r := range [][2]uint64{{0, tip}, {3, 2}, {1, tip + 1}} {
+		_, err := bc.GetBlocksByHeightRange(r[0], r[1])
+		require.Equal(db.ErrNotExist, errors.Cause(err))
+	}
+
+	for height := tip + 1; height <= MaxBlocksInRange+1; height++ {
+		blk, err := bc.MintNewBlock(map[string][]action.SealedEnvelope{}, time.Now())
+		require.NoError(err)
+		require.NoError(bc.CommitBlock(blk))
+	}
+	blks, err := bc.GetBlocksByHeightRange(2, MaxBlocksInRange+1)
+	require.NoError(err)
+	require.Len(blks, MaxBlocksInRange)
+	_, err = bc.GetBlocksByHeightRange(1, MaxBlocksInRange+1)
+	require.Error(err)
+}
+
+func TestBlockchain_GetTransfersAndVotesByAddress(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	cfg := config.Default
+	cfg.Plugins = map[int]interface{}{config.GatewayPlugin: true}
+	cfg.Chain.EnableAsyncIndexWrite = false
+	registry := protocol.Registry{}
+	acc := account.NewProtocol()
+	require.NoError(registry.Register(account.ProtocolID, acc))
+	rp := rolldpos.NewProtocol(cfg.Genesis.NumCandidateDelegates, cfg.Genesis.NumDelegates, cfg.Genesis.NumSubEpochs)
+	require.NoError(registry.Register(rolldpos.ProtocolID, rp))
+	bc := NewBlockchain(cfg, InMemStateFactoryOption(), InMemDaoOption(), RegistryOption(&registry), EnableExperimentalActions())
+	v := vote.NewProtocol(bc)
+	require.NoError(registry.Register(vote.ProtocolID, v))
+	bc.GetFactory().AddActionHandlers(acc, v)
+	require.NoError(bc.Start(ctx))
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+	}()
+	require.NoError(addTestingTsfBlocks(bc))
+
+	// the actions of each address found by scanning the blocks
+	transfers := make(map[string][]hash.Hash256)
+	votes := make(map[string][]hash.Hash256)
+	for height := uint64(1); height <= bc.TipHeight(); height++ {
+		blk, err := bc.GetBlockByHeight(height)
+		require.NoError(err)
+		for _, selp := range blk.Actions {
+			sender, err := addrutil.PubKeyToAddress(selp.SrcPubkey())
+			require.NoError(err)
+			switch act := selp.Action().(type) {
+			case *action.Transfer:
+				transfers[sender.String()] = append(transfers[sender.String()], selp.Hash())
+				if act.Recipient() != sender.String() {
+					transfers[act.Recipient()] = append(transfers[act.Recipient()], selp.Hash())
+				}
+			case *action.Vote:
+				votes[sender.String()] = append(votes[sender.String()], selp.Hash())
+				if act.Votee() != sender.String() {
+					votes[act.Votee()] = append(votes[act.Votee()], selp.Hash())
+				}
+			}
+		}
+	}
+	require.NotEmpty(transfers)
+	require.NotEmpty(votes)
+	for name, addr := range ta.Addrinfo {
+		tsfs, err := bc.GetTransfersByAddress(addr.String())
+		require.NoError(err)
+		var hashes []hash.Hash256
+		for _, tsf := range tsfs {
+			hashes = append(hashes, tsf.Hash())
+		}
+		require.ElementsMatch(transfers[addr.String()], hashes, name)
+		vts, err := bc.GetVotesByAddress(addr.String())
+		require.NoError(err)
+		hashes = nil
+		for _, vt := range vts {
+			hashes = append(hashes, vt.Hash())
+		}
+		require.ElementsMatch(votes[addr.String()], hashes, name)
+	}
+	_, err := bc.GetTransfersByAddress("invalid")
+	require.Error(err)
+
+	// the index is only written with the gateway plugin
+	delete(bc.(*blockchain).config.Plugins, config.GatewayPlugin)
+	_, err = bc.GetTransfersByAddress(ta.Addrinfo["charlie"].String())
+	require.Error(err)
+	_, err = bc.GetVotesByAddress(ta.Addrinfo["charlie"].String())
+	require.Error(err)
+}
+
+func TestBlockchain_GetBlockIntervalStats(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	cfg := config.Default
+	registry := protocol.Registry{}
+	acc := account.NewProtocol()
+	require.NoError(registry.Register(account.ProtocolID, acc))
+	rp := rolldpos.NewProtocol(cfg.Genesis.NumCandidateDelegates, cfg.Genesis.NumDelegates, cfg.Genesis.NumSubEpochs)
+	require.NoError(registry.Register(rolldpos.ProtocolID, rp))
+	bc := NewBlockchain(cfg, InMemStateFactoryOption(), InMemDaoOption(), RegistryOption(&registry), EnableExperimentalActions())
+	v := vote.NewProtocol(bc)
+	require.NoError(registry.Register(vote.ProtocolID, v))
+	bc.GetFactory().AddActionHandlers(acc, v)
+	require.NoError(bc.Start(ctx))
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+	}()
+
+	_, _, _, err := bc.GetBlockIntervalStats(10)
+	require.Equal(db.ErrNotExist, errors.Cause(err))
+
+	// the blocks are minted 500ms, 1s, 2s and 500ms apart
+	ts := time.Now().Add(-time.Hour)
+	for _, interval := range []time.Duration{0, 500 * time.Millisecond, time.Second, 2 * time.Second, 500 * time.Millisecond} {
+		ts = ts.Add(interval)
+		blk, err := bc.MintNewBlock(map[string][]action.SealedEnvelope{}, ts)
+		require.NoError(err)
+		require.NoError(bc.ValidateBlock(blk))
+		require.NoError(bc.CommitBlock(blk))
+	}
+
+	min, max, avg, err := bc.GetBlockIntervalStats(10)
+	require.NoError(err)
+	require.Equal(500*time.Millisecond, min)
+	require.Equal(2*time.Second, max)
+	require.Equal(time.Second, avg)
+	min, max, avg, err = bc.GetBlockIntervalStats(2)
+	require.NoError(err)
+	require.Equal(500*time.Millisecond, min)
+	require.Equal(2*time.Second, max)
+	require.Equal(1250*time.Millisecond, avg)
+	_, _, _, err = bc.GetBlockIntervalStats(0)
+	require.Error(err)
+}
+
+func TestBlockchain_VerifyChain(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	cfg := config.Default
+	registry := protocol.Registry{}
+	acc := account.NewProtocol()
+	require.NoError(registry.Register(account.ProtocolID, acc))
+	rp := rolldpos.NewProtocol(cfg.Genesis.NumCandidateDelegates, cfg.Genesis.NumDelegates, cfg.Genesis.NumSubEpochs)
+	require.NoError(registry.Register(rolldpos.ProtocolID, rp))
+	bc := NewBlockchain(cfg, InMemStateFactoryOption(), InMemDaoOption(), RegistryOption(&registry), EnableExperimentalActions())
+	v := vote.NewProtocol(bc)
+	require.NoError(registry.Register(vote.ProtocolID, v))
+	bc.GetFactory().AddActionHandlers(acc, v)
+	require.NoError(bc.Start(ctx))
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+	}()
+	require.NoError(addTestingTsfBlocks(bc))
+
+	tip := bc.TipHeight()
+	rootHash := bc.GetFactory().RootHash()
+	require.NoError(bc.VerifyChain(1, tip))
+	require.NoError(bc.VerifyChain(3, 4))
+	// the states of the chain are untouched
+	require.Equal(rootHash, bc.GetFactory().RootHash())
+	for _, r := range [][2]uint64{{0, tip}, {3, 2}, {1, tip + 1}} {
+		require.Equal(db.ErrNotExist, errors.Cause(bc.VerifyChain(r[0], r[1])))
+	}
+
+	// drop an action of the tip block in the DB, which breaks its action root
+	dao := bc.(*blockchain).dao
+	blk, err := bc.GetBlockByHeight(tip)
+	require.NoError(err)
+	require.True(len(blk.Actions) > 1)
+	blk.Actions = blk.Actions[:len(blk.Actions)-1]
+	require.NoError(dao.deleteTipBlock())
+	require.NoError(dao.putBlock(blk))
+
+	require.NoError(bc.VerifyChain(1, tip-1))
+	err = bc.VerifyChain(1, tip)
+	require.Equal(ErrInvalidBlock, errors.Cause(err))
+	require.Contains(err.Error(), fmt.Sprintf("block %d", tip))
+}
+
+func TestBlockchain_GetBalanceDelta(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	cfg := config.Default
+	cfg.Plugins = map[int]interface{}{config.GatewayPlugin: true}
+	cfg.Chain.EnableAsyncIndexWrite = false
+	registry := protocol.Registry{}
+	acc := account.NewProtocol()
+	require.NoError(registry.Register(account.ProtocolID, acc))
+	rp := rolldpos.NewProtocol(cfg.Genesis.NumCandidateDelegates, cfg.Genesis.NumDelegates, cfg.Genesis.NumSubEpochs)
+	require.NoError(registry.Register(rolldpos.ProtocolID, rp))
+	bc := NewBlockchain(cfg, InMemStateFactoryOption(), InMemDaoOption(), RegistryOption(&registry), EnableExperimentalActions())
+	v := vote.NewProtocol(bc)
+	require.NoError(registry.Register(vote.ProtocolID, v))
+	bc.GetFactory().AddActionHandlers(acc, v)
+	require.NoError(bc.Start(ctx))
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+	}()
+	require.NoError(addTestingTsfBlocks(bc))
+
+	tip := bc.TipHeight()
+	for name, addr := range ta.Addrinfo {
+		initBalance := big.NewInt(0)
+		if s, ok := cfg.Genesis.InitBalanceMap[addr.String()]; ok {
+			initBalance, ok = big.NewInt(0).SetString(s, 10)
+			require.True(ok)
+		}
+		balance, err := bc.Balance(addr.String())
+		require.NoError(err)
+		delta, err := bc.GetBalanceDelta(addr.String(), 0, tip)
+		require.NoError(err)
+		require.Equal(big.NewInt(0).Sub(balance, initBalance), delta, name)
+	}
+
+	// charlie receives 50 in block 2, sends 5 in block 3 and receives 2 in block 5
+	charlie := ta.Addrinfo["charlie"].String()
+	for r, expected := range map[[2]uint64]int64{{1, 2}: 50, {2, 3}: -5, {1, 3}: 45, {3, 4}: 0, {3, 5}: 2} {
+		delta, err := bc.GetBalanceDelta(charlie, r[0], r[1])
+		require.NoError(err)
+		require.Equal(big.NewInt(expected), delta, r)
+	}
+	for _, r := range [][2]uint64{{3, 2}, {1, tip + 1}} {
+		_, err := bc.GetBalanceDelta(charlie, r[0], r[1])
+		require.Equal(db.ErrNotExist, errors.Cause(err))
+	}
+	_, err := bc.GetBalanceDelta("invalid", 0, tip)
+	require.Error(err)
+}
+
+func TestBlockchain_ReconcileState(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	cfg := config.Default
+	cfg.Chain.MaxReconcileBlocks = 2
+	chainStore := db.NewMemKVStore()
+	trieStore := db.NewMemKVStore()
+	newChain := func() Blockchain {
+		sf, err := factory.NewFactory(cfg, factory.PrecreatedTrieDBOption(trieStore))
+		require.NoError(err)
+		registry := protocol.Registry{}
+		acc := account.NewProtocol()
+		require.NoError(registry.Register(account.ProtocolID, acc))
+		rp := rolldpos.NewProtocol(cfg.Genesis.NumCandidateDelegates, cfg.Genesis.NumDelegates, cfg.Genesis.NumSubEpochs)
+		require.NoError(registry.Register(rolldpos.ProtocolID, rp))
+		bc := NewBlockchain(
+			cfg,
+			PrecreatedStateFactoryOption(sf),
+			PrecreatedDaoOption(newBlockDAO(chainStore, false, false, 0)),
+			RegistryOption(&registry),
+			EnableExperimentalActions(),
+		)
+		v := vote.NewProtocol(bc)
+		require.NoError(registry.Register(vote.ProtocolID, v))
+		sf.AddActionHandlers(acc, v)
+		return bc
+	}
+	bc := newChain()
+	require.NoError(bc.Start(ctx))
+	require.NoError(addTestingTsfBlocks(bc))
+	require.NoError(bc.Stop(ctx))
+	tip := bc.TipHeight()
+	tipHash := bc.TipHash()
+
+	// commits the blocks to the chain DB only, as if the node stopped before committing their states
+	producer := ta.Addrinfo["producer"].String()
+	nonce, err := bc.Nonce(producer)
+	require.NoError(err)
+	putBlocks := func(amounts ...int64) []hash.Hash256 {
+		dao := newBlockDAO(chainStore, false, false, 0)
+		prevHash := tipHash
+		var hashes []hash.Hash256
+		for i, amount := range amounts {
+			tsf, err := testutil.SignedTransfer(ta.Addrinfo["alfa"].String(), ta.Keyinfo["producer"].PriKey,
+				nonce+uint64(i)+1, big.NewInt(amount), nil, testutil.TestGasLimit, big.NewInt(0))
+			require.NoError(err)
+			blk, err := block.NewTestingBuilder().
+				SetHeight(tip+uint64(i)+1).
+				SetPrevBlockHash(prevHash).
+				SetTimeStamp(testutil.TimestampNow()).
+				AddActions(tsf).
+				SignAndBuild(ta.Keyinfo["producer"].PubKey, ta.Keyinfo["producer"].PriKey)
+			require.NoError(err)
+			require.NoError(dao.putBlock(&blk))
+			prevHash = blk.HashBlock()
+			hashes = append(hashes, prevHash)
+		}
+		return hashes
+	}
+	balance, err := bc.Balance(ta.Addrinfo["alfa"].String())
+	require.NoError(err)
+
+	// the chain DB over the limit ahead of the state DB
+	putBlocks(1, 1, 1)
+	bc = newChain()
+	err = bc.Start(ctx)
+	require.Equal(ErrInconsistentDB, errors.Cause(err))
+	require.Contains(err.Error(), fmt.Sprintf("chain tip %d is 3 blocks ahead of state height %d", tip+3, tip))
+
+	// the chain DB ahead of the state DB within the limit is replayed
+	require.NoError(newBlockDAO(chainStore, false, false, 0).deleteTipBlock())
+	bc = newChain()
+	require.NoError(bc.Start(ctx))
+	require.Equal(tip+2, bc.TipHeight())
+	stateHeight, err := bc.GetFactory().Height()
+	require.NoError(err)
+	require.Equal(tip+2, stateHeight)
+	newBalance, err := bc.Balance(ta.Addrinfo["alfa"].String())
+	require.NoError(err)
+	require.Equal(big.NewInt(0).Add(balance, big.NewInt(2)), newBalance)
+	require.NoError(bc.Stop(ctx))
+
+	// the block which cannot be read isn't rolled back, and the node refuses to start
+	tip += 2
+	tipHash = bc.TipHash()
+	nonce += 2
+	hashes := putBlocks(1, math.MaxInt64)
+	body, err := chainStore.Get(blockBodyNS, hashes[0][:])
+	require.NoError(err)
+	require.NoError(chainStore.Delete(blockBodyNS, hashes[0][:]))
+	bc = newChain()
+	err = bc.Start(ctx)
+	require.Error(err)
+	require.NotEqual(ErrInvalidBlock, errors.Cause(err))
+	require.NotEqual(ErrInconsistentDB, errors.Cause(err))
+	height, err := newBlockDAO(chainStore, false, false, 0).getBlockchainHeight()
+	require.NoError(err)
+	require.Equal(tip+2, height)
+	require.NoError(chainStore.Put(blockBodyNS, hashes[0][:], body))
+
+	// the block failing to replay is rolled back along with the blocks above it
+	bc = newChain()
+	require.NoError(bc.Start(ctx))
+	require.Equal(tip+1, bc.TipHeight())
+	blk, err := bc.GetBlockByHeight(tip + 1)
+	require.NoError(err)
+	require.Equal(blk.HashBlock(), bc.TipHash())
+	_, err = bc.GetBlockByHeight(tip + 2)
+	require.Error(err)
+	require.NoError(bc.Stop(ctx))
+
+	// the state DB ahead of the chain DB
+	require.NoError(newBlockDAO(chainStore, false, false, 0).deleteTipBlock())
+	bc = newChain()
+	err = bc.Start(ctx)
+	require.Equal(ErrInconsistentDB, errors.Cause(err))
+	require.Contains(err.Error(), fmt.Sprintf("state height %d is ahead of chain tip %d", tip+1, tip))
+}
+
+func TestBlockchain_CheckpointSync(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "checkpoint")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	newConfig := func(name string) config.Config {
+		cfg := config.Default
+		cfg.Chain.ChainDBPath = filepath.Join(dir, name+".chain.db")
+		cfg.Chain.TrieDBPath = filepath.Join(dir, name+".trie.db")
+		// the state of the snapshot is verified against the root of the state trie
+		cfg.Chain.EnableTrielessStateDB = false
+		return cfg
+	}
+	newChain := func(cfg config.Config) Blockchain {
+		registry := protocol.Registry{}
+		acc := account.NewProtocol()
+		require.NoError(registry.Register(account.ProtocolID, acc))
+		rp := rolldpos.NewProtocol(cfg.Genesis.NumCandidateDelegates, cfg.Genesis.NumDelegates, cfg.Genesis.NumSubEpochs)
+		require.NoError(registry.Register(rolldpos.ProtocolID, rp))
+		bc := NewBlockchain(
+			cfg,
+			DefaultStateFactoryOption(),
+			BoltDBDaoOption(),
+			RegistryOption(&registry),
+			EnableExperimentalActions(),
+		)
+		v := vote.NewProtocol(bc)
+		require.NoError(registry.Register(vote.ProtocolID, v))
+		bc.GetFactory().AddActionHandlers(acc, v)
+		return bc
+	}
+	mint := func(bc Blockchain, n int) []*block.Block {
+		var blks []*block.Block
+		for i := 0; i < n; i++ {
+			blk, err := bc.MintNewBlock(nil, testutil.TimestampNow())
+			require.NoError(err)
+			require.NoError(bc.ValidateBlock(blk))
+			require.NoError(bc.CommitBlock(blk))
+			blks = append(blks, blk)
+		}
+		return blks
+	}
+
+	// the source chain is exported at height 52, in the second epoch of 48 blocks, where the block is endorsed by the
+	// delegates
+	delegates := []*testutil.KeyPair{
+		testutil.NewKeyPair("delegate 0"),
+		testutil.NewKeyPair("delegate 1"),
+		testutil.NewKeyPair("delegate 2"),
+	}
+	srcCfg := newConfig("source")
+	src := newChain(srcCfg)
+	require.NoError(src.Start(ctx))
+	require.NoError(addTestingTsfBlocks(src))
+	mint(src, 51-int(src.TipHeight()))
+	blk, err := src.MintNewBlock(nil, testutil.TimestampNow())
+	require.NoError(err)
+	var endorsements []*endorsement.Endorsement
+	for _, d := range delegates {
+		en, err := endorsement.Endorse(d.PriKey, commitVote(blk.HashBlock()), blk.Timestamp())
+		require.NoError(err)
+		endorsements = append(endorsements, en)
+	}
+	require.NoError(blk.Finalize(endorsements, blk.Timestamp()))
+	require.NoError(src.ValidateBlock(blk))
+	require.NoError(src.CommitBlock(blk))
+	require.NoError(src.Stop(ctx))
+	var snapshot bytes.Buffer
+	checkpoint, err := ExportCheckpoint(srcCfg, &snapshot)
+	require.NoError(err)
+	require.Equal(uint64(52), checkpoint.Height)
+	require.NoError(src.Start(ctx))
+	require.Equal(src.TipHash(), checkpoint.Hash)
+	require.Equal(src.GetFactory().RootHash(), checkpoint.StateRoot)
+	blks := mint(src, 3)
+	defer func() {
+		require.NoError(src.Stop(ctx))
+	}()
+
+	// the snapshot is refused without the checkpoint pinned, or with another one pinned
+	cfg := newConfig("synced")
+	cfg.Chain.CheckpointStateRoots = map[uint64]string{52: hex.EncodeToString(checkpoint.StateRoot[:])}
+	cfg.Chain.CheckpointDelegates = map[uint64][]string{52: {}}
+	for _, d := range delegates {
+		cfg.Chain.CheckpointDelegates[52] = append(cfg.Chain.CheckpointDelegates[52], d.Address.String())
+	}
+	_, err = ImportCheckpoint(cfg, bytes.NewReader(snapshot.Bytes()))
+	require.Equal(ErrCheckpointMismatch, errors.Cause(err))
+	other := blks[0].HashBlock()
+	cfg.Chain.Checkpoints = map[uint64]string{52: hex.EncodeToString(other[:])}
+	_, err = ImportCheckpoint(cfg, bytes.NewReader(snapshot.Bytes()))
+	require.Equal(ErrCheckpointMismatch, errors.Cause(err))
+	_, err = os.Stat(cfg.Chain.TrieDBPath)
+	require.True(os.IsNotExist(err))
+
+	// nor is it taken by the trieless state DB, which cannot be verified
+	cfg.Chain.Checkpoints = map[uint64]string{52: hex.EncodeToString(checkpoint.Hash[:])}
+	trieless := cfg
+	trieless.Chain.EnableTrielessStateDB = true
+	_, err = ImportCheckpoint(trieless, bytes.NewReader(snapshot.Bytes()))
+	require.Equal(errTrielessStateDB, err)
+
+	height, err := ImportCheckpoint(cfg, bytes.NewReader(snapshot.Bytes()))
+	require.NoError(err)
+	require.Equal(uint64(52), height)
+	_, err = ImportCheckpoint(cfg, bytes.NewReader(snapshot.Bytes()))
+	require.Error(err)
+
+	// the node synced from the checkpoint holds the blocks of the epoch, and takes the blocks above
+	bc := newChain(cfg)
+	require.NoError(bc.Start(ctx))
+	defer func() {
+		require.NoError(bc.Stop(ctx))
+	}()
+	require.Equal(uint64(52), bc.TipHeight())
+	require.Equal(checkpoint.Hash, bc.TipHash())
+	_, err = bc.GetBlockByHeight(49)
+	require.NoError(err)
+	_, err = bc.GetBlockByHeight(48)
+	require.Equal(ErrBlockNotAvailable, errors.Cause(err))
+	_, err = bc.BlockHeaderByHeight(1)
+	require.Equal(ErrBlockNotAvailable, errors.Cause(err))
+	for _, blk := range blks {
+		require.NoError(bc.ValidateBlock(blk))
+		require.NoError(bc.CommitBlock(blk))
+	}
+	require.Equal(src.TipHash(), bc.TipHash())
+	require.Equal(src.GetFactory().RootHash(), bc.GetFactory().RootHash())
+	for _, addr := range []string{ta.Addrinfo["producer"].String(), ta.Addrinfo["alfa"].String()} {
+		balance, err := src.Balance(addr)
+		require.NoError(err)
+		synced, err := bc.Balance(addr)
+		require.NoError(err)
+		require.Equal(balance, synced)
+	}
+}
+
+func TestReadSnapshotBlocks(t *testing.T) {
+	require := require.New(t)
+
+	// the counts and the sizes in a malformed snapshot are refused before taking up the memory
+	var buf bytes.Buffer
+	require.NoError(writeSnapshotSize(&buf, 1<<31))
+	_, err := readSnapshotBlocks(&buf, 360)
+	require.Error(err)
+	require.True(strings.Contains(err.Error(), "exceed 360"))
+
+	buf.Reset()
+	require.NoError(writeSnapshotSize(&buf, 1))
+	require.NoError(writeSnapshotSize(&buf, 1<<31))
+	_, err = readSnapshotBlocks(&buf, 360)
+	require.Error(err)
+	require.True(strings.Contains(err.Error(), "exceeds 32MB"))
+}
+
+func TestVerifyEndorsements(t *testing.T) {
+	require := require.New(t)
+
+	blk, err := block.NewTestingBuilder().
+		SetHeight(80).
+		SetPrevBlockHash(hash.ZeroHash256).
+		SetTimeStamp(testutil.TimestampNow()).
+		SignAndBuild(ta.Keyinfo["producer"].PubKey, ta.Keyinfo["producer"].PriKey)
+	require.NoError(err)
+	delegates := make([]*testutil.KeyPair, 4)
+	addrs := make([]string, 0, len(delegates))
+	for i := range delegates {
+		delegates[i] = testutil.NewKeyPair(fmt.Sprintf("delegate %d", i))
+		addrs = append(addrs, delegates[i].Address.String())
+	}
+	endorse := func(signers ...*testutil.KeyPair) *block.Block {
+		var endorsements []*endorsement.Endorsement
+		for _, s := range signers {
+			en, err := endorsement.Endorse(s.PriKey, commitVote(blk.HashBlock()), blk.Timestamp())
+			require.NoError(err)
+			endorsements = append(endorsements, en)
+		}
+		endorsed := blk
+		require.NoError(endorsed.Finalize(endorsements, blk.Timestamp()))
+		return &endorsed
+	}
+
+	require.NoError(verifyEndorsements(endorse(delegates[0], delegates[1], delegates[2]), addrs))
+	// the endorsements by the same delegate are counted once, and the ones by the others aren't counted
+	outsider := testutil.NewKeyPair("outsider")
+	err = verifyEndorsements(endorse(delegates[0], delegates[1], delegates[1], outsider), addrs)
+	require.Equal(ErrCheckpointMismatch, errors.Cause(err))
+	require.True(strings.Contains(err.Error(), "endorsed by 2 of the 4 delegates"))
+	require.NoError(verifyEndorsements(endorse(delegates[0], delegates[1], outsider), addrs[:2]))
+}
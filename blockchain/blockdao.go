@@ -43,12 +43,15 @@ const (
 )
 
 var (
-	topHeightKey     = []byte("th")
-	totalActionsKey  = []byte("ta")
-	hashPrefix       = []byte("ha.")
-	heightPrefix     = []byte("he.")
-	actionFromPrefix = []byte("fr.")
-	actionToPrefix   = []byte("to.")
+	topHeightKey      = []byte("th")
+	totalActionsKey   = []byte("ta")
+	rebuildIndexesKey = []byte("ri")
+	indexedHeightKey  = []byte("ih")
+	lowestHeightKey   = []byte("lh")
+	hashPrefix        = []byte("ha.")
+	heightPrefix      = []byte("he.")
+	actionFromPrefix  = []byte("fr.")
+	actionToPrefix    = []byte("to.")
 )
 
 var (
@@ -70,6 +73,9 @@ type blockDAO struct {
 	headerCache   *cache.ThreadSafeLruCache
 	bodyCache     *cache.ThreadSafeLruCache
 	footerCache   *cache.ThreadSafeLruCache
+	// lowestHeight is the height of the lowest block held on a node synced from a checkpoint, and 0 on a node
+	// holding the blocks from genesis
+	lowestHeight uint64
 }
 
 // newBlockDAO instantiates a block DAO
@@ -121,6 +127,13 @@ func (dao *blockDAO) Start(ctx context.Context) error {
 		}
 	}
 
+	value, err := dao.kvstore.Get(blockNS, lowestHeightKey)
+	switch {
+	case err == nil:
+		dao.lowestHeight = enc.MachineEndian.Uint64(value)
+	case errors.Cause(err) != db.ErrNotExist:
+		return errors.Wrap(err, "failed to get the lowest height")
+	}
 	return nil
 }
 
@@ -132,6 +145,14 @@ func (dao *blockDAO) getBlockHash(height uint64) (hash.Hash256, error) {
 	if height == 0 {
 		return hash.ZeroHash256, nil
 	}
+	if height < dao.lowestHeight {
+		return hash.ZeroHash256, errors.Wrapf(
+			ErrBlockNotAvailable,
+			"block %d is below the lowest block %d held",
+			height,
+			dao.lowestHeight,
+		)
+	}
 	key := append(heightPrefix, byteutil.Uint64ToBytes(height)...)
 	value, err := dao.kvstore.Get(blockHashHeightMappingNS, key)
 	hash := hash.ZeroHash256
@@ -233,6 +254,54 @@ func (dao *blockDAO) body(h hash.Hash256) (*block.Body, error) {
 		}
 		cacheMtc.WithLabelValues("miss_body").Inc()
 	}
+	value, err := dao.rawBody(h)
+	if err != nil {
+		return nil, err
+	}
+	body := &block.Body{}
+	if err := body.Deserialize(value); err != nil {
+		return nil, errors.Wrapf(err, "failed to deserialize block body %x", h)
+	}
+	if dao.bodyCache != nil {
+		dao.bodyCache.Add(h, body)
+	}
+	return body, nil
+}
+
+// bodyActions returns the actions in a block body whose core matches the filter. Only the matching actions are
+// deserialized from the stored body, while a cached body is returned as a whole.
+func (dao *blockDAO) bodyActions(h hash.Hash256, match func(*iotextypes.ActionCore) bool) ([]action.SealedEnvelope, error) {
+	if dao.bodyCache != nil {
+		body, ok := dao.bodyCache.Get(h)
+		if ok {
+			cacheMtc.WithLabelValues("hit_body").Inc()
+			return body.(*block.Body).Actions, nil
+		}
+		cacheMtc.WithLabelValues("miss_body").Inc()
+	}
+	value, err := dao.rawBody(h)
+	if err != nil {
+		return nil, err
+	}
+	pb := iotextypes.BlockBody{}
+	if err := proto.Unmarshal(value, &pb); err != nil {
+		return nil, errors.Wrapf(err, "failed to deserialize block body %x", h)
+	}
+	var selps []action.SealedEnvelope
+	for _, actPb := range pb.Actions {
+		if !match(actPb.GetCore()) {
+			continue
+		}
+		selp := action.SealedEnvelope{}
+		if err := selp.LoadProto(actPb); err != nil {
+			return nil, errors.Wrapf(err, "failed to deserialize action in block body %x", h)
+		}
+		selps = append(selps, selp)
+	}
+	return selps, nil
+}
+
+func (dao *blockDAO) rawBody(h hash.Hash256) ([]byte, error) {
 	value, err := dao.kvstore.Get(blockBodyNS, h[:])
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get block body %x", h)
@@ -248,14 +317,7 @@ func (dao *blockDAO) body(h hash.Hash256) (*block.Body, error) {
 	if len(value) == 0 {
 		return nil, errors.Wrapf(db.ErrNotExist, "block body %x is missing", h)
 	}
-	body := &block.Body{}
-	if err := body.Deserialize(value); err != nil {
-		return nil, errors.Wrapf(err, "failed to deserialize block body %x", h)
-	}
-	if dao.bodyCache != nil {
-		dao.bodyCache.Add(h, body)
-	}
-	return body, nil
+	return value, nil
 }
 
 // Footer returns a block footer
@@ -327,23 +389,37 @@ func (dao *blockDAO) getReceiptByActionHash(h hash.Hash256) (*action.Receipt, er
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get receipt index for action %x", h)
 	}
-	receiptsBytes, err := dao.kvstore.Get(receiptsNS, heightBytes)
+	receipts, err := dao.getReceipts(enc.MachineEndian.Uint64(heightBytes))
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range receipts {
+		if r.ActionHash == h {
+			return r, nil
+		}
+	}
+	return nil, errors.Errorf("receipt of action %x isn't found", h)
+}
+
+// getReceipts returns the receipts of the block of the given height
+func (dao *blockDAO) getReceipts(height uint64) ([]*action.Receipt, error) {
+	var heightBytes [8]byte
+	enc.MachineEndian.PutUint64(heightBytes[:], height)
+	receiptsBytes, err := dao.kvstore.Get(receiptsNS, heightBytes[:])
 	if err != nil {
-		height := enc.MachineEndian.Uint64(heightBytes)
 		return nil, errors.Wrapf(err, "failed to get receipts of block %d", height)
 	}
 	receipts := iotextypes.Receipts{}
 	if err := proto.Unmarshal(receiptsBytes, &receipts); err != nil {
 		return nil, err
 	}
+	res := make([]*action.Receipt, 0, len(receipts.Receipts))
 	for _, receipt := range receipts.Receipts {
 		r := action.Receipt{}
 		r.ConvertFromReceiptPb(receipt)
-		if r.ActionHash == h {
-			return &r, nil
-		}
+		res = append(res, &r)
 	}
-	return nil, errors.Errorf("receipt of action %x isn't found", h)
+	return res, nil
 }
 
 // putBlock puts a block
@@ -494,6 +570,7 @@ func (dao *blockDAO) deleteTipBlock() error {
 	if !dao.writeIndex {
 		return dao.kvstore.Commit(batch)
 	}
+	batch.Put(blockNS, indexedHeightKey, topHeightValue, "failed to put indexed height")
 
 	// update total action count
 	value, err := dao.kvstore.Get(blockNS, totalActionsKey)
@@ -29,7 +29,9 @@ import (
 	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/unit"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/pkg/util/fileutil"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
 	"github.com/iotexproject/iotex-core/test/identityset"
 	"github.com/iotexproject/iotex-core/test/testaddress"
 	"github.com/iotexproject/iotex-core/testutil"
@@ -170,6 +172,14 @@ func TestBlockDAO(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equal(t, uint64(1), height)
 
+		// test getting only the votes of a block
+		votes, err := dao.bodyActions(blks[0].HashBlock(), func(core *iotextypes.ActionCore) bool {
+			return core.GetVote() != nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(votes))
+		assert.Equal(t, blks[0].Actions[1].Hash(), votes[0].Hash())
+
 		err = dao.putBlock(blks[2])
 		assert.Nil(t, err)
 		blk, err = dao.getBlock(blks[2].HashBlock())
@@ -399,6 +409,165 @@ func TestBlockDao_putReceipts(t *testing.T) {
 	}
 }
 
+func TestBlockDAO_RebuildIndexes(t *testing.T) {
+	require := require.New(t)
+
+	var blks []*block.Block
+	for i := uint64(1); i <= 3; i++ {
+		tsf, err := testutil.SignedTransfer(testaddress.Addrinfo["bravo"].String(), testaddress.Keyinfo["alfa"].PriKey, i,
+			big.NewInt(1), nil, genesis.Default.ActionGasLimit, big.NewInt(0))
+		require.NoError(err)
+		blk, err := block.NewTestingBuilder().
+			SetHeight(i).
+			SetTimeStamp(testutil.TimestampNow()).
+			AddActions(tsf).
+			SetReceipts([]*action.Receipt{{BlockHeight: i, ActionHash: tsf.Hash(), Status: 1}}).
+			SignAndBuild(testaddress.Keyinfo["producer"].PubKey, testaddress.Keyinfo["producer"].PriKey)
+		require.NoError(err)
+		blks = append(blks, &blk)
+	}
+	alfa := hash.BytesToHash160(testaddress.Addrinfo["alfa"].Bytes())
+	bravo := hash.BytesToHash160(testaddress.Addrinfo["bravo"].Bytes())
+	checkIndexes := func(dao *blockDAO) {
+		total, err := dao.getTotalActions()
+		require.NoError(err)
+		require.Equal(uint64(3), total)
+		sent, err := getActionsBySenderAddress(dao.kvstore, alfa)
+		require.NoError(err)
+		received, err := getActionsByRecipientAddress(dao.kvstore, bravo)
+		require.NoError(err)
+		for i, blk := range blks {
+			actHash := blk.Actions[0].Hash()
+			require.Equal(actHash, sent[i])
+			require.Equal(actHash, received[i])
+			blkHash, err := getBlockHashByActionHash(dao.kvstore, actHash)
+			require.NoError(err)
+			require.Equal(blk.HashBlock(), blkHash)
+			r, err := dao.getReceiptByActionHash(actHash)
+			require.NoError(err)
+			require.Equal(blk.Height(), r.BlockHeight)
+		}
+		progress, err := dao.rebuildProgress()
+		require.NoError(err)
+		require.Nil(progress)
+	}
+
+	ctx := context.Background()
+	store := db.NewMemKVStore()
+	dao := newBlockDAO(store, true, false, 0)
+	require.NoError(dao.Start(ctx))
+	for _, blk := range blks {
+		require.NoError(dao.putBlock(blk))
+		require.NoError(dao.putReceipts(blk.Height(), blk.Receipts))
+	}
+	checkIndexes(dao)
+
+	// corrupt the indexes
+	require.NoError(store.Put(blockNS, totalActionsKey, byteutil.Uint64ToBytes(100)))
+	require.NoError(store.Put(blockAddressActionCountMappingNS, append(actionFromPrefix, alfa[:]...),
+		byteutil.Uint64ToBytes(5)))
+	actHash := blks[1].Actions[0].Hash()
+	require.NoError(store.Delete(blockActionBlockMappingNS, actHash[hashOffset:]))
+	require.NoError(dao.rebuildIndexes(3))
+	checkIndexes(dao)
+
+	// interrupt the rebuild in each phase, and resume it
+	for commits := 1; commits < 6; commits++ {
+		require.NoError(store.Put(blockNS, totalActionsKey, byteutil.Uint64ToBytes(100)))
+		failing := newBlockDAO(&failingCommitKVStore{KVStore: store, commits: commits}, true, false, 0)
+		require.Error(failing.rebuildIndexes(3))
+		progress, err := dao.rebuildProgress()
+		require.NoError(err)
+		require.NotNil(progress)
+		require.NoError(dao.rebuildIndexes(3))
+		checkIndexes(dao)
+	}
+}
+
+func TestBlockDAO_ReconcileIndexes(t *testing.T) {
+	require := require.New(t)
+
+	var blks []*block.Block
+	for i := uint64(1); i <= 3; i++ {
+		tsf, err := testutil.SignedTransfer(testaddress.Addrinfo["bravo"].String(), testaddress.Keyinfo["alfa"].PriKey, i,
+			big.NewInt(1), nil, genesis.Default.ActionGasLimit, big.NewInt(0))
+		require.NoError(err)
+		blk, err := block.NewTestingBuilder().
+			SetHeight(i).
+			SetTimeStamp(testutil.TimestampNow()).
+			AddActions(tsf).
+			SetReceipts([]*action.Receipt{{BlockHeight: i, ActionHash: tsf.Hash(), Status: 1}}).
+			SignAndBuild(testaddress.Keyinfo["producer"].PubKey, testaddress.Keyinfo["producer"].PriKey)
+		require.NoError(err)
+		blks = append(blks, &blk)
+	}
+	alfa := hash.BytesToHash160(testaddress.Addrinfo["alfa"].Bytes())
+	checkIndexes := func(dao *blockDAO, height uint64) {
+		indexedHeight, ok, err := dao.indexedHeight()
+		require.NoError(err)
+		require.True(ok)
+		require.Equal(height, indexedHeight)
+		total, err := dao.getTotalActions()
+		require.NoError(err)
+		require.Equal(height, total)
+		sent, err := getActionsBySenderAddress(dao.kvstore, alfa)
+		require.NoError(err)
+		require.Len(sent, int(height))
+		for i, blk := range blks[:height] {
+			actHash := blk.Actions[0].Hash()
+			require.Equal(actHash, sent[i])
+			r, err := dao.getReceiptByActionHash(actHash)
+			require.NoError(err)
+			require.Equal(blk.Height(), r.BlockHeight)
+		}
+	}
+
+	ctx := context.Background()
+	store := db.NewMemKVStore()
+	dao := newBlockDAO(store, true, false, 0)
+	require.NoError(dao.Start(ctx))
+	_, ok, err := dao.indexedHeight()
+	require.NoError(err)
+	require.False(ok)
+	require.NoError(dao.putBlock(blks[0]))
+	require.NoError(dao.putReceipts(1, blks[0].Receipts))
+	checkIndexes(dao, 1)
+
+	// the index written asynchronously is behind the chain tip
+	async := newBlockDAO(store, false, false, 0)
+	for _, blk := range blks[1:] {
+		require.NoError(async.putBlock(blk))
+		require.NoError(async.putReceipts(blk.Height(), blk.Receipts))
+	}
+	require.NoError(dao.reconcileIndexes(3))
+	checkIndexes(dao, 3)
+
+	// the chain rolled back without the index written along is behind the index
+	require.NoError(async.deleteTipBlock())
+	require.NoError(dao.reconcileIndexes(2))
+	checkIndexes(dao, 2)
+
+	// the index written along with rolling the chain back stays in line
+	require.NoError(dao.deleteTipBlock())
+	checkIndexes(dao, 1)
+	require.NoError(dao.reconcileIndexes(1))
+	checkIndexes(dao, 1)
+}
+
+// failingCommitKVStore fails the commits after the given number of commits
+type failingCommitKVStore struct {
+	db.KVStore
+	commits int
+}
+
+func (s *failingCommitKVStore) Commit(batch db.KVStoreBatch) error {
+	if s.commits == 0 {
+		return errors.New("failed to commit")
+	}
+	s.commits--
+	return s.KVStore.Commit(batch)
+}
+
 func BenchmarkBlockCache(b *testing.B) {
 	test := func(cacheSize int, b *testing.B) {
 		b.StopTimer()
@@ -14,13 +14,14 @@ import (
 
 	"github.com/pkg/errors"
 
-	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/action/protocol/keyrotation"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
 	"github.com/iotexproject/iotex-core/state/factory"
 )
 
@@ -39,6 +40,8 @@ type validator struct {
 	actionEnvelopeValidators  []protocol.ActionEnvelopeValidator
 	actionValidators          []protocol.ActionValidator
 	enableExperimentalActions bool
+	// strictNonceOrder requires the nonces of a sender to increase in the order of the actions in the block
+	strictNonceOrder bool
 }
 
 var (
@@ -46,6 +49,8 @@ var (
 	ErrInvalidTipHeight = errors.New("invalid tip height")
 	// ErrInvalidBlock is the error returned when the block is not valid
 	ErrInvalidBlock = errors.New("failed to validate the block")
+	// ErrFutureBlock is the error returned when the block timestamp is too far in the future
+	ErrFutureBlock = errors.New("block timestamp is too far in the future")
 	// ErrActionNonce is the error when the nonce of the action is wrong
 	ErrActionNonce = errors.New("invalid action nonce")
 	// ErrGasHigherThanLimit indicates the error of gas value
@@ -66,6 +71,9 @@ func (v *validator) Validate(blk *block.Block, tipHeight uint64, tipHash hash.Ha
 	}
 
 	if v.sf != nil {
+		if _, err := keyrotation.DelegateOf(v.sf, blk.ProducerAddress(), blk.Height()); err != nil {
+			return errors.Wrap(err, "failed to verify block's producer key")
+		}
 		return v.validateActionsOnly(
 			blk.Actions,
 			blk.PublicKey(),
@@ -122,18 +130,32 @@ func (v *validator) validateActionsOnly(
 			return errors.Wrapf(err, "failed to get the confirmed nonce of address %s", srcAddr)
 		}
 		receivedNonces := receivedNonces
-		sort.Slice(receivedNonces, func(i, j int) bool { return receivedNonces[i] < receivedNonces[j] })
+		if !v.strictNonceOrder {
+			sort.Slice(receivedNonces, func(i, j int) bool { return receivedNonces[i] < receivedNonces[j] })
+		}
 		for i, nonce := range receivedNonces {
-			if nonce != confirmedNonce+uint64(i+1) {
+			if nonce == confirmedNonce+uint64(i+1) {
+				continue
+			}
+			if v.strictNonceOrder && i > 0 {
 				return errors.Wrapf(
 					action.ErrNonce,
-					"the %d nonce %d of address %s (confirmed nonce %d) is not continuously increasing",
-					i,
+					"nonce %d of address %s follows nonce %d in the block (confirmed nonce %d), "+
+						"while the nonces should increase by 1 in block order",
 					nonce,
 					srcAddr,
+					receivedNonces[i-1],
 					confirmedNonce,
 				)
 			}
+			return errors.Wrapf(
+				action.ErrNonce,
+				"the %d nonce %d of address %s (confirmed nonce %d) is not continuously increasing",
+				i,
+				nonce,
+				srcAddr,
+				confirmedNonce,
+			)
 		}
 	}
 	return nil
@@ -146,7 +168,7 @@ func (v *validator) validateActions(
 	accountNonceMap map[string][]uint64,
 	errChan chan error,
 ) error {
-	producerAddr, err := address.FromBytes(pk.Hash())
+	producerAddr, err := addrutil.PubKeyToAddress(pk)
 	if err != nil {
 		return err
 	}
@@ -156,7 +178,7 @@ func (v *validator) validateActions(
 		if !v.enableExperimentalActions && action.IsExperimentalAction(selp.Action()) {
 			return errors.New("Enable to process experimental action")
 		}
-		caller, err := address.FromBytes(selp.SrcPubkey().Hash())
+		caller, err := addrutil.PubKeyToAddress(selp.SrcPubkey())
 		if err != nil {
 			return err
 		}
@@ -211,7 +233,7 @@ func verifyHeightAndHash(blk *block.Block, tipHeight uint64, tipHash hash.Hash25
 	}
 	// verify new block has correctly linked to current tip
 	if blk.PrevHash() != tipHash {
-		blk.HeaderLogger(log.L()).Error("Previous block hash doesn't match.",
+		blk.HeaderLogger(log.Logger("blockchain")).Error("Previous block hash doesn't match.",
 			log.Hex("expectedBlockHash", tipHash[:]))
 		return errors.Wrapf(
 			ErrInvalidBlock,
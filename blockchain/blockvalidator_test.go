@@ -8,6 +8,7 @@ package blockchain
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
 	"math/big"
 	"os"
@@ -26,6 +27,7 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
 	"github.com/iotexproject/iotex-core/state/factory"
 	ta "github.com/iotexproject/iotex-core/test/testaddress"
 	"github.com/iotexproject/iotex-core/testutil"
@@ -230,6 +232,30 @@ func TestWrongNonce(t *testing.T) {
 	err = val.Validate(&blk, 2, blkhash)
 	require.Error(err)
 	require.Equal(action.ErrNonce, errors.Cause(err))
+
+	// out of order nonces, which are only valid without the strict nonce order
+	tsf7, err := testutil.SignedTransfer(ta.Addrinfo["bravo"].String(), ta.Keyinfo["producer"].PriKey, 3, big.NewInt(30), []byte{}, 100000, big.NewInt(10))
+	require.NoError(err)
+	blk, err = block.NewTestingBuilder().
+		SetHeight(3).
+		SetPrevBlockHash(blkhash).
+		SetTimeStamp(testutil.TimestampNow()).
+		AddActions(tsf5, tsf6, tsf7).
+		SignAndBuild(ta.Keyinfo["producer"].PubKey, ta.Keyinfo["producer"].PriKey)
+	require.NoError(err)
+	require.NoError(val.Validate(&blk, 2, blkhash))
+	val.strictNonceOrder = true
+	err = val.Validate(&blk, 2, blkhash)
+	require.Equal(action.ErrNonce, errors.Cause(err))
+	require.Contains(err.Error(), fmt.Sprintf("nonce 4 of address %s follows nonce 2", ta.Addrinfo["producer"].String()))
+	blk, err = block.NewTestingBuilder().
+		SetHeight(3).
+		SetPrevBlockHash(blkhash).
+		SetTimeStamp(testutil.TimestampNow()).
+		AddActions(tsf5, tsf7, tsf6).
+		SignAndBuild(ta.Keyinfo["producer"].PubKey, ta.Keyinfo["producer"].PriKey)
+	require.NoError(err)
+	require.NoError(val.Validate(&blk, 2, blkhash))
 }
 
 func TestWrongAddress(t *testing.T) {
@@ -249,8 +275,10 @@ func TestWrongAddress(t *testing.T) {
 		execution.NewProtocol(bc))
 
 	invalidRecipient := "io1qyqsyqcyq5narhapakcsrhksfajfcpl24us3xp38zwvsep"
-	tsf, err := action.NewTransfer(1, big.NewInt(1), invalidRecipient, []byte{}, uint64(100000), big.NewInt(10))
-	require.NoError(t, err)
+	_, err := action.NewTransfer(1, big.NewInt(1), invalidRecipient, []byte{}, uint64(100000), big.NewInt(10))
+	require.Error(t, err)
+	tsf := &action.Transfer{}
+	require.NoError(t, tsf.LoadProto(&iotextypes.Transfer{Amount: "1", Recipient: invalidRecipient}))
 	bd := &action.EnvelopeBuilder{}
 	elp := bd.SetAction(tsf).SetGasLimit(100000).
 		SetGasPrice(big.NewInt(10)).
@@ -0,0 +1,57 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"encoding/hex"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// ErrCheckpointMismatch is the error returned when a block at a checkpoint height isn't the one pinned
+var ErrCheckpointMismatch = errors.New("block doesn't match the checkpoint")
+
+// checkpoints are the hashes of the known-good blocks pinned by height
+type checkpoints map[uint64]hash.Hash256
+
+// newCheckpoints decodes the checkpoints in config, skipping the invalid ones, which are refused by the config
+// validation anyway
+func newCheckpoints(cfg map[uint64]string) checkpoints {
+	c := make(checkpoints, len(cfg))
+	for height, hexHash := range cfg {
+		b, err := hex.DecodeString(hexHash)
+		if err != nil || len(b) != len(hash.ZeroHash256) {
+			log.L().Error("Invalid checkpoint.", zap.Uint64("height", height), zap.String("hash", hexHash))
+			continue
+		}
+		c[height] = hash.BytesToHash256(b)
+	}
+	return c
+}
+
+// verify checks the hash of the block at the given height against the checkpoint if any
+func (c checkpoints) verify(height uint64, h hash.Hash256) error {
+	if expected, ok := c[height]; ok && expected != h {
+		return errors.Wrapf(ErrCheckpointMismatch, "block %d is %x rather than %x", height, h, expected)
+	}
+	return nil
+}
+
+// latest returns the height of the highest checkpoint at or below the tip height, or 0 if there isn't any
+func (c checkpoints) latest(tipHeight uint64) uint64 {
+	var latest uint64
+	for height := range c {
+		if height <= tipHeight && height > latest {
+			latest = height
+		}
+	}
+	return latest
+}
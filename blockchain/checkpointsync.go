@@ -0,0 +1,437 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"os"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/iotexproject/iotex-address/address"
+	blake2b "github.com/minio/blake2b-simd"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/action/protocol/execution/evm"
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/trie"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/unit"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+	"github.com/iotexproject/iotex-core/state"
+	"github.com/iotexproject/iotex-core/state/factory"
+)
+
+const (
+	// snapshotHeaderSize is the number of bytes of the number of blocks in a checkpoint snapshot, and of the size
+	// prefixing each block
+	snapshotHeaderSize = 4
+	// maxSnapshotBlockSize bounds the size of a block in a checkpoint snapshot, far above the size of the blocks
+	// produced, so that a malformed snapshot cannot take up the memory
+	maxSnapshotBlockSize = 32 * unit.MB
+)
+
+// errTrielessStateDB is the error returned when a checkpoint snapshot is exported or imported with the trieless state
+// DB, whose state cannot be verified without the trie committing to the state root
+var errTrielessStateDB = errors.New("checkpoint sync needs the state trie, which chain.enableTrielessStateDB turns off")
+
+// Checkpoint is the block a checkpoint snapshot is exported at, which is pinned in Chain.Checkpoints,
+// Chain.CheckpointStateRoots and Chain.CheckpointDelegates of the nodes synced from it
+type Checkpoint struct {
+	Height    uint64
+	Hash      hash.Hash256
+	StateRoot hash.Hash256
+}
+
+// A checkpoint snapshot is what a fresh node is synced from instead of from genesis. It's made of the blocks of the
+// epoch up to the checkpoint, each prefixed with its size, followed by the state DB at the checkpoint to the end:
+//
+//	number of blocks | size | block | ... | size | block | state DB
+//
+// The blocks of the epoch are kept, since the epoch reward at the end of it is granted by the blocks the delegates
+// produce in the whole epoch.
+
+// ExportCheckpoint writes the checkpoint snapshot of the chain tip. The chain DB and the state DB in config must not be
+// in use, i.e., the node is stopped, and the state DB has to be the state trie, i.e., Chain.EnableTrielessStateDB is off.
+// It returns the checkpoint exported, which is to be pinned along with the delegates of its epoch by the nodes synced
+// from it.
+func ExportCheckpoint(cfg config.Config, w io.Writer) (Checkpoint, error) {
+	if cfg.Chain.EnableTrielessStateDB {
+		return Checkpoint{}, errTrielessStateDB
+	}
+	bc := &blockchain{config: cfg}
+	if err := BoltDBDaoOption()(bc, cfg); err != nil {
+		return Checkpoint{}, err
+	}
+	if err := DefaultStateFactoryOption()(bc, cfg); err != nil {
+		return Checkpoint{}, err
+	}
+	stateHeight, stateRoot, err := stateOf(bc.sf)
+	if err != nil {
+		return Checkpoint{}, err
+	}
+	ctx := context.Background()
+	if err := bc.dao.Start(ctx); err != nil {
+		return Checkpoint{}, errors.Wrap(err, "failed to start the chain DB")
+	}
+	defer func() {
+		if err := bc.dao.Stop(ctx); err != nil {
+			log.L().Error("Failed to stop the chain DB.", zap.Error(err))
+		}
+	}()
+	tipHeight, err := bc.dao.getBlockchainHeight()
+	if err != nil {
+		return Checkpoint{}, err
+	}
+	if tipHeight == 0 {
+		return Checkpoint{}, errors.New("no block to export")
+	}
+	if stateHeight != tipHeight {
+		return Checkpoint{}, errors.Wrapf(
+			ErrInconsistentDB,
+			"state height %d doesn't match chain tip %d, which are reconciled on starting the node",
+			stateHeight,
+			tipHeight,
+		)
+	}
+
+	tipHash, err := bc.dao.getBlockHash(tipHeight)
+	if err != nil {
+		return Checkpoint{}, err
+	}
+	start := epochStartHeight(cfg, tipHeight)
+	if err := writeSnapshotSize(w, tipHeight-start+1); err != nil {
+		return Checkpoint{}, err
+	}
+	for height := start; height <= tipHeight; height++ {
+		blk, err := bc.getBlockByHeight(height)
+		if err != nil {
+			return Checkpoint{}, errors.Wrapf(err, "failed to get block %d", height)
+		}
+		data, err := blk.Serialize()
+		if err != nil {
+			return Checkpoint{}, errors.Wrapf(err, "failed to serialize block %d", height)
+		}
+		if err := writeSnapshotSize(w, uint64(len(data))); err != nil {
+			return Checkpoint{}, err
+		}
+		if _, err := w.Write(data); err != nil {
+			return Checkpoint{}, errors.Wrap(err, "failed to write checkpoint snapshot")
+		}
+	}
+	f, err := os.Open(cfg.Chain.TrieDBPath)
+	if err != nil {
+		return Checkpoint{}, errors.Wrap(err, "failed to open the state DB")
+	}
+	defer f.Close()
+	if _, err := io.Copy(w, f); err != nil {
+		return Checkpoint{}, errors.Wrap(err, "failed to write checkpoint snapshot")
+	}
+	return Checkpoint{Height: tipHeight, Hash: tipHash, StateRoot: stateRoot}, nil
+}
+
+// ImportCheckpoint sets up a fresh node to sync from the checkpoint snapshot written by ExportCheckpoint, putting the
+// state DB and the blocks of the snapshot in place of the state DB and the chain DB in config. The snapshot is taken
+// only if its tip is pinned in Chain.Checkpoints and endorsed by more than 2/3 of the delegates pinned in
+// Chain.CheckpointDelegates, its blocks are chained up to the tip, and its state DB holds exactly the state committed
+// to by the state root pinned in Chain.CheckpointStateRoots, which needs the state trie as ExportCheckpoint does. The
+// blocks above the checkpoint are synced from the peers once the node starts, and the ones below the snapshot aren't
+// available on the node. It returns the height of the checkpoint.
+func ImportCheckpoint(cfg config.Config, r io.Reader) (height uint64, err error) {
+	if cfg.Chain.EnableTrielessStateDB {
+		return 0, errTrielessStateDB
+	}
+	for _, path := range []string{cfg.Chain.ChainDBPath, cfg.Chain.TrieDBPath} {
+		info, err := os.Stat(path)
+		if err == nil && info.Size() > 0 {
+			return 0, errors.Errorf("%s already exists, while a checkpoint is only imported by a fresh node", path)
+		}
+		if err != nil && !os.IsNotExist(err) {
+			return 0, errors.Wrapf(err, "failed to check %s", path)
+		}
+	}
+	// the snapshot holds the blocks of an epoch at most
+	blks, err := readSnapshotBlocks(r, cfg.Genesis.NumDelegates*cfg.Genesis.NumSubEpochs)
+	if err != nil {
+		return 0, err
+	}
+	tip := blks[len(blks)-1]
+	height = tip.Height()
+	checkpoints := newCheckpoints(cfg.Chain.Checkpoints)
+	if _, ok := checkpoints[height]; !ok {
+		return 0, errors.Wrapf(ErrCheckpointMismatch, "no checkpoint is pinned at height %d of the snapshot", height)
+	}
+	if err := checkpoints.verify(height, tip.HashBlock()); err != nil {
+		return 0, err
+	}
+	root, err := hex.DecodeString(cfg.Chain.CheckpointStateRoots[height])
+	if err != nil || len(root) != len(hash.ZeroHash256) {
+		return 0, errors.Wrapf(ErrCheckpointMismatch, "no state root is pinned at height %d of the snapshot", height)
+	}
+	delegates := cfg.Chain.CheckpointDelegates[height]
+	if len(delegates) == 0 {
+		return 0, errors.Wrapf(ErrCheckpointMismatch, "no delegate is pinned at height %d of the snapshot", height)
+	}
+	if err := verifyEndorsements(tip, delegates); err != nil {
+		return 0, err
+	}
+	if start := epochStartHeight(cfg, height); blks[0].Height() != start {
+		return 0, errors.Errorf(
+			"snapshot starts at block %d rather than %d, the start of the epoch",
+			blks[0].Height(),
+			start,
+		)
+	}
+	for i, blk := range blks {
+		if !blk.Header.VerifySignature() {
+			return 0, errors.Errorf("failed to verify the signature of block %d", blk.Height())
+		}
+		if i == 0 {
+			continue
+		}
+		if prev := blks[i-1]; blk.Height() != prev.Height()+1 || blk.PrevHash() != prev.HashBlock() {
+			return 0, errors.Errorf("block %d doesn't follow block %d in the snapshot", blk.Height(), prev.Height())
+		}
+	}
+
+	defer func() {
+		if err == nil {
+			return
+		}
+		// leave the node fresh to import again
+		for _, path := range []string{cfg.Chain.ChainDBPath, cfg.Chain.TrieDBPath} {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				log.L().Error("Failed to remove the DB imported partly.", zap.String("path", path), zap.Error(err))
+			}
+		}
+	}()
+	if err := writeStateDB(cfg.Chain.TrieDBPath, r); err != nil {
+		return 0, err
+	}
+	if err := verifyStateDB(cfg, height, hash.BytesToHash256(root)); err != nil {
+		return 0, err
+	}
+	bc := &blockchain{config: cfg}
+	if err := BoltDBDaoOption()(bc, cfg); err != nil {
+		return 0, err
+	}
+	if err := putSnapshotBlocks(bc.dao, blks); err != nil {
+		return 0, err
+	}
+	return height, nil
+}
+
+// commitVote is the vote of a delegate to commit a block, which the endorsements in the block footer sign. It hashes
+// the same as the commit vote of the roll-DPoS scheme, which isn't imported here as the scheme depends on the chain.
+type commitVote hash.Hash256
+
+// Hash returns the hash of the vote
+func (v commitVote) Hash() ([]byte, error) {
+	ser, err := proto.Marshal(&iotextypes.ConsensusVote{BlockHash: v[:], Topic: iotextypes.ConsensusVote_COMMIT})
+	if err != nil {
+		return nil, err
+	}
+	h := blake2b.Sum256(ser)
+	return h[:], nil
+}
+
+// verifyEndorsements checks that the block is endorsed to commit by more than 2/3 of the delegates, leaving out the
+// endorsements of the others and the ones failing to verify
+func verifyEndorsements(blk *block.Block, delegates []string) error {
+	trusted := make(map[string]bool, len(delegates))
+	for _, d := range delegates {
+		trusted[d] = true
+	}
+	vote := commitVote(blk.HashBlock())
+	endorsers := make(map[string]bool)
+	for _, en := range blk.Endorsements() {
+		addr, err := addrutil.PubKeyToAddress(en.Endorser())
+		if err != nil || !trusted[addr.String()] || !endorsement.VerifyEndorsement(vote, en) {
+			continue
+		}
+		endorsers[addr.String()] = true
+	}
+	if 3*len(endorsers) <= 2*len(trusted) {
+		return errors.Wrapf(
+			ErrCheckpointMismatch,
+			"block %d is endorsed by %d of the %d delegates pinned, rather than more than 2/3",
+			blk.Height(),
+			len(endorsers),
+			len(trusted),
+		)
+	}
+	return nil
+}
+
+// verifyStateDB checks that the state DB in config is at the height, and holds exactly the state the root commits to,
+// i.e., the nodes of the state trie, and the code and the storage of the contracts
+func verifyStateDB(cfg config.Config, height uint64, root hash.Hash256) (err error) {
+	dbCfg := cfg.DB
+	dbCfg.DbPath = cfg.Chain.TrieDBPath
+	kv := db.NewOnDiskDB(dbCfg)
+	sf, err := factory.NewFactory(cfg, factory.PrecreatedTrieDBOption(kv))
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	if err := sf.Start(ctx); err != nil {
+		return errors.Wrap(err, "failed to start the state DB")
+	}
+	defer func() {
+		if stopErr := sf.Stop(ctx); err == nil {
+			err = errors.Wrap(stopErr, "failed to stop the state DB")
+		}
+	}()
+	stateHeight, err := sf.Height()
+	if err != nil {
+		return errors.Wrap(err, "failed to get the state height")
+	}
+	if stateHeight != height {
+		return errors.Wrapf(
+			ErrInconsistentDB,
+			"state height %d of the snapshot doesn't match checkpoint %d",
+			stateHeight,
+			height,
+		)
+	}
+	if stateRoot := sf.RootHash(); stateRoot != root {
+		return errors.Wrapf(
+			ErrCheckpointMismatch,
+			"state root of the snapshot is %x rather than %x",
+			stateRoot,
+			root,
+		)
+	}
+	accountKV, err := db.NewKVStoreForTrie(factory.AccountKVNameSpace, kv)
+	if err != nil {
+		return err
+	}
+	accountTrie, err := trie.NewTrie(trie.KVStoreOption(accountKV), trie.RootHashOption(root[:]))
+	if err != nil {
+		return err
+	}
+	if err := trie.VerifyNodes(accountTrie); err != nil {
+		return errors.Wrap(err, "failed to verify the state trie of the snapshot")
+	}
+	return sf.ForEachAccount(func(addr address.Address, account *state.Account) error {
+		return evm.VerifyContract(hash.BytesToHash160(addr.Bytes()), account, kv)
+	})
+}
+
+// putSnapshotBlocks puts the blocks of the snapshot into the chain DB, and records the lowest one held
+func putSnapshotBlocks(dao *blockDAO, blks []*block.Block) (err error) {
+	ctx := context.Background()
+	if err := dao.Start(ctx); err != nil {
+		return errors.Wrap(err, "failed to start the chain DB")
+	}
+	defer func() {
+		if stopErr := dao.Stop(ctx); err == nil {
+			err = errors.Wrap(stopErr, "failed to stop the chain DB")
+		}
+	}()
+	for _, blk := range blks {
+		if err := dao.putBlock(blk); err != nil {
+			return errors.Wrapf(err, "failed to put block %d", blk.Height())
+		}
+	}
+	return errors.Wrap(
+		dao.kvstore.Put(blockNS, lowestHeightKey, byteutil.Uint64ToBytes(blks[0].Height())),
+		"failed to put the lowest height",
+	)
+}
+
+// epochStartHeight returns the height of the first block in the epoch of the given height
+func epochStartHeight(cfg config.Config, height uint64) uint64 {
+	rp := rolldpos.NewProtocol(cfg.Genesis.NumCandidateDelegates, cfg.Genesis.NumDelegates, cfg.Genesis.NumSubEpochs)
+	return rp.GetEpochHeight(rp.GetEpochNum(height))
+}
+
+// stateOf returns the height and the root of the state DB, which is opened and closed on the way
+func stateOf(sf factory.Factory) (uint64, hash.Hash256, error) {
+	ctx := context.Background()
+	if err := sf.Start(ctx); err != nil {
+		return 0, hash.ZeroHash256, errors.Wrap(err, "failed to start the state DB")
+	}
+	height, err := sf.Height()
+	root := sf.RootHash()
+	if err := sf.Stop(ctx); err != nil {
+		return 0, hash.ZeroHash256, errors.Wrap(err, "failed to stop the state DB")
+	}
+	return height, root, errors.Wrap(err, "failed to get the state height")
+}
+
+// readSnapshotBlocks reads the blocks of the snapshot, refusing more blocks than the max or a block larger than
+// maxSnapshotBlockSize before taking up the memory for them
+func readSnapshotBlocks(r io.Reader, max uint64) ([]*block.Block, error) {
+	n, err := readSnapshotSize(r)
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, errors.New("no block in the snapshot")
+	}
+	if n > max {
+		return nil, errors.Errorf("%d blocks in the snapshot exceed %d, the blocks of an epoch", n, max)
+	}
+	blks := make([]*block.Block, 0, n)
+	for i := uint64(0); i < n; i++ {
+		size, err := readSnapshotSize(r)
+		if err != nil {
+			return nil, err
+		}
+		if size > uint64(maxSnapshotBlockSize) {
+			return nil, errors.Errorf("block of %d bytes in the snapshot exceeds %s", size, maxSnapshotBlockSize)
+		}
+		data := make([]byte, size)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, errors.Wrap(err, "failed to read checkpoint snapshot")
+		}
+		var blk block.Block
+		if err := blk.Deserialize(data); err != nil {
+			return nil, errors.Wrap(err, "failed to deserialize the block in the snapshot")
+		}
+		blks = append(blks, &blk)
+	}
+	return blks, nil
+}
+
+func writeStateDB(path string, r io.Reader) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return errors.Wrap(err, "failed to create the state DB")
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return errors.Wrap(err, "failed to write the state DB")
+	}
+	return errors.Wrap(f.Close(), "failed to close the state DB")
+}
+
+func writeSnapshotSize(w io.Writer, size uint64) error {
+	var buf [snapshotHeaderSize]byte
+	binary.BigEndian.PutUint32(buf[:], uint32(size))
+	_, err := w.Write(buf[:])
+	return errors.Wrap(err, "failed to write checkpoint snapshot")
+}
+
+func readSnapshotSize(r io.Reader) (uint64, error) {
+	var buf [snapshotHeaderSize]byte
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return 0, errors.Wrap(err, "failed to read checkpoint snapshot")
+	}
+	return uint64(binary.BigEndian.Uint32(buf[:])), nil
+}
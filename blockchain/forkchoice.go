@@ -0,0 +1,113 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
+// maxForkTips is the max number of competing fork tips being tracked
+const maxForkTips = 16
+
+// forkTip is the tip of a competing fork, along with the height of the last block it shares with the canonical chain
+type forkTip struct {
+	blk  *block.Block
+	base uint64
+}
+
+// forkTips tracks the tips of the competing forks branching off the canonical chain
+type forkTips struct {
+	mu   sync.RWMutex
+	tips map[hash.Hash256]forkTip
+}
+
+func newForkTips() *forkTips {
+	return &forkTips{tips: make(map[hash.Hash256]forkTip)}
+}
+
+// extend records blk as a fork tip if its parent is a tracked tip, in which case the parent is replaced
+func (f *forkTips) extend(blk *block.Block) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	parent, ok := f.tips[blk.PrevHash()]
+	if !ok || parent.blk.Height()+1 != blk.Height() {
+		return false
+	}
+	delete(f.tips, blk.PrevHash())
+	f.tips[blk.HashBlock()] = forkTip{blk: blk, base: parent.base}
+	return true
+}
+
+// add records blk as the tip of a new fork branching off its parent
+func (f *forkTips) add(blk *block.Block) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.tips) >= maxForkTips {
+		return false
+	}
+	f.tips[blk.HashBlock()] = forkTip{blk: blk, base: blk.Height() - 1}
+	return true
+}
+
+// remove stops tracking the fork tip of the given hash
+func (f *forkTips) remove(h hash.Hash256) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.tips, h)
+}
+
+// prune stops tracking the forks branching off below minBase
+func (f *forkTips) prune(minBase uint64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for h, tip := range f.tips {
+		if tip.base < minBase {
+			delete(f.tips, h)
+		}
+	}
+}
+
+// best returns the best one among blk and the tracked fork tips branching off at or above minBase
+func (f *forkTips) best(blk *block.Block, minBase uint64) *block.Block {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	for _, tip := range f.tips {
+		if tip.base < minBase {
+			continue
+		}
+		if blk == nil || preferred(tip.blk, blk) {
+			blk = tip.blk
+		}
+	}
+	return blk
+}
+
+// minForkBase returns the lowest height a fork may branch off without reorging deeper than maxReorgDepth blocks below
+// the tip. A zero maxReorgDepth doesn't bound the reorg depth.
+func minForkBase(tipHeight, maxReorgDepth uint64) uint64 {
+	if maxReorgDepth == 0 || tipHeight <= maxReorgDepth {
+		return 0
+	}
+	return tipHeight - maxReorgDepth
+}
+
+// preferred tells whether a is preferred over b by the fork-choice rule: the higher block wins, then the earlier one,
+// and at last the one with the smaller hash so that all nodes make the same choice
+func preferred(a, b *block.Block) bool {
+	if a.Height() != b.Height() {
+		return a.Height() > b.Height()
+	}
+	if !a.Timestamp().Equal(b.Timestamp()) {
+		return a.Timestamp().Before(b.Timestamp())
+	}
+	ha, hb := a.HashBlock(), b.HashBlock()
+	return bytes.Compare(ha[:], hb[:]) < 0
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/unit"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
 	"github.com/iotexproject/iotex-core/protogen/iotextypes"
 	"github.com/iotexproject/iotex-core/test/identityset"
 )
@@ -166,18 +167,28 @@ type (
 		// ProductivityThreshold is the percentage number that a delegate's productivity needs to reach to get the
 		// epoch reward
 		ProductivityThreshold uint64 `yaml:"productivityThreshold"`
+		// BurnBlockReward grants the block reward to the burn address of the rewarding protocol instead of the
+		// producer, taking it out of the supply. The gas fees still go into the rewarding fund and are paid out by the
+		// epoch reward, as there's no fee burning here.
+		BurnBlockReward bool `yaml:"burnBlockReward"`
 	}
 )
 
 // New constructs a genesis config. It loads the default values, and could be overwritten by values defined in the yaml
 // config files
 func New() (Genesis, error) {
+	return Load(genesisPath)
+}
+
+// Load constructs a genesis config from the default values overwritten by the ones in the yaml file at the given path,
+// which is skipped if empty
+func Load(path string) (Genesis, error) {
 	def := defaultConfig()
 
 	opts := make([]config.YAMLOption, 0)
 	opts = append(opts, config.Static(def))
-	if genesisPath != "" {
-		opts = append(opts, config.File(genesisPath))
+	if path != "" {
+		opts = append(opts, config.File(path))
 	}
 	yaml, err := config.NewYAML(opts...)
 	if err != nil {
@@ -247,6 +258,7 @@ func (g *Genesis) Hash() hash.Hash256 {
 		NumDelegatesForFoundationBonus: g.NumDelegatesForFoundationBonus,
 		FoundationBonusLastEpoch:       g.FoundationBonusLastEpoch,
 		ProductivityThreshold:          g.ProductivityThreshold,
+		BurnBlockReward:                g.BurnBlockReward,
 	}
 
 	gProto := iotextypes.Genesis{
@@ -290,6 +302,7 @@ func (a *Account) InitBalances() ([]address.Address, []*big.Int) {
 
 // OperatorAddr is the address of operator
 func (d *Delegate) OperatorAddr() address.Address {
+	addrutil.MustBeValid(d.OperatorAddrStr)
 	addr, err := address.FromString(d.OperatorAddrStr)
 	if err != nil {
 		log.L().Panic("Error when decoding the poll protocol operator address from string.", zap.Error(err))
@@ -302,6 +315,7 @@ func (d *Delegate) RewardAddr() address.Address {
 	if d.RewardAddrStr == "" {
 		return nil
 	}
+	addrutil.MustBeValid(d.RewardAddrStr)
 	addr, err := address.FromString(d.RewardAddrStr)
 	if err != nil {
 		log.L().Panic("Error when decoding the poll protocol rewardee address from string.", zap.Error(err))
@@ -27,3 +27,13 @@ func TestDefaultConfig(t *testing.T) {
 	assert.Equal(t, Default.EpochReward(), cfg.EpochReward())
 	assert.Equal(t, Default.FoundationBonus(), cfg.FoundationBonus())
 }
+
+func TestHashBurnBlockReward(t *testing.T) {
+	// the nodes burning the block reward don't share the genesis hash with the ones granting it to the producers
+	cfg := Default
+	h := cfg.Hash()
+	cfg.BurnBlockReward = true
+	require.NotEqual(t, h, cfg.Hash())
+	cfg.BurnBlockReward = false
+	require.Equal(t, h, cfg.Hash())
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/iotexproject/iotex-core/pkg/enc"
 	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/metrics"
 	"github.com/iotexproject/iotex-core/pkg/prometheustimer"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 )
@@ -34,11 +35,12 @@ var batchSizeMtc = prometheus.NewGaugeVec(
 )
 
 func init() {
-	prometheus.MustRegister(batchSizeMtc)
+	metrics.MustRegister(batchSizeMtc)
 }
 
 // IndexBuilder defines the index builder
 type IndexBuilder struct {
+	chain        *blockchain
 	store        db.KVStore
 	pendingBlks  chan *block.Block
 	cancelChan   chan interface{}
@@ -61,6 +63,7 @@ func NewIndexBuilder(chain Blockchain) (*IndexBuilder, error) {
 		return nil, err
 	}
 	return &IndexBuilder{
+		chain:        bc,
 		store:        bc.dao.kvstore,
 		pendingBlks:  make(chan *block.Block, 64), // Actually 1 should be enough
 		cancelChan:   make(chan interface{}),
@@ -68,18 +71,29 @@ func NewIndexBuilder(chain Blockchain) (*IndexBuilder, error) {
 	}, nil
 }
 
-// Start starts the index builder
+// Start starts the index builder. It reconciles the indexes with the chain tip first in the background, and skips the
+// blocks indexed by then.
 func (ib *IndexBuilder) Start(_ context.Context) error {
 	go func() {
+		if err := ib.chain.dao.reconcileIndexes(ib.chain.TipHeight()); err != nil {
+			log.Logger("blockchain").Error("Error when reconciling the indexes with the chain tip.", zap.Error(err))
+		}
+		indexedHeight, _, err := ib.chain.dao.indexedHeight()
+		if err != nil {
+			log.Logger("blockchain").Error("Error when getting the indexed height.", zap.Error(err))
+		}
 		for {
 			select {
 			case <-ib.cancelChan:
 				return
 			case blk := <-ib.pendingBlks:
+				if blk.Height() <= indexedHeight {
+					continue
+				}
 				timer := ib.timerFactory.NewTimer("indexBlock")
 				batch := db.NewBatch()
 				if err := indexBlock(ib.store, blk, batch); err != nil {
-					log.L().Info(
+					log.Logger("blockchain").Info(
 						"Error when indexing the block",
 						zap.Uint64("height", blk.Height()),
 						zap.Error(err),
@@ -89,7 +103,7 @@ func (ib *IndexBuilder) Start(_ context.Context) error {
 				putReceipts(blk.Height(), blk.Receipts, batch)
 				batchSizeMtc.WithLabelValues().Set(float64(batch.Size()))
 				if err := ib.store.Commit(batch); err != nil {
-					log.L().Info(
+					log.Logger("blockchain").Info(
 						"Error when indexing the block",
 						zap.Uint64("height", blk.Height()),
 						zap.Error(err),
@@ -124,6 +138,7 @@ func indexBlock(store db.KVStore, blk *block.Block, batch db.KVStoreBatch) error
 	totalActions += uint64(len(blk.Actions))
 	totalActionsBytes := byteutil.Uint64ToBytes(totalActions)
 	batch.Put(blockNS, totalActionsKey, totalActionsBytes, "failed to put total actions")
+	batch.Put(blockNS, indexedHeightKey, byteutil.Uint64ToBytes(blk.Height()), "failed to put indexed height")
 	for _, elp := range blk.Actions {
 		actHash := elp.Hash()
 		batch.Put(blockActionBlockMappingNS, actHash[hashOffset:], hash[:], "failed to put action hash %x", actHash)
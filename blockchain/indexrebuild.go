@@ -0,0 +1,266 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/pkg/enc"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
+)
+
+// The phases of rebuilding the indexes. The indexes of the committed blocks are dropped first, and then built again
+// from scratch.
+const (
+	rebuildPhaseDrop  byte = 1
+	rebuildPhaseBuild byte = 2
+)
+
+// rebuildLogInterval is the number of blocks between the progress logs of rebuilding the indexes
+const rebuildLogInterval = 1000
+
+// rebuildProgress is the progress of rebuilding the indexes, which is stored along with the indexes, so that an
+// interrupted rebuild resumes from where it stopped
+type rebuildProgress struct {
+	phase  byte
+	height uint64
+}
+
+func (p rebuildProgress) serialize() []byte {
+	return append([]byte{p.phase}, byteutil.Uint64ToBytes(p.height)...)
+}
+
+func (p *rebuildProgress) deserialize(buf []byte) error {
+	if len(buf) != 9 || (buf[0] != rebuildPhaseDrop && buf[0] != rebuildPhaseBuild) {
+		return errors.Errorf("invalid progress of rebuilding the indexes %x", buf)
+	}
+	p.phase = buf[0]
+	p.height = enc.MachineEndian.Uint64(buf[1:])
+	return nil
+}
+
+// rebuildProgress returns the progress of an unfinished rebuild of the indexes, or nil if there isn't any
+func (dao *blockDAO) rebuildProgress() (*rebuildProgress, error) {
+	value, err := dao.kvstore.Get(blockNS, rebuildIndexesKey)
+	if err != nil {
+		if errors.Cause(err) == db.ErrNotExist {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to get the progress of rebuilding the indexes")
+	}
+	progress := &rebuildProgress{}
+	if err := progress.deserialize(value); err != nil {
+		return nil, err
+	}
+	return progress, nil
+}
+
+// rebuildIndexes drops the secondary indexes of the blocks up to the tip height, i.e., action -> block,
+// action -> receipt, address -> actions and the total number of actions, and builds them again by replaying the
+// blocks. Each block is committed along with the progress, so an unfinished rebuild is resumed by calling it again.
+func (dao *blockDAO) rebuildIndexes(tipHeight uint64) error {
+	progress, err := dao.rebuildProgress()
+	if err != nil {
+		return err
+	}
+	if progress == nil {
+		progress = &rebuildProgress{phase: rebuildPhaseDrop, height: dao.floorHeight()}
+	} else {
+		log.L().Info("Resuming rebuilding the indexes.",
+			zap.Uint8("phase", progress.phase),
+			zap.Uint64("height", progress.height))
+	}
+	if progress.phase == rebuildPhaseDrop {
+		for height := progress.height + 1; height <= tipHeight; height++ {
+			batch := db.NewBatch()
+			if err := dao.dropIndexes(height, batch); err != nil {
+				return err
+			}
+			progress.height = height
+			if height == tipHeight {
+				batch.Put(blockNS, totalActionsKey, make([]byte, 8), "failed to reset total actions")
+				batch.Put(blockNS, indexedHeightKey, make([]byte, 8), "failed to reset indexed height")
+				progress = &rebuildProgress{phase: rebuildPhaseBuild, height: dao.floorHeight()}
+			}
+			batch.Put(blockNS, rebuildIndexesKey, progress.serialize(), "failed to put rebuild progress")
+			if err := dao.kvstore.Commit(batch); err != nil {
+				return errors.Wrapf(err, "failed to drop the indexes of block %d", height)
+			}
+			logRebuildProgress("drop", height, tipHeight)
+		}
+		if tipHeight == dao.floorHeight() {
+			progress = &rebuildProgress{phase: rebuildPhaseBuild, height: tipHeight}
+		}
+	}
+	for height := progress.height + 1; height <= tipHeight; height++ {
+		batch := db.NewBatch()
+		if err := dao.indexCommittedBlock(height, batch); err != nil {
+			return err
+		}
+		progress.height = height
+		batch.Put(blockNS, rebuildIndexesKey, progress.serialize(), "failed to put rebuild progress")
+		if err := dao.kvstore.Commit(batch); err != nil {
+			return errors.Wrapf(err, "failed to index block %d", height)
+		}
+		logRebuildProgress("build", height, tipHeight)
+	}
+	batch := db.NewBatch()
+	batch.Put(blockNS, indexedHeightKey, byteutil.Uint64ToBytes(tipHeight), "failed to put indexed height")
+	batch.Delete(blockNS, rebuildIndexesKey, "failed to delete the progress of rebuilding the indexes")
+	if err := dao.kvstore.Commit(batch); err != nil {
+		return errors.Wrap(err, "failed to delete the progress of rebuilding the indexes")
+	}
+	log.L().Info("Rebuilt the indexes.", zap.Uint64("tipHeight", tipHeight))
+	return nil
+}
+
+// floorHeight returns the height below the lowest block held, which is 0 unless the node is synced from a checkpoint
+func (dao *blockDAO) floorHeight() uint64 {
+	if dao.lowestHeight == 0 {
+		return 0
+	}
+	return dao.lowestHeight - 1
+}
+
+// reconcileIndexes brings the indexes to the chain tip on startup. The indexes behind the tip, which are left by the
+// index written asynchronously when the node stops uncleanly, are caught up, and the ones ahead of the tip, which are
+// left by rolling the chain back without the index written along, are rebuilt, since the blocks indexed are gone. The
+// entries of the actions in the blocks gone are left over, pointing to no block.
+func (dao *blockDAO) reconcileIndexes(tipHeight uint64) error {
+	indexedHeight, ok, err := dao.indexedHeight()
+	if err != nil {
+		return err
+	}
+	if !ok || indexedHeight == tipHeight {
+		return nil
+	}
+	if indexedHeight > tipHeight {
+		log.L().Warn("The indexes are ahead of the chain tip, rebuilding them.",
+			zap.Uint64("indexedHeight", indexedHeight),
+			zap.Uint64("tipHeight", tipHeight))
+		return dao.rebuildIndexes(tipHeight)
+	}
+	log.L().Warn("The indexes are behind the chain tip, catching them up.",
+		zap.Uint64("indexedHeight", indexedHeight),
+		zap.Uint64("tipHeight", tipHeight))
+	return dao.catchUpIndexes(indexedHeight, tipHeight)
+}
+
+// indexedHeight returns the height of the last block indexed, and false if it isn't recorded, which is the case of the
+// indexes written before the height is tracked
+func (dao *blockDAO) indexedHeight() (uint64, bool, error) {
+	value, err := dao.kvstore.Get(blockNS, indexedHeightKey)
+	if err != nil {
+		if errors.Cause(err) == db.ErrNotExist {
+			return 0, false, nil
+		}
+		return 0, false, errors.Wrap(err, "failed to get the indexed height")
+	}
+	return enc.MachineEndian.Uint64(value), true, nil
+}
+
+// catchUpIndexes indexes the committed blocks of (indexedHeight, tipHeight], which are left behind by the index
+// written asynchronously when the node stops uncleanly. Each block is committed along with the indexed height, so an
+// interrupted catch-up resumes from where it stopped.
+func (dao *blockDAO) catchUpIndexes(indexedHeight, tipHeight uint64) error {
+	for height := indexedHeight + 1; height <= tipHeight; height++ {
+		batch := db.NewBatch()
+		if err := dao.indexCommittedBlock(height, batch); err != nil {
+			return err
+		}
+		if err := dao.kvstore.Commit(batch); err != nil {
+			return errors.Wrapf(err, "failed to index block %d", height)
+		}
+		logRebuildProgress("catchup", height, tipHeight)
+	}
+	return nil
+}
+
+// indexCommittedBlock puts the indexes of the actions and receipts in the committed block of the given height into the
+// batch
+func (dao *blockDAO) indexCommittedBlock(height uint64, batch db.KVStoreBatch) error {
+	blk, err := dao.getBlockByHeight(height)
+	if err != nil {
+		return err
+	}
+	if err := indexBlock(dao.kvstore, blk, batch); err != nil {
+		return errors.Wrapf(err, "failed to index block %d", height)
+	}
+	receipts, err := dao.getReceipts(height)
+	if err != nil && errors.Cause(err) != db.ErrNotExist {
+		return err
+	}
+	putReceipts(height, receipts, batch)
+	return nil
+}
+
+// dropIndexes deletes the indexes of the actions and receipts in the block of the given height. The entries of
+// address -> actions are left over, as they are out of reach once the counts are reset, and overwritten on rebuilding
+func (dao *blockDAO) dropIndexes(height uint64, batch db.KVStoreBatch) error {
+	blk, err := dao.getBlockByHeight(height)
+	if err != nil {
+		return err
+	}
+	for _, selp := range blk.Actions {
+		actHash := selp.Hash()
+		batch.Delete(blockActionBlockMappingNS, actHash[hashOffset:], "failed to delete action hash %x", actHash)
+		callerAddrBytes := hash.BytesToHash160(selp.SrcPubkey().Hash())
+		senderActionCountKey := append(actionFromPrefix, callerAddrBytes[:]...)
+		batch.Delete(blockAddressActionCountMappingNS, senderActionCountKey,
+			"failed to delete action count for sender %x", callerAddrBytes)
+
+		dst, ok := selp.Destination()
+		if !ok || dst == "" {
+			continue
+		}
+		dstAddr, err := address.FromString(dst)
+		if err != nil {
+			return err
+		}
+		dstAddrBytes := hash.BytesToHash160(dstAddr.Bytes())
+		recipientActionCountKey := append(actionToPrefix, dstAddrBytes[:]...)
+		batch.Delete(blockAddressActionCountMappingNS, recipientActionCountKey,
+			"failed to delete action count for recipient %x", dstAddrBytes)
+	}
+	receipts, err := dao.getReceipts(height)
+	if err != nil {
+		if errors.Cause(err) == db.ErrNotExist {
+			return nil
+		}
+		return err
+	}
+	for _, r := range receipts {
+		batch.Delete(blockActionReceiptMappingNS, r.ActionHash[hashOffset:],
+			"failed to delete receipt for action %x", r.ActionHash[:])
+	}
+	return nil
+}
+
+func (dao *blockDAO) getBlockByHeight(height uint64) (*block.Block, error) {
+	hash, err := dao.getBlockHash(height)
+	if err != nil {
+		return nil, err
+	}
+	return dao.getBlock(hash)
+}
+
+func logRebuildProgress(phase string, height, tipHeight uint64) {
+	if height%rebuildLogInterval != 0 && height != tipHeight {
+		return
+	}
+	log.L().Info("Rebuilding the indexes.",
+		zap.String("phase", phase),
+		zap.Uint64("height", height),
+		zap.Uint64("tipHeight", tipHeight),
+		zap.Float64("percent", float64(height)*100/float64(tipHeight)))
+}
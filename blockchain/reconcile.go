@@ -0,0 +1,115 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// ErrInconsistentDB is the error returned on startup when the chain DB and the state DB cannot be reconciled
+var ErrInconsistentDB = errors.New("chain DB and state DB are inconsistent")
+
+// reconcileState brings the state DB to the chain tip on startup. The state DB is behind if the node stops uncleanly
+// between committing a block and its states, and the blocks ahead are replayed. A block whose actions fail to run or
+// whose delta state digest doesn't match is rolled back along with the blocks above it, to be synced again from the
+// peers, while any other error, e.g., failing to read the block, is returned as is. The node refuses to start if the state DB is
+// ahead of the chain tip, since the state trie keeps no history to roll back, or if the blocks to replay or roll back
+// are more than Chain.MaxReconcileBlocks. A fresh state DB at height 0 is built from genesis regardless of the bound.
+func (bc *blockchain) reconcileState() error {
+	stateHeight, err := bc.sf.Height()
+	if err != nil {
+		return err
+	}
+	if stateHeight > bc.tipHeight {
+		return errors.Wrapf(
+			ErrInconsistentDB,
+			"state height %d is ahead of chain tip %d, and the states cannot be rolled back",
+			stateHeight,
+			bc.tipHeight,
+		)
+	}
+	if stateHeight == bc.tipHeight {
+		return nil
+	}
+	limit := bc.config.Chain.MaxReconcileBlocks
+	if stateHeight > 0 && limit > 0 && bc.tipHeight-stateHeight > limit {
+		return errors.Wrapf(
+			ErrInconsistentDB,
+			"chain tip %d is %d blocks ahead of state height %d, over the %d blocks to reconcile",
+			bc.tipHeight,
+			bc.tipHeight-stateHeight,
+			stateHeight,
+			limit,
+		)
+	}
+	log.L().Warn("The chain DB is ahead of the state DB, replaying the blocks.",
+		zap.Uint64("tipHeight", bc.tipHeight),
+		zap.Uint64("stateHeight", stateHeight))
+	for height := stateHeight + 1; height <= bc.tipHeight; height++ {
+		err := bc.replayBlock(height)
+		if err == nil {
+			continue
+		}
+		if errors.Cause(err) != ErrInvalidBlock {
+			return errors.Wrapf(err, "failed to replay block %d", height)
+		}
+		if limit > 0 && bc.tipHeight-height+1 > limit {
+			return errors.Wrapf(
+				ErrInconsistentDB,
+				"block %d of chain tip %d fails to replay, and rolling back %d blocks is over the %d blocks to "+
+					"reconcile: %v",
+				height,
+				bc.tipHeight,
+				bc.tipHeight-height+1,
+				limit,
+				err,
+			)
+		}
+		log.L().Error("Failed to replay the block, rolling the chain back.",
+			zap.Uint64("height", height),
+			zap.Uint64("tipHeight", bc.tipHeight),
+			zap.Error(err))
+		return bc.rollBack(height - 1)
+	}
+	return nil
+}
+
+// replayBlock runs the actions in the committed block of the given height and commits the states. The actions failing
+// to run or the delta state digest not matching is returned as ErrInvalidBlock
+func (bc *blockchain) replayBlock(height uint64) error {
+	blk, err := bc.getBlockByHeight(height)
+	if err != nil {
+		return err
+	}
+	ws, err := bc.sf.NewWorkingSet()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain working set from state factory")
+	}
+	if _, err := bc.runActions(blk.RunnableActions(), ws); err != nil {
+		return errors.Wrapf(ErrInvalidBlock, "failed to run the actions: %v", err)
+	}
+	if err := blk.VerifyDeltaStateDigest(ws.Digest()); err != nil {
+		return errors.Wrapf(ErrInvalidBlock, "failed to verify the delta state digest: %v", err)
+	}
+	return bc.sf.Commit(ws)
+}
+
+// rollBack deletes the committed blocks above the given height
+func (bc *blockchain) rollBack(height uint64) error {
+	if err := bc.recoverToHeight(height); err != nil {
+		return errors.Wrapf(err, "failed to roll the chain back to height %d", height)
+	}
+	tipHash, err := bc.dao.getBlockHash(height)
+	if err != nil {
+		return err
+	}
+	bc.tipHash = tipHash
+	return nil
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blockchain
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-core/state"
+)
+
+// exportFlushInterval is the number of rows written between two flushes of the CSV export
+const exportFlushInterval = 1000
+
+// ExportStateCSV writes the address, balance, nonce and candidate status of every account at the tip as CSV, one row
+// per account in the order of the state trie. The accounts are iterated from the state trie, so that the ones funded
+// only by contracts are included too, and every row is written to w once read. The state factory is locked from
+// committing blocks during the export, so that the rows reflect the same tip.
+func (bc *blockchain) ExportStateCSV(w io.Writer) error {
+	if bc.sf == nil {
+		return errors.New("state factory is nil")
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"address", "balance", "nonce", "isCandidate"}); err != nil {
+		return err
+	}
+	rows := 0
+	if err := bc.sf.ForEachAccount(func(addr address.Address, account *state.Account) error {
+		if err := cw.Write([]string{
+			addr.String(),
+			account.Balance.String(),
+			strconv.FormatUint(account.Nonce, 10),
+			strconv.FormatBool(account.IsCandidate),
+		}); err != nil {
+			return err
+		}
+		rows++
+		if rows%exportFlushInterval == 0 {
+			cw.Flush()
+			return cw.Error()
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "failed to export the accounts")
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/protogen/iotexrpc"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+// ErrInvalidBatch is the error returned when a block batch is not what was requested from the peer
+var ErrInvalidBatch = errors.New("invalid block batch")
+
+// newBlockBatch reads the blocks of [start, end] in order into a batch of no more than maxBytes, which is unlimited
+// if 0. The blocks missing below the first one found have been pruned and are skipped, and the batch stops at the
+// next block missing or going over the cap, so that it covers the sub-range served. The first block is served even
+// if it goes over the cap alone, otherwise the peer would never get it.
+func newBlockBatch(
+	bc blockchain.Blockchain,
+	start uint64,
+	end uint64,
+	headersOnly bool,
+	maxBytes uint64,
+) (*iotexrpc.BlockBatch, error) {
+	batch := &iotexrpc.BlockBatch{}
+	var size uint64
+	for h := start; h <= end; h++ {
+		var (
+			msg proto.Message
+			err error
+		)
+		if headersOnly {
+			var header *block.Header
+			if header, err = bc.BlockHeaderByHeight(h); err == nil {
+				msg = header.BlockHeaderProto()
+			}
+		} else {
+			var blk *block.Block
+			if blk, err = bc.GetBlockByHeight(h); err == nil {
+				msg = blk.ConvertToBlockPb()
+			}
+		}
+		served := len(batch.Blocks)+len(batch.Headers) > 0
+		switch {
+		case errors.Cause(err) == db.ErrNotExist && !served:
+			continue
+		case errors.Cause(err) == db.ErrNotExist:
+			return batch, nil
+		case err != nil:
+			return nil, err
+		}
+		n := uint64(proto.Size(msg))
+		if served && maxBytes > 0 && size+n > maxBytes {
+			return batch, nil
+		}
+		size += n
+		if !served {
+			batch.Start = h
+		}
+		batch.End = h
+		switch m := msg.(type) {
+		case *iotextypes.BlockHeader:
+			batch.Headers = append(batch.Headers, m)
+		case *iotextypes.Block:
+			batch.Blocks = append(batch.Blocks, m)
+		}
+	}
+	return batch, nil
+}
+
+// verifyBlockBatch checks the batch answering the request for the blocks of [start, end] in no more than maxBytes,
+// and returns the blocks in order. An empty batch means the peer has none of the blocks.
+func verifyBlockBatch(batch *iotexrpc.BlockBatch, start, end uint64, maxBytes uint64) ([]*block.Block, error) {
+	if len(batch.Headers) > 0 {
+		return nil, errors.Wrap(ErrInvalidBatch, "block headers are served while the full blocks are requested")
+	}
+	if len(batch.Blocks) == 0 {
+		return nil, nil
+	}
+	if batch.Start < start || batch.End > end || batch.Start > batch.End {
+		return nil, errors.Wrapf(
+			ErrInvalidBatch,
+			"blocks %d to %d are served while %d to %d are requested",
+			batch.Start,
+			batch.End,
+			start,
+			end,
+		)
+	}
+	if uint64(len(batch.Blocks)) != batch.End-batch.Start+1 {
+		return nil, errors.Wrapf(
+			ErrInvalidBatch,
+			"%d blocks are served for blocks %d to %d",
+			len(batch.Blocks),
+			batch.Start,
+			batch.End,
+		)
+	}
+	if len(batch.Blocks) > 1 && maxBytes > 0 {
+		var size uint64
+		for _, pb := range batch.Blocks {
+			size += uint64(proto.Size(pb))
+		}
+		if size > maxBytes {
+			return nil, errors.Wrapf(ErrInvalidBatch, "batch of %d bytes is over the cap %d", size, maxBytes)
+		}
+	}
+	blks := make([]*block.Block, 0, len(batch.Blocks))
+	for i, pb := range batch.Blocks {
+		blk := &block.Block{}
+		if err := blk.ConvertFromBlockPb(pb); err != nil {
+			return nil, errors.Wrap(ErrInvalidBatch, err.Error())
+		}
+		if h := batch.Start + uint64(i); blk.Height() != h {
+			return nil, errors.Wrapf(ErrInvalidBatch, "block %d is served in place of block %d", blk.Height(), h)
+		}
+		blks = append(blks, blk)
+	}
+	return blks, nil
+}
@@ -9,6 +9,7 @@ package blocksync
 import (
 	"context"
 
+	"github.com/facebookgo/clock"
 	"github.com/golang/protobuf/proto"
 	peerstore "github.com/libp2p/go-libp2p-peerstore"
 	"go.uber.org/zap"
@@ -28,12 +29,16 @@ type (
 	UnicastOutbound func(ctx context.Context, peer peerstore.PeerInfo, msg proto.Message) error
 	// Neighbors returns the neighbors' addresses
 	Neighbors func(ctx context.Context) ([]peerstore.PeerInfo, error)
+	// SyncStateHandler is told whether the node is syncing, i.e., its tip is behind its peers', once it changes
+	SyncStateHandler func(syncing bool)
 )
 
 // Config represents the config to setup blocksync
 type Config struct {
 	unicastHandler   UnicastOutbound
 	neighborsHandler Neighbors
+	syncStateHandler SyncStateHandler
+	clock            clock.Clock
 }
 
 // Option is the option to override the blocksync config
@@ -55,14 +60,37 @@ func WithNeighbors(neighborsHandler Neighbors) Option {
 	}
 }
 
+// WithSyncStateHandler is the option to set the callback of the sync state
+func WithSyncStateHandler(syncStateHandler SyncStateHandler) Option {
+	return func(cfg *Config) error {
+		cfg.syncStateHandler = syncStateHandler
+		return nil
+	}
+}
+
+// WithClock is the option to set the clock measuring the sync speed and the stalls
+func WithClock(clk clock.Clock) Option {
+	return func(cfg *Config) error {
+		cfg.clock = clk
+		return nil
+	}
+}
+
 // BlockSync defines the interface of blocksyncer
 type BlockSync interface {
 	lifecycle.StartStopper
 
 	TargetHeight() uint64
+	// Progress returns the target height, the committed height, and the number of block sync requests waiting for
+	// the blocks
+	Progress() (targetHeight uint64, committedHeight uint64, inFlightChunks int)
+	// Status returns whether the node is catching up with its peers, and how fast
+	Status() Status
 	ProcessSyncRequest(ctx context.Context, peer peerstore.PeerInfo, sync *iotexrpc.BlockSync) error
 	ProcessBlock(ctx context.Context, blk *block.Block) error
 	ProcessBlockSync(ctx context.Context, blk *block.Block) error
+	// ProcessBlockBatch processes a batch of blocks served by the peer for a block sync request
+	ProcessBlockBatch(ctx context.Context, peer peerstore.PeerInfo, batch *iotexrpc.BlockBatch) error
 }
 
 // blockSyncer implements BlockSync interface
@@ -73,6 +101,9 @@ type blockSyncer struct {
 	bc               blockchain.Blockchain
 	unicastHandler   UnicastOutbound
 	neighborsHandler Neighbors
+	syncStateHandler SyncStateHandler
+	status           *syncStatus
+	maxBatchBytes    uint64
 }
 
 // NewBlockSyncer returns a new block syncer instance
@@ -97,12 +128,19 @@ func NewBlockSyncer(
 			return nil, err
 		}
 	}
+	if bsCfg.clock == nil {
+		bsCfg.clock = clock.New()
+	}
+	status := newSyncStatus(cfg.BlockSync.StallWindow, bsCfg.clock)
 	bs := &blockSyncer{
 		bc:               chain,
 		buf:              buf,
 		unicastHandler:   bsCfg.unicastHandler,
 		neighborsHandler: bsCfg.neighborsHandler,
-		worker:           newSyncWorker(chain.ChainID(), cfg, bsCfg.unicastHandler, bsCfg.neighborsHandler, buf),
+		syncStateHandler: bsCfg.syncStateHandler,
+		status:           status,
+		maxBatchBytes:    uint64(cfg.BlockSync.MaxBatchBytes),
+		worker:           newSyncWorker(chain.ChainID(), cfg, bsCfg.unicastHandler, bsCfg.neighborsHandler, buf, status),
 	}
 	return bs, nil
 }
@@ -114,6 +152,17 @@ func (bs *blockSyncer) TargetHeight() uint64 {
 	return bs.worker.targetHeight
 }
 
+// Progress returns the progress of the block sync
+func (bs *blockSyncer) Progress() (uint64, uint64, int) {
+	targetHeight, inFlightChunks := bs.worker.progress()
+	return targetHeight, bs.bc.TipHeight(), inFlightChunks
+}
+
+// Status returns whether the node is catching up with its peers, and how fast
+func (bs *blockSyncer) Status() Status {
+	return bs.status.status(bs.bc.TipHeight())
+}
+
 // Start starts a block syncer
 func (bs *blockSyncer) Start(ctx context.Context) error {
 	log.L().Debug("Starting block syncer.")
@@ -128,7 +177,7 @@ func (bs *blockSyncer) Stop(ctx context.Context) error {
 }
 
 // ProcessBlock processes an incoming latest committed block
-func (bs *blockSyncer) ProcessBlock(_ context.Context, blk *block.Block) error {
+func (bs *blockSyncer) ProcessBlock(ctx context.Context, blk *block.Block) error {
 	var needSync bool
 	moved, re := bs.buf.Flush(blk)
 	switch re {
@@ -147,18 +196,62 @@ func (bs *blockSyncer) ProcessBlock(_ context.Context, blk *block.Block) error {
 	if needSync {
 		bs.worker.SetTargetHeight(blk.Height())
 	}
+	bs.updateSyncState(ctx, blk)
 	return nil
 }
 
-func (bs *blockSyncer) ProcessBlockSync(_ context.Context, blk *block.Block) error {
+func (bs *blockSyncer) ProcessBlockSync(ctx context.Context, blk *block.Block) error {
 	bs.buf.Flush(blk)
 	if bs.bc.TipHeight() == bs.TargetHeight() {
 		bs.worker.SetTargetHeight(bs.TargetHeight() + bs.buf.bufSize())
 	}
+	bs.updateSyncState(ctx, blk)
 	return nil
 }
 
-// ProcessSyncRequest processes a block sync request
+// ProcessBlockBatch processes a batch of blocks served by the peer for a block sync request. The batch is rejected
+// and the peer is penalized if it is not what was requested.
+func (bs *blockSyncer) ProcessBlockBatch(
+	ctx context.Context,
+	peer peerstore.PeerInfo,
+	batch *iotexrpc.BlockBatch,
+) error {
+	blks, err := bs.worker.acceptBatch(peer, batch)
+	if err != nil {
+		return err
+	}
+	ctx = WithPeer(ctx, peer)
+	for _, blk := range blks {
+		if err := bs.ProcessBlockSync(ctx, blk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateSyncState takes the height of a block received from the peers, and tells the sync state handler if the node
+// starts or stops falling behind them. The target height isn't used, which goes beyond the peers' tip speculatively.
+func (bs *blockSyncer) updateSyncState(ctx context.Context, blk *block.Block) {
+	if blk == nil {
+		return
+	}
+	var peerID string
+	if peer, ok := peerFromContext(ctx); ok {
+		peerID = peer.ID.Pretty()
+	}
+	syncing, changed := bs.status.update(bs.bc.TipHeight(), blk.Height(), peerID)
+	if !changed {
+		return
+	}
+	log.L().Info("Sync state changed.", zap.Bool("syncing", syncing), zap.Uint64("height", blk.Height()))
+	if bs.syncStateHandler != nil {
+		bs.syncStateHandler(syncing)
+	}
+}
+
+// ProcessSyncRequest processes a block sync request. The blocks are sent one per message, unless the request asks for
+// a batch of no more than the max bytes, or for the block headers only. The batch is capped by BlockSync.MaxBatchBytes
+// as well.
 func (bs *blockSyncer) ProcessSyncRequest(ctx context.Context, peer peerstore.PeerInfo, sync *iotexrpc.BlockSync) error {
 	end := bs.bc.TipHeight()
 	switch {
@@ -173,6 +266,9 @@ func (bs *blockSyncer) ProcessSyncRequest(ctx context.Context, peer peerstore.Pe
 			zap.Uint64("tipHeight", end),
 		)
 	}
+	if sync.MaxBytes > 0 || sync.HeadersOnly {
+		return bs.serveBatch(peer, sync, end)
+	}
 	for i := sync.Start; i <= end; i++ {
 		blk, err := bs.bc.GetBlockByHeight(i)
 		if err != nil {
@@ -187,3 +283,19 @@ func (bs *blockSyncer) ProcessSyncRequest(ctx context.Context, peer peerstore.Pe
 	}
 	return nil
 }
+
+// serveBatch sends back the blocks of the request up to the end height in one batch
+func (bs *blockSyncer) serveBatch(peer peerstore.PeerInfo, sync *iotexrpc.BlockSync, end uint64) error {
+	maxBytes := sync.MaxBytes
+	if bs.maxBatchBytes > 0 && (maxBytes == 0 || maxBytes > bs.maxBatchBytes) {
+		maxBytes = bs.maxBatchBytes
+	}
+	batch, err := newBlockBatch(bs.bc, sync.Start, end, sync.HeadersOnly, maxBytes)
+	if err != nil {
+		return err
+	}
+	if err := bs.unicastHandler(context.Background(), peer, batch); err != nil {
+		log.L().Debug("Failed to response to ProcessSyncRequest.", zap.Error(err))
+	}
+	return nil
+}
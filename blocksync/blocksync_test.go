@@ -8,13 +8,17 @@ package blocksync
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/facebookgo/clock"
 	"github.com/golang/mock/gomock"
 	"github.com/golang/protobuf/proto"
+	peer "github.com/libp2p/go-libp2p-peer"
 	peerstore "github.com/libp2p/go-libp2p-peerstore"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -29,10 +33,11 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/unit"
 	"github.com/iotexproject/iotex-core/protogen/iotexrpc"
 	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
-	"github.com/iotexproject/iotex-core/test/mock/mock_blocksync"
 	"github.com/iotexproject/iotex-core/test/mock/mock_consensus"
 	ta "github.com/iotexproject/iotex-core/test/testaddress"
 	"github.com/iotexproject/iotex-core/testutil"
@@ -78,30 +83,6 @@ func TestNewBlockSyncer(t *testing.T) {
 	assert.NotNil(bs)
 }
 
-func TestBlockSyncerStart(t *testing.T) {
-	assert := assert.New(t)
-
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	ctx := context.Background()
-	mBs := mock_blocksync.NewMockBlockSync(ctrl)
-	mBs.EXPECT().Start(gomock.Any()).Times(1)
-	assert.Nil(mBs.Start(ctx))
-}
-
-func TestBlockSyncerStop(t *testing.T) {
-	assert := assert.New(t)
-
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	ctx := context.Background()
-	mBs := mock_blocksync.NewMockBlockSync(ctrl)
-	mBs.EXPECT().Stop(gomock.Any()).Times(1)
-	assert.Nil(mBs.Stop(ctx))
-}
-
 func TestBlockSyncerProcessSyncRequest(t *testing.T) {
 	assert := assert.New(t)
 	require := require.New(t)
@@ -462,6 +443,565 @@ func TestBlockSyncerSync(t *testing.T) {
 	time.Sleep(time.Millisecond << 7)
 }
 
+func TestBlockSyncerSyncState(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	cfg, err := newTestConfig()
+	require.NoError(err)
+	source := newTestChain(require, cfg)
+	chain := newTestChain(require, cfg)
+	defer func() {
+		require.NoError(source.Stop(ctx))
+		require.NoError(chain.Stop(ctx))
+	}()
+	blks := make([]*block.Block, 3)
+	for i := range blks {
+		blks[i], err = source.MintNewBlock(nil, testutil.TimestampNow())
+		require.NoError(err)
+		require.NoError(source.CommitBlock(blks[i]))
+	}
+	ap, err := actpool.NewActPool(chain, cfg.ActPool, actpool.EnableExperimentalActions())
+	require.NoError(err)
+	cs := mock_consensus.NewMockConsensus(ctrl)
+	cs.EXPECT().ValidateBlockFooter(gomock.Any()).Return(nil).Times(3)
+	cs.EXPECT().Calibrate(gomock.Any()).Times(3)
+	var states []bool
+	bs, err := NewBlockSyncer(cfg, chain, ap, cs, append(opts, WithSyncStateHandler(func(syncing bool) {
+		states = append(states, syncing)
+	}))...)
+	require.NoError(err)
+
+	// the node falls behind on receiving block 3, and catches up once block 1 and 2 are committed
+	require.NoError(bs.ProcessBlock(ctx, blks[2]))
+	require.Equal([]bool{true}, states)
+	require.NoError(bs.ProcessBlockSync(ctx, blks[0]))
+	require.Equal([]bool{true}, states)
+	require.NoError(bs.ProcessBlockSync(ctx, blks[1]))
+	require.Equal([]bool{true, false}, states)
+	require.Equal(uint64(3), chain.TipHeight())
+}
+
+func TestBlockSyncerStatus(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	cfg, err := newTestConfig()
+	require.NoError(err)
+	// the worker is driven by hand
+	cfg.BlockSync.Interval = 0
+	cfg.BlockSync.Parallelism = 1
+	cfg.BlockSync.StallWindow = 30 * time.Second
+	source := newTestChain(require, cfg)
+	chain := newTestChain(require, cfg)
+	defer func() {
+		require.NoError(source.Stop(ctx))
+		require.NoError(chain.Stop(ctx))
+	}()
+	blks := make([]*block.Block, 6)
+	for i := range blks {
+		blks[i], err = source.MintNewBlock(nil, testutil.TimestampNow())
+		require.NoError(err)
+		require.NoError(source.CommitBlock(blks[i]))
+	}
+	ap, err := actpool.NewActPool(chain, cfg.ActPool, actpool.EnableExperimentalActions())
+	require.NoError(err)
+	cs := mock_consensus.NewMockConsensus(ctrl)
+	cs.EXPECT().ValidateBlockFooter(gomock.Any()).Return(nil).AnyTimes()
+	cs.EXPECT().Calibrate(gomock.Any()).AnyTimes()
+
+	// the peers take the requests without responding, and the test delivers the blocks
+	peers := []peerstore.PeerInfo{{ID: peer.ID("peer0")}, {ID: peer.ID("peer1")}}
+	type request struct {
+		peer peer.ID
+		sync *iotexrpc.BlockSync
+	}
+	var requests []request
+	clk := clock.NewMock()
+	bs, err := NewBlockSyncer(cfg, chain, ap, cs,
+		WithUnicastOutBound(func(_ context.Context, p peerstore.PeerInfo, msg proto.Message) error {
+			requests = append(requests, request{p.ID, msg.(*iotexrpc.BlockSync)})
+			return nil
+		}),
+		WithNeighbors(func(_ context.Context) ([]peerstore.PeerInfo, error) { return peers, nil }),
+		WithClock(clk),
+	)
+	require.NoError(err)
+	worker := bs.(*blockSyncer).worker
+	peerCtx := WithPeer(ctx, peers[0])
+
+	status := bs.Status()
+	require.Equal(SyncIdle, status.State)
+	require.Equal(uint64(0), status.TipHeight)
+	require.Equal(uint64(0), status.PeerHeight)
+
+	// falling behind on hearing of block 6 from peer0
+	require.NoError(bs.ProcessBlock(peerCtx, blks[5]))
+	status = bs.Status()
+	require.Equal(Syncing, status.State)
+	require.Equal(uint64(6), status.PeerHeight)
+	require.Equal(peers[0].ID.Pretty(), status.Peer)
+	require.Equal(time.Duration(0), status.ETA)
+	worker.Sync()
+	require.Equal(1, len(requests))
+	require.Equal(uint64(1), requests[0].sync.Start)
+
+	// 3 blocks a second, leaving 3 blocks to sync in a second
+	clk.Add(time.Second)
+	for _, blk := range blks[:3] {
+		require.NoError(bs.ProcessBlockSync(peerCtx, blk))
+	}
+	status = bs.Status()
+	require.Equal(Syncing, status.State)
+	require.Equal(uint64(3), status.TipHeight)
+	require.Equal(float64(3), status.BlocksPerSecond)
+	require.Equal(time.Second, status.ETA)
+
+	// no progress within the stall window
+	clk.Add(30 * time.Second)
+	status = bs.Status()
+	require.Equal(SyncStalled, status.State)
+	require.True(status.BlocksPerSecond < 0.1)
+
+	// the chunk in flight is requested from the other peer
+	worker.Sync()
+	require.Equal(2, len(requests))
+	require.NotEqual(requests[0].peer, requests[1].peer)
+	require.Equal(uint64(4), requests[1].sync.Start)
+	// and the peers are re-selected once per stall window
+	clk.Add(time.Second)
+	worker.Sync()
+	require.Equal(2, len(requests))
+	require.Equal(SyncStalled, bs.Status().State)
+
+	// back to syncing on progress, and idle once caught up
+	require.NoError(bs.ProcessBlockSync(peerCtx, blks[3]))
+	require.Equal(Syncing, bs.Status().State)
+	require.NoError(bs.ProcessBlockSync(peerCtx, blks[4]))
+	status = bs.Status()
+	require.Equal(SyncIdle, status.State)
+	require.Equal(uint64(6), status.TipHeight)
+	require.Equal(time.Duration(0), status.ETA)
+}
+
+func TestBlockSyncerParallelFetch(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	cfg, err := newTestConfig()
+	require.NoError(err)
+	source := newTestChain(require, cfg)
+	defer func() {
+		require.NoError(source.Stop(ctx))
+	}()
+	for i := 0; i < 48; i++ {
+		blk, err := source.MintNewBlock(nil, testutil.TimestampNow())
+		require.NoError(err)
+		require.NoError(source.CommitBlock(blk))
+	}
+	tip, err := source.GetBlockByHeight(source.TipHeight())
+	require.NoError(err)
+
+	peers := make([]peerstore.PeerInfo, 4)
+	for i := range peers {
+		peers[i] = peerstore.PeerInfo{ID: peer.ID(fmt.Sprintf("peer%d", i))}
+	}
+	// syncPeak returns the peak number of requests in flight, and whether a peer is ever asked for a chunk while it's
+	// still serving another
+	syncPeak := func(parallelism int) (int, bool) {
+		cfg.BlockSync.Interval = 10 * time.Millisecond
+		cfg.BlockSync.IntervalSize = 4
+		cfg.BlockSync.Parallelism = parallelism
+		chain := newTestChain(require, cfg)
+		defer func() {
+			require.NoError(chain.Stop(ctx))
+		}()
+		ap, err := actpool.NewActPool(chain, cfg.ActPool, actpool.EnableExperimentalActions())
+		require.NoError(err)
+		cs := mock_consensus.NewMockConsensus(ctrl)
+		cs.EXPECT().ValidateBlockFooter(gomock.Any()).Return(nil).AnyTimes()
+		cs.EXPECT().Calibrate(gomock.Any()).AnyTimes()
+
+		// every peer serves the blocks after a round trip of 100ms
+		var (
+			mutex    sync.Mutex
+			inFlight = make(map[peer.ID]int)
+			current  int
+			peak     int
+			shared   bool
+		)
+		var bs BlockSync
+		bs, err = NewBlockSyncer(cfg, chain, ap, cs,
+			WithUnicastOutBound(func(_ context.Context, p peerstore.PeerInfo, msg proto.Message) error {
+				req := msg.(*iotexrpc.BlockSync)
+				mutex.Lock()
+				if inFlight[p.ID] > 0 {
+					shared = true
+				}
+				inFlight[p.ID]++
+				current++
+				if current > peak {
+					peak = current
+				}
+				mutex.Unlock()
+				time.AfterFunc(100*time.Millisecond, func() {
+					mutex.Lock()
+					inFlight[p.ID]--
+					current--
+					mutex.Unlock()
+					for h := req.Start; h <= req.End; h++ {
+						blk, err := source.GetBlockByHeight(h)
+						if err != nil {
+							return
+						}
+						bs.ProcessBlock(ctx, blk)
+					}
+				})
+				return nil
+			}),
+			WithNeighbors(func(_ context.Context) ([]peerstore.PeerInfo, error) { return peers, nil }),
+		)
+		require.NoError(err)
+		require.NoError(bs.Start(ctx))
+		defer func() {
+			require.NoError(bs.Stop(ctx))
+		}()
+
+		require.NoError(bs.ProcessBlock(ctx, tip))
+		require.NoError(testutil.WaitUntil(10*time.Millisecond, 10*time.Second, func() (bool, error) {
+			return chain.TipHeight() == tip.Height(), nil
+		}))
+		targetHeight, committedHeight, _ := bs.Progress()
+		require.Equal(tip.Height(), targetHeight)
+		require.Equal(tip.Height(), committedHeight)
+		mutex.Lock()
+		defer mutex.Unlock()
+		return peak, shared
+	}
+
+	peak, shared := syncPeak(1)
+	require.Equal(1, peak)
+	require.False(shared)
+	peak, shared = syncPeak(4)
+	require.Equal(4, peak)
+	require.False(shared)
+}
+
+func TestBlockSyncerChunkTimeout(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	cfg, err := newTestConfig()
+	require.NoError(err)
+	cfg.BlockSync.Interval = 10 * time.Millisecond
+	cfg.BlockSync.IntervalSize = 4
+	cfg.BlockSync.Parallelism = 1
+	cfg.BlockSync.ChunkTimeout = 100 * time.Millisecond
+	source := newTestChain(require, cfg)
+	chain := newTestChain(require, cfg)
+	defer func() {
+		require.NoError(source.Stop(ctx))
+		require.NoError(chain.Stop(ctx))
+	}()
+	for i := 0; i < 8; i++ {
+		blk, err := source.MintNewBlock(nil, testutil.TimestampNow())
+		require.NoError(err)
+		require.NoError(source.CommitBlock(blk))
+	}
+	tip, err := source.GetBlockByHeight(source.TipHeight())
+	require.NoError(err)
+	ap, err := actpool.NewActPool(chain, cfg.ActPool, actpool.EnableExperimentalActions())
+	require.NoError(err)
+	cs := mock_consensus.NewMockConsensus(ctrl)
+	cs.EXPECT().ValidateBlockFooter(gomock.Any()).Return(nil).AnyTimes()
+	cs.EXPECT().Calibrate(gomock.Any()).AnyTimes()
+
+	// peer0 never responds, and peer1 serves the blocks right away
+	peers := []peerstore.PeerInfo{{ID: peer.ID("peer0")}, {ID: peer.ID("peer1")}}
+	var (
+		mu       sync.Mutex
+		requests = make(map[peer.ID]int)
+		bs       BlockSync
+	)
+	bs, err = NewBlockSyncer(cfg, chain, ap, cs,
+		WithUnicastOutBound(func(_ context.Context, p peerstore.PeerInfo, msg proto.Message) error {
+			mu.Lock()
+			requests[p.ID]++
+			mu.Unlock()
+			if p.ID == peers[0].ID {
+				return nil
+			}
+			req := msg.(*iotexrpc.BlockSync)
+			go func() {
+				for h := req.Start; h <= req.End; h++ {
+					blk, err := source.GetBlockByHeight(h)
+					if err != nil {
+						return
+					}
+					bs.ProcessBlock(ctx, blk)
+				}
+			}()
+			return nil
+		}),
+		WithNeighbors(func(_ context.Context) ([]peerstore.PeerInfo, error) { return peers, nil }),
+	)
+	require.NoError(err)
+	// peer0 is preferred at first
+	worker := bs.(*blockSyncer).worker
+	worker.scores[peers[1].ID.Pretty()] = -1
+	require.NoError(bs.Start(ctx))
+	defer func() {
+		require.NoError(bs.Stop(ctx))
+	}()
+
+	require.NoError(bs.ProcessBlock(ctx, tip))
+	require.NoError(testutil.WaitUntil(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		return chain.TipHeight() == tip.Height(), nil
+	}))
+	require.NoError(testutil.WaitUntil(10*time.Millisecond, time.Second, func() (bool, error) {
+		_, _, inFlight := bs.Progress()
+		return inFlight == 0, nil
+	}))
+
+	// the chunk timed out is requested again from peer1, and peer0 is penalized
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(1, requests[peers[0].ID])
+	require.True(requests[peers[1].ID] >= 2)
+	worker.mu.RLock()
+	defer worker.mu.RUnlock()
+	require.Equal(-timeoutPenalty, worker.scores[peers[0].ID.Pretty()])
+	require.Equal(0, worker.scores[peers[1].ID.Pretty()])
+}
+
+func TestBlockSyncerServeBatch(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	cfg, err := newTestConfig()
+	require.NoError(err)
+	source := newTestChain(require, cfg)
+	defer func() {
+		require.NoError(source.Stop(ctx))
+	}()
+	for i := 0; i < 6; i++ {
+		blk, err := source.MintNewBlock(nil, testutil.TimestampNow())
+		require.NoError(err)
+		require.NoError(source.CommitBlock(blk))
+	}
+	blockSize := func(h uint64) uint64 {
+		blk, err := source.GetBlockByHeight(h)
+		require.NoError(err)
+		return uint64(proto.Size(blk.ConvertToBlockPb()))
+	}
+
+	// the blocks below height 3 have been pruned
+	pruned := uint64(0)
+	chain := mock_blockchain.NewMockBlockchain(ctrl)
+	chain.EXPECT().ChainID().Return(uint32(1)).AnyTimes()
+	chain.EXPECT().TipHeight().Return(uint64(6)).AnyTimes()
+	chain.EXPECT().GetBlockByHeight(gomock.Any()).DoAndReturn(func(h uint64) (*block.Block, error) {
+		if h <= pruned {
+			return nil, errors.Wrap(db.ErrNotExist, "failed to get block hash")
+		}
+		return source.GetBlockByHeight(h)
+	}).AnyTimes()
+	chain.EXPECT().BlockHeaderByHeight(gomock.Any()).DoAndReturn(source.BlockHeaderByHeight).AnyTimes()
+	ap, err := actpool.NewActPool(chain, cfg.ActPool, actpool.EnableExperimentalActions())
+	require.NoError(err)
+	cs := mock_consensus.NewMockConsensus(ctrl)
+	var batches []*iotexrpc.BlockBatch
+	bs, err := NewBlockSyncer(cfg, chain, ap, cs,
+		WithUnicastOutBound(func(_ context.Context, _ peerstore.PeerInfo, msg proto.Message) error {
+			batches = append(batches, msg.(*iotexrpc.BlockBatch))
+			return nil
+		}),
+		WithNeighbors(func(_ context.Context) ([]peerstore.PeerInfo, error) { return nil, nil }),
+	)
+	require.NoError(err)
+	heights := func(batch *iotexrpc.BlockBatch) []uint64 {
+		var heights []uint64
+		for _, pb := range batch.Blocks {
+			heights = append(heights, pb.Header.Core.Height)
+		}
+		for _, pb := range batch.Headers {
+			heights = append(heights, pb.Core.Height)
+		}
+		return heights
+	}
+
+	// a full batch up to the tip
+	require.NoError(bs.ProcessSyncRequest(ctx, peerstore.PeerInfo{}, &iotexrpc.BlockSync{
+		Start: 1, End: 10, MaxBytes: 1 << 20,
+	}))
+	require.Equal(1, len(batches))
+	require.Equal(uint64(1), batches[0].Start)
+	require.Equal(uint64(6), batches[0].End)
+	require.Equal([]uint64{1, 2, 3, 4, 5, 6}, heights(batches[0]))
+
+	// a batch truncated by the cap, which serves the first block anyway
+	require.NoError(bs.ProcessSyncRequest(ctx, peerstore.PeerInfo{}, &iotexrpc.BlockSync{
+		Start: 2, End: 6, MaxBytes: blockSize(2) + blockSize(3) + blockSize(4) - 1,
+	}))
+	require.NoError(bs.ProcessSyncRequest(ctx, peerstore.PeerInfo{}, &iotexrpc.BlockSync{
+		Start: 2, End: 6, MaxBytes: 1,
+	}))
+	require.Equal(3, len(batches))
+	require.Equal(uint64(2), batches[1].Start)
+	require.Equal(uint64(3), batches[1].End)
+	require.Equal([]uint64{2, 3}, heights(batches[1]))
+	require.Equal([]uint64{2}, heights(batches[2]))
+
+	// the headers only
+	require.NoError(bs.ProcessSyncRequest(ctx, peerstore.PeerInfo{}, &iotexrpc.BlockSync{
+		Start: 5, End: 6, HeadersOnly: true,
+	}))
+	require.Equal(4, len(batches))
+	require.Empty(batches[3].Blocks)
+	require.Equal([]uint64{5, 6}, heights(batches[3]))
+
+	// the sub-range above the pruned blocks, or nothing if all the blocks requested are pruned
+	pruned = 2
+	require.NoError(bs.ProcessSyncRequest(ctx, peerstore.PeerInfo{}, &iotexrpc.BlockSync{
+		Start: 1, End: 4, MaxBytes: 1 << 20,
+	}))
+	require.NoError(bs.ProcessSyncRequest(ctx, peerstore.PeerInfo{}, &iotexrpc.BlockSync{
+		Start: 1, End: 2, MaxBytes: 1 << 20,
+	}))
+	require.Equal(6, len(batches))
+	require.Equal(uint64(3), batches[4].Start)
+	require.Equal(uint64(4), batches[4].End)
+	require.Equal([]uint64{3, 4}, heights(batches[4]))
+	require.Empty(heights(batches[5]))
+}
+
+func TestBlockSyncerProcessBlockBatch(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	cfg, err := newTestConfig()
+	require.NoError(err)
+	// the worker is driven by hand
+	cfg.BlockSync.Interval = 0
+	cfg.BlockSync.Parallelism = 1
+	source := newTestChain(require, cfg)
+	defer func() {
+		require.NoError(source.Stop(ctx))
+	}()
+	for i := 0; i < 6; i++ {
+		blk, err := source.MintNewBlock(nil, testutil.TimestampNow())
+		require.NoError(err)
+		require.NoError(source.CommitBlock(blk))
+	}
+	tip, err := source.GetBlockByHeight(source.TipHeight())
+	require.NoError(err)
+	blk1, err := source.GetBlockByHeight(1)
+	require.NoError(err)
+	// a batch takes up to 4 blocks
+	cfg.BlockSync.MaxBatchBytes = 4 * unit.ByteSize(proto.Size(blk1.ConvertToBlockPb()))
+	chain := newTestChain(require, cfg)
+	defer func() {
+		require.NoError(chain.Stop(ctx))
+	}()
+	ap, err := actpool.NewActPool(chain, cfg.ActPool, actpool.EnableExperimentalActions())
+	require.NoError(err)
+	cs := mock_consensus.NewMockConsensus(ctrl)
+	cs.EXPECT().ValidateBlockFooter(gomock.Any()).Return(nil).AnyTimes()
+	cs.EXPECT().Calibrate(gomock.Any()).AnyTimes()
+
+	// the peer takes the requests without responding, and the test delivers the batches
+	p := peerstore.PeerInfo{ID: peer.ID("peer0")}
+	var requests []*iotexrpc.BlockSync
+	bs, err := NewBlockSyncer(cfg, chain, ap, cs,
+		WithUnicastOutBound(func(_ context.Context, _ peerstore.PeerInfo, msg proto.Message) error {
+			requests = append(requests, msg.(*iotexrpc.BlockSync))
+			return nil
+		}),
+		WithNeighbors(func(_ context.Context) ([]peerstore.PeerInfo, error) {
+			return []peerstore.PeerInfo{p}, nil
+		}),
+	)
+	require.NoError(err)
+	worker := bs.(*blockSyncer).worker
+	score := func() int {
+		worker.mu.RLock()
+		defer worker.mu.RUnlock()
+		return worker.scores[p.ID.Pretty()]
+	}
+	worker.SetTargetHeight(tip.Height())
+	worker.Sync()
+	require.Equal(1, len(requests))
+	require.Equal(uint64(1), requests[0].Start)
+	require.Equal(uint64(cfg.BlockSync.MaxBatchBytes), requests[0].MaxBytes)
+	serve := func(start, end uint64, maxBytes uint64) *iotexrpc.BlockBatch {
+		batch, err := newBlockBatch(source, start, end, false, maxBytes)
+		require.NoError(err)
+		return batch
+	}
+
+	// the batches out of the range requested, or over the cap, are rejected
+	batch := serve(1, 3, 0)
+	batch.End = requests[0].End + 1
+	require.Equal(ErrInvalidBatch, errors.Cause(bs.ProcessBlockBatch(ctx, p, batch)))
+	batch = serve(2, 3, 0)
+	batch.Start = 1
+	batch.End = 2
+	require.Equal(ErrInvalidBatch, errors.Cause(bs.ProcessBlockBatch(ctx, p, batch)))
+	require.Equal(ErrInvalidBatch, errors.Cause(bs.ProcessBlockBatch(ctx, p, serve(1, 6, 0))))
+	require.Equal(-3*invalidBlockPenalty, score())
+	require.Equal(uint64(0), chain.TipHeight())
+	_, _, inFlight := bs.Progress()
+	require.Equal(1, inFlight)
+
+	// a batch truncated by the cap retires the request, and the rest of the blocks are requested again
+	require.NoError(bs.ProcessBlockBatch(ctx, p, serve(1, requests[0].End, requests[0].MaxBytes)))
+	require.Equal(uint64(4), chain.TipHeight())
+	require.Equal(-3*invalidBlockPenalty+1, score())
+	_, _, inFlight = bs.Progress()
+	require.Equal(0, inFlight)
+	worker.Sync()
+	require.Equal(2, len(requests))
+	require.Equal(uint64(5), requests[1].Start)
+
+	// a batch not serving the beginning of the request is taken, with the peer penalized
+	require.NoError(bs.ProcessBlockBatch(ctx, p, serve(6, requests[1].End, requests[1].MaxBytes)))
+	require.Equal(uint64(4), chain.TipHeight())
+	require.Equal(-3*invalidBlockPenalty+1-timeoutPenalty, score())
+	worker.Sync()
+	require.Equal(3, len(requests))
+	require.Equal(uint64(5), requests[2].Start)
+	require.NoError(bs.ProcessBlockBatch(ctx, p, serve(5, requests[2].End, requests[2].MaxBytes)))
+	require.Equal(tip.Height(), chain.TipHeight())
+
+	// a batch not requested is dropped
+	require.Error(bs.ProcessBlockBatch(ctx, p, serve(1, 2, 0)))
+}
+
+func newTestChain(require *require.Assertions, cfg config.Config) bc.Blockchain {
+	registry := protocol.Registry{}
+	rp := rolldpos.NewProtocol(cfg.Genesis.NumCandidateDelegates, cfg.Genesis.NumDelegates, cfg.Genesis.NumSubEpochs)
+	require.NoError(registry.Register(rolldpos.ProtocolID, rp))
+	chain := bc.NewBlockchain(cfg, bc.InMemStateFactoryOption(), bc.InMemDaoOption(), bc.RegistryOption(&registry))
+	vp := vote.NewProtocol(chain)
+	require.NoError(registry.Register(vote.ProtocolID, vp))
+	chain.Validator().AddActionEnvelopeValidators(protocol.NewGenericValidator(chain, genesis.Default.ActionGasLimit))
+	chain.Validator().AddActionValidators(account.NewProtocol())
+	require.NoError(chain.Start(context.Background()))
+	return chain
+}
+
 func newTestConfig() (config.Config, error) {
 	testTrieFile, err := ioutil.TempFile(os.TempDir(), "trie")
 	if err != nil {
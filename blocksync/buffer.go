@@ -39,6 +39,8 @@ type blockBuffer struct {
 	bufferSize   uint64
 	intervalSize uint64
 	commitHeight uint64 // last commit block height
+	// invalidHeights are the heights of the blocks failed to commit, whose senders are to be penalized
+	invalidHeights []uint64
 }
 
 // CommitHeight return the last commit block height
@@ -79,6 +81,7 @@ func (b *blockBuffer) Flush(blk *block.Block) (bool, bCheckinResult) {
 		delete(b.blocks, heightToSync)
 		if err := commitBlock(b.bc, b.ap, b.cs, blk); err != nil && errors.Cause(err) != blockchain.ErrInvalidTipHeight {
 			l.Error("Failed to commit the block.", zap.Error(err), zap.Uint64("syncHeight", heightToSync))
+			b.invalidHeights = append(b.invalidHeights, heightToSync)
 			break
 		}
 		b.commitHeight = heightToSync
@@ -152,6 +155,33 @@ func (b *blockBuffer) GetBlocksIntervalsToSync(targetHeight uint64) []syncBlocks
 	return bi
 }
 
+// filled returns true if all the blocks of the interval are either committed or in the buffer
+func (b *blockBuffer) filled(interval syncBlocksInterval) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	confirmedHeight := b.bc.TipHeight()
+	for h := interval.Start; h <= interval.End; h++ {
+		if h <= confirmedHeight {
+			continue
+		}
+		if _, ok := b.blocks[h]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// takeInvalidHeights returns the heights of the blocks failed to commit since the last call
+func (b *blockBuffer) takeInvalidHeights() []uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	heights := b.invalidHeights
+	b.invalidHeights = nil
+	return heights
+}
+
 // bufSize return the bufferSize of buffer
 func (b *blockBuffer) bufSize() uint64 {
 	return b.bufferSize
@@ -0,0 +1,43 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blocksync_test
+
+// the mock refers to the types of blocksync, so it's tested out of the package to avoid the import cycle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/iotexproject/iotex-core/test/mock/mock_blocksync"
+)
+
+func TestBlockSyncerStart(t *testing.T) {
+	assert := assert.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mBs := mock_blocksync.NewMockBlockSync(ctrl)
+	mBs.EXPECT().Start(gomock.Any()).Times(1)
+	assert.Nil(mBs.Start(ctx))
+}
+
+func TestBlockSyncerStop(t *testing.T) {
+	assert := assert.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mBs := mock_blocksync.NewMockBlockSync(ctrl)
+	mBs.EXPECT().Stop(gomock.Any()).Times(1)
+	assert.Nil(mBs.Stop(ctx))
+}
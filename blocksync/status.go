@@ -0,0 +1,167 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package blocksync
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/facebookgo/clock"
+	peerstore "github.com/libp2p/go-libp2p-peerstore"
+)
+
+// SyncState is the state of the block sync
+type SyncState string
+
+const (
+	// SyncIdle means the node has caught up with its peers
+	SyncIdle SyncState = "idle"
+	// Syncing means the node is catching up with its peers
+	Syncing SyncState = "syncing"
+	// SyncStalled means the node is behind its peers, but hasn't committed a block within the stall window
+	SyncStalled SyncState = "stalled"
+)
+
+// speedWindow is the window over which the sync speed is measured
+const speedWindow = time.Minute
+
+// Status is the status of the block sync
+type Status struct {
+	State     SyncState
+	TipHeight uint64
+	// PeerHeight is the highest block height heard of the peers, and Peer is the one which sent the block, which is
+	// empty if the block was broadcast
+	PeerHeight uint64
+	Peer       string
+	// BlocksPerSecond is the number of blocks committed per second over the last minute
+	BlocksPerSecond float64
+	// ETA is the rough time to catch up with the peers at the current speed, which is 0 if unknown
+	ETA time.Duration
+}
+
+type peerCtxKey struct{}
+
+// WithPeer attaches the peer sending a block to the context
+func WithPeer(ctx context.Context, peer peerstore.PeerInfo) context.Context {
+	return context.WithValue(ctx, peerCtxKey{}, peer)
+}
+
+func peerFromContext(ctx context.Context) (peerstore.PeerInfo, bool) {
+	peer, ok := ctx.Value(peerCtxKey{}).(peerstore.PeerInfo)
+	return peer, ok
+}
+
+type heightSample struct {
+	time   time.Time
+	height uint64
+}
+
+// syncStatus tracks the tip height against the highest block height heard of the peers
+type syncStatus struct {
+	mutex        sync.Mutex
+	clock        clock.Clock
+	stallWindow  time.Duration
+	peerHeight   uint64
+	peer         string
+	syncing      bool
+	samples      []heightSample
+	lastProgress time.Time
+	lastReselect time.Time
+}
+
+func newSyncStatus(stallWindow time.Duration, clk clock.Clock) *syncStatus {
+	return &syncStatus{
+		clock:       clk,
+		stallWindow: stallWindow,
+	}
+}
+
+// update takes the tip height and the height of a block received from the given peer, and returns whether the node
+// is behind the peers, and whether it has just fallen behind or caught up
+func (s *syncStatus) update(tipHeight uint64, blkHeight uint64, peer string) (bool, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if blkHeight > s.peerHeight {
+		s.peerHeight = blkHeight
+		s.peer = peer
+	}
+	syncing := s.syncing
+	s.observe(tipHeight)
+	return s.syncing, s.syncing != syncing
+}
+
+// status returns the status at the tip height
+func (s *syncStatus) status(tipHeight uint64) Status {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.observe(tipHeight)
+	now := s.clock.Now()
+	status := Status{
+		State:      SyncIdle,
+		TipHeight:  tipHeight,
+		PeerHeight: s.peerHeight,
+		Peer:       s.peer,
+	}
+	if first := s.samples[0]; now.After(first.time) {
+		status.BlocksPerSecond = float64(tipHeight-first.height) / now.Sub(first.time).Seconds()
+	}
+	if !s.syncing {
+		return status
+	}
+	status.State = Syncing
+	if s.isStalled(now) {
+		status.State = SyncStalled
+	}
+	if status.BlocksPerSecond > 0 {
+		status.ETA = time.Duration(float64(s.peerHeight-tipHeight) / status.BlocksPerSecond * float64(time.Second))
+	}
+	return status
+}
+
+// reselect returns true if the sync has stalled and the peers are to be re-selected, which happens once per stall
+// window until the sync progresses
+func (s *syncStatus) reselect(tipHeight uint64) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.observe(tipHeight)
+	now := s.clock.Now()
+	if !s.syncing || !s.isStalled(now) || now.Sub(s.lastReselect) < s.stallWindow {
+		return false
+	}
+	s.lastReselect = now
+	return true
+}
+
+func (s *syncStatus) isStalled(now time.Time) bool {
+	return s.stallWindow > 0 && now.Sub(s.lastProgress) >= s.stallWindow
+}
+
+// observe records the tip height, and updates whether the node is behind the peers
+func (s *syncStatus) observe(tipHeight uint64) {
+	now := s.clock.Now()
+	if n := len(s.samples); n == 0 || tipHeight > s.samples[n-1].height {
+		s.samples = append(s.samples, heightSample{time: now, height: tipHeight})
+		s.lastProgress = now
+	}
+	i := 0
+	for i < len(s.samples)-1 && now.Sub(s.samples[i].time) > speedWindow {
+		i++
+	}
+	s.samples = s.samples[i:]
+
+	syncing := tipHeight < s.peerHeight
+	if syncing && !s.syncing {
+		// the stall window starts over on falling behind
+		s.lastProgress = now
+		s.lastReselect = time.Time{}
+	}
+	s.syncing = syncing
+}
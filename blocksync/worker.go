@@ -10,20 +10,38 @@ import (
 	"context"
 	"math/rand"
 	"sync"
+	"time"
 
+	peerstore "github.com/libp2p/go-libp2p-peerstore"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/routine"
 	"github.com/iotexproject/iotex-core/protogen/iotexrpc"
 )
 
+const (
+	// timeoutPenalty is the score a peer loses when it doesn't serve a chunk in time
+	timeoutPenalty = 2
+	// invalidBlockPenalty is the score a peer loses when it serves a block failing to commit
+	invalidBlockPenalty = 10
+)
+
 type syncBlocksInterval struct {
 	Start uint64
 	End   uint64
 }
 
+// chunk is an interval of blocks requested from a peer
+type chunk struct {
+	interval syncBlocksInterval
+	peer     peerstore.PeerInfo
+	deadline time.Time
+}
+
 type syncWorker struct {
 	chainID          uint32
 	mu               sync.RWMutex
@@ -32,8 +50,16 @@ type syncWorker struct {
 	neighborsHandler Neighbors
 	buf              *blockBuffer
 	task             *routine.RecurringTask
-	maxRepeat        int
-	repeatDecayStep  int
+	parallelism      int
+	chunkTimeout     time.Duration
+	// maxBatchBytes is the cap of the block batch asked for, and the blocks are asked for one per message if it's 0
+	maxBatchBytes uint64
+	inFlight      []*chunk
+	// requestedFrom is the peer last asked for each block not committed yet
+	requestedFrom map[uint64]string
+	// scores are the peers' scores, which are lowered when the peers fail to serve the blocks
+	scores map[string]int
+	status *syncStatus
 }
 
 func newSyncWorker(
@@ -42,6 +68,7 @@ func newSyncWorker(
 	unicastHandler UnicastOutbound,
 	neighborsHandler Neighbors,
 	buf *blockBuffer,
+	status *syncStatus,
 ) *syncWorker {
 	w := &syncWorker{
 		chainID:          chainID,
@@ -49,8 +76,15 @@ func newSyncWorker(
 		neighborsHandler: neighborsHandler,
 		buf:              buf,
 		targetHeight:     0,
-		maxRepeat:        cfg.BlockSync.MaxRepeat,
-		repeatDecayStep:  cfg.BlockSync.RepeatDecayStep,
+		parallelism:      cfg.BlockSync.Parallelism,
+		chunkTimeout:     cfg.BlockSync.ChunkTimeout,
+		maxBatchBytes:    uint64(cfg.BlockSync.MaxBatchBytes),
+		requestedFrom:    make(map[uint64]string),
+		scores:           make(map[string]int),
+		status:           status,
+	}
+	if w.parallelism <= 0 {
+		w.parallelism = 1
 	}
 	if cfg.BlockSync.Interval != 0 {
 		w.task = routine.NewRecurringTask(w.Sync, cfg.BlockSync.Interval)
@@ -80,7 +114,8 @@ func (w *syncWorker) SetTargetHeight(h uint64) {
 	}
 }
 
-// Sync checks the sliding window and send more sync request if needed
+// Sync checks the sliding window and send more sync request if needed. The missing blocks are requested by chunks
+// from different peers in parallel, and a chunk is requested again from another peer if it's not served in time.
 func (w *syncWorker) Sync() {
 	w.mu.Lock()
 	defer w.mu.Unlock()
@@ -95,26 +130,174 @@ func (w *syncWorker) Sync() {
 		log.L().Warn("Error when get neighbor peers.", zap.Error(err))
 		return
 	}
+	w.checkChunks(time.Now())
+	if w.status.reselect(w.buf.bc.TipHeight()) {
+		w.reselectPeers()
+	}
 	intervals := w.buf.GetBlocksIntervalsToSync(w.targetHeight)
 	if intervals != nil {
 		log.L().Info("block sync intervals.",
 			zap.Any("intervals", intervals),
-			zap.Uint64("targetHeight", w.targetHeight))
+			zap.Uint64("targetHeight", w.targetHeight),
+			zap.Int("inFlightChunks", len(w.inFlight)))
+	}
+
+	for _, interval := range intervals {
+		if len(w.inFlight) >= w.parallelism {
+			break
+		}
+		if w.isInFlight(interval) {
+			continue
+		}
+		p := w.pickPeer(peers)
+		if err := w.unicastHandler(ctx, p, &iotexrpc.BlockSync{
+			Start: interval.Start, End: interval.End, MaxBytes: w.maxBatchBytes,
+		}); err != nil {
+			log.L().Debug("Failed to sync block.", zap.Error(err))
+			continue
+		}
+		w.inFlight = append(w.inFlight, &chunk{
+			interval: interval,
+			peer:     p,
+			deadline: time.Now().Add(w.chunkTimeout),
+		})
+		for h := interval.Start; h <= interval.End; h++ {
+			w.requestedFrom[h] = p.ID.Pretty()
+		}
 	}
+}
 
-	for i, interval := range intervals {
-		repeat := w.maxRepeat - i/w.repeatDecayStep
-		if repeat <= 0 {
-			repeat = 1
+// checkChunks retires the chunks served, and penalizes the peers which failed to serve the chunks in time or served
+// the invalid blocks, whose missing blocks are then requested again
+func (w *syncWorker) checkChunks(now time.Time) {
+	for _, h := range w.buf.takeInvalidHeights() {
+		if id, ok := w.requestedFrom[h]; ok {
+			w.penalize(id, invalidBlockPenalty, h)
+		}
+	}
+	inFlight := w.inFlight[:0]
+	for _, c := range w.inFlight {
+		// the blocks beyond the target height may not exist yet
+		interval := c.interval
+		if interval.End > w.targetHeight {
+			interval.End = w.targetHeight
 		}
-		for j := 0; j < repeat; j++ {
-			rrIdx := rand.Intn(len(peers))
-			p := peers[rrIdx]
-			if err := w.unicastHandler(ctx, p, &iotexrpc.BlockSync{
-				Start: interval.Start, End: interval.End,
-			}); err != nil {
-				log.L().Debug("Failed to sync block.", zap.Error(err))
+		id := c.peer.ID.Pretty()
+		switch {
+		case w.buf.filled(interval):
+			if w.scores[id] < 0 {
+				w.scores[id]++
 			}
+		case now.After(c.deadline):
+			w.penalize(id, timeoutPenalty, c.interval.Start)
+		default:
+			inFlight = append(inFlight, c)
+		}
+	}
+	for i := len(inFlight); i < len(w.inFlight); i++ {
+		w.inFlight[i] = nil
+	}
+	w.inFlight = inFlight
+
+	tipHeight := w.buf.bc.TipHeight()
+	for h := range w.requestedFrom {
+		if h <= tipHeight {
+			delete(w.requestedFrom, h)
 		}
 	}
 }
+
+// acceptBatch takes the batch a peer serves for its chunk in flight, and retires the chunk, so that the blocks not
+// served, if any, are requested again. The peer is penalized if the batch is not what was requested, or if it doesn't
+// serve the beginning of the chunk, so that the others are preferred for it. An empty batch, or one not for any chunk
+// in flight, e.g., served after the chunk timed out, is dropped and left to the chunk timeout.
+func (w *syncWorker) acceptBatch(peer peerstore.PeerInfo, batch *iotexrpc.BlockBatch) ([]*block.Block, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(batch.Blocks)+len(batch.Headers) == 0 {
+		return nil, nil
+	}
+	idx := -1
+	for i, c := range w.inFlight {
+		if c.peer.ID == peer.ID && c.interval.Start <= batch.Start && batch.Start <= c.interval.End {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return nil, errors.Errorf("no block sync request in flight for the batch of blocks %d to %d", batch.Start, batch.End)
+	}
+	c := w.inFlight[idx]
+	id := peer.ID.Pretty()
+	blks, err := verifyBlockBatch(batch, c.interval.Start, c.interval.End, w.maxBatchBytes)
+	if err != nil {
+		w.penalize(id, invalidBlockPenalty, batch.Start)
+		return nil, err
+	}
+	w.inFlight = append(w.inFlight[:idx], w.inFlight[idx+1:]...)
+	switch {
+	case batch.Start > c.interval.Start:
+		w.penalize(id, timeoutPenalty, c.interval.Start)
+	case w.scores[id] < 0:
+		w.scores[id]++
+	}
+	return blks, nil
+}
+
+// reselectPeers gives up the chunks in flight once the sync stalls, penalizing their peers, so that the chunks are
+// requested again from the others
+func (w *syncWorker) reselectPeers() {
+	log.L().Warn("Block sync stalled, re-selecting the peers.", zap.Int("inFlightChunks", len(w.inFlight)))
+	for _, c := range w.inFlight {
+		w.penalize(c.peer.ID.Pretty(), timeoutPenalty, c.interval.Start)
+	}
+	w.inFlight = nil
+}
+
+func (w *syncWorker) penalize(id string, penalty int, height uint64) {
+	w.scores[id] -= penalty
+	log.L().Debug("Penalize the peer failing to serve the blocks.",
+		zap.String("peerID", id),
+		zap.Uint64("height", height),
+		zap.Int("score", w.scores[id]))
+}
+
+// isInFlight returns true if the interval overlaps a chunk in flight
+func (w *syncWorker) isInFlight(interval syncBlocksInterval) bool {
+	for _, c := range w.inFlight {
+		if interval.Start <= c.interval.End && c.interval.Start <= interval.End {
+			return true
+		}
+	}
+	return false
+}
+
+// pickPeer returns the peer of the highest score, and of the least chunks in flight among them. The ties are broken
+// randomly to spread the requests.
+func (w *syncWorker) pickPeer(peers []peerstore.PeerInfo) peerstore.PeerInfo {
+	load := make(map[string]int)
+	for _, c := range w.inFlight {
+		load[c.peer.ID.Pretty()]++
+	}
+	var (
+		best                peerstore.PeerInfo
+		bestScore, bestLoad int
+	)
+	for i, idx := range rand.Perm(len(peers)) {
+		p := peers[idx]
+		id := p.ID.Pretty()
+		score, l := w.scores[id], load[id]
+		if i == 0 || score > bestScore || (score == bestScore && l < bestLoad) {
+			best, bestScore, bestLoad = p, score, l
+		}
+	}
+	return best
+}
+
+// progress returns the target height and the number of chunks in flight
+func (w *syncWorker) progress() (uint64, int) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.targetHeight, len(w.inFlight)
+}
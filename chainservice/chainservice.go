@@ -9,6 +9,8 @@ package chainservice
 import (
 	"context"
 	"os"
+	"runtime/pprof"
+	"sync"
 
 	"github.com/golang/protobuf/proto"
 	peerstore "github.com/libp2p/go-libp2p-peerstore"
@@ -31,6 +33,7 @@ import (
 	"github.com/iotexproject/iotex-core/dispatcher"
 	"github.com/iotexproject/iotex-core/indexservice"
 	"github.com/iotexproject/iotex-core/p2p"
+	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/protogen/iotexrpc"
 	"github.com/iotexproject/iotex-core/protogen/iotextypes"
@@ -49,10 +52,18 @@ type ChainService struct {
 	indexBuilder *blockchain.IndexBuilder
 	indexservice *indexservice.Server
 	registry     *protocol.Registry
+
+	stopAPIOnce        sync.Once
+	stopProcessingOnce sync.Once
+	stopStorageOnce    sync.Once
 }
 
 type optionParams struct {
-	isTesting bool
+	isTesting    bool
+	delegateList *consensus.DelegateList
+	chain        blockchain.Blockchain
+	registry     *protocol.Registry
+	actPool      actpool.ActPool
 }
 
 // Option sets ChainService construction parameter.
@@ -66,10 +77,43 @@ func WithTesting() Option {
 	}
 }
 
-// New creates a ChainService from config and network.Overlay and dispatcher.Dispatcher.
+// WithDelegateList is an option to take the delegates of the roll-DPoS scheme from the list rather than the chain
+func WithDelegateList(l *consensus.DelegateList) Option {
+	return func(ops *optionParams) error {
+		ops.delegateList = l
+		return nil
+	}
+}
+
+// WithBlockchain is an option to run the chain given rather than the one built from config, e.g., a fake in tests.
+// The registry is the one the chain is built with, to which the protocols are registered, and a new one is created if
+// it's nil.
+func WithBlockchain(chain blockchain.Blockchain, registry *protocol.Registry) Option {
+	return func(ops *optionParams) error {
+		if chain == nil {
+			return errors.New("blockchain cannot be nil")
+		}
+		ops.chain = chain
+		ops.registry = registry
+		return nil
+	}
+}
+
+// WithActPool is an option to take the actions into the pool given rather than the one built from config
+func WithActPool(ap actpool.ActPool) Option {
+	return func(ops *optionParams) error {
+		if ap == nil {
+			return errors.New("actpool cannot be nil")
+		}
+		ops.actPool = ap
+		return nil
+	}
+}
+
+// New creates a ChainService from config and p2p.Overlay and dispatcher.Dispatcher.
 func New(
 	cfg config.Config,
-	p2pAgent *p2p.Agent,
+	p2pAgent p2p.Overlay,
 	dispatcher dispatcher.Dispatcher,
 	opts ...Option,
 ) (*ChainService, error) {
@@ -82,7 +126,7 @@ func New(
 	}
 
 	var chainOpts []blockchain.Option
-	if ops.isTesting {
+	if ops.isTesting || cfg.Chain.EnableInMemoryDB {
 		chainOpts = []blockchain.Option{
 			blockchain.InMemStateFactoryOption(),
 			blockchain.InMemDaoOption(),
@@ -93,8 +137,11 @@ func New(
 			blockchain.BoltDBDaoOption(),
 		}
 	}
-	registry := protocol.Registry{}
-	chainOpts = append(chainOpts, blockchain.RegistryOption(&registry))
+	registry := ops.registry
+	if registry == nil {
+		registry = &protocol.Registry{}
+	}
+	chainOpts = append(chainOpts, blockchain.RegistryOption(registry))
 	var electionCommittee committee.Committee
 	if cfg.Genesis.EnableGravityChainVoting {
 		committeeConfig := cfg.Chain.Committee
@@ -121,7 +168,10 @@ func New(
 		chainOpts = append(chainOpts, blockchain.EnableExperimentalActions())
 	}
 	// create Blockchain
-	chain := blockchain.NewBlockchain(cfg, chainOpts...)
+	chain := ops.chain
+	if chain == nil {
+		chain = blockchain.NewBlockchain(cfg, chainOpts...)
+	}
 	if chain == nil && cfg.Chain.EnableFallBackToFreshDB {
 		log.L().Warn("Chain db and trie db are falling back to fresh ones.")
 		if err := os.Rename(cfg.Chain.ChainDBPath, cfg.Chain.ChainDBPath+".old"); err != nil {
@@ -150,14 +200,21 @@ func New(
 		}
 	}
 
-	// Create ActPool
-	actOpts := make([]actpool.Option, 0)
-	if cfg.System.EnableExperimentalActions {
-		actOpts = append(actOpts, actpool.EnableExperimentalActions())
+	// the dispatcher remembers the actions committed, whose copies gossiped late are dropped
+	if err := chain.AddSubscriber(seenActionsMarker{dispatcher}); err != nil {
+		log.L().Warn("Failed to add subscriber: seen actions marker.", zap.Error(err))
 	}
-	actPool, err := actpool.NewActPool(chain, cfg.ActPool, actOpts...)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to create actpool")
+
+	// Create ActPool
+	actPool := ops.actPool
+	if actPool == nil {
+		actOpts := make([]actpool.Option, 0)
+		if cfg.System.EnableExperimentalActions {
+			actOpts = append(actOpts, actpool.EnableExperimentalActions())
+		}
+		if actPool, err = actpool.NewActPool(chain, cfg.ActPool, actOpts...); err != nil {
+			return nil, errors.Wrap(err, "failed to create actpool")
+		}
 	}
 	rDPoSProtocol := rolldpos.NewProtocol(
 		cfg.Genesis.NumCandidateDelegates,
@@ -170,22 +227,26 @@ func New(
 		}),
 		consensus.WithRollDPoSProtocol(rDPoSProtocol),
 	}
+	if ops.delegateList != nil {
+		copts = append(copts, consensus.WithCandidatesByHeight(ops.delegateList.CandidatesByHeight))
+	}
 	// TODO: explorer dependency deleted at #1085, need to revive by migrating to api
 	consensus, err := consensus.NewConsensus(cfg, chain, actPool, copts...)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create consensus")
 	}
-	bs, err := blocksync.NewBlockSyncer(
-		cfg,
-		chain,
-		actPool,
-		consensus,
+	bsOpts := []blocksync.Option{
 		blocksync.WithUnicastOutBound(func(ctx context.Context, peer peerstore.PeerInfo, msg proto.Message) error {
 			ctx = p2p.WitContext(ctx, p2p.Context{ChainID: chain.ChainID()})
 			return p2pAgent.UnicastOutbound(ctx, peer, msg)
 		}),
 		blocksync.WithNeighbors(p2pAgent.Neighbors),
-	)
+	}
+	// the standalone node is always synced
+	if cfg.Consensus.Scheme != config.StandaloneScheme {
+		bsOpts = append(bsOpts, blocksync.WithSyncStateHandler(actPool.SetSyncing))
+	}
+	bs, err := blocksync.NewBlockSyncer(cfg, chain, actPool, consensus, bsOpts...)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create blockSyncer")
 	}
@@ -206,7 +267,7 @@ func New(
 			dispatcher,
 			actPool,
 			idx,
-			&registry,
+			registry,
 			api.WithBroadcastOutbound(func(ctx context.Context, chainID uint32, msg proto.Message) error {
 				ctx = p2p.WitContext(ctx, p2p.Context{ChainID: chainID})
 				return p2pAgent.BroadcastOutbound(ctx, msg)
@@ -227,72 +288,126 @@ func New(
 		indexservice:      idx,
 		indexBuilder:      indexBuilder,
 		api:               apiSvr,
-		registry:          &registry,
+		registry:          registry,
 	}, nil
 }
 
-// Start starts the server
+// Start starts the server. The goroutines of each component are labeled with its name in the goroutine profiles.
 func (cs *ChainService) Start(ctx context.Context) error {
 	if cs.indexservice != nil {
-		if err := cs.indexservice.Start(ctx); err != nil {
+		if err := lifecycle.StartLabeled(ctx, "indexservice", cs.indexservice); err != nil {
 			return errors.Wrap(err, "error when starting indexservice")
 		}
 	}
 	if cs.electionCommittee != nil {
-		if err := cs.electionCommittee.Start(ctx); err != nil {
+		if err := lifecycle.StartLabeled(ctx, "electioncommittee", cs.electionCommittee); err != nil {
 			return errors.Wrap(err, "error when starting election committee")
 		}
 	}
-	if err := cs.chain.Start(ctx); err != nil {
+	if err := lifecycle.StartLabeled(ctx, "blockchain", cs.chain); err != nil {
 		return errors.Wrap(err, "error when starting blockchain")
 	}
-	if err := cs.consensus.Start(ctx); err != nil {
+	if err := lifecycle.StartLabeled(ctx, "actpool", cs.actpool); err != nil {
+		return errors.Wrap(err, "error when starting actpool")
+	}
+	if err := lifecycle.StartLabeled(ctx, "consensus", cs.consensus); err != nil {
 		return errors.Wrap(err, "error when starting consensus")
 	}
-	if err := cs.blocksync.Start(ctx); err != nil {
+	if err := lifecycle.StartLabeled(ctx, "blocksync", cs.blocksync); err != nil {
 		return errors.Wrap(err, "error when starting blocksync")
 	}
 	// TODO: explorer dependency deleted at #1085, need to revive by migrating to api
 	if cs.api != nil {
-		if err := cs.api.Start(); err != nil {
+		var err error
+		pprof.Do(ctx, pprof.Labels(lifecycle.ComponentLabel, "api"), func(context.Context) {
+			err = cs.api.Start()
+		})
+		if err != nil {
 			return errors.Wrap(err, "err when starting API server")
 		}
 	}
 	if cs.indexBuilder != nil {
-		if err := cs.indexBuilder.Start(ctx); err != nil {
+		if err := lifecycle.StartLabeled(ctx, "indexbuilder", cs.indexBuilder); err != nil {
 			return errors.Wrap(err, "error when starting index builder")
 		}
 	}
 	return nil
 }
 
-// Stop stops the server
+// Stop stops the server. It stops taking the API requests first, then the consensus and the block processing, and
+// closes the chain DBs last, so that no block in processing is cut off.
 func (cs *ChainService) Stop(ctx context.Context) error {
-	if cs.indexBuilder != nil {
-		if err := cs.indexBuilder.Stop(ctx); err != nil {
-			return errors.Wrap(err, "error when stopping index builder")
-		}
+	if err := cs.StopAPI(ctx); err != nil {
+		return err
 	}
-	// TODO: explorer dependency deleted at #1085, need to revive by migrating to api
-	if cs.api != nil {
-		if err := cs.api.Stop(); err != nil {
-			return errors.Wrap(err, "error when stopping API server")
-		}
+	if err := cs.StopProcessing(ctx); err != nil {
+		return err
 	}
-	if cs.indexservice != nil {
-		if err := cs.indexservice.Stop(ctx); err != nil {
-			return errors.Wrap(err, "error when stopping indexservice")
+	return cs.StopStorage(ctx)
+}
+
+// StopAPI stops taking the API requests. It is a no-op once called.
+func (cs *ChainService) StopAPI(ctx context.Context) (err error) {
+	cs.stopAPIOnce.Do(func() {
+		// TODO: explorer dependency deleted at #1085, need to revive by migrating to api
+		if cs.api != nil {
+			if err = cs.api.Stop(); err != nil {
+				err = errors.Wrap(err, "error when stopping API server")
+			}
 		}
-	}
-	if err := cs.consensus.Stop(ctx); err != nil {
-		return errors.Wrap(err, "error when stopping consensus")
-	}
-	if err := cs.blocksync.Stop(ctx); err != nil {
-		return errors.Wrap(err, "error when stopping blocksync")
-	}
-	if err := cs.chain.Stop(ctx); err != nil {
-		return errors.Wrap(err, "error when stopping blockchain")
-	}
+	})
+	return
+}
+
+// StopProcessing stops the consensus, which finishes the event in handling, e.g., committing the block of the round,
+// and abandons the rest of the round, then the block syncing, the sweep of the action pool and the indexing. It is a
+// no-op once called.
+func (cs *ChainService) StopProcessing(ctx context.Context) (err error) {
+	cs.stopProcessingOnce.Do(func() {
+		if err = cs.consensus.Stop(ctx); err != nil {
+			err = errors.Wrap(err, "error when stopping consensus")
+			return
+		}
+		if err = cs.blocksync.Stop(ctx); err != nil {
+			err = errors.Wrap(err, "error when stopping blocksync")
+			return
+		}
+		if err = cs.actpool.Stop(ctx); err != nil {
+			err = errors.Wrap(err, "error when stopping actpool")
+			return
+		}
+		if cs.indexBuilder != nil {
+			if err = cs.indexBuilder.Stop(ctx); err != nil {
+				err = errors.Wrap(err, "error when stopping index builder")
+				return
+			}
+		}
+		if cs.indexservice != nil {
+			if err = cs.indexservice.Stop(ctx); err != nil {
+				err = errors.Wrap(err, "error when stopping indexservice")
+			}
+		}
+	})
+	return
+}
+
+// StopStorage stops the blockchain and closes its DBs. It is a no-op once called.
+func (cs *ChainService) StopStorage(ctx context.Context) (err error) {
+	cs.stopStorageOnce.Do(func() {
+		if err = cs.chain.Stop(ctx); err != nil {
+			err = errors.Wrap(err, "error when stopping blockchain")
+		}
+	})
+	return
+}
+
+// seenActionsMarker marks the actions of the blocks committed seen by the dispatcher
+type seenActionsMarker struct {
+	dispatcher dispatcher.Dispatcher
+}
+
+func (m seenActionsMarker) HandleBlock(blk *block.Block) error {
+	m.dispatcher.MarkActionsSeen(blk)
 	return nil
 }
 
@@ -305,6 +420,26 @@ func (cs *ChainService) HandleAction(_ context.Context, actPb *iotextypes.Action
 	return cs.actpool.Add(act)
 }
 
+// HandleActions handles a batch of incoming actions, whose signatures are verified concurrently.
+func (cs *ChainService) HandleActions(_ context.Context, actPbs []*iotextypes.Action) []error {
+	errs := make([]error, len(actPbs))
+	acts := make([]action.SealedEnvelope, 0, len(actPbs))
+	indexes := make([]int, 0, len(actPbs))
+	for i, actPb := range actPbs {
+		var act action.SealedEnvelope
+		if err := act.LoadProto(actPb); err != nil {
+			errs[i] = err
+			continue
+		}
+		acts = append(acts, act)
+		indexes = append(indexes, i)
+	}
+	for i, err := range cs.actpool.AddActions(acts) {
+		errs[indexes[i]] = err
+	}
+	return errs
+}
+
 // HandleBlock handles incoming block request.
 func (cs *ChainService) HandleBlock(ctx context.Context, pbBlock *iotextypes.Block) error {
 	blk := &block.Block{}
@@ -328,6 +463,11 @@ func (cs *ChainService) HandleSyncRequest(ctx context.Context, peer peerstore.Pe
 	return cs.blocksync.ProcessSyncRequest(ctx, peer, sync)
 }
 
+// HandleBlockBatch handles incoming block batch served for a sync request.
+func (cs *ChainService) HandleBlockBatch(ctx context.Context, peer peerstore.PeerInfo, batch *iotexrpc.BlockBatch) error {
+	return cs.blocksync.ProcessBlockBatch(ctx, peer, batch)
+}
+
 // HandleConsensusMsg handles incoming consensus message.
 func (cs *ChainService) HandleConsensusMsg(msg *iotextypes.ConsensusMessage) error {
 	return cs.consensus.HandleConsensusMsg(msg)
@@ -371,6 +511,11 @@ func (cs *ChainService) IndexService() *indexservice.Server {
 	return cs.indexservice
 }
 
+// APIServer returns the API server, which is nil if the node doesn't serve the API
+func (cs *ChainService) APIServer() *api.Server {
+	return cs.api
+}
+
 // RegisterProtocol register a protocol
 func (cs *ChainService) RegisterProtocol(id string, p protocol.Protocol) error {
 	if err := cs.registry.Register(id, p); err != nil {
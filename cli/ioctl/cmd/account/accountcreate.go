@@ -14,11 +14,15 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-core/pkg/hdwallet"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/log"
 )
 
-var numAccounts uint
+var (
+	numAccounts  uint
+	withMnemonic bool
+)
 
 // accountCreateCmd represents the account create command
 var accountCreateCmd = &cobra.Command{
@@ -36,6 +40,7 @@ var accountCreateCmd = &cobra.Command{
 }
 
 type generatedAccounts struct {
+	Mnemonic string             `json:"mnemonic,omitempty"`
 	Accounts []generatedAccount `json:"accounts"`
 }
 
@@ -47,12 +52,27 @@ type generatedAccount struct {
 
 func init() {
 	accountCreateCmd.Flags().UintVarP(&numAccounts, "num", "n", 1, "number of accounts to create")
+	accountCreateCmd.Flags().BoolVar(&withMnemonic, "mnemonic", false,
+		"derive the accounts from a newly generated 24-word mnemonic")
 }
 
 func accountCreate() (string, error) {
+	var mnemonic string
+	if withMnemonic {
+		var err error
+		if mnemonic, err = hdwallet.NewMnemonic(); err != nil {
+			return "", err
+		}
+	}
 	newAccounts := make([]generatedAccount, 0)
 	for i := 0; i < int(numAccounts); i++ {
-		private, err := keypair.GenerateKey()
+		var private keypair.PrivateKey
+		var err error
+		if withMnemonic {
+			private, err = hdwallet.PrivateKeyFromMnemonic(mnemonic, "", uint32(i))
+		} else {
+			private, err = keypair.GenerateKey()
+		}
 		if err != nil {
 			return "", err
 		}
@@ -70,7 +90,7 @@ func accountCreate() (string, error) {
 	}
 	var output []byte
 	var err error
-	output, err = json.MarshalIndent(&generatedAccounts{Accounts: newAccounts}, "", "  ")
+	output, err = json.MarshalIndent(&generatedAccounts{Mnemonic: mnemonic, Accounts: newAccounts}, "", "  ")
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,96 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package account
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/ssh/terminal"
+	"gopkg.in/yaml.v2"
+
+	"github.com/iotexproject/iotex-core/cli/ioctl/cmd/config"
+	"github.com/iotexproject/iotex-core/cli/ioctl/validator"
+	"github.com/iotexproject/iotex-core/pkg/hdwallet"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+var recoverIndex uint32
+
+// accountRecoverCmd represents the account recover command
+var accountRecoverCmd = &cobra.Command{
+	Use:   "recover ALIAS",
+	Short: "Recover an account from its mnemonic into wallet",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		output, err := accountRecover(args)
+		if err == nil {
+			fmt.Println(output)
+		}
+		return err
+	},
+}
+
+func init() {
+	accountRecoverCmd.Flags().Uint32VarP(&recoverIndex, "index", "i", 0, "index of the derived account")
+}
+
+func accountRecover(args []string) (string, error) {
+	// Validate inputs
+	if err := validator.ValidateAlias(args[0]); err != nil {
+		return "", err
+	}
+	alias := args[0]
+	if addr, ok := config.ReadConfig.Aliases[alias]; ok {
+		return "", fmt.Errorf("alias \"%s\" has already used for %s", alias, addr)
+	}
+	fmt.Printf("#%s: Enter your mnemonic, which will not be exposed on the screen.\n", alias)
+	mnemonicBytes, err := terminal.ReadPassword(int(syscall.Stdin))
+	if err != nil {
+		log.L().Error("failed to get mnemonic", zap.Error(err))
+		return "", err
+	}
+	mnemonic := strings.TrimSpace(string(mnemonicBytes))
+	for i := 0; i < len(mnemonicBytes); i++ {
+		mnemonicBytes[i] = 0
+	}
+	if err := hdwallet.ValidateMnemonic(mnemonic); err != nil {
+		return "", err
+	}
+	fmt.Printf("#%s: Enter your mnemonic passphrase, leave it empty if there is none.\n", alias)
+	passphraseBytes, err := terminal.ReadPassword(int(syscall.Stdin))
+	if err != nil {
+		log.L().Error("failed to get passphrase", zap.Error(err))
+		return "", err
+	}
+	private, err := hdwallet.PrivateKeyFromMnemonic(mnemonic, string(passphraseBytes), recoverIndex)
+	for i := 0; i < len(passphraseBytes); i++ {
+		passphraseBytes[i] = 0
+	}
+	if err != nil {
+		return "", err
+	}
+	addr, err := newAccountByKey(alias, private.HexString(), config.ReadConfig.Wallet)
+	if err != nil {
+		return "", err
+	}
+	config.ReadConfig.Aliases[alias] = addr
+	out, err := yaml.Marshal(&config.ReadConfig)
+	if err != nil {
+		return "", err
+	}
+	if err := ioutil.WriteFile(config.DefaultConfigFile, out, 0600); err != nil {
+		return "", fmt.Errorf("failed to write to config file %s", config.DefaultConfigFile)
+	}
+	return fmt.Sprintf("Account #%s %s is recovered. Keep your password, or your will lose your private key.",
+		alias, addr), nil
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package account
+
+import (
+	"encoding/hex"
+	"fmt"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/ssh/terminal"
+
+	"github.com/iotexproject/iotex-core/cli/ioctl/cmd/alias"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
+)
+
+// accountSignCmd represents the account sign command
+var accountSignCmd = &cobra.Command{
+	Use:   "sign (ALIAS|ADDRESS) MESSAGE",
+	Short: "Sign a message to prove the ownership of an address",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		output, err := accountSign(args)
+		if err == nil {
+			fmt.Println(output)
+		}
+		return err
+	},
+}
+
+// accountVerifyCmd represents the account verify command
+var accountVerifyCmd = &cobra.Command{
+	Use:   "verify ADDRESS MESSAGE SIGNATURE",
+	Short: "Verify a message signature against an address",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		output, err := accountVerify(args)
+		if err == nil {
+			fmt.Println(output)
+		}
+		return err
+	},
+}
+
+func accountSign(args []string) (string, error) {
+	addr, err := alias.Address(args[0])
+	if err != nil {
+		return "", err
+	}
+	fmt.Printf("Enter password #%s:\n", args[0])
+	bytePassword, err := terminal.ReadPassword(int(syscall.Stdin))
+	if err != nil {
+		log.L().Error("failed to get password", zap.Error(err))
+		return "", err
+	}
+	prvKey, err := KsAccountToPrivateKey(addr, string(bytePassword))
+	if err != nil {
+		return "", err
+	}
+	defer prvKey.Zero()
+	sig, err := addrutil.SignMessage(prvKey, []byte(args[1]))
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(sig), nil
+}
+
+func accountVerify(args []string) (string, error) {
+	addr, err := alias.Address(args[0])
+	if err != nil {
+		return "", err
+	}
+	sig, err := hex.DecodeString(args[2])
+	if err != nil {
+		return "", fmt.Errorf("failed to decode signature %s", args[2])
+	}
+	ok, err := addrutil.VerifyMessage(addr, []byte(args[1]), sig)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", fmt.Errorf("signature is not signed by %s", addr)
+	}
+	return fmt.Sprintf("Signature is signed by %s", addr), nil
+}
@@ -12,11 +12,13 @@ import (
 	"fmt"
 	"math/big"
 	"syscall"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 	"golang.org/x/crypto/ssh/terminal"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/iotexproject/iotex-core/action"
@@ -36,8 +38,22 @@ var (
 	nonce    uint64
 	signer   string
 	bytecode []byte
+	wait     bool
 )
 
+const (
+	// receiptPollInterval is the interval to query the receipt of a sent action
+	receiptPollInterval = time.Second
+	// receiptTimeout is how long to wait for a sent action to be included in a block
+	receiptTimeout = time.Minute
+)
+
+// readPassword reads the password of the signing account from the terminal, which the tests replace
+var readPassword = func() (string, error) {
+	bytePassword, err := terminal.ReadPassword(int(syscall.Stdin))
+	return string(bytePassword), err
+}
+
 // ActionCmd represents the account command
 var ActionCmd = &cobra.Command{
 	Use:   "action",
@@ -51,12 +67,13 @@ func init() {
 	ActionCmd.AddCommand(actionInvokeCmd)
 	ActionCmd.AddCommand(actionClaimCmd)
 	ActionCmd.AddCommand(actionDepositCmd)
+	ActionCmd.AddCommand(actionVoteCmd)
 	ActionCmd.PersistentFlags().StringVar(&config.ReadConfig.Endpoint, "endpoint",
 		config.ReadConfig.Endpoint, "set endpoint for once")
 	ActionCmd.PersistentFlags().BoolVar(&config.Insecure, "insecure", config.Insecure,
 		"insecure connection for once")
 	setActionFlags(actionTransferCmd, actionDeployCmd, actionInvokeCmd, actionClaimCmd,
-		actionDepositCmd)
+		actionDepositCmd, actionVoteCmd)
 }
 
 func setActionFlags(cmds ...*cobra.Command) {
@@ -66,6 +83,7 @@ func setActionFlags(cmds ...*cobra.Command) {
 			"set gas price (unit: 10^(-6)Iotx)")
 		cmd.Flags().StringVarP(&signer, "signer", "s", "", "choose a signing account")
 		cmd.Flags().Uint64VarP(&nonce, "nonce", "n", 0, "set nonce")
+		cmd.Flags().BoolVarP(&wait, "wait", "w", false, "wait until the action is included in a block")
 		cmd.MarkFlagRequired("signer")
 		if cmd == actionDeployCmd || cmd == actionInvokeCmd {
 			cmd.Flags().BytesHexVarP(&bytecode, "bytecode", "b", nil, "set the byte code")
@@ -94,12 +112,12 @@ func GetGasPrice() (*big.Int, error) {
 
 func sendAction(elp action.Envelope) (string, error) {
 	fmt.Printf("Enter password #%s:\n", signer)
-	bytePassword, err := terminal.ReadPassword(int(syscall.Stdin))
+	password, err := readPassword()
 	if err != nil {
 		log.L().Error("failed to get password", zap.Error(err))
 		return "", err
 	}
-	prvKey, err := account.KsAccountToPrivateKey(signer, string(bytePassword))
+	prvKey, err := account.KsAccountToPrivateKey(signer, password)
 	if err != nil {
 		return "", err
 	}
@@ -143,7 +161,39 @@ func sendAction(elp action.Envelope) (string, error) {
 		return "", err
 	}
 	shash := hash.Hash256b(byteutil.Must(proto.Marshal(selp)))
-	return "Action has been sent to blockchain.\n" +
-		"Wait for several seconds and query this action by hash:\n" +
-		hex.EncodeToString(shash[:]), nil
+	actHash := hex.EncodeToString(shash[:])
+	if !wait {
+		return "Action has been sent to blockchain.\n" +
+			"Wait for several seconds and query this action by hash:\n" + actHash, nil
+	}
+	fmt.Printf("Action %s has been sent to blockchain, waiting for it to be included...\n", actHash)
+	height, err := waitForReceipt(cli, actHash, receiptPollInterval, receiptTimeout)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Action has been included in block #%d", height), nil
+}
+
+// waitForReceipt polls the receipt of an action until it's found and returns the height of the block including it
+func waitForReceipt(
+	cli iotexapi.APIServiceClient,
+	actHash string,
+	interval time.Duration,
+	timeout time.Duration,
+) (uint64, error) {
+	deadline := time.Now().Add(timeout)
+	request := &iotexapi.GetReceiptByActionRequest{ActionHash: actHash}
+	for {
+		response, err := cli.GetReceiptByAction(context.Background(), request)
+		if err == nil {
+			return response.ReceiptInfo.Receipt.BlkHeight, nil
+		}
+		if sta, ok := status.FromError(err); !ok || sta.Code() != codes.NotFound {
+			return 0, err
+		}
+		if time.Now().After(deadline) {
+			return 0, fmt.Errorf("action %s is not included in a block after %s", actHash, timeout)
+		}
+		time.Sleep(interval)
+	}
 }
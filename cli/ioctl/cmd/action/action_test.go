@@ -0,0 +1,110 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/iotexproject/iotex-core/cli/ioctl/cmd/config"
+	nodeconfig "github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/protogen/iotexapi"
+	"github.com/iotexproject/iotex-core/server/itx"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+const testPassword = "vote test password"
+
+func TestVoteWait(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "ioctl-vote")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	// run a node producing a block every second, where the voter is funded
+	accounts, alloc := testutil.FundedAccounts(1)
+	voter := accounts[0]
+	cfg := nodeconfig.Default
+	cfg.Plugins = map[int]interface{}{nodeconfig.GatewayPlugin: true}
+	cfg.Chain.TrieDBPath = filepath.Join(dir, "trie.db")
+	cfg.Chain.ChainDBPath = filepath.Join(dir, "chain.db")
+	cfg.ActPool.MinGasPriceStr = "0"
+	cfg.Consensus.Scheme = nodeconfig.StandaloneScheme
+	cfg.Genesis.BlockInterval = time.Second
+	cfg.Genesis.InitBalanceMap = testutil.MergeAllocation(cfg.Genesis.InitBalanceMap, alloc)
+	cfg.Network.Port = testutil.RandomPort()
+	cfg.API.Port = testutil.RandomPort()
+	cfg.System.EnableExperimentalActions = true
+	cfg.Chain.ProducerPrivKey = nodeconfig.Secret(testutil.NewKeyPair("producer").PriKey.HexString())
+	require.NoError(nodeconfig.ValidateAll(cfg, nodeconfig.WarningsAsNonfatal()))
+	ctx := context.Background()
+	svr, err := itx.NewServer(cfg)
+	require.NoError(err)
+	require.NoError(svr.Start(ctx))
+	defer func() {
+		require.NoError(svr.Stop(ctx))
+	}()
+	bc := svr.ChainService(cfg.Chain.ID).Blockchain()
+
+	// the voter's key is in the wallet of ioctl, which talks to the node
+	wallet := filepath.Join(dir, "wallet")
+	ks := keystore.NewKeyStore(wallet, keystore.LightScryptN, keystore.LightScryptP)
+	_, err = ks.ImportECDSA(voter.PriKey.EcdsaPrivateKey(), testPassword)
+	require.NoError(err)
+	savedConfig, savedInsecure, savedReadPassword, savedStdin := config.ReadConfig, config.Insecure, readPassword, os.Stdin
+	defer func() {
+		config.ReadConfig, config.Insecure, readPassword, os.Stdin = savedConfig, savedInsecure, savedReadPassword, savedStdin
+	}()
+	config.ReadConfig = config.Config{Wallet: wallet, Endpoint: fmt.Sprintf("127.0.0.1:%d", cfg.API.Port)}
+	config.Insecure = true
+	readPassword = func() (string, error) { return testPassword, nil }
+	confirm, err := ioutil.TempFile(dir, "confirm")
+	require.NoError(err)
+	_, err = confirm.WriteString("YES\n")
+	require.NoError(err)
+	_, err = confirm.Seek(0, 0)
+	require.NoError(err)
+	defer confirm.Close()
+	os.Stdin = confirm
+
+	// vote --wait returns once the vote is in a block
+	signer, wait = voter.Address.String(), true
+	defer func() {
+		signer, wait, nonce = "", false, 0
+	}()
+	output, err := vote([]string{voter.Address.String()})
+	require.NoError(err)
+	var height uint64
+	_, err = fmt.Sscanf(output, "Action has been included in block #%d", &height)
+	require.NoError(err)
+	require.True(height > 0 && height <= bc.TipHeight())
+	committed, err := bc.Nonce(voter.Address.String())
+	require.NoError(err)
+	require.Equal(uint64(1), committed)
+	votes, err := bc.GetVotesByAddress(voter.Address.String())
+	require.NoError(err)
+	require.Len(votes, 1)
+	require.Equal(voter.Address.String(), votes[0].Votee())
+
+	// time out if the action is never included
+	conn, err := grpc.Dial(config.ReadConfig.Endpoint, grpc.WithInsecure())
+	require.NoError(err)
+	defer conn.Close()
+	_, err = waitForReceipt(iotexapi.NewAPIServiceClient(conn),
+		"0000000000000000000000000000000000000000000000000000000000000000", 10*time.Millisecond, 50*time.Millisecond)
+	require.Error(err)
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package action
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/cli/ioctl/cmd/account"
+	"github.com/iotexproject/iotex-core/cli/ioctl/cmd/alias"
+	"github.com/iotexproject/iotex-core/cli/ioctl/util"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// actionVoteCmd represents the action vote command
+var actionVoteCmd = &cobra.Command{
+	Use:   "vote (ALIAS|VOTEE_ADDRESS) -s SIGNER [-l GAS_LIMIT] [-p GAS_PRICE]",
+	Short: "Vote for a delegate on IoTeX blockchain",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		output, err := vote(args)
+		if err == nil {
+			fmt.Println(output)
+		}
+		return err
+	},
+}
+
+// vote votes for a delegate on IoTeX blockchain
+func vote(args []string) (string, error) {
+	votee, err := alias.Address(args[0])
+	if err != nil {
+		return "", err
+	}
+	sender, err := alias.Address(signer)
+	if err != nil {
+		return "", err
+	}
+	if gasLimit == 0 {
+		gasLimit = action.VoteIntrinsicGas
+	}
+	var gasPriceRau *big.Int
+	if len(gasPrice) == 0 {
+		gasPriceRau, err = GetGasPrice()
+		if err != nil {
+			return "", err
+		}
+	} else {
+		gasPriceRau, err = util.StringToRau(gasPrice, util.GasPriceDecimalNum)
+		if err != nil {
+			return "", err
+		}
+	}
+	if nonce == 0 {
+		accountMeta, err := account.GetAccountMeta(sender)
+		if err != nil {
+			return "", err
+		}
+		nonce = accountMeta.PendingNonce
+	}
+	v, err := action.NewVote(nonce, votee, gasLimit, gasPriceRau)
+	if err != nil {
+		log.L().Error("failed to make a Vote instance", zap.Error(err))
+		return "", err
+	}
+	bd := &action.EnvelopeBuilder{}
+	elp := bd.SetNonce(nonce).
+		SetGasPrice(gasPriceRau).
+		SetGasLimit(gasLimit).
+		SetAction(v).Build()
+	return sendAction(elp)
+}
@@ -9,7 +9,7 @@ package validator
 import (
 	"errors"
 
-	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
 )
 
 // Errors
@@ -29,7 +29,7 @@ const (
 
 // ValidateAddress validates IoTeX address
 func ValidateAddress(addr string) error {
-	if _, err := address.FromString(addr); err != nil {
+	if err := addrutil.Validate(addr); err != nil {
 		return ErrInvalidAddr
 	}
 	return nil
@@ -7,9 +7,13 @@
 package config
 
 import (
+	"encoding/hex"
 	"flag"
+	"fmt"
 	"math/big"
 	"os"
+	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -26,10 +30,13 @@ import (
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/unit"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
 )
 
 // IMPORTANT: to define a config, add a field or a new config type to the existing config types. In addition, provide
-// the default value in Default var.
+// the default value in Default var. An interval, timeout or TTL is a time.Duration, which is given in the config files
+// like 500ms, 10s or 2m, and a size in bytes is a unit.ByteSize, which is given like 1KB or 4MB. A plain number is
+// still taken as nanoseconds or bytes respectively for backward compatibility.
 
 func init() {
 	flag.StringVar(&_overwritePath, "config-path", "", "Config path")
@@ -54,6 +61,12 @@ const (
 	StandaloneScheme = "STANDALONE"
 	// NOOPScheme means that the node does not create only block
 	NOOPScheme = "NOOP"
+	// FeePrioritySelection means that the actpool picks the action of the highest gas price among the next actions of
+	// the senders first
+	FeePrioritySelection = "FEEPRIORITY"
+	// FIFOSelection means that the actpool picks the action arriving earliest among the next actions of the senders
+	// first
+	FIFOSelection = "FIFO"
 	// IndexTransfer is table identifier for transfer index in indexer
 	IndexTransfer = "transfer"
 	// IndexVote is table identifier for vote index in indexer
@@ -96,32 +109,50 @@ var (
 			MasterKey:       "",
 			RateLimit:       p2p.DefaultRatelimitConfig,
 			EnableRateLimit: true,
+			DedupWindow:     5 * time.Second,
 		},
 		Chain: Chain{
 			ChainDBPath:     "./chain.db",
 			TrieDBPath:      "./trie.db",
 			ID:              1,
 			Address:         "",
-			ProducerPrivKey: PrivateKey.HexString(),
+			ProducerPrivKey: Secret(PrivateKey.HexString()),
 			EmptyGenesis:    false,
 			GravityChainDB:  DB{DbPath: "./poll.db", NumRetries: 10},
 			Committee: committee.Config{
 				GravityChainAPIs: []string{},
 			},
+			Checkpoints:             make(map[uint64]string),
+			CheckpointStateRoots:    make(map[uint64]string),
+			CheckpointDelegates:     make(map[uint64][]string),
 			EnableFallBackToFreshDB: false,
 			EnableTrielessStateDB:   true,
 			EnableAsyncIndexWrite:   true,
 			CompressBlock:           false,
 			AllowedBlockGasResidue:  10000,
 			MaxCacheSize:            0,
+			FutureBlockTolerance:    10 * time.Second,
 		},
 		ActPool: ActPool{
-			MaxNumActsPerPool:  32000,
-			MaxGasLimitPerPool: 320000000,
-			MaxNumActsPerAcct:  2000,
-			ActionExpiry:       10 * time.Minute,
-			MinGasPriceStr:     big.NewInt(unit.Qev).String(),
-			BlackList:          []string{},
+			MaxNumActsPerPool:      32000,
+			MaxGasLimitPerPool:     320000000,
+			MaxNumActsPerAcct:      2000,
+			ActionExpiry:           10 * time.Minute,
+			SweepInterval:          time.Minute,
+			MinGasPriceStr:         big.NewInt(unit.Qev).String(),
+			MinTransferAmountStr:   "0",
+			MaxTransferPayloadSize: 0,
+			MaxNonceGap:            0,
+			SenderRateLimit:        0,
+			SenderRateWindow:       time.Second,
+			MaxSenders:             0,
+			BlackList:              []string{},
+			PrioritySenders:        []string{},
+			Selection:              FeePrioritySelection,
+			RejectDuringSync:       false,
+			MaxTransfersPerBlock:   0,
+			MaxVotesPerBlock:       0,
+			Journal:                "",
 		},
 		Consensus: Consensus{
 			Scheme: StandaloneScheme,
@@ -138,16 +169,31 @@ var (
 				ToleratedOvertime: 2 * time.Second,
 				Delay:             5 * time.Second,
 			},
+			RequireDelegateReachability: false,
+			DelegateAddrs:               []string{},
+			ReachabilityCheckInterval:   10 * time.Second,
+			SlotDuration:                0,
+			SlotDelegates:               []string{},
 		},
 		BlockSync: BlockSync{
-			Interval:        10 * time.Second,
-			BufferSize:      100,
-			IntervalSize:    10,
-			MaxRepeat:       3,
-			RepeatDecayStep: 1,
+			Interval:      10 * time.Second,
+			BufferSize:    100,
+			IntervalSize:  10,
+			Parallelism:   4,
+			ChunkTimeout:  20 * time.Second,
+			StallWindow:   time.Minute,
+			MaxBatchBytes: 4 * unit.MB,
 		},
 		Dispatcher: Dispatcher{
-			EventChanSize: 10000,
+			EventChanSize:       10000,
+			BlockChanSize:       1000,
+			BlockEnqueueTimeout: 200 * time.Millisecond,
+			BlockSyncChanSize:   1000,
+			ConsensusChanSize:   10000,
+			SeenBlockCacheSize:  128,
+			SeenBlockTTL:        30 * time.Second,
+			SeenActionCacheSize: 50000,
+			PanicPenalty:        10 * time.Minute,
 		},
 		API: API{
 			UseRDS:    false,
@@ -158,7 +204,9 @@ var (
 				DefaultGas:         uint64(unit.Qev),
 				Percentile:         60,
 			},
-			RangeQueryLimit: 1000,
+			RangeQueryLimit:  1000,
+			StreamBufferSize: 256,
+			PanicPenalty:     10 * time.Minute,
 		},
 		Indexer: Indexer{
 			Enabled:           false,
@@ -172,7 +220,10 @@ var (
 			HeartbeatInterval:         10 * time.Second,
 			HTTPStatsPort:             8080,
 			HTTPAdminPort:             9009,
+			HTTPAdminHost:             "",
+			EnablePprof:               false,
 			StartSubChainInterval:     10 * time.Second,
+			ShutdownTimeout:           30 * time.Second,
 			EnableExperimentalActions: false,
 		},
 		DB: DB{
@@ -182,7 +233,15 @@ var (
 				SQLite3File: "./explorer.db",
 			},
 		},
-		Genesis: genesis.Default,
+		Log: log.GlobalConfig{
+			Modules: make(map[string]log.ModuleConfig),
+		},
+		Genesis:   genesis.Default,
+		SubChains: []SubChain{},
+		Delegate: Delegate{
+			HistoryFile:   "./delegates.history",
+			WatchInterval: 10 * time.Second,
+		},
 	}
 
 	// ErrInvalidCfg indicates the invalid config value
@@ -191,9 +250,19 @@ var (
 	// Validates is the collection config validation functions
 	Validates = []Validate{
 		ValidateRollDPoS,
+		ValidateConsensus,
 		ValidateDispatcher,
+		ValidateBlockSync,
 		ValidateAPI,
 		ValidateActPool,
+		ValidateGenesis,
+		ValidateCheckpoints,
+		ValidateKeyReferences,
+		ValidatePorts,
+		ValidateLog,
+		ValidateSubChains,
+		ValidateDurations,
+		ValidateDelegate,
 	}
 
 	// PrivateKey is a randomly generated producer's key for testing purpose
@@ -208,12 +277,18 @@ type (
 		ExternalHost   string   `yaml:"externalHost"`
 		ExternalPort   int      `yaml:"externalPort"`
 		BootstrapNodes []string `yaml:"bootstrapNodes"`
-		MasterKey      string   `yaml:"masterKey"` // master key will be PrivateKey if not set.
+		MasterKey      Secret   `yaml:"masterKey"` // master key will be PrivateKey if not set.
 		// RelayType is the type of P2P network relay. By default, the value is empty, meaning disabled. Two relay types
 		// are supported: active, nat.
 		RelayType       string              `yaml:"relayType"`
 		RateLimit       p2p.RateLimitConfig `yaml:"rateLimit"`
 		EnableRateLimit bool                `yaml:"enableRateLimit"`
+		// DedupWindow is the time window within which an action broadcast with identical bytes is dropped as a
+		// duplicate. 0 disables the deduplication
+		DedupWindow time.Duration `yaml:"dedupWindow"`
+		// ValidateBeforeBroadcast refuses to broadcast an action failing the validation that needs no chain states, i.e.,
+		// the signature, the intrinsic gas, and not being a system action like granting the block reward
+		ValidateBeforeBroadcast bool `yaml:"validateBeforeBroadcast"`
 	}
 
 	// Chain is the config struct for blockchain package
@@ -222,21 +297,56 @@ type (
 		TrieDBPath      string           `yaml:"trieDBPath"`
 		ID              uint32           `yaml:"id"`
 		Address         string           `yaml:"address"`
-		ProducerPrivKey string           `yaml:"producerPrivKey"`
+		ProducerPrivKey Secret           `yaml:"producerPrivKey"`
 		EmptyGenesis    bool             `yaml:"emptyGenesis"`
 		GravityChainDB  DB               `yaml:"gravityChainDB"`
 		Committee       committee.Config `yaml:"committee"`
 
+		// MaxReorgDepth is the max number of blocks below the tip a competing fork may branch off. A deeper fork is
+		// treated as invalid. 0 doesn't bound the reorg depth
+		MaxReorgDepth uint64 `yaml:"maxReorgDepth"`
+		// Checkpoints pins the hashes of the known-good blocks by height. A block conflicting with a checkpoint is
+		// refused, and a fork cannot branch off below the latest checkpoint committed. A fresh node only syncs from
+		// the checkpoint snapshot of a block pinned here
+		Checkpoints map[uint64]string `yaml:"checkpoints"`
+		// CheckpointStateRoots pins the state roots at the checkpoints, which the state DB of a checkpoint snapshot
+		// has to commit to
+		CheckpointStateRoots map[uint64]string `yaml:"checkpointStateRoots"`
+		// CheckpointDelegates pins the addresses of the delegates of the epochs of the checkpoints, more than 2/3 of
+		// whom have to endorse the checkpoint block of a snapshot
+		CheckpointDelegates map[uint64][]string `yaml:"checkpointDelegates"`
+		// StrictKeyReferences refuses the private keys carried in plaintext by the config rather than referred to in
+		// the form of keystore://<path> or env://<variable>
+		StrictKeyReferences bool `yaml:"strictKeyReferences"`
+
 		EnableFallBackToFreshDB bool `yaml:"enableFallbackToFreshDb"`
 		EnableTrielessStateDB   bool `yaml:"enableTrielessStateDB"`
 		// EnableAsyncIndexWrite enables writing the block actions' and receipts' index asynchronously
 		EnableAsyncIndexWrite bool `yaml:"enableAsyncIndexWrite"`
+		// RebuildIndexes drops the secondary indexes of the blocks and builds them again by replaying the committed
+		// blocks on startup. It's meant to be set for a single start, e.g., after an upgrade or on a corrupted index. An
+		// interrupted rebuild resumes on the next start whether it's still set or not
+		RebuildIndexes bool `yaml:"rebuildIndexes"`
+		// MaxReconcileBlocks is the max number of blocks the chain DB may be ahead of the state DB on startup, which are
+		// replayed, or rolled back if failing to replay. A bigger gap refuses to start, except for a fresh state DB built
+		// from genesis. 0, the default, doesn't bound the gap, replaying all the blocks ahead
+		MaxReconcileBlocks uint64 `yaml:"maxReconcileBlocks"`
 		// CompressBlock enables gzip compression on block data
 		CompressBlock bool `yaml:"compressBlock"`
 		// AllowedBlockGasResidue is the amount of gas remained when block producer could stop processing more actions
 		AllowedBlockGasResidue uint64 `yaml:"allowedBlockGasResidue"`
 		// MaxCacheSize is the max number of blocks that will be put into an LRU cache. 0 means disabled
 		MaxCacheSize int `yaml:"maxCacheSize"`
+		// FutureBlockTolerance is how far a block timestamp is allowed to be ahead of the local clock, to tolerate
+		// clock skew between nodes. Blocks dated beyond it are rejected. 0 means no tolerance
+		FutureBlockTolerance time.Duration `yaml:"futureBlockTolerance"`
+		// StrictNonceOrder requires the actions of each sender in a block to be in the order of their nonces, rather than
+		// only the nonces to be consecutive in any order. It changes which blocks are valid, so all the nodes of a chain
+		// should agree on it
+		StrictNonceOrder bool `yaml:"strictNonceOrder"`
+		// EnableInMemoryDB keeps the chain and the states in memory rather than in the DB files, which suits the
+		// development nodes throwing away their data on restart
+		EnableInMemoryDB bool `yaml:"enableInMemoryDB"`
 	}
 
 	// Consensus is the config struct for consensus package
@@ -244,6 +354,18 @@ type (
 		// There are three schemes that are supported
 		Scheme   string   `yaml:"scheme"`
 		RollDPoS RollDPoS `yaml:"rollDPoS"`
+		// RequireDelegateReachability holds off minting at startup until a quorum of DelegateAddrs is reachable
+		RequireDelegateReachability bool `yaml:"requireDelegateReachability"`
+		// DelegateAddrs are the network addresses (host:port) of the delegates, including the node itself
+		DelegateAddrs []string `yaml:"delegateAddrs"`
+		// ReachabilityCheckInterval is the interval to check the delegate addresses until a quorum is reachable
+		ReachabilityCheckInterval time.Duration `yaml:"reachabilityCheckInterval"`
+		// SlotDuration is the length of the time slot assigned to a delegate to mint in turn with the standalone scheme.
+		// 0 means the block interval.
+		SlotDuration time.Duration `yaml:"slotDuration"`
+		// SlotDelegates are the ordered producer addresses of the delegates minting in turn, one per slot, with the
+		// standalone scheme. Empty means the node is the only delegate, which mints in every slot.
+		SlotDelegates []string `yaml:"slotDelegates"`
 	}
 
 	// BlockSync is the config struct for the BlockSync
@@ -251,10 +373,18 @@ type (
 		Interval     time.Duration `yaml:"interval"` // update duration
 		BufferSize   uint64        `yaml:"bufferSize"`
 		IntervalSize uint64        `yaml:"intervalSize"`
-		// MaxRepeat is the maximal number of repeat of a block sync request
-		MaxRepeat int `yaml:"maxRepeat"`
-		// RepeatDecayStep is the step for repeat number decreasing by 1
-		RepeatDecayStep int `yaml:"repeatDecayStep"`
+		// Parallelism is the maximal number of block sync requests in flight, each of which asks a peer for an
+		// interval of blocks
+		Parallelism int `yaml:"parallelism"`
+		// ChunkTimeout is the time to wait for the blocks of a request, after which the interval is requested from
+		// another peer
+		ChunkTimeout time.Duration `yaml:"chunkTimeout"`
+		// StallWindow is the time without committing a block, after which the sync behind the peers is taken as
+		// stalled and the peers are re-selected
+		StallWindow time.Duration `yaml:"stallWindow"`
+		// MaxBatchBytes is the max size of a batch of blocks requested from or served to a peer in one message. The
+		// blocks are requested one per message if it's 0.
+		MaxBatchBytes unit.ByteSize `yaml:"maxBatchBytes"`
 	}
 
 	// RollDPoS is the config struct for RollDPoS consensus package
@@ -266,7 +396,27 @@ type (
 
 	// Dispatcher is the dispatcher config
 	Dispatcher struct {
+		// EventChanSize is the size of the action queue, which drops the oldest action when full
 		EventChanSize uint `yaml:"eventChanSize"`
+		// BlockChanSize is the size of the block queue, which blocks the sender for up to BlockEnqueueTimeout when full,
+		// then drops the new block
+		BlockChanSize       uint          `yaml:"blockChanSize"`
+		BlockEnqueueTimeout time.Duration `yaml:"blockEnqueueTimeout"`
+		// BlockSyncChanSize is the size of the block sync request queue, which drops the new request when full
+		BlockSyncChanSize uint `yaml:"blockSyncChanSize"`
+		// ConsensusChanSize is the hard cap of the consensus message queue, below which no consensus message is dropped
+		ConsensusChanSize uint `yaml:"consensusChanSize"`
+		// SeenBlockCacheSize is the number of the broadcast blocks remembered, whose copies from the other peers are
+		// dropped before validation. 0 disables the deduplication
+		SeenBlockCacheSize uint `yaml:"seenBlockCacheSize"`
+		// SeenBlockTTL is how long a broadcast block is remembered after it's handled
+		SeenBlockTTL time.Duration `yaml:"seenBlockTTL"`
+		// SeenActionCacheSize is the number of the broadcast or committed actions remembered, whose copies from the
+		// peers are dropped before the signature verification. 0 disables the deduplication
+		SeenActionCacheSize uint `yaml:"seenActionCacheSize"`
+		// PanicPenalty is how long the messages from a peer are dropped after one of its messages makes a handler
+		// panic. 0 disables the penalty
+		PanicPenalty time.Duration `yaml:"panicPenalty"`
 		// TODO: explorer dependency deleted at #1085, need to revive by migrating to api
 	}
 
@@ -277,6 +427,19 @@ type (
 		TpsWindow       int        `yaml:"tpsWindow"`
 		GasStation      GasStation `yaml:"gasStation"`
 		RangeQueryLimit uint64     `yaml:"rangeQueryLimit"`
+		// StreamBufferSize is the number of events buffered for each streaming client, beyond which events are dropped
+		StreamBufferSize int `yaml:"streamBufferSize"`
+		// MaxConnections is the max number of simultaneous connections, beyond which the RPCs are rejected with
+		// ResourceExhausted. 0 means no limit
+		MaxConnections int `yaml:"maxConnections"`
+		// MaxConcurrentStreams is the max number of concurrent RPCs on each connection. 0 means the gRPC default
+		MaxConcurrentStreams int `yaml:"maxConcurrentStreams"`
+		// MaxResponseItems is the max number of items in the response of a range query, beyond which the response is
+		// truncated, with the start of the rest to query next. 0 means no limit other than RangeQueryLimit
+		MaxResponseItems int `yaml:"maxResponseItems"`
+		// PanicPenalty is how long the RPCs from a client host are rejected after one of its requests makes a handler
+		// panic. 0 disables the penalty
+		PanicPenalty time.Duration `yaml:"panicPenalty"`
 	}
 
 	// GasStation is the gas station config
@@ -302,29 +465,80 @@ type (
 		// Active is the status of the node. True means active and false means stand-by
 		Active            bool          `yaml:"active"`
 		HeartbeatInterval time.Duration `yaml:"heartbeatInterval"`
-		// HTTPProfilingPort is the port number to access golang performance profiling data of a blockchain node. It is
-		// 0 by default, meaning performance profiling has been disabled
-		HTTPAdminPort         int           `yaml:"httpAdminPort"`
+		// HTTPAdminPort is the port number of the admin endpoints, e.g., log levels, the metrics, the config dump and
+		// the debug endpoints. 0 disables them
+		HTTPAdminPort int `yaml:"httpAdminPort"`
+		// HTTPAdminHost is the interface the admin port is bound to, which is all the interfaces by default. The debug
+		// endpoints only answer the requests from the loopback addresses unless it's set
+		HTTPAdminHost string `yaml:"httpAdminHost"`
+		// EnablePprof exposes the pprof and the runtime stats endpoints under /debug on the admin port
+		EnablePprof           bool          `yaml:"enablePprof"`
 		HTTPStatsPort         int           `yaml:"httpStatsPort"`
 		StartSubChainInterval time.Duration `yaml:"startSubChainInterval"`
+		// ShutdownTimeout is the deadline of stopping the server gracefully, after which the steps left are abandoned
+		// but the chain DBs are still closed. 0 means no deadline
+		ShutdownTimeout time.Duration `yaml:"shutdownTimeout"`
 		// EnableExperimentalActions is the flag to enable experimental actions
 		EnableExperimentalActions bool `yaml:"enableExperimentalActions"`
+		// MaxPanicsPerMinute is the max number of the panics recovered in the handlers of the P2P messages and the API
+		// requests within a minute, beyond which the node shuts down cleanly. 0 means no limit
+		MaxPanicsPerMinute uint `yaml:"maxPanicsPerMinute"`
 	}
 
 	// ActPool is the actpool config
 	ActPool struct {
-		// MaxNumActsPerPool indicates maximum number of actions the whole actpool can hold
+		// MaxNumActsPerPool indicates maximum number of actions the whole actpool can hold. Once it's full, a new action
+		// evicts the least valuable action in pool if it outranks that one, e.g., by a higher gas price, or is rejected
 		MaxNumActsPerPool uint64 `yaml:"maxNumActsPerPool"`
 		// MaxGasLimitPerPool indicates maximum gas limit the whole actpool can hold
 		MaxGasLimitPerPool uint64
 		// MaxNumActsPerAcct indicates maximum number of actions an account queue can hold
 		MaxNumActsPerAcct uint64 `yaml:"maxNumActsPerAcct"`
-		// ActionExpiry defines how long an action will be kept in action pool.
+		// ActionExpiry defines how long an action will be kept in action pool, i.e., the TTL of the action since its
+		// arrival, beyond which it's evicted on the reset of the pool after a block is committed, or by the sweep,
+		// freeing its nonce. The actions of the higher nonces of the same sender are evicted along with it. 0 keeps the
+		// actions until committed
 		ActionExpiry time.Duration `yaml:"actionExpiry"`
+		// SweepInterval is the interval the pool sweeps the actions beyond ActionExpiry in the background, which
+		// evicts them even when no block is committed. 0 disables the sweep
+		SweepInterval time.Duration `yaml:"sweepInterval"`
 		// MinGasPriceStr defines the minimal gas price the delegate will accept for an action
 		MinGasPriceStr string `yaml:"minGasPrice"`
+		// MinTransferAmountStr defines the minimal amount of a transfer the delegate will accept
+		MinTransferAmountStr string `yaml:"minTransferAmount"`
+		// MaxTransferPayloadSize is the maximum size of the payload of a transfer the delegate will accept. 0 means no
+		// limit other than the size limit of a transfer
+		MaxTransferPayloadSize unit.ByteSize `yaml:"maxTransferPayloadSize"`
+		// MaxNonceGap is the maximum distance of the nonce of an action beyond the confirmed nonce of its sender. 0 makes
+		// it MaxNumActsPerAcct
+		MaxNonceGap uint64 `yaml:"maxNonceGap"`
+		// SenderRateLimit is the maximum number of actions a sender could add to the pool within SenderRateWindow. 0
+		// disables it
+		SenderRateLimit uint64 `yaml:"senderRateLimit"`
+		// SenderRateWindow is the window SenderRateLimit applies to
+		SenderRateWindow time.Duration `yaml:"senderRateWindow"`
+		// MaxSenders is the maximum number of distinct senders having actions in the pool at the same time, beyond
+		// which the actions of new senders are rejected. 0 means no limit
+		MaxSenders uint64 `yaml:"maxSenders"`
 		// BlackList lists the account address that are banned from initiating actions
 		BlackList []string `yaml:"blackList"`
+		// PrioritySenders lists the account addresses whose actions are always picked ahead of the others regardless
+		// of the gas price, e.g., the system accounts of oracles and bridges
+		PrioritySenders []string `yaml:"prioritySenders"`
+		// Selection is the order the pool picks the actions in, FEEPRIORITY or FIFO. Either way the actions of a sender
+		// are picked in nonce order, after the actions of PrioritySenders
+		Selection string `yaml:"selection"`
+		// RejectDuringSync makes the pool reject the new actions while the node is behind its peers, so as not to
+		// take the actions on a stale state. It's ignored by the standalone scheme, which has no peer to sync with
+		RejectDuringSync bool `yaml:"rejectDuringSync"`
+		// MaxTransfersPerBlock is the maximum number of transfers picked from the pool into a block minted, which bounds
+		// the time to construct the block. 0 means no limit
+		MaxTransfersPerBlock uint64 `yaml:"maxTransfersPerBlock"`
+		// MaxVotesPerBlock is the maximum number of votes picked from the pool into a block minted. 0 means no limit
+		MaxVotesPerBlock uint64 `yaml:"maxVotesPerBlock"`
+		// Journal is the path of the file the pool journals the actions it takes to, and takes them again from on
+		// restart, so that the actions not committed yet survive the restart. Empty keeps the actions in memory only
+		Journal string `yaml:"journal"`
 	}
 
 	// DB is the config for database
@@ -351,7 +565,7 @@ type (
 		// AwsRDSUser is the user to access aws rds
 		AwsRDSUser string `yaml:"awsRDSUser"`
 		// AwsPass is the pass to access aws rds
-		AwsPass string `yaml:"awsPass"`
+		AwsPass Secret `yaml:"awsPass"`
 		// AwsDBName is the db name of aws rds
 		AwsDBName string `yaml:"awsDBName"`
 	}
@@ -362,6 +576,41 @@ type (
 		SQLite3File string `yaml:"sqlite3File"`
 	}
 
+	// Delegate is the config of the lists of the delegates and the peers maintained in files by the operators, which
+	// are watched and applied at runtime
+	Delegate struct {
+		// AddrsFile is the path of the file listing the addresses of the delegates one per line, which replace the
+		// candidates of the root chain read from the chain. A line "after <height>" in the file schedules the list to
+		// take effect from the start of the first epoch after the height, which must be yet to start when the file
+		// changes. Empty disables it
+		AddrsFile string `yaml:"addrsFile"`
+		// HistoryFile is the path of the file persisting the delegate lists taken from AddrsFile with the heights
+		// they apply from, so that a restarted node keeps the delegates of the past epochs
+		HistoryFile string `yaml:"historyFile"`
+		// PeersFile is the path of the file listing the IDs of the peers which the node accepts the messages from one
+		// per line. Empty accepts all the peers
+		PeersFile string `yaml:"peersFile"`
+		// WatchInterval is the interval of checking the files for changes
+		WatchInterval time.Duration `yaml:"watchInterval"`
+	}
+
+	// SubChain is the config of a chain the node runs besides the root chain, sharing its network. The values left
+	// empty follow the ones of the root chain.
+	SubChain struct {
+		// ID is the ID of the sub-chain, which tags its messages in the network
+		ID uint32 `yaml:"id"`
+		// ChainDBPath is the path of the chain DB, which is the one of the root chain prefixed by the chain ID if empty
+		ChainDBPath string `yaml:"chainDBPath"`
+		// TrieDBPath is the path of the trie DB, which is the one of the root chain prefixed by the chain ID if empty
+		TrieDBPath string `yaml:"trieDBPath"`
+		// GenesisPath is the path of the genesis file of the sub-chain
+		GenesisPath string `yaml:"genesisPath"`
+		// ConsensusScheme is the consensus scheme of the sub-chain
+		ConsensusScheme string `yaml:"consensusScheme"`
+		// APIPort is the port of the API of the sub-chain, which is served with the gateway plugin. 0 disables it
+		APIPort int `yaml:"apiPort"`
+	}
+
 	// Config is the root config struct, each package's config should be put as its sub struct
 	Config struct {
 		Plugins    map[int]interface{}         `ymal:"plugins"`
@@ -378,38 +627,49 @@ type (
 		Log        log.GlobalConfig            `yaml:"log"`
 		SubLogs    map[string]log.GlobalConfig `yaml:"subLogs"`
 		Genesis    genesis.Genesis             `yaml:"genesis"`
+		SubChains  []SubChain                  `yaml:"subChains"`
+		Delegate   Delegate                    `yaml:"delegate"`
+		// SecretsPath is the path to the secrets file, which sets the Secret fields apart from the config files. See
+		// loadSecrets for its format.
+		SecretsPath string `yaml:"secretsPath"`
+
+		// loadedSecrets is the set of the config paths set by the secrets file
+		loadedSecrets map[string]bool
 	}
 
-	// Validate is the interface of validating the config
+	// Validate is the interface of validating the config, which returns the Violations found
 	Validate func(Config) error
 )
 
 // New creates a config instance. It first loads the default configs. If the config path is not empty, it will read from
-// the file and override the default configs. By default, it will apply all validation functions. To bypass validation,
-// use DoNotValidate instead.
-func New(validates ...Validate) (Config, error) {
-	opts := make([]uconfig.YAMLOption, 0)
-	opts = append(opts, uconfig.Static(Default))
-	opts = append(opts, uconfig.Expand(os.LookupEnv))
+// the file and override the default configs. The environment variables named after EnvOverridePrefix then override
+// the individual config values, and the secrets file at SecretsPath, if any, sets the secrets. It applies all the
+// validation functions, and reports all the violations found. The tests could give WarningsAsNonfatal to tolerate the
+// violations of SeverityWarning, and InsecureSecretsFile to skip the permission check of the secrets file.
+func New(validateOpts ...ValidateOption) (Config, error) {
+	paths := make([]string, 0)
 	if _overwritePath != "" {
-		opts = append(opts, uconfig.File(_overwritePath))
+		paths = append(paths, _overwritePath)
 	}
 	if _secretPath != "" {
-		opts = append(opts, uconfig.File(_secretPath))
+		paths = append(paths, _secretPath)
 	}
-	yaml, err := uconfig.NewYAML(opts...)
+	cfg, err := Load(paths...)
 	if err != nil {
-		return Config{}, errors.Wrap(err, "failed to init config")
+		return Config{}, err
 	}
-
-	var cfg Config
-	if err := yaml.Get(uconfig.Root).Populate(&cfg); err != nil {
-		return Config{}, errors.Wrap(err, "failed to unmarshal YAML config to struct")
+	if cfg.SecretsPath != "" {
+		if err := loadSecrets(&cfg, cfg.SecretsPath, validateOpts...); err != nil {
+			return Config{}, err
+		}
 	}
 
 	// set network master key to private key
 	if cfg.Network.MasterKey == "" {
 		cfg.Network.MasterKey = cfg.Chain.ProducerPrivKey
+		if cfg.loadedSecrets["chain.producerPrivKey"] {
+			cfg.loadedSecrets["network.masterKey"] = true
+		}
 	}
 
 	// set plugins
@@ -422,29 +682,60 @@ func New(validates ...Validate) (Config, error) {
 		}
 	}
 
-	// By default, the config needs to pass all the validation
-	if len(validates) == 0 {
-		validates = Validates
+	if err := ValidateAll(cfg, validateOpts...); err != nil {
+		return Config{}, errors.Wrap(err, "failed to validate config")
 	}
-	for _, validate := range validates {
-		if err := validate(cfg); err != nil {
-			return Config{}, errors.Wrap(err, "failed to validate config")
-		}
+	// resolve the key references after the validation, which tells them from the plaintext keys
+	if err := cfg.resolvePrivateKeys(); err != nil {
+		return Config{}, err
 	}
 	return cfg, nil
 }
 
-// NewSub create config for sub chain.
-func NewSub(validates ...Validate) (Config, error) {
+// NewSub create config for sub chain. The environment variables named after SubChainEnvOverridePrefix, rather than
+// EnvOverridePrefix, override its config values.
+func NewSub(validateOpts ...ValidateOption) (Config, error) {
 	if _subChainPath == "" {
 		return Config{}, nil
 	}
+	paths := []string{_subChainPath}
+	if _secretPath != "" {
+		paths = append(paths, _secretPath)
+	}
+	cfg, err := load(SubChainEnvOverridePrefix, paths...)
+	if err != nil {
+		return Config{}, err
+	}
+	if cfg.SecretsPath != "" {
+		if err := loadSecrets(&cfg, cfg.SecretsPath, validateOpts...); err != nil {
+			return Config{}, err
+		}
+	}
+
+	if err := ValidateAll(cfg, validateOpts...); err != nil {
+		return Config{}, errors.Wrap(err, "failed to validate config")
+	}
+	// resolve the key references after the validation, which tells them from the plaintext keys
+	if err := cfg.resolvePrivateKeys(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// Load reads the config files in order on top of the default configs, where the latter file wins on conflict, and then
+// applies the environment variables named after EnvOverridePrefix. The config is neither validated nor has its private
+// key references resolved.
+func Load(paths ...string) (Config, error) {
+	return load(EnvOverridePrefix, paths...)
+}
+
+// load reads the config files like Load, and applies the environment variables of the given prefix
+func load(envPrefix string, paths ...string) (Config, error) {
 	opts := make([]uconfig.YAMLOption, 0)
 	opts = append(opts, uconfig.Static(Default))
 	opts = append(opts, uconfig.Expand(os.LookupEnv))
-	opts = append(opts, uconfig.File(_subChainPath))
-	if _secretPath != "" {
-		opts = append(opts, uconfig.File(_secretPath))
+	for _, path := range paths {
+		opts = append(opts, uconfig.File(path))
 	}
 	yaml, err := uconfig.NewYAML(opts...)
 	if err != nil {
@@ -455,15 +746,8 @@ func NewSub(validates ...Validate) (Config, error) {
 	if err := yaml.Get(uconfig.Root).Populate(&cfg); err != nil {
 		return Config{}, errors.Wrap(err, "failed to unmarshal YAML config to struct")
 	}
-
-	// By default, the config needs to pass all the validation
-	if len(validates) == 0 {
-		validates = Validates
-	}
-	for _, validate := range validates {
-		if err := validate(cfg); err != nil {
-			return Config{}, errors.Wrap(err, "failed to validate config")
-		}
+	if err := applyEnvOverrides(&cfg, envPrefix); err != nil {
+		return Config{}, err
 	}
 	return cfg, nil
 }
@@ -471,7 +755,7 @@ func NewSub(validates ...Validate) (Config, error) {
 // ProducerAddress returns the configured producer address derived from key
 func (cfg Config) ProducerAddress() address.Address {
 	sk := cfg.ProducerPrivateKey()
-	addr, err := address.FromBytes(sk.PublicKey().Hash())
+	addr, err := addrutil.PubKeyToAddress(sk.PublicKey())
 	if err != nil {
 		log.L().Panic(
 			"Error when constructing producer address",
@@ -483,7 +767,7 @@ func (cfg Config) ProducerAddress() address.Address {
 
 // ProducerPrivateKey returns the configured private key
 func (cfg Config) ProducerPrivateKey() keypair.PrivateKey {
-	sk, err := keypair.HexStringToPrivateKey(cfg.Chain.ProducerPrivKey)
+	sk, err := keypair.HexStringToPrivateKey(string(cfg.Chain.ProducerPrivKey))
 	if err != nil {
 		log.L().Panic(
 			"Error when decoding private key",
@@ -502,12 +786,64 @@ func (ap ActPool) MinGasPrice() *big.Int {
 	return mgp
 }
 
+// MinTransferAmount returns the minimal transfer amount threshold
+func (ap ActPool) MinTransferAmount() *big.Int {
+	if ap.MinTransferAmountStr == "" {
+		return big.NewInt(0)
+	}
+	amount, ok := big.NewInt(0).SetString(ap.MinTransferAmountStr, 10)
+	if !ok {
+		log.S().Panicf("Error when parsing minimal transfer amount string: %s", ap.MinTransferAmountStr)
+	}
+	return amount
+}
+
+// NonceGap returns the maximum distance of the nonce of an action beyond the confirmed nonce of its sender
+func (ap ActPool) NonceGap() uint64 {
+	if ap.MaxNonceGap == 0 {
+		return ap.MaxNumActsPerAcct
+	}
+	return ap.MaxNonceGap
+}
+
 // ValidateDispatcher validates the dispatcher configs
 func ValidateDispatcher(cfg Config) error {
+	var vs Violations
 	if cfg.Dispatcher.EventChanSize <= 0 {
-		return errors.Wrap(ErrInvalidCfg, "dispatcher event chan size should be greater than 0")
+		vs.errorf("dispatcher.eventChanSize", cfg.Dispatcher.EventChanSize,
+			"dispatcher event chan size should be greater than 0")
 	}
-	return nil
+	for _, size := range []struct {
+		name  string
+		value uint
+	}{
+		{"dispatcher.blockChanSize", cfg.Dispatcher.BlockChanSize},
+		{"dispatcher.blockSyncChanSize", cfg.Dispatcher.BlockSyncChanSize},
+		{"dispatcher.consensusChanSize", cfg.Dispatcher.ConsensusChanSize},
+	} {
+		if size.value == 0 {
+			vs.errorf(size.name, size.value, "dispatcher queue size should be greater than 0")
+		}
+	}
+	return vs.err()
+}
+
+// ValidateBlockSync validates the block sync configs
+func ValidateBlockSync(cfg Config) error {
+	var vs Violations
+	if cfg.BlockSync.IntervalSize == 0 {
+		vs.errorf("blockSync.intervalSize", cfg.BlockSync.IntervalSize, "block sync interval size should be greater than 0")
+	}
+	if cfg.BlockSync.Parallelism <= 0 {
+		vs.errorf("blockSync.parallelism", cfg.BlockSync.Parallelism, "block sync parallelism should be greater than 0")
+	}
+	if cfg.BlockSync.ChunkTimeout == 0 {
+		vs.errorf("blockSync.chunkTimeout", cfg.BlockSync.ChunkTimeout, "block sync chunk timeout should be positive")
+	}
+	if cfg.BlockSync.StallWindow == 0 {
+		vs.errorf("blockSync.stallWindow", cfg.BlockSync.StallWindow, "block sync stall window should be positive")
+	}
+	return vs.err()
 }
 
 // ValidateRollDPoS validates the roll-DPoS configs
@@ -515,40 +851,358 @@ func ValidateRollDPoS(cfg Config) error {
 	if cfg.Consensus.Scheme != RollDPoSScheme {
 		return nil
 	}
-	rollDPoS := cfg.Consensus.RollDPoS
-	fsm := rollDPoS.FSM
+	var vs Violations
+	fsm := cfg.Consensus.RollDPoS.FSM
 	if fsm.EventChanSize <= 0 {
-		return errors.Wrap(ErrInvalidCfg, "roll-DPoS event chan size should be greater than 0")
+		vs.errorf("consensus.rollDPoS.fsm.eventChanSize", fsm.EventChanSize,
+			"roll-DPoS event chan size should be greater than 0")
 	}
-	return nil
+	if cfg.Genesis.NumDelegates == 0 {
+		vs.errorf("genesis.blockchain.numDelegates", cfg.Genesis.NumDelegates,
+			"roll-DPoS requires at least 1 delegate")
+	}
+	if len(cfg.Genesis.Delegates) == 0 && !cfg.Genesis.EnableGravityChainVoting {
+		vs.errorf("genesis.poll.delegates", nil,
+			"roll-DPoS requires at least 1 delegate unless genesis.poll.enableGravityChainVoting is set")
+	}
+	if cfg.Consensus.RequireDelegateReachability && len(cfg.Consensus.DelegateAddrs) == 0 {
+		vs.errorf("consensus.delegateAddrs", cfg.Consensus.DelegateAddrs,
+			"delegate addresses are required by consensus.requireDelegateReachability")
+	}
+	return vs.err()
+}
+
+// ValidateConsensus validates the consensus configs shared by the schemes
+func ValidateConsensus(cfg Config) error {
+	var vs Violations
+	switch cfg.Consensus.Scheme {
+	case RollDPoSScheme:
+	case StandaloneScheme, NOOPScheme:
+		if cfg.Consensus.RequireDelegateReachability {
+			vs.warnf("consensus.requireDelegateReachability", true,
+				"only makes sense with the %s scheme rather than %s", RollDPoSScheme, cfg.Consensus.Scheme)
+		}
+	default:
+		vs.errorf("consensus.scheme", cfg.Consensus.Scheme,
+			"should be one of %s, %s and %s", RollDPoSScheme, StandaloneScheme, NOOPScheme)
+	}
+	if cfg.Consensus.RequireDelegateReachability && cfg.Consensus.ReachabilityCheckInterval <= 0 {
+		vs.errorf("consensus.reachabilityCheckInterval", cfg.Consensus.ReachabilityCheckInterval,
+			"should be positive when consensus.requireDelegateReachability is set")
+	}
+	if cfg.Consensus.Scheme == StandaloneScheme && cfg.Consensus.SlotDuration > 0 &&
+		cfg.Consensus.SlotDuration < cfg.Genesis.BlockInterval {
+		vs.errorf("consensus.slotDuration", cfg.Consensus.SlotDuration,
+			"should be no less than the block interval %s, or the slots could pass without a block minted",
+			cfg.Genesis.BlockInterval)
+	}
+	if len(cfg.Consensus.SlotDelegates) == 0 {
+		return vs.err()
+	}
+	if cfg.Consensus.Scheme != StandaloneScheme {
+		vs.warnf("consensus.slotDelegates", cfg.Consensus.SlotDelegates,
+			"only makes sense with the %s scheme rather than %s", StandaloneScheme, cfg.Consensus.Scheme)
+	}
+	seen := make(map[string]bool, len(cfg.Consensus.SlotDelegates))
+	for i, d := range cfg.Consensus.SlotDelegates {
+		path := fmt.Sprintf("consensus.slotDelegates[%d]", i)
+		if err := addrutil.Validate(d); err != nil {
+			vs.errorf(path, d, "invalid delegate address: %v", err)
+			continue
+		}
+		if seen[strings.ToLower(d)] {
+			vs.errorf(path, d, "duplicate delegate address")
+		}
+		seen[strings.ToLower(d)] = true
+	}
+	// an invalid producer key is left to be reported where it's decoded
+	if sk, err := keypair.HexStringToPrivateKey(string(cfg.Chain.ProducerPrivKey)); err == nil {
+		if producer, err := addrutil.PubKeyToAddress(sk.PublicKey()); err == nil && !seen[producer.String()] {
+			vs.warnf("consensus.slotDelegates", cfg.Consensus.SlotDelegates,
+				"the node %s isn't one of the delegates, so it never mints", producer.String())
+		}
+	}
+	return vs.err()
 }
 
 // ValidateAPI validates the api configs
 func ValidateAPI(cfg Config) error {
+	var vs Violations
 	if cfg.API.TpsWindow <= 0 {
-		return errors.Wrap(ErrInvalidCfg, "tps window is not a positive integer when the api is enabled")
+		vs.errorf("api.tpsWindow", cfg.API.TpsWindow, "tps window is not a positive integer when the api is enabled")
+	} else if cfg.API.RangeQueryLimit < uint64(cfg.API.TpsWindow) {
+		vs.errorf("api.rangeQueryLimit", cfg.API.RangeQueryLimit,
+			"range query upper limit cannot be less than tps window %d", cfg.API.TpsWindow)
 	}
-	return nil
+	if cfg.API.MaxConnections < 0 {
+		vs.errorf("api.maxConnections", cfg.API.MaxConnections, "max connections cannot be negative")
+	}
+	if cfg.API.MaxConcurrentStreams < 0 {
+		vs.errorf("api.maxConcurrentStreams", cfg.API.MaxConcurrentStreams,
+			"max concurrent streams cannot be negative")
+	}
+	if cfg.API.MaxResponseItems < 0 {
+		vs.errorf("api.maxResponseItems", cfg.API.MaxResponseItems, "max response items cannot be negative")
+	} else if cfg.API.MaxResponseItems > 0 && cfg.API.MaxResponseItems < cfg.API.TpsWindow {
+		vs.errorf("api.maxResponseItems", cfg.API.MaxResponseItems,
+			"max response items cannot be less than tps window %d", cfg.API.TpsWindow)
+	}
+	return vs.err()
 }
 
 // ValidateActPool validates the given config
 func ValidateActPool(cfg Config) error {
+	var vs Violations
 	maxNumActPerPool := cfg.ActPool.MaxNumActsPerPool
 	maxNumActPerAcct := cfg.ActPool.MaxNumActsPerAcct
-	if maxNumActPerPool <= 0 || maxNumActPerAcct <= 0 {
-		return errors.Wrap(
-			ErrInvalidCfg,
-			"maximum number of actions per pool or per account cannot be zero or negative",
-		)
+	if maxNumActPerPool <= 0 {
+		vs.errorf("actPool.maxNumActsPerPool", maxNumActPerPool,
+			"maximum number of actions per pool or per account cannot be zero or negative")
 	}
-	if maxNumActPerPool < maxNumActPerAcct {
-		return errors.Wrap(
-			ErrInvalidCfg,
-			"maximum number of actions per pool cannot be less than maximum number of actions per account",
-		)
+	if maxNumActPerAcct <= 0 {
+		vs.errorf("actPool.maxNumActsPerAcct", maxNumActPerAcct,
+			"maximum number of actions per pool or per account cannot be zero or negative")
 	}
-	return nil
+	if maxNumActPerPool > 0 && maxNumActPerPool < maxNumActPerAcct {
+		vs.errorf("actPool.maxNumActsPerPool", maxNumActPerPool,
+			"maximum number of actions per pool cannot be less than maximum number of actions per account %d",
+			maxNumActPerAcct)
+	}
+	if cfg.ActPool.MaxGasLimitPerPool <= 0 {
+		vs.errorf("actPool.MaxGasLimitPerPool", cfg.ActPool.MaxGasLimitPerPool,
+			"maximum gas limit per pool cannot be zero")
+	}
+	if v, ok := big.NewInt(0).SetString(cfg.ActPool.MinGasPriceStr, 10); !ok || v.Sign() < 0 {
+		vs.errorf("actPool.minGasPrice", cfg.ActPool.MinGasPriceStr,
+			"minimal gas price should be a non-negative integer")
+	}
+	if cfg.ActPool.MinTransferAmountStr != "" {
+		if v, ok := big.NewInt(0).SetString(cfg.ActPool.MinTransferAmountStr, 10); !ok || v.Sign() < 0 {
+			vs.errorf("actPool.minTransferAmount", cfg.ActPool.MinTransferAmountStr,
+				"minimal transfer amount should be a non-negative integer")
+		}
+	}
+	if cfg.ActPool.SenderRateLimit > 0 && cfg.ActPool.SenderRateWindow <= 0 {
+		vs.errorf("actPool.senderRateWindow", cfg.ActPool.SenderRateWindow,
+			"sender rate window should be positive if the sender rate limit is set")
+	}
+	for i, sender := range cfg.ActPool.PrioritySenders {
+		if err := addrutil.Validate(sender); err != nil {
+			vs.errorf(fmt.Sprintf("actPool.prioritySenders[%d]", i), sender, "invalid priority sender address: %v", err)
+		}
+	}
+	switch cfg.ActPool.Selection {
+	case FeePrioritySelection, FIFOSelection:
+	default:
+		vs.errorf("actPool.selection", cfg.ActPool.Selection, "should be one of %s and %s", FeePrioritySelection,
+			FIFOSelection)
+	}
+	return vs.err()
+}
+
+// ValidateGenesis validates the addresses in the genesis config
+func ValidateGenesis(cfg Config) error {
+	var vs Violations
+	for i, d := range cfg.Genesis.Delegates {
+		if err := addrutil.Validate(d.OperatorAddrStr); err != nil {
+			vs.errorf(fmt.Sprintf("genesis.poll.delegates[%d].operatorAddr", i), d.OperatorAddrStr,
+				"invalid delegate operator address: %v", err)
+		}
+		if d.RewardAddrStr == "" {
+			continue
+		}
+		if err := addrutil.Validate(d.RewardAddrStr); err != nil {
+			vs.errorf(fmt.Sprintf("genesis.poll.delegates[%d].rewardAddr", i), d.RewardAddrStr,
+				"invalid delegate reward address: %v", err)
+		}
+	}
+	return vs.err()
+}
+
+// ValidateCheckpoints validates that the checkpoints are hex-encoded block hashes above the genesis, and that the state
+// roots and the delegates pinned along are at the heights of the checkpoints
+func ValidateCheckpoints(cfg Config) error {
+	var vs Violations
+	pinned := make(map[uint64]bool)
+	for height := range cfg.Chain.Checkpoints {
+		pinned[height] = true
+	}
+	for height := range cfg.Chain.CheckpointStateRoots {
+		pinned[height] = true
+	}
+	for height := range cfg.Chain.CheckpointDelegates {
+		pinned[height] = true
+	}
+	heights := make([]uint64, 0, len(pinned))
+	for height := range pinned {
+		heights = append(heights, height)
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+	for _, height := range heights {
+		h, ok := cfg.Chain.Checkpoints[height]
+		path := fmt.Sprintf("chain.checkpoints[%d]", height)
+		switch {
+		case !ok:
+			vs.errorf(path, nil, "state root or delegates are pinned without the checkpoint")
+		case height == 0:
+			vs.errorf(path, h, "checkpoint cannot be at the genesis height")
+		}
+		if b, err := hex.DecodeString(h); ok && (err != nil || len(b) != 32) {
+			vs.errorf(path, h, "checkpoint should be a hex-encoded block hash of 32 bytes")
+		}
+		if root, ok := cfg.Chain.CheckpointStateRoots[height]; ok {
+			if b, err := hex.DecodeString(root); err != nil || len(b) != 32 {
+				vs.errorf(fmt.Sprintf("chain.checkpointStateRoots[%d]", height), root,
+					"state root should be a hex-encoded hash of 32 bytes")
+			}
+		}
+		for i, d := range cfg.Chain.CheckpointDelegates[height] {
+			if err := addrutil.Validate(d); err != nil {
+				vs.errorf(fmt.Sprintf("chain.checkpointDelegates[%d][%d]", height, i), d,
+					"invalid delegate address: %v", err)
+			}
+		}
+	}
+	return vs.err()
+}
+
+// ValidatePorts validates that the ports the node listens on don't collide with each other
+func ValidatePorts(cfg Config) error {
+	var vs Violations
+	ports := []struct {
+		path string
+		port int
+	}{
+		{"network.port", cfg.Network.Port},
+		{"system.httpStatsPort", cfg.System.HTTPStatsPort},
+		{"system.httpAdminPort", cfg.System.HTTPAdminPort},
+	}
+	if _, ok := cfg.Plugins[GatewayPlugin]; ok {
+		ports = append(ports, struct {
+			path string
+			port int
+		}{"api.port", cfg.API.Port})
+		for i, sub := range cfg.SubChains {
+			ports = append(ports, struct {
+				path string
+				port int
+			}{fmt.Sprintf("subChains[%d].apiPort", i), sub.APIPort})
+		}
+	}
+	used := make(map[int]string)
+	for _, p := range ports {
+		if p.port <= 0 {
+			continue
+		}
+		if path, ok := used[p.port]; ok {
+			vs.errorf(p.path, p.port, "collides with %s", path)
+			continue
+		}
+		used[p.port] = p.path
+	}
+	return vs.err()
+}
+
+// ValidateLog validates the overrides of the module loggers
+func ValidateLog(cfg Config) error {
+	var vs Violations
+	if cfg.Log.ActionTraceRate < 0 || cfg.Log.ActionTraceRate > 1 {
+		vs.errorf("log.actionTraceRate", cfg.Log.ActionTraceRate, "should be between 0 and 1")
+	}
+	names := make([]string, 0, len(cfg.Log.Modules))
+	for name := range cfg.Log.Modules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		m := cfg.Log.Modules[name]
+		path := "log.modules." + name
+		if m.Level != "" {
+			if _, err := log.ParseLevel(m.Level); err != nil {
+				vs.errorf(path+".level", m.Level, "invalid log level: %v", err)
+			}
+		}
+		if m.MaxSizeMB < 0 {
+			vs.errorf(path+".maxSizeMB", m.MaxSizeMB, "max size cannot be negative")
+		}
+		if m.MaxSize > 0 && m.MaxSizeMB != 0 {
+			vs.errorf(path+".maxSizeMB", m.MaxSizeMB, "cannot be given along with maxSize")
+		}
+		isFile := m.Output != "" && m.Output != log.OutputStderr && m.Output != log.OutputStdout
+		if !isFile && (m.RotationSize() > 0 || m.MaxAge > 0) {
+			vs.warnf(path+".output", m.Output, "the rotation only applies to an output file")
+		}
+	}
+	return vs.err()
+}
+
+// ValidateDelegate validates the watch of the delegate and peer list files
+func ValidateDelegate(cfg Config) error {
+	var vs Violations
+	if (cfg.Delegate.AddrsFile != "" || cfg.Delegate.PeersFile != "") && cfg.Delegate.WatchInterval <= 0 {
+		vs.errorf("delegate.watchInterval", cfg.Delegate.WatchInterval,
+			"watch interval should be positive if a delegate or peer list file is given")
+	}
+	if cfg.Delegate.AddrsFile != "" && cfg.Delegate.HistoryFile == "" {
+		vs.errorf("delegate.historyFile", cfg.Delegate.HistoryFile,
+			"history file should be given if a delegate list file is given")
+	}
+	return vs.err()
+}
+
+// ValidateDurations validates all the durations in the config, which cannot be negative. A duration under a
+// millisecond is most likely a plain number meant in seconds or milliseconds but taken as nanoseconds, so it's warned
+// about.
+func ValidateDurations(cfg Config) error {
+	var vs Violations
+	validateDurations(reflect.ValueOf(cfg), "", &vs)
+	return vs.err()
 }
 
-// DoNotValidate validates the given config
-func DoNotValidate(cfg Config) error { return nil }
+func validateDurations(v reflect.Value, path string, vs *Violations) {
+	if v.Type() == durationType {
+		d := time.Duration(v.Int())
+		switch {
+		case d < 0:
+			vs.errorf(path, d, "duration cannot be negative")
+		case d > 0 && d < time.Millisecond:
+			vs.warnf(path, d, "duration is under a millisecond, give the unit if it's a plain number, e.g., 10s")
+		}
+		return
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			validateDurations(v.Elem(), path, vs)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			name, inline := yamlKey(field)
+			if name == "-" {
+				continue
+			}
+			fieldPath := path
+			if !inline {
+				fieldPath = joinPath(path, name)
+			}
+			validateDurations(v.Field(i), fieldPath, vs)
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		for _, key := range keys {
+			validateDurations(v.MapIndex(key), joinPath(path, fmt.Sprint(key.Interface())), vs)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			validateDurations(v.Index(i), fmt.Sprintf("%s[%d]", path, i), vs)
+		}
+	}
+}
@@ -7,17 +7,23 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/unit"
 )
 
 func TestNewDefaultConfig(t *testing.T) {
@@ -25,8 +31,8 @@ func TestNewDefaultConfig(t *testing.T) {
 	require.Nil(t, err)
 }
 
-func TestNewConfigWithoutValidation(t *testing.T) {
-	cfg, err := New(DoNotValidate)
+func TestNewConfigWithWarningsAsNonfatal(t *testing.T) {
+	cfg, err := New(WarningsAsNonfatal())
 	require.Nil(t, err)
 	require.NotNil(t, cfg)
 	exp := Default
@@ -65,7 +71,7 @@ chain:
 	cfg, err := New()
 	require.Nil(t, err)
 	require.NotNil(t, cfg)
-	require.Equal(t, sk.HexString(), cfg.Chain.ProducerPrivKey)
+	require.Equal(t, Secret(sk.HexString()), cfg.Chain.ProducerPrivKey)
 }
 
 func TestNewConfigWithSecret(t *testing.T) {
@@ -105,7 +111,7 @@ chain:
 	cfg, err := New()
 	require.Nil(t, err)
 	require.NotNil(t, cfg)
-	require.Equal(t, sk.HexString(), cfg.Chain.ProducerPrivKey)
+	require.Equal(t, Secret(sk.HexString()), cfg.Chain.ProducerPrivKey)
 }
 
 func TestNewConfigWithLookupEnv(t *testing.T) {
@@ -147,6 +153,353 @@ chain:
 	require.NotNil(t, cfg)
 }
 
+func TestNewConfigWithEnvOverrides(t *testing.T) {
+	require := require.New(t)
+
+	cfgStr := `
+network:
+    port: 1000
+    host: "1.2.3.4"
+`
+	_overwritePath = filepath.Join(os.TempDir(), "config.yaml")
+	require.NoError(ioutil.WriteFile(_overwritePath, []byte(cfgStr), 0666))
+	defer func() {
+		require.NoError(os.Remove(_overwritePath))
+		_overwritePath = ""
+	}()
+
+	envs := map[string]string{
+		"IOTEX_NETWORK_PORT":                "2000",
+		"IOTEX_NETWORK_BOOTSTRAPNODES":      "/ip4/1.2.3.4/tcp/4689, /ip4/5.6.7.8/tcp/4689",
+		"IOTEX_NETWORK_DEDUPWINDOW":         "1m30s",
+		"IOTEX_CHAIN_CHAINDBPATH":           "/var/iotex/chain.db",
+		"IOTEX_CHAIN_ENABLETRIELESSSTATEDB": "false",
+		"IOTEX_ACTPOOL_MAXNUMACTSPERPOOL":   "10000",
+		"IOTEX_CONSENSUS_ROLLDPOS_DELAY":    "3s",
+	}
+	for key, value := range envs {
+		require.NoError(os.Setenv(key, value))
+	}
+	defer func() {
+		for key := range envs {
+			require.NoError(os.Unsetenv(key))
+		}
+	}()
+
+	cfg, err := New()
+	require.NoError(err)
+	// env > file > defaults
+	require.Equal(2000, cfg.Network.Port)
+	require.Equal("1.2.3.4", cfg.Network.Host)
+	require.Equal(Default.Network.ExternalPort, cfg.Network.ExternalPort)
+	require.Equal([]string{"/ip4/1.2.3.4/tcp/4689", "/ip4/5.6.7.8/tcp/4689"}, cfg.Network.BootstrapNodes)
+	require.Equal(90*time.Second, cfg.Network.DedupWindow)
+	require.Equal("/var/iotex/chain.db", cfg.Chain.ChainDBPath)
+	require.False(cfg.Chain.EnableTrielessStateDB)
+	require.Equal(uint64(10000), cfg.ActPool.MaxNumActsPerPool)
+	require.Equal(3*time.Second, cfg.Consensus.RollDPoS.Delay)
+
+	// a malformed value is reported with the variable name
+	require.NoError(os.Setenv("IOTEX_NETWORK_PORT", "port"))
+	_, err = New()
+	require.Equal(ErrInvalidCfg, errors.Cause(err))
+	require.True(strings.Contains(err.Error(), "IOTEX_NETWORK_PORT"))
+}
+
+func TestNewSubConfigWithEnvOverrides(t *testing.T) {
+	require := require.New(t)
+
+	cfgStr := `
+chain:
+    id: 2
+    chainDBPath: "./sub.chain.db"
+`
+	_subChainPath = filepath.Join(os.TempDir(), "config.sub.yaml")
+	require.NoError(ioutil.WriteFile(_subChainPath, []byte(cfgStr), 0666))
+	defer func() {
+		require.NoError(os.Remove(_subChainPath))
+		_subChainPath = ""
+	}()
+
+	envs := map[string]string{
+		"IOTEX_CHAIN_CHAINDBPATH":         "/var/iotex/chain.db",
+		"IOTEX_CHAIN_TRIEDBPATH":          "/var/iotex/trie.db",
+		"IOTEX_SUBCHAIN_CHAIN_TRIEDBPATH": "/var/iotex/sub.trie.db",
+		"IOTEX_SUBCHAIN_NETWORK_PORT":     "2000",
+	}
+	for key, value := range envs {
+		require.NoError(os.Setenv(key, value))
+	}
+	defer func() {
+		for key := range envs {
+			require.NoError(os.Unsetenv(key))
+		}
+	}()
+
+	// the overrides of the root chain don't apply to the sub-chain, which has its own
+	cfg, err := NewSub()
+	require.NoError(err)
+	require.Equal(uint32(2), cfg.Chain.ID)
+	require.Equal("./sub.chain.db", cfg.Chain.ChainDBPath)
+	require.Equal("/var/iotex/sub.trie.db", cfg.Chain.TrieDBPath)
+	require.Equal(2000, cfg.Network.Port)
+
+	cfg, err = New()
+	require.NoError(err)
+	require.Equal("/var/iotex/chain.db", cfg.Chain.ChainDBPath)
+	require.Equal("/var/iotex/trie.db", cfg.Chain.TrieDBPath)
+	require.Equal(Default.Network.Port, cfg.Network.Port)
+}
+
+func TestNewConfigWithDurationsAndSizes(t *testing.T) {
+	require := require.New(t)
+
+	cfgStr := `
+network:
+    dedupWindow: 500ms
+blockSync:
+    interval: 2m
+    maxBatchBytes: 1MB
+actPool:
+    actionExpiry: 600000000000
+    maxTransferPayloadSize: 512
+log:
+    modules:
+        consensus:
+            output: consensus.log
+            maxSize: 4MB
+        network:
+            output: network.log
+            maxSizeMB: 10
+`
+	_overwritePath = filepath.Join(os.TempDir(), "config.yaml")
+	require.NoError(ioutil.WriteFile(_overwritePath, []byte(cfgStr), 0666))
+	defer func() {
+		require.NoError(os.Remove(_overwritePath))
+		_overwritePath = ""
+	}()
+	require.NoError(os.Setenv("IOTEX_API_STREAMBUFFERSIZE", "512"))
+	require.NoError(os.Setenv("IOTEX_CONSENSUS_ROLLDPOS_DELAY", "3000000000"))
+	defer func() {
+		require.NoError(os.Unsetenv("IOTEX_API_STREAMBUFFERSIZE"))
+		require.NoError(os.Unsetenv("IOTEX_CONSENSUS_ROLLDPOS_DELAY"))
+	}()
+
+	cfg, err := New()
+	require.NoError(err)
+	require.Equal(500*time.Millisecond, cfg.Network.DedupWindow)
+	require.Equal(2*time.Minute, cfg.BlockSync.Interval)
+	// the plain numbers are the legacy nanoseconds
+	require.Equal(10*time.Minute, cfg.ActPool.ActionExpiry)
+	require.Equal(3*time.Second, cfg.Consensus.RollDPoS.Delay)
+	require.Equal(int64(4<<20), cfg.Log.Modules["consensus"].RotationSize())
+	require.Equal(int64(10<<20), cfg.Log.Modules["network"].RotationSize())
+	require.Equal(unit.MB, cfg.BlockSync.MaxBatchBytes)
+	require.Equal(512*unit.Byte, cfg.ActPool.MaxTransferPayloadSize)
+
+	// the plain numbers of sizes are bytes
+	require.NoError(ioutil.WriteFile(_overwritePath, []byte("log:\n  modules:\n    consensus:\n      maxSize: 1024\n"), 0666))
+	cfg, err = Load(_overwritePath)
+	require.NoError(err)
+	require.Equal(unit.KB, cfg.Log.Modules["consensus"].MaxSize)
+
+	require.NoError(ioutil.WriteFile(_overwritePath, []byte("log:\n  modules:\n    consensus:\n      maxSize: 4XB\n"), 0666))
+	_, err = New()
+	require.Error(err)
+	require.True(strings.Contains(err.Error(), `invalid size "4XB"`))
+
+	require.NoError(ioutil.WriteFile(_overwritePath, []byte("blockSync:\n  interval: 2x\n"), 0666))
+	_, err = New()
+	require.Error(err)
+	require.True(strings.Contains(err.Error(), "2x"))
+}
+
+func TestNewConfigWithKeyReferences(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := keypair.GenerateKey()
+	require.NoError(err)
+	dir, err := ioutil.TempDir("", "keyref")
+	require.NoError(err)
+	defer func() {
+		require.NoError(os.RemoveAll(dir))
+	}()
+	ks := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
+	account, err := ks.ImportECDSA(sk.EcdsaPrivateKey(), "passphrase")
+	require.NoError(err)
+
+	_overwritePath = filepath.Join(dir, "config.yaml")
+	defer func() { _overwritePath = "" }()
+	newConfig := func(producerPrivKey string) (Config, error) {
+		cfgStr := fmt.Sprintf(`
+chain:
+    producerPrivKey: "%s"
+`,
+			producerPrivKey,
+		)
+		require.NoError(ioutil.WriteFile(_overwritePath, []byte(cfgStr), 0666))
+		return New()
+	}
+	setEnv := func(name, value string) func() {
+		oldValue, oldExist := os.LookupEnv(name)
+		require.NoError(os.Setenv(name, value))
+		return func() {
+			if oldExist {
+				require.NoError(os.Setenv(name, oldValue))
+			} else {
+				require.NoError(os.Unsetenv(name))
+			}
+		}
+	}
+
+	// env reference
+	_, err = newConfig("env://IOTEX_TEST_PRODUCER_KEY")
+	require.Equal(ErrInvalidCfg, errors.Cause(err))
+	restore := setEnv("IOTEX_TEST_PRODUCER_KEY", sk.HexString())
+	cfg, err := newConfig("env://IOTEX_TEST_PRODUCER_KEY")
+	restore()
+	require.NoError(err)
+	require.Equal(Secret(sk.HexString()), cfg.Chain.ProducerPrivKey)
+	require.Equal(Secret(sk.HexString()), cfg.Network.MasterKey)
+
+	// keystore reference with the passphrase in the environment
+	keystoreRef := KeystoreRefPrefix + account.URL.Path
+	restore = setEnv(KeystorePassphraseEnv, "passphrase")
+	cfg, err = newConfig(keystoreRef)
+	require.NoError(err)
+	require.Equal(Secret(sk.HexString()), cfg.Chain.ProducerPrivKey)
+	require.Equal(Secret(sk.HexString()), cfg.Network.MasterKey)
+	require.NoError(os.Setenv(KeystorePassphraseEnv, "wrong"))
+	_, err = newConfig(keystoreRef)
+	require.Error(err)
+	restore()
+
+	// keystore reference with the passphrase prompted for
+	defer func(prompt func(string) (string, error)) { readPassphrase = prompt }(readPassphrase)
+	require.NoError(os.Unsetenv(KeystorePassphraseEnv))
+	readPassphrase = func(path string) (string, error) {
+		require.Equal(account.URL.Path, path)
+		return "passphrase", nil
+	}
+	cfg, err = newConfig(keystoreRef)
+	require.NoError(err)
+	require.Equal(Secret(sk.HexString()), cfg.Chain.ProducerPrivKey)
+
+	// missing passphrase
+	readPassphrase = promptPassphrase
+	_, err = newConfig(keystoreRef)
+	require.Equal(ErrMissingPassphrase, errors.Cause(err))
+}
+
+func TestNewConfigWithSecretsFile(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "secrets")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	sk, err := keypair.GenerateKey()
+	require.NoError(err)
+	secretsPath := filepath.Join(dir, "secrets.yaml")
+	_overwritePath = filepath.Join(dir, "config.yaml")
+	defer func() { _overwritePath = "" }()
+	cfgStr := fmt.Sprintf(`
+chain:
+    strictKeyReferences: true
+secretsPath: %s
+`,
+		secretsPath,
+	)
+	require.NoError(ioutil.WriteFile(_overwritePath, []byte(cfgStr), 0644))
+	secretsStr := fmt.Sprintf(`
+chain.producerPrivKey: "%s"
+db.RDS.awsPass: "rds-password"
+`,
+		sk.HexString(),
+	)
+	require.NoError(ioutil.WriteFile(secretsPath, []byte(secretsStr), 0600))
+
+	// the plaintext keys in the secrets file pass the strict key references
+	cfg, err := New()
+	require.NoError(err)
+	require.Equal(secretsPath, cfg.SecretsPath)
+	require.Equal(Secret(sk.HexString()), cfg.Chain.ProducerPrivKey)
+	require.Equal(Secret(sk.HexString()), cfg.Network.MasterKey)
+	require.Equal(Secret("rds-password"), cfg.DB.RDS.AwsPass)
+
+	// the secrets file readable by others is refused, unless the permission check is skipped
+	require.NoError(os.Chmod(secretsPath, 0644))
+	_, err = New()
+	require.Equal(ErrInvalidCfg, errors.Cause(err))
+	require.Contains(err.Error(), "accessible by group or others")
+	cfg, err = New(InsecureSecretsFile())
+	require.NoError(err)
+	require.Equal(Secret(sk.HexString()), cfg.Chain.ProducerPrivKey)
+
+	// the secrets file only sets the secrets
+	for _, secretsStr := range []string{"chain.id: 2\n", "chain.producerKey: abc\n", "chain: {producerPrivKey: abc}\n"} {
+		require.NoError(ioutil.WriteFile(secretsPath, []byte(secretsStr), 0600))
+		_, err = New()
+		require.Error(err, secretsStr)
+	}
+	require.NoError(os.Remove(secretsPath))
+	_, err = New()
+	require.Error(err)
+}
+
+func TestSecret(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := keypair.GenerateKey()
+	require.NoError(err)
+	cfg := Default
+	cfg.Chain.ProducerPrivKey = Secret(sk.HexString())
+	cfg.Network.MasterKey = ProducerKeystorePlaceholder
+	cfg.DB.RDS.AwsPass = "rds-password"
+
+	require.Equal(RedactedSecret, cfg.Chain.ProducerPrivKey.String())
+	require.Equal("", Secret("").String())
+	for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+		dump := fmt.Sprintf(format, cfg)
+		require.NotContains(dump, sk.HexString(), format)
+		require.NotContains(dump, "rds-password", format)
+		require.Contains(dump, RedactedSecret, format)
+		require.Contains(dump, ProducerKeystorePlaceholder, format)
+	}
+	dump, err := json.Marshal(cfg.Chain)
+	require.NoError(err)
+	require.NotContains(string(dump), sk.HexString())
+
+	// the config dump redacts the secrets
+	dump, err = Marshal(cfg)
+	require.NoError(err)
+	require.NotContains(string(dump), sk.HexString())
+	require.NotContains(string(dump), "rds-password")
+	require.Contains(string(dump), "producerPrivKey: '******'")
+	require.Contains(string(dump), "masterKey: "+ProducerKeystorePlaceholder)
+}
+
+func TestValidateKeyReferences(t *testing.T) {
+	require := require.New(t)
+
+	cfg := Default
+	require.NoError(ValidateKeyReferences(cfg))
+	sk, err := keypair.GenerateKey()
+	require.NoError(err)
+	cfg.Network.MasterKey = Secret(sk.HexString())
+	require.NoError(ValidateKeyReferences(cfg))
+
+	cfg.Chain.StrictKeyReferences = true
+	err = ValidateKeyReferences(cfg)
+	require.Equal(ErrInvalidCfg, errors.Cause(err))
+	require.True(strings.Contains(err.Error(), "network.masterKey: carries a plaintext private key"))
+	cfg.Network.MasterKey = EnvRefPrefix + "IOTEX_TEST_MASTER_KEY"
+	require.NoError(ValidateKeyReferences(cfg))
+	cfg.Chain.ProducerPrivKey = Secret(sk.HexString())
+	err = ValidateKeyReferences(cfg)
+	require.Equal(ErrInvalidCfg, errors.Cause(err))
+	require.True(strings.Contains(err.Error(), "chain.producerPrivKey: carries a plaintext private key"))
+}
+
 func TestValidateDispatcher(t *testing.T) {
 	cfg := Default
 	cfg.Dispatcher.EventChanSize = 0
@@ -171,6 +524,15 @@ func TestValidateRollDPoS(t *testing.T) {
 		t,
 		strings.Contains(err.Error(), "roll-DPoS event chan size should be greater than 0"),
 	)
+
+	cfg.Consensus.RollDPoS.FSM.EventChanSize = 1
+	require.NoError(t, ValidateRollDPoS(cfg))
+	cfg.Genesis.Delegates = nil
+	err = ValidateRollDPoS(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "genesis.poll.delegates: roll-DPoS requires at least 1 delegate"))
+	cfg.Genesis.EnableGravityChainVoting = true
+	require.NoError(t, ValidateRollDPoS(cfg))
 }
 
 func TestValidateActPool(t *testing.T) {
@@ -211,4 +573,317 @@ func TestValidateActPool(t *testing.T) {
 			"maximum number of actions per pool cannot be less than maximum number of actions per account",
 		),
 	)
+
+	cfg = Default
+	cfg.ActPool.PrioritySenders = []string{"io1"}
+	err = ValidateActPool(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "actPool.prioritySenders[0]=io1: invalid priority sender address"))
+
+	cfg = Default
+	cfg.ActPool.Selection = "LIFO"
+	err = ValidateActPool(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "actPool.selection=LIFO: should be one of FEEPRIORITY and FIFO"))
+
+	cfg = Default
+	cfg.ActPool.MinTransferAmountStr = "-1"
+	cfg.ActPool.SenderRateLimit = 10
+	cfg.ActPool.SenderRateWindow = 0
+	err = ValidateActPool(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "actPool.minTransferAmount=-1: minimal transfer amount"))
+	require.True(t, strings.Contains(err.Error(), "actPool.senderRateWindow=0s: sender rate window should be positive"))
+}
+
+func TestValidateGenesis(t *testing.T) {
+	cfg := Default
+	require.NoError(t, ValidateGenesis(cfg))
+
+	delegate := cfg.Genesis.Delegates[0]
+	delegate.RewardAddrStr = ""
+	cfg.Genesis.Delegates = []genesis.Delegate{delegate}
+	require.NoError(t, ValidateGenesis(cfg))
+
+	delegate.RewardAddrStr = delegate.OperatorAddrStr[:len(delegate.OperatorAddrStr)-1]
+	cfg.Genesis.Delegates = []genesis.Delegate{delegate}
+	err := ValidateGenesis(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "invalid delegate reward address"))
+
+	delegate.OperatorAddrStr = "io1"
+	cfg.Genesis.Delegates = []genesis.Delegate{delegate}
+	err = ValidateGenesis(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "invalid delegate operator address"))
+}
+
+func TestValidateCheckpoints(t *testing.T) {
+	cfg := Default
+	require.NoError(t, ValidateCheckpoints(cfg))
+
+	h := strings.Repeat("ab", 32)
+	cfg.Chain.Checkpoints = map[uint64]string{100: h}
+	require.NoError(t, ValidateCheckpoints(cfg))
+
+	cfg.Chain.Checkpoints = map[uint64]string{0: h}
+	err := ValidateCheckpoints(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "genesis height"))
+
+	cfg.Chain.Checkpoints = map[uint64]string{100: h[:62]}
+	err = ValidateCheckpoints(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "chain.checkpoints[100]"))
+
+	cfg.Chain.Checkpoints = map[uint64]string{100: h}
+	cfg.Chain.CheckpointStateRoots = map[uint64]string{100: h}
+	cfg.Chain.CheckpointDelegates = map[uint64][]string{100: {cfg.ProducerAddress().String()}}
+	require.NoError(t, ValidateCheckpoints(cfg))
+
+	cfg.Chain.CheckpointStateRoots = map[uint64]string{100: "root"}
+	cfg.Chain.CheckpointDelegates = map[uint64][]string{100: {"delegate"}}
+	err = ValidateCheckpoints(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "chain.checkpointStateRoots[100]"))
+	require.True(t, strings.Contains(err.Error(), "chain.checkpointDelegates[100][0]"))
+
+	cfg.Chain.CheckpointStateRoots = map[uint64]string{200: h}
+	cfg.Chain.CheckpointDelegates = map[uint64][]string{}
+	err = ValidateCheckpoints(cfg)
+	require.Equal(t, ErrInvalidCfg, errors.Cause(err))
+	require.True(t, strings.Contains(err.Error(), "chain.checkpoints[200]: state root or delegates are pinned"))
+}
+
+func TestDiffAndUpdate(t *testing.T) {
+	require := require.New(t)
+
+	a := Default
+	require.Empty(Diff(a, a))
+
+	b := Default
+	b.ActPool.MaxNumActsPerPool = a.ActPool.MaxNumActsPerPool * 2
+	b.ActPool.MaxGasLimitPerPool = a.ActPool.MaxGasLimitPerPool * 2
+	b.Chain.ChainDBPath = "./other.db"
+	b.Genesis.BlockInterval = a.Genesis.BlockInterval * 2
+	require.Equal(
+		[]string{
+			"chain.chainDBPath",
+			"actPool.maxNumActsPerPool",
+			"actPool.MaxGasLimitPerPool",
+			"genesis.blockchain.blockInterval",
+		},
+		Diff(a, b),
+	)
+
+	require.NoError(a.Update(b, "actPool.maxNumActsPerPool", "genesis.blockchain.blockInterval"))
+	require.Equal(b.ActPool.MaxNumActsPerPool, a.ActPool.MaxNumActsPerPool)
+	require.Equal(b.Genesis.BlockInterval, a.Genesis.BlockInterval)
+	require.Equal(Default.Chain.ChainDBPath, a.Chain.ChainDBPath)
+	require.Equal([]string{"chain.chainDBPath", "actPool.MaxGasLimitPerPool"}, Diff(a, b))
+
+	err := a.Update(b, "actPool.notExist")
+	require.Equal(ErrInvalidCfg, errors.Cause(err))
+}
+
+func TestValidateConsensus(t *testing.T) {
+	require := require.New(t)
+
+	cfg := Default
+	cfg.Consensus.Scheme = StandaloneScheme
+	cfg.Consensus.RequireDelegateReachability = true
+	err := ValidateConsensus(cfg)
+	require.Equal(ErrInvalidCfg, errors.Cause(err))
+	vs := err.(Violations)
+	require.Len(vs, 1)
+	require.Equal(SeverityWarning, vs[0].Severity)
+	require.Equal("consensus.requireDelegateReachability", vs[0].Path)
+	// the standalone scheme doesn't require the delegate addresses
+	require.NoError(ValidateRollDPoS(cfg))
+
+	cfg.Consensus.Scheme = RollDPoSScheme
+	require.NoError(ValidateConsensus(cfg))
+	err = ValidateRollDPoS(cfg)
+	require.Equal(ErrInvalidCfg, errors.Cause(err))
+	require.True(strings.Contains(err.Error(), "consensus.delegateAddrs=[]: delegate addresses are required"))
+
+	cfg.Consensus.Scheme = "POW"
+	err = ValidateConsensus(cfg)
+	require.Equal(ErrInvalidCfg, errors.Cause(err))
+	require.True(strings.Contains(err.Error(), "consensus.scheme=POW"))
+}
+
+func TestValidateConsensus_SlotDelegates(t *testing.T) {
+	require := require.New(t)
+
+	cfg := Default
+	producer := cfg.ProducerAddress().String()
+	cfg.Consensus.SlotDelegates = []string{producer}
+	require.NoError(ValidateConsensus(cfg))
+
+	cfg.Consensus.SlotDelegates = []string{producer, "io1invalid", strings.ToUpper(producer)}
+	err := ValidateConsensus(cfg)
+	require.Equal(ErrInvalidCfg, errors.Cause(err))
+	vs := err.(Violations)
+	require.Len(vs, 2)
+	require.Equal("consensus.slotDelegates[1]", vs[0].Path)
+	require.Equal("consensus.slotDelegates[2]", vs[1].Path)
+	require.True(strings.Contains(vs[1].Reason, "duplicate delegate address"))
+
+	// the node never mints if it isn't a delegate
+	cfg.Consensus.SlotDelegates = []string{"io1mflp9m6hcgm2qcghchsdqj3z3eccrnekx9p0ms"}
+	err = ValidateConsensus(cfg)
+	require.Equal(ErrInvalidCfg, errors.Cause(err))
+	vs = err.(Violations)
+	require.Len(vs, 1)
+	require.Equal(SeverityWarning, vs[0].Severity)
+	require.True(strings.Contains(vs[0].Reason, "never mints"))
+
+	// the node mints once per block interval, which could miss a shorter slot
+	cfg = Default
+	cfg.Consensus.Scheme = StandaloneScheme
+	cfg.Consensus.SlotDuration = cfg.Genesis.BlockInterval
+	require.NoError(ValidateConsensus(cfg))
+	cfg.Consensus.SlotDuration = cfg.Genesis.BlockInterval / 2
+	err = ValidateConsensus(cfg)
+	require.Equal(ErrInvalidCfg, errors.Cause(err))
+	vs = err.(Violations)
+	require.Len(vs, 1)
+	require.Equal("consensus.slotDuration", vs[0].Path)
+	require.Equal(SeverityError, vs[0].Severity)
+}
+
+func TestValidatePorts(t *testing.T) {
+	require := require.New(t)
+
+	cfg := Default
+	require.NoError(ValidatePorts(cfg))
+
+	cfg.System.HTTPAdminPort = cfg.Network.Port
+	err := ValidatePorts(cfg)
+	require.Equal(ErrInvalidCfg, errors.Cause(err))
+	require.True(strings.Contains(err.Error(), "system.httpAdminPort=4689: collides with network.port"))
+
+	// the api port only matters if the gateway plugin is enabled
+	cfg = Default
+	cfg.Plugins = map[int]interface{}{}
+	cfg.API.Port = cfg.System.HTTPStatsPort
+	require.NoError(ValidatePorts(cfg))
+	cfg.Plugins[GatewayPlugin] = nil
+	err = ValidatePorts(cfg)
+	require.Equal(ErrInvalidCfg, errors.Cause(err))
+	require.True(strings.Contains(err.Error(), "api.port=8080: collides with system.httpStatsPort"))
+}
+
+func TestValidateLog(t *testing.T) {
+	require := require.New(t)
+
+	cfg := Default
+	cfg.Log.Modules = map[string]log.ModuleConfig{
+		"consensus": {Level: "debug", Output: "consensus.log", MaxSizeMB: 100, MaxAge: time.Hour},
+		"network":   {Level: "error"},
+	}
+	require.NoError(ValidateLog(cfg))
+
+	cfg.Log.Modules = map[string]log.ModuleConfig{
+		"consensus": {Level: "verbose"},
+		"network":   {Output: log.OutputStderr, MaxSizeMB: 100},
+		"p2p":       {Output: "p2p.log", MaxSize: 100 * unit.MB, MaxSizeMB: 100},
+	}
+	err := ValidateLog(cfg)
+	require.Equal(ErrInvalidCfg, errors.Cause(err))
+	vs := err.(Violations)
+	require.Len(vs, 3)
+	require.Equal("log.modules.consensus.level", vs[0].Path)
+	require.Equal(SeverityError, vs[0].Severity)
+	require.Equal("log.modules.network.output", vs[1].Path)
+	require.Equal(SeverityWarning, vs[1].Severity)
+	require.Equal("log.modules.p2p.maxSizeMB", vs[2].Path)
+	require.Equal(SeverityError, vs[2].Severity)
+
+	cfg = Default
+	cfg.Log.ActionTraceRate = 1
+	require.NoError(ValidateLog(cfg))
+	cfg.Log.ActionTraceRate = 1.5
+	err = ValidateLog(cfg)
+	require.Equal(ErrInvalidCfg, errors.Cause(err))
+	require.True(strings.Contains(err.Error(), "log.actionTraceRate=1.5: should be between 0 and 1"))
+}
+
+func TestValidateDurations(t *testing.T) {
+	require := require.New(t)
+
+	cfg := Default
+	require.NoError(ValidateDurations(cfg))
+
+	cfg.ActPool.ActionExpiry = -time.Second
+	cfg.BlockSync.Interval = 10
+	cfg.Genesis.BlockInterval = -10 * time.Second
+	cfg.Log.Modules = map[string]log.ModuleConfig{
+		"consensus": {Output: "consensus.log", MaxAge: -time.Hour},
+	}
+	err := ValidateDurations(cfg)
+	require.Equal(ErrInvalidCfg, errors.Cause(err))
+	vs := err.(Violations)
+	require.Len(vs, 4)
+	require.Equal("actPool.actionExpiry=-1s: duration cannot be negative", vs[0].String())
+	require.Equal(SeverityError, vs[0].Severity)
+	require.Equal("blockSync.interval", vs[1].Path)
+	require.Equal(SeverityWarning, vs[1].Severity)
+	require.Equal("log.modules.consensus.maxAge=-1h0m0s: duration cannot be negative", vs[2].String())
+	require.Equal("genesis.blockchain.blockInterval=-10s: duration cannot be negative", vs[3].String())
+}
+
+func TestValidateAll(t *testing.T) {
+	require := require.New(t)
+
+	cfg := Default
+	require.NoError(ValidateAll(cfg))
+
+	// all the violations are reported along with their paths and values
+	cfg.Dispatcher.EventChanSize = 0
+	cfg.ActPool.MaxNumActsPerAcct = 0
+	cfg.API.TpsWindow = 10
+	cfg.API.RangeQueryLimit = 5
+	err := ValidateAll(cfg)
+	require.Equal(ErrInvalidCfg, errors.Cause(err))
+	vs := err.(Violations)
+	require.Len(vs, 3)
+	require.Equal(
+		"invalid config value: "+
+			"dispatcher.eventChanSize=0: dispatcher event chan size should be greater than 0; "+
+			"api.rangeQueryLimit=5: range query upper limit cannot be less than tps window 10; "+
+			"actPool.maxNumActsPerAcct=0: maximum number of actions per pool or per account cannot be zero or negative",
+		err.Error(),
+	)
+	require.NoError(ValidateAll(cfg, WithValidates(ValidateGenesis)))
+
+	// the warnings are fatal unless WarningsAsNonfatal is given, while the errors are always fatal
+	cfg = Default
+	cfg.Consensus.RequireDelegateReachability = true
+	err = ValidateAll(cfg)
+	require.Equal(ErrInvalidCfg, errors.Cause(err))
+	require.NoError(ValidateAll(cfg, WarningsAsNonfatal()))
+	cfg.Dispatcher.EventChanSize = 0
+	err = ValidateAll(cfg, WarningsAsNonfatal())
+	require.Equal(ErrInvalidCfg, errors.Cause(err))
+	require.Len(err.(Violations), 1)
+}
+
+func TestValidateDelegate(t *testing.T) {
+	require := require.New(t)
+
+	cfg := Default
+	cfg.Delegate.WatchInterval = 0
+	require.NoError(ValidateDelegate(cfg))
+	cfg.Delegate.AddrsFile = "delegates"
+	err := ValidateDelegate(cfg)
+	require.Equal(ErrInvalidCfg, errors.Cause(err))
+	require.True(strings.Contains(err.Error(), "delegate.watchInterval=0s: watch interval should be positive"))
+
+	cfg.Delegate.WatchInterval = time.Second
+	cfg.Delegate.HistoryFile = ""
+	err = ValidateDelegate(cfg)
+	require.Equal(ErrInvalidCfg, errors.Cause(err))
+	require.True(strings.Contains(err.Error(), "delegate.historyFile=: history file should be given"))
 }
@@ -0,0 +1,150 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package config
+
+import (
+	"encoding"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// EnvOverridePrefix prefixes the environment variables overriding config values. The variable of a config value is
+// named by the prefix followed by the upper-cased YAML keys on the path to the value joined by underscores, e.g.,
+// IOTEX_NETWORK_PORT overrides network.port, and IOTEX_CHAIN_CHAINDBPATH overrides chain.chainDBPath. A list value
+// is given comma-separated, e.g., IOTEX_NETWORK_BOOTSTRAPNODES=/ip4/1.2.3.4/tcp/4689,/ip4/5.6.7.8/tcp/4689. The
+// overrides take precedence over the config files, which take precedence over the defaults.
+const EnvOverridePrefix = "IOTEX"
+
+// SubChainEnvOverridePrefix prefixes the environment variables overriding the sub-chain config values, e.g.,
+// IOTEX_SUBCHAIN_CHAIN_CHAINDBPATH overrides chain.chainDBPath of the sub-chain, so that the overrides of the root
+// chain, e.g., its DB paths, aren't applied to the sub-chain as well.
+const SubChainEnvOverridePrefix = EnvOverridePrefix + "_SUBCHAIN"
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// applyEnvOverrides sets the config values overridden by the environment variables of the given prefix
+func applyEnvOverrides(cfg *Config, prefix string) error {
+	return overrideStruct(reflect.ValueOf(cfg).Elem(), prefix)
+}
+
+func overrideStruct(v reflect.Value, prefix string) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			// unexported
+			continue
+		}
+		name, inline := yamlKey(field)
+		if name == "-" {
+			continue
+		}
+		key := prefix
+		if !inline {
+			key = prefix + "_" + strings.ToUpper(name)
+		}
+		fv := v.Field(i)
+		if fv.Kind() == reflect.Struct && fv.Type() != durationType {
+			if err := overrideStruct(fv, key); err != nil {
+				return err
+			}
+			continue
+		}
+		value, ok := os.LookupEnv(key)
+		if !ok {
+			continue
+		}
+		if err := setValue(fv, value); err != nil {
+			return errors.Wrapf(ErrInvalidCfg, "failed to apply environment variable %s=%q: %v", key, value, err)
+		}
+	}
+	return nil
+}
+
+// yamlKey returns the YAML key of the field, and whether the field is inlined into its parent
+func yamlKey(field reflect.StructField) (string, bool) {
+	tag := strings.Split(field.Tag.Get("yaml"), ",")
+	for _, opt := range tag[1:] {
+		if opt == "inline" {
+			return "", true
+		}
+	}
+	if tag[0] != "" {
+		return tag[0], false
+	}
+	if field.Anonymous {
+		return "", true
+	}
+	return field.Name, false
+}
+
+func setValue(v reflect.Value, value string) error {
+	if v.Type() == durationType {
+		// a plain number is taken as nanoseconds, like the config files do
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			v.SetInt(n)
+			return nil
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(value))
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		elems := []string{}
+		if value != "" {
+			elems = strings.Split(value, ",")
+		}
+		s := reflect.MakeSlice(v.Type(), len(elems), len(elems))
+		for i, elem := range elems {
+			if err := setValue(s.Index(i), strings.TrimSpace(elem)); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+	default:
+		return errors.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
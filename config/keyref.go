@@ -0,0 +1,141 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/ssh/terminal"
+
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+const (
+	// KeystoreRefPrefix prefixes a private key config value referring to a keystore file, e.g., keystore:///path/key
+	KeystoreRefPrefix = "keystore://"
+	// EnvRefPrefix prefixes a private key config value referring to an environment variable, e.g., env://PRODUCER_KEY
+	EnvRefPrefix = "env://"
+	// KeystorePassphraseEnv is the environment variable holding the passphrase of the referred keystore files. The
+	// passphrase is prompted for on the terminal if it isn't set.
+	KeystorePassphraseEnv = "IOTEX_KEYSTORE_PASSPHRASE"
+)
+
+var (
+	// ErrMissingPassphrase indicates the passphrase of a keystore file is neither in the environment nor prompted for
+	ErrMissingPassphrase = errors.New("missing keystore passphrase")
+
+	// readPassphrase reads the passphrase of a keystore file interactively
+	readPassphrase = promptPassphrase
+)
+
+// IsKeyReference returns true if the private key config value refers to a keystore file or an environment variable
+// rather than carrying the key itself
+func IsKeyReference(value string) bool {
+	return strings.HasPrefix(value, KeystoreRefPrefix) || strings.HasPrefix(value, EnvRefPrefix)
+}
+
+// ResolvePrivateKey returns the hex encoded private key a config value refers to. A value which isn't a reference is
+// returned as is.
+func ResolvePrivateKey(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, EnvRefPrefix):
+		name := strings.TrimPrefix(value, EnvRefPrefix)
+		key, ok := os.LookupEnv(name)
+		if !ok || key == "" {
+			return "", errors.Wrapf(ErrInvalidCfg, "environment variable %s of the private key is not set", name)
+		}
+		return key, nil
+	case strings.HasPrefix(value, KeystoreRefPrefix):
+		path := strings.TrimPrefix(value, KeystoreRefPrefix)
+		passphrase, ok := os.LookupEnv(KeystorePassphraseEnv)
+		if !ok {
+			var err error
+			if passphrase, err = readPassphrase(path); err != nil {
+				return "", errors.Wrapf(err, "failed to get the passphrase of keystore %s", path)
+			}
+		}
+		sk, err := keypair.KeystoreToPrivateKey(accounts.Account{URL: accounts.URL{Path: path}}, passphrase)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to load the private key from keystore %s", path)
+		}
+		return sk.HexString(), nil
+	default:
+		return value, nil
+	}
+}
+
+// ValidateKeyReferences warns on the private keys carried by the config in plaintext, and refuses them if
+// Chain.StrictKeyReferences is set. The random producer key of Default is exempted, since it never shows up in a file,
+// and so are the keys set by the secrets file, which is readable by the owner only.
+func ValidateKeyReferences(cfg Config) error {
+	var vs Violations
+	for _, key := range []struct {
+		name  string
+		value Secret
+	}{
+		{"chain.producerPrivKey", cfg.Chain.ProducerPrivKey},
+		{"network.masterKey", cfg.Network.MasterKey},
+	} {
+		name, value := key.name, key.value
+		if value == "" || value == Default.Chain.ProducerPrivKey || IsKeyReference(string(value)) {
+			continue
+		}
+		if cfg.loadedSecrets[name] {
+			continue
+		}
+		if cfg.Chain.StrictKeyReferences {
+			vs.errorf(name, nil, "carries a plaintext private key, use %s or %s instead", KeystoreRefPrefix, EnvRefPrefix)
+			continue
+		}
+		log.L().Warn(
+			"!!! PLAINTEXT PRIVATE KEY IN CONFIG !!! Refer to a keystore file or an environment variable instead.",
+			zap.String("field", name),
+		)
+	}
+	return vs.err()
+}
+
+// resolvePrivateKeys replaces the private key references of the config with the keys they refer to
+func (cfg *Config) resolvePrivateKeys() error {
+	producerKeyRef := cfg.Chain.ProducerPrivKey
+	producerKey, err := ResolvePrivateKey(string(producerKeyRef))
+	if err != nil {
+		return errors.Wrap(err, "failed to resolve the producer private key")
+	}
+	cfg.Chain.ProducerPrivKey = Secret(producerKey)
+	if cfg.Network.MasterKey == producerKeyRef {
+		// avoid decrypting the same keystore twice
+		cfg.Network.MasterKey = cfg.Chain.ProducerPrivKey
+		return nil
+	}
+	masterKey, err := ResolvePrivateKey(string(cfg.Network.MasterKey))
+	if err != nil {
+		return errors.Wrap(err, "failed to resolve the network master key")
+	}
+	cfg.Network.MasterKey = Secret(masterKey)
+	return nil
+}
+
+func promptPassphrase(path string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !terminal.IsTerminal(fd) {
+		return "", errors.Wrapf(ErrMissingPassphrase, "%s is not set and stdin is not a terminal", KeystorePassphraseEnv)
+	}
+	fmt.Fprintf(os.Stderr, "Enter the passphrase of keystore %s: ", path)
+	passphrase, err := terminal.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+	return string(passphrase), nil
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package config
+
+import (
+	"bytes"
+	"encoding"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+)
+
+// sectionComments describes the top level sections of the config file
+var sectionComments = map[string]string{
+	"network":     "P2P network the node joins",
+	"chain":       "Blockchain DBs and the block producer. Replace the producer keystore placeholder with your own keystore.",
+	"actPool":     "Pool of the pending actions",
+	"consensus":   "Consensus scheme, which is one of ROLLDPOS, STANDALONE and NOOP",
+	"blockSync":   "Syncing the blocks from the peers",
+	"dispatcher":  "Dispatching the messages from the network to the chain services",
+	"api":         "gRPC API serving the clients",
+	"indexer":     "Indexing the actions by address",
+	"system":      "Health checks, metrics and admin endpoints",
+	"db":          "DB options shared by the chain DBs",
+	"log":         "Global logger",
+	"subLogs":     "Loggers of the individual modules, keyed by module name",
+	"subChains":   "Sub-chains run besides the root chain, sharing its network",
+	"delegate":    "Delegate and peer list files watched for changes at runtime",
+	"secretsPath": "Secrets file, which is readable by the owner only, setting the private keys and passwords",
+}
+
+// Marshal encodes the config into a commented YAML file which New reads back to the same config. The genesis, which is
+// read from its own file, and the plugins, which are enabled by the command line flags, are left out. The secrets are
+// redacted, so that the output is safe to show, and they are rather kept in the secrets file.
+func Marshal(cfg Config) ([]byte, error) {
+	var buf bytes.Buffer
+	v := reflect.ValueOf(cfg)
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Name == "Plugins" || field.Name == "Genesis" {
+			continue
+		}
+		key, _ := marshalKey(field)
+		value, ok := marshalValue(v.Field(i))
+		if !ok {
+			continue
+		}
+		out, err := yaml.Marshal(yaml.MapSlice{{Key: key, Value: value}})
+		if err != nil {
+			return nil, err
+		}
+		if buf.Len() > 0 {
+			buf.WriteString("\n")
+		}
+		if comment, ok := sectionComments[key]; ok {
+			fmt.Fprintf(&buf, "# %s\n", comment)
+		}
+		buf.Write(out)
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalGenesis encodes the genesis config into a YAML file which genesis.New reads back to the same genesis
+func MarshalGenesis(g genesis.Genesis) ([]byte, error) {
+	value, _ := marshalValue(reflect.ValueOf(g))
+	return yaml.Marshal(value)
+}
+
+// marshalValue converts v into the value encoded by the YAML encoder under the keys the config loader decodes. It
+// returns false if v should be left out, e.g., a nil pointer, map or slice, or a function.
+func marshalValue(v reflect.Value) (interface{}, bool) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		if v.IsNil() {
+			return nil, false
+		}
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return nil, false
+	}
+	if v.Type() == secretType {
+		return v.Interface().(Secret).String(), true
+	}
+	if v.Type() == durationType {
+		return v.Interface().(fmt.Stringer).String(), true
+	}
+	if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+		text, err := m.MarshalText()
+		if err != nil {
+			return nil, false
+		}
+		return string(text), true
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return marshalValue(v.Elem())
+	case reflect.Struct:
+		return marshalStruct(v), true
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		items := yaml.MapSlice{}
+		for _, key := range keys {
+			if value, ok := marshalValue(v.MapIndex(key)); ok {
+				items = append(items, yaml.MapItem{Key: key.Interface(), Value: value})
+			}
+		}
+		return items, true
+	case reflect.Slice, reflect.Array:
+		items := make([]interface{}, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			if value, ok := marshalValue(v.Index(i)); ok {
+				items = append(items, value)
+			}
+		}
+		return items, true
+	default:
+		return v.Interface(), true
+	}
+}
+
+func marshalStruct(v reflect.Value) yaml.MapSlice {
+	items := yaml.MapSlice{}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		key, inline := marshalKey(field)
+		if key == "-" {
+			continue
+		}
+		value, ok := marshalValue(v.Field(i))
+		if !ok {
+			continue
+		}
+		if inline {
+			if fields, ok := value.(yaml.MapSlice); ok {
+				items = append(items, fields...)
+			}
+			continue
+		}
+		items = append(items, yaml.MapItem{Key: key, Value: value})
+	}
+	return items
+}
+
+// marshalKey returns the YAML key of the field the way the YAML decoder names it, which lowercases the name of an
+// untagged field, and only inlines a field tagged so
+func marshalKey(field reflect.StructField) (string, bool) {
+	tag := strings.Split(field.Tag.Get("yaml"), ",")
+	for _, opt := range tag[1:] {
+		if opt == "inline" {
+			return "", true
+		}
+	}
+	if tag[0] != "" {
+		return tag[0], false
+	}
+	return strings.ToLower(field.Name), false
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package config
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+const (
+	// ProfileDelegate is the profile of a node producing blocks with the roll-DPoS scheme
+	ProfileDelegate = "delegate"
+	// ProfileFullNode is the profile of a node syncing and serving the chain without producing blocks
+	ProfileFullNode = "fullnode"
+	// ProfileStandaloneDev is the profile of a single development node producing a block every second in memory
+	ProfileStandaloneDev = "standalone-dev"
+
+	// ProducerKeystorePlaceholder is the producer private key of the profiles, which refers to a keystore file to be
+	// filled in by the operator
+	ProducerKeystorePlaceholder = KeystoreRefPrefix + "producer.keystore"
+)
+
+// Profiles are the names of the supported profiles
+var Profiles = []string{ProfileDelegate, ProfileFullNode, ProfileStandaloneDev}
+
+// NewProfile returns the default configs tuned for the named profile. The producer private key is left as
+// ProducerKeystorePlaceholder rather than a plaintext key.
+func NewProfile(name string) (Config, error) {
+	cfg := Default
+	cfg.Plugins = make(map[int]interface{})
+	cfg.SubLogs = make(map[string]log.GlobalConfig)
+	cfg.Log.Modules = make(map[string]log.ModuleConfig)
+	cfg.Chain.ProducerPrivKey = ProducerKeystorePlaceholder
+
+	switch name {
+	case ProfileDelegate:
+		cfg.Consensus.Scheme = RollDPoSScheme
+		cfg.Chain.StrictKeyReferences = true
+	case ProfileFullNode:
+		cfg.Consensus.Scheme = NOOPScheme
+	case ProfileStandaloneDev:
+		cfg.Consensus.Scheme = StandaloneScheme
+		cfg.Chain.EnableInMemoryDB = true
+		cfg.Genesis.BlockInterval = time.Second
+	default:
+		return Config{}, errors.Errorf("unknown profile %s, should be one of %v", name, Profiles)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package config
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Diff returns the paths of the config values differing between a and b. A path is made of the YAML keys leading to
+// the value joined by dots, e.g., actPool.maxNumActsPerPool.
+func Diff(a, b Config) []string {
+	return diffValues(reflect.ValueOf(a), reflect.ValueOf(b), "", nil)
+}
+
+func diffValues(a, b reflect.Value, path string, diffs []string) []string {
+	switch {
+	case a.Kind() == reflect.Ptr && !a.IsNil() && !b.IsNil() && a.Elem().Kind() == reflect.Struct:
+		return diffValues(a.Elem(), b.Elem(), path, diffs)
+	case a.Kind() == reflect.Struct && a.Type() != durationType && hasExportedField(a.Type()):
+		t := a.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			// the functions, e.g., the encoders of the logger, couldn't be set by the config file
+			if field.PkgPath != "" || field.Type.Kind() == reflect.Func {
+				continue
+			}
+			name, inline := yamlKey(field)
+			if name == "-" {
+				continue
+			}
+			p := path
+			if !inline {
+				p = joinPath(path, name)
+			}
+			diffs = diffValues(a.Field(i), b.Field(i), p, diffs)
+		}
+		return diffs
+	case (a.Kind() == reflect.Map || a.Kind() == reflect.Slice) && a.Len() == 0 && b.Len() == 0:
+		// a nil map or slice is the same as an empty one
+		return diffs
+	case !reflect.DeepEqual(a.Interface(), b.Interface()):
+		return append(diffs, path)
+	}
+	return diffs
+}
+
+// Update sets the config values of the given paths to the ones in src
+func (cfg *Config) Update(src Config, paths ...string) error {
+	for _, path := range paths {
+		dst, err := lookupPath(reflect.ValueOf(cfg).Elem(), path)
+		if err != nil {
+			return err
+		}
+		v, err := lookupPath(reflect.ValueOf(&src).Elem(), path)
+		if err != nil {
+			return err
+		}
+		dst.Set(v)
+	}
+	return nil
+}
+
+func lookupPath(v reflect.Value, path string) (reflect.Value, error) {
+	for _, key := range strings.Split(path, ".") {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, errors.Wrapf(ErrInvalidCfg, "config path %s goes through a nil value", path)
+			}
+			v = v.Elem()
+		}
+		fv, ok := lookupField(v, key)
+		if !ok {
+			return reflect.Value{}, errors.Wrapf(ErrInvalidCfg, "config path %s doesn't exist", path)
+		}
+		v = fv
+	}
+	return v, nil
+}
+
+// lookupField returns the field of the struct named by the YAML key, which could be in an inlined field
+func lookupField(v reflect.Value, key string) (reflect.Value, bool) {
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name, inline := yamlKey(field)
+		if inline {
+			if fv, ok := lookupField(v.Field(i), key); ok {
+				return fv, true
+			}
+			continue
+		}
+		if name == key {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+func hasExportedField(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).PkgPath == "" {
+			return true
+		}
+	}
+	return false
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
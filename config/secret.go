@@ -0,0 +1,96 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package config
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// RedactedSecret is what a secret is printed as
+const RedactedSecret = "******"
+
+// Secret is a config value which must not leak, e.g., a private key or a password. It is redacted when printed by fmt,
+// encoded into JSON by the loggers, or dumped by Marshal, so the code using it converts it to string explicitly. A key
+// reference, e.g., keystore://producer.keystore, isn't a secret itself, and is printed as is.
+type Secret string
+
+var secretType = reflect.TypeOf(Secret(""))
+
+// String returns the redacted secret
+func (s Secret) String() string {
+	if s == "" || IsKeyReference(string(s)) {
+		return string(s)
+	}
+	return RedactedSecret
+}
+
+// GoString returns the redacted secret for %#v
+func (s Secret) GoString() string {
+	return strconv.Quote(s.String())
+}
+
+// MarshalJSON encodes the redacted secret
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// loadSecrets sets the secrets read from the secrets file at path. The file is a flat YAML map from the config paths of
+// the Secret fields, e.g., chain.producerPrivKey, to their values. It is refused if it is accessible by group or others,
+// unless the permission check is skipped by InsecureSecretsFile.
+func loadSecrets(cfg *Config, path string, opts ...ValidateOption) error {
+	var options validateOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to stat secrets file %s", path)
+	}
+	if perm := info.Mode().Perm(); perm&0077 != 0 && !options.insecureSecretsFile {
+		return errors.Wrapf(
+			ErrInvalidCfg,
+			"secrets file %s is accessible by group or others (mode %04o), restrict it to the owner by chmod 600",
+			path,
+			perm,
+		)
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read secrets file %s", path)
+	}
+	secrets := make(map[string]string)
+	if err := yaml.UnmarshalStrict(data, &secrets); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal secrets file %s", path)
+	}
+	keys := make([]string, 0, len(secrets))
+	for key := range secrets {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	root := reflect.ValueOf(cfg).Elem()
+	cfg.loadedSecrets = make(map[string]bool, len(keys))
+	for _, key := range keys {
+		v, err := lookupPath(root, key)
+		if err != nil {
+			return errors.Wrapf(err, "invalid key in secrets file %s", path)
+		}
+		if v.Type() != secretType {
+			return errors.Wrapf(ErrInvalidCfg, "%s in secrets file %s is not a secret", key, path)
+		}
+		v.SetString(secrets[key])
+		cfg.loadedSecrets[key] = true
+	}
+	return nil
+}
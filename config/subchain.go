@@ -0,0 +1,109 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package config
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+)
+
+// SubChainDBPath returns the path of a DB of the sub-chain, which is the path p of the DB of the root chain with the
+// file name prefixed by the chain ID
+func SubChainDBPath(chainID uint32, p string) string {
+	dir, file := path.Split(p)
+	return path.Join(dir, fmt.Sprintf("chain-%d-%s", chainID, file))
+}
+
+// SubChainConfig returns the config of the sub-chain, which is the config of the root chain with the chain ID, the DB
+// paths, the genesis, the consensus scheme and the API port replaced by the ones of the sub-chain
+func (cfg Config) SubChainConfig(sub SubChain) (Config, error) {
+	subCfg := cfg
+	subCfg.SubChains = nil
+	subCfg.Chain.ID = sub.ID
+	subCfg.Chain.ChainDBPath = sub.ChainDBPath
+	if subCfg.Chain.ChainDBPath == "" {
+		subCfg.Chain.ChainDBPath = SubChainDBPath(sub.ID, cfg.Chain.ChainDBPath)
+	}
+	subCfg.Chain.TrieDBPath = sub.TrieDBPath
+	if subCfg.Chain.TrieDBPath == "" {
+		subCfg.Chain.TrieDBPath = SubChainDBPath(sub.ID, cfg.Chain.TrieDBPath)
+	}
+	if sub.ConsensusScheme != "" {
+		subCfg.Consensus.Scheme = sub.ConsensusScheme
+	}
+	if sub.GenesisPath != "" {
+		g, err := genesis.Load(sub.GenesisPath)
+		if err != nil {
+			return Config{}, errors.Wrapf(err, "failed to load the genesis of sub-chain %d", sub.ID)
+		}
+		subCfg.Genesis = g
+	}
+	// the plugins map is shared with the root config, so copy it before leaving out the API
+	subCfg.Plugins = make(map[int]interface{}, len(cfg.Plugins))
+	for plugin, v := range cfg.Plugins {
+		if plugin == GatewayPlugin && sub.APIPort <= 0 {
+			continue
+		}
+		subCfg.Plugins[plugin] = v
+	}
+	subCfg.API.Port = sub.APIPort
+	return subCfg, nil
+}
+
+// ValidateSubChains validates that the sub-chains have distinct chain IDs and DBs from each other and the root chain
+func ValidateSubChains(cfg Config) error {
+	var vs Violations
+	ids := map[uint32]string{cfg.Chain.ID: "chain.id"}
+	dbPaths := map[string]string{
+		cfg.Chain.ChainDBPath: "chain.chainDBPath",
+		cfg.Chain.TrieDBPath:  "chain.trieDBPath",
+	}
+	for i, sub := range cfg.SubChains {
+		prefix := fmt.Sprintf("subChains[%d]", i)
+		if sub.ID == 0 {
+			vs.errorf(prefix+".id", sub.ID, "sub-chain ID cannot be zero")
+			continue
+		}
+		if p, ok := ids[sub.ID]; ok {
+			vs.errorf(prefix+".id", sub.ID, "collides with %s", p)
+			continue
+		}
+		ids[sub.ID] = prefix + ".id"
+		switch sub.ConsensusScheme {
+		case "", RollDPoSScheme, StandaloneScheme, NOOPScheme:
+		default:
+			vs.errorf(prefix+".consensusScheme", sub.ConsensusScheme,
+				"should be one of %s, %s and %s", RollDPoSScheme, StandaloneScheme, NOOPScheme)
+		}
+		if cfg.Chain.EnableInMemoryDB {
+			continue
+		}
+		for _, db := range []struct {
+			path  string
+			value string
+			def   string
+		}{
+			{prefix + ".chainDBPath", sub.ChainDBPath, cfg.Chain.ChainDBPath},
+			{prefix + ".trieDBPath", sub.TrieDBPath, cfg.Chain.TrieDBPath},
+		} {
+			dbPath := db.value
+			if dbPath == "" {
+				dbPath = SubChainDBPath(sub.ID, db.def)
+			}
+			if p, ok := dbPaths[dbPath]; ok {
+				vs.errorf(db.path, dbPath, "collides with %s", p)
+				continue
+			}
+			dbPaths[dbPath] = db.path
+		}
+	}
+	return vs.err()
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2018 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubChainDBPath(t *testing.T) {
+	t.Parallel()
+
+	chainDBPath := SubChainDBPath(1, Default.Chain.ChainDBPath)
+	trieDBPath := SubChainDBPath(1, Default.Chain.TrieDBPath)
+	assert.Equal(t, "chain-1-chain.db", chainDBPath)
+	assert.Equal(t, "chain-1-trie.db", trieDBPath)
+}
+
+func TestSubChainConfig(t *testing.T) {
+	require := require.New(t)
+
+	genesisFile, err := ioutil.TempFile("", "genesis")
+	require.NoError(err)
+	defer os.Remove(genesisFile.Name())
+	_, err = genesisFile.WriteString("blockchain:\n  blockInterval: 3s\n")
+	require.NoError(err)
+	require.NoError(genesisFile.Close())
+
+	cfg := Default
+	cfg.Plugins = map[int]interface{}{GatewayPlugin: true}
+	cfg.SubChains = []SubChain{
+		{ID: 2},
+		{ID: 3, ChainDBPath: "sub.db", GenesisPath: genesisFile.Name(), ConsensusScheme: NOOPScheme, APIPort: 14015},
+	}
+
+	// the values left empty follow the root chain
+	sub, err := cfg.SubChainConfig(cfg.SubChains[0])
+	require.NoError(err)
+	require.Equal(uint32(2), sub.Chain.ID)
+	require.Equal("chain-2-chain.db", sub.Chain.ChainDBPath)
+	require.Equal("chain-2-trie.db", sub.Chain.TrieDBPath)
+	require.Equal(cfg.Consensus.Scheme, sub.Consensus.Scheme)
+	require.Equal(cfg.Genesis, sub.Genesis)
+	require.Empty(sub.SubChains)
+	// the API of the sub-chain is disabled without a port
+	require.NotContains(sub.Plugins, GatewayPlugin)
+	require.Contains(cfg.Plugins, GatewayPlugin)
+
+	sub, err = cfg.SubChainConfig(cfg.SubChains[1])
+	require.NoError(err)
+	require.Equal(uint32(3), sub.Chain.ID)
+	require.Equal("sub.db", sub.Chain.ChainDBPath)
+	require.Equal("chain-3-trie.db", sub.Chain.TrieDBPath)
+	require.Equal(NOOPScheme, sub.Consensus.Scheme)
+	require.Equal(3*time.Second, sub.Genesis.BlockInterval)
+	require.Contains(sub.Plugins, GatewayPlugin)
+	require.Equal(14015, sub.API.Port)
+
+	_, err = cfg.SubChainConfig(SubChain{ID: 4, GenesisPath: "not-exist.yaml"})
+	require.Error(err)
+}
+
+func TestValidateSubChains(t *testing.T) {
+	require := require.New(t)
+
+	cfg := Default
+	require.NoError(ValidateSubChains(cfg))
+	cfg.SubChains = []SubChain{{ID: 2}, {ID: 3, ConsensusScheme: StandaloneScheme}}
+	require.NoError(ValidateSubChains(cfg))
+
+	cfg.SubChains = []SubChain{
+		{ID: 0},
+		{ID: cfg.Chain.ID},
+		{ID: 2, ChainDBPath: cfg.Chain.ChainDBPath},
+		{ID: 3, ConsensusScheme: "POW"},
+		{ID: 4, TrieDBPath: "chain-3-trie.db"},
+	}
+	err := ValidateSubChains(cfg)
+	require.Equal(ErrInvalidCfg, errors.Cause(err))
+	vs, ok := err.(Violations)
+	require.True(ok)
+	paths := make([]string, 0, len(vs))
+	for _, v := range vs {
+		paths = append(paths, v.Path)
+	}
+	require.Equal([]string{
+		"subChains[0].id",
+		"subChains[1].id",
+		"subChains[2].chainDBPath",
+		"subChains[3].consensusScheme",
+		"subChains[4].trieDBPath",
+	}, paths)
+
+	// the DBs of an in-memory node don't collide
+	cfg.SubChains = []SubChain{{ID: 2, ChainDBPath: cfg.Chain.ChainDBPath}}
+	cfg.Chain.EnableInMemoryDB = true
+	require.NoError(ValidateSubChains(cfg))
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// Severity tells how bad a config violation is
+type Severity int
+
+const (
+	// SeverityError is a violation which always fails the validation
+	SeverityError Severity = iota
+	// SeverityWarning is a violation which fails the validation unless WarningsAsNonfatal is given
+	SeverityWarning
+)
+
+// String returns the name of the severity
+func (s Severity) String() string {
+	if s == SeverityWarning {
+		return "warning"
+	}
+	return "error"
+}
+
+// Violation is a config value breaking a validation rule
+type Violation struct {
+	// Path is the YAML keys leading to the offending value joined by dots, e.g., actPool.maxNumActsPerPool
+	Path string
+	// Value is the offending value, which is nil if it shouldn't be revealed, e.g., a private key
+	Value    interface{}
+	Reason   string
+	Severity Severity
+}
+
+// String returns the violation along with its path and value
+func (v Violation) String() string {
+	if v.Path == "" {
+		return v.Reason
+	}
+	if v.Value == nil {
+		return fmt.Sprintf("%s: %s", v.Path, v.Reason)
+	}
+	return fmt.Sprintf("%s=%v: %s", v.Path, v.Value, v.Reason)
+}
+
+// Violations is the error reporting all the violations found in a config. Its cause is ErrInvalidCfg.
+type Violations []Violation
+
+// Error returns all the violations
+func (vs Violations) Error() string {
+	msgs := make([]string, 0, len(vs))
+	for _, v := range vs {
+		msgs = append(msgs, v.String())
+	}
+	return ErrInvalidCfg.Error() + ": " + strings.Join(msgs, "; ")
+}
+
+// Cause returns ErrInvalidCfg
+func (vs Violations) Cause() error { return ErrInvalidCfg }
+
+func (vs *Violations) add(severity Severity, path string, value interface{}, format string, args ...interface{}) {
+	*vs = append(*vs, Violation{
+		Path:     path,
+		Value:    value,
+		Reason:   fmt.Sprintf(format, args...),
+		Severity: severity,
+	})
+}
+
+// errorf adds a violation of SeverityError
+func (vs *Violations) errorf(path string, value interface{}, format string, args ...interface{}) {
+	vs.add(SeverityError, path, value, format, args...)
+}
+
+// warnf adds a violation of SeverityWarning
+func (vs *Violations) warnf(path string, value interface{}, format string, args ...interface{}) {
+	vs.add(SeverityWarning, path, value, format, args...)
+}
+
+// err returns the violations as an error, or nil if there is none
+func (vs Violations) err() error {
+	if len(vs) == 0 {
+		return nil
+	}
+	return vs
+}
+
+type validateOptions struct {
+	validates           []Validate
+	warningsAsNonfatal  bool
+	insecureSecretsFile bool
+}
+
+// ValidateOption customizes the config validation
+type ValidateOption func(*validateOptions)
+
+// WithValidates validates the config by the given functions rather than Validates
+func WithValidates(validates ...Validate) ValidateOption {
+	return func(opts *validateOptions) {
+		opts.validates = validates
+	}
+}
+
+// WarningsAsNonfatal only logs the violations of SeverityWarning rather than failing the validation, which suits the
+// tests running with partial configs
+func WarningsAsNonfatal() ValidateOption {
+	return func(opts *validateOptions) {
+		opts.warningsAsNonfatal = true
+	}
+}
+
+// InsecureSecretsFile loads the secrets file regardless of its permission, which suits the tests writing it with the
+// default umask
+func InsecureSecretsFile() ValidateOption {
+	return func(opts *validateOptions) {
+		opts.insecureSecretsFile = true
+	}
+}
+
+// ValidateAll applies all the validation functions to the config, and reports all the violations found rather than
+// stopping at the first one
+func ValidateAll(cfg Config, opts ...ValidateOption) error {
+	options := validateOptions{validates: Validates}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	var fatal Violations
+	for _, validate := range options.validates {
+		err := validate(cfg)
+		if err == nil {
+			continue
+		}
+		vs, ok := err.(Violations)
+		if !ok {
+			fatal.errorf("", nil, "%v", err)
+			continue
+		}
+		for _, v := range vs {
+			if v.Severity == SeverityWarning && options.warningsAsNonfatal {
+				log.L().Warn("Config violation.", zap.String("violation", v.String()))
+				continue
+			}
+			fatal = append(fatal, v)
+		}
+	}
+	return fatal.err()
+}
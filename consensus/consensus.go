@@ -8,11 +8,15 @@ package consensus
 
 import (
 	"context"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/facebookgo/clock"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	"github.com/iotexproject/iotex-core/action/protocol/keyrotation"
 	rp "github.com/iotexproject/iotex-core/action/protocol/rolldpos"
 	"github.com/iotexproject/iotex-core/actpool"
 	"github.com/iotexproject/iotex-core/blockchain"
@@ -22,9 +26,16 @@ import (
 	"github.com/iotexproject/iotex-core/consensus/scheme/rolldpos"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/metrics"
 	"github.com/iotexproject/iotex-core/protogen/iotextypes"
 )
 
+var blockMtc = metrics.NewCounter(
+	"iotex_consensus_blocks",
+	"Number of the blocks minted and committed by the consensus, by whether they succeed.",
+	"step", "succeed",
+)
+
 // Consensus is the interface for handling IotxConsensus view change.
 type Consensus interface {
 	lifecycle.StartStopper
@@ -39,13 +50,17 @@ type Consensus interface {
 
 // IotxConsensus implements Consensus
 type IotxConsensus struct {
-	cfg    config.Consensus
-	scheme scheme.Scheme
+	cfg     config.Consensus
+	scheme  scheme.Scheme
+	mutex   sync.Mutex
+	started bool
+	quit    chan struct{}
 }
 
 type optionParams struct {
-	broadcastHandler scheme.Broadcast
-	rp               *rp.Protocol
+	broadcastHandler       scheme.Broadcast
+	rp                     *rp.Protocol
+	candidatesByHeightFunc rolldpos.CandidatesByHeightFunc
 }
 
 // Option sets Consensus construction parameter.
@@ -67,6 +82,15 @@ func WithRollDPoSProtocol(rp *rp.Protocol) Option {
 	}
 }
 
+// WithCandidatesByHeight is an option to provide the candidates to the roll-DPoS scheme in place of the ones read from
+// the chain, e.g., by a DelegateList
+func WithCandidatesByHeight(f rolldpos.CandidatesByHeightFunc) Option {
+	return func(ops *optionParams) error {
+		ops.candidatesByHeightFunc = f
+		return nil
+	}
+}
+
 // NewConsensus creates a IotxConsensus struct.
 func NewConsensus(
 	cfg config.Config,
@@ -80,27 +104,36 @@ func NewConsensus(
 			return nil, err
 		}
 	}
+	if err := metrics.Register(blockMtc); err != nil {
+		return nil, err
+	}
 
 	clock := clock.New()
-	cs := &IotxConsensus{cfg: cfg.Consensus}
+	cs := &IotxConsensus{cfg: cfg.Consensus, quit: make(chan struct{})}
 	mintBlockCB := func() (*block.Block, error) {
 		actionMap := ap.PendingActionMap()
 		log.Logger("consensus").Debug("Pick actions.", zap.Int("actions", len(actionMap)))
 		blk, err := bc.MintNewBlock(actionMap, clock.Now())
+		blockMtc.WithLabelValues("mint", strconv.FormatBool(err == nil)).Inc()
 		if err != nil {
 			log.Logger("consensus").Error("Failed to mint a block.", zap.Error(err))
 			return nil, err
 		}
 		log.Logger("consensus").Info("Created a new block.",
-			zap.Uint64("height", blk.Height()),
-			zap.Int("length", len(blk.Actions)))
+			append(blk.LogFields(), zap.Int("length", len(blk.Actions)))...)
+		if log.ActionTracing() {
+			for _, selp := range blk.Actions {
+				log.TraceAction(log.StagePicked, selp.Hash(), blk.LogFields()...)
+			}
+		}
 		return blk, nil
 	}
 
 	commitBlockCB := func(blk *block.Block) error {
 		err := bc.CommitBlock(blk)
+		blockMtc.WithLabelValues("commit", strconv.FormatBool(err == nil)).Inc()
 		if err != nil {
-			log.Logger("consensus").Info("Failed to commit the block.", zap.Error(err), zap.Uint64("height", blk.Height()))
+			log.Logger("consensus").Info("Failed to commit the block.", append(blk.LogFields(), zap.Error(err))...)
 		}
 		// Remove transfers in this block from ActPool and reset ActPool state
 		ap.Reset()
@@ -125,7 +158,13 @@ func NewConsensus(
 			SetActPool(ap).
 			SetClock(clock).
 			SetBroadcast(ops.broadcastHandler).
+			SetCandidatesByHeightFunc(ops.candidatesByHeightFunc).
 			RegisterProtocol(ops.rp)
+		if sf := bc.GetFactory(); sf != nil {
+			bd.SetDelegateOfFunc(func(signer string, height uint64) (string, error) {
+				return keyrotation.DelegateOf(sf, signer, height)
+			})
+		}
 		// TODO: explorer dependency deleted here at #1085, need to revive by migrating to api
 		cs.scheme, err = bd.Build()
 		if err != nil {
@@ -134,12 +173,27 @@ func NewConsensus(
 	case config.NOOPScheme:
 		cs.scheme = scheme.NewNoop()
 	case config.StandaloneScheme:
+		producer := cfg.ProducerAddress().String()
+		slotDuration := cfg.Consensus.SlotDuration
+		if slotDuration == 0 {
+			slotDuration = cfg.Genesis.BlockInterval
+		}
+		// the node is the only delegate minting in every slot by default, and the producers of the blocks received
+		// are validated against the slots only if the delegates are configured
+		delegates := cfg.Consensus.SlotDelegates
+		validateSlots := len(delegates) > 0
+		if !validateSlots {
+			delegates = []string{producer}
+		}
 		cs.scheme = scheme.NewStandalone(
 			mintBlockCB,
 			commitBlockCB,
 			broadcastBlockCB,
 			bc,
 			cfg.Genesis.BlockInterval,
+			scheme.NewSlotScheduler(slotDuration, delegates),
+			producer,
+			validateSlots,
 		)
 	default:
 		return nil, errors.Errorf("unexpected IotxConsensus scheme %s", cfg.Consensus.Scheme)
@@ -148,25 +202,38 @@ func NewConsensus(
 	return cs, nil
 }
 
-// Start starts running the consensus algorithm
+// Start starts running the consensus algorithm. If delegate reachability is required, the scheme is held off until a
+// quorum of delegates is reachable
 func (c *IotxConsensus) Start(ctx context.Context) error {
-	log.Logger("consensus").Info("Starting IotxConsensus scheme.", zap.String("scheme", c.cfg.Scheme))
-
-	err := c.scheme.Start(ctx)
-	if err != nil {
-		return errors.Wrapf(err, "failed to start scheme %s", c.cfg.Scheme)
+	if c.cfg.RequireDelegateReachability && !c.delegatesReachable() {
+		log.Logger("consensus").Warn("Quorum of delegates is not reachable, hold off minting.",
+			zap.Strings("delegates", c.cfg.DelegateAddrs))
+		go c.startWhenReachable(ctx)
+		return nil
 	}
-	return nil
+	return c.startScheme(ctx)
 }
 
 // Stop stops running the consensus algorithm
 func (c *IotxConsensus) Stop(ctx context.Context) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	select {
+	case <-c.quit:
+	default:
+		close(c.quit)
+	}
+	if !c.started {
+		return nil
+	}
 	log.Logger("consensus").Info("Stopping IotxConsensus scheme.", zap.String("scheme", c.cfg.Scheme))
 
 	err := c.scheme.Stop(ctx)
 	if err != nil {
 		return errors.Wrapf(err, "failed to stop scheme %s", c.cfg.Scheme)
 	}
+	c.started = false
 	return nil
 }
 
@@ -202,3 +269,50 @@ func (c *IotxConsensus) Activate(active bool) {
 
 // Active returns true if the consensus component is active or false if it stands by
 func (c *IotxConsensus) Active() bool { return c.scheme.Active() }
+
+func (c *IotxConsensus) startScheme(ctx context.Context) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	select {
+	case <-c.quit:
+		return nil
+	default:
+	}
+	log.Logger("consensus").Info("Starting IotxConsensus scheme.", zap.String("scheme", c.cfg.Scheme))
+
+	err := c.scheme.Start(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "failed to start scheme %s", c.cfg.Scheme)
+	}
+	c.started = true
+	return nil
+}
+
+func (c *IotxConsensus) startWhenReachable(ctx context.Context) {
+	ticker := time.NewTicker(c.cfg.ReachabilityCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.quit:
+			return
+		case <-ticker.C:
+			if !c.delegatesReachable() {
+				continue
+			}
+			if err := c.startScheme(ctx); err != nil {
+				log.Logger("consensus").Error("Failed to start IotxConsensus scheme.", zap.Error(err))
+			}
+			return
+		}
+	}
+}
+
+func (c *IotxConsensus) delegatesReachable() bool {
+	total := len(c.cfg.DelegateAddrs)
+	reachable := countReachable(c.cfg.DelegateAddrs, pingTimeout)
+	log.Logger("consensus").Info("Checked delegate reachability.",
+		zap.Int("reachable", reachable),
+		zap.Int("total", total))
+	return hasQuorum(reachable, total)
+}
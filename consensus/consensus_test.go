@@ -0,0 +1,103 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package consensus
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/test/mock/mock_actpool"
+	"github.com/iotexproject/iotex-core/test/mock/mock_blockchain"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestHasQuorum(t *testing.T) {
+	require := require.New(t)
+
+	require.True(hasQuorum(0, 0))
+	require.True(hasQuorum(1, 1))
+	require.False(hasQuorum(0, 1))
+	require.False(hasQuorum(2, 3))
+	require.True(hasQuorum(3, 4))
+	require.False(hasQuorum(16, 24))
+	require.True(hasQuorum(17, 24))
+}
+
+func TestCountReachable(t *testing.T) {
+	require := require.New(t)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	defer lis.Close()
+	down := unusedAddr(t)
+
+	require.Equal(0, countReachable(nil, time.Second))
+	require.Equal(1, countReachable([]string{lis.Addr().String(), down}, time.Second))
+	require.Equal(2, countReachable([]string{lis.Addr().String(), lis.Addr().String()}, time.Second))
+}
+
+func TestConsensus_RequireDelegateReachability(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	defer lis.Close()
+	down := unusedAddr(t)
+
+	cfg := config.Default
+	cfg.Genesis.BlockInterval = time.Hour
+	cfg.Consensus.RequireDelegateReachability = true
+	cfg.Consensus.DelegateAddrs = []string{lis.Addr().String(), down}
+	cfg.Consensus.ReachabilityCheckInterval = 10 * time.Millisecond
+	cs, err := NewConsensus(cfg, mock_blockchain.NewMockBlockchain(ctrl), mock_actpool.NewMockActPool(ctrl))
+	require.NoError(err)
+	c := cs.(*IotxConsensus)
+
+	require.NoError(c.Start(context.Background()))
+	started := func() (bool, error) {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		return c.started, nil
+	}
+	ok, _ := started()
+	require.False(ok)
+
+	// The scheme starts once the second delegate becomes reachable
+	lis2, err := net.Listen("tcp", down)
+	require.NoError(err)
+	defer lis2.Close()
+	require.NoError(testutil.WaitUntil(10*time.Millisecond, 5*time.Second, started))
+	require.NoError(c.Stop(context.Background()))
+	require.NoError(c.Stop(context.Background()))
+
+	// A single self-delegate is trivially satisfied
+	cfg.Consensus.DelegateAddrs = []string{lis.Addr().String()}
+	cs, err = NewConsensus(cfg, mock_blockchain.NewMockBlockchain(ctrl), mock_actpool.NewMockActPool(ctrl))
+	require.NoError(err)
+	c = cs.(*IotxConsensus)
+	require.NoError(c.Start(context.Background()))
+	ok, _ = started()
+	require.True(ok)
+	require.NoError(c.Stop(context.Background()))
+}
+
+func unusedAddr(t *testing.T) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := lis.Addr().String()
+	require.NoError(t, lis.Close())
+	return addr
+}
@@ -14,8 +14,9 @@ import (
 	"github.com/facebookgo/clock"
 	fsm "github.com/iotexproject/go-fsm"
 	"github.com/pkg/errors"
-	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/pkg/metrics"
 )
 
 /**
@@ -23,19 +24,9 @@ import (
  * without signature, which could be replaced with real signature
  */
 var (
-	consensusMtc = prometheus.NewCounterVec(
-		prometheus.CounterOpts{
-			Name: "iotex_consensus",
-			Help: "Consensus stats",
-		},
-		[]string{"result"},
-	)
+	consensusMtc = metrics.NewCounter("iotex_consensus", "Consensus stats", "result")
 )
 
-func init() {
-	prometheus.MustRegister(consensusMtc)
-}
-
 const (
 	// consensus states
 	sPrepare                    fsm.State = "S_PREPARE"
@@ -107,6 +98,9 @@ type ConsensusFSM struct {
 
 // NewConsensusFSM returns a new fsm
 func NewConsensusFSM(cfg Config, ctx Context, clock clock.Clock) (*ConsensusFSM, error) {
+	if err := metrics.Register(consensusMtc); err != nil {
+		return nil, err
+	}
 	cm := &ConsensusFSM{
 		evtq:  make(chan *ConsensusEvent, cfg.EventChanSize),
 		close: make(chan interface{}),
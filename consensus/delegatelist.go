@@ -0,0 +1,84 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package consensus
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/iotexproject/iotex-core/state"
+)
+
+// DelegateList is a list of the delegate addresses maintained outside the chain, which provides the candidates to the
+// roll-DPoS scheme in place of the ones read from the chain. The list is updated along with the height it applies
+// from, so that the delegates of an epoch don't change in the middle of it.
+type DelegateList struct {
+	mutex sync.RWMutex
+	lists []DelegateListAt
+}
+
+// DelegateListAt is the list of the delegates applying from the start height
+type DelegateListAt struct {
+	StartHeight uint64   `json:"startHeight"`
+	Addrs       []string `json:"addrs"`
+}
+
+// NewDelegateList creates a delegate list of the lists in the order of the start heights, where the first one applies
+// to the heights before its start height too
+func NewDelegateList(history []DelegateListAt) *DelegateList {
+	l := &DelegateList{}
+	for _, list := range history {
+		l.Set(list.StartHeight, list.Addrs)
+	}
+	return l
+}
+
+// Set replaces the delegates from the start height on, which is usually the start height of an epoch
+func (l *DelegateList) Set(startHeight uint64, addrs []string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	for i, list := range l.lists {
+		if list.StartHeight >= startHeight {
+			l.lists = l.lists[:i]
+			break
+		}
+	}
+	l.lists = append(l.lists, DelegateListAt{StartHeight: startHeight, Addrs: addrs})
+}
+
+// History returns the lists set in the order of the start heights
+func (l *DelegateList) History() []DelegateListAt {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	return append([]DelegateListAt{}, l.lists...)
+}
+
+// Delegates returns the latest delegates set
+func (l *DelegateList) Delegates() []string {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	return l.lists[len(l.lists)-1].Addrs
+}
+
+// CandidatesByHeight returns the delegates applying at the height as the candidates, which fits
+// rolldpos.CandidatesByHeightFunc
+func (l *DelegateList) CandidatesByHeight(height uint64) ([]*state.Candidate, error) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	addrs := l.lists[0].Addrs
+	for _, list := range l.lists {
+		if list.StartHeight > height {
+			break
+		}
+		addrs = list.Addrs
+	}
+	candidates := make([]*state.Candidate, 0, len(addrs))
+	for _, addr := range addrs {
+		candidates = append(candidates, &state.Candidate{Address: addr, Votes: big.NewInt(0)})
+	}
+	return candidates, nil
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package consensus
+
+import (
+	"net"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// pingTimeout is the timeout to connect to a delegate address
+const pingTimeout = 2 * time.Second
+
+// countReachable returns the number of addresses accepting a tcp connection
+func countReachable(addrs []string, timeout time.Duration) int {
+	type result struct {
+		addr string
+		err  error
+	}
+	results := make(chan result, len(addrs))
+	for _, addr := range addrs {
+		go func(addr string) {
+			conn, err := net.DialTimeout("tcp", addr, timeout)
+			if err == nil {
+				err = conn.Close()
+			}
+			results <- result{addr, err}
+		}(addr)
+	}
+	reachable := 0
+	for range addrs {
+		r := <-results
+		if r.err != nil {
+			log.Logger("consensus").Debug("Delegate is not reachable.", zap.String("addr", r.addr), zap.Error(r.err))
+			continue
+		}
+		reachable++
+	}
+	return reachable
+}
+
+// hasQuorum returns true if more than 2/3 of the delegates are reachable, which is the same threshold to reach
+// consensus on a block. No delegate address means the node runs on its own, which is trivially a quorum
+func hasQuorum(reachable, total int) bool {
+	return total == 0 || reachable*3 > total*2
+}
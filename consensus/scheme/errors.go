@@ -11,4 +11,6 @@ import "errors"
 var (
 	// ErrNotImplemented indicates the method is not implemented yet
 	ErrNotImplemented = errors.New("not implemented")
+	// ErrSlotMismatch indicates the block is produced by a delegate other than the one of its slot
+	ErrSlotMismatch = errors.New("block producer doesn't own the slot")
 )
@@ -25,6 +25,7 @@ import (
 	"github.com/iotexproject/iotex-core/endorsement"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/metrics"
 	"github.com/iotexproject/iotex-core/protogen/iotextypes"
 )
 
@@ -47,8 +48,8 @@ var (
 )
 
 func init() {
-	prometheus.MustRegister(timeSlotMtc)
-	prometheus.MustRegister(blockIntervalMtc)
+	metrics.MustRegister(timeSlotMtc)
+	metrics.MustRegister(blockIntervalMtc)
 }
 
 var (
@@ -152,7 +153,11 @@ func (r *RollDPoS) ValidateBlockFooter(blk *block.Block) error {
 	if err != nil {
 		return err
 	}
-	if round.Proposer() != blk.ProducerAddress() {
+	proposer, err := r.ctx.RoundCalc().DelegateOf(blk.ProducerAddress(), blk.Height())
+	if err != nil {
+		return err
+	}
+	if round.Proposer() != proposer {
 		return errors.Errorf(
 			"block proposer %s is invalid, %s expected",
 			blk.ProducerAddress(),
@@ -237,6 +242,7 @@ type Builder struct {
 	// TODO: explorer dependency deleted at #1085, need to add api params
 	rp                     *rolldpos.Protocol
 	candidatesByHeightFunc CandidatesByHeightFunc
+	delegateOfFunc         DelegateOfFunc
 }
 
 // NewRollDPoSBuilder instantiates a Builder instance
@@ -294,6 +300,12 @@ func (b *Builder) SetCandidatesByHeightFunc(
 	return b
 }
 
+// SetDelegateOfFunc sets delegateOfFunc
+func (b *Builder) SetDelegateOfFunc(delegateOfFunc DelegateOfFunc) *Builder {
+	b.delegateOfFunc = delegateOfFunc
+	return b
+}
+
 // RegisterProtocol sets the rolldpos protocol
 func (b *Builder) RegisterProtocol(rp *rolldpos.Protocol) *Builder {
 	b.rp = rp
@@ -325,6 +337,7 @@ func (b *Builder) Build() (*RollDPoS, error) {
 		b.rp,
 		b.broadcastHandler,
 		b.candidatesByHeightFunc,
+		b.delegateOfFunc,
 		b.encodedAddr,
 		b.priKey,
 		b.clock,
@@ -15,7 +15,6 @@ import (
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
-	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
 	"github.com/iotexproject/iotex-core/actpool"
 	"github.com/iotexproject/iotex-core/blockchain"
@@ -25,12 +24,16 @@ import (
 	"github.com/iotexproject/iotex-core/endorsement"
 	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
 	"github.com/iotexproject/iotex-core/state"
 )
 
 // CandidatesByHeightFunc defines a function to overwrite candidates
 type CandidatesByHeightFunc func(uint64) ([]*state.Candidate, error)
 
+// DelegateOfFunc defines a function to map a signer address to the delegate identity it signs for at a height
+type DelegateOfFunc func(string, uint64) (string, error)
+
 type rollDPoSCtx struct {
 	cfg config.RollDPoS
 	// TODO: explorer dependency deleted at #1085, need to add api params here
@@ -58,6 +61,7 @@ func newRollDPoSCtx(
 	rp *rolldpos.Protocol,
 	broadcastHandler scheme.Broadcast,
 	candidatesByHeightFunc CandidatesByHeightFunc,
+	delegateOfFunc DelegateOfFunc,
 	encodedAddr string,
 	priKey keypair.PrivateKey,
 	clock clock.Clock,
@@ -68,6 +72,7 @@ func newRollDPoSCtx(
 	roundCalc := &roundCalculator{
 		blockInterval:          blockInterval,
 		candidatesByHeightFunc: candidatesByHeightFunc,
+		delegateOfFunc:         delegateOfFunc,
 		chain:                  chain,
 		rp:                     rp,
 		timeBasedRotation:      timeBasedRotation,
@@ -97,11 +102,15 @@ func (ctx *rollDPoSCtx) CheckVoteEndorser(
 	vote *ConsensusVote,
 	en *endorsement.Endorsement,
 ) error {
-	endorserAddr, err := address.FromBytes(en.Endorser().Hash())
+	endorserAddr, err := addrutil.PubKeyToAddress(en.Endorser())
+	if err != nil {
+		return err
+	}
+	endorser, err := ctx.roundCalc.DelegateOf(endorserAddr.String(), height)
 	if err != nil {
 		return err
 	}
-	if !ctx.roundCalc.IsDelegate(endorserAddr.String(), height) {
+	if !ctx.roundCalc.IsDelegate(endorser, height) {
 		return errors.Errorf("%s is not delegate of the corresponding round", endorserAddr)
 	}
 
@@ -120,11 +129,15 @@ func (ctx *rollDPoSCtx) CheckBlockProposer(
 			height,
 		)
 	}
-	endorserAddr, err := address.FromBytes(en.Endorser().Hash())
+	endorserAddr, err := addrutil.PubKeyToAddress(en.Endorser())
+	if err != nil {
+		return err
+	}
+	endorser, err := ctx.roundCalc.DelegateOf(endorserAddr.String(), height)
 	if err != nil {
 		return err
 	}
-	if ctx.roundCalc.Proposer(height, en.Timestamp()) != endorserAddr.String() {
+	if ctx.roundCalc.Proposer(height, en.Timestamp()) != endorser {
 		return errors.Errorf(
 			"%s is not proposer of the corresponding round, %s expected",
 			endorserAddr.String(),
@@ -132,7 +145,11 @@ func (ctx *rollDPoSCtx) CheckBlockProposer(
 		)
 	}
 	proposerAddr := proposal.ProposerAddress()
-	if ctx.roundCalc.Proposer(height, proposal.block.Timestamp()) != proposerAddr {
+	proposer, err := ctx.roundCalc.DelegateOf(proposerAddr, height)
+	if err != nil {
+		return err
+	}
+	if ctx.roundCalc.Proposer(height, proposal.block.Timestamp()) != proposer {
 		return errors.Errorf("%s is not proposer of the correpsonding round", proposerAddr)
 	}
 	if !proposal.block.VerifySignature() {
@@ -233,12 +250,17 @@ func (ctx *rollDPoSCtx) Prepare() (
 		delay = ctx.round.NextRoundStartTime().Sub(ctx.clock.Now())
 		return
 	}
-	if isDelegate = ctx.round.IsDelegate(ctx.encodedAddr); !isDelegate {
+	delegate, err := ctx.roundCalc.DelegateOf(ctx.encodedAddr, height)
+	if err != nil {
+		ctx.logger().Warn("current node key is not active", zap.Error(err))
+		err = nil
+	}
+	if isDelegate = ctx.round.IsDelegate(delegate); !isDelegate {
 		ctx.logger().Info("current node is not an active consensus delegate")
 		delay = ctx.round.NextRoundStartTime().Sub(ctx.clock.Now())
 		return
 	}
-	if isProposer = ctx.round.Proposer() == ctx.encodedAddr; isProposer {
+	if isProposer = ctx.round.Proposer() == delegate; isProposer {
 		ctx.logger().Info("current node is a proposer")
 		proposal, err = ctx.mintBlock()
 	} else {
@@ -25,6 +25,7 @@ type roundCalculator struct {
 	timeBasedRotation      bool
 	rp                     *rolldpos.Protocol
 	candidatesByHeightFunc CandidatesByHeightFunc
+	delegateOfFunc         DelegateOfFunc
 }
 
 func (c *roundCalculator) BlockInterval() time.Duration {
@@ -99,6 +100,13 @@ func (c *roundCalculator) Proposer(height uint64, roundStartTime time.Time) stri
 	return round.Proposer()
 }
 
+func (c *roundCalculator) DelegateOf(addr string, height uint64) (string, error) {
+	if c.delegateOfFunc == nil {
+		return addr, nil
+	}
+	return c.delegateOfFunc(addr, height)
+}
+
 func (c *roundCalculator) IsDelegate(addr string, height uint64) bool {
 	delegates, err := c.Delegates(height)
 	if err != nil {
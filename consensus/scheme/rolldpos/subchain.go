@@ -22,14 +22,14 @@ func putBlockToParentChain(
 	b *block.Block,
 ) {
 	if err := putBlockToParentChainTask(subChainAddr, senderPrvKey, b); err != nil {
-		log.L().Error("Failed to put block merkle roots to parent chain.",
+		log.Logger("consensus").Error("Failed to put block merkle roots to parent chain.",
 			zap.String("subChainAddress", subChainAddr),
 			zap.String("senderAddress", senderAddr),
 			zap.Uint64("height", b.Height()),
 			zap.Error(err))
 		return
 	}
-	log.L().Info("Succeeded to put block merkle roots to parent chain.",
+	log.Logger("consensus").Info("Succeeded to put block merkle roots to parent chain.",
 		zap.String("subChainAddress", subChainAddr),
 		zap.String("senderAddress", senderAddr),
 		zap.Uint64("height", b.Height()))
@@ -0,0 +1,55 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package scheme
+
+import (
+	"strings"
+	"time"
+)
+
+// SlotScheduler assigns the time slots to the delegates in turn, i.e., the time is cut into the slots of the same
+// duration since the Unix epoch, and the slot n is assigned to the delegate n modulo the number of the delegates, so
+// that the delegates agreeing on the order and the duration mint one at a time without talking to each other
+type SlotScheduler struct {
+	duration  time.Duration
+	delegates []string
+}
+
+// NewSlotScheduler creates a slot scheduler of the ordered delegate addresses. A non-positive duration makes every
+// slot the first delegate's, and no delegate makes every slot no one's.
+func NewSlotScheduler(duration time.Duration, delegates []string) *SlotScheduler {
+	s := &SlotScheduler{
+		duration:  duration,
+		delegates: make([]string, len(delegates)),
+	}
+	for i, d := range delegates {
+		s.delegates[i] = strings.ToLower(d)
+	}
+	return s
+}
+
+// Slot returns the index of the slot the time falls in
+func (s *SlotScheduler) Slot(t time.Time) uint64 {
+	if s.duration <= 0 {
+		return 0
+	}
+	return uint64(t.UnixNano() / int64(s.duration))
+}
+
+// Minter returns the address of the delegate allowed to mint at the time, which is empty if there is no delegate
+func (s *SlotScheduler) Minter(t time.Time) string {
+	if len(s.delegates) == 0 {
+		return ""
+	}
+	return s.delegates[s.Slot(t)%uint64(len(s.delegates))]
+}
+
+// IsMinter tells whether the delegate of the address is allowed to mint at the time
+func (s *SlotScheduler) IsMinter(addr string, t time.Time) bool {
+	minter := s.Minter(t)
+	return minter != "" && minter == strings.ToLower(addr)
+}
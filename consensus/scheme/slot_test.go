@@ -0,0 +1,48 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package scheme
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestSlotScheduler(t *testing.T) {
+	require := require.New(t)
+
+	delegates := []string{
+		identityset.Address(0).String(),
+		identityset.Address(1).String(),
+		identityset.Address(2).String(),
+	}
+	s := NewSlotScheduler(10*time.Second, delegates)
+	base := time.Unix(300, 0)
+	require.Equal(uint64(30), s.Slot(base))
+	require.Equal(uint64(30), s.Slot(base.Add(9*time.Second)))
+	for i := 0; i < 6; i++ {
+		now := base.Add(time.Duration(i) * 10 * time.Second)
+		require.Equal(delegates[i%3], s.Minter(now))
+		require.True(s.IsMinter(delegates[i%3], now))
+		require.False(s.IsMinter(delegates[(i+1)%3], now))
+	}
+
+	// the only delegate mints in every slot
+	s = NewSlotScheduler(time.Second, delegates[:1])
+	for i := 0; i < 5; i++ {
+		require.True(s.IsMinter(delegates[0], base.Add(time.Duration(i)*time.Second)))
+	}
+	s = NewSlotScheduler(0, delegates[:1])
+	require.True(s.IsMinter(delegates[0], base))
+
+	s = NewSlotScheduler(time.Second, nil)
+	require.Equal("", s.Minter(base))
+	require.False(s.IsMinter("", base))
+}
@@ -23,6 +23,8 @@ import (
 // Standalone is the consensus scheme that periodically create blocks
 type Standalone struct {
 	task *routine.RecurringTask
+	// slots validates the producers of the blocks received, which is nil if the delegate set isn't known
+	slots *SlotScheduler
 }
 
 type standaloneHandler struct {
@@ -30,36 +32,67 @@ type standaloneHandler struct {
 	createCb CreateBlockCB
 	commitCb ConsensusDoneCB
 	pubCb    BroadcastCB
+	slots    *SlotScheduler
+	producer string
 }
 
 func (s *standaloneHandler) Run() {
+	if now := time.Now(); !s.slots.IsMinter(s.producer, now) {
+		log.Logger("consensus").Debug("Not the slot of the node to mint.",
+			zap.Uint64("slot", s.slots.Slot(now)),
+			zap.String("minter", s.slots.Minter(now)))
+		return
+	}
 	blk, err := s.createCb()
 	if err != nil {
-		log.L().Error("Failed to create.", zap.Error(err))
+		log.Logger("consensus").Error("Failed to create.", zap.Error(err))
+		return
+	}
+	// the block is dropped if minting it runs past the slot, since the peers tell the slot by the block timestamp
+	if !s.slots.IsMinter(s.producer, blk.Timestamp()) {
+		log.Logger("consensus").Warn("The block is minted past the slot of the node.",
+			append(blk.LogFields(), zap.Uint64("slot", s.slots.Slot(blk.Timestamp())))...)
 		return
 	}
 
 	if err := s.commitCb(blk); err != nil {
-		log.L().Error("Failed to commit.", zap.Error(err))
+		log.Logger("consensus").Error("Failed to commit.", zap.Error(err))
 		return
 	}
 	if err := s.pubCb(blk); err != nil {
-		log.L().Error("Failed to publish event.", zap.Error(err))
+		log.Logger("consensus").Error("Failed to publish event.", zap.Error(err))
 		return
 	}
 }
 
-// NewStandalone creates a Standalone struct.
-func NewStandalone(create CreateBlockCB, commit ConsensusDoneCB, pub BroadcastCB, bc blockchain.Blockchain, interval time.Duration) Scheme {
+// NewStandalone creates a Standalone struct. The node of the producer address mints only in its slots assigned by
+// the slot scheduler. If validateSlots is set, the blocks received are rejected unless their producers own the slots
+// of their timestamps, which needs the slot scheduler to know the whole delegate set.
+func NewStandalone(
+	create CreateBlockCB,
+	commit ConsensusDoneCB,
+	pub BroadcastCB,
+	bc blockchain.Blockchain,
+	interval time.Duration,
+	slots *SlotScheduler,
+	producer string,
+	validateSlots bool,
+) Scheme {
 	h := &standaloneHandler{
 		bc:       bc,
 		createCb: create,
 		commitCb: commit,
 		pubCb:    pub,
+		slots:    slots,
+		producer: producer,
 	}
-	return &Standalone{
+	s := &Standalone{
 		task: routine.NewRecurringTask(h.Run, interval),
 	}
+	if validateSlots {
+		s.slots = slots
+	}
+	return s
 }
 
 // Start starts the service for a standalone
@@ -72,18 +105,36 @@ func (s *Standalone) Stop(ctx context.Context) error {
 	return s.task.Stop(ctx)
 }
 
+// SetInterval changes the interval of creating blocks
+func (s *Standalone) SetInterval(interval time.Duration) {
+	s.task.SetInterval(interval)
+}
+
 // HandleConsensusMsg handles incoming consensus message
 func (s *Standalone) HandleConsensusMsg(msg *iotextypes.ConsensusMessage) error {
-	log.L().Warn("Noop scheme does not handle incoming block propose requests.")
+	log.Logger("consensus").Warn("Noop scheme does not handle incoming block propose requests.")
 	return nil
 }
 
 // Calibrate triggers an event to calibrate consensus context
 func (s *Standalone) Calibrate(uint64) {}
 
-// ValidateBlockFooter validates signatures in block footer
-func (s *Standalone) ValidateBlockFooter(*block.Block) error {
-	log.L().Warn("Standalone scheme always return true for block footer validation")
+// ValidateBlockFooter validates that the producer of the block owns the slot of the block timestamp, if the slots are
+// validated. There are no endorsements in the footer to validate.
+func (s *Standalone) ValidateBlockFooter(blk *block.Block) error {
+	if s.slots == nil {
+		return nil
+	}
+	if producer := blk.ProducerAddress(); !s.slots.IsMinter(producer, blk.Timestamp()) {
+		return errors.Wrapf(
+			ErrSlotMismatch,
+			"block %d is produced by %s in slot %d of %s",
+			blk.Height(),
+			producer,
+			s.slots.Slot(blk.Timestamp()),
+			s.slots.Minter(blk.Timestamp()),
+		)
+	}
 	return nil
 }
 
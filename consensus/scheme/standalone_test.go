@@ -0,0 +1,49 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package scheme
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestStandalone_ValidateBlockFooter(t *testing.T) {
+	require := require.New(t)
+
+	delegates := []string{identityset.Address(0).String(), identityset.Address(1).String()}
+	slots := NewSlotScheduler(10*time.Second, delegates)
+	mint := func(i int, ts time.Time) *block.Block {
+		blk, err := block.NewTestingBuilder().
+			SetHeight(1).
+			SetPrevBlockHash(hash.ZeroHash256).
+			SetTimeStamp(ts).
+			SignAndBuild(identityset.PrivateKey(i).PublicKey(), identityset.PrivateKey(i))
+		require.NoError(err)
+		return &blk
+	}
+	// slot 30 is delegate 0's, and slot 31 is delegate 1's
+	ts := time.Unix(300, 0)
+
+	s := NewStandalone(nil, nil, nil, nil, time.Second, slots, delegates[0], true)
+	require.NoError(s.ValidateBlockFooter(mint(0, ts)))
+	require.NoError(s.ValidateBlockFooter(mint(1, ts.Add(10*time.Second))))
+	err := s.ValidateBlockFooter(mint(1, ts))
+	require.Equal(ErrSlotMismatch, errors.Cause(err))
+	err = s.ValidateBlockFooter(mint(0, ts.Add(19*time.Second)))
+	require.Equal(ErrSlotMismatch, errors.Cause(err))
+
+	// the slots aren't validated without the delegate set
+	s = NewStandalone(nil, nil, nil, nil, time.Second, slots, delegates[0], false)
+	require.NoError(s.ValidateBlockFooter(mint(1, ts)))
+}
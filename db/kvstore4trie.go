@@ -13,6 +13,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
+	"github.com/iotexproject/iotex-core/pkg/metrics"
 )
 
 var (
@@ -26,7 +27,7 @@ var (
 )
 
 func init() {
-	prometheus.MustRegister(trieKeystoreMtc)
+	metrics.MustRegister(trieKeystoreMtc)
 }
 
 // KVStoreForTrie defines a kvstore with fixed bucket and cache layer for trie.
@@ -18,7 +18,7 @@ import (
 // NewAwsRDS instantiates an aws rds
 func NewAwsRDS(cfg config.RDS) Store {
 	connectStr := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s",
-		cfg.AwsRDSUser, cfg.AwsPass, cfg.AwsRDSEndpoint, cfg.AwsRDSPort, cfg.AwsDBName,
+		cfg.AwsRDSUser, string(cfg.AwsPass), cfg.AwsRDSEndpoint, cfg.AwsRDSPort, cfg.AwsDBName,
 	)
 	return newStoreBase("mysql", connectStr)
 }
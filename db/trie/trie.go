@@ -12,6 +12,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/metrics"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -26,7 +27,7 @@ var (
 )
 
 func init() {
-	prometheus.MustRegister(trieMtc)
+	metrics.MustRegister(trieMtc)
 }
 
 var (
@@ -588,3 +588,30 @@ func TestPressure(t *testing.T) {
 	require.Nil(tr.Stop(context.Background()))
 	t.Logf("Warning: test %d entries", c)
 }
+
+func TestVerifyNodes(t *testing.T) {
+	require := require.New(t)
+
+	trieDB := newInMemKVStore()
+	tr, err := NewTrie(KVStoreOption(trieDB), KeyLengthOption(8))
+	require.NoError(err)
+	require.NoError(tr.Start(context.Background()))
+	require.NoError(VerifyNodes(tr))
+	for i, k := range [][]byte{cat, rat, egg, dog, ant} {
+		require.NoError(tr.Upsert(k, testV[i]))
+	}
+	require.NoError(VerifyNodes(tr))
+
+	// a leaf replaced in the KV store is read as is, but doesn't hash to its key
+	h := tr.nodeHash(&leafNode{key: cat, value: testV[0]})
+	require.NoError(trieDB.Put(h, (&leafNode{key: cat, value: []byte("cow")}).serialize()))
+	v, err := tr.Get(cat)
+	require.NoError(err)
+	require.Equal([]byte("cow"), v)
+	err = VerifyNodes(tr)
+	require.Equal(ErrInvalidTrie, errors.Cause(err))
+
+	// so is a missing node
+	require.NoError(trieDB.Delete(h))
+	require.Error(VerifyNodes(tr))
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package trie
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+)
+
+// VerifyNodes walks the nodes of the trie from the root down, and checks that each node stored in the KV store hashes
+// to the key it's stored by, so that the trie holds exactly the entries the root hash commits to. It's meant for a KV
+// store taken from elsewhere, since the nodes aren't checked when they're loaded.
+func VerifyNodes(tr Trie) error {
+	stack := [][]byte{tr.RootHash()}
+	for len(stack) > 0 {
+		h := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		node, err := tr.loadNodeFromDB(h)
+		if err != nil {
+			return err
+		}
+		if nh := tr.nodeHash(node); !bytes.Equal(nh, h) {
+			return errors.Wrapf(ErrInvalidTrie, "node stored by %x hashes to %x", h, nh)
+		}
+		switch n := node.(type) {
+		case *branchNode:
+			for _, child := range n.hashes {
+				stack = append(stack, child)
+			}
+		case *extensionNode:
+			stack = append(stack, n.childHash)
+		}
+	}
+	return nil
+}
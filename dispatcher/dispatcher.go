@@ -9,8 +9,10 @@ package dispatcher
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	peerstore "github.com/libp2p/go-libp2p-peerstore"
@@ -18,9 +20,18 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/actpool"
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/blocksync"
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/p2p"
+	"github.com/iotexproject/iotex-core/pkg/hash"
 	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/metrics"
+	"github.com/iotexproject/iotex-core/pkg/recovery"
+	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/protogen"
 	"github.com/iotexproject/iotex-core/protogen/iotexrpc"
 	"github.com/iotexproject/iotex-core/protogen/iotextypes"
@@ -29,9 +40,12 @@ import (
 // Subscriber is the dispatcher subscriber interface
 type Subscriber interface {
 	HandleAction(context.Context, *iotextypes.Action) error
+	// HandleActions handles a batch of actions, and returns the error of each action at the index of the action
+	HandleActions(context.Context, []*iotextypes.Action) []error
 	HandleBlock(context.Context, *iotextypes.Block) error
 	HandleBlockSync(context.Context, *iotextypes.Block) error
 	HandleSyncRequest(context.Context, peerstore.PeerInfo, *iotexrpc.BlockSync) error
+	HandleBlockBatch(context.Context, peerstore.PeerInfo, *iotexrpc.BlockBatch) error
 	HandleConsensusMsg(*iotextypes.ConsensusMessage) error
 }
 
@@ -47,18 +61,133 @@ type Dispatcher interface {
 	// HandleTell handles the incoming tell message. The transportation layer semantics is exact once. The sender is
 	// given for the sake of replying the message
 	HandleTell(context.Context, uint32, peerstore.PeerInfo, proto.Message)
+	// MarkActionsSeen remembers the actions of a committed block, so that their copies gossiped late are dropped
+	MarkActionsSeen(*block.Block)
 }
 
-var requestMtc = prometheus.NewCounterVec(
-	prometheus.CounterOpts{
-		Name: "iotex_dispatch_request",
-		Help: "Dispatcher request counter.",
-	},
-	[]string{"method", "succeed"},
+var (
+	requestMtc    = metrics.NewCounter("iotex_dispatch_request", "Dispatcher request counter.", "method", "succeed")
+	queueDepthMtc = metrics.NewGauge(
+		"iotex_dispatch_queue_depth",
+		"Number of the messages waiting in the dispatcher queue of each message class.",
+		"class",
+	)
+	droppedMtc = metrics.NewCounter(
+		"iotex_dispatch_dropped_messages",
+		"Number of the messages dropped by the dispatcher queue of each message class on overflow.",
+		"class",
+	)
+	duplicateBlockMtc = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "iotex_dispatch_duplicate_blocks",
+			Help: "Number of the broadcast blocks dropped by the dispatcher as the copies of the blocks seen.",
+		},
+	)
+	duplicateActionMtc = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "iotex_dispatch_duplicate_actions",
+			Help: "Number of the broadcast actions dropped by the dispatcher as the copies of the actions seen.",
+		},
+	)
 )
 
-func init() {
-	prometheus.MustRegister(requestMtc)
+// The message classes, each of which has its own queue
+const (
+	ActionClass    = "action"
+	BlockClass     = "block"
+	BlockSyncClass = "blockSync"
+	ConsensusClass = "consensus"
+)
+
+// maxActionBatch is the max number of the actions in queue handled in a batch, whose signatures are verified
+// concurrently
+const maxActionBatch = 256
+
+// overflowPolicy decides what a full queue does with a new message
+type overflowPolicy int
+
+const (
+	// dropOldest drops the oldest message in queue to take the new one
+	dropOldest overflowPolicy = iota
+	// waitThenDrop blocks the sender for up to the enqueue timeout, and drops the new message if the queue is still
+	// full
+	waitThenDrop
+	// dropNewest drops the new message
+	dropNewest
+)
+
+// msgQueue is the bounded queue of a message class, which is drained by its own handler, so that a flood of one
+// class doesn't hold up the others
+type msgQueue struct {
+	class   string
+	policy  overflowPolicy
+	timeout time.Duration
+	ch      chan interface{}
+	dropped uint64
+	depth   prometheus.Gauge
+	drops   prometheus.Counter
+	// onDrop is called with each message dropped, if not nil
+	onDrop func(interface{})
+}
+
+func newMsgQueue(class string, size uint, policy overflowPolicy, timeout time.Duration) *msgQueue {
+	return &msgQueue{
+		class:   class,
+		policy:  policy,
+		timeout: timeout,
+		ch:      make(chan interface{}, size),
+		depth:   queueDepthMtc.WithLabelValues(class),
+		drops:   droppedMtc.WithLabelValues(class),
+	}
+}
+
+// put adds the message to the queue, applying the overflow policy if the queue is full. It never blocks longer than
+// the enqueue timeout, nor after quit is closed. It returns false if the new message is dropped.
+func (q *msgQueue) put(msg interface{}, quit <-chan struct{}) bool {
+	defer q.depth.Set(float64(len(q.ch)))
+	select {
+	case q.ch <- msg:
+		return true
+	default:
+	}
+	switch q.policy {
+	case dropOldest:
+		// every message either gets in or counts as dropped, even if other senders race for the space freed
+		select {
+		case old := <-q.ch:
+			q.drop(old)
+		default:
+		}
+		select {
+		case q.ch <- msg:
+			return true
+		default:
+			q.drop(msg)
+		}
+	case waitThenDrop:
+		timer := time.NewTimer(q.timeout)
+		defer timer.Stop()
+		select {
+		case q.ch <- msg:
+			return true
+		case <-timer.C:
+			q.drop(msg)
+		case <-quit:
+			q.drop(msg)
+		}
+	default:
+		q.drop(msg)
+	}
+	return false
+}
+
+func (q *msgQueue) drop(msg interface{}) {
+	atomic.AddUint64(&q.dropped, 1)
+	q.drops.Inc()
+	log.L().Debug("Dispatcher queue is full, drop a message.", zap.String("class", q.class))
+	if q.onDrop != nil {
+		q.onDrop(msg)
+	}
 }
 
 // blockMsg packages a proto block message.
@@ -66,6 +195,9 @@ type blockMsg struct {
 	ctx     context.Context
 	chainID uint32
 	block   *iotextypes.Block
+	peer    string
+	// seen is the key of the broadcast block remembered, which is nil if the block isn't deduplicated
+	seen *seenBlockKey
 }
 
 func (m blockMsg) ChainID() uint32 {
@@ -84,22 +216,51 @@ func (m blockSyncMsg) ChainID() uint32 {
 	return m.chainID
 }
 
+// blockBatchMsg packages a proto block batch message.
+type blockBatchMsg struct {
+	ctx     context.Context
+	chainID uint32
+	batch   *iotexrpc.BlockBatch
+	peer    peerstore.PeerInfo
+}
+
+func (m blockBatchMsg) ChainID() uint32 {
+	return m.chainID
+}
+
 // actionMsg packages a proto action message.
 type actionMsg struct {
 	ctx     context.Context
 	chainID uint32
 	action  *iotextypes.Action
+	peer    string
 }
 
 func (m actionMsg) ChainID() uint32 {
 	return m.chainID
 }
 
-// IotxDispatcher is the request and event dispatcher for iotx node.
+// consensusMsg packages a proto consensus message.
+type consensusMsg struct {
+	chainID uint32
+	msg     *iotextypes.ConsensusMessage
+	peer    string
+}
+
+func (m consensusMsg) ChainID() uint32 {
+	return m.chainID
+}
+
+// IotxDispatcher is the request and event dispatcher for iotx node. It queues the messages of each class in a bounded
+// queue with its own overflow policy, so that the P2P receiving goroutines never block for long:
+//   - actions drop the oldest one in queue when full
+//   - blocks and block batches block the sender for up to Dispatcher.BlockEnqueueTimeout, then drop the new one
+//   - block sync requests drop the new one when full
+//   - consensus messages are never dropped until their queue reaches the hard cap Dispatcher.ConsensusChanSize
 type IotxDispatcher struct {
 	started        int32
 	shutdown       int32
-	eventChan      chan interface{}
+	queues         map[string]*msgQueue
 	eventAudit     map[iotexrpc.MessageType]int
 	eventAuditLock sync.RWMutex
 	wg             sync.WaitGroup
@@ -107,15 +268,44 @@ type IotxDispatcher struct {
 
 	subscribers   map[uint32]Subscriber
 	subscribersMU sync.RWMutex
+
+	// seenBlocks is nil if the broadcast blocks aren't deduplicated
+	seenBlocks      *seenBlocks
+	duplicateBlocks uint64
+	// seenActions is nil if the broadcast actions aren't deduplicated
+	seenActions      *seenActions
+	duplicateActions uint64
+	// penalties holds the peers whose messages made a handler panic, whose messages are dropped
+	penalties *recovery.PenaltyBox
 }
 
 // NewDispatcher creates a new Dispatcher
 func NewDispatcher(cfg config.Config) (Dispatcher, error) {
+	if err := metrics.Register(requestMtc, queueDepthMtc, droppedMtc, duplicateBlockMtc, duplicateActionMtc); err != nil {
+		return nil, err
+	}
+	dc := cfg.Dispatcher
 	d := &IotxDispatcher{
-		eventChan:   make(chan interface{}, cfg.Dispatcher.EventChanSize),
+		queues: map[string]*msgQueue{
+			ActionClass:    newMsgQueue(ActionClass, dc.EventChanSize, dropOldest, 0),
+			BlockClass:     newMsgQueue(BlockClass, dc.BlockChanSize, waitThenDrop, dc.BlockEnqueueTimeout),
+			BlockSyncClass: newMsgQueue(BlockSyncClass, dc.BlockSyncChanSize, dropNewest, 0),
+			ConsensusClass: newMsgQueue(ConsensusClass, dc.ConsensusChanSize, dropNewest, 0),
+		},
 		eventAudit:  make(map[iotexrpc.MessageType]int),
 		quit:        make(chan struct{}),
 		subscribers: make(map[uint32]Subscriber),
+		penalties:   recovery.NewPenaltyBox(dc.PanicPenalty),
+	}
+	if dc.SeenBlockCacheSize > 0 {
+		d.seenBlocks = newSeenBlocks(dc.SeenBlockCacheSize, dc.SeenBlockTTL)
+	}
+	if dc.SeenActionCacheSize > 0 {
+		d.seenActions = newSeenActions(dc.SeenActionCacheSize)
+		// the action dropped by the full queue is forgotten, so that its next copy is handled
+		d.queues[ActionClass].onDrop = func(m interface{}) {
+			d.seenActions.forget(actionHash(m.(*actionMsg).action))
+		}
 	}
 	return d, nil
 }
@@ -136,8 +326,20 @@ func (d *IotxDispatcher) Start(ctx context.Context) error {
 		return errors.New("Dispatcher already started")
 	}
 	log.L().Info("Starting dispatcher.")
-	d.wg.Add(1)
-	go d.newsHandler()
+	handlers := map[string]func(interface{}){
+		ActionClass:    func(m interface{}) { d.handleActionMsg(m.(*actionMsg)) },
+		BlockClass:     d.handleBlockClassMsg,
+		BlockSyncClass: func(m interface{}) { d.handleBlockSyncMsg(m.(*blockSyncMsg)) },
+		ConsensusClass: func(m interface{}) { d.handleConsensusMsg(m.(*consensusMsg)) },
+	}
+	for class, handle := range handlers {
+		d.wg.Add(1)
+		if class == ActionClass {
+			go d.actionHandler(d.queues[class], handle)
+			continue
+		}
+		go d.newsHandler(d.queues[class], handle)
+	}
 	return nil
 }
 
@@ -153,9 +355,42 @@ func (d *IotxDispatcher) Stop(ctx context.Context) error {
 	return nil
 }
 
-// EventChan returns the event chan
-func (d *IotxDispatcher) EventChan() *chan interface{} {
-	return &d.eventChan
+// QueueDepths returns the number of the messages waiting in the queue of each message class
+func (d *IotxDispatcher) QueueDepths() map[string]int {
+	depths := make(map[string]int, len(d.queues))
+	for class, q := range d.queues {
+		depths[class] = len(q.ch)
+	}
+	return depths
+}
+
+// DroppedMessages returns the number of the messages dropped by the queue of each message class on overflow
+func (d *IotxDispatcher) DroppedMessages() map[string]uint64 {
+	dropped := make(map[string]uint64, len(d.queues))
+	for class, q := range d.queues {
+		dropped[class] = atomic.LoadUint64(&q.dropped)
+	}
+	return dropped
+}
+
+// DuplicateBlocks returns the number of the broadcast blocks dropped as the copies of the blocks seen
+func (d *IotxDispatcher) DuplicateBlocks() uint64 {
+	return atomic.LoadUint64(&d.duplicateBlocks)
+}
+
+// DuplicateActions returns the number of the broadcast actions dropped as the copies of the actions seen
+func (d *IotxDispatcher) DuplicateActions() uint64 {
+	return atomic.LoadUint64(&d.duplicateActions)
+}
+
+// MarkActionsSeen remembers the actions of a committed block, so that their copies gossiped late are dropped
+func (d *IotxDispatcher) MarkActionsSeen(blk *block.Block) {
+	if d.seenActions == nil {
+		return
+	}
+	for _, selp := range blk.Actions {
+		d.seenActions.add(selp.Hash())
+	}
 }
 
 // EventAudit returns the event audit map
@@ -169,55 +404,203 @@ func (d *IotxDispatcher) EventAudit() map[iotexrpc.MessageType]int {
 	return snapshot
 }
 
-// newsHandler is the main handler for handling all news from peers.
-func (d *IotxDispatcher) newsHandler() {
+// newsHandler handles the news from peers in the queue of a message class.
+func (d *IotxDispatcher) newsHandler(q *msgQueue, handle func(interface{})) {
 loop:
 	for {
 		select {
-		case m := <-d.eventChan:
-			switch msg := m.(type) {
-			case *actionMsg:
-				d.handleActionMsg(msg)
-			case *blockMsg:
-				d.handleBlockMsg(msg)
-			case *blockSyncMsg:
-				d.handleBlockSyncMsg(msg)
-
-			default:
-				log.L().Warn("Invalid message type in block handler.", zap.Any("msg", msg))
-			}
+		case m := <-q.ch:
+			q.depth.Set(float64(len(q.ch)))
+			d.handleNews(m, handle)
+		case <-d.quit:
+			break loop
+		}
+	}
+
+	d.wg.Done()
+	log.L().Info("News handler done.", zap.String("class", q.class))
+}
 
+// actionHandler handles the actions in queue like newsHandler, except that it takes the actions piled up in queue
+// along with the one received, up to maxActionBatch, and handles them in a batch.
+func (d *IotxDispatcher) actionHandler(q *msgQueue, handle func(interface{})) {
+loop:
+	for {
+		select {
+		case m := <-q.ch:
+			batch := []*actionMsg{m.(*actionMsg)}
+		drain:
+			for len(batch) < maxActionBatch {
+				select {
+				case m := <-q.ch:
+					batch = append(batch, m.(*actionMsg))
+				default:
+					break drain
+				}
+			}
+			q.depth.Set(float64(len(q.ch)))
+			if len(batch) == 1 {
+				d.handleNews(batch[0], handle)
+				continue
+			}
+			d.handleActionBatch(batch, handle)
 		case <-d.quit:
 			break loop
 		}
 	}
 
 	d.wg.Done()
-	log.L().Info("News handler done.")
+	log.L().Info("News handler done.", zap.String("class", q.class))
+}
+
+// handleActionBatch handles the actions of each chain in a batch. If the batch makes the handler panic, the actions
+// are handled again one by one, so that only the peer sending the malformed action is penalized.
+func (d *IotxDispatcher) handleActionBatch(batch []*actionMsg, handle func(interface{})) {
+	var chainIDs []uint32
+	chains := make(map[uint32][]*actionMsg)
+	for _, m := range batch {
+		if _, ok := chains[m.ChainID()]; !ok {
+			chainIDs = append(chainIDs, m.ChainID())
+		}
+		chains[m.ChainID()] = append(chains[m.ChainID()], m)
+	}
+	for _, chainID := range chainIDs {
+		msgs := chains[chainID]
+		if recovery.Handle(
+			"dispatcher.ACTION_BATCH",
+			nil,
+			func() { d.handleActionMsgs(chainID, msgs) },
+			zap.Int("numActions", len(msgs)),
+		) {
+			for _, m := range msgs {
+				d.handleNews(m, handle)
+			}
+		}
+	}
+}
+
+// handleNews handles a message, and recovers the handler if it panics, so that a malformed message doesn't take down
+// the node. The peer sending the message is penalized.
+func (d *IotxDispatcher) handleNews(m interface{}, handle func(interface{})) {
+	msgType, peer, payload := describeNews(m)
+	if recovery.Handle(
+		"dispatcher."+msgType.String(),
+		payload,
+		func() { handle(m) },
+		zap.String("msgType", msgType.String()),
+		zap.String("peer", peer),
+	) {
+		d.penalties.Penalize(peer)
+	}
+}
+
+// describeNews returns the type, the sender and the payload of a message in queue
+func describeNews(m interface{}) (iotexrpc.MessageType, string, proto.Message) {
+	switch msg := m.(type) {
+	case *actionMsg:
+		return iotexrpc.MessageType_ACTION, msg.peer, msg.action
+	case *blockMsg:
+		return iotexrpc.MessageType_BLOCK, msg.peer, msg.block
+	case *blockSyncMsg:
+		return iotexrpc.MessageType_BLOCK_REQUEST, msg.peer.ID.Pretty(), msg.sync
+	case *blockBatchMsg:
+		return iotexrpc.MessageType_BLOCK_BATCH, msg.peer.ID.Pretty(), msg.batch
+	case *consensusMsg:
+		return iotexrpc.MessageType_CONSENSUS, msg.peer, msg.msg
+	default:
+		return iotexrpc.MessageType_UNKNOWN, "", nil
+	}
+}
+
+func (d *IotxDispatcher) subscriber(chainID uint32) (Subscriber, bool) {
+	d.subscribersMU.RLock()
+	defer d.subscribersMU.RUnlock()
+	subscriber, ok := d.subscribers[chainID]
+	return subscriber, ok
 }
 
 // handleActionMsg handles actionMsg from all peers.
 func (d *IotxDispatcher) handleActionMsg(m *actionMsg) {
 	d.updateEventAudit(iotexrpc.MessageType_ACTION)
-	if subscriber, ok := d.subscribers[m.ChainID()]; ok {
-		if err := subscriber.HandleAction(m.ctx, m.action); err != nil {
-			requestMtc.WithLabelValues("AddAction", "false").Inc()
-			log.L().Debug("Handle action request error.", zap.Error(err))
+	if subscriber, ok := d.subscriber(m.ChainID()); ok {
+		if log.ActionTracing() {
+			log.TraceAction(log.StageDispatched, actionHash(m.action), log.PeerField(m.peer))
+		}
+		err := subscriber.HandleAction(m.ctx, m.action)
+		requestMtc.WithLabelValues("AddAction", strconv.FormatBool(err == nil)).Inc()
+		if err != nil {
+			d.forgetRejectedAction(m.action, err)
+			fields := append(actionLogFields(m.action), log.PeerField(m.peer), zap.Error(err))
+			log.L().Debug("Handle action request error.", fields...)
 		}
 	} else {
 		log.L().Info("No subscriber specified in the dispatcher.", zap.Uint32("chainID", m.ChainID()))
 	}
 }
 
+// handleActionMsgs handles a batch of actionMsg of the chain from all peers.
+func (d *IotxDispatcher) handleActionMsgs(chainID uint32, msgs []*actionMsg) {
+	subscriber, ok := d.subscriber(chainID)
+	if !ok {
+		log.L().Info("No subscriber specified in the dispatcher.", zap.Uint32("chainID", chainID))
+		return
+	}
+	acts := make([]*iotextypes.Action, 0, len(msgs))
+	for _, m := range msgs {
+		d.updateEventAudit(iotexrpc.MessageType_ACTION)
+		if log.ActionTracing() {
+			log.TraceAction(log.StageDispatched, actionHash(m.action), log.PeerField(m.peer))
+		}
+		acts = append(acts, m.action)
+	}
+	errs := subscriber.HandleActions(msgs[0].ctx, acts)
+	for i, m := range msgs {
+		requestMtc.WithLabelValues("AddAction", strconv.FormatBool(errs[i] == nil)).Inc()
+		if errs[i] != nil {
+			d.forgetRejectedAction(m.action, errs[i])
+			fields := append(actionLogFields(m.action), log.PeerField(m.peer), zap.Error(errs[i]))
+			log.L().Debug("Handle action request error.", fields...)
+		}
+	}
+}
+
+// forgetRejectedAction forgets the action rejected for a reason which may not hold later, e.g., the pool being full,
+// the node syncing or a nonce gap, so that the action is handled again when it's retried or gossiped again. The
+// duplicates and the actions of a bad signature or address stay remembered, as they are rejected for good
+func (d *IotxDispatcher) forgetRejectedAction(act *iotextypes.Action, err error) {
+	if d.seenActions == nil {
+		return
+	}
+	switch errors.Cause(err) {
+	case actpool.ErrDuplicate, action.ErrSignature, action.ErrAddress:
+		return
+	}
+	d.seenActions.forget(actionHash(act))
+}
+
 // handleBlockMsg handles blockMsg from peers.
 func (d *IotxDispatcher) handleBlockMsg(m *blockMsg) {
-	d.subscribersMU.RLock()
-	defer d.subscribersMU.RUnlock()
-	if subscriber, ok := d.subscribers[m.ChainID()]; ok {
+	if subscriber, ok := d.subscriber(m.ChainID()); ok {
 		d.updateEventAudit(iotexrpc.MessageType_BLOCK)
+		handled := false
+		if m.seen != nil {
+			// only the block handled is remembered, so that its copies are dropped. The one failing or panicking is
+			// forgotten, since the copy may be bad while its header is good, e.g., with a tampered body, and the next
+			// copy is handled then
+			defer func() {
+				if handled {
+					d.seenBlocks.done(*m.seen)
+				} else {
+					d.seenBlocks.forget(*m.seen)
+				}
+			}()
+		}
 		if err := subscriber.HandleBlock(m.ctx, m.block); err != nil {
-			log.L().Error("Fail to handle the block.", zap.Error(err))
+			fields := append(blockLogFields(m.block), log.PeerField(m.peer), zap.Error(err))
+			log.L().Error("Fail to handle the block.", fields...)
+			return
 		}
+		handled = true
 	} else {
 		log.L().Info("No subscriber specified in the dispatcher.", zap.Uint32("chainID", m.ChainID()))
 	}
@@ -231,7 +614,7 @@ func (d *IotxDispatcher) handleBlockSyncMsg(m *blockSyncMsg) {
 		zap.Uint64("end", m.sync.End))
 
 	d.updateEventAudit(iotexrpc.MessageType_BLOCK_REQUEST)
-	if subscriber, ok := d.subscribers[m.ChainID()]; ok {
+	if subscriber, ok := d.subscriber(m.ChainID()); ok {
 		// dispatch to block sync
 		if err := subscriber.HandleSyncRequest(m.ctx, m.peer, m.sync); err != nil {
 			log.L().Error("Failed to handle sync request.", zap.Error(err))
@@ -241,36 +624,111 @@ func (d *IotxDispatcher) handleBlockSyncMsg(m *blockSyncMsg) {
 	}
 }
 
-// dispatchAction adds the passed action message to the news handling queue.
-func (d *IotxDispatcher) dispatchAction(ctx context.Context, chainID uint32, msg proto.Message) {
+// handleBlockClassMsg handles the blocks and the block batches, which share the queue of the block class.
+func (d *IotxDispatcher) handleBlockClassMsg(m interface{}) {
+	switch msg := m.(type) {
+	case *blockMsg:
+		d.handleBlockMsg(msg)
+	case *blockBatchMsg:
+		d.handleBlockBatchMsg(msg)
+	}
+}
+
+// handleBlockBatchMsg handles the block batches served by peers.
+func (d *IotxDispatcher) handleBlockBatchMsg(m *blockBatchMsg) {
+	if subscriber, ok := d.subscriber(m.ChainID()); ok {
+		d.updateEventAudit(iotexrpc.MessageType_BLOCK_BATCH)
+		if err := subscriber.HandleBlockBatch(m.ctx, m.peer, m.batch); err != nil {
+			log.L().Error("Fail to handle the block batch.", log.PeerField(m.peer.ID.Pretty()), zap.Error(err))
+		}
+	} else {
+		log.L().Info("No subscriber specified in the dispatcher.", zap.Uint32("chainID", m.ChainID()))
+	}
+}
+
+// handleConsensusMsg handles consensus messages from peers.
+func (d *IotxDispatcher) handleConsensusMsg(m *consensusMsg) {
+	d.updateEventAudit(iotexrpc.MessageType_CONSENSUS)
+	if subscriber, ok := d.subscriber(m.ChainID()); ok {
+		if err := subscriber.HandleConsensusMsg(m.msg); err != nil {
+			log.L().Debug("Failed to handle consensus message.", zap.Error(err))
+		}
+	} else {
+		log.L().Info("No subscriber specified in the dispatcher.", zap.Uint32("chainID", m.ChainID()))
+	}
+}
+
+// dispatchAction adds the passed action message to the news handling queue, unless it's the copy of an action
+// broadcast or committed recently, which is dropped with only the counter incremented.
+func (d *IotxDispatcher) dispatchAction(ctx context.Context, chainID uint32, peer string, msg proto.Message) {
 	if atomic.LoadInt32(&d.shutdown) != 0 {
 		return
 	}
-	d.enqueueEvent(&actionMsg{
+	act := (msg).(*iotextypes.Action)
+	if d.seenActions != nil && d.seenActions.add(actionHash(act)) {
+		atomic.AddUint64(&d.duplicateActions, 1)
+		duplicateActionMtc.Inc()
+		return
+	}
+	d.enqueueEvent(ActionClass, &actionMsg{
 		ctx:     ctx,
 		chainID: chainID,
-		action:  (msg).(*iotextypes.Action),
+		action:  act,
+		peer:    peer,
 	})
 }
 
 // dispatchBlockCommit adds the passed block message to the news handling queue.
-func (d *IotxDispatcher) dispatchBlockCommit(ctx context.Context, chainID uint32, msg proto.Message) {
+func (d *IotxDispatcher) dispatchBlockCommit(ctx context.Context, chainID uint32, peer string, msg proto.Message) {
 	if atomic.LoadInt32(&d.shutdown) != 0 {
 		return
 	}
-	d.enqueueEvent(&blockMsg{
+	d.enqueueEvent(BlockClass, &blockMsg{
 		ctx:     ctx,
 		chainID: chainID,
 		block:   (msg).(*iotextypes.Block),
+		peer:    peer,
 	})
 }
 
+// dispatchBroadcastBlock adds the broadcast block to the news handling queue, unless it's the copy of a block being
+// handled or handled recently, which is dropped with only the counter incremented.
+func (d *IotxDispatcher) dispatchBroadcastBlock(ctx context.Context, chainID uint32, peer string, msg proto.Message) {
+	if d.seenBlocks == nil {
+		d.dispatchBlockCommit(ctx, chainID, peer, msg)
+		return
+	}
+	if atomic.LoadInt32(&d.shutdown) != 0 {
+		return
+	}
+	blk := (msg).(*iotextypes.Block)
+	key, ok := keyOf(chainID, blk)
+	if !ok {
+		d.dispatchBlockCommit(ctx, chainID, peer, msg)
+		return
+	}
+	if d.seenBlocks.add(key) {
+		atomic.AddUint64(&d.duplicateBlocks, 1)
+		duplicateBlockMtc.Inc()
+		return
+	}
+	if !d.enqueueEvent(BlockClass, &blockMsg{
+		ctx:     ctx,
+		chainID: chainID,
+		block:   blk,
+		peer:    peer,
+		seen:    &key,
+	}) {
+		d.seenBlocks.forget(key)
+	}
+}
+
 // dispatchBlockSyncReq adds the passed block sync request to the news handling queue.
 func (d *IotxDispatcher) dispatchBlockSyncReq(ctx context.Context, chainID uint32, peer peerstore.PeerInfo, msg proto.Message) {
 	if atomic.LoadInt32(&d.shutdown) != 0 {
 		return
 	}
-	d.enqueueEvent(&blockSyncMsg{
+	d.enqueueEvent(BlockSyncClass, &blockSyncMsg{
 		ctx:     ctx,
 		chainID: chainID,
 		peer:    peer,
@@ -278,30 +736,53 @@ func (d *IotxDispatcher) dispatchBlockSyncReq(ctx context.Context, chainID uint3
 	})
 }
 
+// dispatchBlockBatch adds the passed block batch to the news handling queue.
+func (d *IotxDispatcher) dispatchBlockBatch(ctx context.Context, chainID uint32, peer peerstore.PeerInfo, msg proto.Message) {
+	if atomic.LoadInt32(&d.shutdown) != 0 {
+		return
+	}
+	d.enqueueEvent(BlockClass, &blockBatchMsg{
+		ctx:     ctx,
+		chainID: chainID,
+		peer:    peer,
+		batch:   (msg).(*iotexrpc.BlockBatch),
+	})
+}
+
+// dispatchConsensus adds the passed consensus message to the news handling queue.
+func (d *IotxDispatcher) dispatchConsensus(chainID uint32, peer string, msg proto.Message) {
+	if atomic.LoadInt32(&d.shutdown) != 0 {
+		return
+	}
+	d.enqueueEvent(ConsensusClass, &consensusMsg{
+		chainID: chainID,
+		msg:     (msg).(*iotextypes.ConsensusMessage),
+		peer:    peer,
+	})
+}
+
 // HandleBroadcast handles incoming broadcast message
 func (d *IotxDispatcher) HandleBroadcast(ctx context.Context, chainID uint32, message proto.Message) {
 	msgType, err := protogen.GetTypeFromRPCMsg(message)
 	if err != nil {
 		log.L().Warn("Unexpected message handled by HandleBroadcast.", zap.Error(err))
 	}
-	d.subscribersMU.RLock()
-	subscriber, ok := d.subscribers[chainID]
-	if !ok {
+	if _, ok := d.subscriber(chainID); !ok {
 		log.L().Warn("chainID has not been registered in dispatcher.", zap.Uint32("chainID", chainID))
-		d.subscribersMU.RUnlock()
 		return
 	}
-	d.subscribersMU.RUnlock()
+	peer, _ := p2p.GetSender(ctx)
+	if d.dropPenalized(peer, msgType) {
+		return
+	}
 
 	switch msgType {
 	case iotexrpc.MessageType_CONSENSUS:
-		if err := subscriber.HandleConsensusMsg(message.(*iotextypes.ConsensusMessage)); err != nil {
-			log.L().Debug("Failed to handle consensus message.", zap.Error(err))
-		}
+		d.dispatchConsensus(chainID, peer, message)
 	case iotexrpc.MessageType_ACTION:
-		d.dispatchAction(ctx, chainID, message)
+		d.dispatchAction(ctx, chainID, peer, message)
 	case iotexrpc.MessageType_BLOCK:
-		d.dispatchBlockCommit(ctx, chainID, message)
+		d.dispatchBroadcastBlock(ctx, chainID, peer, message)
 	default:
 		log.L().Warn("Unexpected msgType handled by HandleBroadcast.", zap.Any("msgType", msgType))
 	}
@@ -313,24 +794,35 @@ func (d *IotxDispatcher) HandleTell(ctx context.Context, chainID uint32, peer pe
 	if err != nil {
 		log.L().Warn("Unexpected message handled by HandleTell.", zap.Error(err))
 	}
+	if d.dropPenalized(peer.ID.Pretty(), msgType) {
+		return
+	}
 	switch msgType {
 	case iotexrpc.MessageType_BLOCK_REQUEST:
 		d.dispatchBlockSyncReq(ctx, chainID, peer, message)
 	case iotexrpc.MessageType_BLOCK:
-		d.dispatchBlockCommit(ctx, chainID, message)
+		d.dispatchBlockCommit(blocksync.WithPeer(ctx, peer), chainID, peer.ID.Pretty(), message)
+	case iotexrpc.MessageType_BLOCK_BATCH:
+		d.dispatchBlockBatch(ctx, chainID, peer, message)
 	default:
 		log.L().Warn("Unexpected msgType handled by HandleTell.", zap.Any("msgType", msgType))
 	}
 }
 
-func (d *IotxDispatcher) enqueueEvent(event interface{}) {
-	go func() {
-		if len(d.eventChan) == cap(d.eventChan) {
-			log.L().Debug("dispatcher event chan is full, drop an event.")
-			return
-		}
-		d.eventChan <- event
-	}()
+// dropPenalized tells whether the message is from a peer penalized for a message making a handler panic, which is
+// dropped
+func (d *IotxDispatcher) dropPenalized(peer string, msgType iotexrpc.MessageType) bool {
+	if !d.penalties.Penalized(peer) {
+		return false
+	}
+	log.L().Debug("Drop the message from the penalized peer.",
+		zap.String("peer", peer),
+		zap.String("msgType", msgType.String()))
+	return true
+}
+
+func (d *IotxDispatcher) enqueueEvent(class string, event interface{}) bool {
+	return d.queues[class].put(event, d.quit)
 }
 
 func (d *IotxDispatcher) updateEventAudit(t iotexrpc.MessageType) {
@@ -338,3 +830,29 @@ func (d *IotxDispatcher) updateEventAudit(t iotexrpc.MessageType) {
 	defer d.eventAuditLock.Unlock()
 	d.eventAudit[t]++
 }
+
+// actionHash returns the hash of the action, which is the same as the hash of the sealed envelope it's loaded into
+func actionHash(pb *iotextypes.Action) hash.Hash256 {
+	return hash.Hash256b(byteutil.Must(proto.Marshal(pb)))
+}
+
+// actionLogFields returns the fields of the action logged, which only has the hash if the action is malformed
+func actionLogFields(pb *iotextypes.Action) []zap.Field {
+	var selp action.SealedEnvelope
+	if err := selp.LoadProto(pb); err != nil {
+		return []zap.Field{log.ShortHash("action", actionHash(pb))}
+	}
+	return selp.LogFields()
+}
+
+// blockLogFields returns the fields of the block logged, which is empty if the block header is malformed
+func blockLogFields(pb *iotextypes.Block) []zap.Field {
+	if pb.GetHeader() == nil {
+		return nil
+	}
+	var header block.Header
+	if err := header.LoadFromBlockHeaderProto(pb.GetHeader()); err != nil {
+		return nil
+	}
+	return header.LogFields()
+}
@@ -8,23 +8,39 @@ package dispatcher
 
 import (
 	"context"
+	"math/big"
+	"runtime"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/golang/protobuf/proto"
 	peerstore "github.com/libp2p/go-libp2p-peerstore"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/actpool"
+	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/p2p"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/recovery"
 	"github.com/iotexproject/iotex-core/protogen/iotexrpc"
 	"github.com/iotexproject/iotex-core/protogen/iotextypes"
 	"github.com/iotexproject/iotex-core/protogen/testingpb"
+	"github.com/iotexproject/iotex-core/testutil"
 )
 
 func createDispatcher(t *testing.T, chainID uint32) Dispatcher {
+	dc := config.Default.Dispatcher
+	dc.EventChanSize = 1024
 	cfg := config.Config{
 		Consensus:  config.Consensus{Scheme: config.NOOPScheme},
-		Dispatcher: config.Dispatcher{EventChanSize: 1024},
+		Dispatcher: dc,
 	}
 	dp, err := NewDispatcher(cfg)
 	assert.NoError(t, err)
@@ -52,6 +68,7 @@ func setTestCase() []proto.Message {
 		&iotextypes.ConsensusMessage{},
 		&iotextypes.Block{},
 		&iotexrpc.BlockSync{},
+		&iotexrpc.BlockBatch{},
 		&testingpb.TestPayload{},
 	}
 }
@@ -86,6 +103,589 @@ func TestHandleTell(t *testing.T) {
 	}
 }
 
+func TestBoundedQueues(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default
+	cfg.Dispatcher.EventChanSize = 1000
+	cfg.Dispatcher.BlockChanSize = 1
+	cfg.Dispatcher.BlockEnqueueTimeout = 10 * time.Millisecond
+	cfg.Dispatcher.ConsensusChanSize = 1000
+	dp, err := NewDispatcher(cfg)
+	require.NoError(err)
+	d := dp.(*IotxDispatcher)
+	sub := &blockingSubscriber{release: make(chan struct{})}
+	chainID := config.Default.Chain.ID
+	d.AddSubscriber(chainID, sub)
+	ctx := context.Background()
+	require.NoError(d.Start(ctx))
+	defer func() {
+		require.NoError(d.Stop(ctx))
+	}()
+
+	// the senders never block on the actions, and no goroutine is spawned per message
+	numGoroutines := runtime.NumGoroutine()
+	numActions := 100000
+	for i := 0; i < numActions; i++ {
+		d.HandleBroadcast(ctx, chainID, &iotextypes.Action{Core: &iotextypes.ActionCore{Nonce: uint64(i)}})
+	}
+	require.True(runtime.NumGoroutine() < numGoroutines+10)
+	require.Equal(1000, d.QueueDepths()[ActionClass])
+	dropped := d.DroppedMessages()[ActionClass]
+	require.True(dropped >= uint64(numActions-1000-maxActionBatch))
+
+	// the consensus messages are kept below the hard cap
+	for i := 0; i < 500; i++ {
+		d.HandleBroadcast(ctx, chainID, &iotextypes.ConsensusMessage{})
+	}
+	require.Zero(d.DroppedMessages()[ConsensusClass])
+
+	// the block senders wait for a while, then the blocks are dropped
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		d.HandleBroadcast(ctx, chainID, &iotextypes.Block{})
+	}
+	require.True(time.Since(start) < time.Second)
+	require.True(d.DroppedMessages()[BlockClass] >= 1)
+
+	// every action is either handled or counted as dropped
+	close(sub.release)
+	require.NoError(testutil.WaitUntil(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		return d.QueueDepths()[ActionClass] == 0 && atomic.LoadUint64(&sub.numActions)+dropped == uint64(numActions), nil
+	}))
+	require.NoError(testutil.WaitUntil(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		return atomic.LoadUint64(&sub.numConsensusMsgs) == 500, nil
+	}))
+}
+
+func TestDeduplicateBroadcastBlocks(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default
+	cfg.Dispatcher.SeenBlockCacheSize = 16
+	cfg.Dispatcher.SeenBlockTTL = time.Minute
+	dp, err := NewDispatcher(cfg)
+	require.NoError(err)
+	d := dp.(*IotxDispatcher)
+	sub := &countingSubscriber{validated: make(map[hash.Hash256]int)}
+	chainID := config.Default.Chain.ID
+	d.AddSubscriber(chainID, sub)
+	ctx := context.Background()
+	require.NoError(d.Start(ctx))
+	defer func() {
+		require.NoError(d.Stop(ctx))
+	}()
+
+	// blocks 1 and 2, and a fork of block 2 by another producer
+	producer := testutil.NewKeyPair("producer")
+	forker := testutil.NewKeyPair("forker")
+	var blks []*iotextypes.Block
+	var hashes []hash.Hash256
+	prevHash := hash.ZeroHash256
+	for _, b := range []struct {
+		height uint64
+		signer *testutil.KeyPair
+	}{{1, producer}, {2, producer}, {2, forker}} {
+		blk, err := block.NewTestingBuilder().
+			SetHeight(b.height).
+			SetPrevBlockHash(prevHash).
+			SetTimeStamp(testutil.TimestampNow()).
+			SignAndBuild(b.signer.PubKey, b.signer.PriKey)
+		require.NoError(err)
+		if b.height == 1 {
+			prevHash = blk.HashBlock()
+		}
+		blks = append(blks, blk.ConvertToBlockPb())
+		hashes = append(hashes, blk.HashBlock())
+	}
+
+	// every peer gossips a copy of every block, which arrive while the first copies are being validated
+	numPeers := 5
+	var wg sync.WaitGroup
+	for i := 0; i < numPeers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, blk := range blks {
+				d.HandleBroadcast(ctx, chainID, proto.Clone(blk))
+			}
+		}()
+	}
+	wg.Wait()
+	require.NoError(testutil.WaitUntil(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		return sub.numValidated() == len(blks), nil
+	}))
+	for _, h := range hashes {
+		require.Equal(1, sub.count(h))
+	}
+	require.Equal(uint64(len(blks)*(numPeers-1)), d.DuplicateBlocks())
+
+	// the copies arriving after the validation are dropped too, while the blocks synced from a peer aren't
+	d.HandleBroadcast(ctx, chainID, blks[0])
+	require.Equal(uint64(len(blks)*(numPeers-1)+1), d.DuplicateBlocks())
+	d.HandleTell(ctx, chainID, peerstore.PeerInfo{}, blks[0])
+	require.NoError(testutil.WaitUntil(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		return sub.count(hashes[0]) == 2, nil
+	}))
+}
+
+func TestRetryFailedBroadcastBlocks(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default
+	cfg.Dispatcher.SeenBlockCacheSize = 16
+	cfg.Dispatcher.SeenBlockTTL = time.Minute
+	dp, err := NewDispatcher(cfg)
+	require.NoError(err)
+	d := dp.(*IotxDispatcher)
+	sub := &txRootSubscriber{}
+	chainID := config.Default.Chain.ID
+	d.AddSubscriber(chainID, sub)
+	ctx := context.Background()
+	require.NoError(d.Start(ctx))
+	defer func() {
+		require.NoError(d.Stop(ctx))
+	}()
+
+	producer := testutil.NewKeyPair("producer")
+	blk, err := block.NewTestingBuilder().
+		SetHeight(1).
+		SetPrevBlockHash(hash.ZeroHash256).
+		SetTimeStamp(testutil.TimestampNow()).
+		AddActions(signedTransfers(t, 1)...).
+		SignAndBuild(producer.PubKey, producer.PriKey)
+	require.NoError(err)
+	pb := blk.ConvertToBlockPb()
+
+	// a peer gossips the block of the good header with the actions dropped first, which fails and is forgotten, so
+	// that the good copy from another peer is handled
+	tampered := proto.Clone(pb).(*iotextypes.Block)
+	tampered.Body.Actions = nil
+	d.HandleBroadcast(p2p.WithSender(ctx, "peerA"), chainID, tampered)
+	require.NoError(testutil.WaitUntil(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		return atomic.LoadUint64(&sub.numFailed) == 1, nil
+	}))
+	d.HandleBroadcast(p2p.WithSender(ctx, "peerB"), chainID, pb)
+	require.NoError(testutil.WaitUntil(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		return atomic.LoadUint64(&sub.numHandled) == 1, nil
+	}))
+	require.Equal(uint64(0), d.DuplicateBlocks())
+
+	// the copies after the block is handled are dropped
+	d.HandleBroadcast(p2p.WithSender(ctx, "peerA"), chainID, tampered)
+	require.Equal(uint64(1), d.DuplicateBlocks())
+	require.Equal(uint64(1), atomic.LoadUint64(&sub.numFailed))
+}
+
+func TestDeduplicateBroadcastActions(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default
+	cfg.Dispatcher.SeenActionCacheSize = 16
+	dp, err := NewDispatcher(cfg)
+	require.NoError(err)
+	d := dp.(*IotxDispatcher)
+	sub := &verifyingSubscriber{}
+	chainID := config.Default.Chain.ID
+	d.AddSubscriber(chainID, sub)
+	ctx := context.Background()
+	require.NoError(d.Start(ctx))
+	defer func() {
+		require.NoError(d.Stop(ctx))
+	}()
+	acts := signedTransfers(t, 18)
+
+	// every peer gossips a copy of the action, which is handled once
+	for _, peer := range []string{"peerA", "peerB", "peerC", "peerD", "peerE"} {
+		d.HandleBroadcast(p2p.WithSender(ctx, peer), chainID, acts[0].Proto())
+	}
+	require.NoError(testutil.WaitUntil(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		return sub.numHandled() == 1, nil
+	}))
+	require.Equal(uint64(4), d.DuplicateActions())
+
+	// the action committed in a block is dropped when gossiped late
+	blk, err := block.NewTestingBuilder().
+		SetHeight(1).
+		SetPrevBlockHash(hash.ZeroHash256).
+		SetTimeStamp(testutil.TimestampNow()).
+		AddActions(acts[1]).
+		SignAndBuild(testutil.NewKeyPair("producer").PubKey, testutil.NewKeyPair("producer").PriKey)
+	require.NoError(err)
+	d.MarkActionsSeen(&blk)
+	d.HandleBroadcast(ctx, chainID, acts[1].Proto())
+	require.Equal(uint64(5), d.DuplicateActions())
+
+	// the cache is bounded, which forgets the least recently seen actions
+	for _, act := range acts[2:] {
+		d.HandleBroadcast(ctx, chainID, act.Proto())
+	}
+	d.HandleBroadcast(ctx, chainID, acts[0].Proto())
+	require.NoError(testutil.WaitUntil(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		return sub.numHandled() == uint64(len(acts)), nil
+	}))
+	require.Equal(uint64(5), d.DuplicateActions())
+}
+
+// BenchmarkDuplicateActions measures the time to admit a burst of 1000 action messages, 80% of which are the copies
+// gossiped by the other peers, with and without the seen action cache
+func TestRetryRejectedActions(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default
+	cfg.Dispatcher.SeenActionCacheSize = 16
+	dp, err := NewDispatcher(cfg)
+	require.NoError(err)
+	d := dp.(*IotxDispatcher)
+	sub := &syncingSubscriber{}
+	sub.syncing.Store(true)
+	chainID := config.Default.Chain.ID
+	d.AddSubscriber(chainID, sub)
+	ctx := context.Background()
+	require.NoError(d.Start(ctx))
+	defer func() {
+		require.NoError(d.Stop(ctx))
+	}()
+	acts := signedTransfers(t, 2)
+	// the action of nonce 2 carries the signature of the one of nonce 1
+	bad := acts[1].Proto()
+	bad.Signature = acts[0].Signature()
+
+	// the action rejected while syncing is taken once retried, while the bad one stays rejected for good
+	for _, pb := range []*iotextypes.Action{acts[0].Proto(), bad} {
+		d.HandleBroadcast(ctx, chainID, pb)
+	}
+	require.NoError(testutil.WaitUntil(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		return sub.numHandled() == 2, nil
+	}))
+	sub.syncing.Store(false)
+	for _, pb := range []*iotextypes.Action{acts[0].Proto(), bad} {
+		d.HandleBroadcast(ctx, chainID, pb)
+	}
+	require.NoError(testutil.WaitUntil(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		return sub.numHandled() == 3, nil
+	}))
+	require.Equal([]hash.Hash256{acts[0].Hash()}, sub.taken())
+	require.Equal(uint64(1), d.DuplicateActions())
+
+	// and its copies are dropped from then on
+	d.HandleBroadcast(ctx, chainID, acts[0].Proto())
+	require.Equal(uint64(2), d.DuplicateActions())
+}
+
+func BenchmarkDuplicateActions(b *testing.B) {
+	const numUnique, numCopies = 200, 5
+	acts := signedTransfers(b, numUnique)
+	for _, c := range []struct {
+		name      string
+		cacheSize uint
+		handled   uint64
+	}{
+		{"without cache", 0, numUnique * numCopies},
+		{"with cache", config.Default.Dispatcher.SeenActionCacheSize, numUnique},
+	} {
+		b.Run(c.name, func(b *testing.B) {
+			cfg := config.Default
+			cfg.Dispatcher.SeenActionCacheSize = c.cacheSize
+			ctx := context.Background()
+			chainID := config.Default.Chain.ID
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				dp, err := NewDispatcher(cfg)
+				require.NoError(b, err)
+				sub := &verifyingSubscriber{}
+				dp.AddSubscriber(chainID, sub)
+				require.NoError(b, dp.Start(ctx))
+				b.StartTimer()
+				for _, act := range acts {
+					for j := 0; j < numCopies; j++ {
+						dp.HandleBroadcast(ctx, chainID, act.Proto())
+					}
+				}
+				for sub.numHandled() < c.handled {
+					time.Sleep(100 * time.Microsecond)
+				}
+				b.StopTimer()
+				require.NoError(b, dp.Stop(ctx))
+				b.StartTimer()
+			}
+		})
+	}
+}
+
+// signedTransfers returns n transfers of the consecutive nonces signed by the same sender
+func signedTransfers(t testing.TB, n int) []action.SealedEnvelope {
+	sender := testutil.NewKeyPair("sender")
+	recipient := testutil.NewKeyPair("recipient").Address.String()
+	acts := make([]action.SealedEnvelope, 0, n)
+	for nonce := uint64(1); nonce <= uint64(n); nonce++ {
+		tsf, err := testutil.SignedTransfer(recipient, sender.PriKey, nonce, big.NewInt(1), []byte{}, uint64(100000),
+			big.NewInt(0))
+		require.NoError(t, err)
+		acts = append(acts, tsf)
+	}
+	return acts
+}
+
+// verifyingSubscriber decodes the actions and verifies their signatures like the chain service, and counts them
+type verifyingSubscriber struct {
+	DummySubscriber
+	handled uint64
+}
+
+func (s *verifyingSubscriber) HandleAction(_ context.Context, pb *iotextypes.Action) error {
+	var selp action.SealedEnvelope
+	if err := selp.LoadProto(pb); err != nil {
+		return err
+	}
+	atomic.AddUint64(&s.handled, 1)
+	return action.Verify(selp)
+}
+
+func (s *verifyingSubscriber) HandleActions(_ context.Context, pbs []*iotextypes.Action) []error {
+	errs := make([]error, len(pbs))
+	selps := make([]action.SealedEnvelope, len(pbs))
+	for i, pb := range pbs {
+		errs[i] = selps[i].LoadProto(pb)
+	}
+	for i, err := range action.VerifyActions(selps) {
+		if errs[i] == nil {
+			errs[i] = err
+		}
+	}
+	atomic.AddUint64(&s.handled, uint64(len(pbs)))
+	return errs
+}
+
+func (s *verifyingSubscriber) numHandled() uint64 {
+	return atomic.LoadUint64(&s.handled)
+}
+
+// syncingSubscriber rejects the actions with actpool.ErrNodeSyncing while syncing, and takes the ones of a valid
+// signature otherwise
+type syncingSubscriber struct {
+	verifyingSubscriber
+	syncing atomic.Value
+	mu      sync.Mutex
+	hashes  []hash.Hash256
+}
+
+func (s *syncingSubscriber) HandleAction(ctx context.Context, pb *iotextypes.Action) error {
+	return s.HandleActions(ctx, []*iotextypes.Action{pb})[0]
+}
+
+func (s *syncingSubscriber) HandleActions(ctx context.Context, pbs []*iotextypes.Action) []error {
+	errs := s.verifyingSubscriber.HandleActions(ctx, pbs)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, pb := range pbs {
+		if errs[i] != nil {
+			continue
+		}
+		if s.syncing.Load().(bool) {
+			errs[i] = errors.Wrap(actpool.ErrNodeSyncing, "cannot take the action")
+			continue
+		}
+		var selp action.SealedEnvelope
+		if errs[i] = selp.LoadProto(pb); errs[i] == nil {
+			s.hashes = append(s.hashes, selp.Hash())
+		}
+	}
+	return errs
+}
+
+func (s *syncingSubscriber) taken() []hash.Hash256 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]hash.Hash256(nil), s.hashes...)
+}
+
+func TestRecoverPanickingHandler(t *testing.T) {
+	require := require.New(t)
+
+	dp, err := NewDispatcher(config.Default)
+	require.NoError(err)
+	d := dp.(*IotxDispatcher)
+	sub := &panickingSubscriber{}
+	chainID := config.Default.Chain.ID
+	d.AddSubscriber(chainID, sub)
+	ctx := context.Background()
+	require.NoError(d.Start(ctx))
+	defer func() {
+		require.NoError(d.Stop(ctx))
+	}()
+
+	// the malformed action makes the handler panic, which is recovered and counted, and its sender is penalized
+	panics := recovery.Panics("dispatcher.ACTION")
+	ctxA := p2p.WithSender(ctx, "peerA")
+	ctxB := p2p.WithSender(ctx, "peerB")
+	d.HandleBroadcast(ctxA, chainID, &iotextypes.Action{})
+	require.NoError(testutil.WaitUntil(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		return recovery.Panics("dispatcher.ACTION") == panics+1, nil
+	}))
+	require.True(d.penalties.Penalized("peerA"))
+	require.False(d.penalties.Penalized("peerB"))
+
+	// the node keeps handling the messages, except the ones from the penalized peer
+	d.HandleBroadcast(ctxA, chainID, &iotextypes.Action{Core: &iotextypes.ActionCore{Nonce: 1}})
+	d.HandleTell(ctx, chainID, peerstore.PeerInfo{}, &iotexrpc.BlockSync{})
+	d.HandleBroadcast(ctxB, chainID, &iotextypes.Action{Core: &iotextypes.ActionCore{Nonce: 2}})
+	require.NoError(testutil.WaitUntil(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		return atomic.LoadUint64(&sub.numActions) == 1, nil
+	}))
+	require.Equal(panics+1, recovery.Panics("dispatcher.ACTION"))
+}
+
+func TestHandleActionBatch(t *testing.T) {
+	require := require.New(t)
+
+	dp, err := NewDispatcher(config.Default)
+	require.NoError(err)
+	d := dp.(*IotxDispatcher)
+	sub := &batchingSubscriber{}
+	chainID := config.Default.Chain.ID
+	d.AddSubscriber(chainID, sub)
+	ctx := context.Background()
+
+	// the actions piled up in queue are handled in a batch, in the order they arrive
+	for nonce := uint64(1); nonce <= 10; nonce++ {
+		d.HandleBroadcast(ctx, chainID, &iotextypes.Action{Core: &iotextypes.ActionCore{Nonce: nonce}})
+	}
+	require.NoError(d.Start(ctx))
+	defer func() {
+		require.NoError(d.Stop(ctx))
+	}()
+	require.NoError(testutil.WaitUntil(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		return len(sub.handled()) == 1, nil
+	}))
+	require.Equal([]uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, sub.handled()[0])
+}
+
+// batchingSubscriber records the nonces of each batch of actions
+type batchingSubscriber struct {
+	DummySubscriber
+	mu      sync.Mutex
+	batches [][]uint64
+}
+
+func (s *batchingSubscriber) HandleActions(_ context.Context, acts []*iotextypes.Action) []error {
+	nonces := make([]uint64, 0, len(acts))
+	for _, act := range acts {
+		nonces = append(nonces, act.GetCore().GetNonce())
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.batches = append(s.batches, nonces)
+	return make([]error, len(acts))
+}
+
+func (s *batchingSubscriber) handled() [][]uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.batches
+}
+
+// panickingSubscriber trips an unguarded index on the actions without nonce, and counts the others
+type panickingSubscriber struct {
+	DummySubscriber
+	numActions uint64
+}
+
+func (s *panickingSubscriber) HandleAction(_ context.Context, act *iotextypes.Action) error {
+	nonces := []uint64{1, 2}
+	_ = nonces[act.GetCore().GetNonce()-1]
+	atomic.AddUint64(&s.numActions, 1)
+	return nil
+}
+
+func (s *panickingSubscriber) HandleActions(ctx context.Context, acts []*iotextypes.Action) []error {
+	return handleEach(ctx, acts, s.HandleAction)
+}
+
+// countingSubscriber validates the blocks slowly, and counts the validations of each block
+type countingSubscriber struct {
+	DummySubscriber
+	mu        sync.Mutex
+	validated map[hash.Hash256]int
+}
+
+func (s *countingSubscriber) HandleBlock(_ context.Context, pb *iotextypes.Block) error {
+	blk := &block.Block{}
+	if err := blk.ConvertFromBlockPb(pb); err != nil {
+		return err
+	}
+	time.Sleep(20 * time.Millisecond)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.validated[blk.HashBlock()]++
+	return nil
+}
+
+func (s *countingSubscriber) count(h hash.Hash256) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.validated[h]
+}
+
+func (s *countingSubscriber) numValidated() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for _, c := range s.validated {
+		n += c
+	}
+	return n
+}
+
+// txRootSubscriber rejects the blocks whose actions don't match the action root in the header, and counts the blocks
+type txRootSubscriber struct {
+	DummySubscriber
+	numHandled uint64
+	numFailed  uint64
+}
+
+func (s *txRootSubscriber) HandleBlock(_ context.Context, pb *iotextypes.Block) error {
+	blk := &block.Block{}
+	if err := blk.ConvertFromBlockPb(pb); err != nil {
+		return err
+	}
+	if blk.CalculateTxRoot() != blk.TxRoot() {
+		atomic.AddUint64(&s.numFailed, 1)
+		return errors.New("action root mismatch")
+	}
+	atomic.AddUint64(&s.numHandled, 1)
+	return nil
+}
+
+// blockingSubscriber holds the messages until released, and counts them
+type blockingSubscriber struct {
+	DummySubscriber
+	release          chan struct{}
+	numActions       uint64
+	numConsensusMsgs uint64
+}
+
+func (s *blockingSubscriber) HandleBlock(context.Context, *iotextypes.Block) error {
+	<-s.release
+	return nil
+}
+
+func (s *blockingSubscriber) HandleAction(context.Context, *iotextypes.Action) error {
+	<-s.release
+	atomic.AddUint64(&s.numActions, 1)
+	return nil
+}
+
+func (s *blockingSubscriber) HandleActions(ctx context.Context, acts []*iotextypes.Action) []error {
+	return handleEach(ctx, acts, s.HandleAction)
+}
+
+func (s *blockingSubscriber) HandleConsensusMsg(*iotextypes.ConsensusMessage) error {
+	<-s.release
+	atomic.AddUint64(&s.numConsensusMsgs, 1)
+	return nil
+}
+
 type DummySubscriber struct{}
 
 func (s *DummySubscriber) HandleBlock(context.Context, *iotextypes.Block) error { return nil }
@@ -96,6 +696,27 @@ func (s *DummySubscriber) HandleSyncRequest(context.Context, peerstore.PeerInfo,
 	return nil
 }
 
+func (s *DummySubscriber) HandleBlockBatch(context.Context, peerstore.PeerInfo, *iotexrpc.BlockBatch) error {
+	return nil
+}
+
 func (s *DummySubscriber) HandleAction(context.Context, *iotextypes.Action) error { return nil }
 
+func (s *DummySubscriber) HandleActions(ctx context.Context, acts []*iotextypes.Action) []error {
+	return handleEach(ctx, acts, s.HandleAction)
+}
+
+// handleEach handles the actions one by one
+func handleEach(
+	ctx context.Context,
+	acts []*iotextypes.Action,
+	handle func(context.Context, *iotextypes.Action) error,
+) []error {
+	errs := make([]error, len(acts))
+	for i, act := range acts {
+		errs[i] = handle(ctx, act)
+	}
+	return errs
+}
+
 func (s *DummySubscriber) HandleConsensusMsg(*iotextypes.ConsensusMessage) error { return nil }
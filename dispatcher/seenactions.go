@@ -0,0 +1,45 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package dispatcher
+
+import (
+	"sync"
+
+	"github.com/golang/groupcache/lru"
+
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
+// seenActions remembers the hashes of the actions recently broadcast or committed in a block in an LRU cache, so that
+// the copies of an action gossiped by many peers are dropped before the signature verification and the admission
+type seenActions struct {
+	mu    sync.Mutex
+	cache *lru.Cache
+}
+
+func newSeenActions(size uint) *seenActions {
+	return &seenActions{cache: lru.New(int(size))}
+}
+
+// add remembers the action, and returns true if it's remembered already, in which case it's a duplicate
+func (s *seenActions) add(h hash.Hash256) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.cache.Get(h); ok {
+		return true
+	}
+	s.cache.Add(h, struct{}{})
+	return false
+}
+
+// forget removes the action, e.g., which is dropped by the full queue or rejected for the time being, so that its next
+// copy is handled
+func (s *seenActions) forget(h hash.Hash256) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache.Remove(h)
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package dispatcher
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golang/groupcache/lru"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+// seenBlockKey identifies a block by its hash, so that the different blocks of the same height, i.e., the forks, are
+// told apart
+type seenBlockKey struct {
+	chainID uint32
+	hash    hash.Hash256
+}
+
+// seenBlock is the state of a broadcast block, which is pending until handled, and is remembered for a while after
+// being handled successfully
+type seenBlock struct {
+	pending bool
+	expiry  time.Time
+}
+
+// seenBlocks remembers the broadcast blocks recently handled or being handled in a small LRU cache, so that the copies
+// of a block gossiped by many peers are deserialized and validated once
+type seenBlocks struct {
+	mu    sync.Mutex
+	cache *lru.Cache
+	ttl   time.Duration
+}
+
+func newSeenBlocks(size uint, ttl time.Duration) *seenBlocks {
+	return &seenBlocks{
+		cache: lru.New(int(size)),
+		ttl:   ttl,
+	}
+}
+
+// keyOf returns the key of the block, which is false if the block header is malformed, and left to the subscriber
+// to reject
+func keyOf(chainID uint32, pb *iotextypes.Block) (seenBlockKey, bool) {
+	if pb.GetHeader() == nil {
+		return seenBlockKey{}, false
+	}
+	var header block.Header
+	if err := header.LoadFromBlockHeaderProto(pb.GetHeader()); err != nil {
+		return seenBlockKey{}, false
+	}
+	return seenBlockKey{chainID: chainID, hash: header.HashBlock()}, true
+}
+
+// add marks the block pending, and returns true if the block is pending or handled within the TTL, in which case it's
+// a duplicate
+func (s *seenBlocks) add(key seenBlockKey) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if v, ok := s.cache.Get(key); ok {
+		seen := v.(seenBlock)
+		if seen.pending || time.Now().Before(seen.expiry) {
+			return true
+		}
+	}
+	s.cache.Add(key, seenBlock{pending: true})
+	return false
+}
+
+// done marks the block handled, which is remembered until the TTL expires
+func (s *seenBlocks) done(key seenBlockKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache.Add(key, seenBlock{expiry: time.Now().Add(s.ttl)})
+}
+
+// forget removes the block, e.g., which is dropped by the full queue or fails to be handled, so that its next copy is
+// handled
+func (s *seenBlocks) forget(key seenBlockKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache.Remove(key)
+}
@@ -24,15 +24,14 @@ import (
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/blockchain/genesis"
 	"github.com/iotexproject/iotex-core/config"
-	"github.com/iotexproject/iotex-core/pkg/keypair"
 	"github.com/iotexproject/iotex-core/test/testaddress"
 	"github.com/iotexproject/iotex-core/testutil"
 )
 
-const (
-	executor       = "io1mflp9m6hcgm2qcghchsdqj3z3eccrnekx9p0ms"
-	recipient      = "io1emxf8zzqckhgjde6dqd97ts0y3q496gm3fdrl6"
-	executorPriKey = "cfa6ef757dee2e50351620dca002d32b9c090cfda55fb81f37f1d26b273743f1"
+var (
+	executorKey = testutil.NewKeyPair("executor")
+	executor    = executorKey.Address.String()
+	recipient   = testutil.NewKeyPair("recipient").Address.String()
 )
 
 func TestTransfer_Negative(t *testing.T) {
@@ -135,8 +134,7 @@ func prepareAction(bc blockchain.Blockchain, r *require.Assertions) (*block.Bloc
 	return prepare(bc, elp, r)
 }
 func prepare(bc blockchain.Blockchain, elp action.Envelope, r *require.Assertions) (*block.Block, error) {
-	priKey, err := keypair.HexStringToPrivateKey(executorPriKey)
-	selp, err := action.Sign(elp, priKey)
+	selp, err := action.Sign(elp, executorKey.PriKey)
 	r.NoError(err)
 	actionMap := make(map[string][]action.SealedEnvelope)
 	actionMap[executor] = []action.SealedEnvelope{selp}
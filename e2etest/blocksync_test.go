@@ -0,0 +1,126 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package e2etest
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	peerstore "github.com/libp2p/go-libp2p-peerstore"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/dispatcher"
+	"github.com/iotexproject/iotex-core/p2p"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/server/itx"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+// latencyOverlay delays the messages unicast by the node, as if its peers were far away
+type latencyOverlay struct {
+	p2p.Overlay
+	latency time.Duration
+}
+
+func (o *latencyOverlay) UnicastOutbound(ctx context.Context, peer peerstore.PeerInfo, msg proto.Message) error {
+	go func() {
+		time.Sleep(o.latency)
+		if err := o.Overlay.UnicastOutbound(ctx, peer, msg); err != nil {
+			log.L().Error("Failed to unicast the delayed message.", zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+func TestParallelBlockSync(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "blocksync")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	// node A produces 160 blocks
+	const tipHeight = 160
+	cfgA, err := newTestConfig()
+	require.NoError(err)
+	cfgA.Chain.ChainDBPath = filepath.Join(dir, "a.chain.db")
+	cfgA.Chain.TrieDBPath = filepath.Join(dir, "a.trie.db")
+	svrA, err := itx.NewServer(cfgA)
+	require.NoError(err)
+	require.NoError(svrA.Start(ctx))
+	defer func() {
+		require.NoError(svrA.Stop(ctx))
+	}()
+	bcA := svrA.ChainService(cfgA.Chain.ID).Blockchain()
+	for bcA.TipHeight() < tipHeight {
+		blk, err := bcA.MintNewBlock(nil, testutil.TimestampNow())
+		require.NoError(err)
+		require.NoError(bcA.ValidateBlock(blk))
+		require.NoError(bcA.CommitBlock(blk))
+	}
+	tip, err := bcA.GetBlockByHeight(tipHeight)
+	require.NoError(err)
+
+	// syncTime runs a node B requesting the blocks from node A with the latency injected, and returns how long it takes
+	// to catch up with node A
+	syncTime := func(parallelism int) time.Duration {
+		cfgB, err := newTestConfig()
+		require.NoError(err)
+		cfgB.Chain.ChainDBPath = filepath.Join(dir, fmt.Sprintf("b%d.chain.db", parallelism))
+		cfgB.Chain.TrieDBPath = filepath.Join(dir, fmt.Sprintf("b%d.trie.db", parallelism))
+		cfgB.Network.BootstrapNodes = []string{svrA.P2PAgent().Self()[0].String()}
+		cfgB.BlockSync.Interval = 20 * time.Millisecond
+		cfgB.BlockSync.IntervalSize = 10
+		cfgB.BlockSync.Parallelism = parallelism
+		dp, err := dispatcher.NewDispatcher(cfgB)
+		require.NoError(err)
+		overlay := &latencyOverlay{
+			Overlay: p2p.NewAgent(cfgB, dp.HandleBroadcast, dp.HandleTell),
+			latency: 200 * time.Millisecond,
+		}
+		svrB, err := itx.NewServerWithOptions(cfgB, itx.WithDispatcher(dp), itx.WithOverlay(overlay))
+		require.NoError(err)
+		require.NoError(svrB.Start(ctx))
+		defer func() {
+			require.NoError(svrB.Stop(ctx))
+		}()
+		bcB := svrB.ChainService(cfgB.Chain.ID).Blockchain()
+		require.NoError(testutil.WaitUntil(100*time.Millisecond, 60*time.Second, func() (bool, error) {
+			peers, err := svrB.P2PAgent().Neighbors(ctx)
+			return len(peers) >= 1, err
+		}))
+
+		// the tip broadcast by node A tells node B how far behind it is
+		start := time.Now()
+		require.NoError(svrA.P2PAgent().BroadcastOutbound(
+			p2p.WitContext(ctx, p2p.Context{ChainID: cfgA.Chain.ID}),
+			tip.ConvertToBlockPb(),
+		))
+		require.NoError(testutil.WaitUntil(10*time.Millisecond, 60*time.Second, func() (bool, error) {
+			return bcB.TipHeight() == tipHeight, nil
+		}))
+		elapsed := time.Since(start)
+		require.Equal(bcA.TipHash(), bcB.TipHash())
+		return elapsed
+	}
+
+	// the 16 chunks take a round trip each one after another, and 4 round trips 4 at a time, where the speedup is close
+	// to 4 as the latency dominates
+	sequential := syncTime(1)
+	parallel := syncTime(4)
+	t.Logf("synced %d blocks in %s one chunk at a time, and in %s 4 chunks at a time", tipHeight, sequential, parallel)
+	require.True(sequential > 16*200*time.Millisecond)
+	require.True(parallel*5 < sequential*2, "the speedup %.2f is less than 2.5", float64(sequential)/float64(parallel))
+}
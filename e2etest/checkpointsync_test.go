@@ -0,0 +1,213 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package e2etest
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/consensus/scheme/rolldpos"
+	"github.com/iotexproject/iotex-core/db"
+	"github.com/iotexproject/iotex-core/db/trie"
+	"github.com/iotexproject/iotex-core/db/trie/triepb"
+	"github.com/iotexproject/iotex-core/endorsement"
+	"github.com/iotexproject/iotex-core/p2p"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/server/itx"
+	"github.com/iotexproject/iotex-core/state/factory"
+	ta "github.com/iotexproject/iotex-core/test/testaddress"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestCheckpointSync(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "checkpointsync")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	mintUpTo := func(bc blockchain.Blockchain, height uint64) {
+		for bc.TipHeight() < height {
+			blk, err := bc.MintNewBlock(nil, testutil.TimestampNow())
+			require.NoError(err)
+			require.NoError(bc.ValidateBlock(blk))
+			require.NoError(bc.CommitBlock(blk))
+		}
+	}
+
+	// node A produces 80 blocks, the last of which 3 of the 4 delegates endorse, and exports the checkpoint snapshot
+	// while it's stopped
+	delegates := make([]*testutil.KeyPair, 4)
+	for i := range delegates {
+		delegates[i] = testutil.NewKeyPair(fmt.Sprintf("checkpoint delegate %d", i))
+	}
+	cfgA, err := newTestConfig()
+	require.NoError(err)
+	cfgA.Chain.ChainDBPath = filepath.Join(dir, "a.chain.db")
+	cfgA.Chain.TrieDBPath = filepath.Join(dir, "a.trie.db")
+	// the state of the snapshot is verified against the root of the state trie
+	cfgA.Chain.EnableTrielessStateDB = false
+	svrA, err := itx.NewServer(cfgA)
+	require.NoError(err)
+	require.NoError(svrA.Start(ctx))
+	bcA := svrA.ChainService(cfgA.Chain.ID).Blockchain()
+	require.NoError(addTestingTsfBlocks(bcA))
+	mintUpTo(bcA, 79)
+	blk, err := bcA.MintNewBlock(nil, testutil.TimestampNow())
+	require.NoError(err)
+	blkHash := blk.HashBlock()
+	var endorsements []*endorsement.Endorsement
+	for _, d := range delegates[:3] {
+		en, err := endorsement.Endorse(d.PriKey, rolldpos.NewConsensusVote(blkHash[:], rolldpos.COMMIT), blk.Timestamp())
+		require.NoError(err)
+		endorsements = append(endorsements, en)
+	}
+	require.NoError(blk.Finalize(endorsements, blk.Timestamp()))
+	require.NoError(bcA.ValidateBlock(blk))
+	require.NoError(bcA.CommitBlock(blk))
+	require.NoError(svrA.Stop(ctx))
+	var snapshot bytes.Buffer
+	checkpoint, err := blockchain.ExportCheckpoint(cfgA, &snapshot)
+	require.NoError(err)
+	require.Equal(uint64(80), checkpoint.Height)
+	require.Equal(blkHash, checkpoint.Hash)
+
+	// a copy of node A, whose state DB swaps two nodes of the state trie, exports a snapshot still claiming the
+	// state root
+	cfgC := cfgA
+	cfgC.Chain.ChainDBPath = filepath.Join(dir, "c.chain.db")
+	cfgC.Chain.TrieDBPath = filepath.Join(dir, "c.trie.db")
+	for src, dst := range map[string]string{
+		cfgA.Chain.ChainDBPath: cfgC.Chain.ChainDBPath,
+		cfgA.Chain.TrieDBPath:  cfgC.Chain.TrieDBPath,
+	} {
+		data, err := ioutil.ReadFile(src)
+		require.NoError(err)
+		require.NoError(ioutil.WriteFile(dst, data, 0600))
+	}
+	cfgC.DB.DbPath = cfgC.Chain.TrieDBPath
+	kv := db.NewOnDiskDB(cfgC.DB)
+	require.NoError(kv.Start(ctx))
+	data, err := kv.Get(factory.AccountKVNameSpace, checkpoint.StateRoot[:])
+	require.NoError(err)
+	var rootNode triepb.NodePb
+	require.NoError(proto.Unmarshal(data, &rootNode))
+	branches := rootNode.GetBranch().GetBranches()
+	require.True(len(branches) >= 2)
+	first, err := kv.Get(factory.AccountKVNameSpace, branches[0].Path)
+	require.NoError(err)
+	second, err := kv.Get(factory.AccountKVNameSpace, branches[1].Path)
+	require.NoError(err)
+	require.NoError(kv.Put(factory.AccountKVNameSpace, branches[0].Path, second))
+	require.NoError(kv.Put(factory.AccountKVNameSpace, branches[1].Path, first))
+	require.NoError(kv.Stop(ctx))
+	var tampered bytes.Buffer
+	tamperedCheckpoint, err := blockchain.ExportCheckpoint(cfgC, &tampered)
+	require.NoError(err)
+	require.Equal(checkpoint, tamperedCheckpoint)
+
+	// node A goes on to produce 100 blocks
+	cfgA.Network.Port = testutil.RandomPort()
+	cfgA.API.Port = testutil.RandomPort()
+	svrA, err = itx.NewServer(cfgA)
+	require.NoError(err)
+	require.NoError(svrA.Start(ctx))
+	defer func() {
+		require.NoError(svrA.Stop(ctx))
+	}()
+	bcA = svrA.ChainService(cfgA.Chain.ID).Blockchain()
+	mintUpTo(bcA, 100)
+
+	// node B syncs from the checkpoint, and catches up with node A
+	cfgB, err := newTestConfig()
+	require.NoError(err)
+	cfgB.Chain.ChainDBPath = filepath.Join(dir, "b.chain.db")
+	cfgB.Chain.TrieDBPath = filepath.Join(dir, "b.trie.db")
+	cfgB.Chain.EnableTrielessStateDB = false
+	cfgB.Chain.Checkpoints = map[uint64]string{80: hex.EncodeToString(checkpoint.Hash[:])}
+	cfgB.Chain.CheckpointStateRoots = map[uint64]string{80: hex.EncodeToString(checkpoint.StateRoot[:])}
+	cfgB.Network.BootstrapNodes = []string{svrA.P2PAgent().Self()[0].String()}
+	cfgB.BlockSync.Interval = time.Second
+
+	// the snapshot is refused if the block isn't endorsed by more than 2/3 of the delegates pinned
+	cfgB.Chain.CheckpointDelegates = map[uint64][]string{80: {
+		delegates[0].Address.String(),
+		delegates[1].Address.String(),
+		testutil.NewKeyPair("another delegate").Address.String(),
+		testutil.NewKeyPair("yet another delegate").Address.String(),
+	}}
+	_, err = blockchain.ImportCheckpoint(cfgB, bytes.NewReader(snapshot.Bytes()))
+	require.Equal(blockchain.ErrCheckpointMismatch, errors.Cause(err))
+	require.True(strings.Contains(err.Error(), "endorsed by 2 of the 4 delegates"))
+	cfgB.Chain.CheckpointDelegates = map[uint64][]string{80: {}}
+	for _, d := range delegates {
+		cfgB.Chain.CheckpointDelegates[80] = append(cfgB.Chain.CheckpointDelegates[80], d.Address.String())
+	}
+
+	// or if its state doesn't commit to the state root pinned, leaving the node fresh to import again
+	root := hash.Hash256b([]byte("another state root"))
+	cfgB.Chain.CheckpointStateRoots[80] = hex.EncodeToString(root[:])
+	_, err = blockchain.ImportCheckpoint(cfgB, bytes.NewReader(snapshot.Bytes()))
+	require.Equal(blockchain.ErrCheckpointMismatch, errors.Cause(err))
+	require.True(strings.Contains(err.Error(), "state root of the snapshot"))
+	cfgB.Chain.CheckpointStateRoots[80] = hex.EncodeToString(checkpoint.StateRoot[:])
+
+	// or if its state trie doesn't hash to the state root
+	_, err = blockchain.ImportCheckpoint(cfgB, &tampered)
+	require.Equal(trie.ErrInvalidTrie, errors.Cause(err))
+
+	_, err = blockchain.ImportCheckpoint(cfgB, &snapshot)
+	require.NoError(err)
+	svrB, err := itx.NewServer(cfgB)
+	require.NoError(err)
+	require.NoError(svrB.Start(ctx))
+	defer func() {
+		require.NoError(svrB.Stop(ctx))
+	}()
+	bcB := svrB.ChainService(cfgB.Chain.ID).Blockchain()
+	require.Equal(uint64(80), bcB.TipHeight())
+
+	require.NoError(testutil.WaitUntil(100*time.Millisecond, 60*time.Second, func() (bool, error) {
+		peers, err := svrA.P2PAgent().Neighbors(ctx)
+		return len(peers) >= 1, err
+	}))
+	tip, err := bcA.GetBlockByHeight(bcA.TipHeight())
+	require.NoError(err)
+	require.NoError(svrA.P2PAgent().BroadcastOutbound(
+		p2p.WitContext(ctx, p2p.Context{ChainID: cfgA.Chain.ID}),
+		tip.ConvertToBlockPb(),
+	))
+	require.NoError(testutil.WaitUntil(100*time.Millisecond, 60*time.Second, func() (bool, error) {
+		return bcB.TipHeight() == 100, nil
+	}))
+	require.Equal(bcA.TipHash(), bcB.TipHash())
+	require.Equal(bcA.GetFactory().RootHash(), bcB.GetFactory().RootHash())
+	balanceA, err := bcA.Balance(ta.Addrinfo["alfa"].String())
+	require.NoError(err)
+	balanceB, err := bcB.Balance(ta.Addrinfo["alfa"].String())
+	require.NoError(err)
+	require.Equal(balanceA, balanceB)
+
+	// the blocks below the epoch of the checkpoint aren't available on node B
+	_, err = bcB.GetBlockByHeight(49)
+	require.NoError(err)
+	_, err = bcB.GetBlockByHeight(10)
+	require.Equal(blockchain.ErrBlockNotAvailable, errors.Cause(err))
+}
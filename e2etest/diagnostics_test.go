@@ -0,0 +1,54 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// +build diagnostics
+
+package e2etest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/server/itx"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+// TestDiagnostics fails on purpose to show the diagnostics of a wait timing out, i.e., what it waits for, the node
+// state, the last value observed and the node logs. Run it by
+//
+//	go test -tags diagnostics -run TestDiagnostics ./e2etest/
+func TestDiagnostics(t *testing.T) {
+	require := require.New(t)
+	defer testutil.CaptureLogs(t)()
+
+	_, alloc := testutil.FundedAccounts(1)
+	cfg := newActPoolConfig(alloc)
+	require.NoError(config.ValidateAll(cfg, config.WarningsAsNonfatal()))
+	ctx := context.Background()
+	svr, err := itx.NewServer(cfg)
+	require.NoError(err)
+	require.NoError(svr.Start(ctx))
+	defer func() {
+		require.NoError(svr.Stop(ctx))
+	}()
+
+	chainID := cfg.Chain.ID
+	dump := nodeState(svr, chainID)
+	// no action is ever sent
+	err = testutil.WaitUntilDescribed(100*time.Millisecond, time.Second, "server to take the 1st action", dump,
+		func() (bool, error) {
+			return svr.ChainService(chainID).ActionPool().GetSize() == 1, nil
+		})
+	assert.NoError(t, err)
+	testutil.EventuallyEqual(t, 1, func() interface{} {
+		return lenPendingActionMap(svr.ChainService(chainID).ActionPool().PendingActionMap())
+	}, time.Second, "pending actions, %s", dump)
+}
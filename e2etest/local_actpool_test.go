@@ -8,44 +8,64 @@ package e2etest
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
 	"math/big"
 	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/golang/protobuf/proto"
 	peerstore "github.com/libp2p/go-libp2p-peerstore"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/action/protocol"
+	"github.com/iotexproject/iotex-core/actpool"
+	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/p2p"
-	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
 	"github.com/iotexproject/iotex-core/server/itx"
-	"github.com/iotexproject/iotex-core/test/identityset"
 	"github.com/iotexproject/iotex-core/testutil"
 )
 
 func TestLocalActPool(t *testing.T) {
 	require := require.New(t)
+	defer testutil.CaptureLogs(t)()
 
-	cfg, err := newActPoolConfig()
-	require.NoError(err)
+	accounts, alloc := testutil.FundedAccounts(2)
+	cfg := newActPoolConfig(alloc)
+	require.NoError(config.ValidateAll(cfg, config.WarningsAsNonfatal()))
 
-	// create server
+	// create server, which takes the actions into an instrumented pool recording the admissions
 	ctx := context.Background()
-	svr, err := itx.NewServer(cfg)
+	registry := &protocol.Registry{}
+	bc := blockchain.NewBlockchain(
+		cfg,
+		blockchain.DefaultStateFactoryOption(),
+		blockchain.BoltDBDaoOption(),
+		blockchain.RegistryOption(registry),
+		blockchain.EnableExperimentalActions(),
+	)
+	require.NotNil(bc)
+	ap, err := actpool.NewActPool(bc, cfg.ActPool, actpool.EnableExperimentalActions())
+	require.NoError(err)
+	rec := newRecordingActPool(ap)
+	svr, err := itx.NewServerWithOptions(cfg, itx.WithBlockchain(bc, registry), itx.WithActPool(rec))
 	require.Nil(err)
 
 	chainID := cfg.Chain.ID
 	require.NoError(svr.Start(ctx))
 
-	require.NotNil(svr.ChainService(chainID).ActionPool())
+	require.Equal(rec, svr.ChainService(chainID).ActionPool())
 
 	// create client
-	cfg, err = newActPoolConfig()
-	require.NoError(err)
+	cfg = newActPoolConfig(alloc)
 	cfg.Network.BootstrapNodes = []string{svr.P2PAgent().Self()[0].String()}
 	cli := p2p.NewAgent(
 		cfg,
@@ -65,46 +85,407 @@ func TestLocalActPool(t *testing.T) {
 	}()
 
 	// Create three valid actions from "from" to "to"
-	tsf1, err := testutil.SignedTransfer(identityset.Address(0).String(), identityset.PrivateKey(1), 1, big.NewInt(1), []byte{}, uint64(100000), big.NewInt(0))
+	tsf1, err := testutil.SignedTransfer(accounts[0].Address.String(), accounts[1].PriKey, 1, big.NewInt(1), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
 	p2pCtx := p2p.WitContext(ctx, p2p.Context{ChainID: chainID})
+	dump := nodeState(svr, chainID)
 	// Wait until server receives the 1st action
-	require.NoError(testutil.WaitUntil(100*time.Millisecond, 60*time.Second, func() (bool, error) {
-		require.NoError(cli.BroadcastOutbound(p2pCtx, tsf1.Proto()))
-		acts := svr.ChainService(chainID).ActionPool().PendingActionMap()
-		return lenPendingActionMap(acts) == 1, nil
-	}))
+	require.NoError(testutil.WaitUntilDescribed(100*time.Millisecond, 60*time.Second, "server to take the 1st action",
+		dump, func() (bool, error) {
+			require.NoError(cli.BroadcastOutbound(p2pCtx, tsf1.Proto()))
+			acts := svr.ChainService(chainID).ActionPool().PendingActionMap()
+			return lenPendingActionMap(acts) == 1, nil
+		}))
 
-	vote2, err := testutil.SignedVote(identityset.Address(1).String(), identityset.PrivateKey(1), 2, uint64(100000), big.NewInt(0))
+	vote2, err := testutil.SignedVote(accounts[1].Address.String(), accounts[1].PriKey, 2, uint64(100000), big.NewInt(0))
 	require.NoError(err)
-	tsf3, err := testutil.SignedTransfer(identityset.Address(0).String(), identityset.PrivateKey(1), 3, big.NewInt(3), []byte{}, uint64(100000), big.NewInt(0))
+	tsf3, err := testutil.SignedTransfer(accounts[0].Address.String(), accounts[1].PriKey, 3, big.NewInt(3), []byte{}, uint64(100000), big.NewInt(0))
 	require.NoError(err)
 	// Create contract
-	exec4, err := testutil.SignedExecution(action.EmptyAddress, identityset.PrivateKey(1), 4, big.NewInt(0), uint64(120000), big.NewInt(10), []byte{})
+	exec4, err := testutil.SignedExecution(action.EmptyAddress, accounts[1].PriKey, 4, big.NewInt(0), uint64(120000), big.NewInt(10), []byte{})
 	require.NoError(err)
 	// Create three invalid actions from "from" to "to"
 	// Existed Vote
-	vote5, err := testutil.SignedVote(identityset.Address(0).String(), identityset.PrivateKey(1), 2, uint64(100000), big.NewInt(0))
+	vote5, err := testutil.SignedVote(accounts[0].Address.String(), accounts[1].PriKey, 2, uint64(100000), big.NewInt(0))
 	require.NoError(err)
 
+	// the actions accepted into the pool are relayed to the subscription as soon as they're taken
+	accepted := make(chan action.SealedEnvelope, 16)
+	sub := actpool.NewActionChan(accepted)
+	require.NoError(rec.AddSubscriber(sub))
+	defer func() {
+		require.NoError(rec.RemoveSubscriber(sub))
+	}()
 	require.NoError(cli.BroadcastOutbound(p2pCtx, vote2.Proto()))
 	require.NoError(cli.BroadcastOutbound(p2pCtx, tsf3.Proto()))
 	require.NoError(cli.BroadcastOutbound(p2pCtx, exec4.Proto()))
 	require.NoError(cli.BroadcastOutbound(p2pCtx, vote5.Proto()))
 
-	// Wait until server receives all the transfers
-	require.NoError(testutil.WaitUntil(100*time.Millisecond, 60*time.Second, func() (bool, error) {
-		acts := svr.ChainService(chainID).ActionPool().PendingActionMap()
-		// 2 valid transfers and 1 valid vote and 1 valid execution
-		return lenPendingActionMap(acts) == 4, nil
-	}))
+	// Wait until server takes all the valid actions, i.e., 1 more valid transfer, 1 valid vote and 1 valid execution
+	waiting := map[hash.Hash256]bool{vote2.Hash(): true, tsf3.Hash(): true, exec4.Hash(): true}
+	timeout := time.After(60 * time.Second)
+	for len(waiting) > 0 {
+		select {
+		case act := <-accepted:
+			delete(waiting, act.Hash())
+		case <-timeout:
+			require.FailNow("server doesn't take the actions", "%d actions left, %s", len(waiting), dump())
+		}
+	}
+	require.Equal(4, lenPendingActionMap(svr.ChainService(chainID).ActionPool().PendingActionMap()))
+	// the actions of nonce 1 to 4 are pending
+	pendingNonce, err := svr.ChainService(chainID).ActionPool().GetPendingNonce(accounts[1].Address.String())
+	require.NoError(err)
+	require.Equal(uint64(5), pendingNonce)
+	// every action broadcast went through the pool given, which rejected the vote for a votee not a candidate
+	for _, act := range []action.SealedEnvelope{tsf1, vote2, tsf3, exec4, vote5} {
+		require.True(rec.calls(act.Hash()) > 0)
+	}
+	require.NoError(rec.addError(vote2.Hash()))
+	require.Equal(action.ErrVotee, errors.Cause(rec.addError(vote5.Hash())))
+
+	// the action already in pool is rejected as a duplicate
+	require.Equal(actpool.ErrDuplicate, errors.Cause(rec.Add(tsf1)))
+
+	// the vote of a tampered signature is rejected for the signature
+	tampered := proto.Clone(vote5.Proto()).(*iotextypes.Action)
+	tampered.Core.Nonce = 5
+	var vote6 action.SealedEnvelope
+	require.NoError(vote6.LoadProto(tampered))
+	require.NoError(testutil.WaitUntilDescribed(100*time.Millisecond, 60*time.Second, "server to reject the vote",
+		dump, func() (bool, error) {
+			require.NoError(cli.BroadcastOutbound(p2pCtx, tampered))
+			return rec.calls(vote6.Hash()) > 0, nil
+		}))
+	require.Equal(action.ErrSignature, errors.Cause(rec.addError(vote6.Hash())))
 }
 
+func TestReplaceActionCommitted(t *testing.T) {
+	require := require.New(t)
+	defer testutil.CaptureLogs(t)()
+
+	accounts, alloc := testutil.FundedAccounts(2)
+	cfg := newActPoolConfig(alloc)
+	ctx := context.Background()
+	svr, err := itx.NewServer(cfg)
+	require.NoError(err)
+	require.NoError(svr.Start(ctx))
+	chainID := cfg.Chain.ID
+	ap := svr.ChainService(chainID).ActionPool()
+	bc := svr.ChainService(chainID).Blockchain()
+
+	cfg = newActPoolConfig(alloc)
+	cfg.Network.BootstrapNodes = []string{svr.P2PAgent().Self()[0].String()}
+	cli := p2p.NewAgent(
+		cfg,
+		func(_ context.Context, _ uint32, _ proto.Message) {},
+		func(_ context.Context, _ uint32, _ peerstore.PeerInfo, _ proto.Message) {},
+	)
+	require.NoError(cli.Start(ctx))
+	defer func() {
+		require.NoError(cli.Stop(ctx))
+		require.NoError(svr.Stop(ctx))
+	}()
+
+	// the transfer of the wrong amount is replaced by the one of the same nonce and a higher gas price
+	original, err := testutil.SignedTransfer(accounts[0].Address.String(), accounts[1].PriKey, 1, big.NewInt(100),
+		[]byte{}, uint64(100000), big.NewInt(1))
+	require.NoError(err)
+	replacement, err := testutil.SignedTransfer(accounts[0].Address.String(), accounts[1].PriKey, 1, big.NewInt(10),
+		[]byte{}, uint64(100000), big.NewInt(2))
+	require.NoError(err)
+	p2pCtx := p2p.WitContext(ctx, p2p.Context{ChainID: chainID})
+	dump := nodeState(svr, chainID)
+	for _, act := range []action.SealedEnvelope{original, replacement} {
+		act := act
+		require.NoError(testutil.WaitUntilDescribed(100*time.Millisecond, 60*time.Second, "server to take the action",
+			dump, func() (bool, error) {
+				require.NoError(cli.BroadcastOutbound(p2pCtx, act.Proto()))
+				_, err := ap.GetActionByHash(act.Hash())
+				return err == nil, nil
+			}))
+	}
+	_, err = ap.GetActionByHash(original.Hash())
+	require.Error(err)
+
+	before, err := bc.Balance(accounts[0].Address.String())
+	require.NoError(err)
+	blk, err := bc.MintNewBlock(ap.PendingActionMap(), testutil.TimestampNow())
+	require.NoError(err)
+	require.NoError(bc.CommitBlock(blk))
+	var hashes []hash.Hash256
+	for _, selp := range blk.Actions {
+		hashes = append(hashes, selp.Hash())
+	}
+	require.Contains(hashes, replacement.Hash())
+	require.NotContains(hashes, original.Hash())
+	// the recipient gets the amount of the replacement
+	after, err := bc.Balance(accounts[0].Address.String())
+	require.NoError(err)
+	require.Equal(big.NewInt(10), new(big.Int).Sub(after, before))
+}
+
+func TestGappedNoncesPromoted(t *testing.T) {
+	require := require.New(t)
+	defer testutil.CaptureLogs(t)()
+
+	accounts, alloc := testutil.FundedAccounts(2)
+	cfg := newActPoolConfig(alloc)
+	ctx := context.Background()
+	svr, err := itx.NewServer(cfg)
+	require.NoError(err)
+	require.NoError(svr.Start(ctx))
+	chainID := cfg.Chain.ID
+	ap := svr.ChainService(chainID).ActionPool()
+	bc := svr.ChainService(chainID).Blockchain()
+
+	cfg = newActPoolConfig(alloc)
+	cfg.Network.BootstrapNodes = []string{svr.P2PAgent().Self()[0].String()}
+	cli := p2p.NewAgent(
+		cfg,
+		func(_ context.Context, _ uint32, _ proto.Message) {},
+		func(_ context.Context, _ uint32, _ peerstore.PeerInfo, _ proto.Message) {},
+	)
+	require.NoError(cli.Start(ctx))
+	defer func() {
+		require.NoError(cli.Stop(ctx))
+		require.NoError(svr.Stop(ctx))
+	}()
+
+	// the transfers arrive out of order, and each one filling a gap makes the ones after it pending at once
+	sender := accounts[1].Address.String()
+	p2pCtx := p2p.WitContext(ctx, p2p.Context{ChainID: chainID})
+	dump := nodeState(svr, chainID)
+	sent := make(map[hash.Hash256]bool)
+	for _, c := range []struct {
+		nonce        uint64
+		pendingNonce uint64
+	}{{4, 1}, {3, 1}, {1, 2}, {2, 5}} {
+		tsf, err := testutil.SignedTransfer(accounts[0].Address.String(), accounts[1].PriKey, c.nonce, big.NewInt(10),
+			[]byte{}, uint64(100000), big.NewInt(1))
+		require.NoError(err)
+		sent[tsf.Hash()] = true
+		require.NoError(testutil.WaitUntilDescribed(100*time.Millisecond, 60*time.Second, "server to take the action",
+			dump, func() (bool, error) {
+				require.NoError(cli.BroadcastOutbound(p2pCtx, tsf.Proto()))
+				_, err := ap.GetActionByHash(tsf.Hash())
+				return err == nil, nil
+			}))
+		pendingNonce, err := ap.GetPendingNonce(sender)
+		require.NoError(err)
+		require.Equal(c.pendingNonce, pendingNonce)
+	}
+
+	blk, err := bc.MintNewBlock(ap.PendingActionMap(), testutil.TimestampNow())
+	require.NoError(err)
+	require.NoError(bc.CommitBlock(blk))
+	for _, selp := range blk.Actions {
+		delete(sent, selp.Hash())
+	}
+	require.Empty(sent)
+	nonce, err := bc.Nonce(sender)
+	require.NoError(err)
+	require.Equal(uint64(4), nonce)
+}
+
+func TestActPoolJournal(t *testing.T) {
+	require := require.New(t)
+	defer testutil.CaptureLogs(t)()
+
+	dir, err := ioutil.TempDir("", "journal")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	accounts, alloc := testutil.FundedAccounts(2)
+	cfg := newActPoolConfig(alloc)
+	cfg.ActPool.Journal = filepath.Join(dir, "actpool.journal")
+	ctx := context.Background()
+	svr, err := itx.NewServer(cfg)
+	require.NoError(err)
+	require.NoError(svr.Start(ctx))
+	chainID := cfg.Chain.ID
+
+	tsf, err := testutil.SignedTransfer(accounts[0].Address.String(), accounts[1].PriKey, 1, big.NewInt(10),
+		[]byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	require.NoError(svr.ChainService(chainID).ActionPool().Add(tsf))
+	require.NoError(svr.Stop(ctx))
+
+	// the transfer pending when the node stops is taken again on restart, and is mined by the standalone producer
+	cfg.Consensus.Scheme = config.StandaloneScheme
+	cfg.Genesis.BlockInterval = time.Second
+	cfg.Network.Port = testutil.RandomPort()
+	svr, err = itx.NewServer(cfg)
+	require.NoError(err)
+	require.NoError(svr.Start(ctx))
+	defer func() {
+		require.NoError(svr.Stop(ctx))
+	}()
+	bc := svr.ChainService(chainID).Blockchain()
+	require.NoError(testutil.WaitUntilDescribed(100*time.Millisecond, 30*time.Second, "transfer to be mined",
+		nodeState(svr, chainID), func() (bool, error) {
+			nonce, err := bc.Nonce(accounts[1].Address.String())
+			return nonce == 1, err
+		}))
+}
+
+func TestPressureActPoolConflictingTransfers(t *testing.T) {
+	require := require.New(t)
+	defer testutil.CaptureLogs(t)()
+
+	accounts, alloc := testutil.FundedAccounts(2)
+	cfg := newActPoolConfig(alloc)
+	ctx := context.Background()
+	svr, err := itx.NewServer(cfg)
+	require.NoError(err)
+	require.NoError(svr.Start(ctx))
+	defer func() {
+		require.NoError(svr.Stop(ctx))
+	}()
+	chainID := cfg.Chain.ID
+	ap := svr.ChainService(chainID).ActionPool()
+	bc := svr.ChainService(chainID).Blockchain()
+
+	// the pool takes two transfers spending half of the balance each
+	half := new(big.Int).Div(testutil.FundedBalance, big.NewInt(2))
+	sender := accounts[1].Address.String()
+	var pooled []action.SealedEnvelope
+	for nonce := uint64(1); nonce <= 2; nonce++ {
+		tsf, err := testutil.SignedTransfer(accounts[0].Address.String(), accounts[1].PriKey, nonce, half, []byte{},
+			uint64(100000), big.NewInt(0))
+		require.NoError(err)
+		require.NoError(ap.Add(tsf))
+		pooled = append(pooled, tsf)
+	}
+	pendingNonce, err := ap.GetPendingNonce(sender)
+	require.NoError(err)
+	require.Equal(uint64(3), pendingNonce)
+
+	// a conflicting transfer of nonce 1 spending the whole balance but the last Rau is committed instead, e.g., in a
+	// block of another producer
+	spent := new(big.Int).Sub(testutil.FundedBalance, big.NewInt(1))
+	conflicting, err := testutil.SignedTransfer(accounts[0].Address.String(), accounts[1].PriKey, 1, spent, []byte{},
+		uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	blk, err := bc.MintNewBlock(map[string][]action.SealedEnvelope{sender: {conflicting}}, testutil.TimestampNow())
+	require.NoError(err)
+	require.NoError(bc.CommitBlock(blk))
+	ap.Reset()
+
+	// only the committed transfer survives, as the nonce of the one pooled is used, and the other is no longer payable
+	for _, tsf := range pooled {
+		_, err := ap.GetActionByHash(tsf.Hash())
+		require.Error(err)
+	}
+	require.Zero(lenPendingActionMap(ap.PendingActionMap()))
+	evictions := ap.RecentEvictions()
+	require.Len(evictions, 1)
+	require.Equal(pooled[1].Hash(), evictions[0].Hash)
+	require.Equal(actpool.EvictedUnpayable, evictions[0].Reason)
+	pendingNonce, err = ap.GetPendingNonce(sender)
+	require.NoError(err)
+	require.Equal(uint64(2), pendingNonce)
+}
+
+// numPressureActs is the number of the actions broadcast by the pressure tests, which is below the broadcast rate
+// limit of 300
+const numPressureActs = 250
+
 func TestPressureActPool(t *testing.T) {
+	// The pool holds all the actions of the sender within the default capacity
+	testPressureActPool(t, func(*config.Config) {})
+}
+
+func TestPressureActPoolWithTinyCap(t *testing.T) {
+	// The pool holds no more than 10 actions regardless of the ones broadcasted
+	testPressureActPool(t, func(cfg *config.Config) {
+		cfg.ActPool.MaxNumActsPerPool = 10
+		cfg.ActPool.MaxNumActsPerAcct = 10
+	})
+}
+
+func TestPressureActPoolWithAccountCap(t *testing.T) {
+	// The account queue holds no more than 100 actions, while the pool could hold more
+	testPressureActPool(t, func(cfg *config.Config) {
+		cfg.ActPool.MaxNumActsPerAcct = 100
+	})
+}
+
+func TestPressureActPoolEviction(t *testing.T) {
 	require := require.New(t)
+	defer testutil.CaptureLogs(t)()
 
-	cfg, err := newActPoolConfig()
+	// 10 spam accounts fill the pool, and then 10 accounts offering a higher gas price take it over
+	const numAccounts, numActsPerAccount = 20, 100
+	accounts, alloc := testutil.FundedAccounts(numAccounts)
+	cfg := newActPoolConfig(alloc)
+	cfg.ActPool.MaxNumActsPerPool = numAccounts / 2 * numActsPerAccount
+	cfg.ActPool.MaxNumActsPerAcct = numActsPerAccount
+	require.NoError(config.ValidateAll(cfg, config.WarningsAsNonfatal()))
+	ctx := context.Background()
+	svr, err := itx.NewServer(cfg)
 	require.NoError(err)
+	require.NoError(svr.Start(ctx))
+	defer func() {
+		require.NoError(svr.Stop(ctx))
+	}()
+	ap := svr.ChainService(cfg.Chain.ID).ActionPool()
+
+	// the actions are added directly, as 2000 of them are beyond the broadcast rate limit
+	var spam, legit []action.SealedEnvelope
+	for i, account := range accounts {
+		for nonce := uint64(1); nonce <= numActsPerAccount; nonce++ {
+			gasPrice := big.NewInt(0)
+			if i >= numAccounts/2 {
+				gasPrice = big.NewInt(1)
+			}
+			tsf, err := testutil.SignedTransfer(accounts[0].Address.String(), account.PriKey, nonce, big.NewInt(1),
+				[]byte{}, uint64(100000), gasPrice)
+			require.NoError(err)
+			require.NoError(ap.Add(tsf))
+			require.True(ap.GetSize() <= ap.GetCapacity())
+			if i < numAccounts/2 {
+				spam = append(spam, tsf)
+			} else {
+				legit = append(legit, tsf)
+			}
+		}
+	}
+
+	// the pool is full of the actions of the higher gas price, all pending, while the spam is evicted
+	require.Equal(cfg.ActPool.MaxNumActsPerPool, ap.GetCapacity())
+	require.Equal(ap.GetCapacity(), ap.GetSize())
+	require.Equal(len(legit), lenPendingActionMap(ap.PendingActionMap()))
+	for _, tsf := range legit {
+		_, err := ap.GetActionByHash(tsf.Hash())
+		require.NoError(err)
+	}
+	evicted := make(map[hash.Hash256]bool)
+	for _, e := range ap.RecentEvictions() {
+		require.Equal(actpool.EvictedOutranked, e.Reason)
+		evicted[e.Hash] = true
+	}
+	require.Len(evicted, len(spam))
+	for _, tsf := range spam {
+		require.True(evicted[tsf.Hash()])
+	}
+}
+
+// testPressureActPool broadcasts numPressureActs actions of a sender, and checks the pool takes them up to the
+// capacity of the pool and of the account queue configured
+func testPressureActPool(t *testing.T, setActPool func(*config.Config)) {
+	require := require.New(t)
+	defer testutil.CaptureLogs(t)()
+
+	accounts, alloc := testutil.FundedAccounts(2)
+	cfg := newActPoolConfig(alloc)
+	setActPool(&cfg)
+	require.NoError(config.ValidateAll(cfg, config.WarningsAsNonfatal()))
+	numPending := numPressureActs
+	for _, limit := range []uint64{cfg.ActPool.MaxNumActsPerPool, cfg.ActPool.MaxNumActsPerAcct} {
+		if limit < uint64(numPending) {
+			numPending = int(limit)
+		}
+	}
 
 	// create server
 	ctx := context.Background()
@@ -115,11 +496,10 @@ func TestPressureActPool(t *testing.T) {
 	require.NotNil(svr.ChainService(chainID).ActionPool())
 
 	// create client
-	cfg, err = newActPoolConfig()
-	require.NoError(err)
-	cfg.Network.BootstrapNodes = []string{svr.P2PAgent().Self()[0].String()}
+	cliCfg := newActPoolConfig(alloc)
+	cliCfg.Network.BootstrapNodes = []string{svr.P2PAgent().Self()[0].String()}
 	cli := p2p.NewAgent(
-		cfg,
+		cliCfg,
 		func(_ context.Context, _ uint32, _ proto.Message) {
 
 		},
@@ -136,31 +516,102 @@ func TestPressureActPool(t *testing.T) {
 	}()
 
 	p2pCtx := p2p.WitContext(ctx, p2p.Context{ChainID: chainID})
-	tsf, err := testutil.SignedTransfer(identityset.Address(0).String(), identityset.PrivateKey(1), 1, big.NewInt(int64(0)), []byte{}, uint64(100000), big.NewInt(0))
+	nonces := action.NewNonceManager(svr.ChainService(chainID).ActionPool())
+	sender := accounts[1].Address.String()
+	nonce, err := nonces.Next(sender)
 	require.NoError(err)
+	tsf, err := testutil.SignedTransfer(accounts[0].Address.String(), accounts[1].PriKey, nonce, big.NewInt(int64(0)), []byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	dump := nodeState(svr, chainID)
 	// Wait until server receives the 1st action
-	require.NoError(testutil.WaitUntil(100*time.Millisecond, 60*time.Second, func() (bool, error) {
-		require.NoError(cli.BroadcastOutbound(p2pCtx, tsf.Proto()))
-		acts := svr.ChainService(chainID).ActionPool().PendingActionMap()
-		return lenPendingActionMap(acts) == 1, nil
-	}))
+	require.NoError(testutil.WaitUntilDescribed(100*time.Millisecond, 60*time.Second, "server to take the 1st action",
+		dump, func() (bool, error) {
+			require.NoError(cli.BroadcastOutbound(p2pCtx, tsf.Proto()))
+			acts := svr.ChainService(chainID).ActionPool().PendingActionMap()
+			return lenPendingActionMap(acts) == 1, nil
+		}))
 
-	// Broadcast has rate limit at 300
-	for i := 2; i <= 250; i++ {
-		tsf, err := testutil.SignedTransfer(identityset.Address(0).String(), identityset.PrivateKey(1), uint64(i), big.NewInt(int64(i)), []byte{}, uint64(100000), big.NewInt(0))
+	for i := 2; i <= numPressureActs; i++ {
+		nonce, err := nonces.Next(sender)
+		require.NoError(err)
+		tsf, err := testutil.SignedTransfer(accounts[0].Address.String(), accounts[1].PriKey, nonce, big.NewInt(int64(i)), []byte{}, uint64(100000), big.NewInt(0))
 		require.NoError(err)
 		require.NoError(cli.BroadcastOutbound(p2pCtx, tsf.Proto()))
 	}
 
-	// Wait until committed blocks contain all broadcasted actions
-	err = testutil.WaitUntil(100*time.Millisecond, 60*time.Second, func() (bool, error) {
-		acts := svr.ChainService(chainID).ActionPool().PendingActionMap()
-		return lenPendingActionMap(acts) == 250, nil
-	})
-	require.Nil(err)
+	// Wait until the pool takes the broadcasted actions up to its capacity
+	ap := svr.ChainService(chainID).ActionPool()
+	testutil.EventuallyEqual(t, numPending, func() interface{} {
+		return lenPendingActionMap(ap.PendingActionMap())
+	}, 60*time.Second, "pending actions, %s", dump)
+	require.Equal(cfg.ActPool.MaxNumActsPerPool, ap.GetCapacity())
+	require.True(ap.GetSize() <= cfg.ActPool.MaxNumActsPerPool)
+}
+
+// recordingActPool records the actions added to the pool, and whether they are taken
+type recordingActPool struct {
+	actpool.ActPool
+	mu       sync.Mutex
+	numCalls map[hash.Hash256]int
+	// firstErr is the error of adding each action for the first time, as the copies of an action taken are rejected
+	firstErr map[hash.Hash256]error
 }
 
-func newActPoolConfig() (config.Config, error) {
+func newRecordingActPool(ap actpool.ActPool) *recordingActPool {
+	return &recordingActPool{
+		ActPool:  ap,
+		numCalls: make(map[hash.Hash256]int),
+		firstErr: make(map[hash.Hash256]error),
+	}
+}
+
+func (ap *recordingActPool) Add(act action.SealedEnvelope) error {
+	err := ap.ActPool.Add(act)
+	ap.mu.Lock()
+	defer ap.mu.Unlock()
+	h := act.Hash()
+	if ap.numCalls[h] == 0 {
+		ap.firstErr[h] = err
+	}
+	ap.numCalls[h]++
+	return err
+}
+
+func (ap *recordingActPool) calls(h hash.Hash256) int {
+	ap.mu.Lock()
+	defer ap.mu.Unlock()
+	return ap.numCalls[h]
+}
+
+// addError returns the error of adding the action for the first time
+func (ap *recordingActPool) addError(h hash.Hash256) error {
+	ap.mu.Lock()
+	defer ap.mu.Unlock()
+	return ap.firstErr[h]
+}
+
+// nodeState dumps the state of the node relevant to the action pool, i.e., the pool size, the tip height and the peer
+// count, to tell why a wait times out
+func nodeState(svr *itx.Server, chainID uint32) testutil.StateDump {
+	return func() string {
+		cs := svr.ChainService(chainID)
+		ap := cs.ActionPool()
+		peers, err := svr.P2PAgent().Neighbors(context.Background())
+		if err != nil {
+			return fmt.Sprintf("failed to get the peers: %v", err)
+		}
+		return fmt.Sprintf(
+			"actpool size %d/%d with %d pending, tip height %d, %d peers",
+			ap.GetSize(),
+			ap.GetCapacity(),
+			lenPendingActionMap(ap.PendingActionMap()),
+			cs.Blockchain().TipHeight(),
+			len(peers),
+		)
+	}
+}
+
+func newActPoolConfig(alloc map[string]string) config.Config {
 	cfg := config.Default
 
 	testTrieFile, _ := ioutil.TempFile(os.TempDir(), "trie")
@@ -176,10 +627,7 @@ func newActPoolConfig() (config.Config, error) {
 	cfg.Network.Port = testutil.RandomPort()
 	cfg.System.EnableExperimentalActions = true
 
-	sk, err := keypair.GenerateKey()
-	if err != nil {
-		return config.Config{}, err
-	}
-	cfg.Chain.ProducerPrivKey = sk.HexString()
-	return cfg, nil
+	cfg.Chain.ProducerPrivKey = config.Secret(testutil.NewKeyPair("producer").PriKey.HexString())
+	cfg.Genesis.InitBalanceMap = testutil.MergeAllocation(cfg.Genesis.InitBalanceMap, alloc)
+	return cfg
 }
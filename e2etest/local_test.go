@@ -495,6 +495,7 @@ func TestStartExistingBlockchain(t *testing.T) {
 	cfg.Chain.EnableAsyncIndexWrite = false
 	cfg.Consensus.Scheme = config.NOOPScheme
 	cfg.Network.Port = testutil.RandomPort()
+	require.NoError(config.ValidateAll(cfg, config.WarningsAsNonfatal()))
 
 	svr, err := itx.NewServer(cfg)
 	require.Nil(err)
@@ -574,6 +575,6 @@ func newTestConfig() (config.Config, error) {
 	if err != nil {
 		return config.Config{}, err
 	}
-	cfg.Chain.ProducerPrivKey = sk.HexString()
-	return cfg, nil
+	cfg.Chain.ProducerPrivKey = config.Secret(sk.HexString())
+	return cfg, config.ValidateAll(cfg, config.WarningsAsNonfatal())
 }
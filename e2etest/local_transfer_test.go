@@ -305,16 +305,27 @@ func TestLocalTransfer(t *testing.T) {
 
 		tsf, err := testutil.SignedTransfer(recvAddr, senderPriKey, tsfTest.nonce, tsfTest.amount,
 			tsfTest.payload, tsfTest.gasLimit, tsfTest.gasPrice)
+		if tsfTest.recvAcntState == AcntBadAddr {
+			// transfer to a bad address cannot even be constructed
+			require.Error(err, tsfTest.message)
+			continue
+		}
 		require.NoError(err, tsfTest.message)
 
 		// wait 2 block time, retry 5 times
 		retryInterval := cfg.Genesis.BlockInterval * 2 / 5
 		bo := backoff.WithMaxRetries(backoff.NewConstantBackOff(retryInterval), 5)
-		err = backoff.Retry(func() error {
+		if tsfTest.expectedResult == TsfFail {
+			// the transfer rejected by the actpool fails the request with the reason
 			_, err := client.SendAction(context.Background(), &iotexapi.SendActionRequest{Action: tsf.Proto()})
-			return err
-		}, bo)
-		require.NoError(err, tsfTest.message)
+			require.Error(err, tsfTest.message)
+		} else {
+			err = backoff.Retry(func() error {
+				_, err := client.SendAction(context.Background(), &iotexapi.SendActionRequest{Action: tsf.Proto()})
+				return err
+			}, bo)
+			require.NoError(err, tsfTest.message)
+		}
 
 		switch tsfTest.expectedResult {
 		case TsfSuccess:
@@ -513,7 +524,7 @@ func newTransferConfig(
 	cfg.API.Port = apiPort
 	cfg.Genesis.BlockInterval = 2 * time.Second
 
-	return cfg, nil
+	return cfg, config.ValidateAll(cfg, config.WarningsAsNonfatal())
 }
 
 func lenPendingActionMap(acts map[string][]action.SealedEnvelope) int {
@@ -0,0 +1,164 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package e2etest
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	peerstore "github.com/libp2p/go-libp2p-peerstore"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/p2p"
+	"github.com/iotexproject/iotex-core/pkg/metrics"
+	"github.com/iotexproject/iotex-core/server/itx"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+// sample is a metric of the given labels, whose value is the sum of the matching series
+type sample struct {
+	name   string
+	labels map[string]string
+}
+
+func TestMetrics(t *testing.T) {
+	require := require.New(t)
+
+	// the subsystems constructed from now on register their metrics to a registry of the test
+	defer metrics.SetRegistry(metrics.NewRegistry())()
+	scraper := httptest.NewServer(metrics.Handler())
+	defer scraper.Close()
+
+	accounts, alloc := testutil.FundedAccounts(2)
+	newConfig := func() config.Config {
+		cfg := newActPoolConfig(alloc)
+		cfg.Consensus.Scheme = config.StandaloneScheme
+		cfg.Genesis.BlockInterval = time.Second
+		return cfg
+	}
+	cfg := newConfig()
+	require.NoError(config.ValidateAll(cfg, config.WarningsAsNonfatal()))
+
+	ctx := context.Background()
+	svr, err := itx.NewServer(cfg)
+	require.NoError(err)
+	require.NoError(svr.Start(ctx))
+	chainID := cfg.Chain.ID
+	bc := svr.ChainService(chainID).Blockchain()
+
+	// the client is of the same genesis, which the P2P topics are derived from
+	cfg = newConfig()
+	cfg.Network.BootstrapNodes = []string{svr.P2PAgent().Self()[0].String()}
+	cli := p2p.NewAgent(
+		cfg,
+		func(_ context.Context, _ uint32, _ proto.Message) {},
+		func(_ context.Context, _ uint32, _ peerstore.PeerInfo, _ proto.Message) {},
+	)
+	require.NoError(cli.Start(ctx))
+	defer func() {
+		require.NoError(cli.Stop(ctx))
+		require.NoError(svr.Stop(ctx))
+	}()
+
+	accepted := sample{"iotex_actpool_actions", map[string]string{"result": "accepted"}}
+	samples := []sample{
+		accepted,
+		{"iotex_p2p_message_counter", map[string]string{"direction": "in"}},
+		{"iotex_dispatch_request", map[string]string{"method": "AddAction"}},
+		{"iotex_block_metrics", map[string]string{"type": "tipHeight"}},
+		{"iotex_consensus_blocks", map[string]string{"step": "commit", "succeed": "true"}},
+	}
+	p2pCtx := p2p.WitContext(ctx, p2p.Context{ChainID: chainID})
+	// sends the transfer of the nonce until it's committed, and scrapes the metrics then
+	transferAndScrape := func(nonce uint64) map[string]*dto.MetricFamily {
+		tsf, err := testutil.SignedTransfer(accounts[1].Address.String(), accounts[0].PriKey, nonce, big.NewInt(1),
+			[]byte{}, uint64(100000), big.NewInt(0))
+		require.NoError(err)
+		require.NoError(testutil.WaitUntil(100*time.Millisecond, 60*time.Second, func() (bool, error) {
+			nonceCommitted, err := bc.Nonce(accounts[0].Address.String())
+			if err != nil || nonceCommitted >= nonce {
+				return err == nil, err
+			}
+			return false, cli.BroadcastOutbound(p2pCtx, tsf.Proto())
+		}))
+		// the block is committed before the consensus counts it
+		var families map[string]*dto.MetricFamily
+		require.NoError(testutil.WaitUntil(100*time.Millisecond, 10*time.Second, func() (bool, error) {
+			families = scrape(t, scraper.URL)
+			_, ok := sum(families, samples[len(samples)-1])
+			return ok, nil
+		}))
+		return families
+	}
+
+	first := transferAndScrape(1)
+	for _, name := range []string{"go_goroutines", "go_memstats_heap_alloc_bytes", "go_gc_duration_seconds",
+		"iotex_build_info"} {
+		require.Contains(first, name)
+	}
+	second := transferAndScrape(2)
+	for _, s := range samples {
+		v1, ok := sum(first, s)
+		require.True(ok, s.name)
+		v2, ok := sum(second, s)
+		require.True(ok, s.name)
+		require.True(v2 >= v1, "%s decreased from %f to %f", s.name, v1, v2)
+	}
+	v1, _ := sum(first, accepted)
+	v2, _ := sum(second, accepted)
+	require.True(v2 > v1)
+}
+
+func scrape(t *testing.T, url string) map[string]*dto.MetricFamily {
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	require.NoError(t, err)
+	return families
+}
+
+// sum adds up the counters or the gauges of the series matching the labels of the sample, and returns false if none
+func sum(families map[string]*dto.MetricFamily, s sample) (float64, bool) {
+	family, ok := families[s.name]
+	if !ok {
+		return 0, false
+	}
+	var total float64
+	found := false
+	for _, m := range family.GetMetric() {
+		if !matches(m, s.labels) {
+			continue
+		}
+		found = true
+		total += m.GetCounter().GetValue() + m.GetGauge().GetValue()
+	}
+	return total, found
+}
+
+func matches(m *dto.Metric, labels map[string]string) bool {
+	matched := 0
+	for _, l := range m.GetLabel() {
+		if v, ok := labels[l.GetName()]; ok {
+			if v != l.GetValue() {
+				return false
+			}
+			matched++
+		}
+	}
+	return matched == len(labels)
+}
@@ -9,6 +9,7 @@ package e2etest
 import (
 	"context"
 	"io/ioutil"
+	"math/big"
 	"os"
 	"testing"
 	"time"
@@ -33,11 +34,12 @@ func TestTwoChains(t *testing.T) {
 	cfg.Plugins[config.GatewayPlugin] = true
 	cfg.Consensus.Scheme = config.StandaloneScheme
 	cfg.Genesis.BlockInterval = time.Second
-	cfg.Chain.ProducerPrivKey = identityset.PrivateKey(1).HexString()
+	cfg.Chain.ProducerPrivKey = config.Secret(identityset.PrivateKey(1).HexString())
 	cfg.Chain.TrieDBPath = testTriePath
 	cfg.Chain.ChainDBPath = testDBPath
 	cfg.Network.Port = testutil.RandomPort()
 	cfg.System.EnableExperimentalActions = true
+	require.NoError(t, config.ValidateAll(cfg, config.WarningsAsNonfatal()))
 
 	svr, err := itx.NewServer(cfg)
 	require.NoError(t, err)
@@ -59,3 +61,60 @@ func TestTwoChains(t *testing.T) {
 	}))
 
 }
+
+func TestConfiguredSubChain(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default
+	cfg.Plugins = make(map[int]interface{})
+	cfg.Consensus.Scheme = config.StandaloneScheme
+	cfg.Genesis.BlockInterval = time.Second
+	cfg.Chain.ProducerPrivKey = config.Secret(identityset.PrivateKey(1).HexString())
+	cfg.Chain.EnableInMemoryDB = true
+	cfg.Network.Port = testutil.RandomPort()
+	cfg.ActPool.MinGasPriceStr = "0"
+	cfg.SubChains = []config.SubChain{{ID: 2}}
+	require.NoError(config.ValidateAll(cfg, config.WarningsAsNonfatal()))
+
+	svr, err := itx.NewServer(cfg)
+	require.NoError(err)
+	ctx := context.Background()
+	require.NoError(svr.Start(ctx))
+	defer func() {
+		require.NoError(svr.Stop(ctx))
+	}()
+
+	chainA := svr.ChainService(cfg.Chain.ID)
+	chainB := svr.ChainService(2)
+	require.NotNil(chainA)
+	require.NotNil(chainB)
+	require.Equal(uint32(2), chainB.ChainID())
+
+	sender := identityset.Address(25).String()
+	recipient := identityset.Address(26).String()
+	balanceA, err := chainA.Blockchain().Balance(recipient)
+	require.NoError(err)
+	balanceB, err := chainB.Blockchain().Balance(recipient)
+	require.NoError(err)
+	tsf, err := testutil.SignedTransfer(recipient, identityset.PrivateKey(25), 1, big.NewInt(100), nil, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	require.NoError(chainA.ActionPool().Add(tsf))
+
+	// The transfer commits on chain A only, while both chains produce blocks
+	require.NoError(testutil.WaitUntil(100*time.Millisecond, 20*time.Second, func() (bool, error) {
+		balance, err := chainA.Blockchain().Balance(recipient)
+		if err != nil {
+			return false, err
+		}
+		return balance.Cmp(new(big.Int).Add(balanceA, big.NewInt(100))) == 0, nil
+	}))
+	require.NoError(testutil.WaitUntil(100*time.Millisecond, 20*time.Second, func() (bool, error) {
+		return chainB.Blockchain().TipHeight() > 1, nil
+	}))
+	balance, err := chainB.Blockchain().Balance(recipient)
+	require.NoError(err)
+	require.Equal(balanceB, balance)
+	nonce, err := chainB.Blockchain().Nonce(sender)
+	require.NoError(err)
+	require.Equal(uint64(0), nonce)
+}
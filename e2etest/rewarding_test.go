@@ -65,10 +65,11 @@ func TestBlockReward(t *testing.T) {
 	cfg.Consensus.Scheme = config.StandaloneScheme
 	cfg.Genesis.BlockInterval = time.Second
 	cfg.Genesis.EnableGravityChainVoting = true
-	cfg.Chain.ProducerPrivKey = identityset.PrivateKey(0).HexString()
+	cfg.Chain.ProducerPrivKey = config.Secret(identityset.PrivateKey(0).HexString())
 	cfg.Chain.TrieDBPath = testTriePath
 	cfg.Chain.ChainDBPath = testDBPath
 	cfg.Network.Port = testutil.RandomPort()
+	require.NoError(t, config.ValidateAll(cfg, config.WarningsAsNonfatal()))
 
 	svr, err := itx.NewServer(cfg)
 	require.NoError(t, err)
@@ -91,7 +92,7 @@ func TestBlockReward(t *testing.T) {
 	ws, err := sf.NewWorkingSet()
 	require.NoError(t, err)
 
-	sk, err := keypair.HexStringToPrivateKey(cfg.Chain.ProducerPrivKey)
+	sk, err := keypair.HexStringToPrivateKey(string(cfg.Chain.ProducerPrivKey))
 	require.NoError(t, err)
 	addr, err := address.FromBytes(sk.PublicKey().Hash())
 	require.NoError(t, err)
@@ -170,6 +171,7 @@ func TestBlockEpochReward(t *testing.T) {
 	// Create mini-cluster
 	svrs := make([]*itx.Server, numNodes)
 	for i := 0; i < numNodes; i++ {
+		require.NoError(t, config.ValidateAll(configs[i], config.WarningsAsNonfatal()))
 		svr, err := itx.NewServer(configs[i])
 		if err != nil {
 			log.L().Fatal("Failed to create server.", zap.Error(err))
@@ -599,7 +601,7 @@ func newConfig(
 	cfg.Chain.ChainDBPath = chainDBPath
 	cfg.Chain.TrieDBPath = trieDBPath
 	cfg.Chain.CompressBlock = true
-	cfg.Chain.ProducerPrivKey = producerPriKey.HexString()
+	cfg.Chain.ProducerPrivKey = config.Secret(producerPriKey.HexString())
 
 	cfg.ActPool.MinGasPriceStr = big.NewInt(0).String()
 
@@ -0,0 +1,130 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package e2etest
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	peerstore "github.com/libp2p/go-libp2p-peerstore"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/p2p"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/server/itx"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+// jsonLogs is the buffer the node writes the JSON logs to concurrently
+type jsonLogs struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (l *jsonLogs) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.buf.Write(p)
+}
+
+// stages returns the stages traced of the action of the short hash
+func (l *jsonLogs) stages(action string) map[string]bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	stages := make(map[string]bool)
+	for _, line := range strings.Split(l.buf.String(), "\n") {
+		entry := make(map[string]interface{})
+		if json.Unmarshal([]byte(line), &entry) != nil || entry["action"] != action {
+			continue
+		}
+		if stage, ok := entry["stage"].(string); ok {
+			stages[stage] = true
+		}
+	}
+	return stages
+}
+
+func TestActionTrace(t *testing.T) {
+	require := require.New(t)
+
+	logs := &jsonLogs{}
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(logs),
+		zap.InfoLevel)
+	defer zap.ReplaceGlobals(zap.New(core))()
+	log.SetActionTraceRate(1)
+	defer log.SetActionTraceRate(0)
+
+	accounts, alloc := testutil.FundedAccounts(2)
+	newConfig := func() config.Config {
+		cfg := newActPoolConfig(alloc)
+		cfg.Consensus.Scheme = config.StandaloneScheme
+		cfg.Genesis.BlockInterval = time.Second
+		return cfg
+	}
+	cfg := newConfig()
+	// the servers of the other tests may be still serving the default API port
+	cfg.API.Port = testutil.RandomPort()
+	ctx := context.Background()
+	svr, err := itx.NewServer(cfg)
+	require.NoError(err)
+	require.NoError(svr.Start(ctx))
+	chainID := cfg.Chain.ID
+	bc := svr.ChainService(chainID).Blockchain()
+
+	cfg = newConfig()
+	cfg.Network.BootstrapNodes = []string{svr.P2PAgent().Self()[0].String()}
+	cli := p2p.NewAgent(
+		cfg,
+		func(_ context.Context, _ uint32, _ proto.Message) {},
+		func(_ context.Context, _ uint32, _ peerstore.PeerInfo, _ proto.Message) {},
+	)
+	require.NoError(cli.Start(ctx))
+	defer func() {
+		require.NoError(cli.Stop(ctx))
+		require.NoError(svr.Stop(ctx))
+	}()
+
+	tsf, err := testutil.SignedTransfer(accounts[1].Address.String(), accounts[0].PriKey, 1, big.NewInt(1),
+		[]byte{}, uint64(100000), big.NewInt(0))
+	require.NoError(err)
+	p2pCtx := p2p.WitContext(ctx, p2p.Context{ChainID: chainID})
+	require.NoError(testutil.WaitUntil(100*time.Millisecond, 60*time.Second, func() (bool, error) {
+		nonce, err := bc.Nonce(accounts[0].Address.String())
+		if err != nil || nonce >= 1 {
+			return err == nil, err
+		}
+		return false, cli.BroadcastOutbound(p2pCtx, tsf.Proto())
+	}))
+
+	// the state is committed before the block is traced
+	h := tsf.Hash()
+	short := hex.EncodeToString(h[:8])
+	require.NoError(testutil.WaitUntil(100*time.Millisecond, 10*time.Second, func() (bool, error) {
+		return logs.stages(short)[log.StageCommitted], nil
+	}))
+	stages := logs.stages(short)
+	for _, stage := range []string{
+		log.StageReceived,
+		log.StageDispatched,
+		log.StageAdmitted,
+		log.StagePicked,
+		log.StageCommitted,
+	} {
+		require.True(stages[stage], "action isn't traced at stage %s", stage)
+	}
+}
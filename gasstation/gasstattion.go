@@ -10,10 +10,10 @@ import (
 	"math/big"
 	"sort"
 
-	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
 	"github.com/iotexproject/iotex-core/protogen/iotextypes"
 )
 
@@ -85,7 +85,7 @@ func (gs *GasStation) EstimateGasForAction(actPb *iotextypes.Action) (uint64, er
 	}
 	// Special handling for executions
 	if sc, ok := selp.Action().(*action.Execution); ok {
-		callerAddr, err := address.FromBytes(selp.SrcPubkey().Hash())
+		callerAddr, err := addrutil.PubKeyToAddress(selp.SrcPubkey())
 		if err != nil {
 			return 0, err
 		}
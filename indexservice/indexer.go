@@ -13,12 +13,12 @@ import (
 
 	"github.com/pkg/errors"
 
-	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/blockchain/block"
 	"github.com/iotexproject/iotex-core/config"
 	s "github.com/iotexproject/iotex-core/db/sql"
 	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
 )
 
 // If you want to add a new index table, please:
@@ -65,7 +65,7 @@ func (idx *Indexer) BuildIndex(blk *block.Block) error {
 		transfers, votes, executions := action.ClassifyActions(blk.Actions)
 		// log transfer index
 		for _, transfer := range transfers {
-			callerAddr, err := address.FromBytes(transfer.SrcPubkey().Hash())
+			callerAddr, err := addrutil.PubKeyToAddress(transfer.SrcPubkey())
 			if err != nil {
 				return err
 			}
@@ -85,7 +85,7 @@ func (idx *Indexer) BuildIndex(blk *block.Block) error {
 
 		// log vote index
 		for _, vote := range votes {
-			callerAddr, err := address.FromBytes(vote.SrcPubkey().Hash())
+			callerAddr, err := addrutil.PubKeyToAddress(vote.SrcPubkey())
 			if err != nil {
 				return err
 			}
@@ -105,7 +105,7 @@ func (idx *Indexer) BuildIndex(blk *block.Block) error {
 
 		// log execution index
 		for _, execution := range executions {
-			callerAddr, err := address.FromBytes(execution.SrcPubkey().Hash())
+			callerAddr, err := addrutil.PubKeyToAddress(execution.SrcPubkey())
 			if err != nil {
 				return err
 			}
@@ -125,7 +125,7 @@ func (idx *Indexer) BuildIndex(blk *block.Block) error {
 
 		// log action index
 		for _, selp := range blk.Actions {
-			callerAddr, err := address.FromBytes(selp.SrcPubkey().Hash())
+			callerAddr, err := addrutil.PubKeyToAddress(selp.SrcPubkey())
 			if err != nil {
 				return err
 			}
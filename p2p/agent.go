@@ -14,6 +14,8 @@ import (
 	"math"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/golang/protobuf/proto"
@@ -26,7 +28,10 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/metrics"
 	"github.com/iotexproject/iotex-core/protogen"
 	"github.com/iotexproject/iotex-core/protogen/iotexrpc"
 )
@@ -37,44 +42,62 @@ const (
 )
 
 var (
-	p2pMsgCounter = prometheus.NewCounterVec(
-		prometheus.CounterOpts{
-			Name: "iotex_p2p_message_counter",
-			Help: "P2P message stats",
-		},
-		[]string{"protocol", "message", "direction", "peer", "status"},
+	p2pMsgCounter = metrics.NewCounter(
+		"iotex_p2p_message_counter",
+		"P2P message stats",
+		"protocol", "message", "direction", "peer", "status",
 	)
-	p2pMsgLatency = prometheus.NewHistogramVec(
-		prometheus.HistogramOpts{
-			Name:    "iotex_p2p_message_latency",
-			Help:    "message latency",
-			Buckets: prometheus.LinearBuckets(0, 10, 200),
-		},
-		[]string{"protocol", "message", "status"},
+	p2pMsgLatency = metrics.NewHistogram(
+		"iotex_p2p_message_latency",
+		"message latency",
+		prometheus.LinearBuckets(0, 10, 200),
+		"protocol", "message", "status",
 	)
 )
 
-func init() {
-	prometheus.MustRegister(p2pMsgCounter)
-	prometheus.MustRegister(p2pMsgLatency)
-}
-
 const (
 	// TODO: the topic could be fine tuned
 	broadcastTopic    = "broadcast"
 	unicastTopic      = "unicast"
 	numDialRetries    = 8
 	dialRetryInterval = 2 * time.Second
+	// outboundPollInterval is how often Stop checks whether the in-flight outbound messages are sent
+	outboundPollInterval = 10 * time.Millisecond
 )
 
+// ErrAgentStopped indicates that the agent is stopping, and doesn't send the messages any more
+var ErrAgentStopped = errors.New("P2P agent is stopped")
+
 type (
 	// HandleBroadcastInbound handles broadcast message when agent listens it from the network
 	HandleBroadcastInbound func(context.Context, uint32, proto.Message)
 
 	// HandleUnicastInboundAsync handles unicast message when agent listens it from the network
 	HandleUnicastInboundAsync func(context.Context, uint32, peerstore.PeerInfo, proto.Message)
+
+	// Overlay is the P2P network the node sends the messages over and takes the messages in from. Agent is the one over
+	// libp2p, and the others, e.g., fakes in tests, pass the messages received to the handlers on their own.
+	Overlay interface {
+		lifecycle.StartStopper
+		// BroadcastOutbound sends the message to all the peers
+		BroadcastOutbound(ctx context.Context, msg proto.Message) error
+		// UnicastOutbound sends the message to the peer
+		UnicastOutbound(ctx context.Context, peer peerstore.PeerInfo, msg proto.Message) error
+		// Neighbors returns the peers connected
+		Neighbors(ctx context.Context) ([]peerstore.PeerInfo, error)
+		// SetWhitelist restricts the peers to the IDs given, and empty lifts the restriction
+		SetWhitelist(peerIDs []string)
+		// Whitelisted tells whether the peer of the ID is allowed by the whitelist
+		Whitelisted(peerID string) bool
+		// Info returns the ID and the addresses of the node
+		Info() peerstore.PeerInfo
+		// Self returns the addresses the node listens on
+		Self() []multiaddr.Multiaddr
+	}
 )
 
+var _ Overlay = (*Agent)(nil)
+
 // Agent is the agent to help the blockchain node connect into the P2P networks and send/receive messages
 type Agent struct {
 	cfg                        config.Network
@@ -82,30 +105,42 @@ type Agent struct {
 	broadcastInboundHandler    HandleBroadcastInbound
 	unicastInboundAsyncHandler HandleUnicastInboundAsync
 	host                       *p2p.Host
+	dedup                      *dedupCache
+	mutex                      sync.RWMutex
+	capture                    *actionCapture
+	whitelist                  map[string]bool
+	// outbound is the number of the outbound messages in sending, which Stop waits for after setting stopping
+	outbound int64
+	stopping int32
 }
 
 // NewAgent instantiates a local P2P agent instance
 func NewAgent(cfg config.Config, broadcastHandler HandleBroadcastInbound, unicastHandler HandleUnicastInboundAsync) *Agent {
+	metrics.MustRegister(p2pMsgCounter, p2pMsgLatency)
 	gh := cfg.Genesis.Hash()
-	return &Agent{
+	agent := &Agent{
 		cfg: cfg.Network,
 		// Make sure the honest node only care the messages related the chain from the same genesis
 		topicSuffix:                hex.EncodeToString(gh[22:]), // last 10 bytes of genesis hash
 		broadcastInboundHandler:    broadcastHandler,
 		unicastInboundAsyncHandler: unicastHandler,
 	}
+	if cfg.Network.DedupWindow > 0 {
+		agent.dedup = newDedupCache(cfg.Network.DedupWindow)
+	}
+	return agent
 }
 
 // Start connects into P2P network
 func (p *Agent) Start(ctx context.Context) error {
 	ready := make(chan interface{})
-	p2p.SetLogger(log.L())
+	p2p.SetLogger(log.Logger("network"))
 	opts := []p2p.Option{
 		p2p.HostName(p.cfg.Host),
 		p2p.Port(p.cfg.Port),
 		p2p.Gossip(),
 		p2p.SecureIO(),
-		p2p.MasterKey(p.cfg.MasterKey),
+		p2p.MasterKey(string(p.cfg.MasterKey)),
 	}
 	if p.cfg.EnableRateLimit {
 		opts = append(opts, p2p.WithRateLimit(p.cfg.RateLimit))
@@ -158,6 +193,21 @@ func (p *Agent) Start(ctx context.Context) error {
 			skip = true
 			return
 		}
+		if !p.Whitelisted(peerID) {
+			skip = true
+			return
+		}
+
+		// Drop the action if the same bytes have been seen recently, e.g., rebroadcast by peers or resubmitted by a
+		// client, before spending effort on validating it again
+		if p.dedup != nil && broadcast.MsgType == iotexrpc.MessageType_ACTION &&
+			p.dedup.isDuplicate(broadcast.MsgBody, time.Now()) {
+			skip = true
+			return
+		}
+		if log.ActionTracing() && broadcast.MsgType == iotexrpc.MessageType_ACTION {
+			log.TraceAction(log.StageReceived, hash.Hash256b(broadcast.MsgBody), log.PeerField(peerID))
+		}
 
 		t, _ := ptypes.Timestamp(broadcast.GetTimestamp())
 		latency = time.Since(t).Nanoseconds() / time.Millisecond.Nanoseconds()
@@ -167,7 +217,12 @@ func (p *Agent) Start(ctx context.Context) error {
 			err = errors.Wrap(err, "error when typifying broadcast message")
 			return
 		}
-		p.broadcastInboundHandler(ctx, broadcast.ChainId, msg)
+		if broadcast.MsgType == iotexrpc.MessageType_ACTION {
+			if err := p.captureAction(broadcast.MsgBody); err != nil {
+				log.Logger("network").Error("Error when capturing action.", zap.Error(err))
+			}
+		}
+		p.broadcastInboundHandler(WithSender(ctx, peerID), broadcast.ChainId, msg)
 		return
 	}); err != nil {
 		return errors.Wrap(err, "error when adding broadcast pubsub")
@@ -208,6 +263,10 @@ func (p *Agent) Start(ctx context.Context) error {
 			return
 		}
 		peerID = stream.Conn().RemotePeer().Pretty()
+		if !p.Whitelisted(peerID) {
+			err = errors.Errorf("peer %s isn't whitelisted", peerID)
+			return
+		}
 		peerInfo := peerstore.PeerInfo{
 			ID:    stream.Conn().RemotePeer(),
 			Addrs: []multiaddr.Multiaddr{stream.Conn().RemoteMultiaddr()},
@@ -247,14 +306,14 @@ func (p *Agent) Start(ctx context.Context) error {
 					return
 				}
 				conn <- true
-				log.L().Info("Connected bootstrap node.", zap.String("address", bootAddr.String()))
+				log.Logger("network").Info("Connected bootstrap node.", zap.String("address", bootAddr.String()))
 			}()
 		}
 		// wait on bootnodes connection
 		for {
 			select {
 			case err := <-connErrChan:
-				log.L().Info("Connection failed.", zap.Error(err))
+				log.Logger("network").Info("Connection failed.", zap.Error(err))
 				errNum++
 				if errNum == tryNum {
 					return errors.New("failed to connect to any bootstrap node")
@@ -274,19 +333,65 @@ func (p *Agent) Start(ctx context.Context) error {
 	return nil
 }
 
-// Stop disconnects from P2P network
+// Stop disconnects from P2P network. It refuses the new outbound messages, and waits for the ones in sending before
+// closing the host, unless the deadline of ctx is hit.
 func (p *Agent) Stop(ctx context.Context) error {
+	atomic.StoreInt32(&p.stopping, 1)
+	if err := p.StopCapture(); err != nil {
+		return err
+	}
 	if p.host == nil {
 		return nil
 	}
+	if err := p.waitOutbound(ctx); err != nil {
+		log.L().Warn(
+			"Closing P2P agent with outbound messages in sending.",
+			zap.Int64("numMessages", atomic.LoadInt64(&p.outbound)),
+			zap.Error(err),
+		)
+	}
 	if err := p.host.Close(); err != nil {
 		return errors.Wrap(err, "error when closing Agent host")
 	}
 	return nil
 }
 
-// BroadcastOutbound sends a broadcast message to the whole network
+// waitOutbound waits until no outbound message is in sending, or ctx is done
+func (p *Agent) waitOutbound(ctx context.Context) error {
+	ticker := time.NewTicker(outboundPollInterval)
+	defer ticker.Stop()
+	for atomic.LoadInt64(&p.outbound) > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+// beginOutbound counts an outbound message in sending, which is refused once the agent is stopping
+func (p *Agent) beginOutbound() error {
+	// count the message before checking stopping, so that Stop either waits for it or it is refused
+	atomic.AddInt64(&p.outbound, 1)
+	if atomic.LoadInt32(&p.stopping) == 1 {
+		p.endOutbound()
+		return ErrAgentStopped
+	}
+	return nil
+}
+
+func (p *Agent) endOutbound() {
+	atomic.AddInt64(&p.outbound, -1)
+}
+
+// BroadcastOutbound sends a broadcast message to the whole network. An action failing the local validation is refused
+// with ErrInvalidBroadcast if Network.ValidateBeforeBroadcast is set.
 func (p *Agent) BroadcastOutbound(ctx context.Context, msg proto.Message) (err error) {
+	if err = p.beginOutbound(); err != nil {
+		return
+	}
+	defer p.endOutbound()
 	var msgType iotexrpc.MessageType
 	var msgBody []byte
 	defer func() {
@@ -306,6 +411,11 @@ func (p *Agent) BroadcastOutbound(ctx context.Context, msg proto.Message) (err e
 	if err != nil {
 		return
 	}
+	if p.cfg.ValidateBeforeBroadcast {
+		if err = validateBroadcast(msg); err != nil {
+			return
+		}
+	}
 	p2pCtx, ok := GetContext(ctx)
 	if !ok {
 		err = errors.New("P2P context doesn't exist")
@@ -332,6 +442,10 @@ func (p *Agent) BroadcastOutbound(ctx context.Context, msg proto.Message) (err e
 
 // UnicastOutbound sends a unicast message to the given address
 func (p *Agent) UnicastOutbound(ctx context.Context, peer peerstore.PeerInfo, msg proto.Message) (err error) {
+	if err = p.beginOutbound(); err != nil {
+		return
+	}
+	defer p.endOutbound()
 	var msgType iotexrpc.MessageType
 	var msgBody []byte
 	defer func() {
@@ -369,6 +483,28 @@ func (p *Agent) UnicastOutbound(ctx context.Context, peer peerstore.PeerInfo, ms
 	return err
 }
 
+// SetWhitelist sets the IDs of the peers which the messages are accepted from. The messages from the others are
+// dropped. An empty whitelist accepts all the peers.
+func (p *Agent) SetWhitelist(peerIDs []string) {
+	var whitelist map[string]bool
+	if len(peerIDs) > 0 {
+		whitelist = make(map[string]bool, len(peerIDs))
+		for _, id := range peerIDs {
+			whitelist[id] = true
+		}
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.whitelist = whitelist
+}
+
+// Whitelisted tells whether the messages from the peer are accepted
+func (p *Agent) Whitelisted(peerID string) bool {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return p.whitelist == nil || p.whitelist[peerID]
+}
+
 // Info returns agents' peer info.
 func (p *Agent) Info() peerstore.PeerInfo { return p.host.Info() }
 
@@ -397,7 +533,7 @@ func exponentialRetry(f func() error, retryInterval time.Duration, numRetries in
 		if err = f(); err == nil {
 			return
 		}
-		log.L().Error("Error happens, will retry.", zap.Error(err))
+		log.Logger("network").Error("Error happens, will retry.", zap.Error(err))
 		time.Sleep(retryInterval)
 		retryInterval *= 2
 	}
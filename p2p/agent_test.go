@@ -142,3 +142,16 @@ func TestUnicast(t *testing.T) {
 		}))
 	}
 }
+
+func TestAgent_Whitelist(t *testing.T) {
+	require := require.New(t)
+
+	agent := NewAgent(config.Default, nil, nil)
+	require.True(agent.Whitelisted("peer1"))
+	agent.SetWhitelist([]string{"peer1", "peer2"})
+	require.True(agent.Whitelisted("peer1"))
+	require.True(agent.Whitelisted("peer2"))
+	require.False(agent.Whitelisted("peer3"))
+	agent.SetWhitelist(nil)
+	require.True(agent.Whitelisted("peer3"))
+}
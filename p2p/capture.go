@@ -0,0 +1,133 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+// maxCapturedActionSize bounds the size of a captured action read back, to tell a corrupted capture from a real one
+const maxCapturedActionSize = 32 << 20
+
+// actionCapture writes the actions received from the network into a file in the order they are handled. Each action
+// is the 4-byte big-endian size followed by the action proto.
+type actionCapture struct {
+	mu     sync.Mutex
+	file   *os.File
+	writer *bufio.Writer
+}
+
+func newActionCapture(path string) (*actionCapture, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create action capture %s", path)
+	}
+	return &actionCapture{file: file, writer: bufio.NewWriter(file)}, nil
+}
+
+// write appends the bytes of an action proto to the capture
+func (c *actionCapture) write(data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var size [4]byte
+	binary.BigEndian.PutUint32(size[:], uint32(len(data)))
+	if _, err := c.writer.Write(size[:]); err != nil {
+		return err
+	}
+	_, err := c.writer.Write(data)
+	return err
+}
+
+func (c *actionCapture) close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.writer.Flush(); err != nil {
+		c.file.Close()
+		return err
+	}
+	return c.file.Close()
+}
+
+// StartCapture starts writing every action received from the network into the file at path, which ReplayActions
+// reads back in the same order. The actions dropped as duplicates aren't captured.
+func (p *Agent) StartCapture(path string) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.capture != nil {
+		return errors.New("action capture has already started")
+	}
+	capture, err := newActionCapture(path)
+	if err != nil {
+		return err
+	}
+	p.capture = capture
+	return nil
+}
+
+// StopCapture stops capturing the actions and flushes the capture file
+func (p *Agent) StopCapture() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.capture == nil {
+		return nil
+	}
+	err := p.capture.close()
+	p.capture = nil
+	return errors.Wrap(err, "failed to close action capture")
+}
+
+// captureAction writes the action into the capture if it has started
+func (p *Agent) captureAction(data []byte) error {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	if p.capture == nil {
+		return nil
+	}
+	return errors.Wrap(p.capture.write(data), "failed to capture action")
+}
+
+// ReplayActions reads the actions captured by Agent.StartCapture from the file at path, and submits them one by one in
+// the order they were received
+func ReplayActions(path string, submit func(*iotextypes.Action)) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open action capture %s", path)
+	}
+	defer file.Close()
+	reader := bufio.NewReader(file)
+	for i := 0; ; i++ {
+		var size [4]byte
+		if _, err := io.ReadFull(reader, size[:]); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return errors.Wrapf(err, "failed to read the size of action %d", i)
+		}
+		n := binary.BigEndian.Uint32(size[:])
+		if n > maxCapturedActionSize {
+			return errors.Errorf("action %d of size %d is too large", i, n)
+		}
+		data := make([]byte, n)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return errors.Wrapf(err, "failed to read action %d", i)
+		}
+		var act iotextypes.Action
+		if err := proto.Unmarshal(data, &act); err != nil {
+			return errors.Wrapf(err, "failed to unmarshal action %d", i)
+		}
+		submit(&act)
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+func TestAgent_CaptureAndReplayActions(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "capture")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "actions.capture")
+
+	agent := NewAgent(config.Default, nil, nil)
+	// nothing is captured before the capture starts
+	require.NoError(agent.captureAction([]byte("ignored")))
+	require.NoError(agent.StartCapture(path))
+	require.Error(agent.StartCapture(path))
+	acts := make([]*iotextypes.Action, 0, 3)
+	for i := 0; i < 3; i++ {
+		act := &iotextypes.Action{
+			Core:      &iotextypes.ActionCore{Version: 1, Nonce: uint64(3 - i), GasLimit: 10000},
+			Signature: []byte{byte(i)},
+		}
+		data, err := proto.Marshal(act)
+		require.NoError(err)
+		require.NoError(agent.captureAction(data))
+		acts = append(acts, act)
+	}
+	require.NoError(agent.StopCapture())
+	require.NoError(agent.StopCapture())
+
+	replayed := make([]*iotextypes.Action, 0, 3)
+	require.NoError(ReplayActions(path, func(act *iotextypes.Action) {
+		replayed = append(replayed, act)
+	}))
+	require.Len(replayed, len(acts))
+	for i := range acts {
+		require.True(proto.Equal(acts[i], replayed[i]))
+	}
+
+	// a truncated capture is reported after replaying the complete actions
+	data, err := ioutil.ReadFile(path)
+	require.NoError(err)
+	require.NoError(ioutil.WriteFile(path, data[:len(data)-1], 0644))
+	replayed = replayed[:0]
+	require.Error(ReplayActions(path, func(act *iotextypes.Action) {
+		replayed = append(replayed, act)
+	}))
+	require.Len(replayed, 2)
+
+	require.Error(ReplayActions(filepath.Join(dir, "missing"), func(*iotextypes.Action) {}))
+}
@@ -25,3 +25,16 @@ func GetContext(ctx context.Context) (Context, bool) {
 	p2pCtx, ok := ctx.Value(p2pCtxKey{}).(Context)
 	return p2pCtx, ok
 }
+
+type senderCtxKey struct{}
+
+// WithSender attaches the ID of the peer sending a broadcast message to the context
+func WithSender(ctx context.Context, peerID string) context.Context {
+	return context.WithValue(ctx, senderCtxKey{}, peerID)
+}
+
+// GetSender gets the ID of the peer sending a broadcast message
+func GetSender(ctx context.Context) (string, bool) {
+	peerID, ok := ctx.Value(senderCtxKey{}).(string)
+	return peerID, ok
+}
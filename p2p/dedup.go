@@ -0,0 +1,41 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"time"
+
+	"github.com/iotexproject/iotex-core/pkg/cache"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
+// dedupCacheSize is the max number of message hashes remembered for deduplication
+const dedupCacheSize = 16384
+
+// dedupCache remembers the hashes of the messages seen within a time window
+type dedupCache struct {
+	window time.Duration
+	seen   *cache.ThreadSafeLruCache
+}
+
+func newDedupCache(window time.Duration) *dedupCache {
+	return &dedupCache{
+		window: window,
+		seen:   cache.NewThreadSafeLruCache(dedupCacheSize),
+	}
+}
+
+// isDuplicate tells whether the same bytes have been seen within the window before now, and remembers them otherwise.
+// A duplicate does not extend the window, so that a message retried after the window is handled again
+func (c *dedupCache) isDuplicate(data []byte, now time.Time) bool {
+	h := hash.Hash256b(data)
+	if v, ok := c.seen.Get(h); ok && now.Sub(v.(time.Time)) < c.window {
+		return true
+	}
+	c.seen.Add(h, now)
+	return false
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/config"
+)
+
+func TestDedupCache(t *testing.T) {
+	require := require.New(t)
+
+	c := newDedupCache(time.Second)
+	now := time.Now()
+	require.False(c.isDuplicate([]byte("action"), now))
+	require.True(c.isDuplicate([]byte("action"), now.Add(500*time.Millisecond)))
+	require.False(c.isDuplicate([]byte("other action"), now.Add(500*time.Millisecond)))
+	// a duplicate does not extend the window
+	require.True(c.isDuplicate([]byte("action"), now.Add(999*time.Millisecond)))
+	require.False(c.isDuplicate([]byte("action"), now.Add(time.Second)))
+	require.True(c.isDuplicate([]byte("action"), now.Add(1500*time.Millisecond)))
+}
+
+func TestNewAgent_DedupWindow(t *testing.T) {
+	cfg := config.Default
+	require.NotNil(t, NewAgent(cfg, nil, nil).dedup)
+	cfg.Network.DedupWindow = 0
+	require.Nil(t, NewAgent(cfg, nil, nil).dedup)
+}
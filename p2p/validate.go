@@ -0,0 +1,51 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+// ErrInvalidBroadcast indicates that the action to broadcast fails the local validation
+var ErrInvalidBroadcast = errors.New("action is refused to broadcast")
+
+// validateBroadcast checks the action to broadcast by what can be told without the chain states, which are the
+// signature of the sender, the intrinsic gas, and not being a system action, like granting the block reward, which is
+// only put into the block by the producer. The other messages pass.
+func validateBroadcast(msg proto.Message) error {
+	pb, ok := msg.(*iotextypes.Action)
+	if !ok {
+		return nil
+	}
+	var selp action.SealedEnvelope
+	if err := selp.LoadProto(pb); err != nil {
+		return errors.Wrap(ErrInvalidBroadcast, err.Error())
+	}
+	if _, ok := selp.Action().(*action.GrantReward); ok {
+		return errors.Wrap(ErrInvalidBroadcast, "granting reward is a system action of the block producer")
+	}
+	intrinsicGas, err := selp.IntrinsicGas()
+	if err != nil {
+		return errors.Wrap(ErrInvalidBroadcast, err.Error())
+	}
+	if intrinsicGas > selp.GasLimit() {
+		return errors.Wrapf(
+			ErrInvalidBroadcast,
+			"gas limit %d is lower than the intrinsic gas %d",
+			selp.GasLimit(),
+			intrinsicGas,
+		)
+	}
+	if err := action.Verify(selp); err != nil {
+		return errors.Wrap(ErrInvalidBroadcast, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package p2p
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/protogen/testingpb"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestValidateBroadcast(t *testing.T) {
+	require := require.New(t)
+
+	tsf, err := testutil.SignedTransfer(identityset.Address(1).String(), identityset.PrivateKey(0), 1, big.NewInt(1),
+		nil, testutil.TestGasLimit, big.NewInt(0))
+	require.NoError(err)
+	require.NoError(validateBroadcast(tsf.Proto()))
+	require.NoError(validateBroadcast(&testingpb.TestPayload{MsgBody: []byte{1}}))
+
+	// unsigned
+	pb := tsf.Proto()
+	pb.Signature = nil
+	require.Equal(ErrInvalidBroadcast, errors.Cause(validateBroadcast(pb)))
+	// signed by another key
+	other, err := testutil.SignedTransfer(identityset.Address(1).String(), identityset.PrivateKey(2), 1, big.NewInt(1),
+		nil, testutil.TestGasLimit, big.NewInt(0))
+	require.NoError(err)
+	pb = tsf.Proto()
+	pb.Signature = other.Proto().Signature
+	require.Equal(ErrInvalidBroadcast, errors.Cause(validateBroadcast(pb)))
+	// gas limit lower than the intrinsic gas
+	tsf, err = testutil.SignedTransfer(identityset.Address(1).String(), identityset.PrivateKey(0), 1, big.NewInt(1),
+		nil, 1, big.NewInt(0))
+	require.NoError(err)
+	require.Equal(ErrInvalidBroadcast, errors.Cause(validateBroadcast(tsf.Proto())))
+	// coinbase
+	gb := action.GrantRewardBuilder{}
+	grant := gb.SetRewardType(action.BlockReward).Build()
+	eb := action.EnvelopeBuilder{}
+	elp := eb.SetNonce(0).
+		SetGasPrice(big.NewInt(0)).
+		SetGasLimit(grant.GasLimit()).
+		SetAction(&grant).
+		Build()
+	selp, err := action.Sign(elp, identityset.PrivateKey(0))
+	require.NoError(err)
+	require.Equal(ErrInvalidBroadcast, errors.Cause(validateBroadcast(selp.Proto())))
+}
+
+func TestBroadcastOutbound_ValidateBeforeBroadcast(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	cfg := config.Config{
+		Network: config.Network{Host: "127.0.0.1", Port: testutil.RandomPort(), ValidateBeforeBroadcast: true},
+	}
+	agent := NewAgent(cfg, nil, nil)
+	require.NoError(agent.Start(ctx))
+	defer func() {
+		require.NoError(agent.Stop(ctx))
+	}()
+	ctx = WitContext(ctx, Context{ChainID: 1})
+
+	tsf, err := testutil.SignedTransfer(identityset.Address(1).String(), identityset.PrivateKey(0), 1, big.NewInt(1),
+		nil, testutil.TestGasLimit, big.NewInt(0))
+	require.NoError(err)
+	require.NoError(agent.BroadcastOutbound(ctx, tsf.Proto()))
+	pb := tsf.Proto()
+	pb.Signature = nil
+	require.Equal(ErrInvalidBroadcast, errors.Cause(agent.BroadcastOutbound(ctx, pb)))
+
+	// the invalid action is broadcast without the validation
+	agent.cfg.ValidateBeforeBroadcast = false
+	require.NoError(agent.BroadcastOutbound(ctx, pb))
+}
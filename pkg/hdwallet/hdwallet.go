@@ -0,0 +1,120 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package hdwallet
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+)
+
+const (
+	// HardenedOffset is added to a child index to derive a hardened child key
+	HardenedOffset uint32 = 0x80000000
+	// CoinType is the registered SLIP-44 coin type of IoTeX
+	CoinType uint32 = 304
+)
+
+// ErrInvalidChild indicates the derived child key is out of range, in which case the next index should be used
+var ErrInvalidChild = errors.New("invalid child key")
+
+var masterKey = []byte("Bitcoin seed")
+
+// ExtendedKey is a private key with its chain code, from which child keys can be derived
+type ExtendedKey struct {
+	key       []byte
+	chainCode []byte
+}
+
+// NewMasterKey derives the master extended key from a seed
+func NewMasterKey(seed []byte) (*ExtendedKey, error) {
+	return newExtendedKey(masterKey, seed)
+}
+
+// Child derives the child extended key at the given index
+func (k *ExtendedKey) Child(index uint32) (*ExtendedKey, error) {
+	var data []byte
+	if index >= HardenedOffset {
+		data = append([]byte{0}, k.key...)
+	} else {
+		curve := crypto.S256()
+		x, y := curve.ScalarBaseMult(k.key)
+		data = crypto.CompressPubkey(&ecdsa.PublicKey{Curve: curve, X: x, Y: y})
+	}
+	var idx [4]byte
+	binary.BigEndian.PutUint32(idx[:], index)
+	child, err := newExtendedKey(k.chainCode, append(data, idx[:]...))
+	if err != nil {
+		return nil, err
+	}
+	n := crypto.S256().Params().N
+	sum := new(big.Int).SetBytes(child.key)
+	sum.Add(sum, new(big.Int).SetBytes(k.key))
+	sum.Mod(sum, n)
+	if sum.Sign() == 0 {
+		return nil, ErrInvalidChild
+	}
+	child.key = padTo32(sum.Bytes())
+	return child, nil
+}
+
+// PrivateKey returns the key pair of the extended key
+func (k *ExtendedKey) PrivateKey() (keypair.PrivateKey, error) {
+	return keypair.BytesToPrivateKey(k.key)
+}
+
+// DerivePrivateKey derives the private key of the given account index along the path m/44'/304'/0'/0/index
+func DerivePrivateKey(seed []byte, index uint32) (keypair.PrivateKey, error) {
+	key, err := NewMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range []uint32{44 + HardenedOffset, CoinType + HardenedOffset, HardenedOffset, 0, index} {
+		if key, err = key.Child(i); err != nil {
+			return nil, errors.Wrapf(err, "failed to derive child %d", i)
+		}
+	}
+	return key.PrivateKey()
+}
+
+// PrivateKeyFromMnemonic derives the private key of the given account index from a mnemonic and passphrase
+func PrivateKeyFromMnemonic(mnemonic, passphrase string, index uint32) (keypair.PrivateKey, error) {
+	seed, err := MnemonicToSeed(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return DerivePrivateKey(seed, index)
+}
+
+func newExtendedKey(hmacKey, data []byte) (*ExtendedKey, error) {
+	mac := hmac.New(sha512.New, hmacKey)
+	if _, err := mac.Write(data); err != nil {
+		return nil, err
+	}
+	sum := mac.Sum(nil)
+	key := new(big.Int).SetBytes(sum[:32])
+	if key.Cmp(crypto.S256().Params().N) >= 0 {
+		return nil, ErrInvalidChild
+	}
+	return &ExtendedKey{key: sum[:32], chainCode: sum[32:]}, nil
+}
+
+func padTo32(b []byte) []byte {
+	if len(b) >= 32 {
+		return b
+	}
+	padded := make([]byte, 32)
+	copy(padded[32-len(b):], b)
+	return padded
+}
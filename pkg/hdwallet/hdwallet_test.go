@@ -0,0 +1,115 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package hdwallet
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-address/address"
+)
+
+func TestMnemonicToSeed(t *testing.T) {
+	require := require.New(t)
+
+	// test vectors from https://github.com/trezor/python-mnemonic/blob/master/vectors.json
+	vectors := []struct {
+		mnemonic string
+		seed     string
+	}{
+		{
+			"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		},
+		{
+			"legal winner thank year wave sausage worth useful legal winner thank yellow",
+			"2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+		},
+		{
+			strings.Repeat("abandon ", 23) + "art",
+			"bda85446c68413707090a52022edd26a1c9462295029f2e60cd7c4f2bbd3097170af7a4d73245cafa9c3cca8d561a7c3de6f5d4a10be8ed2a5e608d68f92fcc8",
+		},
+	}
+	for _, v := range vectors {
+		seed, err := MnemonicToSeed(v.mnemonic, "TREZOR")
+		require.NoError(err)
+		require.Equal(v.seed, hex.EncodeToString(seed))
+	}
+}
+
+func TestValidateMnemonic(t *testing.T) {
+	require := require.New(t)
+
+	mnemonic, err := NewMnemonic()
+	require.NoError(err)
+	require.Equal(24, len(strings.Fields(mnemonic)))
+	require.NoError(ValidateMnemonic(mnemonic))
+
+	err = ValidateMnemonic(strings.Repeat("abandon ", 11))
+	require.Equal(ErrWordCount, errors.Cause(err))
+	err = ValidateMnemonic(strings.Repeat("abandon ", 11) + "iotex")
+	require.Equal(ErrUnknownWord, errors.Cause(err))
+	err = ValidateMnemonic(strings.Repeat("abandon ", 12))
+	require.Equal(ErrChecksum, errors.Cause(err))
+	_, err = MnemonicToSeed(strings.Repeat("abandon ", 12), "")
+	require.Equal(ErrChecksum, errors.Cause(err))
+}
+
+func TestChildKey(t *testing.T) {
+	require := require.New(t)
+
+	// test vector 1 from https://github.com/bitcoin/bips/blob/master/bip-0032.mediawiki
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	require.NoError(err)
+	key, err := NewMasterKey(seed)
+	require.NoError(err)
+	require.Equal("e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35", hex.EncodeToString(key.key))
+
+	expected := []struct {
+		index uint32
+		key   string
+	}{
+		{HardenedOffset, "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{1, "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368"},
+		{2 + HardenedOffset, "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca"},
+	}
+	for _, e := range expected {
+		key, err = key.Child(e.index)
+		require.NoError(err)
+		require.Equal(e.key, hex.EncodeToString(key.key))
+	}
+}
+
+func TestRecoverAccount(t *testing.T) {
+	require := require.New(t)
+
+	mnemonic, err := NewMnemonic()
+	require.NoError(err)
+	created, err := PrivateKeyFromMnemonic(mnemonic, "", 0)
+	require.NoError(err)
+	createdAddr, err := address.FromBytes(created.PublicKey().Hash())
+	require.NoError(err)
+
+	// recovering from the same phrase, even with different spacing, yields the same first address
+	recovered, err := PrivateKeyFromMnemonic(" "+strings.Join(strings.Fields(mnemonic), "  ")+"\n", "", 0)
+	require.NoError(err)
+	recoveredAddr, err := address.FromBytes(recovered.PublicKey().Hash())
+	require.NoError(err)
+	require.Equal(createdAddr.String(), recoveredAddr.String())
+
+	// a different index or passphrase yields a different account
+	other, err := PrivateKeyFromMnemonic(mnemonic, "", 1)
+	require.NoError(err)
+	require.NotEqual(created.HexString(), other.HexString())
+	other, err = PrivateKeyFromMnemonic(mnemonic, "passphrase", 0)
+	require.NoError(err)
+	require.NotEqual(created.HexString(), other.HexString())
+}
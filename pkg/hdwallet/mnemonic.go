@@ -0,0 +1,66 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package hdwallet
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// DefaultEntropyBits is the entropy size of a newly generated mnemonic, which yields a 24-word phrase
+const DefaultEntropyBits = 256
+
+var (
+	// ErrWordCount indicates the mnemonic does not have a supported number of words
+	ErrWordCount = errors.New("invalid number of mnemonic words")
+	// ErrUnknownWord indicates the mnemonic contains a word outside of the English wordlist
+	ErrUnknownWord = errors.New("unknown mnemonic word")
+	// ErrChecksum indicates all the words are known but the checksum does not match
+	ErrChecksum = errors.New("mnemonic checksum mismatch")
+)
+
+// NewMnemonic generates a new 24-word mnemonic from the standard English wordlist
+func NewMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(DefaultEntropyBits)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate entropy")
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// ValidateMnemonic checks the word count, the words and the checksum of a mnemonic
+func ValidateMnemonic(mnemonic string) error {
+	words := strings.Fields(mnemonic)
+	switch len(words) {
+	case 12, 15, 18, 21, 24:
+	default:
+		return errors.Wrapf(ErrWordCount, "got %d words, expect 12, 15, 18, 21 or 24", len(words))
+	}
+	for i, word := range words {
+		if _, ok := bip39.GetWordIndex(word); !ok {
+			return errors.Wrapf(ErrUnknownWord, "word #%d %q", i+1, word)
+		}
+	}
+	if _, err := bip39.EntropyFromMnemonic(strings.Join(words, " ")); err != nil {
+		if err == bip39.ErrChecksumIncorrect {
+			return ErrChecksum
+		}
+		return errors.Wrap(err, "failed to decode mnemonic")
+	}
+	return nil
+}
+
+// MnemonicToSeed validates the mnemonic and converts it together with an optional passphrase into the seed used by
+// key derivation
+func MnemonicToSeed(mnemonic, passphrase string) ([]byte, error) {
+	if err := ValidateMnemonic(mnemonic); err != nil {
+		return nil, err
+	}
+	return bip39.NewSeed(strings.Join(strings.Fields(mnemonic), " "), passphrase), nil
+}
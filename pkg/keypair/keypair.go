@@ -28,6 +28,10 @@ var (
 	ErrPublicKey = errors.New("invalid public key")
 	// ErrPrivateKey indicates the error of private key
 	ErrPrivateKey = errors.New("invalid private key")
+	// ErrInvalidPubKeyLength indicates the public key is not an uncompressed SECP256K1 public key of 65 bytes
+	ErrInvalidPubKeyLength = errors.New("invalid public key length")
+	// ErrInvalidPrvKeyLength indicates the private key is not a SECP256K1 private key of 32 bytes
+	ErrInvalidPrvKeyLength = errors.New("invalid private key length")
 )
 
 type (
@@ -39,14 +43,19 @@ type (
 		Hash() []byte
 		Verify([]byte, []byte) bool
 	}
-	// PrivateKey represents a private key
+	// PrivateKey represents a private key, which is the default Signer with the key loaded in memory
 	PrivateKey interface {
+		Signer
 		Bytes() []byte
 		HexString() string
 		EcdsaPrivateKey() *ecdsa.PrivateKey
+		Zero()
+	}
+	// Signer signs a digest without necessarily exposing the private key, which may be held by a hardware wallet or a
+	// remote signing service
+	Signer interface {
 		PublicKey() PublicKey
 		Sign([]byte) ([]byte, error)
-		Zero()
 	}
 )
 
@@ -59,7 +68,7 @@ func GenerateKey() (PrivateKey, error) {
 func HexStringToPublicKey(pubKey string) (PublicKey, error) {
 	b, err := hex.DecodeString(pubKey)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to decode public key %s", pubKey)
+		return nil, errors.Wrapf(ErrInvalidKey, "failed to decode public key %s: %v", pubKey, err)
 	}
 	return BytesToPublicKey(b)
 }
@@ -68,7 +77,7 @@ func HexStringToPublicKey(pubKey string) (PublicKey, error) {
 func HexStringToPrivateKey(prvKey string) (PrivateKey, error) {
 	b, err := hex.DecodeString(prvKey)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to decode public key %s", prvKey)
+		return nil, errors.Wrapf(ErrInvalidKey, "failed to decode private key: %v", err)
 	}
 	return BytesToPrivateKey(b)
 }
@@ -103,10 +112,10 @@ func KeystoreToPrivateKey(account accounts.Account, password string) (PrivateKey
 func StringToPubKeyBytes(pubKey string) ([]byte, error) {
 	pubKeyBytes, err := hex.DecodeString(pubKey)
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrapf(ErrInvalidKey, "failed to decode public key %s: %v", pubKey, err)
 	}
 	if len(pubKeyBytes) != secp256pubKeyLength {
-		return nil, errors.Wrap(ErrPublicKey, "Invalid public key length")
+		return nil, errors.Wrapf(ErrInvalidPubKeyLength, "public key has %d bytes", len(pubKeyBytes))
 	}
 	return pubKeyBytes, nil
 }
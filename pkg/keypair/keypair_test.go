@@ -7,10 +7,13 @@
 package keypair
 
 import (
+	"encoding/hex"
+	"math/rand"
 	"strings"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotexproject/iotex-address/address"
@@ -60,6 +63,66 @@ func TestKeypair(t *testing.T) {
 	require.NoError(err)
 }
 
+func TestDecodeErrors(t *testing.T) {
+	require := require.New(t)
+
+	pk, err := HexStringToPublicKey(publicKey)
+	require.NoError(err)
+	pkBytes := pk.Bytes()
+	sk, err := HexStringToPrivateKey(privateKey)
+	require.NoError(err)
+	skBytes := sk.Bytes()
+
+	// truncated and oversized
+	for _, b := range [][]byte{nil, pkBytes[:1], pkBytes[:64], append(pkBytes, 0), append(pkBytes, pkBytes...)} {
+		_, err := BytesToPublicKey(b)
+		require.Equal(ErrInvalidPubKeyLength, errors.Cause(err))
+		_, err = StringToPubKeyBytes(hex.EncodeToString(b))
+		require.Equal(ErrInvalidPubKeyLength, errors.Cause(err))
+	}
+	for _, b := range [][]byte{nil, skBytes[:1], skBytes[:31], append(skBytes, 0)} {
+		_, err := BytesToPrivateKey(b)
+		require.Equal(ErrInvalidPrvKeyLength, errors.Cause(err))
+	}
+	// not hex
+	for _, s := range []string{"0", "zz", publicKey[:len(publicKey)-1]} {
+		_, err := HexStringToPublicKey(s)
+		require.Equal(ErrInvalidKey, errors.Cause(err))
+		_, err = HexStringToPrivateKey(s)
+		require.Equal(ErrInvalidKey, errors.Cause(err))
+		_, err = StringToPubKeyBytes(s)
+		require.Equal(ErrInvalidKey, errors.Cause(err))
+	}
+	// a point off the curve, or in another encoding
+	for i := 0; i < len(pkBytes); i++ {
+		b := make([]byte, len(pkBytes))
+		copy(b, pkBytes)
+		b[i] ^= 1
+		_, err := BytesToPublicKey(b)
+		require.Equal(ErrPublicKey, errors.Cause(err), "flipping byte %d", i)
+	}
+	// a key out of range
+	_, err = BytesToPrivateKey(make([]byte, 32))
+	require.Equal(ErrPrivateKey, errors.Cause(err))
+
+	// arbitrary input never panics
+	r := rand.New(rand.NewSource(0))
+	for i := 0; i < 10000; i++ {
+		b := make([]byte, r.Intn(2*secp256pubKeyLength))
+		r.Read(b)
+		if r.Intn(2) == 0 && len(b) > 0 {
+			b[0] = 4
+		}
+		require.NotPanics(func() {
+			BytesToPublicKey(b)
+			BytesToPrivateKey(b)
+			HexStringToPublicKey(string(b))
+			HexStringToPrivateKey(string(b))
+			StringToPubKeyBytes(string(b))
+		})
+	}
+}
+
 func TestCompatibility(t *testing.T) {
 	require := require.New(t)
 
@@ -44,9 +44,12 @@ func newSecp256k1PrvKey() (PrivateKey, error) {
 
 // newSecp256k1PrvKeyFromBytes converts bytes format to PrivateKey
 func newSecp256k1PrvKeyFromBytes(b []byte) (PrivateKey, error) {
+	if len(b) != secp256prvKeyLength {
+		return nil, errors.Wrapf(ErrInvalidPrvKeyLength, "invalid length, need 256 bits, got %d bytes", len(b))
+	}
 	sk, err := crypto.ToECDSA(b)
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrap(ErrPrivateKey, err.Error())
 	}
 	return &secp256k1PrvKey{
 		PrivateKey: sk,
@@ -94,9 +97,12 @@ func (k *secp256k1PrvKey) Zero() {
 
 // newSecp256k1PubKeyFromBytes converts bytes format to PublicKey
 func newSecp256k1PubKeyFromBytes(b []byte) (PublicKey, error) {
+	if len(b) != secp256pubKeyLength {
+		return nil, errors.Wrapf(ErrInvalidPubKeyLength, "invalid secp256k1 public key of %d bytes", len(b))
+	}
 	pk, err := crypto.UnmarshalPubkey(b)
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrap(ErrPublicKey, err.Error())
 	}
 	return &secp256k1PubKey{
 		PublicKey: pk,
@@ -9,6 +9,7 @@ package lifecycle
 
 import (
 	"context"
+	"runtime/pprof"
 
 	"golang.org/x/sync/errgroup"
 )
@@ -34,6 +35,19 @@ type StartStopper interface {
 	Stopper
 }
 
+// ComponentLabel is the goroutine label naming the component a goroutine works for
+const ComponentLabel = "component"
+
+// StartLabeled starts the model with the goroutine label ComponentLabel set to name. The goroutines the model starts
+// inherit the label, so that the goroutine profiles are attributable to the components.
+func StartLabeled(ctx context.Context, name string, s Starter) error {
+	var err error
+	pprof.Do(ctx, pprof.Labels(ComponentLabel, name), func(ctx context.Context) {
+		err = s.Start(ctx)
+	})
+	return err
+}
+
 // Lifecycle manages lifecycle for models. Currently a Lifecycle has two phases: Start and Stop.
 // Currently Lifecycle doesn't support soft dependency models and multi-err, so all models in Lifecycle require to be
 // succeed on both phases.
@@ -29,13 +29,19 @@ type GlobalConfig struct {
 	Zap                *zap.Config `json:"zap" yaml:"zap"`
 	StderrRedirectFile *string     `json:"stderrRedirectFile" yaml:"stderrRedirectFile"`
 	RedirectStdLog     bool        `json:"stdLogRedirect" yaml:"stdLogRedirect"`
+	// Modules overrides the levels and the outputs of the loggers of the modules, keyed by module name
+	Modules map[string]ModuleConfig `json:"modules" yaml:"modules"`
+	// ActionTraceRate is the fraction of the actions, between 0 and 1, whose lifecycle through the components is
+	// logged by the trace logger, e.g., 0.01 traces 1% of the actions. 0 traces none
+	ActionTraceRate float64 `json:"actionTraceRate" yaml:"actionTraceRate"`
 }
 
 var (
 	_globalCfg        GlobalConfig
 	_logMu            sync.RWMutex
 	_logServeMux      = http.NewServeMux()
-	_subLoggers       map[string]*zap.Logger
+	_subLoggers       = make(map[string]*zap.Logger)
+	_moduleLoggers    map[string]moduleLogger
 	_globalLoggerName = "global"
 )
 
@@ -60,13 +66,18 @@ func L() *zap.Logger { return zap.L() }
 // S wraps zap.S().
 func S() *zap.SugaredLogger { return zap.S() }
 
-// Logger returns logger of the given name
+// Logger returns logger of the given name, which is the sub logger or the logger of the module of the name. The
+// logger of a module without config is the global logger named after the module.
 func Logger(name string) *zap.Logger {
-	logger, ok := _subLoggers[name]
-	if !ok {
-		return L()
+	_logMu.RLock()
+	defer _logMu.RUnlock()
+	if logger, ok := _subLoggers[name]; ok {
+		return logger
 	}
-	return logger
+	if m, ok := _moduleLoggers[name]; ok {
+		return m.logger
+	}
+	return L().Named(name)
 }
 
 // InitLoggers initializes the global logger and other sub loggers.
@@ -83,7 +94,10 @@ func InitLoggers(globalCfg GlobalConfig, subCfgs map[string]GlobalConfig, opts .
 			zapCfg := zap.NewProductionConfig()
 			cfg.Zap = &zapCfg
 		} else {
-			cfg.Zap.EncoderConfig = zap.NewProductionEncoderConfig()
+			// copy the zap config rather than overwriting the encoder of the caller's
+			zapCfg := *cfg.Zap
+			zapCfg.EncoderConfig = zap.NewProductionEncoderConfig()
+			cfg.Zap = &zapCfg
 		}
 		logger, err := cfg.Zap.Build(opts...)
 		if err != nil {
@@ -98,9 +112,17 @@ func InitLoggers(globalCfg GlobalConfig, subCfgs map[string]GlobalConfig, opts .
 				return err
 			}
 		}
+		var moduleLoggers map[string]moduleLogger
+		if name == _globalLoggerName {
+			if moduleLoggers, err = buildModuleLoggers(cfg, opts...); err != nil {
+				return err
+			}
+		}
 		_logMu.Lock()
 		if name == _globalLoggerName {
 			_globalCfg = cfg
+			_moduleLoggers = moduleLoggers
+			SetActionTraceRate(cfg.ActionTraceRate)
 			if cfg.RedirectStdLog {
 				zap.RedirectStdLog(logger)
 			}
@@ -115,6 +137,18 @@ func InitLoggers(globalCfg GlobalConfig, subCfgs map[string]GlobalConfig, opts .
 	return nil
 }
 
+// SetLevel sets the level of the global logger, along with the modules following it
+func SetLevel(level zapcore.Level) {
+	_logMu.Lock()
+	defer _logMu.Unlock()
+	_globalCfg.Zap.Level.SetLevel(level)
+	for _, m := range _moduleLoggers {
+		if m.cfg.Level == "" {
+			m.level.SetLevel(level)
+		}
+	}
+}
+
 // RegisterLevelConfigMux registers log's level config http mux.
 func RegisterLevelConfigMux(root *http.ServeMux) {
 	_logMu.Lock()
@@ -0,0 +1,145 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package log
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestModuleLoggers(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "log")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	globalPath := filepath.Join(dir, "global.log")
+	consensusPath := filepath.Join(dir, "consensus.log")
+	networkPath := filepath.Join(dir, "network.log")
+
+	zapCfg := zap.NewProductionConfig()
+	zapCfg.OutputPaths = []string{globalPath}
+	cfg := GlobalConfig{
+		Zap: &zapCfg,
+		Modules: map[string]ModuleConfig{
+			"consensus": {Level: "debug", Output: consensusPath},
+			"network":   {Level: "error", Output: networkPath, MaxSizeMB: 10, MaxAge: time.Hour},
+			"actpool":   {},
+		},
+	}
+	require.NoError(InitLoggers(cfg, map[string]GlobalConfig{}))
+
+	L().Debug("global debug")
+	L().Info("global info")
+	Logger("consensus").Debug("consensus debug")
+	Logger("network").Info("network info")
+	Logger("network").Error("network error")
+	Logger("actpool").Debug("actpool debug")
+	Logger("actpool").Info("actpool info")
+	Logger("blockchain").Info("blockchain info")
+	require.NoError(Logger("consensus").Sync())
+	require.NoError(Logger("network").Sync())
+
+	global := readFile(t, globalPath)
+	require.NotContains(global, "global debug")
+	require.Contains(global, "global info")
+	// the modules without output follow the global logger, tagged with their names
+	require.NotContains(global, "actpool debug")
+	require.True(hasLog(global, "actpool", "actpool info"))
+	require.True(hasLog(global, "blockchain", "blockchain info"))
+	require.NotContains(global, "consensus")
+	require.NotContains(global, "network")
+
+	require.True(hasLog(readFile(t, consensusPath), "consensus", "consensus debug"))
+	network := readFile(t, networkPath)
+	require.NotContains(network, "network info")
+	require.True(hasLog(network, "network", "network error"))
+
+	// reload the levels
+	cfg.Modules = map[string]ModuleConfig{
+		"consensus": {Level: "info", Output: consensusPath},
+		"network":   {Level: "info", Output: networkPath, MaxSizeMB: 10, MaxAge: time.Hour},
+		"actpool":   {},
+	}
+	require.NoError(SetLevels(cfg))
+	Logger("consensus").Debug("consensus debug again")
+	Logger("network").Info("network info again")
+	require.NotContains(readFile(t, consensusPath), "consensus debug again")
+	require.Contains(readFile(t, networkPath), "network info again")
+
+	SetLevel(zap.DebugLevel)
+	Logger("actpool").Debug("actpool debug again")
+	Logger("consensus").Debug("consensus debug again")
+	require.Contains(readFile(t, globalPath), "actpool debug again")
+	require.NotContains(readFile(t, consensusPath), "consensus debug again")
+
+	// the outputs and the set of modules aren't reloadable
+	cfg.Modules["network"] = ModuleConfig{Level: "debug", Output: OutputStderr}
+	require.Error(SetLevels(cfg))
+	delete(cfg.Modules, "network")
+	require.Error(SetLevels(cfg))
+	require.NotContains(readFile(t, networkPath), "debug")
+}
+
+func TestRotatingFile(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "log")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "module.log")
+
+	now := time.Unix(1560000000, 0)
+	f, err := newRotatingFile(path, 10, time.Minute)
+	require.NoError(err)
+	f.now = func() time.Time { return now }
+	f.openedAt = now
+
+	write := func(s string) {
+		_, err := f.Write([]byte(s))
+		require.NoError(err)
+	}
+	write("12345")
+	write("67890")
+	// exceeding the max size
+	now = now.Add(time.Second)
+	write("abc")
+	// exceeding the max age
+	now = now.Add(time.Minute)
+	write("def")
+	require.NoError(f.Sync())
+
+	files, err := filepath.Glob(path + "*")
+	require.NoError(err)
+	require.Len(files, 3)
+	require.Equal("def", readFile(t, path))
+	require.Equal("1234567890", readFile(t, path+"."+time.Unix(1560000001, 0).Format("20060102T150405.000")))
+	require.Equal("abc", readFile(t, path+"."+time.Unix(1560000061, 0).Format("20060102T150405.000")))
+}
+
+// hasLog tells whether the output has a log of the message by the named logger
+func hasLog(output, name, msg string) bool {
+	for _, line := range strings.Split(output, "\n") {
+		if strings.Contains(line, `"logger":"`+name+`"`) && strings.Contains(line, `"msg":"`+msg+`"`) {
+			return true
+		}
+	}
+	return false
+}
+
+func readFile(t *testing.T, path string) string {
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	return string(data)
+}
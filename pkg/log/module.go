@@ -0,0 +1,149 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package log
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/iotexproject/iotex-core/pkg/unit"
+)
+
+const (
+	// OutputStderr sends the logs of a module to stderr
+	OutputStderr = "stderr"
+	// OutputStdout sends the logs of a module to stdout
+	OutputStdout = "stdout"
+)
+
+// ModuleConfig overrides the logger of a module, e.g., consensus, actpool, network, blockchain or api
+type ModuleConfig struct {
+	// Level is the level of the module, which follows the level of the global logger if empty
+	Level string `json:"level" yaml:"level"`
+	// Output is stderr, stdout or the path of the file the logs of the module go to, which are the outputs of the
+	// global logger if empty
+	Output string `json:"output" yaml:"output"`
+	// MaxSize rotates the output file when it grows beyond the size, e.g., 100MB. 0 disables it
+	MaxSize unit.ByteSize `json:"maxSize" yaml:"maxSize"`
+	// MaxSizeMB is the legacy form of MaxSize in megabytes, which applies if MaxSize is 0
+	MaxSizeMB int `json:"maxSizeMB" yaml:"maxSizeMB"`
+	// MaxAge rotates the output file when it has been written for longer than the age. 0 disables it
+	MaxAge time.Duration `json:"maxAge" yaml:"maxAge"`
+}
+
+// moduleLogger is the logger of a module having a ModuleConfig
+type moduleLogger struct {
+	logger *zap.Logger
+	level  zap.AtomicLevel
+	cfg    ModuleConfig
+}
+
+// ParseLevel parses the name of a level, e.g., debug
+func ParseLevel(text string) (zapcore.Level, error) {
+	var level zapcore.Level
+	if err := level.UnmarshalText([]byte(text)); err != nil {
+		return level, err
+	}
+	return level, nil
+}
+
+// RotationSize returns the size in bytes beyond which the output file is rotated, or 0 if it isn't rotated by size
+func (m ModuleConfig) RotationSize() int64 {
+	if m.MaxSize > 0 {
+		return int64(m.MaxSize)
+	}
+	return int64(m.MaxSizeMB) << 20
+}
+
+// moduleLevel returns the level of the module, or def if the module doesn't override it
+func (m ModuleConfig) moduleLevel(def zapcore.Level) (zapcore.Level, error) {
+	if m.Level == "" {
+		return def, nil
+	}
+	return ParseLevel(m.Level)
+}
+
+// buildModuleLoggers builds the loggers of the modules on top of the global zap config
+func buildModuleLoggers(globalCfg GlobalConfig, opts ...zap.Option) (map[string]moduleLogger, error) {
+	loggers := make(map[string]moduleLogger, len(globalCfg.Modules))
+	for name, cfg := range globalCfg.Modules {
+		zapCfg := *globalCfg.Zap
+		level, err := cfg.moduleLevel(zapCfg.Level.Level())
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid log level of module %s", name)
+		}
+		zapCfg.Level = zap.NewAtomicLevelAt(level)
+		logger, err := buildModuleLogger(zapCfg, cfg, opts...)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to build the logger of module %s", name)
+		}
+		loggers[name] = moduleLogger{logger: logger.Named(name), level: zapCfg.Level, cfg: cfg}
+	}
+	return loggers, nil
+}
+
+func buildModuleLogger(zapCfg zap.Config, cfg ModuleConfig, opts ...zap.Option) (*zap.Logger, error) {
+	switch cfg.Output {
+	case "":
+		return zapCfg.Build(opts...)
+	case OutputStderr, OutputStdout:
+		zapCfg.OutputPaths = []string{cfg.Output}
+		return zapCfg.Build(opts...)
+	}
+	file, err := newRotatingFile(cfg.Output, cfg.RotationSize(), cfg.MaxAge)
+	if err != nil {
+		return nil, err
+	}
+	var enc zapcore.Encoder
+	if zapCfg.Encoding == "console" {
+		enc = zapcore.NewConsoleEncoder(zapCfg.EncoderConfig)
+	} else {
+		enc = zapcore.NewJSONEncoder(zapCfg.EncoderConfig)
+	}
+	core := zapcore.NewCore(enc, zapcore.Lock(file), zapCfg.Level)
+	return zapCfg.Build(append(opts, zap.WrapCore(func(zapcore.Core) zapcore.Core { return core }))...)
+}
+
+// SetLevels sets the level of the global logger and the levels of the modules to the ones in cfg. The outputs of the
+// modules and the set of modules having a config couldn't change without rebuilding the loggers.
+func SetLevels(cfg GlobalConfig) error {
+	level := zap.InfoLevel
+	if cfg.Zap != nil {
+		level = cfg.Zap.Level.Level()
+	}
+	_logMu.Lock()
+	defer _logMu.Unlock()
+	if len(cfg.Modules) != len(_moduleLoggers) {
+		return errors.New("cannot add or remove the config of a module without restart")
+	}
+	levels := make(map[string]zapcore.Level, len(cfg.Modules))
+	for name, mcfg := range cfg.Modules {
+		m, ok := _moduleLoggers[name]
+		if !ok {
+			return errors.Errorf("cannot add the config of module %s without restart", name)
+		}
+		if mcfg.Output != m.cfg.Output || mcfg.RotationSize() != m.cfg.RotationSize() || mcfg.MaxAge != m.cfg.MaxAge {
+			return errors.Errorf("cannot change the output of module %s without restart", name)
+		}
+		l, err := mcfg.moduleLevel(level)
+		if err != nil {
+			return errors.Wrapf(err, "invalid log level of module %s", name)
+		}
+		levels[name] = l
+	}
+	_globalCfg.Zap.Level.SetLevel(level)
+	for name, l := range levels {
+		m := _moduleLoggers[name]
+		m.level.SetLevel(l)
+		m.cfg = cfg.Modules[name]
+		_moduleLoggers[name] = m
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package log
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+// rotatingFile is a log file which is rotated when it grows beyond maxSize bytes or has been written for longer than
+// maxAge. The rotated file is renamed after the time of the rotation, e.g., consensus.log.20190601T150405.000.
+type rotatingFile struct {
+	mu       sync.Mutex
+	path     string
+	maxSize  int64
+	maxAge   time.Duration
+	file     *os.File
+	size     int64
+	openedAt time.Time
+	now      func() time.Time
+}
+
+func newRotatingFile(path string, maxSize int64, maxAge time.Duration) (*rotatingFile, error) {
+	f := &rotatingFile{
+		path:    path,
+		maxSize: maxSize,
+		maxAge:  maxAge,
+		now:     time.Now,
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Write writes p into the file, which is rotated first if p would exceed the max size or the file is too old
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.size > 0 && (f.maxSize > 0 && f.size+int64(len(p)) > f.maxSize ||
+		f.maxAge > 0 && f.now().Sub(f.openedAt) >= f.maxAge) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Sync flushes the file
+func (f *rotatingFile) Sync() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Sync()
+}
+
+func (f *rotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file = file
+	f.size = info.Size()
+	f.openedAt = f.now()
+	return nil
+}
+
+func (f *rotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(f.path, f.path+"."+f.now().Format("20060102T150405.000")); err != nil {
+		return err
+	}
+	return f.open()
+}
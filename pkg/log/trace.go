@@ -0,0 +1,109 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package log
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
+// shortHashSize is the number of the leading bytes of a hash logged, which tells the actions or the blocks apart
+const shortHashSize = 8
+
+// The stages of the lifecycle of an action traced, in order
+const (
+	// StageReceived is the action received from a peer by the P2P agent
+	StageReceived = "received"
+	// StageDispatched is the action handed over by the dispatcher to the chain
+	StageDispatched = "dispatched"
+	// StageAdmitted is the action taken into the action pool
+	StageAdmitted = "admitted"
+	// StageRejected is the action rejected by the action pool
+	StageRejected = "rejected"
+	// StagePicked is the action picked from the action pool into a block minted
+	StagePicked = "picked"
+	// StageCommitted is the action committed to the chain in a block
+	StageCommitted = "committed"
+)
+
+// actionTraceThreshold is the threshold of the leading 8 bytes of the hash of an action under which it's traced, which
+// is 0 if no action is traced
+var actionTraceThreshold uint64
+
+// ShortHash creates a zap field of the leading bytes of the hash in hex
+func ShortHash(k string, h hash.Hash256) zap.Field {
+	return zap.String(k, hex.EncodeToString(h[:shortHashSize]))
+}
+
+// ActionFields returns the fields every log line about an action carries, i.e., its short hash and its sender
+func ActionFields(h hash.Hash256, sender string) []zap.Field {
+	return []zap.Field{ShortHash("action", h), SenderField(sender)}
+}
+
+// SenderField returns the field of the sender of an action
+func SenderField(sender string) zap.Field {
+	return zap.String("sender", sender)
+}
+
+// BlockFields returns the fields every log line about a block carries, i.e., its height and its short hash
+func BlockFields(height uint64, h hash.Hash256) []zap.Field {
+	return []zap.Field{zap.Uint64("height", height), ShortHash("block", h)}
+}
+
+// PeerField returns the field of the peer a message is from
+func PeerField(peer string) zap.Field {
+	return zap.String("peer", peer)
+}
+
+// WithAction returns the logger adding the fields of the action to every line it logs
+func WithAction(l *zap.Logger, h hash.Hash256, sender string) *zap.Logger {
+	return l.With(ActionFields(h, sender)...)
+}
+
+// WithBlock returns the logger adding the fields of the block to every line it logs
+func WithBlock(l *zap.Logger, height uint64, h hash.Hash256) *zap.Logger {
+	return l.With(BlockFields(height, h)...)
+}
+
+// SetActionTraceRate makes the fraction of the actions, which is between 0 and 1, traced through the stages of their
+// lifecycle. An action is sampled by its hash, so that all the components and the nodes trace the same actions.
+func SetActionTraceRate(rate float64) {
+	var threshold uint64
+	switch {
+	case rate >= 1:
+		threshold = math.MaxUint64
+	case rate > 0:
+		threshold = uint64(rate * math.MaxUint64)
+	}
+	atomic.StoreUint64(&actionTraceThreshold, threshold)
+}
+
+// ActionTracing tells whether any action is traced, so that the hash of an action is only computed if so
+func ActionTracing() bool {
+	return atomic.LoadUint64(&actionTraceThreshold) > 0
+}
+
+// ActionTraced tells whether the action of the hash is sampled to be traced
+func ActionTraced(h hash.Hash256) bool {
+	threshold := atomic.LoadUint64(&actionTraceThreshold)
+	return threshold == math.MaxUint64 || binary.BigEndian.Uint64(h[:8]) < threshold
+}
+
+// TraceAction logs the stage of the lifecycle of the action, if it's sampled to be traced
+func TraceAction(stage string, h hash.Hash256, fields ...zap.Field) {
+	if !ActionTracing() || !ActionTraced(h) {
+		return
+	}
+	fields = append([]zap.Field{zap.String("stage", stage), ShortHash("action", h)}, fields...)
+	Logger("trace").Info("Action lifecycle.", fields...)
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/iotexproject/iotex-core/pkg/hash"
+)
+
+func TestActionTraced(t *testing.T) {
+	require := require.New(t)
+
+	defer SetActionTraceRate(0)
+	low := hash.Hash256{0x10}
+	high := hash.Hash256{0xf0}
+	require.False(ActionTracing())
+
+	SetActionTraceRate(1)
+	require.True(ActionTracing())
+	require.True(ActionTraced(low))
+	require.True(ActionTraced(high))
+
+	SetActionTraceRate(0.5)
+	require.True(ActionTracing())
+	require.True(ActionTraced(low))
+	require.False(ActionTraced(high))
+
+	SetActionTraceRate(-1)
+	require.False(ActionTracing())
+}
+
+func TestTraceAction(t *testing.T) {
+	require := require.New(t)
+
+	var buf bytes.Buffer
+	core := zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(&buf),
+		zap.DebugLevel)
+	defer zap.ReplaceGlobals(zap.New(core))()
+	defer SetActionTraceRate(0)
+
+	h := hash.Hash256b([]byte("action"))
+	TraceAction(StageReceived, h, PeerField("peer1"))
+	require.Zero(buf.Len())
+
+	SetActionTraceRate(1)
+	TraceAction(StageReceived, h, PeerField("peer1"))
+	TraceAction(StageCommitted, h, BlockFields(3, hash.Hash256b([]byte("block")))...)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(lines, 2)
+	var entries []map[string]interface{}
+	for _, line := range lines {
+		entry := make(map[string]interface{})
+		require.NoError(json.Unmarshal([]byte(line), &entry))
+		entries = append(entries, entry)
+	}
+	short := entries[0]["action"]
+	require.Len(short, 2*shortHashSize)
+	require.Equal(StageReceived, entries[0]["stage"])
+	require.Equal("peer1", entries[0]["peer"])
+	require.Equal(short, entries[1]["action"])
+	require.Equal(StageCommitted, entries[1]["stage"])
+	require.Equal(float64(3), entries[1]["height"])
+	require.Len(entries[1]["block"], 2*shortHashSize)
+}
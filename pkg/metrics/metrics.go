@@ -0,0 +1,132 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// Package metrics holds the process-wide Prometheus registry, which the subsystems register their metrics to when
+// constructed, and which is exposed by the /metrics endpoints in the Prometheus text format. The registry is the
+// Prometheus default one, which has the standard process metrics, e.g., the goroutines, the heap and the GC, unless
+// another one is injected, e.g., by a test.
+package metrics
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/iotexproject/iotex-core/pkg/version"
+)
+
+var buildInfoMtc = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "iotex_build_info",
+		Help: "Build info of the node software, which is always 1.",
+	},
+	[]string{"version", "commit", "go_version", "build_time"},
+)
+
+var (
+	mu         sync.RWMutex
+	registerer = prometheus.DefaultRegisterer
+	gatherer   = prometheus.DefaultGatherer
+)
+
+func init() {
+	buildInfoMtc.WithLabelValues(
+		version.PackageVersion,
+		version.PackageCommitID,
+		version.GoVersion,
+		version.BuildTime,
+	).Set(1)
+	MustRegister(buildInfoMtc)
+}
+
+// NewRegistry creates a registry with the standard process metrics and the build info
+func NewRegistry() *prometheus.Registry {
+	r := prometheus.NewRegistry()
+	r.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		buildInfoMtc,
+	)
+	return r
+}
+
+// SetRegistry makes the registry the process-wide one, and returns the function restoring the previous one. Only the
+// metrics registered afterwards, e.g., by the subsystems constructed afterwards, go to the registry, so it's meant
+// for the tests.
+func SetRegistry(r *prometheus.Registry) (restore func()) {
+	mu.Lock()
+	defer mu.Unlock()
+	prevRegisterer, prevGatherer := registerer, gatherer
+	registerer, gatherer = r, r
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		registerer, gatherer = prevRegisterer, prevGatherer
+	}
+}
+
+// Registerer returns the process-wide registry to register the metrics to
+func Registerer() prometheus.Registerer {
+	mu.RLock()
+	defer mu.RUnlock()
+	return registerer
+}
+
+// Gatherer returns the process-wide registry to gather the metrics from
+func Gatherer() prometheus.Gatherer {
+	mu.RLock()
+	defer mu.RUnlock()
+	return gatherer
+}
+
+// Register registers the collectors to the process-wide registry. A collector registered already is skipped, so that
+// a subsystem constructed more than once, e.g., one for each chain, registers its metrics again without error.
+func Register(cs ...prometheus.Collector) error {
+	r := Registerer()
+	for _, c := range cs {
+		if err := r.Register(c); err != nil {
+			if are, ok := err.(prometheus.AlreadyRegisteredError); ok && are.ExistingCollector == c {
+				continue
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// MustRegister is Register panicking on error, which is a conflict of the metric names
+func MustRegister(cs ...prometheus.Collector) {
+	if err := Register(cs...); err != nil {
+		panic(err)
+	}
+}
+
+// NewCounter creates a counter of the labels, which isn't registered until Register is called
+func NewCounter(name, help string, labels ...string) *prometheus.CounterVec {
+	return prometheus.NewCounterVec(prometheus.CounterOpts{Name: name, Help: help}, labels)
+}
+
+// NewGauge creates a gauge of the labels, which isn't registered until Register is called
+func NewGauge(name, help string, labels ...string) *prometheus.GaugeVec {
+	return prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: name, Help: help}, labels)
+}
+
+// NewHistogram creates a histogram of the buckets and the labels, which isn't registered until Register is called.
+// Nil buckets mean the Prometheus default ones.
+func NewHistogram(name, help string, buckets []float64, labels ...string) *prometheus.HistogramVec {
+	return prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: name, Help: help, Buckets: buckets}, labels)
+}
+
+// Handler serves the metrics of the process-wide registry, which is looked up on each request, in the Prometheus text
+// format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		return Gatherer().Gather()
+	}), promhttp.HandlerOpts{})
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	require := require.New(t)
+
+	r := NewRegistry()
+	restore := SetRegistry(r)
+	require.Equal(r, Registerer())
+	require.Equal(r, Gatherer())
+
+	counter := NewCounter("test_counter", "Test counter.", "label")
+	gauge := NewGauge("test_gauge", "Test gauge.")
+	histogram := NewHistogram("test_histogram", "Test histogram.", nil, "label")
+	require.NoError(Register(counter, gauge, histogram))
+	// registering the same collectors again is skipped, while another one of the same name conflicts
+	require.NoError(Register(counter, gauge, histogram))
+	require.Error(Register(NewCounter("test_counter", "Test counter.", "label")))
+	require.Panics(func() { MustRegister(NewGauge("test_gauge", "Test gauge.")) })
+
+	counter.WithLabelValues("a").Add(2)
+	gauge.WithLabelValues().Set(3)
+	histogram.WithLabelValues("b").Observe(1)
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	for _, s := range []string{
+		`test_counter{label="a"} 2`,
+		"test_gauge 3",
+		`test_histogram_count{label="b"} 1`,
+		"go_goroutines",
+		"go_memstats_heap_alloc_bytes",
+		"go_gc_duration_seconds",
+		"iotex_build_info{",
+	} {
+		require.Contains(body, s)
+	}
+
+	// the handler serves the registry restored
+	restore()
+	require.Equal(prometheus.DefaultRegisterer, Registerer())
+	rec = httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.NotContains(rec.Body.String(), "test_counter")
+	require.Contains(rec.Body.String(), "iotex_build_info{")
+}
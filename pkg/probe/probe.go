@@ -14,9 +14,8 @@ import (
 
 	"go.uber.org/zap"
 
-	"github.com/prometheus/client_golang/prometheus/promhttp"
-
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/metrics"
 	"github.com/iotexproject/iotex-core/pkg/util/httputil"
 )
 
@@ -60,7 +59,7 @@ func New(port int, opts ...Option) *Server {
 
 	mux.HandleFunc("/readiness", readiness)
 	mux.HandleFunc("/health", readiness)
-	mux.Handle("/metrics", promhttp.Handler())
+	mux.Handle("/metrics", metrics.Handler())
 
 	s.server = httputil.Server(fmt.Sprintf(":%d", port), mux)
 	return s
@@ -13,6 +13,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/metrics"
 )
 
 type (
@@ -43,8 +44,12 @@ func New(name, tip string, labelNames []string, defaultLabels []string) (*TimerF
 		},
 		labelNames,
 	)
-	err := prometheus.Register(vect)
-	if _, ok := err.(prometheus.AlreadyRegisteredError); ok {
+	err := metrics.Registerer().Register(vect)
+	if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+		// the timers of the same name, e.g., of the action pools of the chains, share the vector registered
+		if existing, ok := are.ExistingCollector.(*prometheus.GaugeVec); ok {
+			vect = existing
+		}
 		err = nil
 	}
 
@@ -0,0 +1,51 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package recovery
+
+import (
+	"sync"
+	"time"
+)
+
+// PenaltyBox holds the peers whose inputs made a handler panic, whose inputs are refused until the penalty expires
+type PenaltyBox struct {
+	mu       sync.Mutex
+	duration time.Duration
+	expiry   map[string]time.Time
+}
+
+// NewPenaltyBox creates a penalty box keeping a peer for duration. A non-positive duration never keeps any peer.
+func NewPenaltyBox(duration time.Duration) *PenaltyBox {
+	return &PenaltyBox{
+		duration: duration,
+		expiry:   make(map[string]time.Time),
+	}
+}
+
+// Penalize keeps the peer in the box for the penalty duration from now
+func (b *PenaltyBox) Penalize(peer string) {
+	if b.duration <= 0 || peer == "" {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	for p, expiry := range b.expiry {
+		if !now.Before(expiry) {
+			delete(b.expiry, p)
+		}
+	}
+	b.expiry[peer] = now.Add(b.duration)
+}
+
+// Penalized tells whether the peer is in the box
+func (b *PenaltyBox) Penalized(peer string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	expiry, ok := b.expiry[peer]
+	return ok && time.Now().Before(expiry)
+}
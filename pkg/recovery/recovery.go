@@ -0,0 +1,130 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// Package recovery isolates the panics of the handlers of the inputs from the others, e.g., the P2P messages and the
+// API requests, so that a malformed input tripping a bug in one handler doesn't take down the whole node.
+package recovery
+
+import (
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/metrics"
+)
+
+// maxDumpSize is the max number of the payload bytes logged with a panic
+const maxDumpSize = 256
+
+var panicMtc = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "iotex_handler_panics",
+		Help: "Number of the panics recovered in each handler.",
+	},
+	[]string{"handler"},
+)
+
+func init() {
+	metrics.MustRegister(panicMtc)
+}
+
+var (
+	mu     sync.Mutex
+	panics = make(map[string]uint64)
+	// recent is the time of the panics within the last minute
+	recent     []time.Time
+	threshold  uint
+	shutdown   func()
+	escalating bool
+)
+
+// SetEscalation makes the node shut down by calling shutdown once, if there are more than threshold panics within a
+// minute, which likely means the node is broken rather than fed a malformed input. 0 threshold never shuts down.
+func SetEscalation(maxPanicsPerMinute uint, shutdownFunc func()) {
+	mu.Lock()
+	defer mu.Unlock()
+	threshold = maxPanicsPerMinute
+	shutdown = shutdownFunc
+	escalating = false
+	recent = nil
+}
+
+// Handle calls handle, and recovers it if it panics. The panic is logged with the handler name, the fields, e.g.,
+// the peer the input is from, and the hex dump of the payload truncated, and is counted for the handler. It returns
+// true if handle panics.
+func Handle(handler string, payload proto.Message, handle func(), fields ...zap.Field) (panicked bool) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		panicked = true
+		fields = append(fields,
+			zap.String("handler", handler),
+			zap.Any("panic", r),
+			zap.Stack("stack"),
+		)
+		fields = append(fields, dump(payload)...)
+		log.L().Error("Recovered from a panic of the handler.", fields...)
+		panicMtc.WithLabelValues(handler).Inc()
+		count(handler, time.Now())
+	}()
+	handle()
+	return
+}
+
+// Panics returns the number of the panics recovered in the handler
+func Panics(handler string) uint64 {
+	mu.Lock()
+	defer mu.Unlock()
+	return panics[handler]
+}
+
+func count(handler string, now time.Time) {
+	mu.Lock()
+	panics[handler]++
+	if threshold == 0 || escalating {
+		mu.Unlock()
+		return
+	}
+	cutoff := now.Add(-time.Minute)
+	for len(recent) > 0 && !recent[0].After(cutoff) {
+		recent = recent[1:]
+	}
+	recent = append(recent, now)
+	escalate := uint(len(recent)) > threshold
+	if escalate {
+		escalating = true
+	}
+	f := shutdown
+	mu.Unlock()
+
+	if escalate && f != nil {
+		log.L().Error("Too many panics within a minute, shutting down the node.",
+			zap.Uint("maxPanicsPerMinute", threshold))
+		f()
+	}
+}
+
+func dump(payload proto.Message) []zap.Field {
+	if payload == nil {
+		return nil
+	}
+	data, err := proto.Marshal(payload)
+	if err != nil {
+		return []zap.Field{zap.NamedError("payloadError", err)}
+	}
+	fields := []zap.Field{zap.Int("payloadSize", len(data))}
+	if len(data) > maxDumpSize {
+		data = data[:maxDumpSize]
+	}
+	return append(fields, zap.String("payload", hex.EncodeToString(data)))
+}
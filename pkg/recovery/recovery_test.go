@@ -0,0 +1,73 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package recovery
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/protogen/iotextypes"
+)
+
+func TestHandle(t *testing.T) {
+	require := require.New(t)
+
+	called := false
+	require.False(Handle("test.ok", nil, func() { called = true }))
+	require.True(called)
+	require.Equal(uint64(0), Panics("test.ok"))
+
+	payload := &iotextypes.Action{Core: &iotextypes.ActionCore{Nonce: 1, GasLimit: 10}}
+	require.True(Handle("test.panic", payload, func() {
+		var s []int
+		_ = s[1]
+	}))
+	require.True(Handle("test.panic", nil, func() { panic("boom") }))
+	require.Equal(uint64(2), Panics("test.panic"))
+}
+
+func TestEscalation(t *testing.T) {
+	require := require.New(t)
+
+	shutdowns := 0
+	SetEscalation(2, func() { shutdowns++ })
+	defer SetEscalation(0, nil)
+	for i := 0; i < 2; i++ {
+		Handle("test.escalation", nil, func() { panic("boom") })
+	}
+	require.Equal(0, shutdowns)
+	// the third panic within a minute shuts down the node, only once
+	Handle("test.escalation", nil, func() { panic("boom") })
+	Handle("test.escalation", nil, func() { panic("boom") })
+	require.Equal(1, shutdowns)
+
+	// the panics more than a minute ago aren't counted
+	SetEscalation(1, func() { shutdowns++ })
+	now := time.Now()
+	count("test.escalation", now.Add(-2*time.Minute))
+	count("test.escalation", now)
+	require.Equal(1, shutdowns)
+	count("test.escalation", now)
+	require.Equal(2, shutdowns)
+}
+
+func TestPenaltyBox(t *testing.T) {
+	require := require.New(t)
+
+	box := NewPenaltyBox(50 * time.Millisecond)
+	box.Penalize("peer1")
+	require.True(box.Penalized("peer1"))
+	require.False(box.Penalized("peer2"))
+	time.Sleep(60 * time.Millisecond)
+	require.False(box.Penalized("peer1"))
+
+	box = NewPenaltyBox(0)
+	box.Penalize("peer1")
+	require.False(box.Penalized("peer1"))
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package remotesigner
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/protogen/iotexsigner"
+)
+
+// unixPrefix is the prefix of an endpoint listening on a unix domain socket
+const unixPrefix = "unix://"
+
+// ErrSignature indicates the remote signer returns a signature not matching its public key
+var ErrSignature = errors.New("invalid signature from remote signer")
+
+// Signer is a keypair.Signer which proxies signing to a signing service over gRPC, e.g., a bridge to a hardware
+// wallet or HSM, so that the private key never gets loaded into the node
+type Signer struct {
+	conn    *grpc.ClientConn
+	client  iotexsigner.SignerClient
+	pubKey  keypair.PublicKey
+	timeout time.Duration
+}
+
+// NewSigner connects to the signing service at endpoint, which is either a tcp address of host:port or a unix domain
+// socket of unix:///path, and fetches the public key of the signing key. Every call to the service times out after
+// timeout
+func NewSigner(endpoint string, timeout time.Duration) (*Signer, error) {
+	network, addr := "tcp", endpoint
+	if strings.HasPrefix(endpoint, unixPrefix) {
+		network, addr = "unix", strings.TrimPrefix(endpoint, unixPrefix)
+	}
+	conn, err := grpc.Dial(
+		addr,
+		grpc.WithInsecure(),
+		grpc.WithDialer(func(target string, t time.Duration) (net.Conn, error) {
+			return net.DialTimeout(network, target, t)
+		}),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect to remote signer %s", endpoint)
+	}
+	s := &Signer{
+		conn:    conn,
+		client:  iotexsigner.NewSignerClient(conn),
+		timeout: timeout,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	res, err := s.client.GetPublicKey(ctx, &iotexsigner.GetPublicKeyRequest{})
+	if err != nil {
+		conn.Close()
+		return nil, errors.Wrapf(err, "failed to get public key from remote signer %s", endpoint)
+	}
+	if s.pubKey, err = keypair.BytesToPublicKey(res.PublicKey); err != nil {
+		conn.Close()
+		return nil, errors.Wrapf(err, "invalid public key from remote signer %s", endpoint)
+	}
+	return s, nil
+}
+
+// PublicKey returns the public key of the signing key
+func (s *Signer) PublicKey() keypair.PublicKey { return s.pubKey }
+
+// Sign asks the signing service to sign the digest, and verifies the returned signature against the public key
+func (s *Signer) Sign(digest []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	res, err := s.client.Sign(ctx, &iotexsigner.SignRequest{Digest: digest})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign with remote signer")
+	}
+	if !s.pubKey.Verify(digest, res.Signature) {
+		return nil, errors.Wrapf(ErrSignature, "signature %x of digest %x", res.Signature, digest)
+	}
+	return res.Signature, nil
+}
+
+// Close closes the connection to the signing service
+func (s *Signer) Close() error { return s.conn.Close() }
@@ -0,0 +1,129 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package remotesigner
+
+import (
+	"context"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/protogen/iotexsigner"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+// slowServer signs after a delay
+type slowServer struct {
+	*Server
+	delay time.Duration
+}
+
+func (s *slowServer) Sign(ctx context.Context, in *iotexsigner.SignRequest) (*iotexsigner.SignResponse, error) {
+	time.Sleep(s.delay)
+	return s.Server.Sign(ctx, in)
+}
+
+// wrongKeyServer signs with a key other than the one it advertises
+type wrongKeyServer struct {
+	*Server
+	sk keypair.PrivateKey
+}
+
+func (s *wrongKeyServer) Sign(ctx context.Context, in *iotexsigner.SignRequest) (*iotexsigner.SignResponse, error) {
+	sig, err := s.sk.Sign(in.Digest)
+	return &iotexsigner.SignResponse{Signature: sig}, err
+}
+
+func startServer(t *testing.T, srv iotexsigner.SignerServer) (string, func()) {
+	dir, err := ioutil.TempDir(os.TempDir(), "remotesigner")
+	require.NoError(t, err)
+	sock := filepath.Join(dir, "signer.sock")
+	lis, err := net.Listen("unix", sock)
+	require.NoError(t, err)
+	s := grpc.NewServer()
+	iotexsigner.RegisterSignerServer(s, srv)
+	go s.Serve(lis)
+	return unixPrefix + sock, func() {
+		s.Stop()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestSigner(t *testing.T) {
+	require := require.New(t)
+
+	sk := identityset.PrivateKey(0)
+	endpoint, stop := startServer(t, NewServer(sk))
+	defer stop()
+
+	signer, err := NewSigner(endpoint, time.Second)
+	require.NoError(err)
+	defer signer.Close()
+	require.Equal(sk.PublicKey().Bytes(), signer.PublicKey().Bytes())
+
+	// sign a transfer through the proxy and verify it normally
+	tsf, err := action.NewTransfer(1, big.NewInt(10), identityset.Address(1).String(), nil, 10000, big.NewInt(1))
+	require.NoError(err)
+	bd := &action.EnvelopeBuilder{}
+	elp := bd.SetNonce(1).SetGasLimit(10000).SetGasPrice(big.NewInt(1)).SetAction(tsf).Build()
+	selp, err := action.Sign(elp, signer)
+	require.NoError(err)
+	require.NoError(action.Verify(selp))
+	local, err := action.Sign(elp, sk)
+	require.NoError(err)
+	require.Equal(local.Signature(), selp.Signature())
+
+	// errors of the service are propagated
+	_, err = signer.Sign(nil)
+	require.Equal(codes.InvalidArgument, status.Code(errors.Cause(err)))
+}
+
+func TestSigner_Timeout(t *testing.T) {
+	require := require.New(t)
+
+	endpoint, stop := startServer(t, &slowServer{NewServer(identityset.PrivateKey(0)), time.Second})
+	defer stop()
+
+	signer, err := NewSigner(endpoint, 100*time.Millisecond)
+	require.NoError(err)
+	defer signer.Close()
+	h := hash.Hash256b([]byte("digest"))
+	_, err = signer.Sign(h[:])
+	require.Equal(codes.DeadlineExceeded, status.Code(errors.Cause(err)))
+}
+
+func TestSigner_InvalidSignature(t *testing.T) {
+	require := require.New(t)
+
+	endpoint, stop := startServer(t, &wrongKeyServer{NewServer(identityset.PrivateKey(0)), identityset.PrivateKey(1)})
+	defer stop()
+
+	signer, err := NewSigner(endpoint, time.Second)
+	require.NoError(err)
+	defer signer.Close()
+	h := hash.Hash256b([]byte("digest"))
+	_, err = signer.Sign(h[:])
+	require.Equal(ErrSignature, errors.Cause(err))
+}
+
+func TestNewSigner_Unreachable(t *testing.T) {
+	_, err := NewSigner(unixPrefix+filepath.Join(os.TempDir(), "nonexistent.sock"), 100*time.Millisecond)
+	require.Error(t, err)
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package remotesigner
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/protogen/iotexsigner"
+)
+
+// Server serves the signing service with a keypair.Signer, which is the other end of Signer that a hardware wallet
+// or HSM bridge implements
+type Server struct {
+	signer keypair.Signer
+}
+
+// NewServer creates a signing service with the signer
+func NewServer(signer keypair.Signer) *Server {
+	return &Server{signer: signer}
+}
+
+// GetPublicKey returns the public key of the signing key
+func (s *Server) GetPublicKey(
+	ctx context.Context,
+	in *iotexsigner.GetPublicKeyRequest,
+) (*iotexsigner.GetPublicKeyResponse, error) {
+	return &iotexsigner.GetPublicKeyResponse{PublicKey: s.signer.PublicKey().Bytes()}, nil
+}
+
+// Sign signs the digest with the signing key
+func (s *Server) Sign(ctx context.Context, in *iotexsigner.SignRequest) (*iotexsigner.SignResponse, error) {
+	if len(in.Digest) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty digest")
+	}
+	sig, err := s.signer.Sign(in.Digest)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &iotexsigner.SignResponse{Signature: sig}, nil
+}
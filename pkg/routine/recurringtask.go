@@ -8,6 +8,7 @@ package routine
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/facebookgo/clock"
@@ -25,9 +26,11 @@ type RecurringTaskOption interface {
 // RecurringTask represents a recurring task
 type RecurringTask struct {
 	t        Task
+	mu       sync.Mutex
 	interval time.Duration
 	ticker   *clock.Ticker
 	ch       chan interface{}
+	reset    chan struct{}
 	clock    clock.Clock
 }
 
@@ -37,6 +40,7 @@ func NewRecurringTask(t Task, i time.Duration, ops ...RecurringTaskOption) *Recu
 		t:        t,
 		interval: i,
 		ch:       make(chan interface{}, 1),
+		reset:    make(chan struct{}, 1),
 		clock:    clock.New(),
 	}
 	for _, opt := range ops {
@@ -47,16 +51,22 @@ func NewRecurringTask(t Task, i time.Duration, ops ...RecurringTaskOption) *Recu
 
 // Start starts the timer
 func (t *RecurringTask) Start(ctx context.Context) error {
+	t.mu.Lock()
 	t.ticker = t.clock.Ticker(t.interval)
+	t.mu.Unlock()
 	ready := make(chan struct{})
 	go func() {
 		close(ready)
 		for {
+			t.mu.Lock()
+			tick := t.ticker.C
+			t.mu.Unlock()
 			select {
 			// TODO (soy) we can not cancel on ctx.Done, seems there is something cause context timeout of recurring task unexpected
 			case <-t.ch:
 				return
-			case <-t.ticker.C:
+			case <-t.reset:
+			case <-tick:
 				t.t()
 			}
 		}
@@ -69,9 +79,28 @@ func (t *RecurringTask) Start(ctx context.Context) error {
 // Stop stops the timer
 func (t *RecurringTask) Stop(_ context.Context) error {
 	// TODO: actually this happens when stop is called before init/start. We should prevent this from happening
+	t.mu.Lock()
 	if t.ticker != nil {
 		t.ticker.Stop()
 	}
+	t.mu.Unlock()
 	t.ch <- struct{}{}
 	return nil
 }
+
+// SetInterval changes the interval of the task. If the task is running, the next run happens the new interval later.
+func (t *RecurringTask) SetInterval(i time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.interval = i
+	if t.ticker == nil {
+		return
+	}
+	t.ticker.Stop()
+	t.ticker = t.clock.Ticker(i)
+	select {
+	case t.reset <- struct{}{}:
+	default:
+	}
+}
@@ -41,3 +41,21 @@ func TestRecurringTask(t *testing.T) {
 	assert.True(t, h.Count >= 5)
 	h.mu.RUnlock()
 }
+
+func TestRecurringTask_SetInterval(t *testing.T) {
+	h := &MockHandler{Count: 0}
+	ctx := context.Background()
+	ck := clock.NewMock()
+	task := routine.NewRecurringTask(h.Do, 100*time.Millisecond, routine.WithClock(ck))
+	task.Start(ctx)
+	task.SetInterval(time.Second)
+	ck.Add(600 * time.Millisecond)
+	h.mu.RLock()
+	assert.Equal(t, uint(0), h.Count)
+	h.mu.RUnlock()
+	ck.Add(600 * time.Millisecond)
+	task.Stop(ctx)
+	h.mu.RLock()
+	assert.Equal(t, uint(1), h.Count)
+	h.mu.RUnlock()
+}
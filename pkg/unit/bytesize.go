@@ -0,0 +1,102 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package unit
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// Byte is the smallest size unit
+	Byte ByteSize = 1
+	// KB is 1024 Byte
+	KB = Byte << 10
+	// MB is 1024 KB
+	MB = KB << 10
+	// GB is 1024 MB
+	GB = MB << 10
+	// TB is 1024 GB
+	TB = GB << 10
+)
+
+// ByteSize is a size in bytes. It is given in the config files as a number followed by the unit, e.g., 512B, 1KB, 4MB
+// or 2GB, where the units are powers of 1024. A plain number is taken as bytes for backward compatibility.
+type ByteSize uint64
+
+var byteSizeUnits = []struct {
+	name string
+	size ByteSize
+}{
+	{"TB", TB},
+	{"GB", GB},
+	{"MB", MB},
+	{"KB", KB},
+	{"B", Byte},
+}
+
+// ParseByteSize parses a size like 4MB, which is case-insensitive and may have a space before the unit
+func ParseByteSize(s string) (ByteSize, error) {
+	text := strings.ToUpper(strings.TrimSpace(s))
+	size := Byte
+	for _, u := range byteSizeUnits {
+		if strings.HasSuffix(text, u.name) {
+			text = strings.TrimSpace(strings.TrimSuffix(text, u.name))
+			size = u.size
+			break
+		}
+	}
+	n, err := strconv.ParseUint(text, 10, 64)
+	if err != nil {
+		return 0, errors.Errorf("invalid size %q, should be a number followed by B, KB, MB, GB or TB", s)
+	}
+	if n > uint64(^ByteSize(0)/size) {
+		return 0, errors.Errorf("size %q overflows", s)
+	}
+	return ByteSize(n) * size, nil
+}
+
+// String returns the size in the largest unit dividing it, e.g., 4MB
+func (b ByteSize) String() string {
+	for _, u := range byteSizeUnits {
+		if b >= u.size && b%u.size == 0 {
+			return strconv.FormatUint(uint64(b/u.size), 10) + u.name
+		}
+	}
+	return "0B"
+}
+
+// MarshalText encodes the size in the form ParseByteSize reads
+func (b ByteSize) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText decodes a size like 4MB or a plain number of bytes
+func (b *ByteSize) UnmarshalText(text []byte) error {
+	size, err := ParseByteSize(string(text))
+	if err != nil {
+		return err
+	}
+	*b = size
+	return nil
+}
+
+// UnmarshalYAML decodes a size like 4MB or a plain number of bytes from YAML
+func (b *ByteSize) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var n uint64
+	if err := unmarshal(&n); err == nil {
+		*b = ByteSize(n)
+		return nil
+	}
+	var text string
+	if err := unmarshal(&text); err != nil {
+		return err
+	}
+	return b.UnmarshalText([]byte(text))
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package unit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func TestParseByteSize(t *testing.T) {
+	require := require.New(t)
+
+	for _, c := range []struct {
+		text string
+		size ByteSize
+	}{
+		{"0", 0},
+		{"512", 512},
+		{"512B", 512},
+		{"1KB", KB},
+		{"4MB", 4 * MB},
+		{"4 mb", 4 * MB},
+		{"2GB", 2 * GB},
+		{"1TB", TB},
+	} {
+		size, err := ParseByteSize(c.text)
+		require.NoError(err, c.text)
+		require.Equal(c.size, size, c.text)
+	}
+	for _, text := range []string{"", "MB", "-1KB", "1.5MB", "4XB", "20000000TB"} {
+		_, err := ParseByteSize(text)
+		require.Error(err, text)
+	}
+
+	require.Equal("0B", ByteSize(0).String())
+	require.Equal("1000B", ByteSize(1000).String())
+	require.Equal("4MB", (4 * MB).String())
+	require.Equal("1536KB", (MB + 512*KB).String())
+}
+
+func TestByteSizeYAML(t *testing.T) {
+	require := require.New(t)
+
+	var cfg struct {
+		Legacy ByteSize `yaml:"legacy"`
+		Text   ByteSize `yaml:"text"`
+	}
+	require.NoError(yaml.Unmarshal([]byte("legacy: 1024\ntext: 4MB\n"), &cfg))
+	require.Equal(KB, cfg.Legacy)
+	require.Equal(4*MB, cfg.Text)
+
+	out, err := yaml.Marshal(cfg)
+	require.NoError(err)
+	require.Equal("legacy: 1KB\ntext: 4MB\n", string(out))
+
+	err = yaml.Unmarshal([]byte("text: 4XB\n"), &cfg)
+	require.Error(err)
+	require.Contains(err.Error(), `invalid size "4XB"`)
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package addrutil
+
+import (
+	"strings"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-address/address/bech32"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+const (
+	// payloadLength is the byte length of an address payload
+	payloadLength = 20
+	// dataLength is the number of bech32 characters after the separator, i.e., 32 for the payload and 6 for the
+	// checksum
+	dataLength = 38
+	// charset is the bech32 character set
+	charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+)
+
+var (
+	// ErrLength indicates the address or its payload has a wrong length
+	ErrLength = errors.New("invalid address length")
+	// ErrFormat indicates the address is not a well-formed bech32 string
+	ErrFormat = errors.New("invalid address format")
+	// ErrNetwork indicates the address prefix belongs to another network
+	ErrNetwork = errors.New("address of another network")
+	// ErrChecksum indicates the address checksum does not match
+	ErrChecksum = errors.New("address checksum mismatch")
+)
+
+// Validate checks an encoded address string and returns a specific error of the first rule it breaks. Rules are
+// checked in the order of format, network prefix, length and checksum, so that the error points at the most obvious
+// mistake. There is no version byte in an address so far, so the version is implied by the payload length
+func Validate(raw string) error {
+	if raw == "" {
+		return errors.Wrap(ErrLength, "empty address")
+	}
+	lower := strings.ToLower(raw)
+	if lower != raw && strings.ToUpper(raw) != raw {
+		return errors.Wrapf(ErrFormat, "mixed case in address %s", raw)
+	}
+	sep := strings.LastIndexByte(lower, '1')
+	if sep < 0 {
+		return errors.Wrapf(ErrFormat, "missing separator in address %s", raw)
+	}
+	if hrp := lower[:sep]; hrp != prefix() {
+		return errors.Wrapf(ErrNetwork, "address prefix %q doesn't match %q", hrp, prefix())
+	}
+	data := lower[sep+1:]
+	for i := range data {
+		if strings.IndexByte(charset, data[i]) < 0 {
+			return errors.Wrapf(ErrFormat, "invalid character %q at position %d", raw[sep+1+i], sep+1+i)
+		}
+	}
+	if len(data) != dataLength {
+		return errors.Wrapf(ErrLength, "address has %d characters after prefix, expect %d", len(data), dataLength)
+	}
+	_, grouped, err := bech32.Decode(lower)
+	if err != nil {
+		return errors.Wrap(ErrChecksum, err.Error())
+	}
+	payload, err := bech32.ConvertBits(grouped, 5, 8, false)
+	if err != nil {
+		return errors.Wrap(ErrFormat, err.Error())
+	}
+	if len(payload) != payloadLength {
+		return errors.Wrapf(ErrLength, "address payload has %d bytes, expect %d", len(payload), payloadLength)
+	}
+	return nil
+}
+
+// IsAddressError tells whether the error is caused by an invalid address, either found by Validate or by decoding
+func IsAddressError(err error) bool {
+	switch errors.Cause(err) {
+	case ErrLength, ErrFormat, ErrNetwork, ErrChecksum, address.ErrInvalidAddr:
+		return true
+	}
+	return false
+}
+
+// MustBeValid panics if the encoded address string is invalid. It is meant for addresses coming from config, which
+// cannot be corrected at runtime
+func MustBeValid(raw string) {
+	if err := Validate(raw); err != nil {
+		log.L().Panic("Invalid address", zap.String("address", raw), zap.Error(err))
+	}
+}
+
+// prefix returns the address prefix of the network the node runs on
+func prefix() string {
+	zero, err := address.FromBytes(make([]byte, payloadLength))
+	if err != nil {
+		log.L().Panic("Error when constructing zero address", zap.Error(err))
+	}
+	return zero.String()[:strings.LastIndexByte(zero.String(), '1')]
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package addrutil
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-address/address/bech32"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func encode(t *testing.T, hrp string, payload []byte) string {
+	grouped, err := bech32.ConvertBits(payload, 8, 5, true)
+	require.NoError(t, err)
+	encoded, err := bech32.Encode(hrp, grouped)
+	require.NoError(t, err)
+	return encoded
+}
+
+func TestValidate(t *testing.T) {
+	require := require.New(t)
+
+	valid := identityset.Address(0).String()
+	require.NoError(Validate(valid))
+	require.NoError(Validate(strings.ToUpper(valid)))
+	// the last character of the payload part, to be replaced
+	last := valid[len(valid)-1:]
+	other := "q"
+	if last == other {
+		other = "p"
+	}
+
+	otherNetwork := address.TestnetPrefix
+	if prefix() == address.TestnetPrefix {
+		otherNetwork = address.MainnetPrefix
+	}
+
+	tests := []struct {
+		name string
+		raw  string
+		err  error
+	}{
+		{"empty", "", ErrLength},
+		{"prefix only", "io", ErrFormat},
+		{"separator only", prefix() + "1", ErrLength},
+		{"one char missing", valid[:len(valid)-1], ErrLength},
+		{"one char extra", valid + "q", ErrLength},
+		{"too long", valid + valid[3:], ErrLength},
+		{"19-byte payload", encode(t, prefix(), make([]byte, 19)), ErrLength},
+		{"21-byte payload", encode(t, prefix(), make([]byte, 21)), ErrLength},
+		{"32-byte payload", encode(t, prefix(), make([]byte, 32)), ErrLength},
+		{"altered last char", valid[:len(valid)-1] + other, ErrChecksum},
+		{"altered payload", valid[:10] + string(charset[(strings.IndexByte(charset, valid[10])+1)%32]) + valid[11:], ErrChecksum},
+		{"all zero", prefix() + "1" + strings.Repeat("q", dataLength), ErrChecksum},
+		{"mainnet/testnet swapped", otherNetwork + valid[2:], ErrNetwork},
+		{"ethereum prefix", "eth" + valid[2:], ErrNetwork},
+		{"valid encoding of another network", encode(t, "xx", make([]byte, 20)), ErrNetwork},
+		{"leading space", " " + valid, ErrNetwork},
+		{"mixed case", strings.ToUpper(valid[:5]) + valid[5:], ErrFormat},
+		{"character b", valid[:len(valid)-1] + "b", ErrFormat},
+		{"character i", valid[:len(valid)-1] + "i", ErrFormat},
+		{"trailing space", valid + " ", ErrFormat},
+	}
+	for _, test := range tests {
+		err := Validate(test.raw)
+		require.Equal(test.err, errors.Cause(err), test.name)
+	}
+
+	require.NotPanics(func() { MustBeValid(valid) })
+	require.Panics(func() { MustBeValid(valid[:len(valid)-1]) })
+}
+
+func TestValidate_Fuzz(t *testing.T) {
+	require := require.New(t)
+
+	valid := identityset.Address(0).String()
+	// every single bit flip is caught
+	for i := 0; i < len(valid); i++ {
+		for bit := uint(0); bit < 8; bit++ {
+			b := []byte(valid)
+			b[i] ^= 1 << bit
+			err := Validate(string(b))
+			require.Error(err, "flipping bit %d of byte %d", bit, i)
+			require.True(IsAddressError(err))
+		}
+	}
+	// arbitrary input never panics, and is either valid or rejected with an address error
+	r := rand.New(rand.NewSource(0))
+	for i := 0; i < 10000; i++ {
+		b := make([]byte, r.Intn(2*len(valid)))
+		r.Read(b)
+		if r.Intn(2) == 0 && len(b) > 3 {
+			// keep the prefix and separator to get past the early checks
+			copy(b, prefix()+"1")
+		}
+		require.NotPanics(func() {
+			if err := Validate(string(b)); err != nil {
+				require.True(IsAddressError(err))
+			}
+		})
+	}
+	require.False(IsAddressError(nil))
+	require.False(IsAddressError(errors.New("other")))
+	_, err := address.FromBytes(make([]byte, 19))
+	require.True(IsAddressError(err))
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package addrutil
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+)
+
+// MessagePrefix is prepended to a message before signing, so that a message signature never collides with the
+// signature of an action
+const MessagePrefix = "IoTeX Signed Message:\n"
+
+// signatureLength is the length of a signature in [R || S || V] format
+const signatureLength = 65
+
+// MessageHash returns the hash to sign for a message, which is the hash of the prefix, the decimal length of the
+// message and the message itself
+func MessageHash(msg []byte) hash.Hash256 {
+	var b bytes.Buffer
+	b.WriteString(MessagePrefix)
+	b.WriteString(strconv.Itoa(len(msg)))
+	b.Write(msg)
+	return hash.Hash256b(b.Bytes())
+}
+
+// SignMessage signs a message with the private key to prove the ownership of its address
+func SignMessage(sk keypair.PrivateKey, msg []byte) ([]byte, error) {
+	h := MessageHash(msg)
+	return sk.Sign(h[:])
+}
+
+// VerifyMessage verifies the message signature against an encoded address, by recovering the public key from the
+// signature and comparing its address
+func VerifyMessage(rawAddr string, msg, sig []byte) (bool, error) {
+	if err := Validate(rawAddr); err != nil {
+		return false, err
+	}
+	if len(sig) != signatureLength {
+		return false, errors.Errorf("invalid signature length %d, expect %d", len(sig), signatureLength)
+	}
+	h := MessageHash(msg)
+	pk, err := crypto.SigToPub(h[:], sig)
+	if err != nil {
+		return false, nil
+	}
+	pubKey, err := keypair.BytesToPublicKey(crypto.FromECDSAPub(pk))
+	if err != nil {
+		return false, nil
+	}
+	if !pubKey.Verify(h[:], sig) {
+		return false, nil
+	}
+	addr, err := PubKeyToAddress(pubKey)
+	if err != nil {
+		return false, err
+	}
+	expected, err := address.FromString(rawAddr)
+	if err != nil {
+		return false, err
+	}
+	return address.Equal(addr, expected), nil
+}
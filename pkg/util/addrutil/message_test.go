@@ -0,0 +1,79 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package addrutil_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/action"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
+	"github.com/iotexproject/iotex-core/test/identityset"
+)
+
+func TestSignAndVerifyMessage(t *testing.T) {
+	require := require.New(t)
+
+	sk := identityset.PrivateKey(0)
+	addr := identityset.Address(0).String()
+	msg := []byte("challenge 12345")
+	sig, err := addrutil.SignMessage(sk, msg)
+	require.NoError(err)
+
+	ok, err := addrutil.VerifyMessage(addr, msg, sig)
+	require.NoError(err)
+	require.True(ok)
+	ok, err = addrutil.VerifyMessage(strings.ToUpper(addr), msg, sig)
+	require.NoError(err)
+	require.True(ok)
+
+	// tampered message, another address or a malformed signature don't verify
+	ok, err = addrutil.VerifyMessage(addr, []byte("challenge 12346"), sig)
+	require.NoError(err)
+	require.False(ok)
+	ok, err = addrutil.VerifyMessage(identityset.Address(1).String(), msg, sig)
+	require.NoError(err)
+	require.False(ok)
+	_, err = addrutil.VerifyMessage(addr, msg, sig[1:])
+	require.Error(err)
+	_, err = addrutil.VerifyMessage(addr[1:], msg, sig)
+	require.Error(err)
+}
+
+func TestMessageAndActionSignature(t *testing.T) {
+	require := require.New(t)
+
+	sk := identityset.PrivateKey(0)
+	addr := identityset.Address(0).String()
+	tsf, err := action.NewTransfer(1, big.NewInt(10), identityset.Address(1).String(), nil, 100000, big.NewInt(0))
+	require.NoError(err)
+	bd := &action.EnvelopeBuilder{}
+	elp := bd.SetNonce(1).SetGasLimit(100000).SetAction(tsf).Build()
+	selp, err := action.Sign(elp, sk)
+	require.NoError(err)
+	require.NoError(action.Verify(selp))
+
+	// a transfer signature does not verify as a signature of the transfer bytes
+	ok, err := addrutil.VerifyMessage(addr, elp.ByteStream(), selp.Signature())
+	require.NoError(err)
+	require.False(ok)
+	h := elp.Hash()
+	ok, err = addrutil.VerifyMessage(addr, h[:], selp.Signature())
+	require.NoError(err)
+	require.False(ok)
+
+	// a message signature of the transfer bytes does not verify as a transfer signature
+	sig, err := addrutil.SignMessage(sk, elp.ByteStream())
+	require.NoError(err)
+	ok, err = addrutil.VerifyMessage(addr, elp.ByteStream(), sig)
+	require.NoError(err)
+	require.True(ok)
+	require.False(sk.PublicKey().Verify(h[:], sig))
+}
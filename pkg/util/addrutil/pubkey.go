@@ -0,0 +1,62 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package addrutil
+
+import (
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+)
+
+// HashPubKey returns the 20-byte public key hash, which is the payload of an address, of a serialized public key. The
+// public key must be an uncompressed secp256k1 point on the curve.
+func HashPubKey(pub []byte) ([]byte, error) {
+	pk, err := keypair.BytesToPublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+	return pk.Hash(), nil
+}
+
+// AddressFromPubKey returns the encoded address of a serialized public key
+func AddressFromPubKey(pub []byte) (string, error) {
+	pk, err := keypair.BytesToPublicKey(pub)
+	if err != nil {
+		return "", err
+	}
+	addr, err := PubKeyToAddress(pk)
+	if err != nil {
+		return "", err
+	}
+	return addr.String(), nil
+}
+
+// PubKeyHashFromAddress returns the public key hash an encoded address carries
+func PubKeyHashFromAddress(raw string) ([]byte, error) {
+	if err := Validate(raw); err != nil {
+		return nil, err
+	}
+	addr, err := address.FromString(raw)
+	if err != nil {
+		return nil, err
+	}
+	return addr.Bytes(), nil
+}
+
+// PubKeyToAddress returns the address of a public key. It is the canonical derivation every component should use to
+// tell the address of an action sender, a block producer or a voter.
+func PubKeyToAddress(pk keypair.PublicKey) (address.Address, error) {
+	if pk == nil {
+		return nil, errors.Wrap(keypair.ErrPublicKey, "nil public key")
+	}
+	addr, err := address.FromBytes(pk.Hash())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to derive the address of the public key")
+	}
+	return addr, nil
+}
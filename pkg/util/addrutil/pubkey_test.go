@@ -0,0 +1,89 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package addrutil
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/test/testaddress"
+)
+
+func TestPubKeyConversion(t *testing.T) {
+	require := require.New(t)
+
+	// golden vectors of the e2e test keys
+	vectors := []struct {
+		name    string
+		pubKey  string
+		hash    string
+		address string
+	}{
+		{
+			"alfa",
+			"04dc4c548c3a478278a6a09ffa8b5c4b384368e49654b35a6961ee8288fc889cdc39e9f8194e41abdbfac248ef9dc3f37b131a36ee2c052d974c21c1d2cd56730b",
+			"1e14d5373e1af9cc77f0032ad2cd0fba8be5ea2e",
+			"io1rc2d2de7rtuucalsqv4d9ng0h297t63w7wvlph",
+		},
+		{
+			"bravo",
+			"04964f1cb22b681c58f135e8a74bf64bf8468312a1f83be44c5fd4e4eaedeabc791f2a2b316d34912d0089f15c974ca5cecc921d4d0ce01e62a352fbf36d7cfb65",
+			"12745fec82b585f239c01090882eb40702c32b04",
+			"io1zf69lmyzkkzlywwqzzggst45qupvx2cyye62fp",
+		},
+		{
+			"producer",
+			"04755ce6d8903f6b3793bddb4ea5d3589d637de2d209ae0ea930815c82db564ee8cc448886f639e8a0c7e94e99a5c1335b583c0bc76ef30dd6a1038ed9da8daf33",
+			"da7e12ef57c236a06117c5e0d04a228e7181cf36",
+			"io1mflp9m6hcgm2qcghchsdqj3z3eccrnekx9p0ms",
+		},
+	}
+	for _, v := range vectors {
+		require.Equal(v.pubKey, testaddress.Keyinfo[v.name].PubKey.HexString())
+		pub, err := hex.DecodeString(v.pubKey)
+		require.NoError(err)
+
+		h, err := HashPubKey(pub)
+		require.NoError(err)
+		require.Equal(v.hash, hex.EncodeToString(h))
+
+		addr, err := AddressFromPubKey(pub)
+		require.NoError(err)
+		require.Equal(v.address, addr)
+
+		h, err = PubKeyHashFromAddress(v.address)
+		require.NoError(err)
+		require.Equal(v.hash, hex.EncodeToString(h))
+
+		a, err := PubKeyToAddress(testaddress.Keyinfo[v.name].PubKey)
+		require.NoError(err)
+		require.Equal(v.address, a.String())
+	}
+
+	pub, err := hex.DecodeString(vectors[0].pubKey)
+	require.NoError(err)
+	// compressed or truncated public key
+	_, err = HashPubKey(pub[:33])
+	require.Equal(keypair.ErrInvalidPubKeyLength, errors.Cause(err))
+	_, err = AddressFromPubKey(nil)
+	require.Equal(keypair.ErrInvalidPubKeyLength, errors.Cause(err))
+	// point off the curve
+	pub[64] ^= 1
+	_, err = HashPubKey(pub)
+	require.Equal(keypair.ErrPublicKey, errors.Cause(err))
+	_, err = PubKeyToAddress(nil)
+	require.Equal(keypair.ErrPublicKey, errors.Cause(err))
+
+	_, err = PubKeyHashFromAddress(vectors[0].address[:len(vectors[0].address)-1])
+	require.True(IsAddressError(err))
+	_, err = PubKeyHashFromAddress("")
+	require.True(IsAddressError(err))
+}
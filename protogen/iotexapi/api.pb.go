@@ -24,6 +24,31 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type ActPoolEventType int32
+
+const (
+	ActPoolEventType_ADDED   ActPoolEventType = 0
+	ActPoolEventType_REMOVED ActPoolEventType = 1
+)
+
+var ActPoolEventType_name = map[int32]string{
+	0: "ADDED",
+	1: "REMOVED",
+}
+
+var ActPoolEventType_value = map[string]int32{
+	"ADDED":   0,
+	"REMOVED": 1,
+}
+
+func (x ActPoolEventType) String() string {
+	return proto.EnumName(ActPoolEventType_name, int32(x))
+}
+
+func (ActPoolEventType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_ca6d5bbc959d58c0, []int{0}
+}
+
 type GetAccountRequest struct {
 	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -660,10 +685,13 @@ func (m *BlockProducerInfo) GetProduction() uint64 {
 }
 
 type GetActionsResponse struct {
-	ActionInfo           []*ActionInfo `protobuf:"bytes,1,rep,name=actionInfo,proto3" json:"actionInfo,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+	ActionInfo []*ActionInfo `protobuf:"bytes,1,rep,name=actionInfo,proto3" json:"actionInfo,omitempty"`
+	// truncated is set if the response is cut at the max response items, and the rest starts at nextStart
+	Truncated            bool     `protobuf:"varint,2,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	NextStart            uint64   `protobuf:"varint,3,opt,name=nextStart,proto3" json:"nextStart,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *GetActionsResponse) Reset()         { *m = GetActionsResponse{} }
@@ -698,6 +726,20 @@ func (m *GetActionsResponse) GetActionInfo() []*ActionInfo {
 	return nil
 }
 
+func (m *GetActionsResponse) GetTruncated() bool {
+	if m != nil {
+		return m.Truncated
+	}
+	return false
+}
+
+func (m *GetActionsResponse) GetNextStart() uint64 {
+	if m != nil {
+		return m.NextStart
+	}
+	return 0
+}
+
 type GetBlockMetasRequest struct {
 	// Types that are valid to be assigned to Lookup:
 	//	*GetBlockMetasRequest_ByIndex
@@ -865,10 +907,13 @@ func (m *GetBlockMetaByHashRequest) GetBlkHash() string {
 }
 
 type GetBlockMetasResponse struct {
-	BlkMetas             []*iotextypes.BlockMeta `protobuf:"bytes,1,rep,name=blkMetas,proto3" json:"blkMetas,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
-	XXX_unrecognized     []byte                  `json:"-"`
-	XXX_sizecache        int32                   `json:"-"`
+	BlkMetas []*iotextypes.BlockMeta `protobuf:"bytes,1,rep,name=blkMetas,proto3" json:"blkMetas,omitempty"`
+	// truncated is set if the response is cut at the max response items, and the rest starts at nextStart
+	Truncated            bool     `protobuf:"varint,2,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	NextStart            uint64   `protobuf:"varint,3,opt,name=nextStart,proto3" json:"nextStart,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *GetBlockMetasResponse) Reset()         { *m = GetBlockMetasResponse{} }
@@ -903,6 +948,20 @@ func (m *GetBlockMetasResponse) GetBlkMetas() []*iotextypes.BlockMeta {
 	return nil
 }
 
+func (m *GetBlockMetasResponse) GetTruncated() bool {
+	if m != nil {
+		return m.Truncated
+	}
+	return false
+}
+
+func (m *GetBlockMetasResponse) GetNextStart() uint64 {
+	if m != nil {
+		return m.NextStart
+	}
+	return 0
+}
+
 type GetChainMetaRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -1621,7 +1680,102 @@ func (m *GetEpochMetaResponse) GetBlockProducersInfo() []*BlockProducerInfo {
 	return nil
 }
 
+type StreamActPoolRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamActPoolRequest) Reset()         { *m = StreamActPoolRequest{} }
+func (m *StreamActPoolRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamActPoolRequest) ProtoMessage()    {}
+func (*StreamActPoolRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca6d5bbc959d58c0, []int{34}
+}
+
+func (m *StreamActPoolRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamActPoolRequest.Unmarshal(m, b)
+}
+func (m *StreamActPoolRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamActPoolRequest.Marshal(b, m, deterministic)
+}
+func (m *StreamActPoolRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamActPoolRequest.Merge(m, src)
+}
+func (m *StreamActPoolRequest) XXX_Size() int {
+	return xxx_messageInfo_StreamActPoolRequest.Size(m)
+}
+func (m *StreamActPoolRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamActPoolRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamActPoolRequest proto.InternalMessageInfo
+
+type ActPoolEvent struct {
+	Type                 ActPoolEventType   `protobuf:"varint,1,opt,name=type,proto3,enum=iotexapi.ActPoolEventType" json:"type,omitempty"`
+	Action               *iotextypes.Action `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	ActionHash           string             `protobuf:"bytes,3,opt,name=actionHash,proto3" json:"actionHash,omitempty"`
+	Dropped              uint64             `protobuf:"varint,4,opt,name=dropped,proto3" json:"dropped,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *ActPoolEvent) Reset()         { *m = ActPoolEvent{} }
+func (m *ActPoolEvent) String() string { return proto.CompactTextString(m) }
+func (*ActPoolEvent) ProtoMessage()    {}
+func (*ActPoolEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ca6d5bbc959d58c0, []int{35}
+}
+
+func (m *ActPoolEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ActPoolEvent.Unmarshal(m, b)
+}
+func (m *ActPoolEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ActPoolEvent.Marshal(b, m, deterministic)
+}
+func (m *ActPoolEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ActPoolEvent.Merge(m, src)
+}
+func (m *ActPoolEvent) XXX_Size() int {
+	return xxx_messageInfo_ActPoolEvent.Size(m)
+}
+func (m *ActPoolEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ActPoolEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ActPoolEvent proto.InternalMessageInfo
+
+func (m *ActPoolEvent) GetType() ActPoolEventType {
+	if m != nil {
+		return m.Type
+	}
+	return ActPoolEventType_ADDED
+}
+
+func (m *ActPoolEvent) GetAction() *iotextypes.Action {
+	if m != nil {
+		return m.Action
+	}
+	return nil
+}
+
+func (m *ActPoolEvent) GetActionHash() string {
+	if m != nil {
+		return m.ActionHash
+	}
+	return ""
+}
+
+func (m *ActPoolEvent) GetDropped() uint64 {
+	if m != nil {
+		return m.Dropped
+	}
+	return 0
+}
+
 func init() {
+	proto.RegisterEnum("iotexapi.ActPoolEventType", ActPoolEventType_name, ActPoolEventType_value)
 	proto.RegisterType((*GetAccountRequest)(nil), "iotexapi.GetAccountRequest")
 	proto.RegisterType((*GetAccountResponse)(nil), "iotexapi.GetAccountResponse")
 	proto.RegisterType((*GetActionsRequest)(nil), "iotexapi.GetActionsRequest")
@@ -1656,93 +1810,104 @@ func init() {
 	proto.RegisterType((*ReadStateResponse)(nil), "iotexapi.ReadStateResponse")
 	proto.RegisterType((*GetEpochMetaRequest)(nil), "iotexapi.GetEpochMetaRequest")
 	proto.RegisterType((*GetEpochMetaResponse)(nil), "iotexapi.GetEpochMetaResponse")
+	proto.RegisterType((*StreamActPoolRequest)(nil), "iotexapi.StreamActPoolRequest")
+	proto.RegisterType((*ActPoolEvent)(nil), "iotexapi.ActPoolEvent")
 }
 
 func init() { proto.RegisterFile("proto/api/api.proto", fileDescriptor_ca6d5bbc959d58c0) }
 
 var fileDescriptor_ca6d5bbc959d58c0 = []byte{
-	// 1294 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0x6d, 0x6f, 0x1b, 0x45,
-	0x10, 0x76, 0xe2, 0x34, 0xb1, 0xc7, 0x46, 0x34, 0x9b, 0xd4, 0x35, 0x97, 0xd0, 0x86, 0xa5, 0x85,
-	0x0a, 0xa9, 0x36, 0xb4, 0x69, 0x0b, 0x45, 0x14, 0xd9, 0x6d, 0x92, 0xba, 0x15, 0x4d, 0x74, 0xa1,
-	0x08, 0x10, 0x12, 0xdc, 0xcb, 0xc6, 0x3e, 0x62, 0xdf, 0x1e, 0x77, 0xeb, 0xa8, 0x11, 0xff, 0x86,
-	0x0f, 0xfc, 0x08, 0x7e, 0x58, 0x3f, 0xa3, 0xdb, 0xdd, 0xbb, 0x9b, 0x3b, 0x9f, 0x1d, 0x52, 0xf1,
-	0xa1, 0x52, 0x76, 0xe6, 0x99, 0x97, 0x7d, 0x76, 0xf6, 0xd9, 0x73, 0x61, 0x23, 0x08, 0xb9, 0xe0,
-	0x5d, 0x2b, 0xf0, 0xe2, 0x7f, 0x1d, 0xb9, 0x22, 0x35, 0x8f, 0x0b, 0xf6, 0xc6, 0x0a, 0x3c, 0xa3,
-	0xad, 0xdc, 0xe2, 0x3c, 0x60, 0x51, 0xd7, 0x72, 0x84, 0xc7, 0x7d, 0x85, 0x31, 0xb6, 0xb1, 0xc7,
-	0x1e, 0x73, 0xe7, 0xd4, 0x19, 0x59, 0x5e, 0xe2, 0x6d, 0x61, 0xaf, 0xcf, 0x5d, 0xa6, 0xed, 0x37,
-	0x87, 0x9c, 0x0f, 0xc7, 0xac, 0x2b, 0x57, 0xf6, 0xf4, 0xa4, 0x2b, 0xbc, 0x09, 0x8b, 0x84, 0x35,
-	0x09, 0x14, 0x80, 0xde, 0x85, 0xf5, 0x03, 0x26, 0x7a, 0x8e, 0xc3, 0xa7, 0xbe, 0x30, 0xd9, 0x1f,
-	0x53, 0x16, 0x09, 0xd2, 0x86, 0x35, 0xcb, 0x75, 0x43, 0x16, 0x45, 0xed, 0xa5, 0x9d, 0xa5, 0x3b,
-	0x75, 0x33, 0x59, 0xd2, 0x43, 0x20, 0x18, 0x1e, 0x05, 0xdc, 0x8f, 0x18, 0xf9, 0x0a, 0x1a, 0x96,
-	0x32, 0x7d, 0xc7, 0x84, 0x25, 0x63, 0x1a, 0xf7, 0xae, 0x77, 0xe4, 0xae, 0x64, 0x4b, 0x9d, 0x5e,
-	0xe6, 0x36, 0x31, 0x96, 0xbe, 0x5d, 0xd6, 0x0d, 0xc4, 0x5b, 0x8d, 0x92, 0x06, 0x9e, 0xc0, 0x9a,
-	0x7d, 0x3e, 0xf0, 0x5d, 0xf6, 0x46, 0x27, 0xa3, 0x9d, 0x84, 0xa2, 0x4e, 0x86, 0xee, 0x2b, 0x88,
-	0x0e, 0x7a, 0x5e, 0x31, 0x93, 0x20, 0xf2, 0x18, 0x56, 0xed, 0xf3, 0xe7, 0x56, 0x34, 0x6a, 0x2f,
-	0xcb, 0xf0, 0x9d, 0x92, 0xf0, 0xbe, 0x04, 0x64, 0xc1, 0x3a, 0x82, 0x3c, 0x89, 0x63, 0x7b, 0xae,
-	0x1b, 0xb6, 0xab, 0x32, 0xf6, 0x56, 0x79, 0xe9, 0x9e, 0x62, 0x24, 0x17, 0x1f, 0xdb, 0xc8, 0xaf,
-	0xb0, 0x3e, 0xf5, 0x1d, 0xee, 0x9f, 0x78, 0xe1, 0x84, 0xb9, 0x0a, 0xd8, 0x5e, 0x91, 0xa9, 0xba,
-	0xb9, 0x54, 0xaf, 0x33, 0xd4, 0xfc, 0xac, 0xb3, 0xb9, 0xc8, 0x63, 0xb8, 0x62, 0x9f, 0xf7, 0xc7,
-	0xa7, 0xed, 0x2b, 0x8b, 0xa8, 0xe9, 0xc7, 0x23, 0x92, 0xe5, 0x51, 0x21, 0xfd, 0x1a, 0xac, 0x8e,
-	0x39, 0x3f, 0x9d, 0x06, 0x74, 0x1f, 0xda, 0xf3, 0x98, 0x24, 0x9b, 0x70, 0x25, 0x12, 0x56, 0x28,
-	0x24, 0xf9, 0x2b, 0xa6, 0x5a, 0xc4, 0x56, 0x79, 0x6e, 0x92, 0xd3, 0x15, 0x53, 0x2d, 0xe8, 0x2f,
-	0xd0, 0x2a, 0xa7, 0x94, 0xdc, 0x00, 0x50, 0x13, 0x2c, 0x0f, 0x42, 0x0d, 0x12, 0xb2, 0x10, 0x0a,
-	0x4d, 0x67, 0xc4, 0x9c, 0xd3, 0x23, 0xe6, 0xbb, 0x9e, 0x3f, 0x94, 0x69, 0x6b, 0x66, 0xce, 0x46,
-	0x6d, 0x30, 0xe6, 0x93, 0x3e, 0x7f, 0x4e, 0xb3, 0x1d, 0x2c, 0x97, 0xee, 0xa0, 0x8a, 0x77, 0x30,
-	0x81, 0xdb, 0xff, 0xe9, 0x34, 0xfe, 0xa7, 0x72, 0xbf, 0xe5, 0x89, 0xc7, 0xe7, 0x14, 0x57, 0xb0,
-	0xc7, 0xa7, 0x88, 0xaf, 0x64, 0x79, 0xa9, 0x0a, 0x7f, 0x2f, 0x01, 0xa8, 0xfc, 0x03, 0xff, 0x84,
-	0x93, 0xcf, 0x60, 0x55, 0xb1, 0xae, 0xef, 0x12, 0xc9, 0x5f, 0xcc, 0xd8, 0x63, 0x6a, 0x84, 0xdc,
-	0xa2, 0x23, 0xd2, 0x9b, 0x13, 0x6f, 0x51, 0x2d, 0x71, 0x6b, 0xd5, 0x7c, 0x6b, 0x5f, 0x42, 0x3d,
-	0x55, 0x15, 0x3d, 0xe8, 0x46, 0x47, 0xe9, 0x4e, 0x27, 0xd1, 0x9d, 0xce, 0xf7, 0x09, 0xc2, 0xcc,
-	0xc0, 0xf4, 0x07, 0x68, 0x98, 0xcc, 0x61, 0x5e, 0x20, 0x64, 0xa3, 0x77, 0x61, 0x2d, 0x54, 0x4b,
-	0xdd, 0xe9, 0x06, 0xee, 0x54, 0x23, 0xcd, 0x04, 0x83, 0x3b, 0x5a, 0xce, 0x75, 0x44, 0xff, 0x84,
-	0x75, 0x49, 0xeb, 0x51, 0xc8, 0xdd, 0xa9, 0xc3, 0x42, 0x99, 0x7d, 0xe1, 0xe9, 0x9d, 0x71, 0xc1,
-	0x22, 0x9d, 0x46, 0x2d, 0x48, 0x4b, 0xd1, 0x76, 0xc6, 0xe4, 0x7e, 0x6b, 0xa6, 0x5e, 0xc5, 0x63,
-	0x1d, 0xc8, 0xbc, 0x92, 0xd2, 0x15, 0x49, 0x3c, 0xb2, 0xd0, 0x17, 0x5a, 0x22, 0xb5, 0xa0, 0x69,
-	0x89, 0xdc, 0x4d, 0x2e, 0x43, 0xdc, 0x4b, 0x7b, 0x69, 0xa7, 0x7a, 0xa7, 0x71, 0x6f, 0x33, 0xbb,
-	0xb9, 0xd9, 0x71, 0x99, 0x08, 0x47, 0xff, 0x5a, 0x82, 0xcd, 0x03, 0x26, 0xe4, 0x66, 0x62, 0xb9,
-	0x4c, 0x47, 0xb1, 0x57, 0x14, 0xc8, 0xdb, 0x39, 0x15, 0xc8, 0x02, 0xe6, 0x6b, 0xe4, 0x37, 0x05,
-	0x8d, 0xfc, 0xb8, 0x3c, 0xc3, 0x1c, 0x99, 0x44, 0x4a, 0x32, 0x80, 0xad, 0x05, 0x25, 0x2f, 0x25,
-	0x26, 0x0f, 0xe0, 0x83, 0xb9, 0xb5, 0xe7, 0x5f, 0x0e, 0xfa, 0x02, 0xae, 0x15, 0x58, 0xd2, 0xac,
-	0x7f, 0x01, 0x35, 0x7b, 0xac, 0x6c, 0x9a, 0xf3, 0x6b, 0x78, 0xa4, 0xd2, 0x08, 0x33, 0x85, 0xd1,
-	0x6b, 0xb0, 0x71, 0xc0, 0xc4, 0xd3, 0xf8, 0x6d, 0x95, 0x1e, 0x55, 0x9c, 0xbe, 0x94, 0x07, 0x81,
-	0xcc, 0xba, 0xc2, 0x7d, 0xa8, 0x3b, 0x89, 0x51, 0x1f, 0x45, 0xae, 0x44, 0x16, 0x91, 0xe1, 0x68,
-	0x4b, 0x26, 0x3b, 0x66, 0xe1, 0x19, 0x0b, 0x71, 0x91, 0x43, 0xb9, 0x0f, 0x6c, 0xd7, 0x55, 0x1e,
-	0x02, 0x44, 0xa9, 0x55, 0x97, 0x69, 0xe1, 0x32, 0x28, 0x06, 0x21, 0xe9, 0xb7, 0xb0, 0x7e, 0xcc,
-	0x7c, 0x2d, 0x68, 0x09, 0x8f, 0x97, 0xd0, 0x03, 0xba, 0x0b, 0x04, 0x27, 0xd0, 0xed, 0x5c, 0xa0,
-	0xec, 0xf4, 0x6b, 0x79, 0x8c, 0xfa, 0xc2, 0xf6, 0xcf, 0xf3, 0xe5, 0x2f, 0x0a, 0x7e, 0x2d, 0x25,
-	0x7f, 0x26, 0x58, 0x97, 0x7e, 0x04, 0x8d, 0x30, 0x93, 0x8c, 0x3c, 0xe3, 0xf1, 0xe8, 0x22, 0x3d,
-	0x31, 0x31, 0x92, 0xf6, 0x60, 0xc3, 0x64, 0x96, 0xfb, 0x94, 0xfb, 0x22, 0xb4, 0x1c, 0xf1, 0x2e,
-	0x64, 0xfc, 0x04, 0x9b, 0xf9, 0x14, 0xba, 0x27, 0x02, 0x2b, 0xae, 0xa5, 0xcf, 0xa5, 0x6e, 0xca,
-	0xbf, 0xb1, 0x96, 0x2d, 0x5f, 0xac, 0x65, 0xb4, 0x0d, 0xad, 0xe3, 0xe9, 0x70, 0xc8, 0x22, 0x71,
-	0x60, 0x45, 0x47, 0xa1, 0xe7, 0xb0, 0x64, 0x26, 0x1e, 0xc0, 0xf5, 0x19, 0x8f, 0xae, 0x6b, 0x40,
-	0x6d, 0xa8, 0x6d, 0xfa, 0x72, 0xa5, 0xeb, 0xf8, 0x52, 0xee, 0x45, 0xc2, 0x9b, 0x58, 0x82, 0x1d,
-	0x58, 0xd1, 0x3e, 0x0f, 0xdf, 0x7d, 0x06, 0x3e, 0x87, 0xed, 0xf2, 0x54, 0xba, 0x8d, 0xab, 0x50,
-	0x1d, 0x5a, 0x91, 0xee, 0x20, 0xfe, 0x93, 0x06, 0x70, 0x35, 0x26, 0xea, 0x58, 0x58, 0x82, 0xa1,
-	0x63, 0x97, 0x8f, 0x81, 0xc3, 0xc7, 0x83, 0x67, 0x12, 0xdc, 0x34, 0x91, 0x25, 0xf6, 0x4f, 0x98,
-	0x18, 0x71, 0xf7, 0x95, 0x35, 0x61, 0x92, 0xb3, 0xa6, 0x89, 0x2c, 0x64, 0x1b, 0xea, 0x56, 0x38,
-	0x9c, 0x4e, 0x98, 0x2f, 0xa2, 0x76, 0x75, 0xa7, 0x7a, 0xa7, 0x69, 0x66, 0x06, 0xfa, 0x29, 0xac,
-	0xa3, 0x8a, 0x25, 0xe7, 0xd2, 0x54, 0xe7, 0x42, 0x1f, 0xc9, 0xeb, 0xbd, 0x17, 0x70, 0x67, 0x84,
-	0x6e, 0x1e, 0xd9, 0x81, 0x06, 0x8b, 0x6d, 0xaf, 0xa6, 0x13, 0x9b, 0x85, 0x7a, 0x2f, 0xd8, 0x44,
-	0xff, 0x51, 0x52, 0x8c, 0x22, 0x33, 0x05, 0x90, 0xb8, 0x67, 0x56, 0xb9, 0x02, 0xec, 0x25, 0x4e,
-	0x33, 0xc3, 0xc5, 0xf5, 0x04, 0x17, 0xd6, 0x58, 0x2a, 0x50, 0xa4, 0x45, 0x10, 0x9b, 0xc8, 0x4b,
-	0x20, 0x36, 0x7e, 0xc3, 0x22, 0x39, 0xef, 0x55, 0x29, 0x62, 0x5b, 0xd9, 0xbc, 0xcf, 0xbc, 0x73,
-	0x66, 0x49, 0xd8, 0xbd, 0xb7, 0x6b, 0x00, 0xbd, 0xa3, 0x41, 0xac, 0x12, 0x9e, 0xc3, 0xc8, 0x00,
-	0x20, 0xfb, 0x8a, 0x27, 0x5b, 0x85, 0x0f, 0x48, 0xfc, 0x53, 0xc0, 0xd8, 0x2e, 0x77, 0xaa, 0xbd,
-	0xd3, 0x4a, 0x9a, 0x4a, 0xbe, 0x76, 0x33, 0xa9, 0xf0, 0x47, 0xfd, 0x4c, 0xaa, 0xdc, 0x03, 0x49,
-	0x2b, 0xc4, 0x84, 0xf7, 0x72, 0x2a, 0x4e, 0x6e, 0xcc, 0x79, 0xd3, 0x92, 0x84, 0x37, 0xe7, 0xfa,
-	0xd3, 0x9c, 0x87, 0xd0, 0xc4, 0xb2, 0x4d, 0x3e, 0xcc, 0x85, 0x14, 0x55, 0xde, 0xb8, 0x31, 0xcf,
-	0x5d, 0x68, 0x32, 0x93, 0xdb, 0x42, 0x93, 0x33, 0x9a, 0x5e, 0x68, 0x72, 0x56, 0xdb, 0x15, 0x87,
-	0x99, 0xc8, 0x62, 0x0e, 0x67, 0xb4, 0x1b, 0x73, 0x38, 0xab, 0xcb, 0xb4, 0x42, 0x2c, 0xf9, 0xf1,
-	0x51, 0x10, 0x4f, 0x92, 0x7f, 0xda, 0xcb, 0x75, 0xd9, 0xb8, 0xb5, 0x18, 0x84, 0x29, 0xc5, 0x2a,
-	0x88, 0x29, 0x2d, 0x11, 0x58, 0x4c, 0x69, 0x99, 0x78, 0xd2, 0x0a, 0xf9, 0x11, 0xde, 0x2f, 0x28,
-	0x1c, 0x41, 0xbf, 0xd7, 0xca, 0x65, 0xd1, 0xf8, 0x68, 0x01, 0x22, 0xcd, 0x3c, 0x84, 0xcd, 0x32,
-	0xe5, 0x22, 0xe8, 0x63, 0x69, 0x81, 0x48, 0x1a, 0x9f, 0x5c, 0x04, 0x4b, 0x0b, 0xed, 0x43, 0x3d,
-	0x95, 0x1f, 0x62, 0xe4, 0x77, 0x8c, 0x55, 0xd0, 0xd8, 0x2a, 0xf5, 0x15, 0xc6, 0x35, 0xd5, 0x98,
-	0xc2, 0xb8, 0x16, 0x55, 0xab, 0x30, 0xae, 0x33, 0xd2, 0x44, 0x2b, 0xfd, 0x87, 0x3f, 0xef, 0x0e,
-	0x3d, 0x31, 0x9a, 0xda, 0x1d, 0x87, 0x4f, 0xba, 0x12, 0x1d, 0x84, 0xfc, 0x77, 0xe6, 0x08, 0xb5,
-	0xb8, 0xeb, 0xf0, 0x50, 0xff, 0xef, 0xc0, 0x90, 0xf9, 0xdd, 0x24, 0x9d, 0xbd, 0x2a, 0x4d, 0xf7,
-	0xff, 0x0d, 0x00, 0x00, 0xff, 0xff, 0x80, 0x85, 0x7c, 0x01, 0xaf, 0x10, 0x00, 0x00,
+	// 1430 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0x6d, 0x6f, 0xdb, 0x54,
+	0x14, 0x6e, 0x9a, 0xbe, 0x24, 0x27, 0x19, 0xb4, 0xb7, 0x6d, 0x66, 0xdc, 0xd2, 0x15, 0xb3, 0xc1,
+	0x34, 0x69, 0xc9, 0xe8, 0xde, 0x60, 0x88, 0xa1, 0x64, 0xcd, 0xba, 0x6a, 0xda, 0x5a, 0xdd, 0x6e,
+	0x13, 0x20, 0x24, 0x70, 0xec, 0xbb, 0xd4, 0x34, 0xf1, 0x35, 0xf6, 0x4d, 0xb5, 0x8a, 0xef, 0xfc,
+	0x10, 0x84, 0xf8, 0x11, 0x7c, 0xe5, 0x3f, 0xf1, 0x19, 0xdd, 0x17, 0xdb, 0xd7, 0x8e, 0x9d, 0xb2,
+	0xc1, 0x87, 0x49, 0xbd, 0xe7, 0x3c, 0xe7, 0xe5, 0x9e, 0x73, 0xee, 0x73, 0x9c, 0xc1, 0x5a, 0x10,
+	0x52, 0x46, 0x3b, 0x76, 0xe0, 0xf1, 0x7f, 0x6d, 0x71, 0x42, 0x35, 0x8f, 0x32, 0xf2, 0xc6, 0x0e,
+	0x3c, 0xd3, 0x90, 0x6a, 0x76, 0x1e, 0x90, 0xa8, 0x63, 0x3b, 0xcc, 0xa3, 0xbe, 0xc4, 0x98, 0x5b,
+	0xba, 0x66, 0x30, 0xa2, 0xce, 0xa9, 0x73, 0x62, 0x7b, 0xb1, 0xb6, 0xa5, 0x6b, 0x7d, 0xea, 0x12,
+	0x25, 0xbf, 0x32, 0xa4, 0x74, 0x38, 0x22, 0x1d, 0x71, 0x1a, 0x4c, 0x5e, 0x77, 0x98, 0x37, 0x26,
+	0x11, 0xb3, 0xc7, 0x81, 0x04, 0x58, 0x37, 0x61, 0x75, 0x9f, 0xb0, 0xae, 0xe3, 0xd0, 0x89, 0xcf,
+	0x30, 0xf9, 0x79, 0x42, 0x22, 0x86, 0x0c, 0x58, 0xb6, 0x5d, 0x37, 0x24, 0x51, 0x64, 0x54, 0x76,
+	0x2a, 0xd7, 0xeb, 0x38, 0x3e, 0x5a, 0x87, 0x80, 0x74, 0x78, 0x14, 0x50, 0x3f, 0x22, 0xe8, 0x0b,
+	0x68, 0xd8, 0x52, 0xf4, 0x8c, 0x30, 0x5b, 0xd8, 0x34, 0x76, 0x2f, 0xb7, 0xc5, 0xad, 0x44, 0x4a,
+	0xed, 0x6e, 0xaa, 0xc6, 0x3a, 0xd6, 0xfa, 0x7b, 0x5e, 0x25, 0xc0, 0xaf, 0x1a, 0xc5, 0x09, 0x3c,
+	0x84, 0xe5, 0xc1, 0xf9, 0x81, 0xef, 0x92, 0x37, 0xca, 0x99, 0xd5, 0x8e, 0x4b, 0xd4, 0x4e, 0xd1,
+	0x3d, 0x09, 0x51, 0x46, 0x4f, 0xe6, 0x70, 0x6c, 0x84, 0x1e, 0xc0, 0xd2, 0xe0, 0xfc, 0x89, 0x1d,
+	0x9d, 0x18, 0xf3, 0xc2, 0x7c, 0xa7, 0xc0, 0xbc, 0x27, 0x00, 0xa9, 0xb1, 0xb2, 0x40, 0x0f, 0xb9,
+	0x6d, 0xd7, 0x75, 0x43, 0xa3, 0x2a, 0x6c, 0xaf, 0x16, 0x87, 0xee, 0xca, 0x8a, 0x64, 0xec, 0xb9,
+	0x0c, 0xfd, 0x00, 0xab, 0x13, 0xdf, 0xa1, 0xfe, 0x6b, 0x2f, 0x1c, 0x13, 0x57, 0x02, 0x8d, 0x05,
+	0xe1, 0xaa, 0x93, 0x71, 0xf5, 0x32, 0x45, 0x95, 0x7b, 0x9d, 0xf6, 0x85, 0x1e, 0xc0, 0xe2, 0xe0,
+	0xbc, 0x37, 0x3a, 0x35, 0x16, 0x67, 0x95, 0xa6, 0xc7, 0x47, 0x24, 0xf5, 0x23, 0x4d, 0x7a, 0x35,
+	0x58, 0x1a, 0x51, 0x7a, 0x3a, 0x09, 0xac, 0xc7, 0x60, 0x94, 0x55, 0x12, 0xad, 0xc3, 0x62, 0xc4,
+	0xec, 0x90, 0x89, 0xe2, 0x2f, 0x60, 0x79, 0xe0, 0x52, 0xd1, 0x37, 0x51, 0xd3, 0x05, 0x2c, 0x0f,
+	0xd6, 0xf7, 0xd0, 0x2a, 0x2e, 0x29, 0xda, 0x06, 0x90, 0x13, 0x2c, 0x1a, 0x21, 0x07, 0x49, 0x93,
+	0x20, 0x0b, 0x9a, 0xce, 0x09, 0x71, 0x4e, 0x8f, 0x88, 0xef, 0x7a, 0xfe, 0x50, 0xb8, 0xad, 0xe1,
+	0x8c, 0xcc, 0x1a, 0x80, 0x59, 0x5e, 0xf4, 0xf2, 0x39, 0x4d, 0x6f, 0x30, 0x5f, 0x78, 0x83, 0xaa,
+	0x7e, 0x83, 0x31, 0x5c, 0xfb, 0x57, 0xdd, 0xf8, 0x9f, 0xc2, 0xfd, 0x08, 0x46, 0x59, 0x9f, 0x78,
+	0x84, 0xc1, 0xe8, 0x54, 0xab, 0x57, 0x7c, 0x7c, 0xab, 0x08, 0x7f, 0x54, 0x00, 0xa4, 0xff, 0x03,
+	0xff, 0x35, 0x45, 0x37, 0x60, 0x49, 0x56, 0x5d, 0xbd, 0x25, 0x94, 0x7d, 0x98, 0x5c, 0x83, 0x15,
+	0x42, 0x5c, 0xd1, 0x61, 0xc9, 0xcb, 0xa9, 0xe3, 0xf8, 0xa8, 0xa7, 0x56, 0xcd, 0xa6, 0xf6, 0x39,
+	0xd4, 0x13, 0x56, 0x51, 0x83, 0x6e, 0xb6, 0x25, 0xef, 0xb4, 0x63, 0xde, 0x69, 0xbf, 0x88, 0x11,
+	0x38, 0x05, 0x5b, 0xaf, 0xa0, 0x81, 0x89, 0x43, 0xbc, 0x80, 0x89, 0x44, 0x6f, 0xc2, 0x72, 0x28,
+	0x8f, 0x2a, 0xd3, 0x35, 0x3d, 0x53, 0x85, 0xc4, 0x31, 0x46, 0xcf, 0x68, 0x3e, 0x93, 0x91, 0xf5,
+	0x0b, 0xac, 0x8a, 0xb2, 0x1e, 0x85, 0xd4, 0x9d, 0x38, 0x24, 0x14, 0xde, 0x67, 0x76, 0xef, 0x8c,
+	0x32, 0x12, 0x29, 0x37, 0xf2, 0x80, 0x5a, 0xb2, 0x6c, 0x67, 0x44, 0xdc, 0xb7, 0x86, 0xd5, 0x89,
+	0x8f, 0x75, 0x20, 0xfc, 0x8a, 0x92, 0x2e, 0x88, 0xc2, 0x6b, 0x12, 0xeb, 0xd7, 0x8a, 0xe2, 0x48,
+	0xc5, 0x68, 0x8a, 0x23, 0xef, 0xc4, 0xaf, 0x81, 0x27, 0x63, 0x54, 0x76, 0xaa, 0xd7, 0x1b, 0xbb,
+	0xeb, 0xe9, 0xd3, 0x4d, 0xfb, 0x85, 0x35, 0x1c, 0xda, 0x82, 0x3a, 0x0b, 0x27, 0xbe, 0x63, 0x33,
+	0xe2, 0xaa, 0x07, 0x92, 0x0a, 0xb8, 0xd6, 0x27, 0x6f, 0xd8, 0xb1, 0x18, 0x0c, 0x39, 0x02, 0xa9,
+	0xc0, 0xfa, 0xad, 0x02, 0xeb, 0xfb, 0x84, 0x89, 0x4a, 0x70, 0xae, 0x4d, 0xe6, 0xb8, 0x9b, 0x67,
+	0xd7, 0x6b, 0x19, 0x0a, 0x49, 0x0d, 0xca, 0x09, 0xf6, 0xab, 0x1c, 0xc1, 0x7e, 0x5c, 0xec, 0xa1,
+	0x84, 0x63, 0x35, 0x1a, 0x3a, 0x80, 0xcd, 0x19, 0x21, 0xdf, 0x8a, 0x89, 0xee, 0xc2, 0x07, 0xa5,
+	0xb1, 0xcb, 0x5f, 0x16, 0xef, 0xd7, 0x46, 0xae, 0x4c, 0xaa, 0x65, 0x9f, 0x41, 0x6d, 0x30, 0x92,
+	0x32, 0xd5, 0xb0, 0x0d, 0x7d, 0x20, 0x13, 0x0b, 0x9c, 0xc0, 0xfe, 0x53, 0xbf, 0x36, 0x60, 0x6d,
+	0x9f, 0xb0, 0x47, 0x7c, 0xab, 0x0b, 0xaf, 0x32, 0x73, 0xeb, 0x29, 0xac, 0x67, 0xc5, 0x2a, 0xbb,
+	0xdb, 0x50, 0x77, 0x62, 0xa1, 0xea, 0x63, 0x26, 0xbd, 0xd4, 0x22, 0xc5, 0x59, 0x2d, 0xe1, 0xec,
+	0x98, 0x84, 0x67, 0x24, 0xd4, 0x83, 0x1c, 0xc2, 0x46, 0x4e, 0xae, 0xa2, 0xdc, 0x03, 0x88, 0x12,
+	0xa9, 0x0a, 0xd3, 0xd2, 0xc3, 0x68, 0x36, 0x1a, 0xd2, 0xfa, 0x1a, 0x56, 0x8f, 0x89, 0xaf, 0xa8,
+	0x34, 0x6e, 0xc2, 0x5b, 0x30, 0x91, 0x75, 0x07, 0x90, 0xee, 0x40, 0xa5, 0x73, 0xc1, 0x4e, 0xb1,
+	0xbe, 0x14, 0x33, 0xa0, 0xa8, 0xa2, 0x77, 0x9e, 0x0d, 0x7f, 0x91, 0xf1, 0x4b, 0x30, 0x8b, 0x8c,
+	0x55, 0xe8, 0xfb, 0xd0, 0x08, 0x53, 0xb2, 0xca, 0x56, 0x9c, 0xcf, 0xbd, 0xc6, 0x64, 0x58, 0x47,
+	0x5a, 0x5d, 0x58, 0xc3, 0xc4, 0x76, 0x1f, 0x51, 0x9f, 0x85, 0xb6, 0xc3, 0xde, 0xa5, 0x18, 0xdf,
+	0xc2, 0x7a, 0xd6, 0x85, 0xca, 0x09, 0xc1, 0x82, 0x6b, 0xab, 0xbe, 0xd4, 0xb1, 0xf8, 0x5b, 0x67,
+	0xd1, 0xf9, 0x8b, 0x59, 0xd4, 0x32, 0xa0, 0x75, 0x3c, 0x19, 0x0e, 0x49, 0xc4, 0xf6, 0xed, 0xe8,
+	0x28, 0xf4, 0x1c, 0x12, 0xcf, 0xc4, 0x5d, 0xb8, 0x3c, 0xa5, 0x51, 0x71, 0x4d, 0xa8, 0x0d, 0x95,
+	0x4c, 0xbd, 0xcc, 0xe4, 0xcc, 0x5f, 0x74, 0x3f, 0x62, 0xde, 0xd8, 0x66, 0x64, 0xdf, 0x8e, 0x1e,
+	0xd3, 0xf0, 0xdd, 0x67, 0xe0, 0x16, 0x6c, 0x15, 0xbb, 0x52, 0x69, 0xac, 0x40, 0x75, 0x68, 0x47,
+	0x2a, 0x03, 0xfe, 0xa7, 0x15, 0xc0, 0x0a, 0x2f, 0xd4, 0x31, 0xb3, 0x19, 0xd1, 0xda, 0x2e, 0xd6,
+	0x90, 0x43, 0x47, 0x07, 0x7b, 0x02, 0xdc, 0xc4, 0x9a, 0x84, 0xeb, 0xc7, 0x84, 0x9d, 0x50, 0xf7,
+	0xb9, 0x3d, 0x26, 0xa2, 0x66, 0x4d, 0xac, 0x49, 0xf8, 0xab, 0xb5, 0xc3, 0xe1, 0x64, 0x4c, 0x7c,
+	0x16, 0x19, 0xd5, 0x9d, 0xea, 0xf5, 0x26, 0x4e, 0x05, 0xd6, 0xa7, 0xb0, 0xaa, 0x45, 0x2c, 0xe8,
+	0x4b, 0x53, 0xf6, 0xc5, 0xba, 0x2f, 0x9e, 0x77, 0x3f, 0xa0, 0xce, 0x89, 0xf6, 0xf2, 0xd0, 0x0e,
+	0x34, 0x08, 0x97, 0x3d, 0x9f, 0x8c, 0x07, 0x24, 0x54, 0x77, 0xd1, 0x45, 0xd6, 0x9f, 0x92, 0xc7,
+	0x35, 0xcb, 0x94, 0x01, 0x04, 0x6e, 0xcf, 0x2e, 0x66, 0x80, 0x7e, 0xac, 0xc4, 0x29, 0x8e, 0xc7,
+	0x63, 0x94, 0xd9, 0x23, 0xc1, 0x5e, 0x91, 0x62, 0x50, 0x5d, 0x84, 0x9e, 0x02, 0x1a, 0xe8, 0xdb,
+	0x33, 0x12, 0xf3, 0x5e, 0x15, 0x04, 0xb8, 0x99, 0xce, 0xfb, 0xd4, 0x86, 0xc5, 0x05, 0x66, 0x9c,
+	0x70, 0x8e, 0x59, 0x48, 0xec, 0x71, 0xd7, 0x61, 0x47, 0x94, 0x8e, 0xe2, 0xe1, 0xfa, 0xbd, 0x02,
+	0x4d, 0x25, 0xea, 0x9f, 0x11, 0x9f, 0xa1, 0x36, 0x2c, 0xf0, 0xac, 0xc5, 0x3d, 0xde, 0xdb, 0x35,
+	0x33, 0x9b, 0x31, 0x41, 0xbd, 0x38, 0x0f, 0x08, 0x16, 0x38, 0x6d, 0x8e, 0xe6, 0x2f, 0xfc, 0xaa,
+	0xc9, 0x3e, 0xfc, 0xea, 0xd4, 0x97, 0xa8, 0x01, 0xcb, 0x6e, 0x48, 0x83, 0x80, 0xb8, 0x6a, 0x9f,
+	0xc7, 0xc7, 0x1b, 0x37, 0x60, 0x25, 0x1f, 0x1f, 0xd5, 0x61, 0xb1, 0xbb, 0xb7, 0xd7, 0xdf, 0x5b,
+	0x99, 0x43, 0x0d, 0x58, 0xc6, 0xfd, 0x67, 0x87, 0xaf, 0xfa, 0x7b, 0x2b, 0x95, 0xdd, 0xbf, 0x6a,
+	0x00, 0xdd, 0xa3, 0x03, 0x4e, 0x88, 0x9e, 0x43, 0xd0, 0x01, 0x40, 0xfa, 0x53, 0x09, 0x6d, 0xe6,
+	0xbe, 0xd2, 0xf5, 0xdf, 0x5b, 0xe6, 0x56, 0xb1, 0x52, 0xb6, 0xd9, 0x9a, 0x4b, 0x5c, 0xf1, 0x84,
+	0xa3, 0x29, 0x57, 0xfa, 0x2f, 0x27, 0x73, 0xab, 0x58, 0x99, 0xb8, 0xc2, 0x70, 0x29, 0xb3, 0xec,
+	0xd0, 0x76, 0xc9, 0xee, 0x8f, 0x1d, 0x5e, 0x29, 0xd5, 0x27, 0x3e, 0x0f, 0xa1, 0xa9, 0x6f, 0x28,
+	0xf4, 0x61, 0xc6, 0x24, 0xbf, 0xd0, 0xcc, 0xed, 0x32, 0x75, 0x2e, 0xc9, 0x74, 0xb3, 0xe4, 0x92,
+	0x9c, 0x5a, 0x5f, 0xe6, 0x95, 0x52, 0xbd, 0x5e, 0xc3, 0x74, 0x9f, 0xe8, 0x35, 0x9c, 0x5a, 0x53,
+	0xe6, 0x56, 0xb1, 0x32, 0x71, 0x65, 0x8b, 0x0f, 0xbc, 0xdc, 0x9e, 0x40, 0xd9, 0x4f, 0xa0, 0xe2,
+	0x15, 0x64, 0x5e, 0x9d, 0x0d, 0xd2, 0x4b, 0xaa, 0x13, 0xbe, 0x5e, 0xd2, 0x82, 0x5d, 0x62, 0x6e,
+	0x97, 0xa9, 0x13, 0x87, 0xdf, 0xc0, 0xfb, 0x39, 0x32, 0x47, 0xda, 0x8f, 0xe2, 0xe2, 0x0d, 0x60,
+	0x7e, 0x34, 0x03, 0x91, 0x78, 0x1e, 0xc2, 0x7a, 0x11, 0x49, 0x23, 0xed, 0xa3, 0x72, 0xc6, 0x3e,
+	0x30, 0x3f, 0xb9, 0x08, 0x96, 0x04, 0x7a, 0x0c, 0xf5, 0x84, 0x69, 0x91, 0x99, 0xbd, 0xb1, 0x4e,
+	0xf8, 0xe6, 0x66, 0xa1, 0x2e, 0x37, 0xae, 0x09, 0x9d, 0xe6, 0xc6, 0x35, 0x4f, 0xd0, 0xe6, 0x76,
+	0x99, 0x3a, 0x71, 0xf8, 0x14, 0x2e, 0x65, 0x38, 0x4e, 0x1f, 0xd7, 0x22, 0xf2, 0x33, 0x5b, 0xc5,
+	0xec, 0x66, 0xcd, 0xdd, 0xaa, 0xf4, 0xee, 0x7d, 0x77, 0x67, 0xe8, 0xb1, 0x93, 0xc9, 0xa0, 0xed,
+	0xd0, 0x71, 0x47, 0xe0, 0x82, 0x90, 0xfe, 0x44, 0x1c, 0x26, 0x0f, 0x37, 0x1d, 0x1a, 0xaa, 0xff,
+	0xcf, 0x19, 0x12, 0xbf, 0x13, 0x3b, 0x1a, 0x2c, 0x09, 0xd1, 0xed, 0x7f, 0x06, 0x00, 0x2d, 0x3d,
+	0x55, 0xa6, 0x61, 0x12, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1788,6 +1953,8 @@ type APIServiceClient interface {
 	ReadState(ctx context.Context, in *ReadStateRequest, opts ...grpc.CallOption) (*ReadStateResponse, error)
 	// get epoch metadata
 	GetEpochMeta(ctx context.Context, in *GetEpochMetaRequest, opts ...grpc.CallOption) (*GetEpochMetaResponse, error)
+	// stream add/remove events of actpool
+	StreamActPool(ctx context.Context, in *StreamActPoolRequest, opts ...grpc.CallOption) (APIService_StreamActPoolClient, error)
 }
 
 type aPIServiceClient struct {
@@ -1906,6 +2073,38 @@ func (c *aPIServiceClient) GetEpochMeta(ctx context.Context, in *GetEpochMetaReq
 	return out, nil
 }
 
+func (c *aPIServiceClient) StreamActPool(ctx context.Context, in *StreamActPoolRequest, opts ...grpc.CallOption) (APIService_StreamActPoolClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_APIService_serviceDesc.Streams[0], "/iotexapi.APIService/StreamActPool", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPIServiceStreamActPoolClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type APIService_StreamActPoolClient interface {
+	Recv() (*ActPoolEvent, error)
+	grpc.ClientStream
+}
+
+type aPIServiceStreamActPoolClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPIServiceStreamActPoolClient) Recv() (*ActPoolEvent, error) {
+	m := new(ActPoolEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // APIServiceServer is the server API for APIService service.
 type APIServiceServer interface {
 	// get the address detail of an address
@@ -1939,6 +2138,8 @@ type APIServiceServer interface {
 	ReadState(context.Context, *ReadStateRequest) (*ReadStateResponse, error)
 	// get epoch metadata
 	GetEpochMeta(context.Context, *GetEpochMetaRequest) (*GetEpochMetaResponse, error)
+	// stream add/remove events of actpool
+	StreamActPool(*StreamActPoolRequest, APIService_StreamActPoolServer) error
 }
 
 func RegisterAPIServiceServer(s *grpc.Server, srv APIServiceServer) {
@@ -2161,6 +2362,27 @@ func _APIService_GetEpochMeta_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _APIService_StreamActPool_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamActPoolRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServiceServer).StreamActPool(m, &aPIServiceStreamActPoolServer{stream})
+}
+
+type APIService_StreamActPoolServer interface {
+	Send(*ActPoolEvent) error
+	grpc.ServerStream
+}
+
+type aPIServiceStreamActPoolServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPIServiceStreamActPoolServer) Send(m *ActPoolEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _APIService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "iotexapi.APIService",
 	HandlerType: (*APIServiceServer)(nil),
@@ -2214,6 +2436,12 @@ var _APIService_serviceDesc = grpc.ServiceDesc{
 			Handler:    _APIService_GetEpochMeta_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamActPool",
+			Handler:       _APIService_StreamActPool_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "proto/api/api.proto",
 }
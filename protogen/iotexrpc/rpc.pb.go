@@ -7,6 +7,7 @@ import (
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	iotextypes "github.com/iotexproject/iotex-core/protogen/iotextypes"
 	math "math"
 )
 
@@ -29,6 +30,7 @@ const (
 	MessageType_BLOCK         MessageType = 2
 	MessageType_CONSENSUS     MessageType = 3
 	MessageType_BLOCK_REQUEST MessageType = 4
+	MessageType_BLOCK_BATCH   MessageType = 5
 	MessageType_TEST          MessageType = 10001
 )
 
@@ -38,6 +40,7 @@ var MessageType_name = map[int32]string{
 	2:     "BLOCK",
 	3:     "CONSENSUS",
 	4:     "BLOCK_REQUEST",
+	5:     "BLOCK_BATCH",
 	10001: "TEST",
 }
 
@@ -47,6 +50,7 @@ var MessageType_value = map[string]int32{
 	"BLOCK":         2,
 	"CONSENSUS":     3,
 	"BLOCK_REQUEST": 4,
+	"BLOCK_BATCH":   5,
 	"TEST":          10001,
 }
 
@@ -59,8 +63,12 @@ func (MessageType) EnumDescriptor() ([]byte, []int) {
 }
 
 type BlockSync struct {
-	Start                uint64   `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
-	End                  uint64   `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`
+	Start uint64 `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	End   uint64 `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`
+	// headers_only asks for the block headers rather than the full blocks
+	HeadersOnly bool `protobuf:"varint,4,opt,name=headers_only,json=headersOnly,proto3" json:"headers_only,omitempty"`
+	// max_bytes asks for the blocks in a BlockBatch of no more than max_bytes, rather than one block per message
+	MaxBytes             uint64   `protobuf:"varint,5,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -105,6 +113,20 @@ func (m *BlockSync) GetEnd() uint64 {
 	return 0
 }
 
+func (m *BlockSync) GetHeadersOnly() bool {
+	if m != nil {
+		return m.HeadersOnly
+	}
+	return false
+}
+
+func (m *BlockSync) GetMaxBytes() uint64 {
+	if m != nil {
+		return m.MaxBytes
+	}
+	return 0
+}
+
 type BroadcastMsg struct {
 	ChainId              uint32               `protobuf:"varint,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
 	MsgType              MessageType          `protobuf:"varint,2,opt,name=msg_type,json=msgType,proto3,enum=iotexrpc.MessageType" json:"msg_type,omitempty"`
@@ -255,41 +277,114 @@ func (m *UnicastMsg) GetTimestamp() *timestamp.Timestamp {
 	return nil
 }
 
+// BlockBatch answers a BlockSync asking for a batch with the blocks of [start, end], which is the sub-range of the
+// requested that the peer serves
+type BlockBatch struct {
+	Start                uint64                    `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End                  uint64                    `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+	Blocks               []*iotextypes.Block       `protobuf:"bytes,3,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	Headers              []*iotextypes.BlockHeader `protobuf:"bytes,4,rep,name=headers,proto3" json:"headers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *BlockBatch) Reset()         { *m = BlockBatch{} }
+func (m *BlockBatch) String() string { return proto.CompactTextString(m) }
+func (*BlockBatch) ProtoMessage()    {}
+func (*BlockBatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_59d40974ffbedc26, []int{3}
+}
+
+func (m *BlockBatch) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockBatch.Unmarshal(m, b)
+}
+func (m *BlockBatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockBatch.Marshal(b, m, deterministic)
+}
+func (m *BlockBatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockBatch.Merge(m, src)
+}
+func (m *BlockBatch) XXX_Size() int {
+	return xxx_messageInfo_BlockBatch.Size(m)
+}
+func (m *BlockBatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockBatch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockBatch proto.InternalMessageInfo
+
+func (m *BlockBatch) GetStart() uint64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *BlockBatch) GetEnd() uint64 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
+func (m *BlockBatch) GetBlocks() []*iotextypes.Block {
+	if m != nil {
+		return m.Blocks
+	}
+	return nil
+}
+
+func (m *BlockBatch) GetHeaders() []*iotextypes.BlockHeader {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("iotexrpc.MessageType", MessageType_name, MessageType_value)
 	proto.RegisterType((*BlockSync)(nil), "iotexrpc.BlockSync")
 	proto.RegisterType((*BroadcastMsg)(nil), "iotexrpc.BroadcastMsg")
 	proto.RegisterType((*UnicastMsg)(nil), "iotexrpc.UnicastMsg")
+	proto.RegisterType((*BlockBatch)(nil), "iotexrpc.BlockBatch")
 }
 
 func init() { proto.RegisterFile("proto/rpc/rpc.proto", fileDescriptor_59d40974ffbedc26) }
 
 var fileDescriptor_59d40974ffbedc26 = []byte{
-	// 411 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x92, 0xcd, 0x8e, 0xd3, 0x30,
-	0x10, 0xc7, 0xf1, 0x36, 0xfd, 0xc8, 0x74, 0x8b, 0x82, 0x01, 0x51, 0xf6, 0x42, 0xd5, 0x53, 0x85,
-	0x44, 0x82, 0x76, 0x11, 0xe2, 0x4a, 0xaa, 0x1e, 0xaa, 0x65, 0x53, 0x91, 0xa4, 0x42, 0xe2, 0x40,
-	0x95, 0xd8, 0xc6, 0x1b, 0xd8, 0xc4, 0x96, 0xed, 0x95, 0xc8, 0x63, 0xf0, 0x58, 0x3c, 0x03, 0x2f,
-	0x83, 0xec, 0x10, 0x3e, 0x0e, 0x2b, 0xed, 0x1e, 0x2c, 0xfd, 0xff, 0x33, 0xe3, 0x99, 0xf9, 0x59,
-	0x86, 0x87, 0x52, 0x09, 0x23, 0x22, 0x25, 0x89, 0x3d, 0xa1, 0x73, 0x78, 0x52, 0x09, 0xc3, 0xbe,
-	0x29, 0x49, 0x4e, 0x9e, 0x71, 0x21, 0xf8, 0x15, 0x8b, 0x5c, 0xbc, 0xbc, 0xfe, 0x1c, 0x99, 0xaa,
-	0x66, 0xda, 0x14, 0xb5, 0xec, 0x4a, 0x97, 0x67, 0xe0, 0xc7, 0x57, 0x82, 0x7c, 0xcd, 0xda, 0x86,
-	0xe0, 0x47, 0x30, 0xd4, 0xa6, 0x50, 0x66, 0x7e, 0xb4, 0x40, 0x2b, 0x2f, 0xed, 0x0c, 0x0e, 0x60,
-	0xc0, 0x1a, 0x3a, 0x1f, 0xb8, 0x98, 0x95, 0xcb, 0x1f, 0x08, 0x8e, 0x63, 0x25, 0x0a, 0x4a, 0x0a,
-	0x6d, 0x2e, 0x34, 0xc7, 0x4f, 0x61, 0x42, 0x2e, 0x8b, 0xaa, 0x39, 0x54, 0x74, 0x8e, 0x16, 0x68,
-	0x35, 0x4b, 0xc7, 0xce, 0x6f, 0x29, 0x7e, 0x09, 0x93, 0x5a, 0xf3, 0x83, 0x69, 0x25, 0x73, 0x6d,
-	0xef, 0x9f, 0x3e, 0x0e, 0xfb, 0xf5, 0xc2, 0x0b, 0xa6, 0x75, 0xc1, 0x59, 0xde, 0x4a, 0x96, 0x8e,
-	0x6b, 0xcd, 0xad, 0xb0, 0xcd, 0xec, 0x8d, 0x52, 0xd0, 0xd6, 0x0d, 0x3d, 0x76, 0xa9, 0x58, 0xd0,
-	0x16, 0x3f, 0x81, 0xb1, 0x64, 0x4c, 0xd9, 0x31, 0xde, 0x02, 0xad, 0xfc, 0x74, 0x64, 0xed, 0x96,
-	0xe2, 0x37, 0xe0, 0xff, 0x21, 0x9b, 0x0f, 0x17, 0x68, 0x35, 0x3d, 0x3d, 0x09, 0x3b, 0xf6, 0xb0,
-	0x67, 0x0f, 0xf3, 0xbe, 0x22, 0xfd, 0x5b, 0xbc, 0xfc, 0x89, 0x00, 0xf6, 0x4d, 0x75, 0x0b, 0x12,
-	0x0c, 0x5e, 0x41, 0xa9, 0x72, 0x14, 0x7e, 0xea, 0xf4, 0x7f, 0x74, 0x83, 0x3b, 0xd3, 0x79, 0x37,
-	0xd2, 0x0d, 0x6f, 0xa6, 0x1b, 0xdd, 0x81, 0xee, 0xf9, 0x27, 0x98, 0xfe, 0xb3, 0x05, 0x9e, 0xc2,
-	0x78, 0x9f, 0x9c, 0x27, 0xbb, 0x0f, 0x49, 0x70, 0x0f, 0x03, 0x8c, 0xde, 0xae, 0xf3, 0xed, 0x2e,
-	0x09, 0x10, 0xf6, 0x61, 0x18, 0xbf, 0xdb, 0xad, 0xcf, 0x83, 0x23, 0x3c, 0x03, 0x7f, 0xbd, 0x4b,
-	0xb2, 0x4d, 0x92, 0xed, 0xb3, 0x60, 0x80, 0x1f, 0xc0, 0xcc, 0x65, 0x0e, 0xe9, 0xe6, 0xfd, 0x7e,
-	0x93, 0xe5, 0x81, 0x87, 0x7d, 0xf0, 0x72, 0xab, 0xbe, 0x27, 0xf1, 0xeb, 0x8f, 0xaf, 0x78, 0x65,
-	0x2e, 0xaf, 0xcb, 0x90, 0x88, 0x3a, 0x72, 0xe4, 0x52, 0x89, 0x2f, 0x8c, 0x98, 0xce, 0xbc, 0x20,
-	0x42, 0xfd, 0xfe, 0x7d, 0x9c, 0x35, 0x51, 0xff, 0x34, 0xe5, 0xc8, 0x85, 0xce, 0x7e, 0x05, 0x00,
-	0x00, 0xff, 0xff, 0x34, 0xa7, 0x38, 0x96, 0xbf, 0x02, 0x00, 0x00,
+	// 528 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0xef, 0x8e, 0x93, 0x5e,
+	0x10, 0xfd, 0xb1, 0xd0, 0x3f, 0x0c, 0xdb, 0x9f, 0xec, 0x55, 0xb3, 0x6c, 0x35, 0xb1, 0xf6, 0x53,
+	0x35, 0x11, 0xb4, 0x1a, 0xe3, 0xd7, 0xa5, 0x69, 0xb2, 0xcd, 0xba, 0x34, 0x52, 0x1a, 0x13, 0xbf,
+	0x34, 0x17, 0xb8, 0x52, 0xb4, 0x70, 0x09, 0xf7, 0x6e, 0x52, 0x1e, 0x43, 0xdf, 0xca, 0x67, 0xf0,
+	0x65, 0x0c, 0x43, 0x6b, 0x35, 0xba, 0x89, 0xfb, 0xa1, 0xc9, 0x9c, 0x99, 0x33, 0xe5, 0x9c, 0xe1,
+	0x00, 0x77, 0x8b, 0x92, 0x4b, 0xee, 0x94, 0x45, 0x54, 0xff, 0x6c, 0x44, 0xa4, 0x9b, 0x72, 0xc9,
+	0xb6, 0x65, 0x11, 0xf5, 0x1f, 0x36, 0x63, 0x59, 0x15, 0x4c, 0x38, 0xe1, 0x86, 0x47, 0x9f, 0xa3,
+	0x35, 0x4d, 0xf3, 0x86, 0xd7, 0x7f, 0x94, 0x70, 0x9e, 0x6c, 0x98, 0x83, 0x28, 0xbc, 0xfe, 0xe8,
+	0xc8, 0x34, 0x63, 0x42, 0xd2, 0xac, 0x68, 0x08, 0x43, 0x01, 0xba, 0x5b, 0x2f, 0x2d, 0xaa, 0x3c,
+	0x22, 0xf7, 0xa0, 0x25, 0x24, 0x2d, 0xa5, 0x75, 0x34, 0x50, 0x46, 0x9a, 0xdf, 0x00, 0x62, 0x82,
+	0xca, 0xf2, 0xd8, 0x52, 0xb1, 0x57, 0x97, 0xe4, 0x31, 0x1c, 0xaf, 0x19, 0x8d, 0x59, 0x29, 0x56,
+	0x3c, 0xdf, 0x54, 0x96, 0x36, 0x50, 0x46, 0x5d, 0xdf, 0xd8, 0xf5, 0xe6, 0xf9, 0xa6, 0x22, 0x0f,
+	0x40, 0xcf, 0xe8, 0x76, 0x15, 0x56, 0x92, 0x09, 0xab, 0x85, 0xab, 0xdd, 0x8c, 0x6e, 0xdd, 0x1a,
+	0x0f, 0xbf, 0x29, 0x70, 0xec, 0x96, 0x9c, 0xc6, 0x11, 0x15, 0xf2, 0x4a, 0x24, 0xe4, 0x0c, 0xba,
+	0xa8, 0x7a, 0x95, 0xc6, 0x96, 0x32, 0x50, 0x46, 0x3d, 0xbf, 0x83, 0x78, 0x16, 0x93, 0xe7, 0xd0,
+	0xcd, 0x44, 0xb2, 0xaa, 0xfd, 0xa1, 0xac, 0xff, 0xc7, 0xf7, 0xed, 0xbd, 0x79, 0xfb, 0x8a, 0x09,
+	0x41, 0x13, 0x16, 0x54, 0x05, 0xf3, 0x3b, 0x99, 0x48, 0xea, 0x82, 0x9c, 0x35, 0x1b, 0x21, 0x8f,
+	0x2b, 0x14, 0x7d, 0x8c, 0x23, 0x97, 0xc7, 0x15, 0x39, 0x85, 0x4e, 0xc1, 0x58, 0x59, 0x3f, 0xa6,
+	0xd6, 0xac, 0xfb, 0xed, 0x1a, 0xce, 0x62, 0xf2, 0x06, 0xf4, 0x9f, 0x97, 0x41, 0xb9, 0xc6, 0xb8,
+	0x6f, 0x37, 0xb7, 0xb3, 0xf7, 0xb7, 0xb3, 0x83, 0x3d, 0xc3, 0x3f, 0x90, 0x87, 0xdf, 0x15, 0x80,
+	0x65, 0x9e, 0xfe, 0x83, 0x13, 0x02, 0x1a, 0x8d, 0xe3, 0x12, 0x5d, 0xe8, 0x3e, 0xd6, 0xbf, 0xb9,
+	0x53, 0x6f, 0xed, 0x4e, 0xbb, 0xd1, 0x5d, 0xeb, 0x66, 0x77, 0xed, 0xdb, 0xb8, 0xfb, 0xaa, 0x00,
+	0x60, 0x3e, 0x5c, 0x2a, 0xa3, 0xf5, 0x21, 0x20, 0xca, 0x5f, 0x02, 0x72, 0x74, 0x08, 0xc8, 0x13,
+	0x68, 0x63, 0x14, 0x85, 0xa5, 0x0e, 0xd4, 0x91, 0x31, 0x3e, 0x69, 0x4c, 0x61, 0x48, 0x6d, 0xfc,
+	0x3f, 0x7f, 0x47, 0x20, 0x2f, 0xa0, 0xb3, 0xcb, 0x8d, 0xa5, 0x21, 0xf7, 0xf4, 0x0f, 0xee, 0x05,
+	0xce, 0xfd, 0x3d, 0xef, 0x29, 0x07, 0xe3, 0x97, 0xd3, 0x10, 0x03, 0x3a, 0x4b, 0xef, 0xd2, 0x9b,
+	0xbf, 0xf7, 0xcc, 0xff, 0x08, 0x40, 0xfb, 0x7c, 0x12, 0xcc, 0xe6, 0x9e, 0xa9, 0x10, 0x1d, 0x5a,
+	0xee, 0xdb, 0xf9, 0xe4, 0xd2, 0x3c, 0x22, 0x3d, 0xd0, 0x27, 0x73, 0x6f, 0x31, 0xf5, 0x16, 0xcb,
+	0x85, 0xa9, 0x92, 0x13, 0xe8, 0xe1, 0x64, 0xe5, 0x4f, 0xdf, 0x2d, 0xa7, 0x8b, 0xc0, 0xd4, 0xc8,
+	0x1d, 0x30, 0x9a, 0x96, 0x7b, 0x1e, 0x4c, 0x2e, 0xcc, 0x16, 0xd1, 0x41, 0x0b, 0xea, 0xd1, 0x17,
+	0xcf, 0x7d, 0xfd, 0xe1, 0x55, 0x92, 0xca, 0xf5, 0x75, 0x68, 0x47, 0x3c, 0x73, 0x50, 0x5e, 0x51,
+	0xf2, 0x4f, 0x2c, 0x92, 0x0d, 0x78, 0x16, 0xf1, 0x72, 0xf7, 0x8d, 0x25, 0x2c, 0x77, 0xf6, 0x2f,
+	0x30, 0x6c, 0x63, 0xeb, 0xe5, 0x8f, 0x01, 0x00, 0x18, 0x68, 0x05, 0x4e, 0xc3, 0x03, 0x00, 0x00,
 }
@@ -0,0 +1,308 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: proto/signer/signer.proto
+
+package iotexsigner
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type GetPublicKeyRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPublicKeyRequest) Reset()         { *m = GetPublicKeyRequest{} }
+func (m *GetPublicKeyRequest) String() string { return proto.CompactTextString(m) }
+func (*GetPublicKeyRequest) ProtoMessage()    {}
+func (*GetPublicKeyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fb39d7ffbf21e4ab, []int{0}
+}
+
+func (m *GetPublicKeyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPublicKeyRequest.Unmarshal(m, b)
+}
+func (m *GetPublicKeyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPublicKeyRequest.Marshal(b, m, deterministic)
+}
+func (m *GetPublicKeyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPublicKeyRequest.Merge(m, src)
+}
+func (m *GetPublicKeyRequest) XXX_Size() int {
+	return xxx_messageInfo_GetPublicKeyRequest.Size(m)
+}
+func (m *GetPublicKeyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPublicKeyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPublicKeyRequest proto.InternalMessageInfo
+
+type GetPublicKeyResponse struct {
+	PublicKey            []byte   `protobuf:"bytes,1,opt,name=publicKey,proto3" json:"publicKey,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPublicKeyResponse) Reset()         { *m = GetPublicKeyResponse{} }
+func (m *GetPublicKeyResponse) String() string { return proto.CompactTextString(m) }
+func (*GetPublicKeyResponse) ProtoMessage()    {}
+func (*GetPublicKeyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fb39d7ffbf21e4ab, []int{1}
+}
+
+func (m *GetPublicKeyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPublicKeyResponse.Unmarshal(m, b)
+}
+func (m *GetPublicKeyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPublicKeyResponse.Marshal(b, m, deterministic)
+}
+func (m *GetPublicKeyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPublicKeyResponse.Merge(m, src)
+}
+func (m *GetPublicKeyResponse) XXX_Size() int {
+	return xxx_messageInfo_GetPublicKeyResponse.Size(m)
+}
+func (m *GetPublicKeyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPublicKeyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPublicKeyResponse proto.InternalMessageInfo
+
+func (m *GetPublicKeyResponse) GetPublicKey() []byte {
+	if m != nil {
+		return m.PublicKey
+	}
+	return nil
+}
+
+type SignRequest struct {
+	Digest               []byte   `protobuf:"bytes,1,opt,name=digest,proto3" json:"digest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignRequest) Reset()         { *m = SignRequest{} }
+func (m *SignRequest) String() string { return proto.CompactTextString(m) }
+func (*SignRequest) ProtoMessage()    {}
+func (*SignRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fb39d7ffbf21e4ab, []int{2}
+}
+
+func (m *SignRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignRequest.Unmarshal(m, b)
+}
+func (m *SignRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignRequest.Marshal(b, m, deterministic)
+}
+func (m *SignRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignRequest.Merge(m, src)
+}
+func (m *SignRequest) XXX_Size() int {
+	return xxx_messageInfo_SignRequest.Size(m)
+}
+func (m *SignRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignRequest proto.InternalMessageInfo
+
+func (m *SignRequest) GetDigest() []byte {
+	if m != nil {
+		return m.Digest
+	}
+	return nil
+}
+
+type SignResponse struct {
+	Signature            []byte   `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignResponse) Reset()         { *m = SignResponse{} }
+func (m *SignResponse) String() string { return proto.CompactTextString(m) }
+func (*SignResponse) ProtoMessage()    {}
+func (*SignResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_fb39d7ffbf21e4ab, []int{3}
+}
+
+func (m *SignResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SignResponse.Unmarshal(m, b)
+}
+func (m *SignResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SignResponse.Marshal(b, m, deterministic)
+}
+func (m *SignResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignResponse.Merge(m, src)
+}
+func (m *SignResponse) XXX_Size() int {
+	return xxx_messageInfo_SignResponse.Size(m)
+}
+func (m *SignResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignResponse proto.InternalMessageInfo
+
+func (m *SignResponse) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*GetPublicKeyRequest)(nil), "iotexsigner.GetPublicKeyRequest")
+	proto.RegisterType((*GetPublicKeyResponse)(nil), "iotexsigner.GetPublicKeyResponse")
+	proto.RegisterType((*SignRequest)(nil), "iotexsigner.SignRequest")
+	proto.RegisterType((*SignResponse)(nil), "iotexsigner.SignResponse")
+}
+
+func init() { proto.RegisterFile("proto/signer/signer.proto", fileDescriptor_fb39d7ffbf21e4ab) }
+
+var fileDescriptor_fb39d7ffbf21e4ab = []byte{
+	// 238 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0x2c, 0x28, 0xca, 0x2f,
+	0xc9, 0xd7, 0x2f, 0xce, 0x4c, 0xcf, 0x4b, 0x2d, 0x82, 0x52, 0x7a, 0x60, 0x31, 0x21, 0xee, 0xcc,
+	0xfc, 0x92, 0xd4, 0x0a, 0x88, 0x90, 0x92, 0x28, 0x97, 0xb0, 0x7b, 0x6a, 0x49, 0x40, 0x69, 0x52,
+	0x4e, 0x66, 0xb2, 0x77, 0x6a, 0x65, 0x50, 0x6a, 0x61, 0x69, 0x6a, 0x71, 0x89, 0x92, 0x09, 0x97,
+	0x08, 0xaa, 0x70, 0x71, 0x41, 0x7e, 0x5e, 0x71, 0xaa, 0x90, 0x0c, 0x17, 0x67, 0x01, 0x4c, 0x50,
+	0x82, 0x51, 0x81, 0x51, 0x83, 0x27, 0x08, 0x21, 0xa0, 0xa4, 0xca, 0xc5, 0x1d, 0x9c, 0x99, 0x9e,
+	0x07, 0x35, 0x44, 0x48, 0x8c, 0x8b, 0x2d, 0x25, 0x33, 0x3d, 0xb5, 0xb8, 0x04, 0xaa, 0x12, 0xca,
+	0x53, 0xd2, 0xe1, 0xe2, 0x81, 0x28, 0x43, 0x18, 0x0a, 0x72, 0x4d, 0x62, 0x49, 0x69, 0x51, 0x2a,
+	0xcc, 0x50, 0xb8, 0x80, 0xd1, 0x3c, 0x46, 0x2e, 0xb6, 0x60, 0xb0, 0x63, 0x85, 0x42, 0xb9, 0x78,
+	0x90, 0x5d, 0x25, 0xa4, 0xa0, 0x87, 0xe4, 0x15, 0x3d, 0x2c, 0xfe, 0x90, 0x52, 0xc4, 0xa3, 0x02,
+	0x62, 0xbb, 0x12, 0x83, 0x90, 0x2d, 0x17, 0x0b, 0xc8, 0x02, 0x21, 0x09, 0x14, 0xc5, 0x48, 0x3e,
+	0x91, 0x92, 0xc4, 0x22, 0x03, 0xd3, 0xee, 0x64, 0x19, 0x65, 0x9e, 0x9e, 0x59, 0x92, 0x51, 0x9a,
+	0xa4, 0x97, 0x9c, 0x9f, 0xab, 0x0f, 0x56, 0x58, 0x50, 0x94, 0x9f, 0x95, 0x9a, 0x5c, 0x02, 0xe1,
+	0xe8, 0x26, 0xe7, 0x17, 0xa5, 0xea, 0x83, 0xc3, 0x3e, 0x3d, 0x35, 0x4f, 0x1f, 0xc9, 0xa4, 0x24,
+	0x36, 0xb0, 0xa8, 0x31, 0x60, 0x00, 0x20, 0x4e, 0x0c, 0xfa, 0xae, 0x01, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// SignerClient is the client API for Signer service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type SignerClient interface {
+	// get the public key of the signing key
+	GetPublicKey(ctx context.Context, in *GetPublicKeyRequest, opts ...grpc.CallOption) (*GetPublicKeyResponse, error)
+	// sign a digest with the signing key
+	Sign(ctx context.Context, in *SignRequest, opts ...grpc.CallOption) (*SignResponse, error)
+}
+
+type signerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSignerClient(cc *grpc.ClientConn) SignerClient {
+	return &signerClient{cc}
+}
+
+func (c *signerClient) GetPublicKey(ctx context.Context, in *GetPublicKeyRequest, opts ...grpc.CallOption) (*GetPublicKeyResponse, error) {
+	out := new(GetPublicKeyResponse)
+	err := c.cc.Invoke(ctx, "/iotexsigner.Signer/GetPublicKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerClient) Sign(ctx context.Context, in *SignRequest, opts ...grpc.CallOption) (*SignResponse, error) {
+	out := new(SignResponse)
+	err := c.cc.Invoke(ctx, "/iotexsigner.Signer/Sign", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SignerServer is the server API for Signer service.
+type SignerServer interface {
+	// get the public key of the signing key
+	GetPublicKey(context.Context, *GetPublicKeyRequest) (*GetPublicKeyResponse, error)
+	// sign a digest with the signing key
+	Sign(context.Context, *SignRequest) (*SignResponse, error)
+}
+
+func RegisterSignerServer(s *grpc.Server, srv SignerServer) {
+	s.RegisterService(&_Signer_serviceDesc, srv)
+}
+
+func _Signer_GetPublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPublicKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServer).GetPublicKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iotexsigner.Signer/GetPublicKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).GetPublicKey(ctx, req.(*GetPublicKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Signer_Sign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServer).Sign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/iotexsigner.Signer/Sign",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).Sign(ctx, req.(*SignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Signer_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "iotexsigner.Signer",
+	HandlerType: (*SignerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPublicKey",
+			Handler:    _Signer_GetPublicKey_Handler,
+		},
+		{
+			MethodName: "Sign",
+			Handler:    _Signer_Sign_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/signer/signer.proto",
+}
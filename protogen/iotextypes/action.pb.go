@@ -1289,6 +1289,7 @@ type ActionCore struct {
 	//	*ActionCore_ClaimFromRewardingFund
 	//	*ActionCore_GrantReward
 	//	*ActionCore_PutPollResult
+	//	*ActionCore_RotateKey
 	Action               isActionCore_Action `protobuf_oneof:"action"`
 	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
 	XXX_unrecognized     []byte              `json:"-"`
@@ -1440,6 +1441,10 @@ type ActionCore_PutPollResult struct {
 	PutPollResult *PutPollResult `protobuf:"bytes,50,opt,name=putPollResult,proto3,oneof"`
 }
 
+type ActionCore_RotateKey struct {
+	RotateKey *RotateKey `protobuf:"bytes,40,opt,name=rotateKey,proto3,oneof"`
+}
+
 func (*ActionCore_Transfer) isActionCore_Action() {}
 
 func (*ActionCore_Vote) isActionCore_Action() {}
@@ -1484,6 +1489,8 @@ func (*ActionCore_GrantReward) isActionCore_Action() {}
 
 func (*ActionCore_PutPollResult) isActionCore_Action() {}
 
+func (*ActionCore_RotateKey) isActionCore_Action() {}
+
 func (m *ActionCore) GetAction() isActionCore_Action {
 	if m != nil {
 		return m.Action
@@ -1645,6 +1652,13 @@ func (m *ActionCore) GetPutPollResult() *PutPollResult {
 	return nil
 }
 
+func (m *ActionCore) GetRotateKey() *RotateKey {
+	if x, ok := m.GetAction().(*ActionCore_RotateKey); ok {
+		return x.RotateKey
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*ActionCore) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -1670,6 +1684,7 @@ func (*ActionCore) XXX_OneofWrappers() []interface{} {
 		(*ActionCore_ClaimFromRewardingFund)(nil),
 		(*ActionCore_GrantReward)(nil),
 		(*ActionCore_PutPollResult)(nil),
+		(*ActionCore_RotateKey)(nil),
 	}
 }
 
@@ -2027,6 +2042,69 @@ func (m *GrantReward) GetHeight() uint64 {
 	return 0
 }
 
+type RotateKey struct {
+	Delegate             string   `protobuf:"bytes,1,opt,name=delegate,proto3" json:"delegate,omitempty"`
+	NewPubKey            []byte   `protobuf:"bytes,2,opt,name=newPubKey,proto3" json:"newPubKey,omitempty"`
+	RecoveryPubKey       []byte   `protobuf:"bytes,3,opt,name=recoveryPubKey,proto3" json:"recoveryPubKey,omitempty"`
+	NewKeySignature      []byte   `protobuf:"bytes,4,opt,name=newKeySignature,proto3" json:"newKeySignature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RotateKey) Reset()         { *m = RotateKey{} }
+func (m *RotateKey) String() string { return proto.CompactTextString(m) }
+func (*RotateKey) ProtoMessage()    {}
+func (*RotateKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d4dd5ed50f883f28, []int{29}
+}
+
+func (m *RotateKey) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RotateKey.Unmarshal(m, b)
+}
+func (m *RotateKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RotateKey.Marshal(b, m, deterministic)
+}
+func (m *RotateKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RotateKey.Merge(m, src)
+}
+func (m *RotateKey) XXX_Size() int {
+	return xxx_messageInfo_RotateKey.Size(m)
+}
+func (m *RotateKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_RotateKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RotateKey proto.InternalMessageInfo
+
+func (m *RotateKey) GetDelegate() string {
+	if m != nil {
+		return m.Delegate
+	}
+	return ""
+}
+
+func (m *RotateKey) GetNewPubKey() []byte {
+	if m != nil {
+		return m.NewPubKey
+	}
+	return nil
+}
+
+func (m *RotateKey) GetRecoveryPubKey() []byte {
+	if m != nil {
+		return m.RecoveryPubKey
+	}
+	return nil
+}
+
+func (m *RotateKey) GetNewKeySignature() []byte {
+	if m != nil {
+		return m.NewKeySignature
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("iotextypes.RewardType", RewardType_name, RewardType_value)
 	proto.RegisterType((*Transfer)(nil), "iotextypes.Transfer")
@@ -2059,116 +2137,122 @@ func init() {
 	proto.RegisterType((*DepositToRewardingFund)(nil), "iotextypes.DepositToRewardingFund")
 	proto.RegisterType((*ClaimFromRewardingFund)(nil), "iotextypes.ClaimFromRewardingFund")
 	proto.RegisterType((*GrantReward)(nil), "iotextypes.GrantReward")
+	proto.RegisterType((*RotateKey)(nil), "iotextypes.RotateKey")
 }
 
 func init() { proto.RegisterFile("proto/types/action.proto", fileDescriptor_d4dd5ed50f883f28) }
 
 var fileDescriptor_d4dd5ed50f883f28 = []byte{
-	// 1685 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x58, 0x5f, 0x6f, 0x1b, 0x45,
-	0x10, 0xf7, 0xbf, 0xb8, 0xc9, 0xc4, 0x6e, 0x9c, 0x25, 0x75, 0x2f, 0x69, 0x69, 0xa3, 0x2b, 0xa0,
-	0x28, 0x14, 0x47, 0x0a, 0x6a, 0x95, 0x82, 0x54, 0xd1, 0xfc, 0xab, 0x81, 0x54, 0x98, 0x4d, 0xc4,
-	0x43, 0x41, 0x42, 0x97, 0xf3, 0xc6, 0x3e, 0x72, 0xbe, 0x3d, 0xdd, 0xed, 0xa5, 0x71, 0x1f, 0x78,
-	0x86, 0x8f, 0xc2, 0x47, 0xe0, 0x03, 0xf0, 0xc2, 0x1b, 0x1f, 0x08, 0x09, 0xed, 0x9f, 0x3b, 0xef,
-	0xfa, 0xce, 0x69, 0x53, 0x55, 0xe2, 0xcd, 0x33, 0xfb, 0xdb, 0xdf, 0xcc, 0xce, 0x8c, 0x77, 0xe6,
-	0x16, 0xac, 0x30, 0xa2, 0x8c, 0x6e, 0xb1, 0x71, 0x48, 0xe2, 0x2d, 0xc7, 0x65, 0x1e, 0x0d, 0x3a,
-	0x42, 0x85, 0xc0, 0xa3, 0x8c, 0x5c, 0x8a, 0x85, 0xb5, 0xfb, 0x03, 0x4a, 0x07, 0x3e, 0xd9, 0x12,
-	0x2b, 0xa7, 0xc9, 0xd9, 0x16, 0xf3, 0x46, 0x24, 0x66, 0xce, 0x28, 0x94, 0x60, 0xfb, 0x25, 0xcc,
-	0x9f, 0x44, 0x4e, 0x10, 0x9f, 0x91, 0x08, 0xb5, 0xa1, 0xee, 0x8c, 0x68, 0x12, 0x30, 0xab, 0xbc,
-	0x5e, 0xde, 0x58, 0xc0, 0x4a, 0x42, 0x77, 0x61, 0x21, 0x22, 0xae, 0x17, 0x7a, 0x24, 0x60, 0x56,
-	0x45, 0x2c, 0x4d, 0x14, 0xc8, 0x82, 0x1b, 0xa1, 0x33, 0xf6, 0xa9, 0xd3, 0xb7, 0xaa, 0xeb, 0xe5,
-	0x8d, 0x06, 0x4e, 0x45, 0xbb, 0x0f, 0xb5, 0x1f, 0x28, 0x23, 0x68, 0x07, 0x16, 0x32, 0xb3, 0x82,
-	0x7a, 0x71, 0x7b, 0xad, 0x23, 0x1d, 0xeb, 0xa4, 0x8e, 0x75, 0x4e, 0x52, 0x04, 0x9e, 0x80, 0x91,
-	0x0d, 0x8d, 0x0b, 0xca, 0x08, 0x79, 0xd6, 0xef, 0x47, 0x24, 0x8e, 0x95, 0x71, 0x43, 0x67, 0x8f,
-	0x61, 0x61, 0xcf, 0x09, 0xfa, 0x5e, 0xdf, 0x61, 0x84, 0x3b, 0xe3, 0x28, 0xac, 0x3c, 0x43, 0x2a,
-	0xa2, 0x15, 0x98, 0xe3, 0xdb, 0x24, 0x47, 0x03, 0x4b, 0x81, 0x1f, 0x39, 0x4c, 0x4e, 0xbf, 0x25,
-	0x63, 0xe5, 0xbb, 0x92, 0xd0, 0x47, 0xd0, 0x8c, 0xc8, 0x2b, 0x27, 0xea, 0xa7, 0x96, 0x6b, 0x82,
-	0xcd, 0x54, 0xda, 0x87, 0xd0, 0xcc, 0x4c, 0x1f, 0x79, 0x31, 0x43, 0x8f, 0x00, 0xdc, 0x54, 0xc1,
-	0x3d, 0xa8, 0x6e, 0x2c, 0x6e, 0xdf, 0xea, 0x4c, 0xf2, 0xd1, 0xc9, 0xe0, 0x58, 0x03, 0xda, 0xa7,
-	0xd0, 0xec, 0x25, 0xac, 0x47, 0x7d, 0x1f, 0x93, 0x38, 0xf1, 0x19, 0x77, 0x6b, 0x48, 0xbc, 0xc1,
-	0x50, 0x66, 0xa2, 0x86, 0x95, 0x84, 0x9e, 0x18, 0xfc, 0x15, 0x11, 0xca, 0xd5, 0x42, 0x7e, 0xee,
-	0x8e, 0x61, 0xe3, 0x18, 0x16, 0x0e, 0x2e, 0x89, 0x9b, 0xf0, 0x42, 0x99, 0x99, 0xe9, 0x35, 0x98,
-	0x77, 0x69, 0xc0, 0x22, 0xc7, 0x4d, 0x13, 0x9d, 0xc9, 0x08, 0x41, 0xad, 0xef, 0x30, 0x47, 0x05,
-	0x4a, 0xfc, 0xb6, 0xff, 0x29, 0x43, 0xf3, 0x98, 0x39, 0x11, 0x3b, 0x4e, 0x4e, 0xf7, 0x86, 0x8e,
-	0x17, 0xf0, 0x04, 0xb8, 0xfc, 0xc7, 0xd7, 0xfb, 0x82, 0xba, 0x89, 0x53, 0x11, 0x6d, 0xc0, 0x52,
-	0x4c, 0xdc, 0x24, 0xf2, 0xd8, 0x78, 0x9f, 0x84, 0x34, 0xf6, 0x52, 0x13, 0xd3, 0x6a, 0xb4, 0x09,
-	0x2d, 0x1a, 0x92, 0xc8, 0xe1, 0xae, 0xa6, 0xd0, 0xaa, 0x80, 0xe6, 0xf4, 0x68, 0x1d, 0x16, 0x63,
-	0xee, 0x40, 0x57, 0x86, 0xab, 0x26, 0xc2, 0xa5, 0xab, 0x50, 0x07, 0x50, 0xe8, 0x44, 0x24, 0x50,
-	0xf2, 0x77, 0x67, 0x67, 0x31, 0x61, 0xd6, 0x9c, 0x00, 0x16, 0xac, 0xd8, 0x11, 0x34, 0x8e, 0x19,
-	0x0d, 0xdf, 0xe2, 0x44, 0xf7, 0x00, 0x62, 0x46, 0x43, 0x65, 0xba, 0x22, 0x18, 0x35, 0x8d, 0x38,
-	0xb1, 0x62, 0x49, 0xcb, 0xa8, 0xaa, 0x4e, 0x6c, 0xaa, 0xed, 0xc7, 0x00, 0x2f, 0x48, 0x74, 0xee,
-	0x13, 0x4c, 0xa9, 0x88, 0x74, 0xe0, 0x8c, 0x88, 0xca, 0x8d, 0xf8, 0x2d, 0xca, 0xd7, 0xf1, 0x13,
-	0x92, 0x95, 0x2f, 0x17, 0xec, 0xd7, 0x30, 0xdf, 0x4b, 0xd8, 0xae, 0x4f, 0xdd, 0xf3, 0x22, 0x6b,
-	0xe5, 0x42, 0x6b, 0x5a, 0x75, 0x55, 0x8c, 0xea, 0x7a, 0x08, 0x73, 0x11, 0xa5, 0x8c, 0x7b, 0xc9,
-	0x0b, 0xb7, 0xad, 0x17, 0xd6, 0xc4, 0x3d, 0x2c, 0x41, 0xf6, 0xcf, 0xd0, 0xdc, 0x8b, 0x88, 0xc3,
-	0x48, 0x9a, 0x8a, 0xd9, 0x81, 0x9a, 0x94, 0x5b, 0x65, 0xf6, 0xc5, 0x52, 0x9d, 0xba, 0x58, 0xec,
-	0x1f, 0xa1, 0x79, 0x4c, 0x18, 0xf3, 0x33, 0x03, 0xef, 0x76, 0x3f, 0xad, 0xc0, 0x9c, 0x17, 0xf4,
-	0xc9, 0xa5, 0x30, 0x50, 0xc3, 0x52, 0xb0, 0x97, 0x61, 0x49, 0x7a, 0xdf, 0xf3, 0x93, 0x91, 0x88,
-	0x8e, 0xfd, 0x14, 0xd0, 0x09, 0x89, 0x46, 0x5e, 0xa0, 0x6b, 0xdf, 0x3e, 0xac, 0xf6, 0x5f, 0x65,
-	0x68, 0xf0, 0x7d, 0xef, 0x31, 0x23, 0x4f, 0xcc, 0x8c, 0x3c, 0xd0, 0x33, 0xa2, 0x9b, 0xea, 0xf0,
-	0xc4, 0xc4, 0x07, 0x01, 0x8b, 0xc6, 0x2a, 0x3d, 0x6b, 0x3b, 0x00, 0x13, 0x25, 0x6a, 0x41, 0xf5,
-	0x9c, 0x8c, 0x95, 0x79, 0xfe, 0xb3, 0xb8, 0xa0, 0xbe, 0xa8, 0xec, 0x94, 0xed, 0x18, 0x96, 0xc5,
-	0xf1, 0x8d, 0xe4, 0x5e, 0xeb, 0x2c, 0xef, 0x90, 0xec, 0x7f, 0x2b, 0xd0, 0xe4, 0x56, 0xc5, 0x6d,
-	0x72, 0x70, 0x79, 0x2d, 0x8b, 0x9b, 0xd0, 0x0a, 0x23, 0x72, 0xe1, 0xd1, 0x24, 0x4e, 0x7b, 0x99,
-	0x3a, 0x55, 0x4e, 0x8f, 0x9e, 0xc2, 0xda, 0xb4, 0x4e, 0x44, 0xb0, 0x17, 0x51, 0x7a, 0xa6, 0xee,
-	0xb6, 0x2b, 0x10, 0xe8, 0x2b, 0xb8, 0x53, 0xb8, 0x6a, 0xdc, 0x3f, 0x57, 0x41, 0x78, 0x4f, 0x23,
-	0x97, 0x1e, 0xcb, 0x3c, 0x9d, 0x13, 0x36, 0x0d, 0x1d, 0x7a, 0x0c, 0x6d, 0x5d, 0xd6, 0x3c, 0xac,
-	0x0b, 0xf4, 0x8c, 0x55, 0xb4, 0x03, 0xb7, 0x73, 0x2b, 0xca, 0xb3, 0x1b, 0xc2, 0xb3, 0x59, 0xcb,
-	0xf6, 0xef, 0x15, 0x95, 0xf5, 0xa1, 0xe3, 0xfb, 0x24, 0x18, 0x90, 0x6b, 0xe6, 0xa0, 0x0d, 0x75,
-	0x97, 0x8a, 0xff, 0xbe, 0xaa, 0x60, 0x29, 0xa1, 0x87, 0xb0, 0xec, 0xa6, 0x94, 0xd9, 0x91, 0x65,
-	0x98, 0xf3, 0x0b, 0x3c, 0xba, 0x39, 0xa5, 0x76, 0xf8, 0x9a, 0xd8, 0x77, 0x15, 0x04, 0xed, 0xc2,
-	0xdd, 0xe2, 0x65, 0x15, 0x06, 0x79, 0xef, 0x5f, 0x89, 0xb1, 0xff, 0xac, 0xc0, 0x2a, 0x8f, 0x05,
-	0x26, 0x71, 0x48, 0x83, 0x98, 0xfc, 0xbf, 0x31, 0xd9, 0x84, 0x56, 0xa4, 0x1c, 0xc9, 0xc0, 0x32,
-	0x10, 0x39, 0x3d, 0xaf, 0xee, 0x69, 0x9d, 0x16, 0x3e, 0x59, 0x69, 0x57, 0x20, 0xde, 0x54, 0xdd,
-	0xf5, 0x37, 0x56, 0xb7, 0x7d, 0x02, 0x2d, 0x1e, 0xba, 0x43, 0x2f, 0x70, 0x7c, 0xef, 0xf5, 0x7b,
-	0x8a, 0x98, 0xfd, 0xa9, 0x2c, 0xce, 0x5c, 0x3b, 0x50, 0xe0, 0xb2, 0x01, 0xfe, 0x55, 0x5e, 0xc3,
-	0xfa, 0x58, 0x5b, 0x84, 0xe3, 0x7f, 0xc4, 0x3e, 0x09, 0xa8, 0xb8, 0xf0, 0x3d, 0x1a, 0xa8, 0x2b,
-	0xc3, 0xd0, 0xf1, 0x5b, 0x92, 0xbe, 0x0a, 0x54, 0x7a, 0x16, 0xb0, 0x14, 0xcc, 0xab, 0xac, 0x36,
-	0x7d, 0x95, 0xfd, 0xd6, 0x04, 0x78, 0x26, 0x06, 0xf2, 0x3d, 0x1a, 0x89, 0x91, 0xf4, 0x82, 0x44,
-	0x31, 0xb7, 0xa0, 0xda, 0xa2, 0x12, 0x39, 0x79, 0x40, 0x03, 0x97, 0xa8, 0xc3, 0x4a, 0x81, 0xcf,
-	0x60, 0x03, 0x27, 0x3e, 0xf2, 0x46, 0x6a, 0xea, 0xa9, 0xe1, 0x4c, 0x56, 0x6b, 0xbd, 0xc8, 0x73,
-	0x89, 0xb2, 0x9b, 0xc9, 0x68, 0x1b, 0xe6, 0x59, 0x5a, 0x1f, 0x20, 0x26, 0xc3, 0x15, 0xbd, 0x5d,
-	0xa4, 0xe1, 0xe8, 0x96, 0x70, 0x86, 0x43, 0x9f, 0x40, 0x8d, 0xcf, 0xc1, 0xd6, 0xa2, 0xc0, 0xb7,
-	0x74, 0x3c, 0x9f, 0xdc, 0xbb, 0x25, 0x2c, 0xd6, 0xd1, 0x23, 0x58, 0x20, 0xe9, 0xf0, 0x68, 0x35,
-	0x04, 0xd8, 0x18, 0x6b, 0xb3, 0xc9, 0xb2, 0x5b, 0xc2, 0x13, 0x24, 0x7a, 0x06, 0xcd, 0x58, 0x9f,
-	0x0e, 0xad, 0x66, 0x7e, 0x62, 0x35, 0xc6, 0xc7, 0x6e, 0x09, 0x9b, 0x3b, 0xd0, 0x53, 0x68, 0xc4,
-	0xda, 0x34, 0x66, 0xdd, 0x14, 0x0c, 0x96, 0xc9, 0x30, 0x59, 0xef, 0x96, 0xb0, 0x81, 0xe7, 0x51,
-	0x09, 0x55, 0x93, 0xb4, 0x96, 0xf2, 0x51, 0x49, 0x1b, 0x28, 0x8f, 0x4a, 0x8a, 0xe3, 0x6e, 0xbb,
-	0x7a, 0xf3, 0xb3, 0x5a, 0x05, 0x83, 0xb6, 0x0e, 0xe0, 0x6e, 0x1b, 0x3b, 0xc4, 0xc9, 0xf5, 0x62,
-	0xb5, 0x96, 0x0b, 0x4e, 0xae, 0x03, 0xc4, 0xc9, 0x8d, 0xf2, 0x7e, 0x0e, 0x4b, 0xae, 0x39, 0xa1,
-	0x58, 0x48, 0x90, 0xdc, 0xc9, 0xfb, 0x91, 0x41, 0xba, 0x25, 0x3c, 0xbd, 0x0b, 0xf5, 0x00, 0xb1,
-	0xdc, 0x5c, 0x63, 0x7d, 0x20, 0xb8, 0xee, 0x19, 0x25, 0x92, 0x43, 0x75, 0x4b, 0xb8, 0x60, 0x2f,
-	0x4f, 0x4a, 0xa8, 0x4d, 0x1f, 0xd6, 0x4a, 0x3e, 0x29, 0xfa, 0x74, 0xc2, 0x93, 0xa2, 0xe3, 0xd1,
-	0x0b, 0x58, 0x0e, 0xa7, 0x27, 0x0c, 0xeb, 0x96, 0x20, 0xf9, 0x70, 0x9a, 0x64, 0x3a, 0xd0, 0xf9,
-	0x9d, 0x3c, 0xd8, 0xa1, 0x3e, 0x3a, 0x58, 0xed, 0x7c, 0xb0, 0x8d, 0xd9, 0x82, 0x07, 0xdb, 0xd8,
-	0x91, 0x79, 0xa4, 0xdf, 0xf4, 0xd6, 0xed, 0x19, 0x1e, 0xe9, 0xa0, 0xcc, 0x23, 0xa3, 0x47, 0x10,
-	0x58, 0x0d, 0x67, 0x35, 0x10, 0xcb, 0x12, 0xb4, 0x1f, 0x4f, 0xd3, 0x16, 0x82, 0xbb, 0x25, 0x3c,
-	0x9b, 0x09, 0x7d, 0x03, 0xad, 0x70, 0xea, 0xb2, 0xb5, 0x56, 0x05, 0xfb, 0xdd, 0x69, 0x76, 0x1d,
-	0xd3, 0x2d, 0xe1, 0xdc, 0xbe, 0x34, 0x02, 0x46, 0x51, 0x5a, 0x6b, 0xc5, 0x11, 0x98, 0xae, 0xdc,
-	0xfc, 0xce, 0xb4, 0x44, 0xb2, 0x8e, 0x75, 0xa7, 0xb8, 0x44, 0xb4, 0x5b, 0xc9, 0xc0, 0xa3, 0x9f,
-	0xa0, 0xdd, 0x97, 0x54, 0x27, 0x14, 0x8b, 0x8f, 0x6e, 0x2f, 0x18, 0x1c, 0x26, 0x41, 0xdf, 0xba,
-	0x27, 0x98, 0x6c, 0x9d, 0x69, 0xbf, 0x10, 0xd9, 0x2d, 0xe1, 0x19, 0x1c, 0x9c, 0xdd, 0xf5, 0x1d,
-	0x6f, 0x74, 0x18, 0xd1, 0x91, 0xc9, 0x7e, 0x3f, 0xcf, 0xbe, 0x57, 0x88, 0xe4, 0xec, 0xc5, 0x1c,
-	0xe8, 0x4b, 0x58, 0x1c, 0x44, 0x4e, 0xc0, 0xa4, 0xd6, 0x5a, 0x17, 0x94, 0xb7, 0x75, 0xca, 0xe7,
-	0x93, 0xe5, 0x6e, 0x09, 0xeb, 0x68, 0x51, 0xcc, 0xfa, 0x5b, 0x80, 0xb5, 0x5d, 0x50, 0xcc, 0x3a,
-	0x40, 0x14, 0xb3, 0xae, 0xd8, 0x9d, 0x87, 0xba, 0x7c, 0x10, 0xb2, 0x2f, 0xa0, 0x2e, 0x3b, 0x11,
-	0xda, 0x84, 0x9a, 0x4b, 0x23, 0xa2, 0x9e, 0x5f, 0x8c, 0x4f, 0xbb, 0x49, 0xaf, 0xc2, 0x02, 0xc3,
-	0x1b, 0x63, 0x4c, 0x82, 0x3e, 0x89, 0x7a, 0xf2, 0x69, 0x44, 0x35, 0x46, 0x5d, 0xc7, 0x5b, 0x60,
-	0xec, 0x0d, 0x02, 0x87, 0x25, 0x11, 0x51, 0xb3, 0xcb, 0x44, 0x61, 0xff, 0x5d, 0x86, 0x1b, 0x98,
-	0xb8, 0xc4, 0x0b, 0x45, 0x9b, 0x8e, 0x99, 0xc3, 0x92, 0x38, 0x6d, 0xbf, 0x52, 0xe2, 0x0c, 0xa7,
-	0xfe, 0xb9, 0xf1, 0xf1, 0x3c, 0x51, 0x88, 0x87, 0x1c, 0x97, 0x75, 0x9d, 0x78, 0x98, 0xbe, 0x2a,
-	0x29, 0x91, 0x7f, 0xf1, 0x0f, 0x9c, 0x78, 0x8f, 0x06, 0x71, 0x32, 0x22, 0xfd, 0xf4, 0x8b, 0x5f,
-	0x53, 0xf1, 0x79, 0x23, 0x7d, 0xb5, 0x48, 0xe7, 0x8d, 0x39, 0x39, 0x6f, 0x4c, 0xa9, 0xd1, 0x03,
-	0xa8, 0xf9, 0x74, 0x10, 0x5b, 0x75, 0xf1, 0x79, 0xb5, 0xa4, 0x47, 0xe5, 0x88, 0x0e, 0xb0, 0x58,
-	0xb4, 0xff, 0x28, 0x43, 0xf5, 0x88, 0x0e, 0x8a, 0x68, 0xcb, 0xc5, 0xb4, 0x6d, 0xa8, 0x33, 0x1a,
-	0x7a, 0x6e, 0x6c, 0x55, 0xd6, 0xab, 0x1b, 0x0d, 0xac, 0xa4, 0xa2, 0x27, 0x14, 0x33, 0x0c, 0xb5,
-	0x2b, 0xc2, 0x30, 0x67, 0x86, 0x21, 0xfb, 0xac, 0xad, 0x8b, 0xa1, 0x42, 0x7d, 0xd6, 0xee, 0x43,
-	0xbb, 0xf8, 0xcf, 0x30, 0xf3, 0xe3, 0x39, 0xf5, 0xa9, 0xa2, 0x3d, 0xeb, 0xec, 0x43, 0xbb, 0xb8,
-	0xe8, 0xaf, 0xc5, 0xf2, 0x3d, 0x2c, 0x6a, 0x75, 0xce, 0x2b, 0x90, 0x47, 0x56, 0x6c, 0xbc, 0x69,
-	0x56, 0xa0, 0x44, 0x9c, 0x8c, 0x43, 0x82, 0x05, 0x66, 0xd6, 0xf7, 0xf0, 0x66, 0x07, 0x60, 0x82,
-	0x45, 0x4b, 0xb0, 0x28, 0xfa, 0x89, 0x54, 0xb5, 0x4a, 0x5c, 0x71, 0x10, 0x52, 0x77, 0xa8, 0x14,
-	0xe5, 0xdd, 0x9d, 0x97, 0x8f, 0x07, 0x1e, 0x1b, 0x26, 0xa7, 0x1d, 0x97, 0x8e, 0xb6, 0x84, 0xc5,
-	0x30, 0xa2, 0xbf, 0x10, 0x97, 0x49, 0xe1, 0x33, 0x5e, 0xef, 0xf2, 0x71, 0x74, 0x40, 0x82, 0xad,
-	0x89, 0x4b, 0xa7, 0x75, 0xa1, 0xfc, 0xfc, 0xbf, 0x00, 0x00, 0x00, 0xff, 0xff, 0xf4, 0x4c, 0xaa,
-	0x42, 0x67, 0x15, 0x00, 0x00,
+	// 1764 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x58, 0x5f, 0x6f, 0xdb, 0xc8,
+	0x11, 0xd7, 0x3f, 0x2b, 0xd6, 0xd8, 0x8a, 0xe5, 0xad, 0xa3, 0xd0, 0x4e, 0x9a, 0x18, 0x4c, 0x1b,
+	0x18, 0x6e, 0x2a, 0x03, 0x2e, 0x12, 0x38, 0x2d, 0x10, 0x34, 0xfe, 0x17, 0xb5, 0x71, 0x50, 0x95,
+	0x36, 0xfa, 0x90, 0x16, 0x28, 0x68, 0x6a, 0x2d, 0xf3, 0x4c, 0x71, 0x89, 0xe5, 0xd2, 0xb6, 0xf2,
+	0x70, 0xef, 0xf7, 0x09, 0xee, 0x33, 0xdc, 0x47, 0xb8, 0xa7, 0x7b, 0xba, 0x97, 0x7b, 0xbb, 0x0f,
+	0x74, 0xc0, 0x61, 0xff, 0x90, 0xda, 0x25, 0x29, 0x27, 0x0e, 0x02, 0xdc, 0x9b, 0x66, 0xf6, 0xb7,
+	0xb3, 0xb3, 0xbf, 0x19, 0xcd, 0x0c, 0x17, 0xac, 0x88, 0x12, 0x46, 0xb6, 0xd8, 0x24, 0xc2, 0xf1,
+	0x96, 0xeb, 0x31, 0x9f, 0x84, 0x3d, 0xa1, 0x42, 0xe0, 0x13, 0x86, 0xaf, 0xc5, 0xc2, 0xda, 0xe3,
+	0x11, 0x21, 0xa3, 0x00, 0x6f, 0x89, 0x95, 0xd3, 0xe4, 0x6c, 0x8b, 0xf9, 0x63, 0x1c, 0x33, 0x77,
+	0x1c, 0x49, 0xb0, 0xfd, 0x1e, 0xe6, 0x4f, 0xa8, 0x1b, 0xc6, 0x67, 0x98, 0xa2, 0x2e, 0x34, 0xdd,
+	0x31, 0x49, 0x42, 0x66, 0x55, 0xd7, 0xab, 0x1b, 0x2d, 0x47, 0x49, 0xe8, 0x21, 0xb4, 0x28, 0xf6,
+	0xfc, 0xc8, 0xc7, 0x21, 0xb3, 0x6a, 0x62, 0x69, 0xaa, 0x40, 0x16, 0xdc, 0x89, 0xdc, 0x49, 0x40,
+	0xdc, 0xa1, 0x55, 0x5f, 0xaf, 0x6e, 0x2c, 0x3a, 0xa9, 0x68, 0x0f, 0xa1, 0xf1, 0x1f, 0xc2, 0x30,
+	0xda, 0x81, 0x56, 0x76, 0xac, 0x30, 0xbd, 0xb0, 0xbd, 0xd6, 0x93, 0x8e, 0xf5, 0x52, 0xc7, 0x7a,
+	0x27, 0x29, 0xc2, 0x99, 0x82, 0x91, 0x0d, 0x8b, 0x97, 0x84, 0x61, 0xfc, 0x7a, 0x38, 0xa4, 0x38,
+	0x8e, 0xd5, 0xe1, 0x86, 0xce, 0x9e, 0x40, 0x6b, 0xcf, 0x0d, 0x87, 0xfe, 0xd0, 0x65, 0x98, 0x3b,
+	0xe3, 0x2a, 0xac, 0xbc, 0x43, 0x2a, 0xa2, 0x15, 0x98, 0xe3, 0xdb, 0xa4, 0x8d, 0x45, 0x47, 0x0a,
+	0xfc, 0xca, 0x51, 0x72, 0xfa, 0x16, 0x4f, 0x94, 0xef, 0x4a, 0x42, 0x7f, 0x80, 0x36, 0xc5, 0x57,
+	0x2e, 0x1d, 0xa6, 0x27, 0x37, 0x84, 0x35, 0x53, 0x69, 0x1f, 0x42, 0x3b, 0x3b, 0xfa, 0xc8, 0x8f,
+	0x19, 0x7a, 0x0e, 0xe0, 0xa5, 0x0a, 0xee, 0x41, 0x7d, 0x63, 0x61, 0xfb, 0x5e, 0x6f, 0x1a, 0x8f,
+	0x5e, 0x06, 0x77, 0x34, 0xa0, 0x7d, 0x0a, 0xed, 0x41, 0xc2, 0x06, 0x24, 0x08, 0x1c, 0x1c, 0x27,
+	0x01, 0xe3, 0x6e, 0x9d, 0x63, 0x7f, 0x74, 0x2e, 0x23, 0xd1, 0x70, 0x94, 0x84, 0x5e, 0x1a, 0xf6,
+	0x6b, 0x82, 0xca, 0xd5, 0x52, 0xfb, 0xdc, 0x1d, 0xe3, 0x8c, 0x63, 0x68, 0x1d, 0x5c, 0x63, 0x2f,
+	0xe1, 0x89, 0x32, 0x33, 0xd2, 0x6b, 0x30, 0xef, 0x91, 0x90, 0x51, 0xd7, 0x4b, 0x03, 0x9d, 0xc9,
+	0x08, 0x41, 0x63, 0xe8, 0x32, 0x57, 0x11, 0x25, 0x7e, 0xdb, 0x3f, 0x57, 0xa1, 0x7d, 0xcc, 0x5c,
+	0xca, 0x8e, 0x93, 0xd3, 0xbd, 0x73, 0xd7, 0x0f, 0x79, 0x00, 0x3c, 0xfe, 0xe3, 0x1f, 0xfb, 0xc2,
+	0x74, 0xdb, 0x49, 0x45, 0xb4, 0x01, 0x4b, 0x31, 0xf6, 0x12, 0xea, 0xb3, 0xc9, 0x3e, 0x8e, 0x48,
+	0xec, 0xa7, 0x47, 0xe4, 0xd5, 0x68, 0x13, 0x3a, 0x24, 0xc2, 0xd4, 0xe5, 0xae, 0xa6, 0xd0, 0xba,
+	0x80, 0x16, 0xf4, 0x68, 0x1d, 0x16, 0x62, 0xee, 0x40, 0x5f, 0xd2, 0xd5, 0x10, 0x74, 0xe9, 0x2a,
+	0xd4, 0x03, 0x14, 0xb9, 0x14, 0x87, 0x4a, 0xfe, 0xd7, 0xd9, 0x59, 0x8c, 0x99, 0x35, 0x27, 0x80,
+	0x25, 0x2b, 0x36, 0x85, 0xc5, 0x63, 0x46, 0xa2, 0x4f, 0xb8, 0xd1, 0x23, 0x80, 0x98, 0x91, 0x48,
+	0x1d, 0x5d, 0x13, 0x16, 0x35, 0x8d, 0xb8, 0xb1, 0xb2, 0x92, 0xa6, 0x51, 0x5d, 0xdd, 0xd8, 0x54,
+	0xdb, 0x2f, 0x00, 0xde, 0x61, 0x7a, 0x11, 0x60, 0x87, 0x10, 0xc1, 0x74, 0xe8, 0x8e, 0xb1, 0x8a,
+	0x8d, 0xf8, 0x2d, 0xd2, 0xd7, 0x0d, 0x12, 0x9c, 0xa5, 0x2f, 0x17, 0xec, 0x0f, 0x30, 0x3f, 0x48,
+	0xd8, 0x6e, 0x40, 0xbc, 0x8b, 0xb2, 0xd3, 0xaa, 0xa5, 0xa7, 0x69, 0xd9, 0x55, 0x33, 0xb2, 0xeb,
+	0x19, 0xcc, 0x51, 0x42, 0x18, 0xf7, 0x92, 0x27, 0x6e, 0x57, 0x4f, 0xac, 0xa9, 0x7b, 0x8e, 0x04,
+	0xd9, 0xff, 0x87, 0xf6, 0x1e, 0xc5, 0x2e, 0xc3, 0x69, 0x28, 0x66, 0x13, 0x35, 0x4d, 0xb7, 0xda,
+	0xec, 0xc2, 0x52, 0xcf, 0x15, 0x16, 0xfb, 0xbf, 0xd0, 0x3e, 0xc6, 0x8c, 0x05, 0xd9, 0x01, 0x9f,
+	0x57, 0x9f, 0x56, 0x60, 0xce, 0x0f, 0x87, 0xf8, 0x5a, 0x1c, 0xd0, 0x70, 0xa4, 0x60, 0x2f, 0xc3,
+	0x92, 0xf4, 0x7e, 0x10, 0x24, 0x63, 0xc1, 0x8e, 0xfd, 0x0a, 0xd0, 0x09, 0xa6, 0x63, 0x3f, 0xd4,
+	0xb5, 0x9f, 0x4e, 0xab, 0xfd, 0x63, 0x15, 0x16, 0xf9, 0xbe, 0x2f, 0x18, 0x91, 0x97, 0x66, 0x44,
+	0x9e, 0xe8, 0x11, 0xd1, 0x8f, 0xea, 0xf1, 0xc0, 0xc4, 0x07, 0x21, 0xa3, 0x13, 0x15, 0x9e, 0xb5,
+	0x1d, 0x80, 0xa9, 0x12, 0x75, 0xa0, 0x7e, 0x81, 0x27, 0xea, 0x78, 0xfe, 0xb3, 0x3c, 0xa1, 0xfe,
+	0x5a, 0xdb, 0xa9, 0xda, 0x31, 0x2c, 0x8b, 0xeb, 0x1b, 0xc1, 0xbd, 0xd5, 0x5d, 0x3e, 0x23, 0xd8,
+	0xbf, 0xd4, 0xa0, 0xcd, 0x4f, 0x15, 0xd5, 0xe4, 0xe0, 0xfa, 0x56, 0x27, 0x6e, 0x42, 0x27, 0xa2,
+	0xf8, 0xd2, 0x27, 0x49, 0x9c, 0xf6, 0x32, 0x75, 0xab, 0x82, 0x1e, 0xbd, 0x82, 0xb5, 0xbc, 0x4e,
+	0x30, 0x38, 0xa0, 0x84, 0x9c, 0xa9, 0xda, 0x76, 0x03, 0x02, 0xfd, 0x1d, 0x1e, 0x94, 0xae, 0x1a,
+	0xf5, 0xe7, 0x26, 0x08, 0xef, 0x69, 0xf8, 0xda, 0x67, 0x99, 0xa7, 0x73, 0xe2, 0x4c, 0x43, 0x87,
+	0x5e, 0x40, 0x57, 0x97, 0x35, 0x0f, 0x9b, 0x02, 0x3d, 0x63, 0x15, 0xed, 0xc0, 0xfd, 0xc2, 0x8a,
+	0xf2, 0xec, 0x8e, 0xf0, 0x6c, 0xd6, 0xb2, 0xfd, 0x4d, 0x4d, 0x45, 0xfd, 0xdc, 0x0d, 0x02, 0x1c,
+	0x8e, 0xf0, 0x2d, 0x63, 0xd0, 0x85, 0xa6, 0x47, 0xc4, 0x7f, 0x5f, 0x65, 0xb0, 0x94, 0xd0, 0x33,
+	0x58, 0xf6, 0x52, 0x93, 0xd9, 0x95, 0x25, 0xcd, 0xc5, 0x05, 0xce, 0x6e, 0x41, 0xa9, 0x5d, 0xbe,
+	0x21, 0xf6, 0xdd, 0x04, 0x41, 0xbb, 0xf0, 0xb0, 0x7c, 0x59, 0xd1, 0x20, 0xeb, 0xfe, 0x8d, 0x18,
+	0xfb, 0xfb, 0x1a, 0xac, 0x72, 0x2e, 0x1c, 0x1c, 0x47, 0x24, 0x8c, 0xf1, 0x6f, 0xcb, 0xc9, 0x26,
+	0x74, 0xa8, 0x72, 0x24, 0x03, 0x4b, 0x22, 0x0a, 0x7a, 0x9e, 0xdd, 0x79, 0x9d, 0x46, 0x9f, 0xcc,
+	0xb4, 0x1b, 0x10, 0x1f, 0xcb, 0xee, 0xe6, 0x47, 0xb3, 0xdb, 0x3e, 0x81, 0x0e, 0xa7, 0xee, 0xd0,
+	0x0f, 0xdd, 0xc0, 0xff, 0xf0, 0x85, 0x18, 0xb3, 0xff, 0x24, 0x93, 0xb3, 0xd0, 0x0e, 0x14, 0xb8,
+	0x6a, 0x80, 0xbf, 0x96, 0x65, 0x58, 0x1f, 0x6b, 0xcb, 0x70, 0xfc, 0x8f, 0x38, 0xc4, 0x21, 0x11,
+	0x05, 0xdf, 0x27, 0xa1, 0x2a, 0x19, 0x86, 0x8e, 0x57, 0x49, 0x72, 0x15, 0xaa, 0xf0, 0xb4, 0x1c,
+	0x29, 0x98, 0xa5, 0xac, 0x91, 0x2f, 0x65, 0x3f, 0xb4, 0x01, 0x5e, 0x8b, 0x81, 0x7c, 0x8f, 0x50,
+	0x31, 0x92, 0x5e, 0x62, 0x1a, 0xf3, 0x13, 0x54, 0x5b, 0x54, 0x22, 0x37, 0x1e, 0x92, 0xd0, 0xc3,
+	0xea, 0xb2, 0x52, 0xe0, 0x33, 0xd8, 0xc8, 0x8d, 0x8f, 0xfc, 0xb1, 0x9a, 0x7a, 0x1a, 0x4e, 0x26,
+	0xab, 0xb5, 0x01, 0xf5, 0x3d, 0xac, 0xce, 0xcd, 0x64, 0xb4, 0x0d, 0xf3, 0x2c, 0xcd, 0x0f, 0x10,
+	0x93, 0xe1, 0x8a, 0xde, 0x2e, 0x52, 0x3a, 0xfa, 0x15, 0x27, 0xc3, 0xa1, 0xa7, 0xd0, 0xe0, 0x73,
+	0xb0, 0xb5, 0x20, 0xf0, 0x1d, 0x1d, 0xcf, 0x27, 0xf7, 0x7e, 0xc5, 0x11, 0xeb, 0xe8, 0x39, 0xb4,
+	0x70, 0x3a, 0x3c, 0x5a, 0x8b, 0xeb, 0xd5, 0xfc, 0x58, 0x9b, 0x4d, 0x96, 0xfd, 0x8a, 0x33, 0x45,
+	0xa2, 0xd7, 0xd0, 0x8e, 0xf5, 0xe9, 0xd0, 0x6a, 0x17, 0x27, 0x56, 0x63, 0x7c, 0xec, 0x57, 0x1c,
+	0x73, 0x07, 0x7a, 0x05, 0x8b, 0xb1, 0x36, 0x8d, 0x59, 0x77, 0x85, 0x05, 0xcb, 0xb4, 0x30, 0x5d,
+	0xef, 0x57, 0x1c, 0x03, 0xcf, 0x59, 0x89, 0x54, 0x93, 0xb4, 0x96, 0x8a, 0xac, 0xa4, 0x0d, 0x94,
+	0xb3, 0x92, 0xe2, 0xb8, 0xdb, 0x9e, 0xde, 0xfc, 0xac, 0x4e, 0xc9, 0xa0, 0xad, 0x03, 0xb8, 0xdb,
+	0xc6, 0x0e, 0x71, 0x73, 0x3d, 0x59, 0xad, 0xe5, 0x92, 0x9b, 0xeb, 0x00, 0x71, 0x73, 0x5d, 0x81,
+	0xde, 0xc0, 0x92, 0x67, 0x4e, 0x28, 0x16, 0x12, 0x46, 0x1e, 0x14, 0xfd, 0xc8, 0x20, 0xfd, 0x8a,
+	0x93, 0xdf, 0x85, 0x06, 0x80, 0x58, 0x61, 0xae, 0xb1, 0x7e, 0x27, 0x6c, 0x3d, 0x32, 0x52, 0xa4,
+	0x80, 0xea, 0x57, 0x9c, 0x92, 0xbd, 0x3c, 0x28, 0x91, 0x36, 0x7d, 0x58, 0x2b, 0xc5, 0xa0, 0xe8,
+	0xd3, 0x09, 0x0f, 0x8a, 0x8e, 0x47, 0xef, 0x60, 0x39, 0xca, 0x4f, 0x18, 0xd6, 0x3d, 0x61, 0xe4,
+	0xf7, 0x79, 0x23, 0x79, 0xa2, 0x8b, 0x3b, 0x39, 0xd9, 0x91, 0x3e, 0x3a, 0x58, 0xdd, 0x22, 0xd9,
+	0xc6, 0x6c, 0xc1, 0xc9, 0x36, 0x76, 0x64, 0x1e, 0xe9, 0x95, 0xde, 0xba, 0x3f, 0xc3, 0x23, 0x1d,
+	0x94, 0x79, 0xa4, 0x2b, 0x11, 0x86, 0xd5, 0x68, 0x56, 0x03, 0xb1, 0x2c, 0x61, 0xf6, 0x8f, 0x79,
+	0xb3, 0xa5, 0xe0, 0x7e, 0xc5, 0x99, 0x6d, 0x09, 0xfd, 0x13, 0x3a, 0x51, 0xae, 0xd8, 0x5a, 0xab,
+	0xc2, 0xfa, 0xc3, 0xbc, 0x75, 0x1d, 0xd3, 0xaf, 0x38, 0x85, 0x7d, 0x29, 0x03, 0x46, 0x52, 0x5a,
+	0x6b, 0xe5, 0x0c, 0xe4, 0x33, 0xb7, 0xb8, 0x33, 0x4d, 0x91, 0xac, 0x63, 0x3d, 0x28, 0x4f, 0x11,
+	0xad, 0x2a, 0x19, 0x78, 0xf4, 0x3f, 0xe8, 0x0e, 0xa5, 0xa9, 0x13, 0xe2, 0x88, 0x8f, 0x6e, 0x3f,
+	0x1c, 0x1d, 0x26, 0xe1, 0xd0, 0x7a, 0x24, 0x2c, 0xd9, 0xba, 0xa5, 0xfd, 0x52, 0x64, 0xbf, 0xe2,
+	0xcc, 0xb0, 0xc1, 0xad, 0x7b, 0x81, 0xeb, 0x8f, 0x0f, 0x29, 0x19, 0x9b, 0xd6, 0x1f, 0x17, 0xad,
+	0xef, 0x95, 0x22, 0xb9, 0xf5, 0x72, 0x1b, 0xe8, 0x6f, 0xb0, 0x30, 0xa2, 0x6e, 0xc8, 0xa4, 0xd6,
+	0x5a, 0x17, 0x26, 0xef, 0xeb, 0x26, 0xdf, 0x4c, 0x97, 0xfb, 0x15, 0x47, 0x47, 0x8b, 0x64, 0xd6,
+	0xdf, 0x02, 0xac, 0xed, 0x92, 0x64, 0xd6, 0x01, 0x22, 0x99, 0x75, 0x05, 0xaf, 0xd6, 0x94, 0x30,
+	0x97, 0x61, 0xfe, 0xae, 0xb1, 0x51, 0xac, 0xd6, 0x4e, 0xba, 0xc8, 0xab, 0x75, 0x86, 0xdc, 0x9d,
+	0x87, 0xa6, 0x7c, 0x47, 0xb2, 0x2f, 0xa1, 0x29, 0x1b, 0x18, 0xda, 0x84, 0x86, 0x47, 0x28, 0x56,
+	0xaf, 0x36, 0xc6, 0x17, 0xe1, 0xb4, 0xc5, 0x39, 0x02, 0xc3, 0xfb, 0x69, 0x8c, 0xc3, 0x21, 0xa6,
+	0x03, 0xf9, 0xa2, 0xa2, 0xfa, 0xa9, 0xae, 0xe3, 0x9d, 0x33, 0xf6, 0x47, 0xa1, 0xcb, 0x12, 0x8a,
+	0xd5, 0xc8, 0x33, 0x55, 0xd8, 0x3f, 0x55, 0xe1, 0x8e, 0x83, 0x3d, 0xec, 0x47, 0xa2, 0xbb, 0xc7,
+	0xcc, 0x65, 0x49, 0x9c, 0x76, 0x6d, 0x29, 0x71, 0x0b, 0xa7, 0xc1, 0x85, 0xf1, 0xcd, 0x3d, 0x55,
+	0x88, 0xf7, 0x1f, 0x8f, 0xf5, 0xdd, 0xf8, 0x3c, 0x7d, 0x8c, 0x52, 0x22, 0x7f, 0x28, 0x18, 0xb9,
+	0xf1, 0x1e, 0x09, 0xe3, 0x64, 0x8c, 0x87, 0xe9, 0x43, 0x81, 0xa6, 0xe2, 0x63, 0x4a, 0xfa, 0xd8,
+	0x91, 0x8e, 0x29, 0x73, 0x72, 0x4c, 0xc9, 0xa9, 0xd1, 0x13, 0x68, 0x04, 0x64, 0x14, 0x5b, 0x4d,
+	0xf1, 0x55, 0xb6, 0xa4, 0xb3, 0x72, 0x44, 0x46, 0x8e, 0x58, 0xb4, 0xbf, 0xab, 0x42, 0xfd, 0x88,
+	0x8c, 0xca, 0xcc, 0x56, 0xcb, 0xcd, 0x76, 0xa1, 0xc9, 0x48, 0xe4, 0x7b, 0xfc, 0x65, 0xa7, 0xce,
+	0x1f, 0xa3, 0xa4, 0x54, 0xf6, 0xf2, 0x62, 0xd2, 0xd0, 0xb8, 0x81, 0x86, 0x39, 0x93, 0x86, 0xec,
+	0x6b, 0xb8, 0x29, 0x66, 0x11, 0x29, 0xd8, 0xfb, 0xd0, 0x2d, 0xff, 0x0f, 0xcd, 0xfc, 0xe6, 0x4e,
+	0x7d, 0xaa, 0x69, 0xaf, 0x41, 0xfb, 0xd0, 0x2d, 0xff, 0xaf, 0xdc, 0xca, 0xca, 0xbf, 0x61, 0x41,
+	0xfb, 0x7b, 0xf0, 0x0c, 0xe4, 0xcc, 0x8a, 0x8d, 0x77, 0xcd, 0x0c, 0x94, 0x88, 0x93, 0x49, 0x84,
+	0x1d, 0x81, 0x99, 0xf5, 0x19, 0x6d, 0x7f, 0x5b, 0x85, 0x56, 0x96, 0xf4, 0x7c, 0x88, 0x1a, 0xe2,
+	0x00, 0x8f, 0x5c, 0x96, 0x3e, 0xb1, 0x64, 0x32, 0xa7, 0x35, 0xc4, 0x57, 0x46, 0x02, 0x4f, 0x15,
+	0xe8, 0x29, 0xdc, 0xa5, 0xd8, 0x23, 0x97, 0x98, 0x4e, 0x06, 0xfa, 0xab, 0x61, 0x4e, 0xcb, 0x43,
+	0x1e, 0xe2, 0xab, 0xb7, 0x78, 0x72, 0x9c, 0xe5, 0xba, 0x9c, 0xd8, 0xf3, 0xea, 0xcd, 0x1e, 0xc0,
+	0xf4, 0x16, 0x68, 0x09, 0x16, 0x44, 0x83, 0x94, 0xaa, 0x4e, 0x85, 0x2b, 0x0e, 0x22, 0xe2, 0x9d,
+	0x2b, 0x45, 0x75, 0x77, 0xe7, 0xfd, 0x8b, 0x91, 0xcf, 0xce, 0x93, 0xd3, 0x9e, 0x47, 0xc6, 0x5b,
+	0x82, 0x8b, 0x88, 0x92, 0xaf, 0xb0, 0xc7, 0xa4, 0xf0, 0x67, 0xfe, 0x4f, 0x94, 0xaf, 0xbd, 0x23,
+	0x1c, 0x6e, 0x4d, 0xc9, 0x3a, 0x6d, 0x0a, 0xe5, 0x5f, 0x7e, 0x1d, 0x00, 0x2e, 0x2b, 0x3d, 0x47,
+	0x38, 0x16, 0x00, 0x00,
 }
@@ -385,6 +385,7 @@ type GenesisRewarding struct {
 	NumDelegatesForFoundationBonus uint64   `protobuf:"varint,7,opt,name=numDelegatesForFoundationBonus,proto3" json:"numDelegatesForFoundationBonus,omitempty"`
 	FoundationBonusLastEpoch       uint64   `protobuf:"varint,8,opt,name=foundationBonusLastEpoch,proto3" json:"foundationBonusLastEpoch,omitempty"`
 	ProductivityThreshold          uint64   `protobuf:"varint,9,opt,name=productivityThreshold,proto3" json:"productivityThreshold,omitempty"`
+	BurnBlockReward                bool     `protobuf:"varint,10,opt,name=burnBlockReward,proto3" json:"burnBlockReward,omitempty"`
 	XXX_NoUnkeyedLiteral           struct{} `json:"-"`
 	XXX_unrecognized               []byte   `json:"-"`
 	XXX_sizecache                  int32    `json:"-"`
@@ -478,6 +479,13 @@ func (m *GenesisRewarding) GetProductivityThreshold() uint64 {
 	return 0
 }
 
+func (m *GenesisRewarding) GetBurnBlockReward() bool {
+	if m != nil {
+		return m.BurnBlockReward
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*Genesis)(nil), "iotextypes.Genesis")
 	proto.RegisterType((*GenesisBlockchain)(nil), "iotextypes.GenesisBlockchain")
@@ -490,51 +498,51 @@ func init() {
 func init() { proto.RegisterFile("proto/types/genesis.proto", fileDescriptor_8090b9f9a91af920) }
 
 var fileDescriptor_8090b9f9a91af920 = []byte{
-	// 722 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x95, 0xed, 0x6e, 0xd3, 0x3e,
-	0x14, 0xc6, 0xd5, 0xa6, 0x5b, 0x97, 0xd3, 0xff, 0x7f, 0x2f, 0xd6, 0x60, 0x61, 0x8c, 0xa9, 0xaa,
-	0x10, 0xaa, 0x78, 0x69, 0xa5, 0x31, 0x4d, 0x63, 0x12, 0x48, 0xeb, 0xd8, 0x06, 0xd2, 0x3e, 0x20,
-	0x0f, 0xf1, 0x81, 0x4f, 0xb8, 0x89, 0x97, 0x9a, 0x25, 0x76, 0x64, 0x3b, 0x83, 0xdd, 0x16, 0x97,
-	0xc0, 0x1d, 0x70, 0x15, 0xdc, 0x06, 0xb2, 0xd3, 0x36, 0x2f, 0x4b, 0xe1, 0x63, 0x9f, 0xf3, 0x7b,
-	0xec, 0x9c, 0x9c, 0xe7, 0xa4, 0xf0, 0x20, 0x91, 0x42, 0x8b, 0xa1, 0xbe, 0x4d, 0xa8, 0x1a, 0x86,
-	0x94, 0x53, 0xc5, 0xd4, 0xc0, 0x6a, 0x08, 0x98, 0xd0, 0xf4, 0xbb, 0xad, 0xf4, 0x7e, 0x37, 0xa0,
-	0x7d, 0x9e, 0x55, 0xd1, 0x6b, 0x80, 0x71, 0x24, 0xfc, 0x6b, 0x7f, 0x42, 0x18, 0xf7, 0x1a, 0xdd,
-	0x46, 0xbf, 0xb3, 0xf7, 0x68, 0x90, 0xc3, 0x83, 0x29, 0x38, 0x9a, 0x43, 0xb8, 0x60, 0x40, 0xfb,
-	0xd0, 0x26, 0xbe, 0x2f, 0x52, 0xae, 0xbd, 0xa6, 0xf5, 0x6e, 0xd7, 0x78, 0x8f, 0x33, 0x02, 0xcf,
-	0x50, 0xf4, 0x0c, 0x5a, 0x89, 0x88, 0x22, 0xcf, 0xb1, 0x96, 0xad, 0x1a, 0xcb, 0x07, 0x11, 0x45,
-	0xd8, 0x42, 0xe8, 0x08, 0x5c, 0x49, 0xbf, 0x11, 0x19, 0x30, 0x1e, 0x7a, 0x2d, 0xeb, 0xd8, 0xa9,
-	0x71, 0xe0, 0x19, 0x83, 0x73, 0xbc, 0xf7, 0xab, 0x09, 0x1b, 0x77, 0x1a, 0x40, 0x3b, 0xe0, 0x6a,
-	0x16, 0x53, 0xa5, 0x49, 0x9c, 0xd8, 0x96, 0x1d, 0x9c, 0x0b, 0xe8, 0x31, 0xfc, 0x6f, 0x1b, 0x3c,
-	0x27, 0xea, 0x82, 0xc5, 0x2c, 0x6b, 0xac, 0x85, 0xcb, 0x22, 0x7a, 0x02, 0xab, 0xc4, 0xd7, 0x4c,
-	0xf0, 0x39, 0xe6, 0x58, 0xac, 0xa2, 0xce, 0x4f, 0x7b, 0xcf, 0x35, 0x95, 0x37, 0x24, 0xb2, 0x1d,
-	0x38, 0xb8, 0x2c, 0xa2, 0x1e, 0xfc, 0xc7, 0xd3, 0xf8, 0x32, 0x1d, 0x9f, 0x26, 0xc2, 0x9f, 0x28,
-	0x6f, 0xc9, 0x9e, 0x55, 0xd2, 0xa6, 0xcc, 0x5b, 0x1a, 0xd1, 0x90, 0x68, 0xaa, 0xbc, 0xe5, 0x39,
-	0x33, 0xd7, 0xd0, 0x3e, 0xdc, 0xe3, 0x69, 0x7c, 0x42, 0x78, 0xc0, 0x02, 0xa2, 0x69, 0x0e, 0xb7,
-	0x2d, 0x5c, 0x5f, 0x44, 0xcf, 0x61, 0xc3, 0xb4, 0x3f, 0x22, 0x8a, 0x06, 0x58, 0x68, 0x62, 0x1a,
-	0xf0, 0x56, 0xba, 0x8d, 0xfe, 0x0a, 0xbe, 0x5b, 0xe8, 0x7d, 0x81, 0xd5, 0xf2, 0x5c, 0xd1, 0x53,
-	0x58, 0x67, 0x9c, 0xe9, 0x11, 0x89, 0x08, 0xf7, 0xe9, 0x71, 0x10, 0x48, 0xe5, 0x35, 0xba, 0x4e,
-	0xdf, 0xc5, 0x77, 0x74, 0xd3, 0x45, 0x41, 0x53, 0x5e, 0xd3, 0x72, 0x25, 0xad, 0xf7, 0xc3, 0x81,
-	0x4e, 0x21, 0x07, 0xe8, 0x08, 0x3c, 0xca, 0xc9, 0x38, 0xa2, 0xe7, 0x92, 0xdc, 0x30, 0x7d, 0x7b,
-	0x62, 0xa6, 0xf8, 0x49, 0x68, 0x13, 0x88, 0x86, 0x7d, 0xcc, 0x85, 0x75, 0x74, 0x08, 0x5b, 0x61,
-	0x41, 0xbd, 0xd4, 0x44, 0xea, 0x77, 0x94, 0x85, 0x93, 0xd9, 0x5c, 0x17, 0x95, 0x8d, 0x53, 0xd2,
-	0x90, 0x29, 0x4d, 0xe5, 0x89, 0xe0, 0x5a, 0x12, 0x5f, 0x9b, 0x16, 0xa8, 0x52, 0x76, 0xd4, 0x2e,
-	0x5e, 0x54, 0x46, 0x07, 0x70, 0x5f, 0x69, 0x72, 0xcd, 0x78, 0x58, 0x35, 0xb6, 0xac, 0x71, 0x41,
-	0xd5, 0x64, 0xe5, 0x46, 0x68, 0xfa, 0x71, 0x22, 0xa9, 0x9a, 0x88, 0x28, 0xb0, 0x31, 0x70, 0x71,
-	0x59, 0x34, 0xc9, 0x53, 0xbe, 0x90, 0x05, 0x6c, 0xd9, 0x62, 0x15, 0x15, 0xed, 0xc1, 0xa6, 0xa2,
-	0xd1, 0xd5, 0x65, 0x76, 0x57, 0x4e, 0xb7, 0x2d, 0x5d, 0x5b, 0x43, 0xaf, 0xc0, 0x0d, 0xe6, 0x99,
-	0x59, 0xe9, 0x3a, 0xfd, 0xce, 0xde, 0xc3, 0x9a, 0x5d, 0x9b, 0x45, 0x07, 0xe7, 0x74, 0xef, 0x1a,
-	0xd6, 0x2a, 0x55, 0x33, 0x6b, 0x91, 0x50, 0x49, 0xb4, 0x90, 0xa6, 0x45, 0x3b, 0x2b, 0x17, 0x97,
-	0x34, 0xb4, 0x0b, 0x90, 0xad, 0xab, 0x25, 0x9a, 0x96, 0x28, 0x28, 0x68, 0x13, 0x96, 0x4c, 0xfb,
-	0xb3, 0x77, 0x9e, 0xfd, 0xe8, 0xfd, 0x74, 0x60, 0xbd, 0xba, 0xf7, 0xe6, 0xf5, 0x99, 0x18, 0x1d,
-	0x07, 0x31, 0xe3, 0x85, 0xfb, 0xca, 0x22, 0xea, 0x42, 0xa7, 0x10, 0xb6, 0xe9, 0x8d, 0x45, 0xc9,
-	0x10, 0x76, 0x3b, 0xb3, 0x93, 0xa7, 0x17, 0x17, 0x25, 0x43, 0x50, 0xb3, 0x94, 0x53, 0x22, 0x9b,
-	0x6a, 0x51, 0x42, 0x6f, 0x60, 0xbb, 0xb8, 0x98, 0x67, 0x42, 0x9e, 0x16, 0x0c, 0xd9, 0x7a, 0xff,
-	0x85, 0x40, 0x7d, 0x58, 0xbb, 0x12, 0x29, 0x0f, 0xec, 0xca, 0x8d, 0x04, 0x4f, 0xd5, 0x74, 0xca,
-	0x55, 0x19, 0x9d, 0xc1, 0x6e, 0xe5, 0x9c, 0xb3, 0x8a, 0x31, 0xdb, 0xfd, 0x7f, 0x50, 0x66, 0xc9,
-	0x2a, 0x47, 0x5f, 0x10, 0xa5, 0xed, 0x33, 0xd9, 0x6f, 0x41, 0x0b, 0x2f, 0xac, 0x9b, 0xcf, 0x4e,
-	0x22, 0x45, 0x90, 0xfa, 0x9a, 0x99, 0x55, 0xca, 0xb3, 0xe6, 0x66, 0x9f, 0x9d, 0xda, 0xe2, 0xe8,
-	0xf0, 0xf3, 0x41, 0xc8, 0xf4, 0x24, 0x1d, 0x0f, 0x7c, 0x11, 0x0f, 0x6d, 0xca, 0x12, 0x29, 0xbe,
-	0x52, 0x5f, 0x67, 0x3f, 0x5e, 0x98, 0x3c, 0x0f, 0xed, 0xdf, 0x57, 0x48, 0xf9, 0x30, 0x8f, 0xe1,
-	0x78, 0xd9, 0x8a, 0x2f, 0xff, 0x04, 0x00, 0x00, 0xff, 0xff, 0x7a, 0x69, 0x94, 0x56, 0xf0, 0x06,
-	0x00, 0x00,
+	// 734 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x95, 0xdd, 0x6e, 0xdb, 0x36,
+	0x14, 0xc7, 0x61, 0xcb, 0x89, 0xa3, 0xe3, 0x2d, 0x1f, 0x44, 0xb6, 0x68, 0x59, 0x16, 0x18, 0xc6,
+	0x30, 0x18, 0xfb, 0xb0, 0x81, 0x2c, 0x08, 0xb2, 0x00, 0x1b, 0x10, 0x67, 0x49, 0x5a, 0x20, 0x17,
+	0x05, 0x53, 0xf4, 0xa2, 0x57, 0xa5, 0x25, 0x46, 0x66, 0x23, 0x93, 0x02, 0x49, 0xa5, 0xcd, 0x6b,
+	0xf5, 0x4d, 0xfa, 0x14, 0xbd, 0xe9, 0x43, 0x14, 0x3c, 0xb2, 0xad, 0x8f, 0xd8, 0xed, 0xa5, 0xff,
+	0xe7, 0x77, 0x48, 0x1d, 0x9d, 0xff, 0x5f, 0x86, 0x9f, 0x52, 0xad, 0xac, 0x1a, 0xda, 0xc7, 0x94,
+	0x9b, 0x61, 0xcc, 0x25, 0x37, 0xc2, 0x0c, 0x50, 0x23, 0x20, 0x94, 0xe5, 0xef, 0xb1, 0xd2, 0xfb,
+	0xd4, 0x80, 0xf6, 0x75, 0x5e, 0x25, 0xff, 0x02, 0x8c, 0x13, 0x15, 0xde, 0x87, 0x13, 0x26, 0x64,
+	0xd0, 0xe8, 0x36, 0xfa, 0x9d, 0xa3, 0x5f, 0x06, 0x05, 0x3c, 0x98, 0x81, 0xa3, 0x05, 0x44, 0x4b,
+	0x0d, 0xe4, 0x18, 0xda, 0x2c, 0x0c, 0x55, 0x26, 0x6d, 0xd0, 0xc4, 0xde, 0xfd, 0x25, 0xbd, 0xe7,
+	0x39, 0x41, 0xe7, 0x28, 0xf9, 0x03, 0x5a, 0xa9, 0x4a, 0x92, 0xc0, 0xc3, 0x96, 0xbd, 0x25, 0x2d,
+	0x2f, 0x54, 0x92, 0x50, 0x84, 0xc8, 0x19, 0xf8, 0x9a, 0xbf, 0x63, 0x3a, 0x12, 0x32, 0x0e, 0x5a,
+	0xd8, 0x71, 0xb0, 0xa4, 0x83, 0xce, 0x19, 0x5a, 0xe0, 0xbd, 0x8f, 0x4d, 0xd8, 0x79, 0x32, 0x00,
+	0x39, 0x00, 0xdf, 0x8a, 0x29, 0x37, 0x96, 0x4d, 0x53, 0x1c, 0xd9, 0xa3, 0x85, 0x40, 0x7e, 0x85,
+	0xef, 0x71, 0xc0, 0x6b, 0x66, 0x6e, 0xc4, 0x54, 0xe4, 0x83, 0xb5, 0x68, 0x55, 0x24, 0xbf, 0xc1,
+	0x26, 0x0b, 0xad, 0x50, 0x72, 0x81, 0x79, 0x88, 0xd5, 0xd4, 0xc5, 0x69, 0xcf, 0xa5, 0xe5, 0xfa,
+	0x81, 0x25, 0x38, 0x81, 0x47, 0xab, 0x22, 0xe9, 0xc1, 0x77, 0x32, 0x9b, 0xde, 0x66, 0xe3, 0xcb,
+	0x54, 0x85, 0x13, 0x13, 0xac, 0xe1, 0x59, 0x15, 0x6d, 0xc6, 0xfc, 0xcf, 0x13, 0x1e, 0x33, 0xcb,
+	0x4d, 0xb0, 0xbe, 0x60, 0x16, 0x1a, 0x39, 0x86, 0x1f, 0x64, 0x36, 0xbd, 0x60, 0x32, 0x12, 0x11,
+	0xb3, 0xbc, 0x80, 0xdb, 0x08, 0x2f, 0x2f, 0x92, 0x3f, 0x61, 0xc7, 0x8d, 0x3f, 0x62, 0x86, 0x47,
+	0x54, 0x59, 0xe6, 0x06, 0x08, 0x36, 0xba, 0x8d, 0xfe, 0x06, 0x7d, 0x5a, 0xe8, 0xbd, 0x81, 0xcd,
+	0xea, 0x5e, 0xc9, 0xef, 0xb0, 0x2d, 0xa4, 0xb0, 0x23, 0x96, 0x30, 0x19, 0xf2, 0xf3, 0x28, 0xd2,
+	0x26, 0x68, 0x74, 0xbd, 0xbe, 0x4f, 0x9f, 0xe8, 0x6e, 0x8a, 0x92, 0x66, 0x82, 0x26, 0x72, 0x15,
+	0xad, 0xf7, 0xc1, 0x83, 0x4e, 0xc9, 0x07, 0xe4, 0x0c, 0x02, 0x2e, 0xd9, 0x38, 0xe1, 0xd7, 0x9a,
+	0x3d, 0x08, 0xfb, 0x78, 0xe1, 0xb6, 0xf8, 0x4a, 0x59, 0x67, 0x88, 0x06, 0x3e, 0xe6, 0xca, 0x3a,
+	0x39, 0x85, 0xbd, 0xb8, 0xa4, 0xde, 0x5a, 0xa6, 0xed, 0x33, 0x2e, 0xe2, 0xc9, 0x7c, 0xaf, 0xab,
+	0xca, 0xae, 0x53, 0xf3, 0x58, 0x18, 0xcb, 0xf5, 0x85, 0x92, 0x56, 0xb3, 0xd0, 0xba, 0x11, 0xb8,
+	0x31, 0xb8, 0x6a, 0x9f, 0xae, 0x2a, 0x93, 0x13, 0xf8, 0xd1, 0x58, 0x76, 0x2f, 0x64, 0x5c, 0x6f,
+	0x6c, 0x61, 0xe3, 0x8a, 0xaa, 0xf3, 0xca, 0x83, 0xb2, 0xfc, 0xe5, 0x44, 0x73, 0x33, 0x51, 0x49,
+	0x84, 0x36, 0xf0, 0x69, 0x55, 0x74, 0xce, 0x33, 0xa1, 0xd2, 0x25, 0x6c, 0x1d, 0xb1, 0x9a, 0x4a,
+	0x8e, 0x60, 0xd7, 0xf0, 0xe4, 0xee, 0x36, 0xbf, 0xab, 0xa0, 0xdb, 0x48, 0x2f, 0xad, 0x91, 0x7f,
+	0xc0, 0x8f, 0x16, 0x9e, 0xd9, 0xe8, 0x7a, 0xfd, 0xce, 0xd1, 0xcf, 0x4b, 0xb2, 0x36, 0xb7, 0x0e,
+	0x2d, 0xe8, 0xde, 0x3d, 0x6c, 0xd5, 0xaa, 0x6e, 0xd7, 0x2a, 0xe5, 0x9a, 0x59, 0xa5, 0xdd, 0x88,
+	0xb8, 0x2b, 0x9f, 0x56, 0x34, 0x72, 0x08, 0x90, 0xc7, 0x15, 0x89, 0x26, 0x12, 0x25, 0x85, 0xec,
+	0xc2, 0x9a, 0x1b, 0x7f, 0xfe, 0xce, 0xf3, 0x1f, 0xbd, 0xcf, 0x1e, 0x6c, 0xd7, 0x73, 0xef, 0x5e,
+	0x9f, 0xb3, 0xd1, 0x79, 0x34, 0x15, 0xb2, 0x74, 0x5f, 0x55, 0x24, 0x5d, 0xe8, 0x94, 0xcc, 0x36,
+	0xbb, 0xb1, 0x2c, 0x39, 0x02, 0xd3, 0x99, 0x9f, 0x3c, 0xbb, 0xb8, 0x2c, 0x39, 0x82, 0xbb, 0x50,
+	0xce, 0x88, 0x7c, 0xab, 0x65, 0x89, 0xfc, 0x07, 0xfb, 0xe5, 0x60, 0x5e, 0x29, 0x7d, 0x59, 0x6a,
+	0xc8, 0xe3, 0xfd, 0x15, 0x82, 0xf4, 0x61, 0xeb, 0x4e, 0x65, 0x32, 0xc2, 0xc8, 0x8d, 0x94, 0xcc,
+	0xcc, 0x6c, 0xcb, 0x75, 0x99, 0x5c, 0xc1, 0x61, 0xed, 0x9c, 0xab, 0x5a, 0x63, 0x9e, 0xfd, 0x6f,
+	0x50, 0x2e, 0x64, 0xb5, 0xa3, 0x6f, 0x98, 0xb1, 0xf8, 0x4c, 0xf8, 0x2d, 0x68, 0xd1, 0x95, 0x75,
+	0xf7, 0xd9, 0x49, 0xb5, 0x8a, 0xb2, 0xd0, 0x0a, 0x17, 0xa5, 0xc2, 0x6b, 0x7e, 0xfe, 0xd9, 0x59,
+	0x5a, 0x74, 0x33, 0x8e, 0x33, 0x2d, 0x47, 0xa5, 0x77, 0x0d, 0x98, 0xe6, 0xba, 0x3c, 0x3a, 0x7d,
+	0x7d, 0x12, 0x0b, 0x3b, 0xc9, 0xc6, 0x83, 0x50, 0x4d, 0x87, 0xe8, 0xc7, 0x54, 0xab, 0xb7, 0x3c,
+	0xb4, 0xf9, 0x8f, 0xbf, 0x9c, 0xf3, 0x87, 0xf8, 0x47, 0x17, 0x73, 0x39, 0x2c, 0x0c, 0x3b, 0x5e,
+	0x47, 0xf1, 0xef, 0x2f, 0x03, 0x00, 0xb8, 0xbf, 0x73, 0x27, 0x1a, 0x07, 0x00, 0x00,
 }
@@ -0,0 +1,201 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"runtime/debug"
+	rpprof "runtime/pprof"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/ha"
+	"github.com/iotexproject/iotex-core/pkg/lifecycle"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/metrics"
+)
+
+// maxGCPauses is the number of the latest GC pauses served
+const maxGCPauses = 20
+
+// newAdminMux returns the handlers of the admin port. The debug endpoints are only added if enabled, and only answer
+// the requests from the loopback addresses unless the admin host is set explicitly.
+func newAdminMux(svr *Server, cfg config.Config) *http.ServeMux {
+	mux := http.NewServeMux()
+	log.RegisterLevelConfigMux(mux)
+	haCtl := ha.New(svr.rootChainService.Consensus())
+	mux.Handle("/ha", http.HandlerFunc(haCtl.Handle))
+	mux.Handle("/config", http.HandlerFunc(svr.HandleConfigDump))
+	mux.Handle("/syncstatus", http.HandlerFunc(svr.HandleSyncStatus))
+	mux.Handle("/metrics", metrics.Handler())
+	if !cfg.System.EnablePprof {
+		return mux
+	}
+
+	debugHandler := func(h http.HandlerFunc) http.Handler {
+		if cfg.System.HTTPAdminHost != "" {
+			return h
+		}
+		return loopbackOnly(h)
+	}
+	mux.Handle("/debug/pprof/", debugHandler(pprof.Index))
+	mux.Handle("/debug/pprof/cmdline", debugHandler(pprof.Cmdline))
+	mux.Handle("/debug/pprof/profile", debugHandler(pprof.Profile))
+	mux.Handle("/debug/pprof/symbol", debugHandler(pprof.Symbol))
+	mux.Handle("/debug/pprof/trace", debugHandler(pprof.Trace))
+	mux.Handle("/debug/runtime/goroutines", debugHandler(handleGoroutines))
+	mux.Handle("/debug/runtime/heap", debugHandler(handleHeap))
+	mux.Handle("/debug/runtime/gc", debugHandler(handleGC))
+	return mux
+}
+
+// loopbackOnly rejects the requests not from the loopback addresses
+func loopbackOnly(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+		if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+			http.Error(w, "debug endpoints are only served to the loopback addresses", http.StatusForbidden)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// handleGoroutines serves the number of the goroutines, in total and by the component label
+func handleGoroutines(w http.ResponseWriter, _ *http.Request) {
+	var buf bytes.Buffer
+	if err := rpprof.Lookup("goroutine").WriteTo(&buf, 1); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, struct {
+		Count       int            `json:"count"`
+		ByComponent map[string]int `json:"byComponent"`
+	}{
+		Count:       runtime.NumGoroutine(),
+		ByComponent: countGoroutinesByComponent(buf.Bytes()),
+	})
+}
+
+// countGoroutinesByComponent sums up the goroutines by the component label in the goroutine profile of debug level 1,
+// in which a group of goroutines starts with a line of the count and the stack, followed by a line of the labels if
+// any. The goroutines without the label are counted under "none".
+func countGoroutinesByComponent(profile []byte) map[string]int {
+	counts := make(map[string]int)
+	labelPrefix := "# labels: "
+	count := 0
+	labeled := false
+	flush := func() {
+		if count > 0 && !labeled {
+			counts["none"] += count
+		}
+		count, labeled = 0, false
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(profile))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.Contains(line, " @ ") && !strings.HasPrefix(line, "#"):
+			flush()
+			if n, err := strconv.Atoi(strings.Fields(line)[0]); err == nil {
+				count = n
+			}
+		case strings.HasPrefix(line, labelPrefix) && count > 0:
+			var labels map[string]string
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(line, labelPrefix)), &labels); err != nil {
+				continue
+			}
+			if component, ok := labels[lifecycle.ComponentLabel]; ok {
+				counts[component] += count
+				labeled = true
+			}
+		}
+	}
+	flush()
+	return counts
+}
+
+// handleHeap serves the heap stats
+func handleHeap(w http.ResponseWriter, _ *http.Request) {
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	writeJSON(w, struct {
+		HeapAlloc    uint64 `json:"heapAlloc"`
+		HeapSys      uint64 `json:"heapSys"`
+		HeapIdle     uint64 `json:"heapIdle"`
+		HeapInuse    uint64 `json:"heapInuse"`
+		HeapReleased uint64 `json:"heapReleased"`
+		HeapObjects  uint64 `json:"heapObjects"`
+		TotalAlloc   uint64 `json:"totalAlloc"`
+		Sys          uint64 `json:"sys"`
+		NextGC       uint64 `json:"nextGC"`
+	}{
+		HeapAlloc:    ms.HeapAlloc,
+		HeapSys:      ms.HeapSys,
+		HeapIdle:     ms.HeapIdle,
+		HeapInuse:    ms.HeapInuse,
+		HeapReleased: ms.HeapReleased,
+		HeapObjects:  ms.HeapObjects,
+		TotalAlloc:   ms.TotalAlloc,
+		Sys:          ms.Sys,
+		NextGC:       ms.NextGC,
+	})
+}
+
+// handleGC serves the GC stats with the latest pauses, the most recent first
+func handleGC(w http.ResponseWriter, _ *http.Request) {
+	var stats debug.GCStats
+	debug.ReadGCStats(&stats)
+	pauses := stats.Pause
+	if len(pauses) > maxGCPauses {
+		pauses = pauses[:maxGCPauses]
+	}
+	pauseStrs := make([]string, 0, len(pauses))
+	for _, p := range pauses {
+		pauseStrs = append(pauseStrs, p.String())
+	}
+	var lastGC string
+	if !stats.LastGC.IsZero() {
+		lastGC = stats.LastGC.Format(time.RFC3339Nano)
+	}
+	writeJSON(w, struct {
+		NumGC      int64    `json:"numGC"`
+		PauseTotal string   `json:"pauseTotal"`
+		LastGC     string   `json:"lastGC,omitempty"`
+		Pauses     []string `json:"pauses"`
+	}{
+		NumGC:      stats.NumGC,
+		PauseTotal: stats.PauseTotal.String(),
+		LastGC:     lastGC,
+		Pauses:     pauseStrs,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(data); err != nil {
+		log.L().Error("Error when writing the debug response.", zap.Error(err))
+	}
+}
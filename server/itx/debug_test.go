@@ -0,0 +1,98 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime/pprof"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/lifecycle"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestAdminMux_DebugEndpoints(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := keypair.GenerateKey()
+	require.NoError(err)
+	cfg := config.Default
+	cfg.Chain.ProducerPrivKey = config.Secret(sk.HexString())
+	cfg.Consensus.Scheme = config.NOOPScheme
+	cfg.Network.Port = testutil.RandomPort()
+	cfg.API.Port = testutil.RandomPort()
+	svr, err := NewInMemTestServer(cfg)
+	require.NoError(err)
+
+	paths := []string{
+		"/debug/pprof/",
+		"/debug/runtime/goroutines",
+		"/debug/runtime/heap",
+		"/debug/runtime/gc",
+	}
+	get := func(mux *http.ServeMux, path, remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = remoteAddr
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// disabled by default
+	mux := newAdminMux(svr, cfg)
+	for _, path := range paths {
+		require.Equal(http.StatusNotFound, get(mux, path, "127.0.0.1:1234").Code, path)
+	}
+	require.Equal(http.StatusOK, get(mux, "/config", "127.0.0.1:1234").Code)
+	metricsRec := get(mux, "/metrics", "10.0.0.1:1234")
+	require.Equal(http.StatusOK, metricsRec.Code)
+	require.Contains(metricsRec.Body.String(), "iotex_build_info")
+
+	// only served to the loopback addresses if enabled
+	cfg.System.EnablePprof = true
+	mux = newAdminMux(svr, cfg)
+	for _, path := range paths {
+		require.Equal(http.StatusOK, get(mux, path, "127.0.0.1:1234").Code, path)
+		require.Equal(http.StatusOK, get(mux, path, "[::1]:1234").Code, path)
+		require.Equal(http.StatusForbidden, get(mux, path, "10.0.0.1:1234").Code, path)
+	}
+
+	// served to all if the admin host is set explicitly
+	cfg.System.HTTPAdminHost = "0.0.0.0"
+	mux = newAdminMux(svr, cfg)
+	for _, path := range paths {
+		require.Equal(http.StatusOK, get(mux, path, "10.0.0.1:1234").Code, path)
+	}
+
+	// the goroutines are counted by the component label
+	done := make(chan struct{})
+	started := make(chan struct{})
+	pprof.Do(context.Background(), pprof.Labels(lifecycle.ComponentLabel, "test"), func(context.Context) {
+		go func() {
+			close(started)
+			<-done
+		}()
+	})
+	defer close(done)
+	<-started
+	rec := get(mux, "/debug/runtime/goroutines", "127.0.0.1:1234")
+	require.Equal(http.StatusOK, rec.Code)
+	var goroutines struct {
+		Count       int            `json:"count"`
+		ByComponent map[string]int `json:"byComponent"`
+	}
+	require.NoError(json.Unmarshal(rec.Body.Bytes(), &goroutines))
+	require.True(goroutines.Count > 0)
+	require.True(goroutines.ByComponent["test"] >= 1)
+}
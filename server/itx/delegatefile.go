@@ -0,0 +1,321 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/iotexproject/iotex-address/address"
+	peer "github.com/libp2p/go-libp2p-peer"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
+	"github.com/iotexproject/iotex-core/chainservice"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus"
+	"github.com/iotexproject/iotex-core/p2p"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// afterPrefix prefixes the line of the delegate list file giving the height after which the delegates apply
+const afterPrefix = "after "
+
+// delegateWatcher watches the delegate and peer list files, and applies their changes to the delegate list of the
+// consensus of the root chain and the whitelist of the P2P agent together. A malformed change is rejected, and the
+// lists in use stay. The delegate list file schedules the delegates from the start of the first epoch after a height,
+// so that all the nodes apply them from the same height no matter when they see the change, and the lists applied
+// are persisted into the history file.
+type delegateWatcher struct {
+	mutex        sync.Mutex
+	cfg          config.Delegate
+	numDelegates uint64
+	epochs       *rolldpos.Protocol
+	list         *consensus.DelegateList
+	agent        p2p.Overlay
+	cs           *chainservice.ChainService
+	files        map[string]os.FileInfo
+	peers        []string
+}
+
+// watchedLists are the lists read from the delegate and peer list files
+type watchedLists struct {
+	// scheduled tells whether the delegate list file gives the height after which the delegates apply
+	scheduled   bool
+	startHeight uint64
+	delegates   []string
+	peers       []string
+}
+
+// newDelegateWatcher reads the delegate and peer list files, and applies the peer list to the agent. The delegate list
+// is nil if there is no delegate list file, and otherwise restored from the history file, where the delegate list file
+// is taken as the first list if there is no history yet.
+func newDelegateWatcher(cfg config.Config, agent p2p.Overlay) (*delegateWatcher, error) {
+	w := &delegateWatcher{
+		cfg:          cfg.Delegate,
+		numDelegates: cfg.Genesis.NumDelegates,
+		epochs: rolldpos.NewProtocol(
+			cfg.Genesis.NumCandidateDelegates,
+			cfg.Genesis.NumDelegates,
+			cfg.Genesis.NumSubEpochs,
+		),
+		agent: agent,
+		files: make(map[string]os.FileInfo),
+	}
+	if _, err := w.statFiles(); err != nil {
+		return nil, err
+	}
+	lists, err := w.readFiles()
+	if err != nil {
+		return nil, err
+	}
+	if w.cfg.AddrsFile != "" {
+		history, err := readDelegateHistory(w.cfg.HistoryFile)
+		if err != nil {
+			return nil, err
+		}
+		if len(history) == 0 {
+			history = []consensus.DelegateListAt{{StartHeight: lists.startHeight, Addrs: lists.delegates}}
+			if err := writeDelegateHistory(w.cfg.HistoryFile, history); err != nil {
+				return nil, err
+			}
+		}
+		w.list = consensus.NewDelegateList(history)
+		if w.delegatesChanged(lists) {
+			// the file has changed while the node was down, which is applied at the first check when the tip is known
+			delete(w.files, w.cfg.AddrsFile)
+		}
+	}
+	w.peers = lists.peers
+	agent.SetWhitelist(lists.peers)
+	return w, nil
+}
+
+// check applies the changes of the files, which runs periodically
+func (w *delegateWatcher) check() {
+	if err := w.reload(); err != nil {
+		log.L().Error("Rejected the change of the delegate or peer list file.", zap.Error(err))
+	}
+}
+
+// reload schedules the delegate list and applies the peer list at once if any file has changed
+func (w *delegateWatcher) reload() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	changed, err := w.statFiles()
+	if err != nil || !changed {
+		return err
+	}
+	lists, err := w.readFiles()
+	if err != nil {
+		return err
+	}
+	if w.delegatesChanged(lists) {
+		if !lists.scheduled {
+			return errors.Errorf("%s changes the delegates without the height after which they apply",
+				w.cfg.AddrsFile)
+		}
+		// the delegates of the epoch of the next height are in use or about to be, which cannot change
+		if next := w.cs.Blockchain().TipHeight() + 1; lists.startHeight <= next {
+			return errors.Errorf("%s schedules the delegates from height %d, which isn't after the next height %d",
+				w.cfg.AddrsFile, lists.startHeight, next)
+		}
+		history := w.list.History()
+		latest := history[len(history)-1].Addrs
+		scheduled := consensus.NewDelegateList(history)
+		scheduled.Set(lists.startHeight, lists.delegates)
+		if err := writeDelegateHistory(w.cfg.HistoryFile, scheduled.History()); err != nil {
+			return err
+		}
+		w.list.Set(lists.startHeight, lists.delegates)
+		added, removed := diffLists(latest, lists.delegates)
+		log.L().Info("Scheduled the delegates.",
+			zap.Uint64("startHeight", lists.startHeight),
+			zap.Strings("added", added),
+			zap.Strings("removed", removed))
+	}
+	if !equalLists(w.peers, lists.peers) {
+		w.agent.SetWhitelist(lists.peers)
+		added, removed := diffLists(w.peers, lists.peers)
+		log.L().Info("Updated the peer whitelist.", zap.Strings("added", added), zap.Strings("removed", removed))
+		w.peers = lists.peers
+	}
+	return nil
+}
+
+// delegatesChanged tells whether the delegate list file differs from the latest delegate list scheduled
+func (w *delegateWatcher) delegatesChanged(lists *watchedLists) bool {
+	if w.list == nil {
+		return false
+	}
+	history := w.list.History()
+	latest := history[len(history)-1]
+	return latest.StartHeight != lists.startHeight || !equalLists(latest.Addrs, lists.delegates)
+}
+
+// statFiles tells whether the size or the modification time of any file has changed since the last call
+func (w *delegateWatcher) statFiles() (bool, error) {
+	changed := false
+	for _, path := range []string{w.cfg.AddrsFile, w.cfg.PeersFile} {
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to stat %s", path)
+		}
+		if last, ok := w.files[path]; !ok || last.Size() != info.Size() || !last.ModTime().Equal(info.ModTime()) {
+			changed = true
+		}
+		w.files[path] = info
+	}
+	return changed, nil
+}
+
+// readFiles reads and validates the delegate and peer lists
+func (w *delegateWatcher) readFiles() (*watchedLists, error) {
+	lists := &watchedLists{}
+	if w.cfg.AddrsFile != "" {
+		items, err := readListFile(w.cfg.AddrsFile)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			if strings.HasPrefix(item, afterPrefix) {
+				if lists.scheduled {
+					return nil, errors.Errorf("%s gives more than one height to apply after", w.cfg.AddrsFile)
+				}
+				after, err := strconv.ParseUint(strings.TrimSpace(strings.TrimPrefix(item, afterPrefix)), 10, 64)
+				if err != nil {
+					return nil, errors.Wrapf(err, "invalid height to apply after in %s", w.cfg.AddrsFile)
+				}
+				lists.scheduled = true
+				lists.startHeight = w.epochs.GetEpochHeight(w.epochs.GetEpochNum(after) + 1)
+				continue
+			}
+			if _, err := address.FromString(item); err != nil {
+				return nil, errors.Wrapf(err, "invalid delegate address %s in %s", item, w.cfg.AddrsFile)
+			}
+			lists.delegates = append(lists.delegates, item)
+		}
+		if uint64(len(lists.delegates)) < w.numDelegates {
+			return nil, errors.Errorf("%s lists %d delegates, fewer than the number of delegates %d",
+				w.cfg.AddrsFile, len(lists.delegates), w.numDelegates)
+		}
+	}
+	if w.cfg.PeersFile != "" {
+		peers, err := readListFile(w.cfg.PeersFile)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range peers {
+			if _, err := peer.IDB58Decode(id); err != nil {
+				return nil, errors.Wrapf(err, "invalid peer ID %s in %s", id, w.cfg.PeersFile)
+			}
+		}
+		lists.peers = peers
+	}
+	return lists, nil
+}
+
+// readDelegateHistory reads the delegate lists applied, which are none if the history file doesn't exist yet
+func readDelegateHistory(path string) ([]consensus.DelegateListAt, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", path)
+	}
+	var history []consensus.DelegateListAt
+	if err := json.Unmarshal(data, &history); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s", path)
+	}
+	return history, nil
+}
+
+// writeDelegateHistory replaces the history file, which is written aside and then renamed so that it's never torn
+func writeDelegateHistory(path string, history []consensus.DelegateListAt) error {
+	data, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return errors.Wrapf(err, "failed to write %s", tmp)
+	}
+	return errors.Wrapf(os.Rename(tmp, path), "failed to replace %s", path)
+}
+
+// readListFile reads the items listed one per line, skipping the empty lines and the comments starting with #
+func readListFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open %s", path)
+	}
+	defer file.Close()
+	items := []string{}
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		item := strings.TrimSpace(scanner.Text())
+		if item == "" || strings.HasPrefix(item, "#") {
+			continue
+		}
+		if seen[item] {
+			return nil, errors.Errorf("%s lists %s more than once", path, item)
+		}
+		seen[item] = true
+		items = append(items, item)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", path)
+	}
+	return items, nil
+}
+
+func equalLists(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// diffLists returns the items added to and removed from the old list by the new one
+func diffLists(old, new []string) ([]string, []string) {
+	oldSet := make(map[string]bool, len(old))
+	for _, item := range old {
+		oldSet[item] = true
+	}
+	newSet := make(map[string]bool, len(new))
+	for _, item := range new {
+		newSet[item] = true
+	}
+	var added, removed []string
+	for _, item := range new {
+		if !oldSet[item] {
+			added = append(added, item)
+		}
+	}
+	for _, item := range old {
+		if !newSet[item] {
+			removed = append(removed, item)
+		}
+	}
+	return added, removed
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	peer "github.com/libp2p/go-libp2p-peer"
+	multihash "github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/test/identityset"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestServer_WatchDelegateFiles(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "delegate")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	addrsFile := filepath.Join(dir, "delegates")
+	peersFile := filepath.Join(dir, "peers")
+	// rewriting a file bumps its modification time, so that the change is seen regardless of the time resolution
+	modTime := time.Now()
+	writeList := func(path string, items ...string) {
+		require.NoError(ioutil.WriteFile(path, []byte("# list\n"+strings.Join(items, "\n")+"\n"), 0644))
+		modTime = modTime.Add(time.Second)
+		require.NoError(os.Chtimes(path, modTime, modTime))
+	}
+	addr := func(i int) string { return identityset.Address(i).String() }
+	peerID := func(s string) string {
+		h, err := multihash.Sum([]byte(s), multihash.SHA2_256, -1)
+		require.NoError(err)
+		return peer.IDB58Encode(peer.ID(h))
+	}
+	writeList(addrsFile, addr(0), addr(1))
+	writeList(peersFile, peerID("peer1"))
+
+	sk, err := keypair.GenerateKey()
+	require.NoError(err)
+	cfg := config.Default
+	cfg.Chain.ProducerPrivKey = config.Secret(sk.HexString())
+	cfg.Consensus.Scheme = config.NOOPScheme
+	cfg.Network.Port = testutil.RandomPort()
+	cfg.API.Port = testutil.RandomPort()
+	cfg.Genesis.NumDelegates = 2
+	cfg.Genesis.NumCandidateDelegates = 2
+	cfg.Genesis.NumSubEpochs = 1
+	cfg.Delegate.AddrsFile = addrsFile
+	cfg.Delegate.PeersFile = peersFile
+	cfg.Delegate.HistoryFile = filepath.Join(dir, "history")
+	svr, err := NewInMemTestServer(cfg)
+	require.NoError(err)
+	w := svr.delegateWatcher
+	require.NotNil(w)
+	candidates := func(w *delegateWatcher, height uint64) []string {
+		cands, err := w.list.CandidatesByHeight(height)
+		require.NoError(err)
+		addrs := make([]string, 0, len(cands))
+		for _, c := range cands {
+			addrs = append(addrs, c.Address)
+		}
+		return addrs
+	}
+	require.Equal([]string{addr(0), addr(1)}, candidates(w, 1))
+	require.True(svr.p2pAgent.Whitelisted(peerID("peer1")))
+	require.False(svr.p2pAgent.Whitelisted(peerID("peer2")))
+
+	// nothing changes without a change of the files
+	require.NoError(w.reload())
+
+	// the new delegates take effect from the first epoch after height 1, which starts at height 3 with 2 blocks per
+	// epoch
+	writeList(addrsFile, "after 1", addr(1), addr(2))
+	writeList(peersFile, peerID("peer1"), peerID("peer2"))
+	require.NoError(w.reload())
+	require.Equal([]string{addr(0), addr(1)}, candidates(w, 1))
+	require.Equal([]string{addr(1), addr(2)}, candidates(w, 3))
+	require.Equal([]string{addr(1), addr(2)}, w.list.Delegates())
+	require.True(svr.p2pAgent.Whitelisted(peerID("peer2")))
+	history := w.list.History()
+	require.Len(history, 2)
+
+	// the start height is derived from the file alone, where any height of the same epoch schedules the same
+	writeList(addrsFile, "after 2", addr(1), addr(2))
+	require.NoError(w.reload())
+	require.Equal(history, w.list.History())
+
+	// the delegates cannot change without a height to apply after, or from the epoch of the next height
+	writeList(addrsFile, addr(3), addr(4))
+	err = w.reload()
+	require.Error(err)
+	require.Contains(err.Error(), "without the height")
+	writeList(addrsFile, "after 0", addr(3), addr(4))
+	err = w.reload()
+	require.Error(err)
+	require.Contains(err.Error(), "isn't after the next height 1")
+	require.Equal(history, w.list.History())
+
+	// a malformed change of either file is rejected as a whole, and the lists in use stay
+	writeList(addrsFile, "after 4", addr(3), addr(4))
+	writeList(peersFile, "peer3")
+	require.Error(w.reload())
+	require.Equal([]string{addr(1), addr(2)}, candidates(w, 5))
+	require.True(svr.p2pAgent.Whitelisted(peerID("peer2")))
+
+	writeList(addrsFile, "after 4", addr(3))
+	writeList(peersFile, peerID("peer1"))
+	err = w.reload()
+	require.Error(err)
+	require.Contains(err.Error(), "fewer than the number of delegates")
+	require.Equal([]string{addr(1), addr(2)}, candidates(w, 5))
+
+	writeList(addrsFile, "after 4", addr(3), "io1")
+	require.Error(w.reload())
+	require.Equal([]string{addr(1), addr(2)}, candidates(w, 5))
+
+	// a malformed file fails the server at startup
+	_, err = NewInMemTestServer(cfg)
+	require.Error(err)
+
+	// a restarted node restores the delegates from the history, and applies the change made while it was down at the
+	// first check
+	writeList(addrsFile, "after 4", addr(3), addr(4))
+	svr, err = NewInMemTestServer(cfg)
+	require.NoError(err)
+	w = svr.delegateWatcher
+	require.Equal(history, w.list.History())
+	require.Equal([]string{addr(0), addr(1)}, candidates(w, 1))
+	require.Equal([]string{addr(1), addr(2)}, candidates(w, 5))
+	require.NoError(w.reload())
+	require.Equal([]string{addr(1), addr(2)}, candidates(w, 3))
+	require.Equal([]string{addr(3), addr(4)}, candidates(w, 5))
+	require.Len(w.list.History(), 3)
+}
+
+func TestDiffLists(t *testing.T) {
+	added, removed := diffLists([]string{"a", "b", "c"}, []string{"b", "d"})
+	require.Equal(t, []string{"d"}, added)
+	require.Equal(t, []string{"a", "c"}, removed)
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/iotexproject/iotex-core/consensus/scheme/rolldpos"
 	"github.com/iotexproject/iotex-core/dispatcher"
 	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/metrics"
 	"github.com/iotexproject/iotex-core/pkg/version"
 )
 
@@ -42,8 +43,8 @@ var versionMtc = prometheus.NewGaugeVec(
 )
 
 func init() {
-	prometheus.MustRegister(heartbeatMtc)
-	prometheus.MustRegister(versionMtc)
+	metrics.MustRegister(heartbeatMtc)
+	metrics.MustRegister(versionMtc)
 }
 
 // HeartbeatHandler is the handler to periodically log the system key metrics
@@ -67,7 +68,10 @@ func (h *HeartbeatHandler) Log() {
 		log.L().Error("dispatcher is not the instance of IotxDispatcher")
 		return
 	}
-	numDPEvts := len(*dp.EventChan())
+	numDPEvts := 0
+	for _, depth := range dp.QueueDepths() {
+		numDPEvts += depth
+	}
 	dpEvtsAudit, err := json.Marshal(dp.EventAudit())
 	if err != nil {
 		log.L().Error("error when serializing the dispatcher event audit map.", zap.Error(err))
@@ -111,7 +115,7 @@ func (h *HeartbeatHandler) Log() {
 
 		actPoolSize := c.ActionPool().GetSize()
 		actPoolCapacity := c.ActionPool().GetCapacity()
-		targetHeight := c.BlockSync().TargetHeight()
+		targetHeight, _, syncChunks := c.BlockSync().Progress()
 
 		log.L().Info("chain service status",
 			zap.Int("rolldposEvents", numPendingEvts),
@@ -121,6 +125,7 @@ func (h *HeartbeatHandler) Log() {
 			zap.Uint64("actpoolCapacity", actPoolCapacity),
 			zap.Uint32("chainID", c.ChainID()),
 			zap.Uint64("targetHeight", targetHeight),
+			zap.Int("syncChunksInFlight", syncChunks),
 		)
 
 		chainIDStr := strconv.FormatUint(uint64(c.ChainID()), 10)
@@ -129,6 +134,7 @@ func (h *HeartbeatHandler) Log() {
 		heartbeatMtc.WithLabelValues("actpoolSize", chainIDStr).Set(float64(actPoolSize))
 		heartbeatMtc.WithLabelValues("actpoolCapacity", chainIDStr).Set(float64(actPoolCapacity))
 		heartbeatMtc.WithLabelValues("targetHeight", chainIDStr).Set(float64(targetHeight))
+		heartbeatMtc.WithLabelValues("syncChunksInFlight", chainIDStr).Set(float64(syncChunks))
 		heartbeatMtc.WithLabelValues("packageVersion", version.PackageVersion).Set(1)
 		heartbeatMtc.WithLabelValues("packageCommitID", version.PackageCommitID).Set(1)
 		heartbeatMtc.WithLabelValues("goVersion", version.GoVersion).Set(1)
@@ -0,0 +1,58 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/actpool"
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/pkg/lifecycle"
+)
+
+// Plugin is a component run by the server besides the built-in ones, e.g., a custom indexer or a bridge listener.
+// The plugins are initialized before the server starts any component, started after the chains, and stopped before
+// the chains, in the order of the registration on start and in the reverse order on stop.
+type Plugin interface {
+	lifecycle.StartStopper
+
+	// Name returns the name of the plugin, which is unique in the server
+	Name() string
+	// Init wires the plugin up with the components of the server, e.g., subscribes to the blocks committed
+	Init(*Server) error
+}
+
+// RegisterPlugin registers a plugin to the server, which must be done before the server starts
+func (s *Server) RegisterPlugin(p Plugin) error {
+	s.lifecycleMutex.Lock()
+	defer s.lifecycleMutex.Unlock()
+	if s.started {
+		return errors.Errorf("cannot register plugin %s to the started server", p.Name())
+	}
+	for _, registered := range s.plugins {
+		if registered.Name() == p.Name() {
+			return errors.Errorf("plugin %s is already registered", p.Name())
+		}
+	}
+	s.plugins = append(s.plugins, p)
+	return nil
+}
+
+// Blockchain returns the blockchain of the root chain
+func (s *Server) Blockchain() blockchain.Blockchain {
+	return s.rootChainService.Blockchain()
+}
+
+// ActionPool returns the action pool of the root chain
+func (s *Server) ActionPool() actpool.ActPool {
+	return s.rootChainService.ActionPool()
+}
+
+// SubscribeBlockCommit makes the subscriber handle every block committed to the root chain
+func (s *Server) SubscribeBlockCommit(subscriber blockchain.BlockCreationSubscriber) error {
+	return s.rootChainService.Blockchain().AddSubscriber(subscriber)
+}
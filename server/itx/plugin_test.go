@@ -0,0 +1,142 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+type eventLog struct {
+	mutex  sync.Mutex
+	events []string
+}
+
+func (l *eventLog) add(event string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.events = append(l.events, event)
+}
+
+func (l *eventLog) get() []string {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return append([]string{}, l.events...)
+}
+
+type testPlugin struct {
+	name     string
+	log      *eventLog
+	startErr error
+	svr      *Server
+	mutex    sync.Mutex
+	running  bool
+	blocks   int
+}
+
+func (p *testPlugin) Name() string { return p.name }
+
+func (p *testPlugin) Init(svr *Server) error {
+	p.svr = svr
+	p.log.add("init " + p.name)
+	return svr.SubscribeBlockCommit(p)
+}
+
+func (p *testPlugin) Start(_ context.Context) error {
+	if p.startErr != nil {
+		return p.startErr
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.running = true
+	p.log.add("start " + p.name)
+	return nil
+}
+
+func (p *testPlugin) Stop(_ context.Context) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.running = false
+	p.log.add("stop " + p.name)
+	return nil
+}
+
+func (p *testPlugin) HandleBlock(_ *block.Block) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.running {
+		p.blocks++
+	}
+	return nil
+}
+
+func (p *testPlugin) handledBlocks() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.blocks
+}
+
+func TestServer_Plugins(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default
+	cfg.Chain.ProducerPrivKey = config.Secret(testutil.NewKeyPair("producer").PriKey.HexString())
+	cfg.Consensus.Scheme = config.StandaloneScheme
+	cfg.Genesis.BlockInterval = 100 * time.Millisecond
+	cfg.Network.Port = testutil.RandomPort()
+	cfg.API.Port = testutil.RandomPort()
+	svr, err := NewInMemTestServer(cfg)
+	require.NoError(err)
+
+	events := &eventLog{}
+	indexer := &testPlugin{name: "indexer", log: events}
+	bridge := &testPlugin{name: "bridge", log: events}
+	require.NoError(svr.RegisterPlugin(indexer))
+	require.NoError(svr.RegisterPlugin(bridge))
+	require.Error(svr.RegisterPlugin(&testPlugin{name: "indexer", log: events}))
+
+	ctx := context.Background()
+	require.NoError(svr.Start(ctx))
+	require.Error(svr.RegisterPlugin(&testPlugin{name: "late", log: events}))
+	require.Equal(indexer.svr, svr)
+	require.Equal(svr.ChainService(cfg.Chain.ID).Blockchain(), svr.Blockchain())
+	require.Equal(svr.ChainService(cfg.Chain.ID).ActionPool(), svr.ActionPool())
+
+	// the plugins get the blocks committed once started
+	require.NoError(testutil.WaitUntil(100*time.Millisecond, 10*time.Second, func() (bool, error) {
+		return indexer.handledBlocks() >= 2 && bridge.handledBlocks() >= 2, nil
+	}))
+	require.NoError(svr.Stop(ctx))
+	require.Equal([]string{
+		"init indexer",
+		"init bridge",
+		"start indexer",
+		"start bridge",
+		"stop bridge",
+		"stop indexer",
+	}, events.get())
+
+	// a plugin failing to start aborts the server startup
+	cfg.Network.Port = testutil.RandomPort()
+	cfg.API.Port = testutil.RandomPort()
+	svr, err = NewInMemTestServer(cfg)
+	require.NoError(err)
+	require.NoError(svr.RegisterPlugin(&testPlugin{name: "broken", log: events, startErr: errors.New("boom")}))
+	err = svr.Start(ctx)
+	require.Error(err)
+	require.Contains(err.Error(), "plugin broken")
+	require.NoError(svr.Stop(ctx))
+}
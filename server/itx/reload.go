@@ -0,0 +1,141 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/consensus"
+	"github.com/iotexproject/iotex-core/consensus/scheme"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// Reloader applies the changed config values to a running component
+type Reloader func(cfg config.Config) error
+
+type reloader struct {
+	paths  []string
+	reload Reloader
+}
+
+// RegisterReloader registers r to apply the changes of the config values under the given paths on reload, which are
+// the YAML keys joined by dots, e.g., actPool.maxNumActsPerPool. Only the values under a registered path are
+// hot-reloadable, and the changes of the others take effect on restart.
+func (s *Server) RegisterReloader(r Reloader, paths ...string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.reloaders = append(s.reloaders, reloader{paths: paths, reload: r})
+}
+
+// HandleConfigDump serves the config in use, including the reloaded values, in YAML with the secrets redacted
+func (s *Server) HandleConfigDump(w http.ResponseWriter, r *http.Request) {
+	s.mutex.RLock()
+	data, err := config.Marshal(s.cfg)
+	s.mutex.RUnlock()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-yaml")
+	if _, err := w.Write(data); err != nil {
+		log.L().Error("Error when writing the config dump.", zap.Error(err))
+	}
+}
+
+// Reload applies the changes of the hot-reloadable values in cfg to the running server. The changes of the others,
+// e.g., the DB paths, the chain ID and the genesis, are ignored with a warning, and the running config keeps their
+// current values.
+func (s *Server) Reload(cfg config.Config) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	diffs := config.Diff(s.cfg, cfg)
+	handled := make(map[string]bool)
+	var reloadErr error
+	for _, r := range s.reloaders {
+		changed := matchPaths(diffs, r.paths)
+		if len(changed) == 0 {
+			continue
+		}
+		for _, path := range changed {
+			handled[path] = true
+		}
+		if err := r.reload(cfg); err != nil {
+			log.L().Error("Error when reloading config values.", zap.Strings("paths", changed), zap.Error(err))
+			if reloadErr == nil {
+				reloadErr = errors.Wrapf(err, "failed to reload %s", strings.Join(changed, ","))
+			}
+			continue
+		}
+		if err := s.cfg.Update(cfg, changed...); err != nil {
+			return err
+		}
+		log.L().Info("Reloaded config values.", zap.Strings("paths", changed))
+	}
+	for _, path := range diffs {
+		if !handled[path] {
+			log.L().Warn("Ignored the change of a config value which isn't hot-reloadable, restart the node to apply it.",
+				zap.String("path", path))
+		}
+	}
+	return reloadErr
+}
+
+// matchPaths returns the paths in diffs which are under one of the given paths
+func matchPaths(diffs []string, paths []string) []string {
+	var matched []string
+	for _, diff := range diffs {
+		for _, path := range paths {
+			if diff == path || strings.HasPrefix(diff, path+".") {
+				matched = append(matched, diff)
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// registerDefaultReloaders makes the log levels, the action trace rate, the actpool limits, the API connection limit
+// and, with the standalone scheme, the block interval hot-reloadable
+func (s *Server) registerDefaultReloaders() {
+	s.RegisterReloader(func(cfg config.Config) error {
+		return log.SetLevels(cfg.Log)
+	}, "log.zap.level", "log.modules")
+	s.RegisterReloader(func(cfg config.Config) error {
+		log.SetActionTraceRate(cfg.Log.ActionTraceRate)
+		return nil
+	}, "log.actionTraceRate")
+
+	cs := s.rootChainService
+	s.RegisterReloader(func(cfg config.Config) error {
+		cs.ActionPool().SetLimits(cfg.ActPool)
+		return nil
+	}, "actPool.maxNumActsPerPool", "actPool.MaxGasLimitPerPool", "actPool.maxNumActsPerAcct", "actPool.minGasPrice",
+		"actPool.minTransferAmount", "actPool.maxTransferPayloadSize", "actPool.maxNonceGap", "actPool.senderRateLimit",
+		"actPool.senderRateWindow", "actPool.maxSenders", "actPool.maxTransfersPerBlock", "actPool.maxVotesPerBlock")
+
+	if apiSvr := cs.APIServer(); apiSvr != nil {
+		s.RegisterReloader(func(cfg config.Config) error {
+			apiSvr.SetMaxConnections(cfg.API.MaxConnections)
+			return nil
+		}, "api.maxConnections")
+	}
+
+	if c, ok := cs.Consensus().(*consensus.IotxConsensus); ok {
+		if standalone, ok := c.Scheme().(*scheme.Standalone); ok {
+			s.RegisterReloader(func(cfg config.Config) error {
+				standalone.SetInterval(cfg.Genesis.BlockInterval)
+				return nil
+			}, "genesis.blockchain.blockInterval")
+		}
+	}
+}
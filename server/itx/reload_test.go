@@ -0,0 +1,74 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"flag"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestServer_Reload(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := keypair.GenerateKey()
+	require.NoError(err)
+	cfg := config.Default
+	cfg.Chain.ProducerPrivKey = config.Secret(sk.HexString())
+	cfg.Consensus.Scheme = config.NOOPScheme
+	cfg.Network.Port = testutil.RandomPort()
+	cfg.API.Port = testutil.RandomPort()
+	svr, err := NewInMemTestServer(cfg)
+	require.NoError(err)
+	ap := svr.ChainService(cfg.Chain.ID).ActionPool()
+	require.Equal(cfg.ActPool.MaxNumActsPerPool, ap.GetCapacity())
+
+	cfgFile, err := ioutil.TempFile("", "config")
+	require.NoError(err)
+	defer func() {
+		require.NoError(os.Remove(cfgFile.Name()))
+	}()
+	_, err = cfgFile.WriteString(`
+chain:
+    chainDBPath: "./other-chain.db"
+actPool:
+    maxNumActsPerPool: 64000
+`)
+	require.NoError(err)
+	require.NoError(cfgFile.Close())
+	require.NoError(flag.Set("config-path", cfgFile.Name()))
+	defer func() {
+		require.NoError(flag.Set("config-path", ""))
+	}()
+
+	newCfg, err := config.New()
+	require.NoError(err)
+	newCfg.Genesis = cfg.Genesis
+	require.NoError(svr.Reload(newCfg))
+
+	// the actpool capacity is hot-reloadable, while the DB path isn't
+	require.Equal(uint64(64000), ap.GetCapacity())
+	require.Equal(uint64(64000), svr.cfg.ActPool.MaxNumActsPerPool)
+	require.Equal(cfg.Chain.ChainDBPath, svr.cfg.Chain.ChainDBPath)
+
+	// the config dump shows the reloaded values without the secrets
+	rec := httptest.NewRecorder()
+	svr.HandleConfigDump(rec, httptest.NewRequest(http.MethodGet, "/config", nil))
+	require.Equal(http.StatusOK, rec.Code)
+	require.Contains(rec.Body.String(), "maxNumActsPerPool: 64000")
+	require.Contains(rec.Body.String(), "producerPrivKey: '"+config.RedactedSecret+"'")
+	require.NotContains(rec.Body.String(), sk.HexString())
+}
@@ -10,7 +10,6 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"net/http/pprof"
 	"runtime"
 	"sync"
 	"time"
@@ -21,20 +20,24 @@ import (
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/action/protocol/account"
 	"github.com/iotexproject/iotex-core/action/protocol/execution"
+	"github.com/iotexproject/iotex-core/action/protocol/keyrotation"
 	"github.com/iotexproject/iotex-core/action/protocol/multichain/mainchain"
 	"github.com/iotexproject/iotex-core/action/protocol/poll"
 	"github.com/iotexproject/iotex-core/action/protocol/rewarding"
 	"github.com/iotexproject/iotex-core/action/protocol/rolldpos"
 	"github.com/iotexproject/iotex-core/action/protocol/vote"
-	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/actpool"
+	"github.com/iotexproject/iotex-core/blockchain"
 	"github.com/iotexproject/iotex-core/chainservice"
 	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/dispatcher"
 	"github.com/iotexproject/iotex-core/p2p"
-	"github.com/iotexproject/iotex-core/pkg/ha"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/lifecycle"
 	"github.com/iotexproject/iotex-core/pkg/log"
 	"github.com/iotexproject/iotex-core/pkg/probe"
 	"github.com/iotexproject/iotex-core/pkg/routine"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
 	"github.com/iotexproject/iotex-core/pkg/util/httputil"
 )
 
@@ -43,41 +46,133 @@ type Server struct {
 	cfg                  config.Config
 	rootChainService     *chainservice.ChainService
 	chainservices        map[uint32]*chainservice.ChainService
-	p2pAgent             *p2p.Agent
+	p2pAgent             p2p.Overlay
 	dispatcher           dispatcher.Dispatcher
 	mainChainProtocol    *mainchain.Protocol
 	initializedSubChains map[uint32]bool
 	mutex                sync.RWMutex
 	subModuleCancel      context.CancelFunc
+	reloaders            []reloader
+	delegateWatcher      *delegateWatcher
+	delegateWatchTask    *routine.RecurringTask
+	plugins              []Plugin
+	// lifecycleMutex serializes Start and Stop, which may be called again, e.g., by a signal handler
+	lifecycleMutex sync.Mutex
+	started        bool
+	stopped        bool
 }
 
-// NewServer creates a new server
+// Option sets a construction parameter of the server
+type Option func(*serverOptions) error
+
+type serverOptions struct {
+	testing    bool
+	dispatcher dispatcher.Dispatcher
+	overlay    p2p.Overlay
+	// rootOpts are the options of the root chain only
+	rootOpts []chainservice.Option
+}
+
+// WithTesting is an option to run the chains in memory
+func WithTesting() Option {
+	return func(ops *serverOptions) error {
+		ops.testing = true
+		return nil
+	}
+}
+
+// WithDispatcher is an option to route the network messages through the dispatcher given rather than the one built
+// from config
+func WithDispatcher(d dispatcher.Dispatcher) Option {
+	return func(ops *serverOptions) error {
+		if d == nil {
+			return errors.New("dispatcher cannot be nil")
+		}
+		ops.dispatcher = d
+		return nil
+	}
+}
+
+// WithOverlay is an option to send and take in the messages over the P2P network given rather than the agent built from
+// config. The overlay passes the messages received to the dispatcher on its own, e.g., the one given by WithDispatcher.
+func WithOverlay(o p2p.Overlay) Option {
+	return func(ops *serverOptions) error {
+		if o == nil {
+			return errors.New("overlay cannot be nil")
+		}
+		ops.overlay = o
+		return nil
+	}
+}
+
+// WithBlockchain is an option to run the root chain given rather than the one built from config. The registry is the
+// one the chain is built with, and a new one is created if it's nil.
+func WithBlockchain(bc blockchain.Blockchain, registry *protocol.Registry) Option {
+	return func(ops *serverOptions) error {
+		ops.rootOpts = append(ops.rootOpts, chainservice.WithBlockchain(bc, registry))
+		return nil
+	}
+}
+
+// WithActPool is an option to take the actions of the root chain into the pool given rather than the one built from
+// config
+func WithActPool(ap actpool.ActPool) Option {
+	return func(ops *serverOptions) error {
+		ops.rootOpts = append(ops.rootOpts, chainservice.WithActPool(ap))
+		return nil
+	}
+}
+
+// NewServer creates a new server running the root chain and the sub-chains in config
 // TODO clean up config, make root config contains network, dispatch and chainservice
 func NewServer(cfg config.Config) (*Server, error) {
-	return newServer(cfg, false)
+	return NewServerWithOptions(cfg)
 }
 
 // NewInMemTestServer creates a test server in memory
 func NewInMemTestServer(cfg config.Config) (*Server, error) {
-	return newServer(cfg, true)
+	return NewServerWithOptions(cfg, WithTesting())
 }
 
-func newServer(cfg config.Config, testing bool) (*Server, error) {
-	// create dispatcher instance
-	dispatcher, err := dispatcher.NewDispatcher(cfg)
-	if err != nil {
-		return nil, errors.Wrap(err, "fail to create dispatcher")
+// NewServerWithOptions creates a new server like NewServer, with the components given by the options, e.g., fakes in
+// tests. The components not given are built from config.
+func NewServerWithOptions(cfg config.Config, options ...Option) (*Server, error) {
+	var ops serverOptions
+	for _, opt := range options {
+		if err := opt(&ops); err != nil {
+			return nil, err
+		}
+	}
+	var err error
+	dp := ops.dispatcher
+	if dp == nil {
+		if dp, err = dispatcher.NewDispatcher(cfg); err != nil {
+			return nil, errors.Wrap(err, "fail to create dispatcher")
+		}
+	}
+	p2pAgent := ops.overlay
+	if p2pAgent == nil {
+		p2pAgent = p2p.NewAgent(cfg, dp.HandleBroadcast, dp.HandleTell)
 	}
-	p2pAgent := p2p.NewAgent(cfg, dispatcher.HandleBroadcast, dispatcher.HandleTell)
 	chains := make(map[uint32]*chainservice.ChainService)
 	var cs *chainservice.ChainService
 	var opts []chainservice.Option
-	if testing {
+	if ops.testing {
 		opts = []chainservice.Option{
 			chainservice.WithTesting(),
 		}
 	}
-	cs, err = chainservice.New(cfg, p2pAgent, dispatcher, opts...)
+	rootOpts := append(opts, ops.rootOpts...)
+	var watcher *delegateWatcher
+	if cfg.Delegate.AddrsFile != "" || cfg.Delegate.PeersFile != "" {
+		if watcher, err = newDelegateWatcher(cfg, p2pAgent); err != nil {
+			return nil, errors.Wrap(err, "fail to read the delegate or peer list file")
+		}
+		if watcher.list != nil {
+			rootOpts = append([]chainservice.Option{chainservice.WithDelegateList(watcher.list)}, rootOpts...)
+		}
+	}
+	cs, err = chainservice.New(cfg, p2pAgent, dp, rootOpts...)
 	if err != nil {
 		return nil, errors.Wrap(err, "fail to create chain service")
 	}
@@ -92,7 +187,7 @@ func newServer(cfg config.Config, testing bool) (*Server, error) {
 			protocol.NewGenericValidator(cs.Blockchain(), cfg.Genesis.ActionGasLimit),
 		)
 	// Install protocols
-	if err := registerDefaultProtocols(cs, cfg.Genesis); err != nil {
+	if err := registerDefaultProtocols(cs, cfg); err != nil {
 		return nil, err
 	}
 	mainChainProtocol := mainchain.NewProtocol(cs.Blockchain())
@@ -101,26 +196,57 @@ func newServer(cfg config.Config, testing bool) (*Server, error) {
 	}
 	// TODO: explorer dependency deleted here at #1085, need to revive by migrating to api
 	chains[cs.ChainID()] = cs
-	dispatcher.AddSubscriber(cs.ChainID(), cs)
+	dp.AddSubscriber(cs.ChainID(), cs)
 	svr := Server{
 		cfg:                  cfg,
 		p2pAgent:             p2pAgent,
-		dispatcher:           dispatcher,
+		dispatcher:           dp,
 		rootChainService:     cs,
 		chainservices:        chains,
 		mainChainProtocol:    mainChainProtocol,
 		initializedSubChains: map[uint32]bool{},
 	}
+	if watcher != nil {
+		watcher.cs = cs
+		svr.delegateWatcher = watcher
+		svr.delegateWatchTask = routine.NewRecurringTask(watcher.check, cfg.Delegate.WatchInterval)
+	}
+	// Create the chain services of the sub-chains in config, which share the network with the root chain
+	for _, sub := range cfg.SubChains {
+		subCfg, err := cfg.SubChainConfig(sub)
+		if err != nil {
+			return nil, err
+		}
+		if err := svr.newSubChainService(subCfg, opts...); err != nil {
+			return nil, errors.Wrapf(err, "fail to create chain service of sub-chain %d", sub.ID)
+		}
+		dp.AddSubscriber(sub.ID, svr.chainservices[sub.ID])
+	}
+	svr.registerDefaultReloaders()
 	// Setup sub-chain starter
 	// TODO: sub-chain infra should use main-chain API instead of protocol directly
 	return &svr, nil
 }
 
-// Start starts the server
+// Start starts the server. It is a no-op if the server is started, and fails if the server is stopped.
 func (s *Server) Start(ctx context.Context) error {
+	s.lifecycleMutex.Lock()
+	defer s.lifecycleMutex.Unlock()
+	if s.stopped {
+		return errors.New("server is stopped")
+	}
+	if s.started {
+		return nil
+	}
+	s.started = true
+	for _, p := range s.plugins {
+		if err := p.Init(s); err != nil {
+			return errors.Wrapf(err, "error when initializing plugin %s", p.Name())
+		}
+	}
 	cctx, cancel := context.WithCancel(context.Background())
 	s.subModuleCancel = cancel
-	if err := s.p2pAgent.Start(cctx); err != nil {
+	if err := lifecycle.StartLabeled(cctx, "p2p", s.p2pAgent); err != nil {
 		return errors.Wrap(err, "error when starting P2P agent")
 	}
 	if err := s.rootChainService.Blockchain().AddSubscriber(s); err != nil {
@@ -131,31 +257,123 @@ func (s *Server) Start(ctx context.Context) error {
 			return errors.Wrap(err, "error when starting blockchain")
 		}
 	}
-	if err := s.dispatcher.Start(cctx); err != nil {
+	if err := lifecycle.StartLabeled(cctx, "dispatcher", s.dispatcher); err != nil {
 		return errors.Wrap(err, "error when starting dispatcher")
 	}
+	if s.delegateWatchTask != nil {
+		if err := lifecycle.StartLabeled(cctx, "delegatewatcher", s.delegateWatchTask); err != nil {
+			return errors.Wrap(err, "error when starting watching the delegate and peer list files")
+		}
+	}
+	for _, p := range s.plugins {
+		if err := lifecycle.StartLabeled(cctx, "plugin."+p.Name(), p); err != nil {
+			return errors.Wrapf(err, "error when starting plugin %s", p.Name())
+		}
+	}
 
 	return nil
 }
 
-// Stop stops the server
+// Stop stops the server in the order that keeps the work in flight. It stops the plugins first, then stops taking the
+// network messages and the API requests, lets the consensus finish the event in handling and abandon the rest of the
+// round, and sends the outbound messages left before disconnecting from the network. The chain DBs are closed last,
+// even if the deadline of ctx is hit by the steps before, so that the node restarts from a consistent state. Stop is a
+// no-op if the server isn't started or is stopped.
 func (s *Server) Stop(ctx context.Context) error {
+	s.lifecycleMutex.Lock()
+	defer s.lifecycleMutex.Unlock()
+	if !s.started || s.stopped {
+		return nil
+	}
+	s.stopped = true
 	defer s.subModuleCancel()
-	if err := s.p2pAgent.Stop(ctx); err != nil {
-		return errors.Wrap(err, "error when stopping P2P agent")
+
+	s.mutex.RLock()
+	chainservices := make([]*chainservice.ChainService, 0, len(s.chainservices))
+	for _, cs := range s.chainservices {
+		chainservices = append(chainservices, cs)
 	}
-	if err := s.dispatcher.Stop(ctx); err != nil {
-		return errors.Wrap(err, "error when stopping dispatcher")
+	s.mutex.RUnlock()
+	forEachChain := func(stop func(*chainservice.ChainService, context.Context) error) func(context.Context) error {
+		return func(ctx context.Context) error {
+			for _, cs := range chainservices {
+				if err := stop(cs, ctx); err != nil {
+					return errors.Wrapf(err, "chain %d", cs.ChainID())
+				}
+			}
+			return nil
+		}
 	}
-	if err := s.rootChainService.Blockchain().RemoveSubscriber(s); err != nil {
-		return errors.Wrap(err, "error when unsubscribing root chain block creation")
+	type step struct {
+		name string
+		stop func(context.Context) error
 	}
-	for _, cs := range s.chainservices {
-		if err := cs.Stop(ctx); err != nil {
-			return errors.Wrap(err, "error when stopping blockchain")
+	// the plugins are stopped first, in the reverse order of starting
+	steps := make([]step, 0, len(s.plugins)+5)
+	for i := len(s.plugins) - 1; i >= 0; i-- {
+		steps = append(steps, step{"plugin " + s.plugins[i].Name(), s.plugins[i].Stop})
+	}
+	steps = append(steps, []step{
+		{"delegate watcher", func(ctx context.Context) error {
+			if s.delegateWatchTask == nil {
+				return nil
+			}
+			return s.delegateWatchTask.Stop(ctx)
+		}},
+		{"dispatcher", s.dispatcher.Stop},
+		{"API", forEachChain((*chainservice.ChainService).StopAPI)},
+		{"consensus", func(ctx context.Context) error {
+			if err := s.rootChainService.Blockchain().RemoveSubscriber(s); err != nil {
+				return errors.Wrap(err, "error when unsubscribing root chain block creation")
+			}
+			return forEachChain((*chainservice.ChainService).StopProcessing)(ctx)
+		}},
+		{"P2P agent", s.p2pAgent.Stop},
+	}...)
+	var stopErr error
+	for _, step := range steps {
+		if err := stopWithDeadline(ctx, step.name, step.stop); err != nil && stopErr == nil {
+			stopErr = err
 		}
 	}
-	return nil
+	// the chain DBs are closed regardless of the errors and the deadline
+	start := time.Now()
+	err := forEachChain((*chainservice.ChainService).StopStorage)(ctx)
+	logStop("chain DBs", start, err)
+	if err != nil && stopErr == nil {
+		stopErr = errors.Wrap(err, "error when stopping chain DBs")
+	}
+	return stopErr
+}
+
+// stopWithDeadline runs the stop step, and gives up waiting for it once ctx is done
+func stopWithDeadline(ctx context.Context, name string, stop func(context.Context) error) error {
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- stop(ctx)
+	}()
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = errors.Wrap(ctx.Err(), "abandoned")
+	}
+	logStop(name, start, err)
+	return errors.Wrapf(err, "error when stopping %s", name)
+}
+
+func logStop(name string, start time.Time, err error) {
+	if err != nil {
+		log.L().Error(
+			"Failed to stop server component.",
+			zap.String("component", name),
+			zap.Duration("duration", time.Since(start)),
+			zap.Error(err),
+		)
+		return
+	}
+	log.L().Info("Stopped server component.", zap.String("component", name), zap.Duration("duration", time.Since(start)))
 }
 
 // NewSubChainService creates a new chain service in this server.
@@ -179,7 +397,7 @@ func (s *Server) newSubChainService(cfg config.Config, opts ...chainservice.Opti
 		AddActionEnvelopeValidators(
 			protocol.NewGenericValidator(cs.Blockchain(), cfg.Genesis.ActionGasLimit),
 		)
-	if err := registerDefaultProtocols(cs, cfg.Genesis); err != nil {
+	if err := registerDefaultProtocols(cs, cfg); err != nil {
 		return err
 	}
 	s.chainservices[cs.ChainID()] = cs
@@ -197,8 +415,8 @@ func (s *Server) StopChainService(ctx context.Context, id uint32) error {
 	return c.Stop(ctx)
 }
 
-// P2PAgent returns the P2P agent
-func (s *Server) P2PAgent() *p2p.Agent {
+// P2PAgent returns the P2P network the server runs over
+func (s *Server) P2PAgent() p2p.Overlay {
 	return s.p2pAgent
 }
 
@@ -214,11 +432,27 @@ func (s *Server) Dispatcher() dispatcher.Dispatcher {
 	return s.dispatcher
 }
 
-// StartServer starts a node server
-func StartServer(ctx context.Context, svr *Server, probeSvr *probe.Server, cfg config.Config) {
+// Identity returns the address and the public key of the node, which are derived from its producer private key
+func (s *Server) Identity() (string, []byte, error) {
+	s.mutex.RLock()
+	key := string(s.cfg.Chain.ProducerPrivKey)
+	s.mutex.RUnlock()
+	sk, err := keypair.HexStringToPrivateKey(key)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed to decode the producer private key")
+	}
+	addr, err := addrutil.PubKeyToAddress(sk.PublicKey())
+	if err != nil {
+		return "", nil, err
+	}
+	return addr.String(), sk.PublicKey().Bytes(), nil
+}
+
+// StartServer starts a node server, and stops it gracefully within System.ShutdownTimeout once ctx is done. It returns
+// an error if the server fails to start or to stop.
+func StartServer(ctx context.Context, svr *Server, probeSvr *probe.Server, cfg config.Config) error {
 	if err := svr.Start(ctx); err != nil {
-		log.L().Fatal("Failed to start server.", zap.Error(err))
-		return
+		return errors.Wrap(err, "failed to start server")
 	}
 	probeSvr.Ready()
 
@@ -236,21 +470,13 @@ func StartServer(ctx context.Context, svr *Server, probeSvr *probe.Server, cfg c
 
 	var adminserv http.Server
 	if cfg.System.HTTPAdminPort > 0 {
-		mux := http.NewServeMux()
-		log.RegisterLevelConfigMux(mux)
-		haCtl := ha.New(svr.rootChainService.Consensus())
-		mux.Handle("/ha", http.HandlerFunc(haCtl.Handle))
-		mux.Handle("/debug/pprof/", http.HandlerFunc(pprof.Index))
-		mux.Handle("/debug/pprof/cmdline", http.HandlerFunc(pprof.Cmdline))
-		mux.Handle("/debug/pprof/profile", http.HandlerFunc(pprof.Profile))
-		mux.Handle("/debug/pprof/symbol", http.HandlerFunc(pprof.Symbol))
-		mux.Handle("/debug/pprof/trace", http.HandlerFunc(pprof.Trace))
-
-		port := fmt.Sprintf(":%d", cfg.System.HTTPAdminPort)
-		adminserv = httputil.Server(port, mux)
-		go func() {
+		if cfg.System.EnablePprof {
 			runtime.SetMutexProfileFraction(1)
 			runtime.SetBlockProfileRate(1)
+		}
+		addr := fmt.Sprintf("%s:%d", cfg.System.HTTPAdminHost, cfg.System.HTTPAdminPort)
+		adminserv = httputil.Server(addr, newAdminMux(svr, cfg))
+		go func() {
 			ln, err := httputil.LimitListener(adminserv.Addr)
 			if err != nil {
 				log.L().Error("Error when listen to profiling port.", zap.Error(err))
@@ -264,15 +490,24 @@ func StartServer(ctx context.Context, svr *Server, probeSvr *probe.Server, cfg c
 
 	<-ctx.Done()
 	probeSvr.NotReady()
-	if err := adminserv.Shutdown(ctx); err != nil {
+	// ctx is done already, so stopping takes a fresh one with the shutdown deadline
+	stopCtx := context.Background()
+	if cfg.System.ShutdownTimeout > 0 {
+		var cancel context.CancelFunc
+		stopCtx, cancel = context.WithTimeout(stopCtx, cfg.System.ShutdownTimeout)
+		defer cancel()
+	}
+	if err := adminserv.Shutdown(stopCtx); err != nil {
 		log.L().Error("Error when serving metrics data.", zap.Error(err))
 	}
-	if err := svr.Stop(ctx); err != nil {
-		log.L().Panic("Failed to stop server.", zap.Error(err))
+	if err := svr.Stop(stopCtx); err != nil {
+		return errors.Wrap(err, "failed to stop server gracefully")
 	}
+	return nil
 }
 
-func registerDefaultProtocols(cs *chainservice.ChainService, genesisConfig genesis.Genesis) (err error) {
+func registerDefaultProtocols(cs *chainservice.ChainService, cfg config.Config) (err error) {
+	genesisConfig := cfg.Genesis
 	accountProtocol := account.NewProtocol()
 	if err = cs.RegisterProtocol(account.ProtocolID, accountProtocol); err != nil {
 		return
@@ -327,6 +562,14 @@ func registerDefaultProtocols(cs *chainservice.ChainService, genesisConfig genes
 	if err = cs.RegisterProtocol(execution.ProtocolID, executionProtocol); err != nil {
 		return
 	}
-	rewardingProtocol := rewarding.NewProtocol(cs.Blockchain(), rolldposProtocol)
+	keyRotationProtocol := keyrotation.NewProtocol(cs.Blockchain(), rolldposProtocol)
+	if err = cs.RegisterProtocol(keyrotation.ProtocolID, keyRotationProtocol); err != nil {
+		return
+	}
+	var rewardingOpts []rewarding.Option
+	if cfg.Genesis.BurnBlockReward {
+		rewardingOpts = append(rewardingOpts, rewarding.BurnBlockRewardOption())
+	}
+	rewardingProtocol := rewarding.NewProtocol(cs.Blockchain(), rolldposProtocol, rewardingOpts...)
 	return cs.RegisterProtocol(rewarding.ProtocolID, rewardingProtocol)
 }
@@ -0,0 +1,247 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"context"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	peerstore "github.com/libp2p/go-libp2p-peerstore"
+	multiaddr "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/dispatcher"
+	"github.com/iotexproject/iotex-core/state/factory"
+	"github.com/iotexproject/iotex-core/testutil"
+)
+
+func TestServer_StopUnderPressure(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "itx")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	accounts, alloc := testutil.FundedAccounts(4)
+	cfg := config.Default
+	cfg.Chain.ProducerPrivKey = config.Secret(testutil.NewKeyPair("producer").PriKey.HexString())
+	cfg.Chain.ChainDBPath = filepath.Join(dir, "chain.db")
+	cfg.Chain.TrieDBPath = filepath.Join(dir, "trie.db")
+	cfg.Consensus.Scheme = config.StandaloneScheme
+	cfg.Genesis.BlockInterval = 100 * time.Millisecond
+	cfg.Genesis.InitBalanceMap = testutil.MergeAllocation(cfg.Genesis.InitBalanceMap, alloc)
+	cfg.ActPool.MinGasPriceStr = "0"
+	cfg.Network.Port = testutil.RandomPort()
+	cfg.API.Port = testutil.RandomPort()
+
+	ctx := context.Background()
+	svr, err := NewServer(cfg)
+	require.NoError(err)
+	require.NoError(svr.Start(ctx))
+	// starting again is a no-op
+	require.NoError(svr.Start(ctx))
+	bc := svr.ChainService(cfg.Chain.ID).Blockchain()
+	ap := svr.ChainService(cfg.Chain.ID).ActionPool()
+
+	// every account keeps sending the transfers, which are retried once refused, e.g., by the full pool
+	quit := make(chan struct{})
+	var wg sync.WaitGroup
+	for _, acct := range accounts {
+		wg.Add(1)
+		go func(acct *testutil.KeyPair) {
+			defer wg.Done()
+			nonce := uint64(1)
+			for {
+				select {
+				case <-quit:
+					return
+				default:
+				}
+				selp, err := testutil.SignedTransfer(
+					accounts[0].Address.String(),
+					acct.PriKey,
+					nonce,
+					big.NewInt(1),
+					nil,
+					testutil.TestGasLimit,
+					big.NewInt(0),
+				)
+				if err != nil {
+					return
+				}
+				if err := ap.Add(selp); err != nil {
+					time.Sleep(10 * time.Millisecond)
+					continue
+				}
+				nonce++
+			}
+		}(acct)
+	}
+	require.NoError(testutil.WaitUntil(100*time.Millisecond, 20*time.Second, func() (bool, error) {
+		return bc.TipHeight() >= 5, nil
+	}))
+
+	// stop in the middle of the pressure, as the signal handlers do, which may call Stop more than once
+	stopCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			errs <- svr.Stop(stopCtx)
+		}()
+	}
+	require.NoError(<-errs)
+	require.NoError(<-errs)
+	close(quit)
+	wg.Wait()
+	require.Error(svr.Start(ctx))
+	height := bc.TipHeight()
+
+	// the states are committed along with the last block, leaving nothing to replay at restart
+	sf, err := factory.NewFactory(cfg, factory.DefaultTrieOption())
+	require.NoError(err)
+	require.NoError(sf.Start(ctx))
+	stateHeight, err := sf.Height()
+	require.NoError(err)
+	require.NoError(sf.Stop(ctx))
+	require.Equal(height, stateHeight)
+
+	cfg.Network.Port = testutil.RandomPort()
+	cfg.API.Port = testutil.RandomPort()
+	svr, err = NewServer(cfg)
+	require.NoError(err)
+	require.NoError(svr.Start(ctx))
+	defer func() {
+		require.NoError(svr.Stop(ctx))
+	}()
+	bc = svr.ChainService(cfg.Chain.ID).Blockchain()
+	require.True(bc.TipHeight() >= height)
+	require.NoError(bc.VerifyChain(1, height))
+}
+
+func TestServer_Identity(t *testing.T) {
+	require := require.New(t)
+
+	producer := testutil.NewKeyPair("producer")
+	cfg := config.Default
+	cfg.Chain.ProducerPrivKey = config.Secret(producer.PriKey.HexString())
+	svr := &Server{cfg: cfg}
+	addr, pubKey, err := svr.Identity()
+	require.NoError(err)
+	require.Equal(producer.Address.String(), addr)
+	require.Equal(producer.PubKey.Bytes(), pubKey)
+
+	svr.cfg.Chain.ProducerPrivKey = "invalid"
+	_, _, err = svr.Identity()
+	require.Error(err)
+}
+
+func TestNewServerWithOptions(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default
+	cfg.Chain.ProducerPrivKey = config.Secret(testutil.NewKeyPair("producer").PriKey.HexString())
+	cfg.Consensus.Scheme = config.NOOPScheme
+	cfg.Network.Port = testutil.RandomPort()
+	dp, err := dispatcher.NewDispatcher(cfg)
+	require.NoError(err)
+	svr, err := NewServerWithOptions(cfg, WithTesting(), WithDispatcher(dp))
+	require.NoError(err)
+	require.Equal(dp, svr.Dispatcher())
+
+	_, err = NewServerWithOptions(cfg, WithTesting(), WithDispatcher(nil))
+	require.Error(err)
+	_, err = NewServerWithOptions(cfg, WithTesting(), WithActPool(nil))
+	require.Error(err)
+	_, err = NewServerWithOptions(cfg, WithTesting(), WithOverlay(nil))
+	require.Error(err)
+}
+
+func TestNewServerWithOverlay(t *testing.T) {
+	require := require.New(t)
+
+	cfg := config.Default
+	cfg.Chain.ProducerPrivKey = config.Secret(testutil.NewKeyPair("producer").PriKey.HexString())
+	cfg.Consensus.Scheme = config.StandaloneScheme
+	cfg.Genesis.BlockInterval = 100 * time.Millisecond
+	cfg.API.Port = testutil.RandomPort()
+	o := &fakeOverlay{}
+	svr, err := NewServerWithOptions(cfg, WithTesting(), WithOverlay(o))
+	require.NoError(err)
+	require.Equal(o, svr.P2PAgent())
+
+	// the server runs over the overlay given, which the blocks minted are broadcast over
+	ctx := context.Background()
+	require.NoError(svr.Start(ctx))
+	require.True(o.isStarted())
+	require.NoError(testutil.WaitUntil(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		return o.numBroadcast() > 0, nil
+	}))
+	require.NoError(svr.Stop(ctx))
+	require.False(o.isStarted())
+}
+
+// fakeOverlay records the messages broadcast without a network
+type fakeOverlay struct {
+	mu        sync.Mutex
+	started   bool
+	broadcast []proto.Message
+}
+
+func (o *fakeOverlay) Start(context.Context) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.started = true
+	return nil
+}
+
+func (o *fakeOverlay) Stop(context.Context) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.started = false
+	return nil
+}
+
+func (o *fakeOverlay) BroadcastOutbound(_ context.Context, msg proto.Message) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.broadcast = append(o.broadcast, msg)
+	return nil
+}
+
+func (o *fakeOverlay) UnicastOutbound(context.Context, peerstore.PeerInfo, proto.Message) error {
+	return nil
+}
+
+func (o *fakeOverlay) Neighbors(context.Context) ([]peerstore.PeerInfo, error) { return nil, nil }
+
+func (o *fakeOverlay) SetWhitelist([]string) {}
+
+func (o *fakeOverlay) Whitelisted(string) bool { return true }
+
+func (o *fakeOverlay) Info() peerstore.PeerInfo { return peerstore.PeerInfo{} }
+
+func (o *fakeOverlay) Self() []multiaddr.Multiaddr { return nil }
+
+func (o *fakeOverlay) isStarted() bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.started
+}
+
+func (o *fakeOverlay) numBroadcast() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.broadcast)
+}
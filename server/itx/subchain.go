@@ -8,8 +8,6 @@ package itx
 
 import (
 	"context"
-	"fmt"
-	"path"
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -17,6 +15,7 @@ import (
 	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-core/action/protocol/multichain/mainchain"
 	"github.com/iotexproject/iotex-core/blockchain/block"
+	"github.com/iotexproject/iotex-core/config"
 	"github.com/iotexproject/iotex-core/pkg/log"
 )
 
@@ -31,8 +30,8 @@ func (s *Server) runSubChain(addr address.Address, subChain *mainchain.SubChain)
 	cfg := s.cfg
 	cfg.Chain.ID = subChain.ChainID
 	cfg.Chain.Address = addr.String()
-	cfg.Chain.ChainDBPath = getSubChainDBPath(subChain.ChainID, cfg.Chain.ChainDBPath)
-	cfg.Chain.TrieDBPath = getSubChainDBPath(subChain.ChainID, cfg.Chain.TrieDBPath)
+	cfg.Chain.ChainDBPath = config.SubChainDBPath(subChain.ChainID, cfg.Chain.ChainDBPath)
+	cfg.Chain.TrieDBPath = config.SubChainDBPath(subChain.ChainID, cfg.Chain.TrieDBPath)
 	cfg.Chain.EmptyGenesis = true
 	if err := s.newSubChainService(cfg); err != nil {
 		return err
@@ -93,8 +92,3 @@ func (s *Server) HandleBlock(blk *block.Block) error {
 	}
 	return nil
 }
-
-func getSubChainDBPath(chainID uint32, p string) string {
-	dir, file := path.Split(p)
-	return path.Join(dir, fmt.Sprintf("chain-%d-%s", chainID, file))
-}
@@ -0,0 +1,75 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package itx
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/blocksync"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// syncStatusView is the sync status of a chain served in JSON
+type syncStatusView struct {
+	ChainID         uint32  `json:"chainID"`
+	State           string  `json:"state"`
+	TipHeight       uint64  `json:"tipHeight"`
+	PeerHeight      uint64  `json:"peerHeight"`
+	Peer            string  `json:"peer,omitempty"`
+	BlocksPerSecond float64 `json:"blocksPerSecond"`
+	ETA             string  `json:"eta,omitempty"`
+}
+
+// SyncStatus returns the block sync status of every chain
+func (s *Server) SyncStatus() map[uint32]blocksync.Status {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	statuses := make(map[uint32]blocksync.Status, len(s.chainservices))
+	for id, cs := range s.chainservices {
+		statuses[id] = cs.BlockSync().Status()
+	}
+	return statuses
+}
+
+// HandleSyncStatus serves the block sync status of every chain in JSON. It responds 503 if any chain is behind its
+// peers, so as to serve the readiness probe as well.
+func (s *Server) HandleSyncStatus(w http.ResponseWriter, r *http.Request) {
+	code := http.StatusOK
+	views := make([]syncStatusView, 0)
+	for id, status := range s.SyncStatus() {
+		view := syncStatusView{
+			ChainID:         id,
+			State:           string(status.State),
+			TipHeight:       status.TipHeight,
+			PeerHeight:      status.PeerHeight,
+			Peer:            status.Peer,
+			BlocksPerSecond: status.BlocksPerSecond,
+		}
+		if status.ETA > 0 {
+			view.ETA = status.ETA.String()
+		}
+		if status.State != blocksync.SyncIdle {
+			code = http.StatusServiceUnavailable
+		}
+		views = append(views, view)
+	}
+	sort.Slice(views, func(i, j int) bool { return views[i].ChainID < views[j].ChainID })
+	data, err := json.Marshal(views)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if _, err := w.Write(data); err != nil {
+		log.L().Error("Error when writing the sync status.", zap.Error(err))
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package nodecmd
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/blockchain"
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/log"
+)
+
+// runCheckpoint exports the checkpoint snapshot of the chain tip of the stopped node, which a fresh node is synced
+// from by run --sync-from-checkpoint
+func runCheckpoint(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] != "export" {
+		fmt.Fprintln(stderr, "usage: server checkpoint export --out=snapshot [--config=config.yaml] [flags]")
+		return ExitInvalidConfig
+	}
+	fs := newRunFlagSet(stderr)
+	out := fs.String("out", "", "Path of the checkpoint snapshot to write")
+	if err := fs.Parse(args[1:]); err != nil {
+		if err == flag.ErrHelp {
+			return ExitOK
+		}
+		return ExitInvalidConfig
+	}
+	if *out == "" || fs.NArg() > 0 {
+		fmt.Fprintln(stderr, "usage: server checkpoint export --out=snapshot [--config=config.yaml] [flags]")
+		return ExitInvalidConfig
+	}
+	genesisCfg, err := genesis.New()
+	if err != nil {
+		fmt.Fprintln(stderr, "Failed to new genesis config:", err)
+		return ExitInvalidConfig
+	}
+	cfg, err := config.New()
+	if err != nil {
+		fmt.Fprintln(stderr, "Failed to new config:", err)
+		return ExitInvalidConfig
+	}
+	cfg.Genesis = genesisCfg
+
+	f, err := os.OpenFile(*out, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		fmt.Fprintln(stderr, "Failed to create the checkpoint snapshot:", err)
+		return ExitFailure
+	}
+	checkpoint, err := blockchain.ExportCheckpoint(cfg, f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(*out)
+		fmt.Fprintln(stderr, "Failed to export the checkpoint snapshot:", err)
+		return ExitFailure
+	}
+	fmt.Fprintf(stdout, "Exported block %d to %s, which is taken with the checkpoint and the state root pinned:\n",
+		checkpoint.Height, *out)
+	fmt.Fprintf(stdout, "  checkpoints:\n    %d: %x\n", checkpoint.Height, checkpoint.Hash)
+	fmt.Fprintf(stdout, "  checkpointStateRoots:\n    %d: %x\n", checkpoint.Height, checkpoint.StateRoot)
+	fmt.Fprintf(stdout, "along with the delegates of the epoch in checkpointDelegates, more than 2/3 of whom endorse "+
+		"the block.\n")
+	return ExitOK
+}
+
+// importCheckpoint sets up the fresh node to sync from the checkpoint snapshot in the file
+func importCheckpoint(cfg config.Config, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return errors.Wrap(err, "failed to open the checkpoint snapshot")
+	}
+	defer f.Close()
+	height, err := blockchain.ImportCheckpoint(cfg, f)
+	if err != nil {
+		return err
+	}
+	log.L().Info("Imported the checkpoint snapshot, syncing the blocks above it.",
+		zap.String("snapshot", path),
+		zap.Uint64("height", height))
+	return nil
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package nodecmd
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
+	"github.com/iotexproject/iotex-core/tools/configcmd"
+)
+
+const (
+	nodeConfigFile   = "config.yaml"
+	nodeGenesisFile  = "genesis.yaml"
+	nodeKeystoreFile = "producer.keystore"
+)
+
+var (
+	// the scrypt parameters encrypting the producer keystore, which the tests lighten
+	keystoreScryptN = keystore.StandardScryptN
+	keystoreScryptP = keystore.StandardScryptP
+)
+
+// initNode creates the files of a new node in a directory: the producer keystore holding a new key, the config of the
+// profile referring to the keystore, and the genesis. The passphrase of the keystore is read from --passphrase-file,
+// or config.KeystorePassphraseEnv, which the node reads it from on start too.
+func initNode(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("init", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	dir := fs.String("dir", ".", "directory of the node files")
+	profile := fs.String("profile", config.ProfileFullNode, "profile of the node, delegate, fullnode or standalone-dev")
+	passphraseFile := fs.String("passphrase-file", "", "path of the file holding the passphrase of the keystore")
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return ExitOK
+		}
+		return ExitInvalidConfig
+	}
+	if _, err := config.NewProfile(*profile); err != nil {
+		fmt.Fprintln(stderr, err)
+		return ExitInvalidConfig
+	}
+	passphrase, ok := os.LookupEnv(config.KeystorePassphraseEnv)
+	if *passphraseFile != "" {
+		data, err := ioutil.ReadFile(*passphraseFile)
+		if err != nil {
+			fmt.Fprintln(stderr, "Failed to read the passphrase:", err)
+			return ExitInvalidConfig
+		}
+		passphrase, ok = strings.TrimSpace(string(data)), true
+	}
+	if !ok || passphrase == "" {
+		fmt.Fprintf(stderr, "the keystore passphrase is in neither --passphrase-file nor %s\n",
+			config.KeystorePassphraseEnv)
+		return ExitInvalidConfig
+	}
+
+	files, err := writeNodeFiles(*dir, *profile, passphrase)
+	if err != nil {
+		fmt.Fprintln(stderr, "Failed to init the node:", err)
+		return ExitFailure
+	}
+	fmt.Fprintf(stdout, "Created a %s node with producer %s:\n", *profile, files.producer)
+	fmt.Fprintf(stdout, "  config:   %s\n  genesis:  %s\n  keystore: %s\n", files.config, files.genesis, files.keystore)
+	fmt.Fprintf(stdout, "Start the node by:\n  %s=<passphrase> server run --config=%s --genesis-path=%s\n",
+		config.KeystorePassphraseEnv, files.config, files.genesis)
+	return ExitOK
+}
+
+type nodeFiles struct {
+	config   string
+	genesis  string
+	keystore string
+	producer string
+}
+
+// writeNodeFiles writes the node files into dir, which refuses to overwrite any of them
+func writeNodeFiles(dir, profile, passphrase string) (*nodeFiles, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	files := &nodeFiles{
+		config:   filepath.Join(dir, nodeConfigFile),
+		genesis:  filepath.Join(dir, nodeGenesisFile),
+		keystore: filepath.Join(dir, nodeKeystoreFile),
+	}
+	for _, path := range []string{files.config, files.genesis, files.keystore} {
+		if _, err := os.Stat(path); err == nil {
+			return nil, errors.Errorf("%s already exists", path)
+		} else if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	var cfgBuf, genesisBuf bytes.Buffer
+	if err := configcmd.InitWithProducerKey(
+		profile,
+		config.KeystoreRefPrefix+files.keystore,
+		&cfgBuf,
+		&genesisBuf,
+	); err != nil {
+		return nil, err
+	}
+	sk, err := keypair.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	addr, err := addrutil.PubKeyToAddress(sk.PublicKey())
+	if err != nil {
+		return nil, err
+	}
+	files.producer = addr.String()
+	if err := writeKeystore(sk, passphrase, files.keystore); err != nil {
+		return nil, errors.Wrap(err, "failed to write the producer keystore")
+	}
+	if err := ioutil.WriteFile(files.config, cfgBuf.Bytes(), 0644); err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(files.genesis, genesisBuf.Bytes(), 0644); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// writeKeystore encrypts the private key into the keystore file at path
+func writeKeystore(sk keypair.PrivateKey, passphrase, path string) error {
+	// the keystore names the file after the time and the address, so it's written aside and then moved to path
+	tmpDir, err := ioutil.TempDir(filepath.Dir(path), ".keystore")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+	ks := keystore.NewKeyStore(tmpDir, keystoreScryptN, keystoreScryptP)
+	account, err := ks.ImportECDSA(sk.EcdsaPrivateKey(), passphrase)
+	if err != nil {
+		return err
+	}
+	return os.Rename(account.URL.Path, path)
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// Package nodecmd is the command line of the node binary. Usage:
+//
+//	server [run] [--config=config.yaml] [--genesis-path=genesis.yaml] [flags]
+//	server run --sync-from-checkpoint=snapshot [--config=config.yaml] [--genesis-path=genesis.yaml] [flags]
+//	server checkpoint export --out=snapshot [--config=config.yaml] [--genesis-path=genesis.yaml] [flags]
+//	server init [--dir=.] [--profile=delegate|fullnode|standalone-dev] [--passphrase-file=path]
+//	server config init|validate [flags]
+//	server version
+//	server tools genaddr [flags]
+package nodecmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/pkg/version"
+	"github.com/iotexproject/iotex-core/tools/configcmd"
+	"github.com/iotexproject/iotex-core/tools/genaddr"
+)
+
+const (
+	// ExitOK is the exit code of a successful command
+	ExitOK = 0
+	// ExitFailure is the exit code of a command failing at runtime, e.g., the node failing to start or to stop
+	ExitFailure = 1
+	// ExitInvalidConfig is the exit code of a command given invalid flags or config files
+	ExitInvalidConfig = 2
+)
+
+const usage = `usage: server [run] [--config=config.yaml] [--genesis-path=genesis.yaml] [flags]
+       server run --sync-from-checkpoint=snapshot [--config=config.yaml] [--genesis-path=genesis.yaml] [flags]
+       server checkpoint export --out=snapshot [--config=config.yaml] [--genesis-path=genesis.yaml] [flags]
+       server init [--dir=.] [--profile=delegate|fullnode|standalone-dev] [--passphrase-file=path]
+       server config init|validate [flags]
+       server version
+       server tools genaddr [flags]
+`
+
+// Main runs the command given by args, and returns the exit code. Running the node is the default command, so that
+// the binary started with the flags only keeps working.
+func Main(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return runNode(args, stderr)
+	}
+	switch args[0] {
+	case "run":
+		return runNode(args[1:], stderr)
+	case "init":
+		return initNode(args[1:], stdout, stderr)
+	case "checkpoint":
+		return runCheckpoint(args[1:], stdout, stderr)
+	case "config":
+		return runConfig(args[1:], stdout, stderr)
+	case "version":
+		printVersion(stdout)
+		return ExitOK
+	case "tools":
+		return runTools(args[1:], stdout, stderr)
+	case "help":
+		fmt.Fprint(stdout, usage)
+		return ExitOK
+	default:
+		fmt.Fprintf(stderr, "unknown command %s\n%s", args[0], usage)
+		return ExitInvalidConfig
+	}
+}
+
+// runConfig generates or validates the config files. Failing to write the generated files is a runtime failure, and
+// the others are config errors.
+func runConfig(args []string, stdout, stderr io.Writer) int {
+	err := configcmd.Run(args, stdout)
+	if err == nil {
+		return ExitOK
+	}
+	fmt.Fprintln(stderr, "Failed to run config:", err)
+	if _, ok := errors.Cause(err).(*os.PathError); ok && args[0] == "init" {
+		return ExitFailure
+	}
+	return ExitInvalidConfig
+}
+
+// runTools runs the offline tools bundled in the node binary
+func runTools(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, "usage: server tools genaddr [flags]")
+		return ExitInvalidConfig
+	}
+	switch args[0] {
+	case "genaddr":
+		if err := genaddr.Run(args[1:], stdout); err != nil {
+			fmt.Fprintln(stderr, "Failed to run genaddr:", err)
+			return ExitFailure
+		}
+		return ExitOK
+	default:
+		fmt.Fprintf(stderr, "unknown tool %s\n", args[0])
+		return ExitInvalidConfig
+	}
+}
+
+// printVersion prints the build info embedded by the ldflags of the Makefile
+func printVersion(out io.Writer) {
+	fmt.Fprintf(out, "version:    %s\n", version.PackageVersion)
+	fmt.Fprintf(out, "commit:     %s\n", version.PackageCommitID)
+	fmt.Fprintf(out, "git status: %s\n", version.GitStatus)
+	fmt.Fprintf(out, "go version: %s\n", version.GoVersion)
+	fmt.Fprintf(out, "build time: %s\n", version.BuildTime)
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package nodecmd
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
+	"github.com/iotexproject/iotex-core/pkg/version"
+)
+
+func TestMain_Commands(t *testing.T) {
+	require := require.New(t)
+
+	var stdout, stderr bytes.Buffer
+	require.Equal(ExitOK, Main([]string{"version"}, &stdout, &stderr))
+	require.Contains(stdout.String(), "version:    "+version.PackageVersion)
+	require.Contains(stdout.String(), "commit:     "+version.PackageCommitID)
+	require.Contains(stdout.String(), "build time: "+version.BuildTime)
+
+	stdout.Reset()
+	require.Equal(ExitOK, Main([]string{"help"}, &stdout, &stderr))
+	require.Contains(stdout.String(), "usage: server")
+
+	require.Equal(ExitInvalidConfig, Main([]string{"unknown"}, &stdout, &stderr))
+	require.Contains(stderr.String(), "unknown command unknown")
+	require.Equal(ExitInvalidConfig, Main([]string{"tools"}, &stdout, &stderr))
+	require.Equal(ExitInvalidConfig, Main([]string{"run", "--unknown-flag"}, &stdout, &stderr))
+	require.Equal(ExitInvalidConfig, Main([]string{"run", "extra"}, &stdout, &stderr))
+	require.Equal(ExitInvalidConfig, Main([]string{"checkpoint"}, &stdout, &stderr))
+	require.Equal(ExitInvalidConfig, Main([]string{"checkpoint", "export"}, &stdout, &stderr))
+	require.Equal(
+		ExitFailure,
+		Main([]string{"run", "--sync-from-checkpoint=" + filepath.Join(os.TempDir(), "missing")}, &stdout, &stderr),
+	)
+}
+
+func TestMain_ConfigValidate(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "nodecmd")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	var stdout, stderr bytes.Buffer
+	validPath := filepath.Join(dir, "valid.yaml")
+	require.Equal(ExitOK, Main([]string{"config", "init", "--out=" + validPath}, &stdout, &stderr))
+	require.Equal(ExitOK, Main([]string{"config", "validate", validPath}, &stdout, &stderr))
+	require.Contains(stdout.String(), validPath+": ok")
+
+	invalidPath := filepath.Join(dir, "invalid.yaml")
+	require.NoError(ioutil.WriteFile(invalidPath, []byte("actPool:\n  maxNumActsPerPool: 0\n"), 0644))
+	require.Equal(ExitInvalidConfig, Main([]string{"config", "validate", invalidPath}, &stdout, &stderr))
+	require.Equal(ExitInvalidConfig, Main([]string{"config", "validate"}, &stdout, &stderr))
+	require.Equal(ExitInvalidConfig, Main([]string{"config", "init", "--profile=unknown"}, &stdout, &stderr))
+	require.Equal(
+		ExitFailure,
+		Main([]string{"config", "init", "--out=" + filepath.Join(dir, "missing", "config.yaml")}, &stdout, &stderr),
+	)
+
+	// the node refuses to run with an invalid config
+	defer func() {
+		require.NoError(flag.Set("config-path", ""))
+	}()
+	require.Equal(ExitInvalidConfig, Main([]string{"run", "--config=" + invalidPath}, &stdout, &stderr))
+	require.Contains(stderr.String(), "maxNumActsPerPool")
+	require.Equal(ExitInvalidConfig, Main([]string{"--config-path=" + invalidPath}, &stdout, &stderr))
+}
+
+func TestMain_Init(t *testing.T) {
+	require := require.New(t)
+
+	keystoreScryptN, keystoreScryptP = keystore.LightScryptN, keystore.LightScryptP
+	defer func() {
+		keystoreScryptN, keystoreScryptP = keystore.StandardScryptN, keystore.StandardScryptP
+	}()
+	dir, err := ioutil.TempDir("", "nodecmd")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	passphraseFile := filepath.Join(dir, "passphrase")
+	require.NoError(ioutil.WriteFile(passphraseFile, []byte("passphrase\n"), 0600))
+	nodeDir := filepath.Join(dir, "node")
+
+	var stdout, stderr bytes.Buffer
+	require.Equal(ExitInvalidConfig, Main([]string{"init", "--dir=" + nodeDir}, &stdout, &stderr))
+	require.Contains(stderr.String(), config.KeystorePassphraseEnv)
+	require.Equal(ExitInvalidConfig, Main([]string{
+		"init", "--dir=" + nodeDir, "--profile=unknown", "--passphrase-file=" + passphraseFile,
+	}, &stdout, &stderr))
+
+	args := []string{"init", "--dir=" + nodeDir, "--profile=delegate", "--passphrase-file=" + passphraseFile}
+	require.Equal(ExitOK, Main(args, &stdout, &stderr))
+
+	// the config refers to the keystore of the producer printed
+	cfg, err := config.Load(filepath.Join(nodeDir, nodeConfigFile))
+	require.NoError(err)
+	require.NoError(config.ValidateAll(cfg))
+	require.Equal(config.RollDPoSScheme, cfg.Consensus.Scheme)
+	keystorePath := filepath.Join(nodeDir, nodeKeystoreFile)
+	require.Equal(config.KeystoreRefPrefix+keystorePath, string(cfg.Chain.ProducerPrivKey))
+	sk, err := keypair.KeystoreToPrivateKey(accounts.Account{URL: accounts.URL{Path: keystorePath}}, "passphrase")
+	require.NoError(err)
+	addr, err := addrutil.PubKeyToAddress(sk.PublicKey())
+	require.NoError(err)
+	require.True(strings.Contains(stdout.String(), "producer "+addr.String()))
+	_, err = os.Stat(filepath.Join(nodeDir, nodeGenesisFile))
+	require.NoError(err)
+
+	// the files of an existing node aren't overwritten
+	require.Equal(ExitFailure, Main(args, &stdout, &stderr))
+	require.Contains(stderr.String(), "already exists")
+}
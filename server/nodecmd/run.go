@@ -0,0 +1,186 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package nodecmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	glog "log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/config"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/probe"
+	"github.com/iotexproject/iotex-core/pkg/recovery"
+	"github.com/iotexproject/iotex-core/server/itx"
+)
+
+// newRunFlagSet returns the flags of running the node, which are the flags the config packages register globally, and
+// --config as the alias of --config-path
+func newRunFlagSet(stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		fs.PrintDefaults()
+	}
+	// share the flag values, so that the config packages read what is parsed here
+	flag.CommandLine.VisitAll(func(f *flag.Flag) {
+		fs.Var(f.Value, f.Name, f.Usage)
+	})
+	if f := flag.Lookup("config-path"); f != nil {
+		fs.Var(f.Value, "config", "Config path, alias of -config-path")
+	}
+	return fs
+}
+
+// runNode runs the node until SIGINT or SIGTERM, on which the node is stopped gracefully within
+// System.ShutdownTimeout. SIGHUP reloads the config files. A fresh node given --sync-from-checkpoint imports the
+// checkpoint snapshot first, and syncs the blocks above it.
+func runNode(args []string, stderr io.Writer) int {
+	fs := newRunFlagSet(stderr)
+	checkpoint := fs.String("sync-from-checkpoint", "",
+		"Checkpoint snapshot to sync the fresh node from, whose tip is pinned in chain.checkpoints along with its "+
+			"state root and delegates")
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return ExitOK
+		}
+		return ExitInvalidConfig
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(stderr, "unexpected arguments %v\n%s", fs.Args(), usage)
+		return ExitInvalidConfig
+	}
+
+	genesisCfg, err := genesis.New()
+	if err != nil {
+		fmt.Fprintln(stderr, "Failed to new genesis config:", err)
+		return ExitInvalidConfig
+	}
+	cfg, err := config.New()
+	if err != nil {
+		fmt.Fprintln(stderr, "Failed to new config:", err)
+		return ExitInvalidConfig
+	}
+	cfgsub, err := config.NewSub()
+	if err != nil {
+		fmt.Fprintln(stderr, "Failed to new sub chain config:", err)
+		return ExitInvalidConfig
+	}
+	initLogger(cfg)
+
+	cfg.Genesis = genesisCfg
+	log.S().Infof("Config in use: %+v", cfg)
+	if *checkpoint != "" {
+		if err := importCheckpoint(cfg, *checkpoint); err != nil {
+			log.L().Error("Failed to import the checkpoint snapshot.", zap.Error(err))
+			return ExitFailure
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// too many panics recovered in the handlers stop the node as the signals do
+	recovery.SetEscalation(cfg.System.MaxPanicsPerMinute, cancel)
+	defer recovery.SetEscalation(0, nil)
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(stop)
+	go func() {
+		select {
+		case sig := <-stop:
+			log.L().Info("Stopping the node on signal.", zap.String("signal", sig.String()))
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	// liveness start, and the readiness is served once the node starts, which fails while it's catching up with the
+	// peers
+	var svr *itx.Server
+	probeSvr := probe.New(cfg.System.HTTPStatsPort, probe.WithReadinessHandler(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) { svr.HandleSyncStatus(w, r) },
+	)))
+	if err := probeSvr.Start(ctx); err != nil {
+		log.L().Error("Failed to start probe server.", zap.Error(err))
+		return ExitFailure
+	}
+	defer func() {
+		// liveness end
+		if err := probeSvr.Stop(context.Background()); err != nil {
+			log.L().Error("Error when stopping probe server.", zap.Error(err))
+		}
+	}()
+
+	// create and start the node
+	svr, err = itx.NewServer(cfg)
+	if err != nil {
+		log.L().Error("Failed to create server.", zap.Error(err))
+		return ExitFailure
+	}
+	if cfgsub.Chain.ID != 0 {
+		if err := svr.NewSubChainService(cfgsub); err != nil {
+			log.L().Error("Failed to new sub chain.", zap.Error(err))
+			return ExitFailure
+		}
+	}
+
+	go reloadOnHangup(ctx, svr)
+
+	if err := itx.StartServer(ctx, svr, probeSvr, cfg); err != nil {
+		log.L().Error("Node failed.", zap.Error(err))
+		return ExitFailure
+	}
+	return ExitOK
+}
+
+// reloadOnHangup re-reads the config files on SIGHUP until ctx is done, and applies the changes of the hot-reloadable
+// values to the server
+func reloadOnHangup(ctx context.Context, svr *itx.Server) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+		}
+		log.L().Info("Reloading config on SIGHUP.")
+		cfg, err := config.New()
+		if err != nil {
+			log.L().Error("Failed to reload config.", zap.Error(err))
+			continue
+		}
+		if cfg.Genesis, err = genesis.New(); err != nil {
+			log.L().Error("Failed to reload genesis config.", zap.Error(err))
+			continue
+		}
+		if err := svr.Reload(cfg); err != nil {
+			log.L().Error("Failed to apply the reloaded config.", zap.Error(err))
+		}
+	}
+}
+
+func initLogger(cfg config.Config) {
+	addr := cfg.ProducerAddress()
+	if err := log.InitLoggers(cfg.Log, cfg.SubLogs, zap.Fields(
+		zap.String("ioAddr", addr.String()),
+		zap.String("networkAddr", fmt.Sprintf("%s:%d", cfg.Network.Host, cfg.Network.Port)),
+	)); err != nil {
+		glog.Println("Cannot config global logger, use default one: ", err)
+	}
+}
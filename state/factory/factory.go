@@ -7,6 +7,7 @@
 package factory
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"math/big"
@@ -51,6 +52,7 @@ type (
 		Balance(string) (*big.Int, error)
 		Nonce(string) (uint64, error) // Note that Nonce starts with 1.
 		AccountState(string) (*state.Account, error)
+		ForEachAccount(func(address.Address, *state.Account) error) error
 		RootHash() hash.Hash256
 		RootHashByHeight(uint64) (hash.Hash256, error)
 		Height() (uint64, error)
@@ -206,6 +208,42 @@ func (sf *factory) AccountState(addr string) (*state.Account, error) {
 	return sf.accountState(addr)
 }
 
+// ForEachAccount calls fn with every account in the state trie, in the order of the trie. The trie holds the states of
+// all the protocols keyed by hash, of which a leaf is taken as an account only if it's decoded into an account and
+// encoded back byte by byte. The state is locked from committing until the iteration is done or fn fails.
+func (sf *factory) ForEachAccount(fn func(address.Address, *state.Account) error) error {
+	sf.mutex.RLock()
+	defer sf.mutex.RUnlock()
+
+	iter, err := trie.NewLeafIterator(sf.accountTrie)
+	if err != nil {
+		return errors.Wrap(err, "failed to iterate the state trie")
+	}
+	for {
+		key, value, err := iter.Next()
+		if err == trie.ErrEndOfIterator {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to iterate the state trie")
+		}
+		var account state.Account
+		if err := account.Deserialize(value); err != nil {
+			continue
+		}
+		if encoded, err := account.Serialize(); err != nil || !bytes.Equal(encoded, value) {
+			continue
+		}
+		addr, err := address.FromBytes(key)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get the address of account %x", key)
+		}
+		if err := fn(addr, &account); err != nil {
+			return err
+		}
+	}
+}
+
 // RootHash returns the hash of the root node of the state trie
 func (sf *factory) RootHash() hash.Hash256 {
 	sf.mutex.RLock()
@@ -137,6 +137,11 @@ func (sdb *stateDB) AccountState(addr string) (*state.Account, error) {
 	return sdb.accountState(addr)
 }
 
+// ForEachAccount isn't supported, since the states are keyed by hash in a flat namespace which cannot be listed
+func (sdb *stateDB) ForEachAccount(func(address.Address, *state.Account) error) error {
+	return errors.New("the trieless state DB cannot list the accounts")
+}
+
 // RootHash returns the hash of the root node of the state trie
 func (sdb *stateDB) RootHash() hash.Hash256 { return hash.ZeroHash256 }
 
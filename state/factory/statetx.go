@@ -11,11 +11,11 @@ import (
 
 	"github.com/pkg/errors"
 
-	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/state"
 )
@@ -87,7 +87,7 @@ func (stx *stateTX) RunAction(
 ) (*action.Receipt, error) {
 	// Handle action
 	// Add caller address into the run action context
-	callerAddr, err := address.FromBytes(elp.SrcPubkey().Hash())
+	callerAddr, err := addrutil.PubKeyToAddress(elp.SrcPubkey())
 	if err != nil {
 		return nil, err
 	}
@@ -13,12 +13,13 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 
-	"github.com/iotexproject/iotex-address/address"
 	"github.com/iotexproject/iotex-core/action"
 	"github.com/iotexproject/iotex-core/action/protocol"
 	"github.com/iotexproject/iotex-core/db"
 	"github.com/iotexproject/iotex-core/db/trie"
 	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/metrics"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
 	"github.com/iotexproject/iotex-core/pkg/util/byteutil"
 	"github.com/iotexproject/iotex-core/state"
 )
@@ -41,8 +42,8 @@ var (
 )
 
 func init() {
-	prometheus.MustRegister(stateDBMtc)
-	prometheus.MustRegister(dbBatchSizelMtc)
+	metrics.MustRegister(stateDBMtc)
+	metrics.MustRegister(dbBatchSizelMtc)
 }
 
 type (
@@ -160,7 +161,7 @@ func (ws *workingSet) RunAction(
 ) (*action.Receipt, error) {
 	// Handle action
 	// Add caller address into the run action context
-	caller, err := address.FromBytes(elp.SrcPubkey().Hash())
+	caller, err := addrutil.PubKeyToAddress(elp.SrcPubkey())
 	if err != nil {
 		return nil, err
 	}
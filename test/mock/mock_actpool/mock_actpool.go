@@ -5,10 +5,14 @@
 package mock_actpool
 
 import (
+	context "context"
 	gomock "github.com/golang/mock/gomock"
 	action "github.com/iotexproject/iotex-core/action"
 	protocol "github.com/iotexproject/iotex-core/action/protocol"
+	actpool "github.com/iotexproject/iotex-core/actpool"
+	config "github.com/iotexproject/iotex-core/config"
 	hash "github.com/iotexproject/iotex-core/pkg/hash"
+	big "math/big"
 	reflect "reflect"
 )
 
@@ -35,6 +39,30 @@ func (m *MockActPool) EXPECT() *MockActPoolMockRecorder {
 	return m.recorder
 }
 
+// Start mocks base method
+func (m *MockActPool) Start(arg0 context.Context) error {
+	ret := m.ctrl.Call(m, "Start", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start
+func (mr *MockActPoolMockRecorder) Start(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockActPool)(nil).Start), arg0)
+}
+
+// Stop mocks base method
+func (m *MockActPool) Stop(arg0 context.Context) error {
+	ret := m.ctrl.Call(m, "Stop", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Stop indicates an expected call of Stop
+func (mr *MockActPoolMockRecorder) Stop(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockActPool)(nil).Stop), arg0)
+}
+
 // Reset mocks base method
 func (m *MockActPool) Reset() {
 	m.ctrl.Call(m, "Reset")
@@ -57,6 +85,18 @@ func (mr *MockActPoolMockRecorder) PendingActionMap() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingActionMap", reflect.TypeOf((*MockActPool)(nil).PendingActionMap))
 }
 
+// PendingActionMapWithLimit mocks base method
+func (m *MockActPool) PendingActionMapWithLimit(maxTransfers, maxVotes int) map[string][]action.SealedEnvelope {
+	ret := m.ctrl.Call(m, "PendingActionMapWithLimit", maxTransfers, maxVotes)
+	ret0, _ := ret[0].(map[string][]action.SealedEnvelope)
+	return ret0
+}
+
+// PendingActionMapWithLimit indicates an expected call of PendingActionMapWithLimit
+func (mr *MockActPoolMockRecorder) PendingActionMapWithLimit(maxTransfers, maxVotes interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingActionMapWithLimit", reflect.TypeOf((*MockActPool)(nil).PendingActionMapWithLimit), maxTransfers, maxVotes)
+}
+
 // Add mocks base method
 func (m *MockActPool) Add(act action.SealedEnvelope) error {
 	ret := m.ctrl.Call(m, "Add", act)
@@ -69,6 +109,30 @@ func (mr *MockActPoolMockRecorder) Add(act interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockActPool)(nil).Add), act)
 }
 
+// AddIfNonce mocks base method
+func (m *MockActPool) AddIfNonce(act action.SealedEnvelope, expectedPendingNonce uint64) error {
+	ret := m.ctrl.Call(m, "AddIfNonce", act, expectedPendingNonce)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddIfNonce indicates an expected call of AddIfNonce
+func (mr *MockActPoolMockRecorder) AddIfNonce(act, expectedPendingNonce interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddIfNonce", reflect.TypeOf((*MockActPool)(nil).AddIfNonce), act, expectedPendingNonce)
+}
+
+// AddActions mocks base method
+func (m *MockActPool) AddActions(acts []action.SealedEnvelope) []error {
+	ret := m.ctrl.Call(m, "AddActions", acts)
+	ret0, _ := ret[0].([]error)
+	return ret0
+}
+
+// AddActions indicates an expected call of AddActions
+func (mr *MockActPoolMockRecorder) AddActions(acts interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddActions", reflect.TypeOf((*MockActPool)(nil).AddActions), acts)
+}
+
 // GetPendingNonce mocks base method
 func (m *MockActPool) GetPendingNonce(addr string) (uint64, error) {
 	ret := m.ctrl.Call(m, "GetPendingNonce", addr)
@@ -94,6 +158,50 @@ func (mr *MockActPoolMockRecorder) GetUnconfirmedActs(addr interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnconfirmedActs", reflect.TypeOf((*MockActPool)(nil).GetUnconfirmedActs), addr)
 }
 
+// RemoveActs mocks base method
+func (m *MockActPool) RemoveActs(acts []action.SealedEnvelope) {
+	m.ctrl.Call(m, "RemoveActs", acts)
+}
+
+// RemoveActs indicates an expected call of RemoveActs
+func (mr *MockActPoolMockRecorder) RemoveActs(acts interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveActs", reflect.TypeOf((*MockActPool)(nil).RemoveActs), acts)
+}
+
+// DropAccount mocks base method
+func (m *MockActPool) DropAccount(addr string) {
+	m.ctrl.Call(m, "DropAccount", addr)
+}
+
+// DropAccount indicates an expected call of DropAccount
+func (mr *MockActPoolMockRecorder) DropAccount(addr interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DropAccount", reflect.TypeOf((*MockActPool)(nil).DropAccount), addr)
+}
+
+// GetUnconfirmedTransfers mocks base method
+func (m *MockActPool) GetUnconfirmedTransfers(addr string) []*action.Transfer {
+	ret := m.ctrl.Call(m, "GetUnconfirmedTransfers", addr)
+	ret0, _ := ret[0].([]*action.Transfer)
+	return ret0
+}
+
+// GetUnconfirmedTransfers indicates an expected call of GetUnconfirmedTransfers
+func (mr *MockActPoolMockRecorder) GetUnconfirmedTransfers(addr interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnconfirmedTransfers", reflect.TypeOf((*MockActPool)(nil).GetUnconfirmedTransfers), addr)
+}
+
+// GetUnconfirmedVotes mocks base method
+func (m *MockActPool) GetUnconfirmedVotes(addr string) []*action.Vote {
+	ret := m.ctrl.Call(m, "GetUnconfirmedVotes", addr)
+	ret0, _ := ret[0].([]*action.Vote)
+	return ret0
+}
+
+// GetUnconfirmedVotes indicates an expected call of GetUnconfirmedVotes
+func (mr *MockActPoolMockRecorder) GetUnconfirmedVotes(addr interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnconfirmedVotes", reflect.TypeOf((*MockActPool)(nil).GetUnconfirmedVotes), addr)
+}
+
 // GetActionByHash mocks base method
 func (m *MockActPool) GetActionByHash(hash hash.Hash256) (action.SealedEnvelope, error) {
 	ret := m.ctrl.Call(m, "GetActionByHash", hash)
@@ -107,6 +215,44 @@ func (mr *MockActPoolMockRecorder) GetActionByHash(hash interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActionByHash", reflect.TypeOf((*MockActPool)(nil).GetActionByHash), hash)
 }
 
+// GetPendingByArrival mocks base method
+func (m *MockActPool) GetPendingByArrival() []actpool.ActionWithTime {
+	ret := m.ctrl.Call(m, "GetPendingByArrival")
+	ret0, _ := ret[0].([]actpool.ActionWithTime)
+	return ret0
+}
+
+// GetPendingByArrival indicates an expected call of GetPendingByArrival
+func (mr *MockActPoolMockRecorder) GetPendingByArrival() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingByArrival", reflect.TypeOf((*MockActPool)(nil).GetPendingByArrival))
+}
+
+// RecentEvictions mocks base method
+func (m *MockActPool) RecentEvictions() []actpool.EvictionRecord {
+	ret := m.ctrl.Call(m, "RecentEvictions")
+	ret0, _ := ret[0].([]actpool.EvictionRecord)
+	return ret0
+}
+
+// RecentEvictions indicates an expected call of RecentEvictions
+func (mr *MockActPoolMockRecorder) RecentEvictions() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecentEvictions", reflect.TypeOf((*MockActPool)(nil).RecentEvictions))
+}
+
+// GetPickRank mocks base method
+func (m *MockActPool) GetPickRank(hash hash.Hash256) (int, int, error) {
+	ret := m.ctrl.Call(m, "GetPickRank", hash)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPickRank indicates an expected call of GetPickRank
+func (mr *MockActPoolMockRecorder) GetPickRank(hash interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPickRank", reflect.TypeOf((*MockActPool)(nil).GetPickRank), hash)
+}
+
 // GetSize mocks base method
 func (m *MockActPool) GetSize() uint64 {
 	ret := m.ctrl.Call(m, "GetSize")
@@ -155,6 +301,86 @@ func (mr *MockActPoolMockRecorder) GetGasCapacity() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGasCapacity", reflect.TypeOf((*MockActPool)(nil).GetGasCapacity))
 }
 
+// GasPricePercentiles mocks base method
+func (m *MockActPool) GasPricePercentiles() map[int]*big.Int {
+	ret := m.ctrl.Call(m, "GasPricePercentiles")
+	ret0, _ := ret[0].(map[int]*big.Int)
+	return ret0
+}
+
+// GasPricePercentiles indicates an expected call of GasPricePercentiles
+func (mr *MockActPoolMockRecorder) GasPricePercentiles() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GasPricePercentiles", reflect.TypeOf((*MockActPool)(nil).GasPricePercentiles))
+}
+
+// MemoryUsage mocks base method
+func (m *MockActPool) MemoryUsage() int64 {
+	ret := m.ctrl.Call(m, "MemoryUsage")
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// MemoryUsage indicates an expected call of MemoryUsage
+func (mr *MockActPoolMockRecorder) MemoryUsage() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MemoryUsage", reflect.TypeOf((*MockActPool)(nil).MemoryUsage))
+}
+
+// Stats mocks base method
+func (m *MockActPool) Stats() actpool.ActPoolStats {
+	ret := m.ctrl.Call(m, "Stats")
+	ret0, _ := ret[0].(actpool.ActPoolStats)
+	return ret0
+}
+
+// Stats indicates an expected call of Stats
+func (mr *MockActPoolMockRecorder) Stats() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockActPool)(nil).Stats))
+}
+
+// SizeStats mocks base method
+func (m *MockActPool) SizeStats() (int, int, int) {
+	ret := m.ctrl.Call(m, "SizeStats")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(int)
+	return ret0, ret1, ret2
+}
+
+// SizeStats indicates an expected call of SizeStats
+func (mr *MockActPoolMockRecorder) SizeStats() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SizeStats", reflect.TypeOf((*MockActPool)(nil).SizeStats))
+}
+
+// SetAdmissionHook mocks base method
+func (m *MockActPool) SetAdmissionHook(arg0 actpool.AdmissionHook) {
+	m.ctrl.Call(m, "SetAdmissionHook", arg0)
+}
+
+// SetAdmissionHook indicates an expected call of SetAdmissionHook
+func (mr *MockActPoolMockRecorder) SetAdmissionHook(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAdmissionHook", reflect.TypeOf((*MockActPool)(nil).SetAdmissionHook), arg0)
+}
+
+// SetSyncing mocks base method
+func (m *MockActPool) SetSyncing(syncing bool) {
+	m.ctrl.Call(m, "SetSyncing", syncing)
+}
+
+// SetSyncing indicates an expected call of SetSyncing
+func (mr *MockActPoolMockRecorder) SetSyncing(syncing interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSyncing", reflect.TypeOf((*MockActPool)(nil).SetSyncing), syncing)
+}
+
+// SetLimits mocks base method
+func (m *MockActPool) SetLimits(cfg config.ActPool) {
+	m.ctrl.Call(m, "SetLimits", cfg)
+}
+
+// SetLimits indicates an expected call of SetLimits
+func (mr *MockActPoolMockRecorder) SetLimits(cfg interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLimits", reflect.TypeOf((*MockActPool)(nil).SetLimits), cfg)
+}
+
 // AddActionValidators mocks base method
 func (m *MockActPool) AddActionValidators(arg0 ...protocol.ActionValidator) {
 	varargs := []interface{}{}
@@ -182,3 +408,27 @@ func (m *MockActPool) AddActionEnvelopeValidators(arg0 ...protocol.ActionEnvelop
 func (mr *MockActPoolMockRecorder) AddActionEnvelopeValidators(arg0 ...interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddActionEnvelopeValidators", reflect.TypeOf((*MockActPool)(nil).AddActionEnvelopeValidators), arg0...)
 }
+
+// AddSubscriber mocks base method
+func (m *MockActPool) AddSubscriber(arg0 actpool.ActionSubscriber) error {
+	ret := m.ctrl.Call(m, "AddSubscriber", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSubscriber indicates an expected call of AddSubscriber
+func (mr *MockActPoolMockRecorder) AddSubscriber(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSubscriber", reflect.TypeOf((*MockActPool)(nil).AddSubscriber), arg0)
+}
+
+// RemoveSubscriber mocks base method
+func (m *MockActPool) RemoveSubscriber(arg0 actpool.ActionSubscriber) error {
+	ret := m.ctrl.Call(m, "RemoveSubscriber", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveSubscriber indicates an expected call of RemoveSubscriber
+func (mr *MockActPoolMockRecorder) RemoveSubscriber(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSubscriber", reflect.TypeOf((*MockActPool)(nil).RemoveSubscriber), arg0)
+}
@@ -6,6 +6,7 @@ package mock_blockchain
 
 import (
 	context "context"
+	io "io"
 	big "math/big"
 	reflect "reflect"
 	time "time"
@@ -93,6 +94,19 @@ func (mr *MockBlockchainMockRecorder) Nonce(addr interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Nonce", reflect.TypeOf((*MockBlockchain)(nil).Nonce), addr)
 }
 
+// GetSentActionCount mocks base method
+func (m *MockBlockchain) GetSentActionCount(addr string) (uint64, error) {
+	ret := m.ctrl.Call(m, "GetSentActionCount", addr)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSentActionCount indicates an expected call of GetSentActionCount
+func (mr *MockBlockchainMockRecorder) GetSentActionCount(addr interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSentActionCount", reflect.TypeOf((*MockBlockchain)(nil).GetSentActionCount), addr)
+}
+
 // CreateState mocks base method
 func (m *MockBlockchain) CreateState(addr string, init *big.Int) (*state.Account, error) {
 	ret := m.ctrl.Call(m, "CreateState", addr, init)
@@ -172,6 +186,19 @@ func (mr *MockBlockchainMockRecorder) GetBlockByHeight(height interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockByHeight", reflect.TypeOf((*MockBlockchain)(nil).GetBlockByHeight), height)
 }
 
+// GetBlocksByHeightRange mocks base method
+func (m *MockBlockchain) GetBlocksByHeightRange(start, end uint64) ([]*block.Block, error) {
+	ret := m.ctrl.Call(m, "GetBlocksByHeightRange", start, end)
+	ret0, _ := ret[0].([]*block.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlocksByHeightRange indicates an expected call of GetBlocksByHeightRange
+func (mr *MockBlockchainMockRecorder) GetBlocksByHeightRange(start, end interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocksByHeightRange", reflect.TypeOf((*MockBlockchain)(nil).GetBlocksByHeightRange), start, end)
+}
+
 // GetBlockByHash mocks base method
 func (m *MockBlockchain) GetBlockByHash(h hash.Hash256) (*block.Block, error) {
 	ret := m.ctrl.Call(m, "GetBlockByHash", h)
@@ -185,6 +212,32 @@ func (mr *MockBlockchainMockRecorder) GetBlockByHash(h interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockByHash", reflect.TypeOf((*MockBlockchain)(nil).GetBlockByHash), h)
 }
 
+// GetBlockVotes mocks base method
+func (m *MockBlockchain) GetBlockVotes(height uint64) ([]*action.Vote, error) {
+	ret := m.ctrl.Call(m, "GetBlockVotes", height)
+	ret0, _ := ret[0].([]*action.Vote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockVotes indicates an expected call of GetBlockVotes
+func (mr *MockBlockchainMockRecorder) GetBlockVotes(height interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockVotes", reflect.TypeOf((*MockBlockchain)(nil).GetBlockVotes), height)
+}
+
+// GetBlockTransfers mocks base method
+func (m *MockBlockchain) GetBlockTransfers(height uint64) ([]*action.Transfer, error) {
+	ret := m.ctrl.Call(m, "GetBlockTransfers", height)
+	ret0, _ := ret[0].([]*action.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockTransfers indicates an expected call of GetBlockTransfers
+func (mr *MockBlockchainMockRecorder) GetBlockTransfers(height interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockTransfers", reflect.TypeOf((*MockBlockchain)(nil).GetBlockTransfers), height)
+}
+
 // BlockHeaderByHeight mocks base method
 func (m *MockBlockchain) BlockHeaderByHeight(height uint64) (*block.Header, error) {
 	ret := m.ctrl.Call(m, "BlockHeaderByHeight", height)
@@ -211,6 +264,34 @@ func (mr *MockBlockchainMockRecorder) BlockHeaderByHash(h interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockHeaderByHash", reflect.TypeOf((*MockBlockchain)(nil).BlockHeaderByHash), h)
 }
 
+// GetBlockTime mocks base method
+func (m *MockBlockchain) GetBlockTime(height uint64) (int64, error) {
+	ret := m.ctrl.Call(m, "GetBlockTime", height)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockTime indicates an expected call of GetBlockTime
+func (mr *MockBlockchainMockRecorder) GetBlockTime(height interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockTime", reflect.TypeOf((*MockBlockchain)(nil).GetBlockTime), height)
+}
+
+// GetBlockIntervalStats mocks base method
+func (m *MockBlockchain) GetBlockIntervalStats(lastN int) (time.Duration, time.Duration, time.Duration, error) {
+	ret := m.ctrl.Call(m, "GetBlockIntervalStats", lastN)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(time.Duration)
+	ret2, _ := ret[2].(time.Duration)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// GetBlockIntervalStats indicates an expected call of GetBlockIntervalStats
+func (mr *MockBlockchainMockRecorder) GetBlockIntervalStats(lastN interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockIntervalStats", reflect.TypeOf((*MockBlockchain)(nil).GetBlockIntervalStats), lastN)
+}
+
 // BlockFooterByHeight mocks base method
 func (m *MockBlockchain) BlockFooterByHeight(height uint64) (*block.Footer, error) {
 	ret := m.ctrl.Call(m, "BlockFooterByHeight", height)
@@ -289,6 +370,32 @@ func (mr *MockBlockchainMockRecorder) GetActionsToAddress(address interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActionsToAddress", reflect.TypeOf((*MockBlockchain)(nil).GetActionsToAddress), address)
 }
 
+// GetTransfersByAddress mocks base method
+func (m *MockBlockchain) GetTransfersByAddress(address string) ([]*action.Transfer, error) {
+	ret := m.ctrl.Call(m, "GetTransfersByAddress", address)
+	ret0, _ := ret[0].([]*action.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransfersByAddress indicates an expected call of GetTransfersByAddress
+func (mr *MockBlockchainMockRecorder) GetTransfersByAddress(address interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfersByAddress", reflect.TypeOf((*MockBlockchain)(nil).GetTransfersByAddress), address)
+}
+
+// GetVotesByAddress mocks base method
+func (m *MockBlockchain) GetVotesByAddress(address string) ([]*action.Vote, error) {
+	ret := m.ctrl.Call(m, "GetVotesByAddress", address)
+	ret0, _ := ret[0].([]*action.Vote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVotesByAddress indicates an expected call of GetVotesByAddress
+func (mr *MockBlockchainMockRecorder) GetVotesByAddress(address interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVotesByAddress", reflect.TypeOf((*MockBlockchain)(nil).GetVotesByAddress), address)
+}
+
 // GetActionCountByAddress mocks base method
 func (m *MockBlockchain) GetActionCountByAddress(address string) (uint64, error) {
 	ret := m.ctrl.Call(m, "GetActionCountByAddress", address)
@@ -302,6 +409,19 @@ func (mr *MockBlockchainMockRecorder) GetActionCountByAddress(address interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActionCountByAddress", reflect.TypeOf((*MockBlockchain)(nil).GetActionCountByAddress), address)
 }
 
+// GetBalanceDelta mocks base method
+func (m *MockBlockchain) GetBalanceDelta(address string, fromHeight, toHeight uint64) (*big.Int, error) {
+	ret := m.ctrl.Call(m, "GetBalanceDelta", address, fromHeight, toHeight)
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalanceDelta indicates an expected call of GetBalanceDelta
+func (mr *MockBlockchainMockRecorder) GetBalanceDelta(address, fromHeight, toHeight interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceDelta", reflect.TypeOf((*MockBlockchain)(nil).GetBalanceDelta), address, fromHeight, toHeight)
+}
+
 // GetActionByActionHash mocks base method
 func (m *MockBlockchain) GetActionByActionHash(h hash.Hash256) (action.SealedEnvelope, error) {
 	ret := m.ctrl.Call(m, "GetActionByActionHash", h)
@@ -401,6 +521,18 @@ func (mr *MockBlockchainMockRecorder) StateByAddr(address interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StateByAddr", reflect.TypeOf((*MockBlockchain)(nil).StateByAddr), address)
 }
 
+// ExportStateCSV mocks base method
+func (m *MockBlockchain) ExportStateCSV(w io.Writer) error {
+	ret := m.ctrl.Call(m, "ExportStateCSV", w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportStateCSV indicates an expected call of ExportStateCSV
+func (mr *MockBlockchainMockRecorder) ExportStateCSV(w interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportStateCSV", reflect.TypeOf((*MockBlockchain)(nil).ExportStateCSV), w)
+}
+
 // RecoverChainAndState mocks base method
 func (m *MockBlockchain) RecoverChainAndState(targetHeight uint64) error {
 	ret := m.ctrl.Call(m, "RecoverChainAndState", targetHeight)
@@ -413,6 +545,18 @@ func (mr *MockBlockchainMockRecorder) RecoverChainAndState(targetHeight interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecoverChainAndState", reflect.TypeOf((*MockBlockchain)(nil).RecoverChainAndState), targetHeight)
 }
 
+// VerifyChain mocks base method
+func (m *MockBlockchain) VerifyChain(from, to uint64) error {
+	ret := m.ctrl.Call(m, "VerifyChain", from, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyChain indicates an expected call of VerifyChain
+func (mr *MockBlockchainMockRecorder) VerifyChain(from, to interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyChain", reflect.TypeOf((*MockBlockchain)(nil).VerifyChain), from, to)
+}
+
 // GenesisTimestamp mocks base method
 func (m *MockBlockchain) GenesisTimestamp() int64 {
 	ret := m.ctrl.Call(m, "GenesisTimestamp")
@@ -425,6 +569,19 @@ func (mr *MockBlockchainMockRecorder) GenesisTimestamp() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenesisTimestamp", reflect.TypeOf((*MockBlockchain)(nil).GenesisTimestamp))
 }
 
+// GetForkChoice mocks base method
+func (m *MockBlockchain) GetForkChoice() (*block.Block, error) {
+	ret := m.ctrl.Call(m, "GetForkChoice")
+	ret0, _ := ret[0].(*block.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetForkChoice indicates an expected call of GetForkChoice
+func (mr *MockBlockchainMockRecorder) GetForkChoice() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetForkChoice", reflect.TypeOf((*MockBlockchain)(nil).GetForkChoice))
+}
+
 // MintNewBlock mocks base method
 func (m *MockBlockchain) MintNewBlock(actionMap map[string][]action.SealedEnvelope, timestamp time.Time) (*block.Block, error) {
 	ret := m.ctrl.Call(m, "MintNewBlock", actionMap, timestamp)
@@ -8,6 +8,7 @@ import (
 	context "context"
 	gomock "github.com/golang/mock/gomock"
 	block "github.com/iotexproject/iotex-core/blockchain/block"
+	blocksync "github.com/iotexproject/iotex-core/blocksync"
 	iotexrpc "github.com/iotexproject/iotex-core/protogen/iotexrpc"
 	go_libp2p_peerstore "github.com/libp2p/go-libp2p-peerstore"
 	reflect "reflect"
@@ -72,6 +73,32 @@ func (mr *MockBlockSyncMockRecorder) TargetHeight() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TargetHeight", reflect.TypeOf((*MockBlockSync)(nil).TargetHeight))
 }
 
+// Progress mocks base method
+func (m *MockBlockSync) Progress() (uint64, uint64, int) {
+	ret := m.ctrl.Call(m, "Progress")
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(uint64)
+	ret2, _ := ret[2].(int)
+	return ret0, ret1, ret2
+}
+
+// Progress indicates an expected call of Progress
+func (mr *MockBlockSyncMockRecorder) Progress() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Progress", reflect.TypeOf((*MockBlockSync)(nil).Progress))
+}
+
+// Status mocks base method
+func (m *MockBlockSync) Status() blocksync.Status {
+	ret := m.ctrl.Call(m, "Status")
+	ret0, _ := ret[0].(blocksync.Status)
+	return ret0
+}
+
+// Status indicates an expected call of Status
+func (mr *MockBlockSyncMockRecorder) Status() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockBlockSync)(nil).Status))
+}
+
 // ProcessSyncRequest mocks base method
 func (m *MockBlockSync) ProcessSyncRequest(ctx context.Context, peer go_libp2p_peerstore.PeerInfo, sync *iotexrpc.BlockSync) error {
 	ret := m.ctrl.Call(m, "ProcessSyncRequest", ctx, peer, sync)
@@ -107,3 +134,15 @@ func (m *MockBlockSync) ProcessBlockSync(ctx context.Context, blk *block.Block)
 func (mr *MockBlockSyncMockRecorder) ProcessBlockSync(ctx, blk interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessBlockSync", reflect.TypeOf((*MockBlockSync)(nil).ProcessBlockSync), ctx, blk)
 }
+
+// ProcessBlockBatch mocks base method
+func (m *MockBlockSync) ProcessBlockBatch(ctx context.Context, peer go_libp2p_peerstore.PeerInfo, batch *iotexrpc.BlockBatch) error {
+	ret := m.ctrl.Call(m, "ProcessBlockBatch", ctx, peer, batch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessBlockBatch indicates an expected call of ProcessBlockBatch
+func (mr *MockBlockSyncMockRecorder) ProcessBlockBatch(ctx, peer, batch interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessBlockBatch", reflect.TypeOf((*MockBlockSync)(nil).ProcessBlockBatch), ctx, peer, batch)
+}
@@ -8,6 +8,7 @@ import (
 	context "context"
 	gomock "github.com/golang/mock/gomock"
 	proto "github.com/golang/protobuf/proto"
+	block "github.com/iotexproject/iotex-core/blockchain/block"
 	dispatcher "github.com/iotexproject/iotex-core/dispatcher"
 	iotexrpc "github.com/iotexproject/iotex-core/protogen/iotexrpc"
 	iotextypes "github.com/iotexproject/iotex-core/protogen/iotextypes"
@@ -50,6 +51,18 @@ func (mr *MockSubscriberMockRecorder) HandleAction(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleAction", reflect.TypeOf((*MockSubscriber)(nil).HandleAction), arg0, arg1)
 }
 
+// HandleActions mocks base method
+func (m *MockSubscriber) HandleActions(arg0 context.Context, arg1 []*iotextypes.Action) []error {
+	ret := m.ctrl.Call(m, "HandleActions", arg0, arg1)
+	ret0, _ := ret[0].([]error)
+	return ret0
+}
+
+// HandleActions indicates an expected call of HandleActions
+func (mr *MockSubscriberMockRecorder) HandleActions(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleActions", reflect.TypeOf((*MockSubscriber)(nil).HandleActions), arg0, arg1)
+}
+
 // HandleBlock mocks base method
 func (m *MockSubscriber) HandleBlock(arg0 context.Context, arg1 *iotextypes.Block) error {
 	ret := m.ctrl.Call(m, "HandleBlock", arg0, arg1)
@@ -86,6 +99,18 @@ func (mr *MockSubscriberMockRecorder) HandleSyncRequest(arg0, arg1, arg2 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleSyncRequest", reflect.TypeOf((*MockSubscriber)(nil).HandleSyncRequest), arg0, arg1, arg2)
 }
 
+// HandleBlockBatch mocks base method
+func (m *MockSubscriber) HandleBlockBatch(arg0 context.Context, arg1 go_libp2p_peerstore.PeerInfo, arg2 *iotexrpc.BlockBatch) error {
+	ret := m.ctrl.Call(m, "HandleBlockBatch", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleBlockBatch indicates an expected call of HandleBlockBatch
+func (mr *MockSubscriberMockRecorder) HandleBlockBatch(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleBlockBatch", reflect.TypeOf((*MockSubscriber)(nil).HandleBlockBatch), arg0, arg1, arg2)
+}
+
 // HandleConsensusMsg mocks base method
 func (m *MockSubscriber) HandleConsensusMsg(arg0 *iotextypes.ConsensusMessage) error {
 	ret := m.ctrl.Call(m, "HandleConsensusMsg", arg0)
@@ -174,3 +199,13 @@ func (m *MockDispatcher) HandleTell(arg0 context.Context, arg1 uint32, arg2 go_l
 func (mr *MockDispatcherMockRecorder) HandleTell(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleTell", reflect.TypeOf((*MockDispatcher)(nil).HandleTell), arg0, arg1, arg2, arg3)
 }
+
+// MarkActionsSeen mocks base method
+func (m *MockDispatcher) MarkActionsSeen(arg0 *block.Block) {
+	m.ctrl.Call(m, "MarkActionsSeen", arg0)
+}
+
+// MarkActionsSeen indicates an expected call of MarkActionsSeen
+func (mr *MockDispatcherMockRecorder) MarkActionsSeen(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkActionsSeen", reflect.TypeOf((*MockDispatcher)(nil).MarkActionsSeen), arg0)
+}
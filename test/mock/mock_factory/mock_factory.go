@@ -7,6 +7,7 @@ package mock_factory
 import (
 	context "context"
 	gomock "github.com/golang/mock/gomock"
+	address "github.com/iotexproject/iotex-address/address"
 	protocol "github.com/iotexproject/iotex-core/action/protocol"
 	hash "github.com/iotexproject/iotex-core/pkg/hash"
 	state "github.com/iotexproject/iotex-core/state"
@@ -101,6 +102,18 @@ func (mr *MockFactoryMockRecorder) AccountState(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccountState", reflect.TypeOf((*MockFactory)(nil).AccountState), arg0)
 }
 
+// ForEachAccount mocks base method
+func (m *MockFactory) ForEachAccount(arg0 func(address.Address, *state.Account) error) error {
+	ret := m.ctrl.Call(m, "ForEachAccount", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForEachAccount indicates an expected call of ForEachAccount
+func (mr *MockFactoryMockRecorder) ForEachAccount(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForEachAccount", reflect.TypeOf((*MockFactory)(nil).ForEachAccount), arg0)
+}
+
 // RootHash mocks base method
 func (m *MockFactory) RootHash() hash.Hash256 {
 	ret := m.ctrl.Call(m, "RootHash")
@@ -0,0 +1,75 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package testutil
+
+import (
+	"strconv"
+
+	"go.uber.org/zap"
+
+	"github.com/iotexproject/iotex-address/address"
+	"github.com/iotexproject/iotex-core/pkg/hash"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/log"
+	"github.com/iotexproject/iotex-core/pkg/unit"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
+)
+
+// FundedBalance is the balance in Rau that the genesis allocation of FundedAccounts grants to each account
+var FundedBalance = unit.ConvertIotxToRau(100000000)
+
+// KeyPair is a test identity
+type KeyPair struct {
+	PriKey  keypair.PrivateKey
+	PubKey  keypair.PublicKey
+	Address address.Address
+}
+
+// NewKeyPair returns the key pair derived from the seed, so that the same seed always yields the same identity
+func NewKeyPair(seed string) *KeyPair {
+	h := hash.Hash256b([]byte(seed))
+	for {
+		// A hash which isn't a valid secp256k1 private key is vanishingly rare, but rehash it just in case
+		sk, err := keypair.BytesToPrivateKey(h[:])
+		if err != nil {
+			h = hash.Hash256b(h[:])
+			continue
+		}
+		addr, err := addrutil.PubKeyToAddress(sk.PublicKey())
+		if err != nil {
+			log.L().Panic("Error when constructing the address of a key pair", zap.Error(err))
+		}
+		return &KeyPair{PriKey: sk, PubKey: sk.PublicKey(), Address: addr}
+	}
+}
+
+// FundedAccounts returns n key pairs seeded by "account-<i>", along with the genesis allocation granting each of them
+// FundedBalance. The allocation is in the format of the initBalances section of the genesis config, and could be
+// merged into it by MergeAllocation.
+func FundedAccounts(n int) ([]*KeyPair, map[string]string) {
+	accounts := make([]*KeyPair, 0, n)
+	alloc := make(map[string]string, n)
+	for i := 0; i < n; i++ {
+		kp := NewKeyPair("account-" + strconv.Itoa(i))
+		accounts = append(accounts, kp)
+		alloc[kp.Address.String()] = FundedBalance.String()
+	}
+	return accounts, alloc
+}
+
+// MergeAllocation returns a new genesis allocation which contains the balances of all the given allocations, where the
+// latter one wins on conflict. The given allocations are left untouched, so that it is safe to merge into the one of
+// config.Default.
+func MergeAllocation(allocs ...map[string]string) map[string]string {
+	merged := make(map[string]string)
+	for _, alloc := range allocs {
+		for addr, balance := range alloc {
+			merged[addr] = balance
+		}
+	}
+	return merged
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package testutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewKeyPair(t *testing.T) {
+	require := require.New(t)
+	kp1 := NewKeyPair("alfa")
+	kp2 := NewKeyPair("alfa")
+	require.Equal(kp1.PriKey.HexString(), kp2.PriKey.HexString())
+	require.Equal(kp1.Address.String(), kp2.Address.String())
+	require.Equal(kp1.PriKey.PublicKey().HexString(), kp1.PubKey.HexString())
+	require.NotEqual(kp1.Address.String(), NewKeyPair("bravo").Address.String())
+}
+
+func TestFundedAccounts(t *testing.T) {
+	require := require.New(t)
+	accounts, alloc := FundedAccounts(3)
+	require.Equal(3, len(accounts))
+	require.Equal(3, len(alloc))
+	for _, kp := range accounts {
+		require.Equal(FundedBalance.String(), alloc[kp.Address.String()])
+	}
+	require.Equal(NewKeyPair("account-0").Address.String(), accounts[0].Address.String())
+
+	base := map[string]string{"a": "1", accounts[0].Address.String(): "2"}
+	merged := MergeAllocation(base, alloc)
+	require.Equal(4, len(merged))
+	require.Equal(FundedBalance.String(), merged[accounts[0].Address.String()])
+	require.Equal("2", base[accounts[0].Address.String()])
+}
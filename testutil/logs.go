@@ -0,0 +1,52 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package testutil
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// logBuffer is a buffer the loggers write to concurrently
+type logBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *logBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *logBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// CaptureLogs keeps the logs of the node, i.e., of the global logger and the module loggers following it, rather than
+// printing them, and returns the function to call at the end of the test, which restores the logger and attaches the
+// logs to the test output only if the test fails, e.g.,
+//
+//	defer testutil.CaptureLogs(t)()
+func CaptureLogs(t testing.TB) (done func()) {
+	buf := &logBuffer{}
+	encoderCfg := zap.NewDevelopmentEncoderConfig()
+	logger := zap.New(zapcore.NewCore(zapcore.NewConsoleEncoder(encoderCfg), zapcore.AddSync(buf), zap.InfoLevel))
+	restore := zap.ReplaceGlobals(logger)
+	return func() {
+		restore()
+		if t.Failed() {
+			t.Logf("logs of the node:\n%s", buf.String())
+		}
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package testutil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestCaptureLogs(t *testing.T) {
+	require := require.New(t)
+
+	global := zap.L()
+	// the logs of a test passing are dropped
+	ft := &fakeT{}
+	done := CaptureLogs(ft)
+	require.NotEqual(global, zap.L())
+	zap.L().Info("passing")
+	done()
+	require.Equal(global, zap.L())
+	require.Empty(ft.logs)
+
+	// the logs of a test failing are attached, including the ones of the named loggers
+	ft = &fakeT{}
+	done = CaptureLogs(ft)
+	zap.L().Named("actpool").Info("failing", zap.Int("size", 2))
+	zap.L().Debug("too verbose")
+	ft.failed = true
+	done()
+	require.Len(ft.logs, 1)
+	require.True(strings.Contains(ft.logs[0], "actpool"))
+	require.True(strings.Contains(ft.logs[0], `failing	{"size": 2}`))
+	require.False(strings.Contains(ft.logs[0], "too verbose"))
+}
@@ -7,6 +7,9 @@
 package testutil
 
 import (
+	"fmt"
+	"reflect"
+	"testing"
 	"time"
 
 	"github.com/pkg/errors"
@@ -18,6 +21,14 @@ var ErrTimeout = errors.New("timed out")
 // CheckCondition defines a func type that checks whether a certain condition is satisfied
 type CheckCondition func() (bool, error)
 
+// StateDump returns the state relevant to a wait, e.g., the sizes of the action pools, the tip heights and the peer
+// counts of the nodes, which is reported when the wait times out
+type StateDump func() string
+
+// String dumps the state, so that a StateDump passed as a message argument, e.g., to EventuallyEqual, is only called
+// when the message is formatted on failure
+func (d StateDump) String() string { return d() }
+
 // SignalChan returns a channel that will be written every interval until timeout
 func SignalChan(interval, timeout time.Duration) <-chan struct{} {
 	ch := make(chan struct{})
@@ -70,3 +81,41 @@ func WaitUntil(interval, timeout time.Duration, f CheckCondition) error {
 		}
 	}
 }
+
+// WaitUntilDescribed is WaitUntil reporting what it waits for and the state dumped by dump, if not nil, when it times
+// out, whose cause is still ErrTimeout
+func WaitUntilDescribed(interval, timeout time.Duration, desc string, dump StateDump, f CheckCondition) error {
+	err := WaitUntil(interval, timeout, f)
+	if err != ErrTimeout {
+		return err
+	}
+	if dump == nil {
+		return errors.Wrapf(err, "%s after %s", desc, timeout)
+	}
+	return errors.Wrapf(err, "%s after %s, state: %s", desc, timeout, dump())
+}
+
+// EventuallyEqual waits until the value returned by get equals want, and fails the test reporting the last value
+// observed if it doesn't within timeout
+func EventuallyEqual(t testing.TB, want interface{}, get func() interface{}, timeout time.Duration,
+	msgAndArgs ...interface{}) {
+	t.Helper()
+	// poll as often as the e2e tests do, but at least 10 times within a short timeout
+	interval := 100 * time.Millisecond
+	if timeout < time.Second {
+		interval = timeout/10 + time.Millisecond
+	}
+	var last interface{}
+	err := WaitUntil(interval, timeout, func() (bool, error) {
+		last = get()
+		return reflect.DeepEqual(want, last), nil
+	})
+	if err == nil {
+		return
+	}
+	msg := ""
+	if len(msgAndArgs) > 0 {
+		msg = fmt.Sprintf(fmt.Sprint(msgAndArgs[0]), msgAndArgs[1:]...) + ": "
+	}
+	t.Fatalf("%snot equal to %v after %s, last observed %v", msg, want, timeout, last)
+}
@@ -7,10 +7,13 @@
 package testutil
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestWaitUntil(t *testing.T) {
@@ -27,3 +30,68 @@ func TestWaitUntil(t *testing.T) {
 	err = WaitUntil(time.Millisecond, time.Millisecond*10, check2)
 	assert.Equal(ErrTimeout, err)
 }
+
+// fakeT records the failure of a helper under test rather than failing the test
+type fakeT struct {
+	testing.TB
+	failed bool
+	logs   []string
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Failed() bool { return t.failed }
+
+func (t *fakeT) Fatalf(format string, args ...interface{}) {
+	t.failed = true
+	t.logs = append(t.logs, fmt.Sprintf(format, args...))
+}
+
+func (t *fakeT) Logf(format string, args ...interface{}) {
+	t.logs = append(t.logs, fmt.Sprintf(format, args...))
+}
+
+func TestWaitUntilDescribed(t *testing.T) {
+	require := require.New(t)
+
+	require.NoError(WaitUntilDescribed(time.Millisecond, time.Second, "ok", nil, func() (bool, error) {
+		return true, nil
+	}))
+	errCheck := errors.New("check failed")
+	require.Equal(errCheck, WaitUntilDescribed(time.Millisecond, time.Second, "error", nil, func() (bool, error) {
+		return false, errCheck
+	}))
+
+	never := func() (bool, error) { return false, nil }
+	err := WaitUntilDescribed(time.Millisecond, 10*time.Millisecond, "pool size 3", nil, never)
+	require.Equal(ErrTimeout, errors.Cause(err))
+	require.Equal("pool size 3 after 10ms: timed out", err.Error())
+	err = WaitUntilDescribed(time.Millisecond, 10*time.Millisecond, "pool size 3", func() string {
+		return "pool size 2"
+	}, never)
+	require.Equal(ErrTimeout, errors.Cause(err))
+	require.Equal("pool size 3 after 10ms, state: pool size 2: timed out", err.Error())
+}
+
+func TestEventuallyEqual(t *testing.T) {
+	require := require.New(t)
+
+	n := 0
+	ft := &fakeT{}
+	EventuallyEqual(ft, 3, func() interface{} {
+		n++
+		return n
+	}, time.Second)
+	require.False(ft.failed)
+
+	dumps := 0
+	dump := StateDump(func() string {
+		dumps++
+		return "tip height 5"
+	})
+	EventuallyEqual(ft, 3, func() interface{} { return 2 }, 10*time.Millisecond, "pool size of %s, %s", "node1", dump)
+	require.True(ft.failed)
+	require.Equal([]string{"pool size of node1, tip height 5: not equal to 3 after 10ms, last observed 2"}, ft.logs)
+	// the state is dumped only on failure
+	require.Equal(1, dumps)
+}
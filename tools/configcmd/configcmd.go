@@ -0,0 +1,140 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// Package configcmd generates and checks the node config files offline. Usage:
+//
+//	server config init [--profile=delegate|fullnode|standalone-dev] [--out=config.yaml] [--genesis-out=genesis.yaml]
+//	server config validate config.yaml
+package configcmd
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/config"
+)
+
+// Init writes the commented config file of the named profile to out, and its genesis to genesisOut if it isn't nil
+func Init(profile string, out io.Writer, genesisOut io.Writer) error {
+	return InitWithProducerKey(profile, config.ProducerKeystorePlaceholder, out, genesisOut)
+}
+
+// InitWithProducerKey is Init with the producer private key set to producerPrivKey, which is expected to be a key
+// reference rather than a plaintext key
+func InitWithProducerKey(profile, producerPrivKey string, out io.Writer, genesisOut io.Writer) error {
+	cfg, err := config.NewProfile(profile)
+	if err != nil {
+		return err
+	}
+	cfg.Chain.ProducerPrivKey = config.Secret(producerPrivKey)
+	// never hand out a config the loader would refuse
+	if err := config.ValidateAll(cfg); err != nil {
+		return errors.Wrapf(err, "profile %s is invalid", profile)
+	}
+	data, err := config.Marshal(cfg)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode the config")
+	}
+	if _, err := fmt.Fprintf(out, "# Config of the %s profile generated by `server config init`\n\n", profile); err != nil {
+		return err
+	}
+	if _, err := out.Write(data); err != nil {
+		return err
+	}
+	if genesisOut == nil {
+		return nil
+	}
+	if data, err = config.MarshalGenesis(cfg.Genesis); err != nil {
+		return errors.Wrap(err, "failed to encode the genesis")
+	}
+	_, err = genesisOut.Write(data)
+	return err
+}
+
+// Check loads the config file, and writes one line per violation found to out. It returns an error if there is a
+// violation of config.SeverityError.
+func Check(path string, out io.Writer) error {
+	cfg, err := config.Load(path)
+	if err != nil {
+		return err
+	}
+	err = config.ValidateAll(cfg)
+	if err == nil {
+		fmt.Fprintf(out, "%s: ok\n", path)
+		return nil
+	}
+	vs, ok := err.(config.Violations)
+	if !ok {
+		return err
+	}
+	numErrors := 0
+	for _, v := range vs {
+		if v.Severity == config.SeverityError {
+			numErrors++
+		}
+		fmt.Fprintf(out, "%s\t%s\n", v.Severity, v)
+	}
+	fmt.Fprintf(out, "%s: %d errors, %d warnings\n", path, numErrors, len(vs)-numErrors)
+	if numErrors > 0 {
+		return errors.Wrapf(config.ErrInvalidCfg, "%s has %d errors", path, numErrors)
+	}
+	return nil
+}
+
+// Run runs the config subcommand given by args
+func Run(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("usage: server config init|validate [flags]")
+	}
+	switch args[0] {
+	case "init":
+		return runInit(args[1:], stdout)
+	case "validate", "check":
+		if len(args) != 2 {
+			return errors.Errorf("usage: server config %s <path>", args[0])
+		}
+		return Check(args[1], stdout)
+	default:
+		return errors.Errorf("unknown config subcommand %s", args[0])
+	}
+}
+
+func runInit(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("init", flag.ContinueOnError)
+	fs.SetOutput(stdout)
+	profile := fs.String("profile", config.ProfileFullNode, "profile of the node, delegate, fullnode or standalone-dev")
+	out := fs.String("out", "", "path of the config file, stdout by default")
+	genesisOut := fs.String("genesis-out", "", "path of the genesis file, which isn't written by default")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var cfgBuf, genesisBuf bytes.Buffer
+	var genesisWriter io.Writer
+	if *genesisOut != "" {
+		genesisWriter = &genesisBuf
+	}
+	// buffer the output, so that no file is left half written on failure
+	if err := Init(*profile, &cfgBuf, genesisWriter); err != nil {
+		return err
+	}
+	if *out == "" {
+		if _, err := stdout.Write(cfgBuf.Bytes()); err != nil {
+			return err
+		}
+	} else if err := ioutil.WriteFile(*out, cfgBuf.Bytes(), 0644); err != nil {
+		return err
+	}
+	if *genesisOut != "" {
+		return ioutil.WriteFile(*genesisOut, genesisBuf.Bytes(), 0644)
+	}
+	return nil
+}
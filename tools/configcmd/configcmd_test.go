@@ -0,0 +1,94 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package configcmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	uconfig "go.uber.org/config"
+
+	"github.com/iotexproject/iotex-core/blockchain/genesis"
+	"github.com/iotexproject/iotex-core/config"
+)
+
+func TestInitRoundTrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "configcmd")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for _, profile := range config.Profiles {
+		t.Run(profile, func(t *testing.T) {
+			require := require.New(t)
+
+			cfgPath := filepath.Join(dir, profile+".yaml")
+			genesisPath := filepath.Join(dir, profile+".genesis.yaml")
+			var stdout bytes.Buffer
+			require.NoError(Run([]string{"init", "--profile=" + profile, "--out=" + cfgPath, "--genesis-out=" + genesisPath},
+				&stdout))
+
+			expected, err := config.NewProfile(profile)
+			require.NoError(err)
+			cfg, err := config.Load(cfgPath)
+			require.NoError(err)
+			require.NoError(config.ValidateAll(cfg))
+			require.Equal(config.ProducerKeystorePlaceholder, string(cfg.Chain.ProducerPrivKey))
+			require.Equal(expected.Consensus.Scheme, cfg.Consensus.Scheme)
+			// the genesis is read from its own file
+			cfg.Genesis = expected.Genesis
+			require.Empty(config.Diff(expected, cfg))
+
+			yaml, err := uconfig.NewYAML(uconfig.Static(genesis.Default), uconfig.File(genesisPath))
+			require.NoError(err)
+			var g genesis.Genesis
+			require.NoError(yaml.Get(uconfig.Root).Populate(&g))
+			require.Equal(expected.Genesis, g)
+
+			require.NoError(Run([]string{"check", cfgPath}, &stdout))
+			require.Contains(stdout.String(), cfgPath+": ok")
+		})
+	}
+
+	cfg, err := config.NewProfile(config.ProfileStandaloneDev)
+	require.NoError(t, err)
+	require.True(t, cfg.Chain.EnableInMemoryDB)
+	require.Equal(t, time.Second, cfg.Genesis.BlockInterval)
+
+	_, err = config.NewProfile("unknown")
+	require.Error(t, err)
+}
+
+func TestCheck(t *testing.T) {
+	require := require.New(t)
+
+	f, err := ioutil.TempFile("", "configcmd")
+	require.NoError(err)
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(`
+actPool:
+  maxNumActsPerPool: 0
+consensus:
+  scheme: STANDALONE
+  requireDelegateReachability: true
+  delegateAddrs: ["127.0.0.1:4689"]
+`)
+	require.NoError(err)
+	require.NoError(f.Close())
+
+	var stdout bytes.Buffer
+	err = Check(f.Name(), &stdout)
+	require.Equal(config.ErrInvalidCfg, errors.Cause(err))
+	require.Contains(stdout.String(), "error\tactPool.maxNumActsPerPool=0: ")
+	require.Contains(stdout.String(), "warning\tconsensus.requireDelegateReachability=true: ")
+	require.Contains(stdout.String(), "1 errors, 1 warnings")
+}
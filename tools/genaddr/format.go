@@ -0,0 +1,168 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package genaddr
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// FormatCSV writes the records as CSV with a header line
+	FormatCSV = "csv"
+	// FormatJSON writes the records as a JSON array
+	FormatJSON = "json"
+)
+
+// ErrUnknownFormat indicates the output format isn't supported
+var ErrUnknownFormat = errors.New("unknown format")
+
+// record is a line of the address file or of the key file, where value is either the address or the encrypted key
+type record struct {
+	Index uint32
+	Value string
+}
+
+// recordWriter streams the records to a file, so that a large batch never resides in memory
+type recordWriter interface {
+	Write(record) error
+	// Close finishes the output, but doesn't close the underlying writer
+	Close() error
+}
+
+// recordReader streams the records from a file. It returns io.EOF after the last record.
+type recordReader interface {
+	Read() (record, error)
+}
+
+func newRecordWriter(w io.Writer, format, field string) (recordWriter, error) {
+	switch format {
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write([]string{"index", field}); err != nil {
+			return nil, err
+		}
+		return &csvWriter{w: cw}, nil
+	case FormatJSON:
+		return &jsonWriter{w: w, field: field}, nil
+	default:
+		return nil, errors.Wrap(ErrUnknownFormat, format)
+	}
+}
+
+func newRecordReader(r io.Reader, format, field string) (recordReader, error) {
+	switch format {
+	case FormatCSV:
+		cr := csv.NewReader(r)
+		cr.FieldsPerRecord = 2
+		header, err := cr.Read()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read the header")
+		}
+		if header[0] != "index" || header[1] != field {
+			return nil, errors.Errorf("unexpected header %v", header)
+		}
+		return &csvReader{r: cr}, nil
+	case FormatJSON:
+		dec := json.NewDecoder(r)
+		if t, err := dec.Token(); err != nil || t != json.Delim('[') {
+			return nil, errors.New("expect a JSON array")
+		}
+		return &jsonReader{dec: dec, field: field}, nil
+	default:
+		return nil, errors.Wrap(ErrUnknownFormat, format)
+	}
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (w *csvWriter) Write(r record) error {
+	return w.w.Write([]string{strconv.FormatUint(uint64(r.Index), 10), r.Value})
+}
+
+func (w *csvWriter) Close() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+type csvReader struct {
+	r *csv.Reader
+}
+
+func (r *csvReader) Read() (record, error) {
+	fields, err := r.r.Read()
+	if err != nil {
+		return record{}, err
+	}
+	index, err := strconv.ParseUint(fields[0], 10, 32)
+	if err != nil {
+		return record{}, errors.Wrapf(err, "invalid index %s", fields[0])
+	}
+	return record{Index: uint32(index), Value: fields[1]}, nil
+}
+
+// jsonWriter writes the elements of the array one by one rather than marshaling the whole array
+type jsonWriter struct {
+	w     io.Writer
+	field string
+	count int
+}
+
+func (w *jsonWriter) Write(r record) error {
+	data, err := json.Marshal(map[string]interface{}{"index": r.Index, w.field: r.Value})
+	if err != nil {
+		return err
+	}
+	sep := ",\n"
+	if w.count == 0 {
+		sep = "[\n"
+	}
+	w.count++
+	if _, err := io.WriteString(w.w, sep); err != nil {
+		return err
+	}
+	_, err = w.w.Write(data)
+	return err
+}
+
+func (w *jsonWriter) Close() error {
+	end := "\n]\n"
+	if w.count == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(w.w, end)
+	return err
+}
+
+type jsonReader struct {
+	dec   *json.Decoder
+	field string
+}
+
+func (r *jsonReader) Read() (record, error) {
+	if !r.dec.More() {
+		return record{}, io.EOF
+	}
+	var elem map[string]json.RawMessage
+	if err := r.dec.Decode(&elem); err != nil {
+		return record{}, err
+	}
+	var rec record
+	if err := json.Unmarshal(elem["index"], &rec.Index); err != nil {
+		return record{}, errors.Wrap(err, "invalid index")
+	}
+	if err := json.Unmarshal(elem[r.field], &rec.Value); err != nil {
+		return record{}, errors.Wrapf(err, "invalid %s", r.field)
+	}
+	return rec, nil
+}
@@ -0,0 +1,269 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+// Package genaddr generates addresses and key pairs offline in batch. The addresses and the private keys, encrypted into
+// keystore JSONs by the password, are written into two separate files, and the raw private keys never show up in the
+// output. Usage:
+//
+//	server tools genaddr --count=100 --out=addrs.csv --password-file=pwd [--key-out=keys.csv] [--format=csv|json]
+//	    [--seed-file=seed] [--light-kdf]
+//	server tools genaddr --verify --out=addrs.csv --password-file=pwd [--key-out=keys.csv] [--format=csv|json]
+package genaddr
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pborman/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/iotexproject/iotex-core/pkg/hdwallet"
+	"github.com/iotexproject/iotex-core/pkg/keypair"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
+)
+
+const (
+	addressField = "address"
+	keyField     = "encryptedKey"
+)
+
+// ErrMismatch indicates the addresses re-derived from the key file don't match the address file
+var ErrMismatch = errors.New("addresses mismatch")
+
+// Config is the config of generating the addresses
+type Config struct {
+	Count    uint32
+	Format   string
+	Password string
+	// Seed enables the HD derivation when set, where the i-th key is derived by the HD wallet along m/44'/304'/0'/0/i,
+	// so that the same batch could be re-generated from the seed. Otherwise the keys are generated randomly.
+	Seed []byte
+	// ScryptN and ScryptP are the scrypt parameters encrypting the keystores, which are the standard ones if 0
+	ScryptN int
+	ScryptP int
+}
+
+// VerifyResult is the result of verifying an address file against a key file
+type VerifyResult struct {
+	Checked    int
+	Mismatches []uint32
+}
+
+// Generate generates the key pairs, and streams the addresses to addrOut and the encrypted private keys to keyOut
+func Generate(cfg Config, addrOut io.Writer, keyOut io.Writer) error {
+	if cfg.ScryptN == 0 {
+		cfg.ScryptN = keystore.StandardScryptN
+	}
+	if cfg.ScryptP == 0 {
+		cfg.ScryptP = keystore.StandardScryptP
+	}
+	addrWriter, err := newRecordWriter(addrOut, cfg.Format, addressField)
+	if err != nil {
+		return err
+	}
+	keyWriter, err := newRecordWriter(keyOut, cfg.Format, keyField)
+	if err != nil {
+		return err
+	}
+	for i := uint32(0); i < cfg.Count; i++ {
+		var sk keypair.PrivateKey
+		if len(cfg.Seed) > 0 {
+			sk, err = hdwallet.DerivePrivateKey(cfg.Seed, i)
+		} else {
+			sk, err = keypair.GenerateKey()
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to generate key %d", i)
+		}
+		addr, err := addrutil.PubKeyToAddress(sk.PublicKey())
+		if err != nil {
+			return err
+		}
+		encrypted, err := encryptKey(sk, cfg.Password, cfg.ScryptN, cfg.ScryptP)
+		sk.Zero()
+		if err != nil {
+			return errors.Wrapf(err, "failed to encrypt key %d", i)
+		}
+		if err := addrWriter.Write(record{Index: i, Value: addr.String()}); err != nil {
+			return err
+		}
+		if err := keyWriter.Write(record{Index: i, Value: encrypted}); err != nil {
+			return err
+		}
+	}
+	if err := addrWriter.Close(); err != nil {
+		return err
+	}
+	return keyWriter.Close()
+}
+
+// Verify re-derives the addresses from the encrypted keys, and compares them with the address file line by line
+func Verify(format, password string, addrIn io.Reader, keyIn io.Reader) (*VerifyResult, error) {
+	addrReader, err := newRecordReader(addrIn, format, addressField)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the address file")
+	}
+	keyReader, err := newRecordReader(keyIn, format, keyField)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the key file")
+	}
+	res := &VerifyResult{}
+	for {
+		addrRec, addrErr := addrReader.Read()
+		keyRec, keyErr := keyReader.Read()
+		if addrErr == io.EOF && keyErr == io.EOF {
+			return res, nil
+		}
+		if addrErr == io.EOF || keyErr == io.EOF {
+			return res, errors.Wrapf(ErrMismatch, "files have different lengths after %d records", res.Checked)
+		}
+		if addrErr != nil {
+			return res, errors.Wrap(addrErr, "failed to read the address file")
+		}
+		if keyErr != nil {
+			return res, errors.Wrap(keyErr, "failed to read the key file")
+		}
+		if addrRec.Index != keyRec.Index {
+			return res, errors.Wrapf(ErrMismatch, "index %d in the address file vs %d in the key file", addrRec.Index, keyRec.Index)
+		}
+		sk, err := decryptKey(keyRec.Value, password)
+		if err != nil {
+			return res, errors.Wrapf(err, "key %d", keyRec.Index)
+		}
+		addr, err := addrutil.PubKeyToAddress(sk.PublicKey())
+		sk.Zero()
+		if err != nil {
+			return res, err
+		}
+		res.Checked++
+		if addr.String() != addrRec.Value {
+			res.Mismatches = append(res.Mismatches, addrRec.Index)
+		}
+	}
+}
+
+// Run runs the genaddr command with the arguments following "genaddr"
+func Run(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("genaddr", flag.ContinueOnError)
+	fs.SetOutput(stdout)
+	count := fs.Uint("count", 1, "number of addresses to generate")
+	out := fs.String("out", "", "path of the address file")
+	keyOut := fs.String("key-out", "", "path of the encrypted key file, <out>.keys by default")
+	format := fs.String("format", FormatCSV, "format of the files, csv or json")
+	passwordFile := fs.String("password-file", "", "path of the file containing the password encrypting the keys")
+	seedFile := fs.String("seed-file", "", "path of the file containing the hex encoded HD seed")
+	lightKDF := fs.Bool("light-kdf", false, "encrypt the keys with the light scrypt parameters, faster but weaker")
+	verify := fs.Bool("verify", false, "verify the address file against the key file instead of generating")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *out == "" {
+		return errors.New("--out is required")
+	}
+	if *keyOut == "" {
+		*keyOut = *out + ".keys"
+	}
+	if *passwordFile == "" {
+		return errors.New("--password-file is required")
+	}
+	password, err := readTrimmed(*passwordFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to read the password file")
+	}
+
+	if *verify {
+		addrIn, err := os.Open(*out)
+		if err != nil {
+			return err
+		}
+		defer addrIn.Close()
+		keyIn, err := os.Open(*keyOut)
+		if err != nil {
+			return err
+		}
+		defer keyIn.Close()
+		res, err := Verify(*format, password, addrIn, keyIn)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(stdout, "checked %d addresses, %d mismatched\n", res.Checked, len(res.Mismatches))
+		for _, index := range res.Mismatches {
+			fmt.Fprintf(stdout, "mismatch at index %d\n", index)
+		}
+		if len(res.Mismatches) > 0 {
+			return ErrMismatch
+		}
+		return nil
+	}
+
+	cfg := Config{Count: uint32(*count), Format: *format, Password: password}
+	if *lightKDF {
+		cfg.ScryptN, cfg.ScryptP = keystore.LightScryptN, keystore.LightScryptP
+	}
+	if *seedFile != "" {
+		seed, err := readTrimmed(*seedFile)
+		if err != nil {
+			return errors.Wrap(err, "failed to read the seed file")
+		}
+		if cfg.Seed, err = hex.DecodeString(seed); err != nil {
+			return errors.Wrap(err, "failed to decode the seed")
+		}
+	}
+	addrOut, err := os.OpenFile(*out, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	defer addrOut.Close()
+	keyFile, err := os.OpenFile(*keyOut, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	defer keyFile.Close()
+	if err := Generate(cfg, addrOut, keyFile); err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "generated %d addresses into %s, encrypted keys into %s\n", cfg.Count, *out, *keyOut)
+	return nil
+}
+
+func readTrimmed(path string) (string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// encryptKey encrypts the private key into a keystore JSON, in the same format as the keystore files of ioctl and the
+// node
+func encryptKey(sk keypair.PrivateKey, password string, scryptN, scryptP int) (string, error) {
+	ecdsaKey := sk.EcdsaPrivateKey()
+	keyJSON, err := keystore.EncryptKey(&keystore.Key{
+		Id:         uuid.NewRandom(),
+		Address:    crypto.PubkeyToAddress(ecdsaKey.PublicKey),
+		PrivateKey: ecdsaKey,
+	}, password, scryptN, scryptP)
+	if err != nil {
+		return "", err
+	}
+	return string(keyJSON), nil
+}
+
+// decryptKey decrypts the private key from a keystore JSON
+func decryptKey(keyJSON, password string) (keypair.PrivateKey, error) {
+	key, err := keystore.DecryptKey([]byte(keyJSON), password)
+	if err != nil {
+		return nil, err
+	}
+	return keypair.BytesToPrivateKey(crypto.FromECDSA(key.PrivateKey))
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2019 IoTeX
+// This is an alpha (internal) release and is not suitable for production. This source code is provided 'as is' and no
+// warranties are given as to title or non-infringement, merchantability or fitness for purpose and, to the extent
+// permitted by law, all liability for your use of the code is disclaimed. This source code is governed by Apache
+// License 2.0 that can be found in the LICENSE file.
+
+package genaddr
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotexproject/iotex-core/pkg/hdwallet"
+	"github.com/iotexproject/iotex-core/pkg/util/addrutil"
+)
+
+func TestGenerateAndVerify(t *testing.T) {
+	for _, format := range []string{FormatCSV, FormatJSON} {
+		t.Run(format, func(t *testing.T) {
+			require := require.New(t)
+
+			cfg := Config{
+				Count:    5,
+				Format:   format,
+				Password: "secret",
+				Seed:     []byte("genaddr test seed"),
+				ScryptN:  keystore.LightScryptN,
+				ScryptP:  keystore.LightScryptP,
+			}
+			var addrs, keys bytes.Buffer
+			require.NoError(Generate(cfg, &addrs, &keys))
+
+			// the keys are derived by the HD wallet
+			sk, err := hdwallet.DerivePrivateKey(cfg.Seed, 4)
+			require.NoError(err)
+			addr, err := addrutil.PubKeyToAddress(sk.PublicKey())
+			require.NoError(err)
+			require.Contains(addrs.String(), addr.String())
+
+			res, err := Verify(format, "secret", bytes.NewReader(addrs.Bytes()), bytes.NewReader(keys.Bytes()))
+			require.NoError(err)
+			require.Equal(5, res.Checked)
+			require.Empty(res.Mismatches)
+
+			// the same seed derives the same addresses, while the encryption is randomized
+			var addrs2, keys2 bytes.Buffer
+			require.NoError(Generate(cfg, &addrs2, &keys2))
+			require.Equal(addrs.String(), addrs2.String())
+			require.NotEqual(keys.String(), keys2.String())
+
+			// a wrong password fails to decrypt
+			_, err = Verify(format, "wrong", bytes.NewReader(addrs.Bytes()), bytes.NewReader(keys.Bytes()))
+			require.Equal(keystore.ErrDecrypt, errors.Cause(err))
+
+			// an address file of another batch mismatches everywhere
+			cfg.Seed = nil
+			var addrs3, keys3 bytes.Buffer
+			require.NoError(Generate(cfg, &addrs3, &keys3))
+			res, err = Verify(format, "secret", bytes.NewReader(addrs3.Bytes()), bytes.NewReader(keys.Bytes()))
+			require.NoError(err)
+			require.Equal([]uint32{0, 1, 2, 3, 4}, res.Mismatches)
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "genaddr")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	pwd := filepath.Join(dir, "pwd")
+	require.NoError(ioutil.WriteFile(pwd, []byte("secret\n"), 0600))
+	seed := filepath.Join(dir, "seed")
+	require.NoError(ioutil.WriteFile(seed, []byte("000102030405060708090a0b0c0d0e0f"), 0600))
+	out := filepath.Join(dir, "addrs.json")
+
+	var stdout bytes.Buffer
+	require.NoError(Run([]string{
+		"--count=3", "--out=" + out, "--format=json", "--password-file=" + pwd, "--seed-file=" + seed, "--light-kdf",
+	}, &stdout))
+	// an existing file is never overwritten
+	require.Error(Run([]string{"--out=" + out, "--format=json", "--password-file=" + pwd}, &stdout))
+
+	stdout.Reset()
+	require.NoError(Run([]string{"--verify", "--out=" + out, "--format=json", "--password-file=" + pwd}, &stdout))
+	require.Equal("checked 3 addresses, 0 mismatched\n", stdout.String())
+
+	// tamper with the address file
+	data, err := ioutil.ReadFile(out)
+	require.NoError(err)
+	lines := strings.Split(string(data), "\n")
+	lines[2], lines[3] = strings.Replace(lines[3], `"index":2`, `"index":1`, 1)+",", strings.TrimSuffix(
+		strings.Replace(lines[2], `"index":1`, `"index":2`, 1), ",")
+	require.NoError(ioutil.WriteFile(out, []byte(strings.Join(lines, "\n")), 0644))
+	stdout.Reset()
+	err = Run([]string{"--verify", "--out=" + out, "--format=json", "--password-file=" + pwd}, &stdout)
+	require.Equal(ErrMismatch, errors.Cause(err))
+	require.Contains(stdout.String(), "mismatch at index 1\n")
+	require.Contains(stdout.String(), "mismatch at index 2\n")
+}
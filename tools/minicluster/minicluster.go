@@ -384,7 +384,7 @@ func newConfig(
 	cfg.Chain.ChainDBPath = chainDBPath
 	cfg.Chain.TrieDBPath = trieDBPath
 	cfg.Chain.CompressBlock = true
-	cfg.Chain.ProducerPrivKey = producerPriKey.HexString()
+	cfg.Chain.ProducerPrivKey = config.Secret(producerPriKey.HexString())
 
 	cfg.ActPool.MinGasPriceStr = big.NewInt(0).String()
 